@@ -1,28 +1,66 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
+	"github.com/trustwallet/blockatlas/api/endpoint"
+	"github.com/trustwallet/blockatlas/api/grpc"
 	_ "github.com/trustwallet/blockatlas/docs"
 	"github.com/trustwallet/blockatlas/platform"
 )
 
-func SetupPlatformAPI(router gin.IRouter) {
+// SetupPlatformAPI serves the endpoints of the platforms, the transactions merge the notes when notes is set
+func SetupPlatformAPI(router gin.IRouter, notes *endpoint.TxNotes) {
 	for _, api := range platform.Platforms {
-		RegisterTransactionsAPI(router, api)
-		RegisterTokensAPI(router, api)
-		RegisterStakeAPI(router, api)
+		platformRouter := availableRouter(router, api.Coin().Handle)
+		RegisterTransactionsAPI(platformRouter, api, notes)
+		RegisterSummaryAPI(platformRouter, api)
+		RegisterTokensAPI(platformRouter, api)
+		RegisterSyncAPI(platformRouter, api)
+		RegisterBlockbookAPI(platformRouter, api)
+		RegisterStakeAPI(platformRouter, api)
+		RegisterFeeAPI(platformRouter, api)
+		RegisterSimulationAPI(platformRouter, api)
 	}
 	for _, api := range platform.CollectionsAPIs {
-		RegisterCollectionsAPI(router, api)
+		RegisterCollectionsAPI(availableRouter(router, api.Coin().Handle), api)
 	}
 
 	RegisterBatchAPI(router)
+	RegisterLendingAPI(router, platform.LendingAPIs)
 	RegisterDomainAPI(router)
+	RegisterCapabilitiesAPI(router)
+	RegisterErrorsAPI(router)
+	RegisterBasicAPI(router)
+}
+
+// availableRouter answers 503 to the routes of the platform while it fails its start-up check
+func availableRouter(router gin.IRouter, handle string) gin.IRouter {
+	return router.Group("", func(c *gin.Context) {
+		if err := platform.Unavailable(handle); err != nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": gin.H{"message": handle + " is unavailable, retry later"}})
+			return
+		}
+		c.Next()
+	})
+}
+
+// SetupMarketAPI serves the lending markets without the coin endpoints
+func SetupMarketAPI(router gin.IRouter) {
+	RegisterLendingAPI(router, platform.LendingAPIs)
+	RegisterErrorsAPI(router)
 	RegisterBasicAPI(router)
 }
 
+// SetupGRPCAPI returns the gRPC service of the platforms and lending providers, answered by the handlers
+// of the REST endpoints
+func SetupGRPCAPI() *grpc.Server {
+	return grpc.NewServer(platform.Platforms, platform.LendingAPIs, providerInfoCache)
+}
+
 func SetupSwaggerAPI(router gin.IRouter) {
 	router.GET("swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
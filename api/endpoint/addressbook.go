@@ -0,0 +1,304 @@
+package endpoint
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const addressBookKey = "address_book_id"
+
+var errInvalidToken = errors.E("Invalid or missing address book token")
+
+type (
+	// AddressBookAuth resolves the address book of a device token hash
+	AddressBookAuth interface {
+		GetAddressBookID(tokenHash string, ctx context.Context) (uint, error)
+	}
+
+	// AddressBookStorage persists the address books, implemented by db.Instance
+	AddressBookStorage interface {
+		AddressBookAuth
+		CreateAddressBook(tokenHash, device string, ctx context.Context) (uint, error)
+		AddAddressBookToken(bookID uint, tokenHash, device string, ctx context.Context) error
+		DeleteAddressBookToken(tokenHash string, ctx context.Context) error
+		GetAddressBookEntries(bookID uint, since time.Time, ctx context.Context) ([]models.AddressBookEntry, error)
+		AddAddressBookEntry(entry *models.AddressBookEntry, ctx context.Context) error
+		UpdateAddressBookEntry(entry *models.AddressBookEntry, ctx context.Context) error
+		DeleteAddressBookEntry(bookID, id uint, ctx context.Context) error
+	}
+
+	AddressBookEntryRequest struct {
+		Coin    uint   `json:"coin"`
+		Address string `json:"address" binding:"required"`
+		Label   string `json:"label" binding:"required"`
+		Memo    string `json:"memo"`
+	}
+
+	AddressBookTokenRequest struct {
+		Device string `json:"device"`
+	}
+)
+
+// RequireAddressBook authenticates the request with the device token of the `Authorization: Bearer` header
+func RequireAddressBook(storage AddressBookAuth) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidToken))
+			return
+		}
+		bookID, err := storage.GetAddressBookID(hashToken(token), c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidToken))
+			return
+		}
+		c.Set(addressBookKey, bookID)
+		c.Next()
+	}
+}
+
+// @Summary Create an address book device token
+// @ID addressbook_token
+// @Description Creates a token for a new address book, or for a new device of the authenticated address book
+// @Accept json
+// @Produce json
+// @Tags Address Book
+// @Param Authorization header string false "Bearer device token"
+// @Param data body endpoint.AddressBookTokenRequest false "Device name"
+// @Success 200 {object} types.AddressBookToken
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/addressbook/tokens [post]
+func CreateAddressBookToken(c *gin.Context, storage AddressBookStorage) {
+	var req AddressBookTokenRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	token, err := newToken()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if existing := bearerToken(c); existing != "" {
+		bookID, err := storage.GetAddressBookID(hashToken(existing), ctx)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidToken))
+			return
+		}
+		err = storage.AddAddressBookToken(bookID, hashToken(token), req.Device, ctx)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	} else if _, err := storage.CreateAddressBook(hashToken(token), req.Device, ctx); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, types.AddressBookToken{Token: token})
+}
+
+// @Summary Revoke the device token
+// @ID addressbook_token_delete
+// @Tags Address Book
+// @Param Authorization header string true "Bearer device token"
+// @Success 204 {string} string "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Router /v1/addressbook/tokens/revoke [post]
+func DeleteAddressBookToken(c *gin.Context, storage AddressBookStorage) {
+	if err := storage.DeleteAddressBookToken(hashToken(bearerToken(c)), c.Request.Context()); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get address book entries
+// @ID addressbook
+// @Description Get the entries changed after `since`, deleted entries are included when `since` is set
+// @Produce json
+// @Tags Address Book
+// @Param Authorization header string true "Bearer device token"
+// @Param since query int false "Unix timestamp of the last sync"
+// @Success 200 {object} types.AddressBookPage
+// @Failure 401 {object} ErrorResponse
+// @Router /v1/addressbook [get]
+func GetAddressBook(c *gin.Context, storage AddressBookStorage) {
+	var since time.Time
+	if s := c.Query("since"); s != "" {
+		unix, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("Invalid since parameter")))
+			return
+		}
+		since = time.Unix(unix, 0)
+	}
+	now := time.Now().Unix()
+	entries, err := storage.GetAddressBookEntries(addressBookID(c), since, c.Request.Context())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	page := types.AddressBookPage{Docs: make([]types.AddressBookEntry, 0, len(entries)), Timestamp: now}
+	for _, e := range entries {
+		page.Docs = append(page.Docs, normalizeAddressBookEntry(e))
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// @Summary Add an address book entry
+// @ID addressbook_add
+// @Accept json
+// @Produce json
+// @Tags Address Book
+// @Param Authorization header string true "Bearer device token"
+// @Param data body endpoint.AddressBookEntryRequest true "Entry"
+// @Success 200 {object} types.AddressBookEntry
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/addressbook [post]
+func AddAddressBookEntry(c *gin.Context, storage AddressBookStorage) {
+	var req AddressBookEntryRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	entry := models.AddressBookEntry{
+		AddressBookID: addressBookID(c),
+		Coin:          req.Coin,
+		Address:       req.Address,
+		Label:         req.Label,
+		Memo:          req.Memo,
+	}
+	if err := storage.AddAddressBookEntry(&entry, c.Request.Context()); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, normalizeAddressBookEntry(entry))
+}
+
+// @Summary Update an address book entry
+// @ID addressbook_update
+// @Accept json
+// @Produce json
+// @Tags Address Book
+// @Param Authorization header string true "Bearer device token"
+// @Param id path int true "Entry ID"
+// @Param data body endpoint.AddressBookEntryRequest true "Entry"
+// @Success 200 {object} types.AddressBookEntry
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/addressbook/{id} [put]
+func UpdateAddressBookEntry(c *gin.Context, storage AddressBookStorage) {
+	id, ok := entryID(c)
+	if !ok {
+		return
+	}
+	var req AddressBookEntryRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	entry := models.AddressBookEntry{
+		ID:            id,
+		AddressBookID: addressBookID(c),
+		Coin:          req.Coin,
+		Address:       req.Address,
+		Label:         req.Label,
+		Memo:          req.Memo,
+	}
+	if err := storage.UpdateAddressBookEntry(&entry, c.Request.Context()); err != nil {
+		abortWithStorageError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, normalizeAddressBookEntry(entry))
+}
+
+// @Summary Delete an address book entry
+// @ID addressbook_delete
+// @Tags Address Book
+// @Param Authorization header string true "Bearer device token"
+// @Param id path int true "Entry ID"
+// @Success 204 {string} string "No Content"
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/addressbook/{id} [delete]
+func DeleteAddressBookEntry(c *gin.Context, storage AddressBookStorage) {
+	id, ok := entryID(c)
+	if !ok {
+		return
+	}
+	if err := storage.DeleteAddressBookEntry(addressBookID(c), id, c.Request.Context()); err != nil {
+		abortWithStorageError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func normalizeAddressBookEntry(e models.AddressBookEntry) types.AddressBookEntry {
+	return types.AddressBookEntry{
+		ID:        e.ID,
+		Coin:      e.Coin,
+		Address:   e.Address,
+		Label:     e.Label,
+		Memo:      e.Memo,
+		UpdatedAt: e.UpdatedAt.Unix(),
+		Deleted:   e.DeletedAt != nil,
+	}
+}
+
+func entryID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("Invalid entry id")))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func abortWithStorageError(c *gin.Context, err error) {
+	if gorm.IsRecordNotFoundError(err) {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(errors.E("Entry not found")))
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+}
+
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.E(err, "Failed to generate token")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func addressBookID(c *gin.Context) uint {
+	id, _ := c.Get(addressBookKey)
+	bookID, _ := id.(uint)
+	return bookID
+}
@@ -0,0 +1,181 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type memoryAddressBook struct {
+	tokens  map[string]uint
+	entries map[uint]*models.AddressBookEntry
+	books   uint
+}
+
+func newMemoryAddressBook() *memoryAddressBook {
+	return &memoryAddressBook{tokens: make(map[string]uint), entries: make(map[uint]*models.AddressBookEntry)}
+}
+
+func (m *memoryAddressBook) CreateAddressBook(tokenHash, device string, ctx context.Context) (uint, error) {
+	m.books++
+	m.tokens[tokenHash] = m.books
+	return m.books, nil
+}
+
+func (m *memoryAddressBook) AddAddressBookToken(bookID uint, tokenHash, device string, ctx context.Context) error {
+	m.tokens[tokenHash] = bookID
+	return nil
+}
+
+func (m *memoryAddressBook) DeleteAddressBookToken(tokenHash string, ctx context.Context) error {
+	delete(m.tokens, tokenHash)
+	return nil
+}
+
+func (m *memoryAddressBook) GetAddressBookID(tokenHash string, ctx context.Context) (uint, error) {
+	id, ok := m.tokens[tokenHash]
+	if !ok {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return id, nil
+}
+
+func (m *memoryAddressBook) GetAddressBookEntries(bookID uint, since time.Time, ctx context.Context) ([]models.AddressBookEntry, error) {
+	var result []models.AddressBookEntry
+	for _, e := range m.entries {
+		if e.AddressBookID != bookID || (since.IsZero() && e.DeletedAt != nil) || e.UpdatedAt.Before(since) {
+			continue
+		}
+		result = append(result, *e)
+	}
+	return result, nil
+}
+
+func (m *memoryAddressBook) AddAddressBookEntry(entry *models.AddressBookEntry, ctx context.Context) error {
+	entry.ID = uint(len(m.entries) + 1)
+	entry.UpdatedAt = time.Now()
+	stored := *entry
+	m.entries[entry.ID] = &stored
+	return nil
+}
+
+func (m *memoryAddressBook) UpdateAddressBookEntry(entry *models.AddressBookEntry, ctx context.Context) error {
+	e, ok := m.entries[entry.ID]
+	if !ok || e.AddressBookID != entry.AddressBookID {
+		return gorm.ErrRecordNotFound
+	}
+	entry.UpdatedAt = time.Now()
+	*e = *entry
+	return nil
+}
+
+func (m *memoryAddressBook) DeleteAddressBookEntry(bookID, id uint, ctx context.Context) error {
+	e, ok := m.entries[id]
+	if !ok || e.AddressBookID != bookID {
+		return gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	e.DeletedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+func addressBookRouter(storage AddressBookStorage) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	auth := RequireAddressBook(storage)
+	router.POST("/v1/addressbook/tokens", func(c *gin.Context) { CreateAddressBookToken(c, storage) })
+	router.GET("/v1/addressbook", auth, func(c *gin.Context) { GetAddressBook(c, storage) })
+	router.POST("/v1/addressbook", auth, func(c *gin.Context) { AddAddressBookEntry(c, storage) })
+	router.PUT("/v1/addressbook/:id", auth, func(c *gin.Context) { UpdateAddressBookEntry(c, storage) })
+	router.DELETE("/v1/addressbook/:id", auth, func(c *gin.Context) { DeleteAddressBookEntry(c, storage) })
+	return router
+}
+
+func serve(router *gin.Engine, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAddressBook(t *testing.T) {
+	storage := newMemoryAddressBook()
+	router := addressBookRouter(storage)
+
+	w := serve(router, http.MethodGet, "/v1/addressbook", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var token types.AddressBookToken
+	w = serve(router, http.MethodPost, "/v1/addressbook/tokens", "", AddressBookTokenRequest{Device: "phone"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &token))
+	assert.Len(t, token.Token, 64)
+	assert.NotContains(t, storage.tokens, token.Token)
+
+	var device types.AddressBookToken
+	w = serve(router, http.MethodPost, "/v1/addressbook/tokens", token.Token, AddressBookTokenRequest{Device: "tablet"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &device))
+
+	w = serve(router, http.MethodPost, "/v1/addressbook", token.Token, AddressBookEntryRequest{Coin: 60, Address: "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1", Label: "Alice"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var entry types.AddressBookEntry
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &entry))
+	assert.Equal(t, "Alice", entry.Label)
+
+	w = serve(router, http.MethodPut, "/v1/addressbook/1", device.Token, AddressBookEntryRequest{Coin: 60, Address: entry.Address, Label: "Alice Work"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var page types.AddressBookPage
+	w = serve(router, http.MethodGet, "/v1/addressbook", device.Token, nil)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 1)
+	assert.Equal(t, "Alice Work", page.Docs[0].Label)
+
+	w = serve(router, http.MethodDelete, "/v1/addressbook/1", token.Token, nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serve(router, http.MethodDelete, "/v1/addressbook/2", token.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(router, http.MethodGet, "/v1/addressbook?since=1", device.Token, nil)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 1)
+	assert.True(t, page.Docs[0].Deleted)
+
+	w = serve(router, http.MethodGet, "/v1/addressbook", device.Token, nil)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Empty(t, page.Docs)
+}
+
+func TestAddressBook_OtherBook(t *testing.T) {
+	storage := newMemoryAddressBook()
+	router := addressBookRouter(storage)
+
+	var owner, other types.AddressBookToken
+	_ = json.Unmarshal(serve(router, http.MethodPost, "/v1/addressbook/tokens", "", nil).Body.Bytes(), &owner)
+	_ = json.Unmarshal(serve(router, http.MethodPost, "/v1/addressbook/tokens", "", nil).Body.Bytes(), &other)
+
+	serve(router, http.MethodPost, "/v1/addressbook", owner.Token, AddressBookEntryRequest{Address: "bnb1", Label: "Bob"})
+	w := serve(router, http.MethodPut, "/v1/addressbook/1", other.Token, AddressBookEntryRequest{Address: "bnb1", Label: "Mallory"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serve(router, http.MethodDelete, "/v1/addressbook/1", other.Token, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
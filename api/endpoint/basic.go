@@ -3,9 +3,13 @@ package endpoint
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/trustwallet/blockatlas/internal"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"net/http"
 )
 
+// DeploymentModeHeader carries the deployment mode of the instance on /v1/capabilities
+const DeploymentModeHeader = "X-Deployment-Mode"
+
 func GetStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, map[string]interface{}{
 		"status": true,
@@ -13,3 +17,16 @@ func GetStatus(c *gin.Context) {
 		"date":   internal.Date,
 	})
 }
+
+// @Summary Get coin capabilities
+// @ID capabilities
+// @Description Get the features served for each configured coin
+// @Produce json
+// @Tags Info
+// @Success 200 {array} blockatlas.CoinCapabilities
+// @Header 200 {string} X-Deployment-Mode "self_hosted when the upstreams are restricted to the hosts of the operator, default otherwise"
+// @Router /v1/capabilities [get]
+func GetCapabilities(c *gin.Context, capabilities []blockatlas.CoinCapabilities, mode string) {
+	c.Header(DeploymentModeHeader, mode)
+	c.JSON(http.StatusOK, capabilities)
+}
@@ -0,0 +1,59 @@
+package endpoint
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// latentLendingAPI answers the provider info after latency, as a remote provider would
+type latentLendingAPI struct {
+	mockLendingAPI
+	latency time.Duration
+}
+
+func (m latentLendingAPI) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	if m.latency > 0 {
+		time.Sleep(m.latency)
+	}
+	return m.provider, nil
+}
+
+// benchmarkProviders returns count providers accepting assets assets each
+func benchmarkProviders(count, assets int, latency time.Duration) map[string]blockatlas.LendingAPI {
+	apis := make(map[string]blockatlas.LendingAPI, count)
+	for i := 0; i < count; i++ {
+		id := "provider" + strconv.Itoa(i)
+		provider := types.LendingProvider{ID: id, Type: types.ProviderTypeLending, Assets: make([]types.AssetInfo, assets)}
+		for j := range provider.Assets {
+			provider.Assets[j] = types.AssetInfo{Symbol: "ASSET" + strconv.Itoa(j), APY: float64(j) / 10}
+		}
+		apis[id] = latentLendingAPI{mockLendingAPI: mockLendingAPI{provider: provider}, latency: latency}
+	}
+	return apis
+}
+
+func BenchmarkGetProviders(b *testing.B) {
+	for _, bench := range []struct {
+		name    string
+		latency time.Duration
+	}{
+		{"Instant", 0},
+		{"Latency1ms", time.Millisecond},
+	} {
+		apis := benchmarkProviders(64, 20, bench.latency)
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				res := getProviders(apis, nil, providersWorkers, providerTimeout, context.Background())
+				if len(res.Providers) != len(apis) {
+					b.Fatalf("got %d providers", len(res.Providers))
+				}
+			}
+		})
+	}
+}
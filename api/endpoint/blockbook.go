@@ -0,0 +1,271 @@
+package endpoint
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	blockbookDetailsBasic = "basic"
+	blockbookDetailsTxids = "txids"
+	blockbookDetailsTxs   = "txs"
+)
+
+type (
+	// BlockbookAPIs are the services of a coin behind its Blockbook-compatible routes, nil when the coin lacks one
+	BlockbookAPIs struct {
+		Txs     blockatlas.TxAPI
+		Lookup  blockatlas.TxLookupAPI
+		Utxos   blockatlas.UtxoAPI
+		Balance blockatlas.BalanceAPI
+		Blocks  blockatlas.BlockAPI
+	}
+
+	// BlockbookError is the error body of Blockbook
+	BlockbookError struct {
+		Error string `json:"error"`
+	}
+
+	// BlockbookAddress is the Blockbook v2 address, with the latest page of transactions only
+	BlockbookAddress struct {
+		Page               int           `json:"page"`
+		TotalPages         int           `json:"totalPages"`
+		ItemsOnPage        int           `json:"itemsOnPage"`
+		Address            string        `json:"address"`
+		Balance            string        `json:"balance"`
+		UnconfirmedBalance string        `json:"unconfirmedBalance"`
+		UnconfirmedTxs     int           `json:"unconfirmedTxs"`
+		Txs                int           `json:"txs"`
+		Txids              []string      `json:"txids,omitempty"`
+		Transactions       []BlockbookTx `json:"transactions,omitempty"`
+	}
+
+	// BlockbookTx is the Blockbook v2 transaction, the block height is -1 while pending
+	BlockbookTx struct {
+		Txid           string                   `json:"txid"`
+		Vin            []BlockbookVout          `json:"vin"`
+		Vout           []BlockbookVout          `json:"vout"`
+		BlockHeight    int64                    `json:"blockHeight"`
+		Confirmations  uint64                   `json:"confirmations"`
+		BlockTime      int64                    `json:"blockTime"`
+		Value          string                   `json:"value"`
+		Fees           string                   `json:"fees"`
+		TokenTransfers []BlockbookTokenTransfer `json:"tokenTransfers,omitempty"`
+	}
+
+	BlockbookVout struct {
+		N         int      `json:"n"`
+		Value     string   `json:"value,omitempty"`
+		Addresses []string `json:"addresses"`
+		IsAddress bool     `json:"isAddress"`
+	}
+
+	BlockbookTokenTransfer struct {
+		Type     string `json:"type"`
+		From     string `json:"from"`
+		To       string `json:"to"`
+		Token    string `json:"token"`
+		Name     string `json:"name"`
+		Symbol   string `json:"symbol"`
+		Decimals uint   `json:"decimals"`
+		Value    string `json:"value"`
+	}
+
+	// BlockbookUtxo is the Blockbook v2 unspent output, without height while unconfirmed
+	BlockbookUtxo struct {
+		Txid          string `json:"txid"`
+		Vout          uint32 `json:"vout"`
+		Value         string `json:"value"`
+		Height        uint64 `json:"height,omitempty"`
+		Confirmations uint64 `json:"confirmations"`
+	}
+)
+
+// @Summary Get address (Blockbook)
+// @ID blockbook_address
+// @Description Get the balance and the latest transactions of the address in the Blockbook v2 format,
+// @Description for the wallet backends written for Blockbook
+// @Produce json
+// @Tags Blockbook
+// @Param coin path string true "the coin name" default(bitcoin)
+// @Param address path string true "the query address"
+// @Param details query string false "basic, txids (default) or txs"
+// @Success 200 {object} endpoint.BlockbookAddress
+// @Failure 400 {object} endpoint.BlockbookError
+// @Router /blockbook/{coin}/api/v2/address/{address} [get]
+func GetBlockbookAddress(c *gin.Context, apis BlockbookAPIs) {
+	address := c.Param("address")
+	details := c.DefaultQuery("details", blockbookDetailsTxids)
+	switch details {
+	case blockbookDetailsBasic, blockbookDetailsTxids, blockbookDetailsTxs:
+	default:
+		c.AbortWithStatusJSON(http.StatusBadRequest, BlockbookError{Error: "Invalid details parameter"})
+		return
+	}
+	txs, err := apis.Txs.GetTxsByAddress(address)
+	if err != nil && !isEmptyResult(err) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, BlockbookError{Error: err.Error()})
+		return
+	}
+	all := types.Txs(txs).FilterUniqueID().SortByDate()
+	balance, err := blockbookBalance(apis, address)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, BlockbookError{Error: err.Error()})
+		return
+	}
+	response := BlockbookAddress{
+		Page:               1,
+		TotalPages:         1,
+		ItemsOnPage:        types.TxPerPage,
+		Address:            address,
+		Balance:            balance,
+		UnconfirmedBalance: "0",
+		Txs:                len(all),
+	}
+	height := currentHeight(apis.Blocks)
+	for _, tx := range all {
+		if tx.Status == types.StatusPending {
+			response.UnconfirmedTxs++
+		}
+		switch details {
+		case blockbookDetailsTxids:
+			response.Txids = append(response.Txids, tx.ID)
+		case blockbookDetailsTxs:
+			response.Transactions = append(response.Transactions, NewBlockbookTx(tx, height))
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Get transaction (Blockbook)
+// @ID blockbook_tx
+// @Description Get the transaction in the Blockbook v2 format
+// @Produce json
+// @Tags Blockbook
+// @Param coin path string true "the coin name" default(bitcoin)
+// @Param txid path string true "the transaction ID"
+// @Success 200 {object} endpoint.BlockbookTx
+// @Failure 400 {object} endpoint.BlockbookError
+// @Router /blockbook/{coin}/api/v2/tx/{txid} [get]
+func GetBlockbookTx(c *gin.Context, apis BlockbookAPIs) {
+	tx, err := apis.Lookup.GetTx(c.Param("txid"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, BlockbookError{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, NewBlockbookTx(*tx, currentHeight(apis.Blocks)))
+}
+
+// @Summary Get unspent outputs (Blockbook)
+// @ID blockbook_utxo
+// @Description Get the unspent outputs of the address in the Blockbook v2 format
+// @Produce json
+// @Tags Blockbook
+// @Param coin path string true "the coin name" default(bitcoin)
+// @Param address path string true "the query address"
+// @Success 200 {array} endpoint.BlockbookUtxo
+// @Failure 400 {object} endpoint.BlockbookError
+// @Router /blockbook/{coin}/api/v2/utxo/{address} [get]
+func GetBlockbookUtxo(c *gin.Context, apis BlockbookAPIs) {
+	utxos, err := apis.Utxos.GetUtxos(c.Param("address"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, BlockbookError{Error: err.Error()})
+		return
+	}
+	result := make([]BlockbookUtxo, 0, len(utxos))
+	for _, u := range utxos {
+		result = append(result, BlockbookUtxo{Txid: u.TxID, Vout: u.Vout, Value: string(u.Value), Height: u.Height, Confirmations: u.Confirmations})
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// NewBlockbookTx maps the transaction to Blockbook, confirmed against the height when it is known
+func NewBlockbookTx(tx types.Tx, height int64) BlockbookTx {
+	result := BlockbookTx{
+		Txid:        tx.ID,
+		Vin:         blockbookVouts(tx.Inputs, tx.From, ""),
+		BlockHeight: -1,
+		BlockTime:   tx.Date,
+		Value:       "0",
+		Fees:        string(tx.Fee),
+	}
+	if tx.Block > 0 {
+		result.BlockHeight = int64(tx.Block)
+		if height >= result.BlockHeight {
+			result.Confirmations = uint64(height-result.BlockHeight) + 1
+		}
+	}
+	switch meta := tx.Meta.(type) {
+	case types.Transfer:
+		result.Value = string(meta.Value)
+	case *types.Transfer:
+		result.Value = string(meta.Value)
+	case types.TokenTransfer:
+		result.TokenTransfers = []BlockbookTokenTransfer{blockbookTokenTransfer(meta)}
+	case *types.TokenTransfer:
+		result.TokenTransfers = []BlockbookTokenTransfer{blockbookTokenTransfer(*meta)}
+	}
+	result.Vout = blockbookVouts(tx.Outputs, tx.To, result.Value)
+	return result
+}
+
+// blockbookVouts maps the outputs, the address with the value for the account based coins
+func blockbookVouts(outputs []types.TxOutput, address, value string) []BlockbookVout {
+	if len(outputs) == 0 {
+		return []BlockbookVout{{Value: value, Addresses: []string{address}, IsAddress: address != ""}}
+	}
+	result := make([]BlockbookVout, 0, len(outputs))
+	for i, o := range outputs {
+		result = append(result, BlockbookVout{N: i, Value: string(o.Value), Addresses: []string{o.Address}, IsAddress: o.Address != ""})
+	}
+	return result
+}
+
+func blockbookTokenTransfer(meta types.TokenTransfer) BlockbookTokenTransfer {
+	return BlockbookTokenTransfer{
+		Type:     "ERC20",
+		From:     meta.From,
+		To:       meta.To,
+		Token:    meta.TokenID,
+		Name:     meta.Name,
+		Symbol:   meta.Symbol,
+		Decimals: meta.Decimals,
+		Value:    string(meta.Value),
+	}
+}
+
+// blockbookBalance returns the balance of the coin, the total of the unspent outputs without one
+func blockbookBalance(apis BlockbookAPIs, address string) (string, error) {
+	if apis.Balance != nil {
+		return apis.Balance.GetBalance(address, "")
+	}
+	if apis.Utxos == nil {
+		return "0", nil
+	}
+	utxos, err := apis.Utxos.GetUtxos(address)
+	if err != nil && !isEmptyResult(err) {
+		return "", err
+	}
+	total := new(big.Int)
+	for _, u := range utxos {
+		if value, ok := new(big.Int).SetString(string(u.Value), 10); ok {
+			total.Add(total, value)
+		}
+	}
+	return total.String(), nil
+}
+
+func currentHeight(blocks blockatlas.BlockAPI) int64 {
+	if blocks == nil {
+		return 0
+	}
+	height, err := blocks.CurrentBlockNumber()
+	if err != nil {
+		return 0
+	}
+	return height
+}
@@ -0,0 +1,107 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type utxoAPIMock struct {
+	utxos []types.Utxo
+}
+
+func (m utxoAPIMock) Coin() coin.Coin { return coin.Bitcoin() }
+
+func (m utxoAPIMock) GetUtxos(address string) ([]types.Utxo, error) {
+	return m.utxos, nil
+}
+
+type txLookupAPIMock struct {
+	txs []types.Tx
+}
+
+func (m txLookupAPIMock) Coin() coin.Coin { return coin.Bitcoin() }
+
+func (m txLookupAPIMock) GetTx(id string) (*types.Tx, error) {
+	for _, tx := range m.txs {
+		if tx.ID == id {
+			return &tx, nil
+		}
+	}
+	return nil, errors.E("transaction not found")
+}
+
+func blockbookRouter(apis BlockbookAPIs) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/blockbook/bitcoin/api/v2/address/:address", func(c *gin.Context) { GetBlockbookAddress(c, apis) })
+	router.GET("/blockbook/bitcoin/api/v2/tx/:txid", func(c *gin.Context) { GetBlockbookTx(c, apis) })
+	router.GET("/blockbook/bitcoin/api/v2/utxo/:address", func(c *gin.Context) { GetBlockbookUtxo(c, apis) })
+	return router
+}
+
+func TestBlockbook(t *testing.T) {
+	txs := []types.Tx{
+		{ID: "pending", Coin: coin.BTC, From: "bc1a", To: "bc1b", Fee: "1", Status: types.StatusPending, Type: types.TxTransfer,
+			Meta: types.Transfer{Value: "5"}, Inputs: []types.TxOutput{{Address: "bc1a", Value: "6"}}, Outputs: []types.TxOutput{{Address: "bc1b", Value: "5"}}},
+		{ID: "mined", Coin: coin.BTC, From: "bc1b", To: "bc1a", Fee: "1", Block: 100, Date: 1600000000, Status: types.StatusCompleted,
+			Type: types.TxTransfer, Meta: types.Transfer{Value: "7"}},
+	}
+	apis := BlockbookAPIs{
+		Txs:    txAPIMock{txs: txs},
+		Lookup: txLookupAPIMock{txs: txs},
+		Utxos:  utxoAPIMock{utxos: []types.Utxo{{TxID: "mined", Vout: 0, Value: "7", Height: 100, Confirmations: 3}, {TxID: "other", Vout: 2, Value: "3"}}},
+	}
+	router := blockbookRouter(apis)
+
+	w := serve(router, http.MethodGet, "/blockbook/bitcoin/api/v2/address/bc1a", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var address BlockbookAddress
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &address))
+	assert.Equal(t, "10", address.Balance)
+	assert.Equal(t, 2, address.Txs)
+	assert.Equal(t, 1, address.UnconfirmedTxs)
+	assert.Len(t, address.Txids, 2)
+	assert.Empty(t, address.Transactions)
+
+	w = serve(router, http.MethodGet, "/blockbook/bitcoin/api/v2/address/bc1a?details=txs", "", nil)
+	address = BlockbookAddress{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &address))
+	assert.Len(t, address.Transactions, 2)
+	assert.Empty(t, address.Txids)
+
+	w = serve(router, http.MethodGet, "/blockbook/bitcoin/api/v2/address/bc1a?details=all", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(router, http.MethodGet, "/blockbook/bitcoin/api/v2/tx/pending", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var tx BlockbookTx
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &tx))
+	assert.Equal(t, int64(-1), tx.BlockHeight)
+	assert.Equal(t, []BlockbookVout{{N: 0, Value: "6", Addresses: []string{"bc1a"}, IsAddress: true}}, tx.Vin)
+	assert.Equal(t, []BlockbookVout{{N: 0, Value: "5", Addresses: []string{"bc1b"}, IsAddress: true}}, tx.Vout)
+
+	w = serve(router, http.MethodGet, "/blockbook/bitcoin/api/v2/tx/unknown", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"transaction not found"}`, w.Body.String())
+
+	w = serve(router, http.MethodGet, "/blockbook/bitcoin/api/v2/utxo/bc1a", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[{"txid":"mined","vout":0,"value":"7","height":100,"confirmations":3},{"txid":"other","vout":2,"value":"3","confirmations":0}]`, w.Body.String())
+}
+
+func TestNewBlockbookTx(t *testing.T) {
+	tx := NewBlockbookTx(types.Tx{ID: "0x1", From: "0xa", To: "0xb", Fee: "21", Block: 10, Type: types.TxTokenTransfer,
+		Meta: types.TokenTransfer{Symbol: "DAI", TokenID: "0xdai", Decimals: 18, Value: "3", From: "0xa", To: "0xc"}}, 12)
+	assert.Equal(t, int64(10), tx.BlockHeight)
+	assert.Equal(t, uint64(3), tx.Confirmations)
+	assert.Equal(t, "0", tx.Value)
+	assert.Equal(t, []BlockbookVout{{Value: "0", Addresses: []string{"0xb"}, IsAddress: true}}, tx.Vout)
+	assert.Equal(t, []BlockbookTokenTransfer{{Type: "ERC20", From: "0xa", To: "0xc", Token: "0xdai", Symbol: "DAI", Decimals: 18, Value: "3"}}, tx.TokenTransfers)
+}
@@ -0,0 +1,49 @@
+package endpoint
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// @Summary Get circuit breakers
+// @ID admin_breakers
+// @Description Get the state, failure counts and last error of the circuit breaker of every upstream host
+// @Produce json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Success 200 {object} types.DocsResponse
+// @Router /admin/breakers [get]
+func GetCircuitBreakers(c *gin.Context) {
+	breakers := blockatlas.CircuitBreakers()
+	c.JSON(http.StatusOK, types.DocsResponse{Docs: &breakers})
+}
+
+// @Summary Reset circuit breaker
+// @ID admin_breaker_reset
+// @Description Close the circuit breaker of the upstream host
+// @Produce json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Param host path string true "Upstream host" default(api.etherscan.io)
+// @Success 200 {object} blockatlas.CircuitBreaker
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/breakers/{host}/reset [post]
+func ResetCircuitBreaker(c *gin.Context) {
+	host := c.Param("host")
+	if !blockatlas.ResetCircuitBreaker(host) {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(errors.E("unknown upstream host", errors.Params{"host": host})))
+		return
+	}
+	for _, b := range blockatlas.CircuitBreakers() {
+		if strings.EqualFold(b.Host, host) {
+			c.JSON(http.StatusOK, &b)
+			return
+		}
+	}
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,54 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer upstream.Close()
+	blockatlas.SetCircuitBreakers(1, time.Hour)
+	defer blockatlas.SetCircuitBreakers(0, 0)
+
+	client := blockatlas.InitClient(upstream.URL)
+	var result interface{}
+	assert.NotNil(t, client.Get(&result, "txs", nil))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/breakers", GetCircuitBreakers)
+	router.POST("/admin/breakers/:host/reset", ResetCircuitBreaker)
+
+	w := serve(router, http.MethodGet, "/admin/breakers", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var page struct {
+		Docs []blockatlas.CircuitBreaker `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 1)
+	assert.Equal(t, blockatlas.BreakerOpen, page.Docs[0].State)
+	assert.Equal(t, 1, page.Docs[0].Failures)
+	assert.Equal(t, "upstream status 502", page.Docs[0].LastError)
+
+	host, _ := url.Parse(upstream.URL)
+	w = serve(router, http.MethodPost, "/admin/breakers/"+host.Hostname()+"/reset", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var breaker blockatlas.CircuitBreaker
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &breaker))
+	assert.Equal(t, blockatlas.BreakerClosed, breaker.State)
+	assert.Equal(t, int64(1), breaker.TotalFailures)
+
+	w = serve(router, http.MethodPost, "/admin/breakers/unknown.example.com/reset", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,24 @@
+package endpoint
+
+import (
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// Suggested client TTLs of the data types when the provider data isn't cached by the API
+var defaultCacheTTLs = map[types.CacheDataType]time.Duration{
+	types.CacheCollections:  time.Minute * 10,
+	types.CacheCollectibles: time.Minute * 10,
+	types.CacheStaking:      time.Hour,
+}
+
+// cacheControl returns the caching hints of the data type, the provider data served
+// from the API cache (freshness) never gets renewed sooner than the cache expires
+func cacheControl(dataType types.CacheDataType, freshness time.Duration) types.CacheControl {
+	ttl := defaultCacheTTLs[dataType]
+	if freshness > ttl {
+		ttl = freshness
+	}
+	return types.CacheControl{dataType: int64(ttl / time.Second)}
+}
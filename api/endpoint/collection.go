@@ -3,6 +3,7 @@ package endpoint
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
 	"net/http"
 	"strconv"
 )
@@ -16,16 +17,60 @@ import (
 // @Param coin path string true "the coin name" default(ethereum)
 // @Param owner path string true "the query address" default(0x0875BCab22dE3d02402bc38aEe4104e1239374a7)
 // @Param collection_id path string true "the query collection" default(0x06012c8cf97bead5deae237070f9587f8e7a266d)
-// @Success 200 {object} blockatlas.CollectionPage
+// @Param cursor query string false "The next_page of the previous page"
+// @Param limit query int false "Collectibles per page, 100 by default"
+// @Success 200 {object} blockatlas.PageResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /v4/{coin}/collections/{owner}/collection/{collection_id} [get]
 func GetCollectiblesForSpecificCollectionAndOwner(c *gin.Context, api blockatlas.CollectionsAPI) {
+	cursor, ok := bindPage(c, blockatlas.DefaultPageLimit)
+	if !ok {
+		return
+	}
 	collectibles, err := api.GetCollectibles(c.Param("owner"), c.Param("collection_id"))
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
+		return
+	}
+	if collectibles == nil {
+		collectibles = make(types.CollectiblePage, 0)
+	}
+	start, end, next := cursor.Bounds(len(collectibles))
+	c.JSON(http.StatusOK, pageResponse([]types.Collectible(collectibles[start:end]), end-start, next))
+}
+
+// @Summary Get Collections
+// @ID collections_v4
+// @Description Get the NFT collections of the address, with the number of collectibles held in each
+// @Accept json
+// @Produce json
+// @Tags Collections
+// @Param coin path string true "the coin name" default(ethereum)
+// @Param owner path string true "the query address" default(0x0875BCab22dE3d02402bc38aEe4104e1239374a7)
+// @Param cursor query string false "The next_page of the previous page"
+// @Param limit query int false "Collections per page, 100 by default"
+// @Success 200 {object} blockatlas.PageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v4/{coin}/collections/{owner} [get]
+func GetCollectionsForOwner(c *gin.Context, api blockatlas.CollectionsAPI) {
+	cursor, ok := bindPage(c, blockatlas.DefaultPageLimit)
+	if !ok {
+		return
+	}
+	collections, err := api.GetCollections(c.Param("owner"))
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, &collectibles)
+	if collections == nil {
+		collections = make(types.CollectionPage, 0)
+	}
+	start, end, next := cursor.Bounds(len(collections))
+	c.JSON(http.StatusOK, pageResponse([]types.Collection(collections[start:end]), end-start, next))
 }
 
 // @Description Get collection categories
@@ -35,6 +80,7 @@ func GetCollectiblesForSpecificCollectionAndOwner(c *gin.Context, api blockatlas
 // @Produce json
 // @Tags Collections
 // @Param data body string true "Payload" default({"60": ["0xb3624367b1ab37daef42e1a3a2ced012359659b0"]})
+// @Param stream query bool false "Write the collections as newline-delimited JSON"
 // @Success 200 {object} blockatlas.DocsResponse
 // @Router /v4/collectibles/categories [post]
 func GetCollectionCategoriesFromList(c *gin.Context, apis blockatlas.CollectionsAPIs) {
@@ -44,8 +90,10 @@ func GetCollectionCategoriesFromList(c *gin.Context, apis blockatlas.Collections
 		return
 	}
 
+	stream := newStreamWriter(c)
 	batch := make(blockatlas.CollectionPage, 0)
-	for key, addresses := range reqs {
+	for _, key := range types.SortedKeys(reqs) {
+		addresses := reqs[key]
 		coinId, err := strconv.Atoi(key)
 		if err != nil {
 			continue
@@ -59,29 +107,57 @@ func GetCollectionCategoriesFromList(c *gin.Context, apis blockatlas.Collections
 			if err != nil {
 				continue
 			}
+			if stream != nil {
+				for _, collection := range collections {
+					if !stream.Write(collection) {
+						return
+					}
+				}
+				continue
+			}
 			batch = append(batch, collections...)
 		}
 	}
+	if stream != nil {
+		return
+	}
 	c.JSON(http.StatusOK, &batch)
 }
 
 func GetCollectiblesForOwnerV3(c *gin.Context, api blockatlas.CollectionsAPI) {
+	cursor, ok := bindPage(c, blockatlas.DefaultPageLimit)
+	if !ok {
+		return
+	}
 	collections, err := api.GetCollectionsV3(c.Param("owner"))
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
 		return
 	}
-
-	c.JSON(http.StatusOK, &collections)
+	if collections == nil {
+		collections = make(types.CollectionPageV3, 0)
+	}
+	start, end, next := cursor.Bounds(len(collections))
+	page := pageResponse([]types.CollectionV3(collections[start:end]), end-start, next)
+	renderNegotiated(c, http.StatusOK, types.CachedResponse{Response: page, CacheControl: cacheControl(types.CacheCollections, 0)})
 }
 
 func GetCollectiblesForSpecificCollectionAndOwnerV3(c *gin.Context, api blockatlas.CollectionsAPI) {
+	cursor, ok := bindPage(c, blockatlas.DefaultPageLimit)
+	if !ok {
+		return
+	}
 	collectibles, err := api.GetCollectiblesV3(c.Param("owner"), c.Param("collection_id"))
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, &collectibles)
+	if collectibles == nil {
+		collectibles = make(types.CollectiblePageV3, 0)
+	}
+	start, end, next := cursor.Bounds(len(collectibles))
+	page := pageResponse([]types.CollectibleV3(collectibles[start:end]), end-start, next)
+	renderNegotiated(c, http.StatusOK, types.CachedResponse{Response: page, CacheControl: cacheControl(types.CacheCollectibles, 0)})
 }
 
 func GetCollectionCategoriesFromListV3(c *gin.Context, apis blockatlas.CollectionsAPIs) {
@@ -91,8 +167,10 @@ func GetCollectionCategoriesFromListV3(c *gin.Context, apis blockatlas.Collectio
 		return
 	}
 
+	stream := newStreamWriter(c)
 	batch := make(blockatlas.CollectionPageV3, 0)
-	for key, addresses := range reqs {
+	for _, key := range types.SortedKeys(reqs) {
+		addresses := reqs[key]
 		coinId, err := strconv.Atoi(key)
 		if err != nil {
 			continue
@@ -106,8 +184,19 @@ func GetCollectionCategoriesFromListV3(c *gin.Context, apis blockatlas.Collectio
 			if err != nil {
 				continue
 			}
+			if stream != nil {
+				for _, collection := range collections {
+					if !stream.Write(collection) {
+						return
+					}
+				}
+				continue
+			}
 			batch = append(batch, collections...)
 		}
 	}
-	c.JSON(http.StatusOK, &batch)
+	if stream != nil {
+		return
+	}
+	renderNegotiated(c, http.StatusOK, types.CachedResponse{Response: &batch, CacheControl: cacheControl(types.CacheCollections, 0)})
 }
@@ -0,0 +1,81 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type collectionsAPIMock struct {
+	collections  types.CollectionPage
+	collectibles types.CollectiblePage
+}
+
+func (m collectionsAPIMock) Coin() coin.Coin { return coin.Ethereum() }
+
+func (m collectionsAPIMock) GetCollections(owner string) (types.CollectionPage, error) {
+	return m.collections, nil
+}
+
+func (m collectionsAPIMock) GetCollectibles(owner, collectibleID string) (types.CollectiblePage, error) {
+	return m.collectibles, nil
+}
+
+func (m collectionsAPIMock) GetCollectionsV3(owner string) (types.CollectionPageV3, error) {
+	return nil, nil
+}
+
+func (m collectionsAPIMock) GetCollectiblesV3(owner, collectibleID string) (types.CollectiblePageV3, error) {
+	return nil, nil
+}
+
+func TestGetCollectionsForOwner(t *testing.T) {
+	api := collectionsAPIMock{
+		collections: types.CollectionPage{
+			{Id: "cryptokitties", Name: "CryptoKitties", Total: 2, Coin: coin.ETH},
+			{Id: "ens", Name: "ENS", Total: 1, Coin: coin.ETH},
+		},
+		collectibles: types.CollectiblePage{
+			{ID: "0x06012c-1", CollectionID: "cryptokitties", TokenID: "1", Type: "ERC721", Coin: coin.ETH},
+		},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v4/ethereum/collections/:owner", func(c *gin.Context) {
+		GetCollectionsForOwner(c, api)
+	})
+	router.GET("/v4/ethereum/collections/:owner/collection/:collection_id", func(c *gin.Context) {
+		GetCollectiblesForSpecificCollectionAndOwner(c, api)
+	})
+
+	w := serve(router, http.MethodGet, "/v4/ethereum/collections/0xa?limit=1", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var collections struct {
+		Docs     []types.Collection `json:"docs"`
+		NextPage string             `json:"next_page"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &collections))
+	assert.Len(t, collections.Docs, 1)
+	assert.Equal(t, "cryptokitties", collections.Docs[0].Id)
+	assert.NotEmpty(t, collections.NextPage)
+
+	w = serve(router, http.MethodGet, "/v4/ethereum/collections/0xa?cursor="+collections.NextPage, "", nil)
+	collections.NextPage = ""
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &collections))
+	assert.Len(t, collections.Docs, 1)
+	assert.Equal(t, "ens", collections.Docs[0].Id)
+	assert.Empty(t, collections.NextPage)
+
+	w = serve(router, http.MethodGet, "/v4/ethereum/collections/0xa/collection/cryptokitties", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var collectibles struct {
+		Docs []types.Collectible `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &collectibles))
+	assert.Equal(t, "ERC721", collectibles.Docs[0].Type)
+}
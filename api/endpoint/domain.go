@@ -39,8 +39,8 @@ func GetAddressByCoinAndDomain(c *gin.Context) {
 	c.JSON(http.StatusOK, result[0])
 }
 
-// @Summary Lookup .eth / .zil addresses
-// @ID lookup
+// @Summary Lookup .eth / .zil addresses for multiple coins
+// @ID lookup_batch
 // @Description Lookup ENS/ZNS to find registered addresses for multiple coins
 // @Produce json
 // @Tags Naming
@@ -0,0 +1,172 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	// ContentTypeMsgPack is negotiated by the v3 endpoints with the Accept header
+	ContentTypeMsgPack = "application/msgpack"
+	// contentTypeXMsgPack is the legacy name of MessagePack some clients still send
+	contentTypeXMsgPack = "application/x-msgpack"
+)
+
+type (
+	// ResponseEncoder writes the items of a response in one content type,
+	// shared by the export and the MessagePack responses of the v3 endpoints
+	ResponseEncoder interface {
+		ContentType() string
+		Encode(item interface{}) error
+		Flush() error
+	}
+
+	// csvRecord is implemented by the items written as CSV rows
+	csvRecord interface {
+		CSVRecord() []string
+	}
+
+	ndjsonEncoder struct {
+		encoder *json.Encoder
+	}
+
+	csvEncoder struct {
+		writer      *csv.Writer
+		header      []string
+		wroteHeader bool
+	}
+
+	msgpackEncoder struct {
+		encoder *codec.Encoder
+	}
+)
+
+func newNDJSONEncoder(w io.Writer) ResponseEncoder {
+	return &ndjsonEncoder{encoder: json.NewEncoder(w)}
+}
+
+func (e *ndjsonEncoder) ContentType() string { return contentTypeNDJSON }
+
+func (e *ndjsonEncoder) Encode(item interface{}) error { return e.encoder.Encode(item) }
+
+func (e *ndjsonEncoder) Flush() error { return nil }
+
+// newCSVEncoder writes the header row before the first item, the items must implement csvRecord
+func newCSVEncoder(w io.Writer, header []string) ResponseEncoder {
+	return &csvEncoder{writer: csv.NewWriter(w), header: header}
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.wroteHeader {
+		return nil
+	}
+	e.wroteHeader = true
+	return e.writer.Write(e.header)
+}
+
+func (e *csvEncoder) ContentType() string { return contentTypeCSV }
+
+func (e *csvEncoder) Encode(item interface{}) error {
+	record, ok := item.(csvRecord)
+	if !ok {
+		return errors.E("item has no CSV record")
+	}
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.writer.Write(record.CSVRecord())
+}
+
+func (e *csvEncoder) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// newMsgPackEncoder writes every item as a MessagePack value
+func newMsgPackEncoder(w io.Writer) ResponseEncoder {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.Canonical = true
+	return &msgpackEncoder{encoder: codec.NewEncoder(w, handle)}
+}
+
+func (e *msgpackEncoder) ContentType() string { return ContentTypeMsgPack }
+
+// Encode transcodes the JSON document of the item, the custom JSON marshalers of the
+// models shape the MessagePack response the same as the JSON one
+func (e *msgpackEncoder) Encode(item interface{}) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return err
+	}
+	return e.encoder.Encode(msgpackValue(document))
+}
+
+func (e *msgpackEncoder) Flush() error { return nil }
+
+// msgpackValue keeps the integers of the JSON document as MessagePack integers
+func msgpackValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, value := range v {
+			v[k] = msgpackValue(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = msgpackValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// acceptsMsgPack reports whether the client prefers MessagePack to JSON
+func acceptsMsgPack(c *gin.Context) bool {
+	switch c.NegotiateFormat(binding.MIMEJSON, ContentTypeMsgPack, contentTypeXMsgPack) {
+	case ContentTypeMsgPack, contentTypeXMsgPack:
+		return true
+	default:
+		return false
+	}
+}
+
+// renderNegotiated writes the response as MessagePack to the clients sending
+// Accept: application/msgpack, as JSON otherwise, both with Vary: Accept for the shared caches
+func renderNegotiated(c *gin.Context, status int, response interface{}) {
+	c.Header("Vary", "Accept")
+	if !acceptsMsgPack(c) {
+		c.JSON(status, response)
+		return
+	}
+	var buf bytes.Buffer
+	encoder := newMsgPackEncoder(&buf)
+	if err := encoder.Encode(response); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.Data(status, encoder.ContentType(), buf.Bytes())
+}
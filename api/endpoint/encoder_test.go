@@ -0,0 +1,74 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/ugorji/go/codec"
+)
+
+func negotiatedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v3/ethereum/collections/:owner", func(c *gin.Context) {
+		collections := types.CollectionPageV3{{Id: "punks", Name: "CryptoPunks", Total: 2}}
+		renderNegotiated(c, http.StatusOK, types.CachedResponse{Response: &collections, CacheControl: cacheControl(types.CacheCollections, 0)})
+	})
+	return router
+}
+
+func TestRenderNegotiated(t *testing.T) {
+	router := negotiatedRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/v3/ethereum/collections/0x0", nil)
+	req.Header.Set("Accept", ContentTypeMsgPack)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ContentTypeMsgPack, w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	var (
+		decoded map[string]interface{}
+		handle  codec.MsgpackHandle
+	)
+	handle.RawToString = true
+	assert.Nil(t, codec.NewDecoderBytes(w.Body.Bytes(), &handle).Decode(&decoded))
+	assert.Equal(t, int64(1), decoded["total"])
+	docs := decoded["docs"].([]interface{})
+	assert.Equal(t, "punks", docs[0].(map[interface{}]interface{})["id"])
+	assert.Equal(t, int64(2), docs[0].(map[interface{}]interface{})["total"])
+	assert.NotNil(t, decoded["cache_control"])
+
+	// JSON stays the default
+	w = serve(router, http.MethodGet, "/v3/ethereum/collections/0x0", "", nil)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	var page map[string]interface{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Equal(t, float64(1), page["total"])
+}
+
+type recordMock []string
+
+func (r recordMock) CSVRecord() []string { return r }
+
+func TestCSVEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := newCSVEncoder(&buf, []string{"a", "b"})
+	assert.Nil(t, encoder.Encode(recordMock{"1", "2"}))
+	assert.NotNil(t, encoder.Encode("no record"))
+	assert.Nil(t, encoder.Flush())
+	assert.Equal(t, "a,b\n1,2\n", buf.String())
+
+	buf.Reset()
+	empty := newCSVEncoder(&buf, []string{"a", "b"})
+	assert.Nil(t, empty.Flush())
+	assert.Equal(t, "a,b\n", buf.String())
+}
@@ -1,16 +1,79 @@
 package endpoint
 
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
 type (
 	ErrorResponse struct {
 		Error ErrorDetails `json:"error"`
 	}
 	ErrorDetails struct {
 		Message string `json:"message"`
+		// Code is the machine-readable reason of the rejected requests, see middleware.RenderErrors
+		Code string `json:"code,omitempty"`
 	}
 
 	ErrorCode int
+
+	// ErrorCatalogEntry describes a machine-readable error code for the API clients
+	ErrorCatalogEntry struct {
+		Code        string `json:"code"`
+		Status      int    `json:"status"`
+		Description string `json:"description"`
+		// Retryable tells whether the same request may succeed later
+		Retryable bool `json:"retryable"`
+	}
 )
 
+// Codes of the platform errors, see errorStatus
+const (
+	CodeInvalidAddress      = "invalid_address"
+	CodeInvalidKey          = "invalid_key"
+	CodeNotSupported        = "not_supported"
+	CodeUpstreamUnavailable = "upstream_unavailable"
+	CodeUpstreamTimeout     = "upstream_timeout"
+	CodeInvalidCursor       = "invalid_cursor"
+	// CodeSubscriptionRejected is the refusal of the subscription screening of the operator
+	CodeSubscriptionRejected = "subscription_rejected"
+)
+
+// ErrorCatalog lists the codes the API answers, <field> stands for the name of a request field
+var ErrorCatalog = []ErrorCatalogEntry{
+	{CodeInvalidAddress, http.StatusBadRequest, "The address is invalid for the coin", false},
+	{CodeInvalidKey, http.StatusBadRequest, "The extended public key is invalid for the coin", false},
+	{CodeInvalidCursor, http.StatusBadRequest, "The cursor is not a next_page or the limit is not between 1 and 500", false},
+	{CodeInvalidJSON, http.StatusBadRequest, "The request body is not valid JSON", false},
+	{CodeUnknownProvider, http.StatusNotFound, "The lending provider is not served", false},
+	{CodeSubscriptionRejected, http.StatusForbidden, "The screening policy of the operator rejects an address or the webhook host", false},
+	{CodeRequestTooLarge, http.StatusRequestEntityTooLarge, "The request body is larger than 64KB", false},
+	{"empty_<field>", http.StatusUnprocessableEntity, "The field is required", false},
+	{"too_many_<field>", http.StatusUnprocessableEntity, "The list of the field has too many elements", false},
+	{"invalid_<field>", http.StatusUnprocessableEntity, "The field or one of its elements is invalid", false},
+	{"invalid_request", http.StatusUnprocessableEntity, "The request is invalid", false},
+	{CodeInvalidXpub, http.StatusUnprocessableEntity, "The xpub of a sub-wallet is invalid", false},
+	{CodeInvalidCurrency, http.StatusUnprocessableEntity, "The currency is not a 3 to 5 letters code", false},
+	{CodeNotSupported, http.StatusNotImplemented, "The coin does not support the request", false},
+	{CodePricesDisabled, http.StatusNotImplemented, "The instance has no price source for ?currency=", false},
+	{CodeUpstreamUnavailable, http.StatusServiceUnavailable, "The node or API of the coin is unreachable", true},
+	{CodeUpstreamTimeout, http.StatusGatewayTimeout, "The lending provider did not answer in time", true},
+}
+
+// @Summary Get error codes
+// @ID error_codes
+// @Description Get the machine-readable codes of the error responses, with their status and retryability
+// @Produce json
+// @Tags Info
+// @Success 200 {array} endpoint.ErrorCatalogEntry
+// @Router /v1/errors [get]
+func GetErrorCatalog(c *gin.Context) {
+	c.JSON(http.StatusOK, ErrorCatalog)
+}
+
 func errorResponse(err error) ErrorResponse {
 	var message string
 	if err != nil {
@@ -18,5 +81,55 @@ func errorResponse(err error) ErrorResponse {
 	}
 	return ErrorResponse{Error: ErrorDetails{
 		Message: message,
+		Code:    errorCode(err),
 	}}
 }
+
+// isEmptyResult reports whether the platform has no data for the address,
+// served as an empty result with 200 rather than an error
+func isEmptyResult(err error) bool {
+	return err == blockatlas.ErrNotFound
+}
+
+// ErrorStatus maps a platform error to the status of the response:
+// 400 for invalid input, 501 when the coin doesn't support the call,
+// 503 when the upstream is unreachable, 504 when a lending provider timed out and 500 otherwise
+func ErrorStatus(err error) int {
+	switch {
+	case err == blockatlas.ErrInvalidAddr, err == blockatlas.ErrInvalidKey:
+		return http.StatusBadRequest
+	case err == blockatlas.ErrNotSupported:
+		return http.StatusNotImplemented
+	case err == blockatlas.ErrSourceConn, errors.Is(err, errors.TypePlatformRequest):
+		return http.StatusServiceUnavailable
+	case blockatlas.IsLendingTimeout(err):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorCode is the code of the platform errors of ErrorStatus, empty for the others
+func errorCode(err error) string {
+	switch {
+	case err == blockatlas.ErrInvalidAddr:
+		return CodeInvalidAddress
+	case err == blockatlas.ErrInvalidKey:
+		return CodeInvalidKey
+	case err == blockatlas.ErrNotSupported:
+		return CodeNotSupported
+	case err == blockatlas.ErrInvalidPage:
+		return CodeInvalidCursor
+	case err == blockatlas.ErrSourceConn, errors.Is(err, errors.TypePlatformRequest):
+		return CodeUpstreamUnavailable
+	case blockatlas.IsLendingTimeout(err):
+		return CodeUpstreamTimeout
+	default:
+		return ""
+	}
+}
+
+// renderError aborts the request with the status of the platform error
+func renderError(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(ErrorStatus(err), errorResponse(err))
+}
@@ -0,0 +1,123 @@
+package endpoint
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"invalid address", blockatlas.ErrInvalidAddr, http.StatusBadRequest},
+		{"invalid key", blockatlas.ErrInvalidKey, http.StatusBadRequest},
+		{"not supported", blockatlas.ErrNotSupported, http.StatusNotImplemented},
+		{"source connection", blockatlas.ErrSourceConn, http.StatusServiceUnavailable},
+		{"platform request", errors.E("timeout", errors.TypePlatformRequest), http.StatusServiceUnavailable},
+		{"platform unmarshal", errors.E("bad json", errors.TypePlatformUnmarshal), http.StatusInternalServerError},
+		{"lending timeout", &blockatlas.LendingError{Err: errors.E("deadline"), Timeout: true}, http.StatusGatewayTimeout},
+		{"lending failure", &blockatlas.LendingError{Err: errors.E("boom")}, http.StatusInternalServerError},
+		{"unknown", errors.E("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorStatus(tt.err))
+		})
+	}
+}
+
+func Test_errorCode(t *testing.T) {
+	assert.Equal(t, CodeInvalidAddress, errorCode(blockatlas.ErrInvalidAddr))
+	assert.Equal(t, CodeNotSupported, errorCode(blockatlas.ErrNotSupported))
+	assert.Equal(t, CodeUpstreamUnavailable, errorCode(errors.E("timeout", errors.TypePlatformRequest)))
+	assert.Equal(t, CodeUpstreamTimeout, errorCode(&blockatlas.LendingError{Err: errors.E("deadline"), Timeout: true}))
+	assert.Equal(t, "", errorCode(errors.E("boom")))
+	assert.Equal(t, ErrorDetails{Message: blockatlas.ErrInvalidAddr.Error(), Code: CodeInvalidAddress}, errorResponse(blockatlas.ErrInvalidAddr).Error)
+}
+
+// TestErrorCatalog_Complete checks the catalog has every code constant, and the status of the codes
+// passed to AbortWithRequestError
+func TestErrorCatalog_Complete(t *testing.T) {
+	catalog := make(map[string]int)
+	for _, e := range ErrorCatalog {
+		catalog[e.Code] = e.Status
+	}
+	for _, code := range []string{CodeInvalidAddress, CodeInvalidKey, CodeNotSupported, CodeUpstreamUnavailable, CodeUpstreamTimeout} {
+		assert.Contains(t, catalog, code)
+	}
+
+	var (
+		constant = regexp.MustCompile(`(Code\w+)\s+= "(\w+)"`)
+		abort    = regexp.MustCompile(`AbortWithRequestError\(c, http\.(\w+), (Code\w+)`)
+		statuses = map[string]int{
+			"StatusBadRequest":            http.StatusBadRequest,
+			"StatusNotFound":              http.StatusNotFound,
+			"StatusRequestEntityTooLarge": http.StatusRequestEntityTooLarge,
+			"StatusUnprocessableEntity":   http.StatusUnprocessableEntity,
+			"StatusNotImplemented":        http.StatusNotImplemented,
+		}
+		codes = make(map[string]string)
+		calls [][]string
+	)
+	files, err := filepath.Glob("*.go")
+	assert.Nil(t, err)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		raw, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		for _, m := range constant.FindAllStringSubmatch(string(raw), -1) {
+			codes[m[1]] = m[2]
+		}
+		calls = append(calls, abort.FindAllStringSubmatch(string(raw), -1)...)
+	}
+	for name, code := range codes {
+		assert.Contains(t, catalog, code, name)
+	}
+	assert.NotEmpty(t, calls)
+	for _, m := range calls {
+		status, ok := statuses[m[1]]
+		assert.True(t, ok, m[1])
+		assert.Equal(t, status, catalog[codes[m[2]]], m[2])
+	}
+}
+
+func TestGetTransactionsHistory_EmptyResults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		api      blockatlas.TxAPI
+		wantCode int
+		wantBody string
+	}{
+		{"no history", txAPIMock{err: blockatlas.ErrNotFound}, http.StatusOK, `{"docs":[],"status":true,"total":0}`},
+		{"empty history", txAPIMock{}, http.StatusOK, `{"docs":[],"status":true,"total":0}`},
+		{"upstream down", txAPIMock{err: errors.E("connection refused", errors.TypePlatformRequest)}, http.StatusServiceUnavailable, ""},
+		{"not supported", nil, http.StatusNotImplemented, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/v2/ethereum/transactions/:address", func(c *gin.Context) {
+				GetTransactionsHistory(c, tt.api, nil, nil)
+			})
+			w := serve(router, http.MethodGet, "/v2/ethereum/transactions/0x0", "", nil)
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/parser"
+)
+
+const (
+	// MaxExportBlocks bounds the block range of an export
+	MaxExportBlocks = 10000
+
+	// ExportLastBlockTrailer is the last block written by an export, the export is
+	// incomplete when it is not the requested one
+	ExportLastBlockTrailer = "X-Export-Last-Block"
+
+	contentTypeCSV = "text/csv"
+)
+
+var exportColumns = []string{"block", "date", "id", "from", "to", "type", "status", "error", "direction", "fee", "sequence", "memo", "event", "metadata"}
+
+// @Summary Export transactions
+// @ID export_blocks
+// @Description Stream the normalized transactions of a block range, with the events known to the observer, for audits
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Tags Transactions
+// @Param coin path string true "Coin handle, e.g. ethereum"
+// @Param from query int true "First block"
+// @Param to query int true "Last block"
+// @Param format query string false "csv (default) or ndjson"
+// @Param X-API-Key header string true "Export API key"
+// @Success 200
+// @Router /v1/export/{coin} [get]
+func ExportBlocks(c *gin.Context, apis map[string]blockatlas.BlockAPI) {
+	api, ok := apis[c.Param("coin")]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(errors.E("unknown coin")))
+		return
+	}
+	from, errFrom := strconv.ParseInt(c.Query("from"), 10, 64)
+	to, errTo := strconv.ParseInt(c.Query("to"), 10, 64)
+	if errFrom != nil || errTo != nil || from < 0 || to < from {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid block range")))
+		return
+	}
+	if to-from >= MaxExportBlocks {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("block range too large", errors.Params{"max": MaxExportBlocks})))
+		return
+	}
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "ndjson" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid format", errors.Params{"format": format})))
+		return
+	}
+
+	encoder := newCSVEncoder(c.Writer, exportColumns)
+	if format == "ndjson" {
+		encoder = newNDJSONEncoder(c.Writer)
+	}
+	c.Header("Content-Type", encoder.ContentType())
+	c.Header("Trailer", ExportLastBlockTrailer)
+	c.Status(http.StatusOK)
+	flush := func() {
+		_ = encoder.Flush()
+		c.Writer.Flush()
+	}
+
+	last := from - 1
+	defer func() {
+		flush()
+		c.Writer.Header().Set(ExportLastBlockTrailer, strconv.FormatInt(last, 10))
+	}()
+	for num := from; num <= to; num++ {
+		if c.Request.Context().Err() != nil {
+			return
+		}
+		block, err := api.GetBlockByNumber(num)
+		if err != nil {
+			logger.Error(err, "Export stopped", logger.Params{"coin": api.Coin().Handle, "block": num})
+			return
+		}
+		parser.DetectEvents(block.Txs)
+		for i := range block.Txs {
+			if err := encoder.Encode(exportTx{&block.Txs[i]}); err != nil {
+				return
+			}
+		}
+		last = num
+		flush()
+	}
+}
+
+// exportTx is written as a CSV row or as the JSON of the transaction
+type exportTx struct {
+	*types.Tx
+}
+
+func (tx exportTx) CSVRecord() []string {
+	var event string
+	if tx.Event != nil {
+		event = string(tx.Event.Type)
+	}
+	meta, err := json.Marshal(tx.Meta)
+	if err != nil {
+		meta = nil
+	}
+	return []string{
+		strconv.FormatUint(tx.Block, 10),
+		strconv.FormatInt(tx.Date, 10),
+		tx.ID,
+		tx.From,
+		tx.To,
+		string(tx.Type),
+		string(tx.Status),
+		tx.Error,
+		string(tx.Direction),
+		string(tx.Fee),
+		strconv.FormatUint(tx.Sequence, 10),
+		tx.Memo,
+		event,
+		string(meta),
+	}
+}
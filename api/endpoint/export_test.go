@@ -0,0 +1,74 @@
+package endpoint
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type mockBlockAPI struct {
+	blocks map[int64]*types.Block
+}
+
+func (m mockBlockAPI) Coin() coin.Coin {
+	return coin.Coins[coin.ETH]
+}
+
+func (m mockBlockAPI) CurrentBlockNumber() (int64, error) {
+	return int64(len(m.blocks)), nil
+}
+
+func (m mockBlockAPI) GetBlockByNumber(num int64) (*types.Block, error) {
+	block, ok := m.blocks[num]
+	if !ok {
+		return nil, errors.E("block not found")
+	}
+	return block, nil
+}
+
+func TestExportBlocks(t *testing.T) {
+	apis := map[string]blockatlas.BlockAPI{"ethereum": mockBlockAPI{blocks: map[int64]*types.Block{
+		10: {Number: 10, Txs: []types.Tx{
+			{ID: "0x1", Coin: coin.ETH, From: "0xa", To: "0xb", Fee: "21000", Block: 10, Status: types.StatusCompleted, Type: types.TxTransfer, Meta: types.Transfer{Value: "1", Symbol: "ETH", Decimals: 18}},
+		}},
+		11: {Number: 11, Txs: []types.Tx{
+			{ID: "0x2", Coin: coin.ETH, From: "0xb", To: "0xc", Fee: "21000", Block: 11, Status: types.StatusCompleted, Type: types.TxTransfer, Meta: types.Transfer{Value: "2", Symbol: "ETH", Decimals: 18}},
+			{ID: "0x3", Coin: coin.ETH, From: "0xc", To: "0xd", Fee: "21000", Block: 11, Status: types.StatusError, Type: types.TxTransfer, Meta: types.Transfer{Value: "3", Symbol: "ETH", Decimals: 18}},
+		}},
+	}}}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/export/:coin", func(c *gin.Context) { ExportBlocks(c, apis) })
+
+	w := serve(router, http.MethodGet, "/v1/export/ethereum?from=10&to=11", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, exportColumns, records[0])
+	assert.Equal(t, "0x3", records[3][2])
+	assert.Equal(t, "error", records[3][6])
+	assert.Equal(t, "11", w.Header().Get(ExportLastBlockTrailer))
+
+	w = serve(router, http.MethodGet, "/v1/export/ethereum?from=11&to=12&format=ndjson", "", nil)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	var tx types.Tx
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &tx))
+	assert.Equal(t, "0x2", tx.ID)
+	assert.Equal(t, "11", w.Header().Get(ExportLastBlockTrailer), "block 12 failed")
+
+	w = serve(router, http.MethodGet, "/v1/export/ethereum?from=12&to=11", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(router, http.MethodGet, "/v1/export/bitcoin?from=1&to=2", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
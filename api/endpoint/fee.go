@@ -0,0 +1,28 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+// @Summary Get Fee Estimates
+// @ID fees
+// @Description Get the fee rates of the coin by priority with the expected confirmation time, read from the
+// @Description mempool fee histogram when the coin has a fee API and from the estimates of the node otherwise
+// @Produce json
+// @Tags Fees
+// @Param coin path string true "the coin name" default(bitcoin)
+// @Success 200 {object} types.FeeEstimates
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v2/{coin}/fees [get]
+func GetFeeEstimates(c *gin.Context, api blockatlas.FeeAPI) {
+	estimates, err := api.GetFeeEstimates()
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, &estimates)
+}
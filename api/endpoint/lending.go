@@ -0,0 +1,468 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/lending"
+	"github.com/trustwallet/blockatlas/services/market"
+)
+
+// defaultEarningsInterval is the spacing of the earnings history points without ?interval=
+const defaultEarningsInterval = time.Hour * 24
+
+var (
+	// providersWorkers is the number of providers queried at once by /v1/lending/providers and best-rates
+	providersWorkers = 8
+	// providerTimeout is the time a provider has to answer before being listed in the errors
+	providerTimeout = time.Second * 5
+)
+
+// @Summary Get lending providers
+// @ID lending_providers
+// @Description Get the lending providers and the assets they accept, with the errors of the providers failing or timing out
+// @Produce json
+// @Tags Lending
+// @Param no_cache query bool false "Fetch the info from the providers, bypassing the info cache"
+// @Success 200 {object} types.LendingProvidersResponse
+// @Router /v1/lending/providers [get]
+func ServeProviders(c *gin.Context, apis map[string]blockatlas.LendingAPI, cache *lending.InfoCache) {
+	if c.Query("no_cache") == "true" {
+		cache = nil
+	}
+	c.JSON(http.StatusOK, LendingProviders(apis, cache, c.Request.Context()))
+}
+
+// LendingProviders returns the providers answering in time with the errors of the others, served by the
+// REST and the gRPC APIs
+func LendingProviders(apis map[string]blockatlas.LendingAPI, cache *lending.InfoCache, ctx context.Context) types.LendingProvidersResponse {
+	return getProviders(apis, cache, providersWorkers, providerTimeout, ctx)
+}
+
+type providerResult struct {
+	id    string
+	value interface{}
+	err   error
+}
+
+// getProviders queries the providers concurrently, at most workers at once, and lists the ones
+// failing or not answering within timeout in the errors. The info is read from the cache if any.
+func getProviders(apis map[string]blockatlas.LendingAPI, cache *lending.InfoCache, workers int, timeout time.Duration, ctx context.Context) types.LendingProvidersResponse {
+	results, errs := queryProviders(apis, workers, timeout, func(id string, api blockatlas.LendingAPI, ctx context.Context) (interface{}, error) {
+		if cache == nil {
+			return api.GetProviderInfo(ctx)
+		}
+		return cache.GetProviderInfo(id, api, ctx)
+	}, ctx)
+	response := types.LendingProvidersResponse{
+		Providers: make(types.LendingProviders, 0, len(results)),
+		Errors:    errs,
+	}
+	for _, r := range results {
+		response.Providers = append(response.Providers, r.value.(types.LendingProvider))
+	}
+	response.Providers.SortByID()
+	return response
+}
+
+// providerCall is a call to a provider, stopped at the end of the context
+type providerCall func(id string, api blockatlas.LendingAPI, ctx context.Context) (interface{}, error)
+
+// queryProviders calls every provider concurrently, at most workers at once, each within timeout of the
+// request context, returning the answers by provider ID and the errors of the providers failing or not
+// answering in time
+func queryProviders(apis map[string]blockatlas.LendingAPI, workers int, timeout time.Duration, call providerCall, ctx context.Context) ([]providerResult, []types.ProviderError) {
+	ids := providerIDs(apis)
+	if workers > len(ids) {
+		workers = len(ids)
+	}
+	var (
+		jobs    = make(chan string)
+		results = make(chan providerResult, len(ids))
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				results <- callProvider(id, apis[id], timeout, call, ctx)
+			}
+		}()
+	}
+	for _, id := range ids {
+		jobs <- id
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	answers := make([]providerResult, 0, len(ids))
+	errs := make([]types.ProviderError, 0)
+	for r := range results {
+		switch {
+		case blockatlas.IsLendingTimeout(r.err):
+			errs = append(errs, types.ProviderError{Provider: r.id, Error: errProviderTimeout.Error(), Timeout: true})
+		case r.err != nil:
+			errs = append(errs, types.ProviderError{Provider: r.id, Error: r.err.Error()})
+		default:
+			answers = append(answers, r)
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool { return answers[i].id < answers[j].id })
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Provider < errs[j].Provider })
+	return answers, errs
+}
+
+var errProviderTimeout = errors.E("provider timed out")
+
+// callProvider gives the provider a context ending after timeout, the late answer of a provider
+// ignoring its context is dropped
+func callProvider(id string, api blockatlas.LendingAPI, timeout time.Duration, call providerCall, parent context.Context) providerResult {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+	done := make(chan providerResult, 1)
+	go func() {
+		value, err := call(id, api, ctx)
+		done <- providerResult{id: id, value: value, err: err}
+	}()
+	select {
+	case r := <-done:
+		return r
+	case <-ctx.Done():
+		return providerResult{id: id, err: blockatlas.NewLendingError(ctx.Err(), ctx)}
+	}
+}
+
+// providerIDs returns the IDs of the providers in order, the rates of several providers
+// are listed provider by provider
+func providerIDs(apis map[string]blockatlas.LendingAPI) []string {
+	ids := make([]string, 0, len(apis))
+	for id := range apis {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// @Summary Get lending rates
+// @ID lending_rates
+// @Description Get the current rates of the assets from all the providers
+// @Accept json
+// @Produce json
+// @Tags Lending
+// @Param include_inactive query bool false "Include the paused, frozen and deprecated markets"
+// @Param request body types.RatesRequest true "Assets"
+// @Success 200 {object} types.LendingRates
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/lending/rates [post]
+func ServeRates(c *gin.Context, apis map[string]blockatlas.LendingAPI) {
+	var req types.RatesRequest
+	if !bindValid(c, &req) || !validate(c, &req) {
+		return
+	}
+	includeInactive := c.Query("include_inactive") == "true"
+	results, _ := queryProviders(apis, providersWorkers, providerTimeout, func(id string, api blockatlas.LendingAPI, ctx context.Context) (interface{}, error) {
+		return api.GetCurrentLendingRates(req.Assets, ctx)
+	}, c.Request.Context())
+	rates := make(types.LendingRates, 0)
+	for _, r := range results {
+		providerRates := r.value.(types.LendingRates)
+		if !includeInactive {
+			providerRates = activeRates(providerRates)
+		}
+		rates = append(rates, providerRates...)
+	}
+	c.JSON(http.StatusOK, rates)
+}
+
+// activeRates drops the markets not accepting deposits, wallets offer the rates to deposit at
+func activeRates(rates types.LendingRates) types.LendingRates {
+	result := make(types.LendingRates, 0, len(rates))
+	for _, r := range rates {
+		if r.Status.AcceptsDeposits() {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// @Summary Get best lending rates
+// @ID lending_best_rates
+// @Description Get the highest deposit APY of the assets across the providers, with the provider offering it
+// @Produce json
+// @Tags Lending
+// @Param assets query string true "Comma-separated asset symbols, e.g. DAI,USDC"
+// @Success 200 {object} types.BestRatesResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/lending/best-rates [get]
+func ServeBestRates(c *gin.Context, apis map[string]blockatlas.LendingAPI) {
+	assets := make([]string, 0)
+	for _, asset := range strings.Split(c.Query("assets"), ",") {
+		if asset = strings.TrimSpace(asset); asset != "" {
+			assets = append(assets, asset)
+		}
+	}
+	if len(assets) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("assets is required")))
+		return
+	}
+	c.JSON(http.StatusOK, getBestRates(apis, assets, providersWorkers, providerTimeout, c.Request.Context()))
+}
+
+// getBestRates keeps the highest APY of the active markets of each asset, the first provider by ID
+// on a tie. The assets no provider answering in time offers are left out.
+func getBestRates(apis map[string]blockatlas.LendingAPI, assets []string, workers int, timeout time.Duration, ctx context.Context) types.BestRatesResponse {
+	results, errs := queryProviders(apis, workers, timeout, func(id string, api blockatlas.LendingAPI, ctx context.Context) (interface{}, error) {
+		return api.GetCurrentLendingRates(assets, ctx)
+	}, ctx)
+	best := make(map[string]types.BestRate)
+	for _, r := range results {
+		for _, rate := range activeRates(r.value.(types.LendingRates)) {
+			key := strings.ToUpper(rate.Asset)
+			if current, ok := best[key]; ok && current.APY >= rate.MaxAPY {
+				continue
+			}
+			best[key] = types.BestRate{Asset: rate.Asset, Provider: r.id, APY: rate.MaxAPY}
+		}
+	}
+	response := types.BestRatesResponse{Rates: make([]types.BestRate, 0, len(assets)), Errors: errs}
+	seen := make(map[string]bool)
+	for _, asset := range assets {
+		key := strings.ToUpper(asset)
+		if rate, ok := best[key]; ok && !seen[key] {
+			seen[key] = true
+			response.Rates = append(response.Rates, rate)
+		}
+	}
+	return response
+}
+
+// @Summary Compare savings products
+// @ID lending_compare
+// @Description Compare the savings products of an asset across the providers, normalized from the cached provider data
+// @Produce json
+// @Tags Lending
+// @Param asset query string true "Asset symbol, e.g. USDC"
+// @Success 200 {object} types.LendingComparison
+// @Router /v1/lending/compare [get]
+func ServeComparison(c *gin.Context, cache *lending.ProviderCache) {
+	asset := c.Query("asset")
+	if asset == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("asset is required")))
+		return
+	}
+	c.JSON(http.StatusOK, lending.Compare(asset, cache.All(), time.Now(), cache.StaleAfter()))
+}
+
+// @Summary Refresh lending provider
+// @ID lending_provider_refresh
+// @Description Fetch the info of the provider at once and update the cache served by /v1/lending/compare
+// @Produce json
+// @Tags Lending
+// @Param X-API-Key header string true "Admin API key"
+// @Param provider path string true "Provider ID"
+// @Success 200 {object} lending.CachedProvider
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v1/lending/providers/{provider}/refresh [post]
+func RefreshProvider(c *gin.Context, apis map[string]blockatlas.LendingAPI, cache *lending.ProviderCache) {
+	id := c.Param("provider")
+	api, ok := apis[id]
+	if !ok {
+		abortUnknownProvider(c, id)
+		return
+	}
+	provider, err := cache.RefreshProvider(id, api, time.Now(), c.Request.Context())
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, provider)
+}
+
+// @Summary Get lending account
+// @ID lending_account
+// @Description Get the lending contracts of the addresses at the provider. With sub-wallets among the addresses,
+// @Description {"wallet": "savings", "addresses": [...]} or {"wallet": "savings", "xpub": "xpub..."}, the contracts are grouped by wallet ID.
+// @Description The principal and the earned interest of the contracts come from the provider or its deposit history.
+// @Description With a currency, the contracts get the value of their current amount at the price of their asset on its chain.
+// @Accept json
+// @Produce json
+// @Tags Lending
+// @Param provider path string true "Provider ID"
+// @Param currency query string false "Fiat currency of the contract values, e.g. usd"
+// @Param request body types.AccountRequest true "Addresses and assets"
+// @Success 200 {object} []types.AccountLendingContracts
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 501 {object} ErrorResponse
+// @Router /v1/lending/account/{provider} [post]
+func ServeAccount(c *gin.Context, apis map[string]blockatlas.LendingAPI, prices market.PriceSource) {
+	api, ok := apis[c.Param("provider")]
+	if !ok {
+		abortUnknownProvider(c, c.Param("provider"))
+		return
+	}
+	currency := c.Query("currency")
+	if currency != "" && !currencyPattern.MatchString(currency) {
+		middleware.AbortWithRequestError(c, http.StatusUnprocessableEntity, CodeInvalidCurrency, errors.E("invalid currency", errors.Params{"currency": currency}))
+		return
+	}
+	if currency != "" && prices == nil {
+		middleware.AbortWithRequestError(c, http.StatusNotImplemented, CodePricesDisabled, errors.E("prices are not enabled"))
+		return
+	}
+	req, ok := bindAccountRequest(c)
+	if !ok {
+		return
+	}
+	contracts, err := api.GetAccountLendingContracts(req, c.Request.Context())
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	if eventsAPI, ok := api.(blockatlas.LendingEventsAPI); ok {
+		lending.AddAccountPrincipal(eventsAPI, *contracts, c.Request.Context())
+	}
+	if currency != "" {
+		info, err := api.GetProviderInfo(c.Request.Context())
+		if err != nil {
+			renderError(c, err)
+			return
+		}
+		lending.AddAccountValues(*contracts, info.Assets, prices, currency, c.Request.Context())
+	}
+	if len(req.Wallets) > 0 {
+		c.JSON(http.StatusOK, lending.GroupByWallet(req, *contracts))
+		return
+	}
+	c.JSON(http.StatusOK, contracts)
+}
+
+// currencyPattern matches the fiat currency codes, e.g. usd
+var currencyPattern = regexp.MustCompile(`^[a-zA-Z]{3,5}$`)
+
+// bindAccountRequest reads the request with the addresses of its sub-wallets resolved, then validated
+func bindAccountRequest(c *gin.Context) (types.AccountRequest, bool) {
+	var req types.AccountRequest
+	if !bindValid(c, &req) {
+		return req, false
+	}
+	resolved, err := lending.ResolveWallets(req)
+	if err != nil {
+		middleware.AbortWithRequestError(c, http.StatusUnprocessableEntity, CodeInvalidXpub, err)
+		return req, false
+	}
+	return resolved, validate(c, &resolved)
+}
+
+// @Summary Get lending earnings
+// @ID lending_account_earnings
+// @Description Get the interest accrued by the addresses, reconstructed from the rate history of the provider
+// @Accept json
+// @Produce json
+// @Tags Lending
+// @Param provider path string true "Provider ID"
+// @Param interval query string false "Spacing of the history points, e.g. 24h"
+// @Param cursor query string false "The next_page of the previous page"
+// @Param limit query int false "History points of each asset per page, 100 by default"
+// @Param request body types.AccountRequest true "Addresses and assets"
+// @Success 200 {object} blockatlas.PageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Router /v1/lending/account/{provider}/earnings [post]
+func ServeAccountEarnings(c *gin.Context, apis map[string]blockatlas.LendingAPI) {
+	api, ok := apis[c.Param("provider")]
+	if !ok {
+		abortUnknownProvider(c, c.Param("provider"))
+		return
+	}
+	historyAPI, ok := api.(blockatlas.LendingHistoryAPI)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotImplemented, errorResponse(errors.E("the provider has no rate history")))
+		return
+	}
+	interval := defaultEarningsInterval
+	if value, ok := c.GetQuery("interval"); ok {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid interval", errors.Params{"interval": value})))
+			return
+		}
+		interval = d
+	}
+	cursor, ok := bindPage(c, blockatlas.DefaultPageLimit)
+	if !ok {
+		return
+	}
+	req, ok := bindAccountRequest(c)
+	if !ok {
+		return
+	}
+	earnings, err := lending.AccountEarnings(historyAPI, req, time.Now().Unix(), int64(interval/time.Second), c.Request.Context())
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	earnings, next := pageEarnings(earnings, cursor)
+	c.JSON(http.StatusOK, pageResponse(earnings, len(earnings), next))
+}
+
+// pageEarnings keeps the points of the page in the history of every asset, oldest first,
+// the next page goes on while a history has more points
+func pageEarnings(accounts []types.AccountLendingEarnings, page blockatlas.Page) ([]types.AccountLendingEarnings, string) {
+	var next string
+	for i := range accounts {
+		for j := range accounts[i].Earnings {
+			history := accounts[i].Earnings[j].History
+			start, end, n := page.Bounds(len(history))
+			accounts[i].Earnings[j].History = history[start:end]
+			if n != "" {
+				next = n
+			}
+		}
+	}
+	return accounts, next
+}
+
+// @Summary Get withdrawal queue
+// @ID lending_queue
+// @Description Get the exit queue of the asset at the provider, for the providers whose withdrawals wait
+// @Produce json
+// @Tags Lending
+// @Param provider path string true "Provider ID"
+// @Param asset path string true "Asset symbol"
+// @Success 200 {object} types.WithdrawalQueue
+// @Router /v1/lending/queue/{provider}/{asset} [get]
+func ServeWithdrawalQueue(c *gin.Context, apis map[string]blockatlas.LendingAPI) {
+	api, ok := apis[c.Param("provider")]
+	if !ok {
+		abortUnknownProvider(c, c.Param("provider"))
+		return
+	}
+	queueAPI, ok := api.(blockatlas.LendingQueueAPI)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotImplemented, errorResponse(errors.E("the provider has no withdrawal queue")))
+		return
+	}
+	queue, err := queueAPI.GetWithdrawalQueue(c.Param("asset"), c.Request.Context())
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, queue)
+}
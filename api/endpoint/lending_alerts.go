@@ -0,0 +1,89 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/notifier/push"
+	"github.com/trustwallet/blockatlas/services/observer/subscriber"
+)
+
+// LendingAlertStorage persists the lending rate alerts evaluated by the notifier, implemented by db.Instance
+type LendingAlertStorage interface {
+	AddLendingRateAlerts(alerts []models.LendingRateAlert, ctx context.Context) error
+	DeleteLendingRateAlerts(alerts []models.LendingRateAlert, ctx context.Context) error
+}
+
+// @Summary Create lending alert
+// @ID lending_alert
+// @Description Post the APY of the asset at the provider to the callback URL when it goes above or below the thresholds,
+// @Description or moves by more than change percent. Creating an existing alert updates its thresholds.
+// @Accept json
+// @Produce json
+// @Tags Lending
+// @Param X-API-Key header string true "Lending alerts API key"
+// @Param request body types.LendingAlertRequest true "Alert and callback URL"
+// @Success 200 {object} types.LendingAlertRequest
+// @Router /v1/lending/alerts [post]
+func CreateLendingAlert(c *gin.Context, apis map[string]blockatlas.LendingAPI, storage LendingAlertStorage) {
+	req, alert, ok := bindLendingAlert(c, subscriber.AddSubscription, apis)
+	if !ok {
+		return
+	}
+	if err := storage.AddLendingRateAlerts([]models.LendingRateAlert{alert}, c.Request.Context()); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, req)
+}
+
+// @Summary Delete lending alert
+// @ID lending_alert_delete
+// @Description Stop posting the alert of the asset at the provider to the callback URL
+// @Accept json
+// @Tags Lending
+// @Param X-API-Key header string true "Lending alerts API key"
+// @Param request body types.LendingAlertRequest true "Alert and callback URL"
+// @Success 204
+// @Router /v1/lending/alerts [delete]
+func DeleteLendingAlert(c *gin.Context, apis map[string]blockatlas.LendingAPI, storage LendingAlertStorage) {
+	_, alert, ok := bindLendingAlert(c, subscriber.DeleteSubscription, apis)
+	if !ok {
+		return
+	}
+	if err := storage.DeleteLendingRateAlerts([]models.LendingRateAlert{alert}, c.Request.Context()); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// bindLendingAlert reads the alert of a served provider with an https callback URL, and a threshold
+// when it is added
+func bindLendingAlert(c *gin.Context, operation types.SubscriptionOperation, apis map[string]blockatlas.LendingAPI) (types.LendingAlertRequest, models.LendingRateAlert, bool) {
+	var req types.LendingAlertRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return req, models.LendingRateAlert{}, false
+	}
+	if !push.ValidWebhookURL(req.CallbackURL, false) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("callback_url must be an https URL")))
+		return req, models.LendingRateAlert{}, false
+	}
+	channel := types.Channel{Provider: types.ChannelWebhook, Token: req.CallbackURL}
+	alerts := subscriber.ToLendingRateAlertData(operation, []types.LendingRateAlert{req.LendingRateAlert}, channel, req.Locale)
+	if len(alerts) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("provider and asset are required, and a threshold to create the alert")))
+		return req, models.LendingRateAlert{}, false
+	}
+	if _, ok := apis[alerts[0].Provider]; !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(errors.E("unknown provider")))
+		return req, models.LendingRateAlert{}, false
+	}
+	return req, alerts[0], true
+}
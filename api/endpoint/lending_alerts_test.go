@@ -0,0 +1,70 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type memoryLendingAlerts map[string]models.LendingRateAlert
+
+func (m memoryLendingAlerts) key(a models.LendingRateAlert) string {
+	return a.Provider + "/" + a.Asset + "/" + a.Channel + "/" + a.Token
+}
+
+func (m memoryLendingAlerts) AddLendingRateAlerts(alerts []models.LendingRateAlert, ctx context.Context) error {
+	for _, a := range alerts {
+		m[m.key(a)] = a
+	}
+	return nil
+}
+
+func (m memoryLendingAlerts) DeleteLendingRateAlerts(alerts []models.LendingRateAlert, ctx context.Context) error {
+	for _, a := range alerts {
+		delete(m, m.key(a))
+	}
+	return nil
+}
+
+func TestLendingAlerts(t *testing.T) {
+	storage := memoryLendingAlerts{}
+	apis := map[string]blockatlas.LendingAPI{"compound": mockLendingAPI{}}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/v1/lending/alerts", func(c *gin.Context) { CreateLendingAlert(c, apis, storage) })
+	router.DELETE("/v1/lending/alerts", func(c *gin.Context) { DeleteLendingAlert(c, apis, storage) })
+
+	alert := map[string]interface{}{"provider": "Compound", "asset": "dai", "above": 5.5, "callback_url": "https://example.com/hooks/apy"}
+	w := serve(router, http.MethodPost, "/v1/lending/alerts", "", alert)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, memoryLendingAlerts{"compound/DAI/webhook/https://example.com/hooks/apy": {
+		Provider: "compound",
+		Asset:    "DAI",
+		Channel:  string(types.ChannelWebhook),
+		Token:    "https://example.com/hooks/apy",
+		Above:    5.5,
+	}}, storage)
+
+	// The alert is deleted without its thresholds
+	w = serve(router, http.MethodDelete, "/v1/lending/alerts", "", map[string]interface{}{"provider": "compound", "asset": "DAI", "callback_url": "https://example.com/hooks/apy"})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, storage)
+
+	for _, invalid := range []map[string]interface{}{
+		{"provider": "compound", "asset": "DAI", "above": 5.5, "callback_url": "http://example.com/hooks/apy"},
+		{"provider": "compound", "asset": "DAI", "callback_url": "https://example.com/hooks/apy"},
+		{"provider": "compound", "above": 5.5, "callback_url": "https://example.com/hooks/apy"},
+	} {
+		w = serve(router, http.MethodPost, "/v1/lending/alerts", "", invalid)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+	w = serve(router, http.MethodPost, "/v1/lending/alerts", "", map[string]interface{}{"provider": "aave", "asset": "DAI", "below": 1, "callback_url": "https://example.com"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, storage)
+}
@@ -0,0 +1,343 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/lending"
+	"github.com/trustwallet/blockatlas/services/market"
+)
+
+type mockLendingAPI struct {
+	provider types.LendingProvider
+	rates    types.LendingRates
+}
+
+func (m mockLendingAPI) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	return m.provider, nil
+}
+
+func (m mockLendingAPI) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	return m.rates, nil
+}
+
+func (m mockLendingAPI) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	return &[]types.AccountLendingContracts{}, nil
+}
+
+func lendingRouter(apis map[string]blockatlas.LendingAPI) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RenderErrors())
+	router.POST("/v1/lending/rates", func(c *gin.Context) { ServeRates(c, apis) })
+	return router
+}
+
+func TestServeRates(t *testing.T) {
+	router := lendingRouter(map[string]blockatlas.LendingAPI{
+		"compound": mockLendingAPI{rates: types.LendingRates{
+			{Asset: "DAI", MaxAPY: 3.1},
+			{Asset: "USDC", MaxAPY: 2.4, Status: types.MarketActive},
+			{Asset: "BAT", MaxAPY: 1.2, Status: types.MarketFrozen},
+			{Asset: "SAI", MaxAPY: 0.1, Status: types.MarketDeprecated},
+		}},
+	})
+
+	var rates types.LendingRates
+	w := serve(router, http.MethodPost, "/v1/lending/rates", "", types.RatesRequest{Assets: []string{"DAI"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &rates))
+	assert.Len(t, rates, 2)
+
+	w = serve(router, http.MethodPost, "/v1/lending/rates?include_inactive=true", "", types.RatesRequest{Assets: []string{"DAI"}})
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &rates))
+	assert.Len(t, rates, 4)
+	assert.Equal(t, types.MarketFrozen, rates[2].Status)
+}
+
+type mockLendingQueueAPI struct {
+	mockLendingAPI
+}
+
+func (m mockLendingQueueAPI) GetWithdrawalQueue(asset string, ctx context.Context) (types.WithdrawalQueue, error) {
+	return types.WithdrawalQueue{Asset: asset, Length: 12, Amount: "32000000000000000000", EstimatedWait: 86400}, nil
+}
+
+func TestServeWithdrawalQueue(t *testing.T) {
+	apis := map[string]blockatlas.LendingAPI{"lido": mockLendingQueueAPI{}, "compound": mockLendingAPI{}}
+	router := lendingRouter(apis)
+	router.GET("/v1/lending/queue/:provider/:asset", func(c *gin.Context) { ServeWithdrawalQueue(c, apis) })
+
+	var queue types.WithdrawalQueue
+	w := serve(router, http.MethodGet, "/v1/lending/queue/lido/ETH", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &queue))
+	assert.Equal(t, types.WithdrawalQueue{Asset: "ETH", Length: 12, Amount: "32000000000000000000", EstimatedWait: 86400}, queue)
+
+	w = serve(router, http.MethodGet, "/v1/lending/queue/compound/DAI", "", nil)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+	w = serve(router, http.MethodGet, "/v1/lending/queue/unknown/DAI", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestServeComparison(t *testing.T) {
+	cache := lending.NewProviderCache()
+	cache.Set("compound", types.LendingProvider{ID: "compound", Assets: []types.AssetInfo{{Symbol: "USDC", APY: 4}}}, time.Now())
+	router := lendingRouter(nil)
+	router.GET("/v1/lending/compare", func(c *gin.Context) { ServeComparison(c, cache) })
+
+	var comparison types.LendingComparison
+	w := serve(router, http.MethodGet, "/v1/lending/compare?asset=usdc", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &comparison))
+	assert.Len(t, comparison.Products, 1)
+	assert.Equal(t, 4.0, comparison.Products[0].APY)
+
+	w = serve(router, http.MethodGet, "/v1/lending/compare", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+type slowLendingAPI struct {
+	mockLendingAPI
+	delay time.Duration
+}
+
+func (m slowLendingAPI) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	select {
+	case <-time.After(m.delay):
+		return m.provider, nil
+	case <-ctx.Done():
+		return types.LendingProvider{}, blockatlas.NewLendingError(ctx.Err(), ctx)
+	}
+}
+
+func TestGetProviders(t *testing.T) {
+	apis := map[string]blockatlas.LendingAPI{
+		"compound": mockLendingAPI{provider: types.LendingProvider{ID: "compound"}},
+		"aave":     slowLendingAPI{mockLendingAPI{provider: types.LendingProvider{ID: "aave"}}, time.Millisecond * 10},
+		"cream":    slowLendingAPI{mockLendingAPI{provider: types.LendingProvider{ID: "cream"}}, time.Second},
+		"yearn":    failingLendingAPI{},
+	}
+	response := getProviders(apis, nil, 2, time.Millisecond*200, context.Background())
+	assert.Equal(t, types.LendingProviders{{ID: "aave"}, {ID: "compound"}}, response.Providers)
+	assert.Equal(t, []types.ProviderError{
+		{Provider: "cream", Error: "provider timed out", Timeout: true},
+		{Provider: "yearn", Error: blockatlas.ErrSourceConn.Error()},
+	}, response.Errors)
+
+	empty := getProviders(map[string]blockatlas.LendingAPI{}, nil, 2, time.Second, context.Background())
+	assert.Empty(t, empty.Providers)
+	assert.NotNil(t, empty.Errors)
+
+	// The providers stop with the request, they didn't time out
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := getProviders(map[string]blockatlas.LendingAPI{"cream": apis["cream"]}, nil, 2, time.Second, ctx)
+	assert.Empty(t, canceled.Providers)
+	assert.Equal(t, []types.ProviderError{{Provider: "cream", Error: context.Canceled.Error()}}, canceled.Errors)
+}
+
+type failingLendingAPI struct {
+	mockLendingAPI
+}
+
+func (m failingLendingAPI) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	return types.LendingProvider{}, blockatlas.ErrSourceConn
+}
+
+func (m failingLendingAPI) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	return nil, blockatlas.ErrSourceConn
+}
+
+func TestRefreshProvider(t *testing.T) {
+	cache := lending.NewProviderCache()
+	cache.Set("compound", types.LendingProvider{ID: "compound"}, time.Unix(1000, 0))
+	apis := map[string]blockatlas.LendingAPI{
+		"compound": mockLendingAPI{provider: types.LendingProvider{ID: "compound", Info: types.ProviderInfo{Description: "Compound"}}},
+		"aave":     failingLendingAPI{},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RenderErrors())
+	router.POST("/v1/lending/providers/:provider/refresh", func(c *gin.Context) { RefreshProvider(c, apis, cache) })
+
+	w := serve(router, http.MethodPost, "/v1/lending/providers/compound/refresh", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var snapshot lending.CachedProvider
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &snapshot))
+	assert.Equal(t, "Compound", snapshot.Info.Info.Description)
+	assert.True(t, snapshot.UpdatedAt.After(time.Unix(1000, 0)))
+	cached, _ := cache.Get("compound")
+	assert.Equal(t, "Compound", cached.Info.Info.Description)
+
+	w = serve(router, http.MethodPost, "/v1/lending/providers/aave/refresh", "", nil)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = serve(router, http.MethodPost, "/v1/lending/providers/unknown/refresh", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestServeBestRates(t *testing.T) {
+	apis := map[string]blockatlas.LendingAPI{
+		"compound": mockLendingAPI{rates: types.LendingRates{
+			{Asset: "DAI", MaxAPY: 3.1},
+			{Asset: "USDC", MaxAPY: 2.4},
+			{Asset: "BAT", MaxAPY: 9.5, Status: types.MarketFrozen},
+		}},
+		"aave": mockLendingAPI{rates: types.LendingRates{
+			{Asset: "dai", MaxAPY: 2.8},
+			{Asset: "USDC", MaxAPY: 2.4},
+			{Asset: "BAT", MaxAPY: 1.2},
+		}},
+		"yearn": failingLendingAPI{},
+	}
+	response := getBestRates(apis, []string{"USDC", "DAI", "BAT", "WBTC", "dai"}, 2, time.Second, context.Background())
+	assert.Equal(t, []types.BestRate{
+		{Asset: "USDC", Provider: "aave", APY: 2.4},
+		{Asset: "DAI", Provider: "compound", APY: 3.1},
+		{Asset: "BAT", Provider: "aave", APY: 1.2},
+	}, response.Rates)
+	assert.Equal(t, []types.ProviderError{{Provider: "yearn", Error: blockatlas.ErrSourceConn.Error()}}, response.Errors)
+
+	router := lendingRouter(apis)
+	router.GET("/v1/lending/best-rates", func(c *gin.Context) { ServeBestRates(c, apis) })
+	w := serve(router, http.MethodGet, "/v1/lending/best-rates?assets=DAI,%20USDC", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body types.BestRatesResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Rates, 2)
+
+	w = serve(router, http.MethodGet, "/v1/lending/best-rates?assets=,", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+type accountsLendingAPI struct {
+	mockLendingAPI
+}
+
+func (m accountsLendingAPI) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	accounts := make([]types.AccountLendingContracts, 0, len(req.Addresses))
+	for _, address := range req.Addresses {
+		accounts = append(accounts, types.AccountLendingContracts{Address: address, Contracts: []types.LendingContract{{Asset: "DAI", StartAmount: "1", CurrentAmount: "1"}}})
+	}
+	return &accounts, nil
+}
+
+func TestServeAccount(t *testing.T) {
+	apis := map[string]blockatlas.LendingAPI{"compound": accountsLendingAPI{}}
+	router := lendingRouter(apis)
+	router.POST("/v1/lending/account/:provider", func(c *gin.Context) { ServeAccount(c, apis, nil) })
+
+	w := serve(router, http.MethodPost, "/v1/lending/account/compound", "", map[string]interface{}{"addresses": []string{address1, address2}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var accounts []types.AccountLendingContracts
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &accounts))
+	assert.Len(t, accounts, 2)
+
+	w = serve(router, http.MethodPost, "/v1/lending/account/compound", "", map[string]interface{}{"addresses": []interface{}{
+		address1,
+		map[string]interface{}{"wallet": "savings", "addresses": []string{address2, address3}},
+	}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var wallets []types.WalletLendingContracts
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &wallets))
+	assert.Len(t, wallets, 2)
+	assert.Equal(t, "savings", wallets[1].Wallet)
+	assert.Len(t, wallets[1].Accounts, 2)
+
+	w = serve(router, http.MethodPost, "/v1/lending/account/compound", "", map[string]interface{}{"addresses": []interface{}{
+		map[string]interface{}{"wallet": "hd", "xpub": "xpub-invalid"},
+	}})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+type mockPrices map[string]market.Price
+
+func (m mockPrices) GetPrices(chain string, symbols []string, currency string, ctx context.Context) (map[string]market.Price, error) {
+	return m, nil
+}
+
+func TestServeAccount_Currency(t *testing.T) {
+	provider := types.LendingProvider{ID: "compound", Assets: []types.AssetInfo{{Symbol: "DAI", Chain: "ETH", Decimals: 0}}}
+	apis := map[string]blockatlas.LendingAPI{"compound": accountsLendingAPI{mockLendingAPI{provider: provider}}}
+	router := lendingRouter(apis)
+	router.POST("/v1/lending/account/:provider", func(c *gin.Context) { ServeAccount(c, apis, mockPrices{"DAI": {Value: 0.9, UpdatedAt: 1600000000}}) })
+	router.POST("/v1/lending/disabled/:provider", func(c *gin.Context) { ServeAccount(c, apis, nil) })
+	body := map[string]interface{}{"addresses": []string{address1}}
+
+	w := serve(router, http.MethodPost, "/v1/lending/account/compound?currency=eur", "", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var accounts []types.AccountLendingContracts
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &accounts))
+	assert.Equal(t, &types.FiatValue{Currency: "EUR", Amount: 0.9, Price: 0.9, UpdatedAt: 1600000000}, accounts[0].Contracts[0].Value)
+
+	w = serve(router, http.MethodPost, "/v1/lending/account/compound", "", body)
+	assert.NotContains(t, w.Body.String(), `"value"`)
+
+	w = serve(router, http.MethodPost, "/v1/lending/account/compound?currency=e1", "", body)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), CodeInvalidCurrency)
+
+	w = serve(router, http.MethodPost, "/v1/lending/disabled/compound?currency=eur", "", body)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+	assert.Contains(t, w.Body.String(), CodePricesDisabled)
+}
+
+const (
+	address1 = "0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"
+	address2 = "0x3d9819210A31b4961b30EF54bE2aeD79B9c9Cd3B"
+	address3 = "0x5d3a536E4D6DbD6114cc1Ead35777bAB948E3643"
+)
+
+type eventsLendingAPI struct {
+	accountsLendingAPI
+}
+
+func (m eventsLendingAPI) GetAccountLendingEvents(address string, ctx context.Context) ([]types.LendingEvent, error) {
+	if address == address2 {
+		return nil, blockatlas.ErrSourceConn
+	}
+	return []types.LendingEvent{{Type: types.LendingDeposit, Asset: "DAI", Value: "1", Date: 1}}, nil
+}
+
+func TestServeAccount_Principal(t *testing.T) {
+	apis := map[string]blockatlas.LendingAPI{"aave": eventsLendingAPI{}}
+	router := lendingRouter(apis)
+	router.POST("/v1/lending/account/:provider", func(c *gin.Context) { ServeAccount(c, apis, nil) })
+
+	w := serve(router, http.MethodPost, "/v1/lending/account/aave", "", map[string]interface{}{"addresses": []string{address1, address2}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var accounts []types.AccountLendingContracts
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &accounts))
+	assert.Equal(t, types.Amount("1"), accounts[0].Contracts[0].Principal)
+	assert.Equal(t, types.Amount("0"), accounts[0].Contracts[0].Earned)
+	// The failing address keeps its contracts
+	assert.Empty(t, accounts[1].Contracts[0].Principal)
+}
+
+func TestServeProviders_InfoCache(t *testing.T) {
+	cache := lending.NewInfoCache(lending.NewMemoryStore(), time.Minute, nil)
+	cached := getProviders(map[string]blockatlas.LendingAPI{"compound": mockLendingAPI{provider: types.LendingProvider{ID: "compound", Type: "cached"}}}, cache, 2, time.Second, context.Background())
+	assert.Empty(t, cached.Errors)
+
+	apis := map[string]blockatlas.LendingAPI{"compound": mockLendingAPI{provider: types.LendingProvider{ID: "compound", Type: "fetched"}}}
+	router := lendingRouter(apis)
+	router.GET("/v1/lending/providers", func(c *gin.Context) { ServeProviders(c, apis, cache) })
+
+	var response types.LendingProvidersResponse
+	w := serve(router, http.MethodGet, "/v1/lending/providers", "", nil)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, types.ProviderType("cached"), response.Providers[0].Type)
+
+	w = serve(router, http.MethodGet, "/v1/lending/providers?no_cache=true", "", nil)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, types.ProviderType("fetched"), response.Providers[0].Type)
+}
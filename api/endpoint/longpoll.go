@@ -0,0 +1,56 @@
+package endpoint
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// WaitParam holds the transactions request until a new transaction of the address or the timeout, e.g. 30s
+const WaitParam = "wait"
+
+// TxWaiter signals the new transactions of an address, implemented by longpoll.Hub
+type TxWaiter interface {
+	Wait(coin uint, address string) (<-chan struct{}, func())
+}
+
+// LongPoll holds the request with ?wait= until the parser reports a new transaction
+// of the address or the wait elapses, then serves the transactions as usual.
+// The wait is capped at maxWait, a nil waiter answers 501 to the requests asking to wait.
+func LongPoll(waiter TxWaiter, coin uint, maxWait time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.GetQuery(WaitParam)
+		if !ok {
+			c.Next()
+			return
+		}
+		wait, err := time.ParseDuration(value)
+		if err != nil || wait < 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid wait", errors.Params{"wait": value})))
+			return
+		}
+		if waiter == nil {
+			c.AbortWithStatusJSON(http.StatusNotImplemented, errorResponse(errors.E("long polling is not enabled")))
+			return
+		}
+		if wait > maxWait {
+			wait = maxWait
+		}
+
+		newTx, cancel := waiter.Wait(coin, c.Param("address"))
+		defer cancel()
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-newTx:
+		case <-timer.C:
+		case <-c.Request.Context().Done():
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
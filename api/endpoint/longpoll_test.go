@@ -0,0 +1,68 @@
+package endpoint
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// waiterMock signals a new transaction after the delay
+type waiterMock struct {
+	delay time.Duration
+}
+
+func (m waiterMock) Wait(coin uint, address string) (<-chan struct{}, func()) {
+	ch := make(chan struct{})
+	timer := time.AfterFunc(m.delay, func() { close(ch) })
+	return ch, func() { timer.Stop() }
+}
+
+func longPollRouter(waiter TxWaiter, maxWait time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v2/ethereum/transactions/:address", LongPoll(waiter, coin.ETH, maxWait), func(c *gin.Context) {
+		GetTransactionsHistory(c, txAPIMock{txs: []types.Tx{{ID: "0x1", Meta: types.Transfer{Value: "1"}}}}, nil, nil)
+	})
+	return router
+}
+
+func TestLongPoll(t *testing.T) {
+	router := longPollRouter(waiterMock{delay: time.Millisecond * 20}, time.Minute)
+
+	start := time.Now()
+	w := serve(router, http.MethodGet, "/v2/ethereum/transactions/0x0?wait=10s", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"0x1"`)
+	assert.True(t, time.Since(start) >= time.Millisecond*20)
+	assert.True(t, time.Since(start) < time.Second*10)
+
+	w = serve(router, http.MethodGet, "/v2/ethereum/transactions/0x0?wait=soon", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLongPoll_Timeout(t *testing.T) {
+	router := longPollRouter(waiterMock{delay: time.Hour}, time.Millisecond*20)
+
+	start := time.Now()
+	w := serve(router, http.MethodGet, "/v2/ethereum/transactions/0x0?wait=30s", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, time.Since(start) < time.Second)
+
+	w = serve(router, http.MethodGet, "/v2/ethereum/transactions/0x0", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestLongPoll_Disabled(t *testing.T) {
+	router := longPollRouter(nil, time.Minute)
+
+	w := serve(router, http.MethodGet, "/v2/ethereum/transactions/0x0?wait=30s", "", nil)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+
+	w = serve(router, http.MethodGet, "/v2/ethereum/transactions/0x0", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
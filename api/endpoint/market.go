@@ -0,0 +1,138 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/market"
+)
+
+const (
+	defaultTickerCurrency = "USD"
+	defaultChartPeriod    = time.Hour * 24
+	defaultChartItems     = 64
+	maxChartItems         = 1000
+)
+
+type (
+	// TickerSource serves the tickers of the market providers, resolved by provider priority
+	TickerSource interface {
+		GetTickers(coins []string, currency string, now time.Time, ctx context.Context) ([]market.Ticker, error)
+	}
+
+	// ChartSource serves the price history of the market providers, downsampled to maxItems points
+	ChartSource interface {
+		GetChart(coin uint, currency string, start, end int64, maxItems int, ctx context.Context) (market.Chart, error)
+	}
+
+	// RatesSource serves the fiat rates of the rates provider in market.BaseCurrency
+	RatesSource interface {
+		GetRates(now time.Time, ctx context.Context) (market.Rates, error)
+	}
+)
+
+// @Summary Get market tickers
+// @ID market_ticker
+// @Description Get the price and 24 hours change of the coins, from the first market provider by priority with a recent ticker
+// @Produce json
+// @Tags Market
+// @Param coins query string true "Comma-separated coin symbols, e.g. BTC,ETH"
+// @Param currency query string false "Currency of the prices, USD by default"
+// @Success 200 {object} types.DocsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/market/ticker [get]
+func GetMarketTickers(c *gin.Context, source TickerSource) {
+	coins := make([]string, 0)
+	for _, coin := range strings.Split(c.Query("coins"), ",") {
+		if coin = strings.TrimSpace(coin); coin != "" {
+			coins = append(coins, coin)
+		}
+	}
+	if len(coins) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("coins is required")))
+		return
+	}
+	currency := c.DefaultQuery("currency", defaultTickerCurrency)
+	tickers, err := source.GetTickers(coins, currency, time.Now(), c.Request.Context())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, types.DocsResponse{Docs: tickers})
+}
+
+// @Summary Get market charts
+// @ID market_charts
+// @Description Get the price history of the coin from the first market provider by priority with one,
+// @Description merged server-side into max_items periods at most
+// @Produce json
+// @Tags Market
+// @Param coin query int true "Coin id" default(60)
+// @Param currency query string false "Currency of the prices, USD by default"
+// @Param time_start query int false "Unix time of the first price, 24 hours ago by default"
+// @Param max_items query int false "Maximum number of points, 64 by default and 1000 at most"
+// @Success 200 {object} market.Chart
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/market/charts [get]
+func GetMarketCharts(c *gin.Context, source ChartSource) {
+	coinID, err := strconv.ParseUint(c.Query("coin"), 10, 32)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid coin", errors.Params{"coin": c.Query("coin")})))
+		return
+	}
+	end := time.Now().Unix()
+	start := end - int64(defaultChartPeriod.Seconds())
+	if value := c.Query("time_start"); value != "" {
+		if start, err = strconv.ParseInt(value, 10, 64); err != nil || start >= end {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid time_start", errors.Params{"time_start": value})))
+			return
+		}
+	}
+	maxItems := defaultChartItems
+	if value := c.Query("max_items"); value != "" {
+		if maxItems, err = strconv.Atoi(value); err != nil || maxItems <= 0 || maxItems > maxChartItems {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid max_items", errors.Params{"max_items": value})))
+			return
+		}
+	}
+	chart, err := source.GetChart(uint(coinID), c.DefaultQuery("currency", defaultTickerCurrency), start, end, maxItems, c.Request.Context())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, chart)
+}
+
+// @Summary Get fiat rates
+// @ID market_rates
+// @Description Get the units of each fiat currency worth one unit of the currency, the rates the prices in the
+// @Description other currencies are converted with
+// @Produce json
+// @Tags Market
+// @Param currency query string false "Base currency of the rates, USD by default"
+// @Success 200 {object} market.Rates
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/market/rates [get]
+func GetMarketRates(c *gin.Context, source RatesSource) {
+	rates, err := source.GetRates(time.Now(), c.Request.Context())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	currency := c.DefaultQuery("currency", market.BaseCurrency)
+	rebased, ok := rates.Rebase(currency)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("unknown currency", errors.Params{"currency": currency})))
+		return
+	}
+	c.JSON(http.StatusOK, rebased)
+}
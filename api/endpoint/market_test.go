@@ -0,0 +1,110 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/services/market"
+)
+
+type tickerSourceMock struct {
+	coins    []string
+	currency string
+}
+
+func (m *tickerSourceMock) GetTickers(coins []string, currency string, now time.Time, ctx context.Context) ([]market.Ticker, error) {
+	m.coins, m.currency = coins, currency
+	return []market.Ticker{{Coin: "BTC", Currency: currency, Price: 10000, Provider: "coingecko", UpdatedAt: 1600000000}}, nil
+}
+
+func TestGetMarketTickers(t *testing.T) {
+	source := &tickerSourceMock{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/market/ticker", func(c *gin.Context) { GetMarketTickers(c, source) })
+
+	w := serve(router, http.MethodGet, "/v1/market/ticker?coins=BTC,%20ETH,", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"BTC", "ETH"}, source.coins)
+	assert.Equal(t, "USD", source.currency)
+	var response struct {
+		Docs []market.Ticker `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Docs, 1)
+
+	w = serve(router, http.MethodGet, "/v1/market/ticker?coins=BTC&currency=EUR", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "EUR", source.currency)
+
+	w = serve(router, http.MethodGet, "/v1/market/ticker", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+type chartSourceMock struct {
+	coin     uint
+	start    int64
+	maxItems int
+}
+
+func (m *chartSourceMock) GetChart(coin uint, currency string, start, end int64, maxItems int, ctx context.Context) (market.Chart, error) {
+	m.coin, m.start, m.maxItems = coin, start, maxItems
+	return market.Chart{Coin: coin, Currency: currency, Provider: "coingecko", Prices: []market.ChartPoint{{Date: start, Price: 300}}}, nil
+}
+
+func TestGetMarketCharts(t *testing.T) {
+	source := &chartSourceMock{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/market/charts", func(c *gin.Context) { GetMarketCharts(c, source) })
+
+	w := serve(router, http.MethodGet, "/v1/market/charts?coin=60&time_start=1600000000&max_items=10", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, uint(60), source.coin)
+	assert.Equal(t, int64(1600000000), source.start)
+	assert.Equal(t, 10, source.maxItems)
+	var chart market.Chart
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &chart))
+	assert.Equal(t, "USD", chart.Currency)
+	assert.Len(t, chart.Prices, 1)
+
+	w = serve(router, http.MethodGet, "/v1/market/charts?coin=60", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, defaultChartItems, source.maxItems)
+
+	for _, query := range []string{"", "coin=eth", "coin=60&time_start=tomorrow", "coin=60&max_items=0", "coin=60&max_items=5000"} {
+		w = serve(router, http.MethodGet, "/v1/market/charts?"+query, "", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+type ratesSourceMock struct{}
+
+func (ratesSourceMock) GetRates(now time.Time, ctx context.Context) (market.Rates, error) {
+	return market.Rates{Base: "USD", Rates: map[string]float64{"USD": 1, "EUR": 0.8}, Provider: "fixer", UpdatedAt: 1600000000}, nil
+}
+
+func TestGetMarketRates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/market/rates", func(c *gin.Context) { GetMarketRates(c, ratesSourceMock{}) })
+
+	w := serve(router, http.MethodGet, "/v1/market/rates?currency=eur", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var rates market.Rates
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &rates))
+	assert.Equal(t, "EUR", rates.Base)
+	assert.Equal(t, 1.0, rates.Rates["EUR"])
+	assert.InDelta(t, 1.25, rates.Rates["USD"], 1e-9)
+
+	w = serve(router, http.MethodGet, "/v1/market/rates", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(router, http.MethodGet, "/v1/market/rates?currency=JPY", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
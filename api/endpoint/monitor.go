@@ -0,0 +1,27 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/monitor"
+)
+
+// SyntheticMonitor keeps the last results of the synthetic checks of the API
+type SyntheticMonitor interface {
+	Results() []monitor.Result
+}
+
+// @Summary Get synthetic checks
+// @ID admin_monitor
+// @Description Get the last synthetic check of the critical endpoints of every coin, flagged when the endpoint fails while its upstream answers
+// @Produce json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Success 200 {object} types.DocsResponse
+// @Router /admin/monitor [get]
+func GetMonitorResults(c *gin.Context, m SyntheticMonitor) {
+	results := m.Results()
+	c.JSON(http.StatusOK, types.DocsResponse{Docs: &results})
+}
@@ -0,0 +1,234 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	maxTxNoteTags = 16
+	// maxTxNoteLength and maxTxNoteTagsLength are the characters of the note and of the comma separated
+	// tags stored by models.TxNote
+	maxTxNoteLength     = 1024
+	maxTxNoteTagsLength = 512
+	// txNoteKeyHeader is the API key owning the notes
+	txNoteKeyHeader = "X-API-Key"
+)
+
+var errInvalidNotesKey = errors.E("Invalid or missing notes API key")
+
+type (
+	// TxNotesStorage persists the transaction notes, implemented by db.Instance.
+	// Notes are owned by the API key that wrote them, stored as its hash.
+	TxNotesStorage interface {
+		SetTxNote(note *models.TxNote, ctx context.Context) error
+		DeleteTxNote(keyHash string, coin uint, hash string, ctx context.Context) error
+		GetTxNotes(keyHash string, coin uint, hashes []string, ctx context.Context) ([]models.TxNote, error)
+	}
+
+	// TxNotes are the notes of the holders of the API keys, the transactions are served without notes when nil
+	TxNotes struct {
+		Storage TxNotesStorage
+		APIKeys []string
+	}
+
+	TxNoteRequest struct {
+		Note string   `json:"note"`
+		Tags []string `json:"tags"`
+	}
+)
+
+// @Summary Get transaction notes
+// @ID tx_notes
+// @Description Get the notes of the coin transactions
+// @Produce json
+// @Tags Transactions
+// @Param X-API-Key header string true "Notes API key"
+// @Param coin path int true "the coin id" default(60)
+// @Success 200 {array} types.TxNote
+// @Failure 401 {object} ErrorResponse
+// @Router /v1/notes/{coin} [get]
+func GetTxNotes(c *gin.Context, storage TxNotesStorage) {
+	coin, ok := noteCoin(c)
+	if !ok {
+		return
+	}
+	notes, err := storage.GetTxNotes(txNoteKeyHash(c), coin, nil, c.Request.Context())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	result := make([]types.TxNote, 0, len(notes))
+	for _, n := range notes {
+		result = append(result, normalizeTxNote(n))
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Set a transaction note
+// @ID tx_note_set
+// @Description Creates or replaces the note and tags of the transaction
+// @Accept json
+// @Produce json
+// @Tags Transactions
+// @Param X-API-Key header string true "Notes API key"
+// @Param coin path int true "the coin id" default(60)
+// @Param hash path string true "the transaction hash"
+// @Param data body endpoint.TxNoteRequest true "Note"
+// @Success 200 {object} types.TxNote
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/notes/{coin}/{hash} [put]
+func SetTxNote(c *gin.Context, storage TxNotesStorage) {
+	coin, ok := noteCoin(c)
+	if !ok {
+		return
+	}
+	var req TxNoteRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	tags := make([]string, 0, len(req.Tags))
+	for _, tag := range req.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if strings.Contains(tag, ",") {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("Tags cannot contain commas")))
+			return
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxTxNoteTags {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("Too many tags", errors.Params{"max": maxTxNoteTags})))
+		return
+	}
+	if utf8.RuneCountInString(req.Note) > maxTxNoteLength {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("Note too long", errors.Params{"max": maxTxNoteLength})))
+		return
+	}
+	joinedTags := strings.Join(tags, ",")
+	if utf8.RuneCountInString(joinedTags) > maxTxNoteTagsLength {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("Tags too long", errors.Params{"max": maxTxNoteTagsLength})))
+		return
+	}
+	note := models.TxNote{
+		KeyHash: txNoteKeyHash(c),
+		Coin:    coin,
+		Hash:    c.Param("hash"),
+		Note:    req.Note,
+		Tags:    joinedTags,
+	}
+	if err := storage.SetTxNote(&note, c.Request.Context()); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, normalizeTxNote(note))
+}
+
+// @Summary Delete a transaction note
+// @ID tx_note_delete
+// @Tags Transactions
+// @Param X-API-Key header string true "Notes API key"
+// @Param coin path int true "the coin id" default(60)
+// @Param hash path string true "the transaction hash"
+// @Success 204 {string} string "No Content"
+// @Failure 404 {object} ErrorResponse
+// @Router /v1/notes/{coin}/{hash} [delete]
+func DeleteTxNote(c *gin.Context, storage TxNotesStorage) {
+	coin, ok := noteCoin(c)
+	if !ok {
+		return
+	}
+	if err := storage.DeleteTxNote(txNoteKeyHash(c), coin, c.Param("hash"), c.Request.Context()); err != nil {
+		abortWithStorageError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// attachTxNotes sets the notes of the API key on the page when `include_notes=true`,
+// it aborts the request and returns false if the key is not one of the notes keys
+func attachTxNotes(c *gin.Context, page types.TxPage, notes *TxNotes) bool {
+	if notes == nil || c.Query("include_notes") != "true" || len(page) == 0 {
+		return true
+	}
+	if !notes.authorized(c.GetHeader(txNoteKeyHeader)) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errInvalidNotesKey))
+		return false
+	}
+	ctx := c.Request.Context()
+	keyHash := txNoteKeyHash(c)
+
+	hashes := make(map[uint][]string)
+	for _, tx := range page {
+		hashes[tx.Coin] = append(hashes[tx.Coin], tx.ID)
+	}
+	byCoin := make(map[uint]map[string]types.TxNote)
+	for coin, ids := range hashes {
+		coinNotes, err := notes.Storage.GetTxNotes(keyHash, coin, ids, ctx)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		byCoin[coin] = make(map[string]types.TxNote, len(coinNotes))
+		for _, n := range coinNotes {
+			byCoin[coin][n.Hash] = normalizeTxNote(n)
+		}
+	}
+	for i := range page {
+		if note, ok := byCoin[page[i].Coin][page[i].ID]; ok {
+			page[i].Note = &note
+		}
+	}
+	return true
+}
+
+func (n *TxNotes) authorized(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, k := range n.APIKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// txNoteKeyHash is the owner of the notes of the request, the API key is not stored
+func txNoteKeyHash(c *gin.Context) string {
+	return hashToken(c.GetHeader(txNoteKeyHeader))
+}
+
+func normalizeTxNote(n models.TxNote) types.TxNote {
+	tags := make([]string, 0)
+	if n.Tags != "" {
+		tags = strings.Split(n.Tags, ",")
+	}
+	return types.TxNote{
+		Coin:      n.Coin,
+		Hash:      n.Hash,
+		Note:      n.Note,
+		Tags:      tags,
+		UpdatedAt: n.UpdatedAt.Unix(),
+	}
+}
+
+func noteCoin(c *gin.Context) (uint, bool) {
+	coin, err := strconv.ParseUint(c.Param("coin"), 10, 32)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("Invalid coin id")))
+		return 0, false
+	}
+	return uint(coin), true
+}
@@ -0,0 +1,160 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type memoryTxNotes struct {
+	notes map[string]models.TxNote
+}
+
+func newMemoryTxNotes() *memoryTxNotes {
+	return &memoryTxNotes{notes: make(map[string]models.TxNote)}
+}
+
+func noteKey(keyHash string, coin uint, hash string) string {
+	return fmt.Sprintf("%s/%d/%s", keyHash, coin, hash)
+}
+
+func (m *memoryTxNotes) SetTxNote(note *models.TxNote, ctx context.Context) error {
+	note.UpdatedAt = time.Now()
+	m.notes[noteKey(note.KeyHash, note.Coin, note.Hash)] = *note
+	return nil
+}
+
+func (m *memoryTxNotes) DeleteTxNote(keyHash string, coin uint, hash string, ctx context.Context) error {
+	key := noteKey(keyHash, coin, hash)
+	if _, ok := m.notes[key]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.notes, key)
+	return nil
+}
+
+func (m *memoryTxNotes) GetTxNotes(keyHash string, coin uint, hashes []string, ctx context.Context) ([]models.TxNote, error) {
+	var result []models.TxNote
+	for _, n := range m.notes {
+		if n.KeyHash != keyHash || n.Coin != coin {
+			continue
+		}
+		if len(hashes) == 0 {
+			result = append(result, n)
+			continue
+		}
+		for _, h := range hashes {
+			if h == n.Hash {
+				result = append(result, n)
+			}
+		}
+	}
+	return result, nil
+}
+
+type txAPIMock struct {
+	txs []types.Tx
+	err error
+}
+
+func (m txAPIMock) Coin() coin.Coin { return coin.Ethereum() }
+
+func (m txAPIMock) GetTxsByAddress(address string) (types.TxPage, error) {
+	return m.txs, m.err
+}
+
+func notesRouter(notes *TxNotes, txs []types.Tx) *gin.Engine {
+	router := gin.New()
+	router.GET("/v1/notes/:coin", func(c *gin.Context) { GetTxNotes(c, notes.Storage) })
+	router.PUT("/v1/notes/:coin/:hash", func(c *gin.Context) { SetTxNote(c, notes.Storage) })
+	router.DELETE("/v1/notes/:coin/:hash", func(c *gin.Context) { DeleteTxNote(c, notes.Storage) })
+	router.GET("/v2/ethereum/transactions/:address", func(c *gin.Context) {
+		GetTransactionsHistory(c, txAPIMock{txs: txs}, nil, notes)
+	})
+	return router
+}
+
+func serveKey(router *gin.Engine, method, path, key string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if key != "" {
+		req.Header.Set(txNoteKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestTxNotes(t *testing.T) {
+	txs := []types.Tx{
+		{ID: "0x1", Coin: 60, From: "0xa", To: "0xb", Fee: "0", Date: 2, Meta: types.Transfer{Value: "1"}},
+		{ID: "0x2", Coin: 60, From: "0xb", To: "0xa", Fee: "0", Date: 1, Meta: types.Transfer{Value: "2"}},
+	}
+	router := notesRouter(&TxNotes{Storage: newMemoryTxNotes(), APIKeys: []string{"wallet", "exchange"}}, txs)
+
+	w := serveKey(router, http.MethodPut, "/v1/notes/60/0x1", "wallet", TxNoteRequest{Note: "rent", Tags: []string{"home", " ", "monthly"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var note types.TxNote
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &note))
+	assert.Equal(t, []string{"home", "monthly"}, note.Tags)
+
+	w = serveKey(router, http.MethodPut, "/v1/notes/60/0x2", "wallet", TxNoteRequest{Tags: []string{"a,b"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveKey(router, http.MethodPut, "/v1/notes/60/0x2", "wallet", TxNoteRequest{Note: strings.Repeat("é", maxTxNoteLength+1)})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveKey(router, http.MethodPut, "/v1/notes/60/0x2", "wallet", TxNoteRequest{Tags: []string{strings.Repeat("t", maxTxNoteTagsLength+1)}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serveKey(router, http.MethodPut, "/v1/notes/60/0x3", "wallet", TxNoteRequest{Note: strings.Repeat("é", maxTxNoteLength)})
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serveKey(router, http.MethodDelete, "/v1/notes/60/0x3", "wallet", nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	var notes []types.TxNote
+	w = serveKey(router, http.MethodGet, "/v1/notes/60", "wallet", nil)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &notes))
+	assert.Len(t, notes, 1)
+
+	var page struct {
+		Docs []types.Tx `json:"docs"`
+	}
+	w = serveKey(router, http.MethodGet, "/v2/ethereum/transactions/0xa?include_notes=true", "wallet", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 2)
+	assert.Equal(t, "rent", page.Docs[0].Note.Note)
+	assert.Nil(t, page.Docs[1].Note)
+
+	w = serveKey(router, http.MethodGet, "/v2/ethereum/transactions/0xa", "wallet", nil)
+	assert.NotContains(t, w.Body.String(), `"note"`)
+
+	w = serveKey(router, http.MethodGet, "/v2/ethereum/transactions/0xa?include_notes=true", "invalid", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// The notes of a key are private to it
+	w = serveKey(router, http.MethodGet, "/v2/ethereum/transactions/0xa?include_notes=true", "exchange", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"note"`)
+	w = serveKey(router, http.MethodDelete, "/v1/notes/60/0x1", "exchange", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveKey(router, http.MethodDelete, "/v1/notes/60/0x1", "wallet", nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serveKey(router, http.MethodDelete, "/v1/notes/60/0x1", "wallet", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
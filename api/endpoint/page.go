@@ -0,0 +1,31 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+// NextPageHeader carries the next_page cursor of the streamed lists, which have no page wrapper
+const NextPageHeader = "X-Next-Page"
+
+// bindPage reads the ?cursor= and ?limit= of the list endpoints, the invalid ones are rejected with 400
+func bindPage(c *gin.Context, defaultLimit int) (blockatlas.Page, bool) {
+	page, err := blockatlas.ParsePage(c.Query("cursor"), c.Query("limit"), defaultLimit)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return page, false
+	}
+	return page, true
+}
+
+func pageResponse(docs interface{}, total int, next string) blockatlas.PageResponse {
+	return blockatlas.PageResponse{Total: total, Docs: docs, Status: true, NextPage: next}
+}
+
+func setNextPage(c *gin.Context, next string) {
+	if next != "" {
+		c.Header(NextPageHeader, next)
+	}
+}
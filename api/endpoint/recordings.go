@@ -0,0 +1,92 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// RecordingKeyRequest is the API key to start or stop recording
+type RecordingKeyRequest struct {
+	APIKey string `json:"api_key"`
+}
+
+// @Summary Get recorded requests
+// @ID admin_recordings
+// @Description Get the last requests and responses recorded for the debug API keys, secrets redacted, the most recent first
+// @Produce json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Param api_key query string false "Recorded API key"
+// @Success 200 {object} types.DocsResponse
+// @Router /admin/recordings [get]
+func GetRecordings(c *gin.Context, recorder *middleware.Recorder) {
+	exchanges := recorder.Exchanges(c.Query("api_key"))
+	c.JSON(http.StatusOK, types.DocsResponse{Docs: &exchanges})
+}
+
+// @Summary Clear recorded requests
+// @ID admin_recordings_clear
+// @Description Drop the recorded requests and responses, the API keys stay recorded
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Success 204
+// @Router /admin/recordings [delete]
+func ClearRecordings(c *gin.Context, recorder *middleware.Recorder) {
+	recorder.Clear()
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Start recording an API key
+// @ID admin_recording_key_add
+// @Description Record the requests and responses of the API key from now on, until the instance restarts or the key is removed
+// @Accept json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Param request body endpoint.RecordingKeyRequest true "API key to record"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/recordings/keys [post]
+func AddRecordingKey(c *gin.Context, recorder *middleware.Recorder) {
+	key, ok := bindRecordingKey(c)
+	if !ok {
+		return
+	}
+	recorder.Enable(key)
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Stop recording an API key
+// @ID admin_recording_key_delete
+// @Description Stop recording the requests of the API key, its recorded requests are kept
+// @Accept json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Param request body endpoint.RecordingKeyRequest true "API key to stop recording"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/recordings/keys [delete]
+func DeleteRecordingKey(c *gin.Context, recorder *middleware.Recorder) {
+	key, ok := bindRecordingKey(c)
+	if !ok {
+		return
+	}
+	recorder.Disable(key)
+	c.Status(http.StatusNoContent)
+}
+
+func bindRecordingKey(c *gin.Context) (string, bool) {
+	var req RecordingKeyRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return "", false
+	}
+	if req.APIKey == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("empty api_key")))
+		return "", false
+	}
+	return req.APIKey, true
+}
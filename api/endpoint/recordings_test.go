@@ -0,0 +1,57 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/api/middleware"
+)
+
+func TestRecordings(t *testing.T) {
+	recorder := middleware.NewRecorder(10, 0, nil)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RecordRequests("Authorization", recorder))
+	router.GET("/v1/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	router.GET("/admin/recordings", func(c *gin.Context) { GetRecordings(c, recorder) })
+	router.DELETE("/admin/recordings", func(c *gin.Context) { ClearRecordings(c, recorder) })
+	router.POST("/admin/recordings/keys", func(c *gin.Context) { AddRecordingKey(c, recorder) })
+	router.DELETE("/admin/recordings/keys", func(c *gin.Context) { DeleteRecordingKey(c, recorder) })
+
+	recordings := func(key string) []middleware.Exchange {
+		w := serve(router, http.MethodGet, "/admin/recordings?api_key="+url.QueryEscape(key), "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res struct {
+			Docs []middleware.Exchange `json:"docs"`
+		}
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+		return res.Docs
+	}
+
+	w := serve(router, http.MethodPost, "/admin/recordings/keys", "", RecordingKeyRequest{APIKey: "Bearer device"})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	serve(router, http.MethodGet, "/v1/ping", "device", nil)
+	serve(router, http.MethodGet, "/v1/ping", "other", nil)
+
+	exchanges := recordings("Bearer device")
+	assert.Len(t, exchanges, 1)
+	assert.Equal(t, "/v1/ping", exchanges[0].URL)
+	assert.Equal(t, "pong", exchanges[0].ResponseBody)
+	assert.Empty(t, recordings("Bearer other"))
+
+	w = serve(router, http.MethodDelete, "/admin/recordings/keys", "", RecordingKeyRequest{APIKey: "Bearer device"})
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	serve(router, http.MethodGet, "/v1/ping", "device", nil)
+	assert.Len(t, recordings(""), 1)
+
+	w = serve(router, http.MethodDelete, "/admin/recordings", "", nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, recordings(""))
+
+	w = serve(router, http.MethodPost, "/admin/recordings/keys", "", RecordingKeyRequest{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,49 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/notifier"
+)
+
+// NotificationReplayer publishes again the recorded notifications of a replay request
+type NotificationReplayer interface {
+	Replay(req types.ReplayRequest, ctx context.Context) (int, error)
+}
+
+// @Summary Replay notifications
+// @ID replay_notifications
+// @Description Publish again the notifications of the addresses for the transactions of a time window, after a consumer outage
+// @Accept json
+// @Produce json
+// @Tags Observer
+// @Param X-API-Key header string true "Replay API key"
+// @Param request body types.ReplayRequest true "Subscriptions and unix time window"
+// @Success 200 {object} types.ReplayResponse
+// @Router /observers/v1/replay [post]
+func ReplayNotifications(c *gin.Context, replayer NotificationReplayer) {
+	var req types.ReplayRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if len(req.Subscriptions) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("empty subscriptions")))
+		return
+	}
+	if req.To < req.From || time.Duration(req.To-req.From)*time.Second > notifier.MaxReplayWindow {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid replay window", errors.Params{"max": notifier.MaxReplayWindow.String()})))
+		return
+	}
+	count, err := replayer.Replay(req, c.Request.Context())
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, types.ReplayResponse{Notifications: count})
+}
@@ -0,0 +1,43 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type mockReplayer struct {
+	requests []types.ReplayRequest
+}
+
+func (m *mockReplayer) Replay(req types.ReplayRequest, ctx context.Context) (int, error) {
+	m.requests = append(m.requests, req)
+	return len(req.Subscriptions["60"]), nil
+}
+
+func TestReplayNotifications(t *testing.T) {
+	replayer := &mockReplayer{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/observers/v1/replay", func(c *gin.Context) { ReplayNotifications(c, replayer) })
+
+	req := types.ReplayRequest{Subscriptions: types.Subscriptions{"60": {"0xa", "0xb"}}, From: 1600000000, To: 1600003600}
+	var res types.ReplayResponse
+	w := serve(router, http.MethodPost, "/observers/v1/replay", "", req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 2, res.Notifications)
+	assert.Equal(t, []types.ReplayRequest{req}, replayer.requests)
+
+	req.To = req.From + 30*24*3600
+	w = serve(router, http.MethodPost, "/observers/v1/replay", "", req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(router, http.MethodPost, "/observers/v1/replay", "", types.ReplayRequest{From: 1, To: 2})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, replayer.requests, 1)
+}
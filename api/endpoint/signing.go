@@ -0,0 +1,31 @@
+package endpoint
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/api/middleware"
+)
+
+// SigningKey is the public key verifying the X-Signature of the responses
+type SigningKey struct {
+	KeyID     string `json:"key_id"`
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+// @Summary Get the response signing key
+// @ID signing_key
+// @Description Get the base64 Ed25519 public key verifying the X-Signature header of the responses, named by X-Signature-Key
+// @Produce json
+// @Tags Info
+// @Success 200 {object} endpoint.SigningKey
+// @Router /v1/signing-key [get]
+func GetSigningKey(c *gin.Context, signer *middleware.ResponseSigner) {
+	c.JSON(http.StatusOK, SigningKey{
+		KeyID:     signer.KeyID,
+		Algorithm: "ed25519",
+		PublicKey: base64.StdEncoding.EncodeToString(signer.PublicKey()),
+	})
+}
@@ -0,0 +1,36 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// @Summary Simulate a transaction
+// @ID simulate
+// @Description Dry-run an unsigned transaction on the node of the coin to preview it before signing: the balance
+// @Description changes with the fee, the token transfers it emits, or the reason it fails with success false
+// @Accept json
+// @Produce json
+// @Tags Transactions
+// @Param coin path string true "the coin name" default(ethereum)
+// @Param request body types.SimulationRequest true "The unsigned transaction"
+// @Success 200 {object} types.Simulation
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v2/{coin}/simulate [post]
+func SimulateTx(c *gin.Context, api blockatlas.SimulationAPI) {
+	var req types.SimulationRequest
+	if !bindValid(c, &req) || !validate(c, &req) {
+		return
+	}
+	simulation, err := api.Simulate(req)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, &simulation)
+}
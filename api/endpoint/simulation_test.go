@@ -0,0 +1,50 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type simulationAPIMock struct {
+	requests []types.SimulationRequest
+}
+
+func (m *simulationAPIMock) Coin() coin.Coin {
+	return coin.Coins[coin.ETH]
+}
+
+func (m *simulationAPIMock) Simulate(tx types.SimulationRequest) (types.Simulation, error) {
+	m.requests = append(m.requests, tx)
+	return types.Simulation{Coin: coin.ETH, Success: true, Gas: "21000", Fee: "21000000000000"}, nil
+}
+
+func TestSimulateTx(t *testing.T) {
+	api := &simulationAPIMock{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.RenderErrors())
+	router.POST("/v2/ethereum/simulate", func(c *gin.Context) { SimulateTx(c, api) })
+
+	tx := types.SimulationRequest{From: "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1", To: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Value: "1000"}
+	w := serve(router, http.MethodPost, "/v2/ethereum/simulate", "", tx)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response types.Simulation
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "21000", response.Gas)
+	assert.Equal(t, []types.SimulationRequest{tx}, api.requests)
+
+	w = serve(router, http.MethodPost, "/v2/ethereum/simulate", "", types.SimulationRequest{To: tx.To})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	w = serve(router, http.MethodPost, "/v2/ethereum/simulate", "", types.SimulationRequest{From: tx.From, Value: "0x10"})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Len(t, api.requests, 1)
+}
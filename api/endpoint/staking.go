@@ -1,14 +1,21 @@
 package endpoint
 
 import (
+	"context"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/patrickmn/go-cache"
 	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"math/big"
 	"net/http"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type (
@@ -37,6 +44,7 @@ type (
 // @Produce json
 // @Tags Staking
 // @Param delegations body AddressesRequest true "Validators addresses and coins"
+// @Param stream query bool false "Write the delegations as newline-delimited JSON"
 // @Success 200 {object} blockatlas.DelegationsBatchPage
 // @Router /v2/staking/delegations [post]
 func GetStakeDelegationsWithAllInfoForBatch(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
@@ -46,6 +54,7 @@ func GetStakeDelegationsWithAllInfoForBatch(c *gin.Context, apis map[string]bloc
 		return
 	}
 
+	stream := newStreamWriter(c)
 	batch := make(blockatlas.DelegationsBatchPage, 0)
 	for _, r := range reqs {
 		requestCoin, ok := coin.Coins[r.Coin]
@@ -61,18 +70,126 @@ func GetStakeDelegationsWithAllInfoForBatch(c *gin.Context, apis map[string]bloc
 			continue
 		}
 		delegation.Delegations = sortDelegations(delegation.Delegations)
+		if stream != nil {
+			if !stream.Write(delegation) {
+				return
+			}
+			continue
+		}
 		batch = append(batch, delegation)
 	}
+	if stream != nil {
+		return
+	}
 	c.JSON(http.StatusOK, blockatlas.DocsResponse{Docs: &batch})
 }
 
-// @Summary Get Multiple Stake Delegations
-// @ID batch_delegations
-// @Description Get Stake Delegations for multiple coins
+const (
+	// MaxDelegationsBatch is the number of addresses accepted at once by /v1/staking/delegations
+	MaxDelegationsBatch = 50
+	// delegationsWorkers is the number of addresses of a batch queried at once
+	delegationsWorkers = 8
+	// delegationsBatchTimeout is the time the whole batch has to answer, the addresses left get a timeout error
+	delegationsBatchTimeout = time.Second * 10
+)
+
+var errDelegationsTimeout = errors.E("coin timed out")
+
+// @Summary Get Stake Delegations of Multiple Coins
+// @ID batch_delegations_v1
+// @Description Get the stake delegations of every address in one call, the addresses are queried concurrently.
+// @Description The items answer in the order of the request, with an error instead of the delegations for the
+// @Description unknown coins, the failures and the coins not answering within the batch timeout.
 // @Accept json
 // @Produce json
 // @Tags Staking
-// @Param delegations body AddressesRequest true "Validators addresses and coins"
+// @Param delegations body AddressesRequest true "Addresses and coins"
+// @Success 200 {object} types.DocsResponse{docs=[]types.DelegationsBatchItem}
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/staking/delegations [post]
+func GetStakeDelegationsBatch(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
+	var reqs AddressesRequest
+	if err := c.BindJSON(&reqs); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if len(reqs) > MaxDelegationsBatch {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("too many addresses", errors.Params{"max": MaxDelegationsBatch})))
+		return
+	}
+	items := getDelegationsBatch(apis, reqs, delegationsWorkers, delegationsBatchTimeout, c.Request.Context())
+	c.JSON(http.StatusOK, types.DocsResponse{Docs: &items})
+}
+
+// getDelegationsBatch queries the addresses concurrently, at most workers at once, the ones not answering
+// before the end of timeout get a timeout error
+func getDelegationsBatch(apis map[string]blockatlas.StakeAPI, reqs AddressesRequest, workers int, timeout time.Duration, parent context.Context) []types.DelegationsBatchItem {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+	if workers > len(reqs) {
+		workers = len(reqs)
+	}
+	var (
+		jobs  = make(chan int)
+		items = make([]types.DelegationsBatchItem, len(reqs))
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				items[i] = getDelegationsBatchItem(apis, reqs[i], ctx)
+			}
+		}()
+	}
+	for i := range reqs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return items
+}
+
+// getDelegationsBatchItem stops waiting for the coin at the end of ctx, its late answer is dropped
+func getDelegationsBatchItem(apis map[string]blockatlas.StakeAPI, req AddressBatchRequest, ctx context.Context) types.DelegationsBatchItem {
+	item := types.DelegationsBatchItem{Coin: req.Coin, Address: req.Address}
+	api, ok := apis[coin.Coins[req.Coin].Handle]
+	if !ok {
+		item.Error = "unknown coin"
+		return item
+	}
+	type result struct {
+		delegation types.DelegationResponse
+		err        error
+	}
+	done := make(chan result, 1)
+	go func() {
+		delegation, err := getDelegationResponse(api, req.Address)
+		done <- result{delegation: delegation, err: err}
+	}()
+	select {
+	case r := <-done:
+		if r.err != nil {
+			item.Error = r.err.Error()
+			return item
+		}
+		r.delegation.Delegations = sortDelegations(r.delegation.Delegations)
+		item.Delegations = &r.delegation
+	case <-ctx.Done():
+		item.Error = errDelegationsTimeout.Error()
+		item.Timeout = true
+	}
+	return item
+}
+
+// @Summary Get Multiple Stake Info
+// @ID batch_staking_list
+// @Description Get the staking info of multiple coins
+// @Accept json
+// @Produce json
+// @Tags Staking
+// @Param coins body CoinsRequest true "Coins"
 // @Success 200 {object} blockatlas.DelegationsBatchPage
 // @Router /v2/staking/list [post]
 func GetStakeInfoForBatch(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
@@ -98,16 +215,41 @@ func GetStakeInfoForBatch(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
 	c.JSON(http.StatusOK, blockatlas.DocsResponse{Docs: &batch})
 }
 
+// StakingListCache keeps the v3 staking list of each coins query for its ttl, the responses hint the
+// time left until it expires
+type StakingListCache struct {
+	cache *cache.Cache
+	ttl   time.Duration
+}
+
+func NewStakingListCache(ttl time.Duration) *StakingListCache {
+	return &StakingListCache{cache: cache.New(ttl, ttl), ttl: ttl}
+}
+
+// get returns the cached list of the query with its remaining freshness, fetched when missing or expired
+func (s *StakingListCache) get(query string, fetch func() blockatlas.StakingBatchPage, ctx context.Context) (blockatlas.StakingBatchPage, time.Duration) {
+	provenance := blockatlas.ProvenanceFrom(ctx)
+	if batch, expiry, ok := s.cache.GetWithExpiration(query); ok {
+		provenance.Cached(blockatlas.CacheHit, expiry.Add(-s.ttl))
+		return batch.(blockatlas.StakingBatchPage), time.Until(expiry)
+	}
+	batch := fetch()
+	s.cache.SetDefault(query, batch)
+	provenance.Cached(blockatlas.CacheMiss, time.Now())
+	return batch, s.ttl
+}
+
 // @Summary Get staking info by coin ID
 // @ID batch_info
 // @Description Get staking info by coin ID
 // @Produce json
+// @Produce application/msgpack
 // @Tags Staking
 // @Param coins query string true "List of coins"
 // @Success 200 {array} blockatlas.DelegationsBatchPage
 // @Failure 400 {object} ErrorResponse
 // @Router /v3/staking/list [get]
-func GetStakeInfoForCoins(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
+func GetStakeInfoForCoins(c *gin.Context, apis map[string]blockatlas.StakeAPI, list *StakingListCache) {
 	coinsRequest := c.Query("coins")
 	if coinsRequest == "" {
 		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("empty coins list")))
@@ -122,25 +264,26 @@ func GetStakeInfoForCoins(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
 		return
 	}
 
-	var reqs CoinsRequest
-	for _, c := range coins {
-		reqs = append(reqs, CoinBatchRequest{Coin: uint(c)})
-	}
-
-	batch := make(blockatlas.StakingBatchPage, 0)
-	for _, r := range reqs {
-		requestCoin, ok := coin.Coins[r.Coin]
-		if !ok {
-			continue
-		}
-		p, ok := apis[requestCoin.Handle]
-		if !ok {
-			continue
+	batch, freshness := list.get(coinsRequest, func() blockatlas.StakingBatchPage {
+		batch := make(blockatlas.StakingBatchPage, 0)
+		for _, id := range coins {
+			requestCoin, ok := coin.Coins[uint(id)]
+			if !ok {
+				continue
+			}
+			p, ok := apis[requestCoin.Handle]
+			if !ok {
+				continue
+			}
+			batch = append(batch, getStakingResponse(p))
 		}
-		staking := getStakingResponse(p)
-		batch = append(batch, staking)
-	}
-	c.JSON(http.StatusOK, blockatlas.DocsResponse{Docs: &batch})
+		return batch
+	}, c.Request.Context())
+	c.Header("Cache-Control", fmt.Sprintf("max-age=%d", int64(freshness/time.Second)))
+	renderNegotiated(c, http.StatusOK, types.CachedResponse{
+		Response:     types.DocsResponse{Docs: &batch},
+		CacheControl: cacheControl(types.CacheStaking, freshness),
+	})
 }
 
 // @Summary Get Validators
@@ -152,13 +295,48 @@ func GetStakeInfoForCoins(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
 // @Param coin path string true "the coin name" default(cosmos)
 // @Success 200 {object} blockatlas.DocsResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /v2/{coin}/staking/validators [get]
 func GetValidators(c *gin.Context, api blockatlas.StakeAPI) {
 	results, err := api.GetActiveValidators()
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
 		return
 	}
+	if results == nil {
+		results = make(types.StakeValidators, 0)
+	}
+	c.JSON(http.StatusOK, types.DocsResponse{Docs: &results})
+}
+
+// ValidatorDetailsSource returns the validators of a chain with their registry metadata, see assets.GetValidatorDetails
+type ValidatorDetailsSource func(api blockatlas.StakeAPI) ([]types.ValidatorDetails, error)
+
+// @Summary Get Validator Details
+// @ID validator_details
+// @Description Get the validators of the coin with their metadata, annual reward, commission and status (active, inactive or jailed)
+// @Produce json
+// @Tags Staking
+// @Param coin path string true "the coin handle or ID" default(cosmos)
+// @Success 200 {object} types.DocsResponse{docs=[]types.ValidatorDetails}
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v1/staking/{coin}/validators [get]
+func GetValidatorDetails(c *gin.Context, apis map[string]blockatlas.StakeAPI, source ValidatorDetailsSource) {
+	api, ok := stakeAPIByCoin(apis, c.Param("coin"))
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(errors.E("unknown coin")))
+		return
+	}
+	results, err := source(api)
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
+		return
+	}
+	if results == nil {
+		results = make([]types.ValidatorDetails, 0)
+	}
 	c.JSON(http.StatusOK, blockatlas.DocsResponse{Docs: &results})
 }
 
@@ -172,32 +350,132 @@ func GetValidators(c *gin.Context, api blockatlas.StakeAPI) {
 // @Param address path string true "the query address" default(TPJYCz8ppZNyvw7pTwmjajcx4Kk1MmEUhD)
 // @Success 200 {object} blockatlas.DelegationResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /v2/{coin}/staking/delegations/{address} [get]
 func GetStakingDelegationsForSpecificCoin(c *gin.Context, api blockatlas.StakeAPI) {
-	result, err := getDelegationResponse(api, c.Param("address"))
+	result, err := Delegations(c.Param("address"), api)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		renderError(c, err)
 		return
 	}
-	result.Delegations = sortDelegations(result.Delegations)
 	c.JSON(http.StatusOK, &result)
 }
 
+// Delegations returns the delegations of the address by value with its undelegated balance, served by the
+// REST and the gRPC APIs
+func Delegations(address string, api blockatlas.StakeAPI) (types.DelegationResponse, error) {
+	result, err := getDelegationResponse(api, address)
+	if err != nil {
+		return result, err
+	}
+	result.Delegations = sortDelegations(result.Delegations)
+	return result, nil
+}
+
+// @Summary Get Stake Delegations Summary
+// @ID delegations_summary
+// @Description Get the staking position of the address: its delegations, the totals staked and unbonding,
+// @Description the rewards not withdrawn yet when the coin reports them and the unbonding period in seconds
+// @Produce json
+// @Tags Staking
+// @Param coin path string true "the coin handle or ID" default(cosmos)
+// @Param address path string true "the query address" default(cosmos135qla4294zxarqhhgxsx0sw56yssa3z0f78pm0)
+// @Success 200 {object} types.DelegationsSummary
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v1/staking/{coin}/delegations/{address} [get]
+func GetStakingDelegationsSummary(c *gin.Context, apis map[string]blockatlas.StakeAPI) {
+	api, ok := stakeAPIByCoin(apis, c.Param("coin"))
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(errors.E("unknown coin")))
+		return
+	}
+	address := c.Param("address")
+	delegation, err := getDelegationResponse(api, address)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	delegation.Delegations = sortDelegations(delegation.Delegations)
+	summary := summarizeDelegations(delegation)
+	if rewardsAPI, ok := api.(blockatlas.StakeRewardsAPI); ok {
+		rewards, err := rewardsAPI.GetPendingRewards(address)
+		if isEmptyResult(err) {
+			rewards, err = "0", nil
+		}
+		if err != nil {
+			renderError(c, err)
+			return
+		}
+		summary.Rewards = rewards
+	}
+	c.JSON(http.StatusOK, &summary)
+}
+
+// stakeAPIByCoin returns the staking platform of the coin, given by handle or ID
+func stakeAPIByCoin(apis map[string]blockatlas.StakeAPI, coinParam string) (blockatlas.StakeAPI, bool) {
+	if api, ok := apis[coinParam]; ok {
+		return api, true
+	}
+	id, err := strconv.ParseUint(coinParam, 10, 32)
+	if err != nil {
+		return nil, false
+	}
+	requestCoin, ok := coin.Coins[uint(id)]
+	if !ok {
+		return nil, false
+	}
+	api, ok := apis[requestCoin.Handle]
+	return api, ok
+}
+
+// summarizeDelegations totals the active delegations as staked and the pending ones as unbonding,
+// the values not in the smallest unit are left out
+func summarizeDelegations(delegation types.DelegationResponse) types.DelegationsSummary {
+	staked, unbonding := new(big.Int), new(big.Int)
+	for _, d := range delegation.Delegations {
+		value, ok := new(big.Int).SetString(d.Value, 10)
+		if !ok {
+			continue
+		}
+		switch d.Status {
+		case types.DelegationStatusActive:
+			staked.Add(staked, value)
+		case types.DelegationStatusPending:
+			unbonding.Add(unbonding, value)
+		}
+	}
+	return types.DelegationsSummary{
+		Staked:             staked.String(),
+		Unbonding:          unbonding.String(),
+		UnbondingPeriod:    delegation.Details.LockTime,
+		DelegationResponse: delegation,
+	}
+}
+
 func getDelegationResponse(api blockatlas.StakeAPI, address string) (blockatlas.DelegationResponse, error) {
+	// The errors are returned as is, the handler maps them to the response status
 	delegations, err := api.GetDelegations(address)
+	if isEmptyResult(err) {
+		delegations, err = make(types.DelegationsPage, 0), nil
+	}
 	if err != nil {
 		return blockatlas.DelegationResponse{
 			StakingResponse: getStakingResponse(api),
 			Address:         address,
-		}, errors.E("Unable to fetch delegations list", err)
+		}, err
 	}
 	balance, err := api.UndelegatedBalance(address)
+	if isEmptyResult(err) {
+		balance, err = "0", nil
+	}
 	if err != nil {
 		return blockatlas.DelegationResponse{
 			Delegations:     delegations,
 			Address:         address,
 			StakingResponse: getStakingResponse(api),
-		}, errors.E("Unable to fetch undelegated balance", err)
+		}, err
 	}
 	return blockatlas.DelegationResponse{
 		Balance:         balance,
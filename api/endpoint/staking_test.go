@@ -0,0 +1,203 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type mockStakeAPI struct {
+	delegations types.DelegationsPage
+	rewards     string
+	delay       time.Duration
+}
+
+func (m mockStakeAPI) Coin() coin.Coin {
+	return coin.Coins[coin.ATOM]
+}
+
+func (m mockStakeAPI) UndelegatedBalance(address string) (string, error) {
+	return "7", nil
+}
+
+func (m mockStakeAPI) GetDetails() types.StakingDetails {
+	return types.StakingDetails{LockTime: 1814400, MinimumAmount: "1", Type: types.DelegationTypeDelegate}
+}
+
+func (m mockStakeAPI) GetValidators() (types.ValidatorPage, error) {
+	return nil, nil
+}
+
+func (m mockStakeAPI) GetDelegations(address string) (types.DelegationsPage, error) {
+	time.Sleep(m.delay)
+	if m.delegations == nil {
+		return nil, blockatlas.ErrNotFound
+	}
+	return m.delegations, nil
+}
+
+func (m mockStakeAPI) GetActiveValidators() (types.StakeValidators, error) {
+	return nil, nil
+}
+
+type mockStakeRewardsAPI struct {
+	mockStakeAPI
+}
+
+func (m mockStakeRewardsAPI) GetPendingRewards(address string) (string, error) {
+	return m.rewards, nil
+}
+
+func TestGetStakingDelegationsSummary(t *testing.T) {
+	validator := types.StakeValidator{ID: "cosmosvaloper1", Status: true, Details: mockStakeAPI{}.GetDetails()}
+	delegations := types.DelegationsPage{
+		{Delegator: validator, Value: "100", Status: types.DelegationStatusActive},
+		{Delegator: validator, Value: "250", Status: types.DelegationStatusActive},
+		{Delegator: validator, Value: "30", Status: types.DelegationStatusPending, Metadata: types.DelegationMetaDataPending{AvailableDate: 1577861658}},
+		{Delegator: validator, Value: "0.5", Status: types.DelegationStatusActive},
+	}
+	apis := map[string]blockatlas.StakeAPI{
+		"cosmos": mockStakeRewardsAPI{mockStakeAPI{delegations: delegations, rewards: "12"}},
+		"tezos":  mockStakeAPI{},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/staking/:coin/delegations/:address", func(c *gin.Context) { GetStakingDelegationsSummary(c, apis) })
+
+	for _, coinParam := range []string{"cosmos", "118"} {
+		w := serve(router, http.MethodGet, "/v1/staking/"+coinParam+"/delegations/cosmos1", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var summary types.DelegationsSummary
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &summary))
+		assert.Equal(t, "350", summary.Staked)
+		assert.Equal(t, "30", summary.Unbonding)
+		assert.Equal(t, "12", summary.Rewards)
+		assert.Equal(t, "7", summary.Balance)
+		assert.Equal(t, 1814400, summary.UnbondingPeriod)
+		assert.Len(t, summary.Delegations, 4)
+		assert.Equal(t, "250", summary.Delegations[0].Value)
+	}
+
+	w := serve(router, http.MethodGet, "/v1/staking/tezos/delegations/tz1", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var summary map[string]interface{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &summary))
+	assert.Equal(t, "0", summary["staked"])
+	assert.NotContains(t, summary, "rewards")
+
+	w = serve(router, http.MethodGet, "/v1/staking/bitcoin/delegations/bc1", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serve(router, http.MethodGet, "/v1/staking/0/delegations/bc1", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetValidatorDetails(t *testing.T) {
+	apis := map[string]blockatlas.StakeAPI{"cosmos": mockStakeRewardsAPI{}, "tezos": mockStakeAPI{}}
+	source := func(api blockatlas.StakeAPI) ([]types.ValidatorDetails, error) {
+		if _, ok := api.(mockStakeRewardsAPI); !ok {
+			return nil, blockatlas.ErrNotFound
+		}
+		return []types.ValidatorDetails{{ID: "cosmosvaloper1", Name: "Spider", Commission: 7.04, Status: types.ValidatorStatusJailed}}, nil
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/staking/:coin/validators", func(c *gin.Context) { GetValidatorDetails(c, apis, source) })
+
+	w := serve(router, http.MethodGet, "/v1/staking/118/validators", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var page struct {
+		Docs []types.ValidatorDetails `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Equal(t, []types.ValidatorDetails{{ID: "cosmosvaloper1", Name: "Spider", Commission: 7.04, Status: types.ValidatorStatusJailed}}, page.Docs)
+
+	w = serve(router, http.MethodGet, "/v1/staking/tezos/validators", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"docs":[]}`, w.Body.String())
+
+	w = serve(router, http.MethodGet, "/v1/staking/bitcoin/validators", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetStakeInfoForCoins(t *testing.T) {
+	apis := map[string]blockatlas.StakeAPI{"cosmos": mockStakeAPI{}}
+	list := NewStakingListCache(time.Hour * 10)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v3/staking/list", func(c *gin.Context) { GetStakeInfoForCoins(c, apis, list) })
+
+	var page struct {
+		Docs         []blockatlas.StakingResponse `json:"docs"`
+		CacheControl types.CacheControl           `json:"cache_control"`
+	}
+	w := serve(router, http.MethodGet, "/v3/staking/list?coins=118,0", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 1)
+	assert.Equal(t, types.CacheControl{types.CacheStaking: 36000}, page.CacheControl)
+	assert.Equal(t, "max-age=36000", w.Header().Get("Cache-Control"))
+
+	// The cached list hints the time left until it expires
+	list.cache.Set("118,0", blockatlas.StakingBatchPage{}, time.Hour*3+time.Second/2)
+	w = serve(router, http.MethodGet, "/v3/staking/list?coins=118,0", "", nil)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Empty(t, page.Docs)
+	assert.Equal(t, types.CacheControl{types.CacheStaking: 10800}, page.CacheControl)
+	assert.Equal(t, "max-age=10800", w.Header().Get("Cache-Control"))
+}
+
+func TestGetStakeDelegationsBatch(t *testing.T) {
+	validator := types.StakeValidator{ID: "cosmosvaloper1", Details: mockStakeAPI{}.GetDetails()}
+	apis := map[string]blockatlas.StakeAPI{
+		"cosmos": mockStakeAPI{delegations: types.DelegationsPage{{Delegator: validator, Value: "100", Status: types.DelegationStatusActive}}},
+		"tezos":  mockStakeAPI{delay: time.Second},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/v1/staking/delegations", func(c *gin.Context) {
+		items := getDelegationsBatch(apis, AddressesRequest{
+			{Address: "cosmos1", CoinBatchRequest: CoinBatchRequest{Coin: coin.ATOM}},
+			{Address: "tz1", CoinBatchRequest: CoinBatchRequest{Coin: coin.XTZ}},
+			{Address: "bc1", CoinBatchRequest: CoinBatchRequest{Coin: coin.BTC}},
+			{Address: "cosmos2", CoinBatchRequest: CoinBatchRequest{Coin: coin.ATOM}},
+		}, 2, time.Millisecond*100, c.Request.Context())
+		c.JSON(http.StatusOK, types.DocsResponse{Docs: &items})
+	})
+
+	start := time.Now()
+	w := serve(router, http.MethodPost, "/v1/staking/delegations", "", nil)
+	assert.True(t, time.Since(start) < time.Second, "the slow coin timed out")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var page struct {
+		Docs []types.DelegationsBatchItem `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 4)
+	assert.Equal(t, "cosmos1", page.Docs[0].Address)
+	assert.Equal(t, "100", page.Docs[0].Delegations.Delegations[0].Value)
+	assert.Equal(t, "7", page.Docs[0].Delegations.Balance)
+	assert.Equal(t, types.DelegationsBatchItem{Coin: coin.XTZ, Address: "tz1", Error: "coin timed out", Timeout: true}, page.Docs[1])
+	assert.Equal(t, types.DelegationsBatchItem{Coin: coin.BTC, Address: "bc1", Error: "unknown coin"}, page.Docs[2])
+	assert.Equal(t, "cosmos2", page.Docs[3].Address)
+	assert.NotNil(t, page.Docs[3].Delegations)
+}
+
+func TestGetStakeDelegationsBatch_TooMany(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/v1/staking/delegations", func(c *gin.Context) { GetStakeDelegationsBatch(c, nil) })
+
+	reqs := make(AddressesRequest, MaxDelegationsBatch+1)
+	w := serve(router, http.MethodPost, "/v1/staking/delegations", "", reqs)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(router, http.MethodPost, "/v1/staking/delegations", "", AddressesRequest{})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"docs":[]}`, w.Body.String())
+}
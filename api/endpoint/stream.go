@@ -0,0 +1,44 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const contentTypeNDJSON = "application/x-ndjson"
+
+// StreamParam is the query parameter switching the batch endpoints to newline-delimited JSON
+const StreamParam = "stream"
+
+// ndjsonWriter writes the items of a response one JSON document per line as they are fetched,
+// so large batches are never buffered in memory
+type ndjsonWriter struct {
+	c       *gin.Context
+	encoder *json.Encoder
+}
+
+// newStreamWriter returns the writer of the response if the request sets ?stream=true, nil otherwise
+func newStreamWriter(c *gin.Context) *ndjsonWriter {
+	if c.Query(StreamParam) != "true" {
+		return nil
+	}
+	c.Header("Content-Type", contentTypeNDJSON)
+	c.Status(http.StatusOK)
+	return &ndjsonWriter{c: c, encoder: json.NewEncoder(c.Writer)}
+}
+
+// Write encodes and flushes the item, false once the client went away
+func (w *ndjsonWriter) Write(item interface{}) bool {
+	select {
+	case <-w.c.Request.Context().Done():
+		return false
+	default:
+	}
+	if err := w.encoder.Encode(item); err != nil {
+		return false
+	}
+	w.c.Writer.Flush()
+	return true
+}
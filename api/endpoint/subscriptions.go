@@ -0,0 +1,216 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/notifier/push"
+	"github.com/trustwallet/blockatlas/services/observer/screening"
+	"github.com/trustwallet/blockatlas/services/observer/subscriber"
+	"github.com/trustwallet/blockatlas/services/observer/tenant"
+)
+
+type (
+	// SubscriptionPublisher queues the subscription events for the subscriber, e.g. mq.Subscriptions
+	SubscriptionPublisher interface {
+		Publish(body []byte) error
+	}
+
+	// QuotaStorage provides the usage of the quota of the tenants
+	QuotaStorage interface {
+		CountSubscriptions(tenant string, ctx context.Context) (int64, error)
+		GetTenantUsage(tenant, day string, ctx context.Context) (models.TenantUsage, error)
+	}
+)
+
+// @Summary Subscribe addresses
+// @ID publish_subscriptions
+// @Description Queue a subscription event (AddSubscription, UpdateSubscription or DeleteSubscription) of the tenant of the API key
+// @Accept json
+// @Produce json
+// @Tags Observer
+// @Param X-API-Key header string true "Subscriptions API key"
+// @Param request body types.SubscriptionEvent true "Subscription event"
+// @Success 202 {object} types.SubscriptionsResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /observers/v1/subscriptions [post]
+func PublishSubscriptions(c *gin.Context, publisher SubscriptionPublisher, screener screening.Screener, tenant string) {
+	var event types.SubscriptionEvent
+	if err := c.BindJSON(&event); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	switch event.Operation {
+	case subscriber.AddSubscription, subscriber.UpdateSubscription, subscriber.DeleteSubscription:
+	default:
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid operation")))
+		return
+	}
+	screenAndPublish(c, publisher, screener, event, tenant)
+}
+
+// @Summary Subscribe addresses to a webhook
+// @ID subscribe_webhook
+// @Description Post the normalized transactions of the addresses to the webhook of the tenant of the API key,
+// @Description with retries and a dead-letter queue for the deliveries failing
+// @Accept json
+// @Produce json
+// @Tags Observer
+// @Param X-API-Key header string true "Subscriptions API key"
+// @Param request body types.WebhookSubscriptionRequest true "Addresses by coin and webhook"
+// @Success 202 {object} types.SubscriptionsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v1/observer/subscriptions [post]
+func SubscribeWebhook(c *gin.Context, publisher SubscriptionPublisher, screener screening.Screener, tenant string) {
+	if event, ok := bindWebhookSubscription(c, subscriber.AddSubscription); ok {
+		screenAndPublish(c, publisher, screener, event, tenant)
+	}
+}
+
+// @Summary Unsubscribe addresses from a webhook
+// @ID unsubscribe_webhook
+// @Description Stop posting the transactions of the addresses to the webhook
+// @Accept json
+// @Produce json
+// @Tags Observer
+// @Param X-API-Key header string true "Subscriptions API key"
+// @Param request body types.WebhookSubscriptionRequest true "Addresses by coin and webhook"
+// @Success 202 {object} types.SubscriptionsResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /v1/observer/subscriptions [delete]
+func UnsubscribeWebhook(c *gin.Context, publisher SubscriptionPublisher, tenant string) {
+	if event, ok := bindWebhookSubscription(c, subscriber.DeleteSubscription); ok {
+		publishSubscriptionEvent(c, publisher, event, tenant)
+	}
+}
+
+// bindWebhookSubscription reads the request as an event of the webhook channel, false once the request is rejected
+func bindWebhookSubscription(c *gin.Context, operation types.SubscriptionOperation) (types.SubscriptionEvent, bool) {
+	var request types.WebhookSubscriptionRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return types.SubscriptionEvent{}, false
+	}
+	if !push.ValidWebhookURL(request.Webhook, false) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid webhook, an https URL is expected")))
+		return types.SubscriptionEvent{}, false
+	}
+	return types.SubscriptionEvent{
+		Subscriptions: request.Subscriptions,
+		Operation:     operation,
+		Channel:       &types.Channel{Provider: types.ChannelWebhook, Token: request.Webhook},
+		TTL:           request.TTL,
+	}, true
+}
+
+// screenAndPublish queues the event once the screener allows it, the deletions are not screened
+func screenAndPublish(c *gin.Context, publisher SubscriptionPublisher, screener screening.Screener, event types.SubscriptionEvent, tenant string) {
+	if screener != nil && event.Operation != subscriber.DeleteSubscription {
+		event.Tenant = tenant
+		decision, err := screener.Screen(screening.NewRequest(event), c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, ErrorResponse{Error: ErrorDetails{
+				Message: errors.E(err, "failed to screen the subscriptions").Error(),
+				Code:    CodeUpstreamUnavailable,
+			}})
+			return
+		}
+		if !decision.Allowed {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{Error: ErrorDetails{
+				Message: decision.Reason,
+				Code:    CodeSubscriptionRejected,
+			}})
+			return
+		}
+	}
+	publishSubscriptionEvent(c, publisher, event, tenant)
+}
+
+// @Summary Renew subscriptions
+// @ID renew_subscriptions
+// @Description Extend the expiry of the subscriptions (and of the channel ones with a channel) by the ttl in seconds, or by the default ttl of the observer
+// @Accept json
+// @Produce json
+// @Tags Observer
+// @Param X-API-Key header string true "Subscriptions API key"
+// @Param request body types.SubscriptionEvent true "Subscriptions, channel and ttl"
+// @Success 202 {object} types.SubscriptionsResponse
+// @Router /observers/v1/subscriptions/renew [post]
+func RenewSubscriptions(c *gin.Context, publisher SubscriptionPublisher, tenant string) {
+	var event types.SubscriptionEvent
+	if err := c.BindJSON(&event); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	renewal := types.SubscriptionEvent{
+		Subscriptions: event.Subscriptions,
+		Xpubs:         event.Xpubs,
+		Operation:     subscriber.RenewSubscription,
+		Channel:       event.Channel,
+		TTL:           event.TTL,
+	}
+	publishSubscriptionEvent(c, publisher, renewal, tenant)
+}
+
+// publishSubscriptionEvent queues the event as one of the tenant
+func publishSubscriptionEvent(c *gin.Context, publisher SubscriptionPublisher, event types.SubscriptionEvent, tenant string) {
+	count := len(event.ParseSubscriptions(event.Subscriptions)) + len(event.ParseSubscriptions(event.Xpubs))
+	if count == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("empty subscriptions")))
+		return
+	}
+	if event.TTL < 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid ttl")))
+		return
+	}
+	event.Tenant = tenant
+	raw, err := json.Marshal(event)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if err := publisher.Publish(raw); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(errors.E(err, "failed to queue the subscriptions")))
+		return
+	}
+	c.JSON(http.StatusAccepted, types.SubscriptionsResponse{Subscriptions: count})
+}
+
+// @Summary Quota usage
+// @ID quota_usage
+// @Description Get the subscriptions and the notifications of the day of the tenant of the API key, with its limits
+// @Produce json
+// @Tags Observer
+// @Param X-API-Key header string true "Subscriptions API key"
+// @Success 200 {object} types.QuotaUsage
+// @Router /observers/v1/quota [get]
+func GetQuotaUsage(c *gin.Context, storage QuotaStorage, t tenant.Tenant) {
+	ctx := c.Request.Context()
+	subscriptions, err := storage.CountSubscriptions(t.Name, ctx)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	usage, err := storage.GetTenantUsage(t.Name, time.Now().UTC().Format("2006-01-02"), ctx)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	c.JSON(http.StatusOK, types.QuotaUsage{
+		Tenant:                    t.Name,
+		Subscriptions:             subscriptions,
+		MaxSubscriptions:          t.MaxSubscriptions,
+		MaxNotificationsPerMinute: t.MaxNotificationsPerMinute,
+		NotificationsToday:        usage.Notifications,
+		ThrottledToday:            usage.Throttled,
+	})
+}
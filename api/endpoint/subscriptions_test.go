@@ -0,0 +1,186 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/screening"
+	"github.com/trustwallet/blockatlas/services/observer/subscriber"
+	"github.com/trustwallet/blockatlas/services/observer/tenant"
+)
+
+type (
+	mockPublisher struct {
+		published []types.SubscriptionEvent
+	}
+
+	mockQuotaStorage struct{}
+)
+
+func (m *mockPublisher) Publish(body []byte) error {
+	var event types.SubscriptionEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return err
+	}
+	m.published = append(m.published, event)
+	return nil
+}
+
+func (mockQuotaStorage) CountSubscriptions(tenant string, ctx context.Context) (int64, error) {
+	return 42, nil
+}
+
+func (mockQuotaStorage) GetTenantUsage(tenant, day string, ctx context.Context) (models.TenantUsage, error) {
+	return models.TenantUsage{Tenant: tenant, Day: day, Notifications: 100, Throttled: 5}, nil
+}
+
+func TestPublishSubscriptions(t *testing.T) {
+	publisher := &mockPublisher{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/observers/v1/subscriptions", func(c *gin.Context) { PublishSubscriptions(c, publisher, nil, "exchange") })
+
+	req := types.SubscriptionEvent{
+		Subscriptions: types.Subscriptions{"60": {"0xa"}},
+		Xpubs:         types.Subscriptions{"0": {"zpub1"}},
+		Operation:     subscriber.AddSubscription,
+		Tenant:        "other",
+	}
+	var res types.SubscriptionsResponse
+	w := serve(router, http.MethodPost, "/observers/v1/subscriptions", "", req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 2, res.Subscriptions)
+	assert.Len(t, publisher.published, 1)
+	assert.Equal(t, "exchange", publisher.published[0].Tenant, "the tenant is the one of the API key")
+
+	req.Operation = subscriber.RenewSubscription
+	w = serve(router, http.MethodPost, "/observers/v1/subscriptions", "", req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, publisher.published, 1)
+}
+
+func TestPublishSubscriptions_Screening(t *testing.T) {
+	publisher := &mockPublisher{}
+	screener := screening.Rules{Addresses: []string{"0xBAD"}, WebhookHosts: []string{".evil.example"}}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/observers/v1/subscriptions", func(c *gin.Context) { PublishSubscriptions(c, publisher, screener, "") })
+
+	req := types.SubscriptionEvent{
+		Subscriptions: types.Subscriptions{"60": {"0xa", "0xbad"}},
+		Operation:     subscriber.AddSubscription,
+	}
+	w := serve(router, http.MethodPost, "/observers/v1/subscriptions", "", req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var res ErrorResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, CodeSubscriptionRejected, res.Error.Code)
+	assert.Equal(t, "address not allowed: 0xbad", res.Error.Message)
+
+	req.Subscriptions = types.Subscriptions{"60": {"0xa"}}
+	req.Channel = &types.Channel{Provider: types.ChannelWebhook, Token: "https://hooks.evil.example/cb"}
+	w = serve(router, http.MethodPost, "/observers/v1/subscriptions", "", req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// The deletions are never screened
+	req.Operation = subscriber.DeleteSubscription
+	w = serve(router, http.MethodPost, "/observers/v1/subscriptions", "", req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	req.Operation = subscriber.AddSubscription
+	req.Channel = &types.Channel{Provider: types.ChannelWebhook, Token: "https://hooks.example.com/cb"}
+	w = serve(router, http.MethodPost, "/observers/v1/subscriptions", "", req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Len(t, publisher.published, 2)
+}
+
+func TestRenewSubscriptions(t *testing.T) {
+	publisher := &mockPublisher{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/observers/v1/subscriptions/renew", func(c *gin.Context) { RenewSubscriptions(c, publisher, "") })
+
+	req := types.SubscriptionEvent{
+		Subscriptions: types.Subscriptions{"60": {"0xa", "0xb"}},
+		Operation:     subscriber.DeleteSubscription,
+		Channel:       &types.Channel{Provider: types.ChannelFCM, Token: "device"},
+		TTL:           3600,
+	}
+	var res types.SubscriptionsResponse
+	w := serve(router, http.MethodPost, "/observers/v1/subscriptions/renew", "", req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, 2, res.Subscriptions)
+
+	assert.Len(t, publisher.published, 1)
+	event := publisher.published[0]
+	assert.Equal(t, subscriber.RenewSubscription, event.Operation, "the operation of the request is ignored")
+	assert.Equal(t, req.Channel, event.Channel)
+	assert.Equal(t, int64(3600), event.TTL)
+
+	w = serve(router, http.MethodPost, "/observers/v1/subscriptions/renew", "", types.SubscriptionEvent{})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	req.TTL = -1
+	w = serve(router, http.MethodPost, "/observers/v1/subscriptions/renew", "", req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, publisher.published, 1)
+}
+
+func TestGetQuotaUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	exchange := tenant.Tenant{Name: "exchange", Quota: tenant.Quota{MaxSubscriptions: 1000, MaxNotificationsPerMinute: 60}}
+	router.GET("/observers/v1/quota", func(c *gin.Context) { GetQuotaUsage(c, mockQuotaStorage{}, exchange) })
+
+	var res types.QuotaUsage
+	w := serve(router, http.MethodGet, "/observers/v1/quota", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, types.QuotaUsage{
+		Tenant:                    "exchange",
+		Subscriptions:             42,
+		MaxSubscriptions:          1000,
+		MaxNotificationsPerMinute: 60,
+		NotificationsToday:        100,
+		ThrottledToday:            5,
+	}, res)
+}
+
+func TestSubscribeWebhook(t *testing.T) {
+	publisher := &mockPublisher{}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/v1/observer/subscriptions", func(c *gin.Context) { SubscribeWebhook(c, publisher, nil, "exchange") })
+	router.DELETE("/v1/observer/subscriptions", func(c *gin.Context) { UnsubscribeWebhook(c, publisher, "exchange") })
+
+	req := types.WebhookSubscriptionRequest{Subscriptions: types.Subscriptions{"60": {"0xa", "0xb"}}, Webhook: "https://example.com/hook", TTL: 3600}
+	w := serve(router, http.MethodPost, "/v1/observer/subscriptions", "", req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	w = serve(router, http.MethodDelete, "/v1/observer/subscriptions", "", req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	if assert.Len(t, publisher.published, 2) {
+		added := publisher.published[0]
+		assert.Equal(t, subscriber.AddSubscription, added.Operation)
+		assert.Equal(t, &types.Channel{Provider: types.ChannelWebhook, Token: "https://example.com/hook"}, added.Channel)
+		assert.Equal(t, int64(3600), added.TTL)
+		assert.Equal(t, "exchange", added.Tenant)
+		assert.Equal(t, subscriber.DeleteSubscription, publisher.published[1].Operation)
+	}
+
+	for _, invalid := range []types.WebhookSubscriptionRequest{
+		{Subscriptions: req.Subscriptions, Webhook: "http://example.com/hook"},
+		{Subscriptions: req.Subscriptions},
+		{Webhook: req.Webhook},
+	} {
+		w = serve(router, http.MethodPost, "/v1/observer/subscriptions", "", invalid)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+	assert.Len(t, publisher.published, 2)
+}
@@ -0,0 +1,38 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/classifier"
+)
+
+// @Summary Get Account Summary
+// @ID account_summary
+// @Description Get the class (exchange, contract, miner, wallet) and the latest activity of the address
+// @Accept json
+// @Produce json
+// @Tags Transactions
+// @Param coin path string true "the coin name" default(ethereum)
+// @Param address path string true "the query address" default(0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB)
+// @Success 200 {object} types.AccountSummary
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v2/{coin}/summary/{address} [get]
+func GetAccountSummary(c *gin.Context, txAPI blockatlas.TxAPI) {
+	address := c.Param("address")
+	if address == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(blockatlas.ErrInvalidAddr))
+		return
+	}
+	txs, err := txAPI.GetTxsByAddress(address)
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
+		return
+	}
+	// Addresses without history are summarized as unknown
+	summary := classifier.Summarize(txAPI.Coin().ID, address, types.Txs(txs).FilterUniqueID())
+	c.JSON(http.StatusOK, &summary)
+}
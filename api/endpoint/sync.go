@@ -0,0 +1,179 @@
+package endpoint
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type (
+	// SyncAPIs are the services of a coin read by the sync of an address, nil when the coin lacks one
+	SyncAPIs struct {
+		Txs    blockatlas.TxAPI
+		Tokens blockatlas.TokensAPI
+		Stake  blockatlas.StakeAPI
+		Blocks blockatlas.BlockAPI
+	}
+
+	// SyncResponse holds the changes of an address since a block. Block is the since_block of the next sync.
+	// Tokens are the tokens transferred since the block with their balance, "0" for the ones no longer held,
+	// and Delegations the delegations of the address after a staking transaction, omitted without one.
+	// Truncated is set when the history of the upstream may not reach back to since_block, the client then
+	// pulls the full history.
+	SyncResponse struct {
+		SinceBlock  uint64                 `json:"since_block"`
+		Block       uint64                 `json:"block"`
+		Txs         []types.Tx             `json:"txs"`
+		Tokens      []types.Token          `json:"tokens,omitempty"`
+		Delegations *types.DelegationsPage `json:"delegations,omitempty"`
+		Truncated   bool                   `json:"truncated,omitempty"`
+	}
+)
+
+// @Summary Get changes of an address
+// @ID sync_v3
+// @Description Get the transactions, token balances and staking delegations of the address changed since the block,
+// @Description the compact alternative to pulling the full history on every sync
+// @Produce json
+// @Tags Transactions
+// @Param coin path string true "the coin name" default(ethereum)
+// @Param address path string true "the query address" default(0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB)
+// @Param since_block query int false "Block of the previous sync, 0 for everything"
+// @Success 200 {object} endpoint.SyncResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v3/{coin}/sync/{address} [get]
+func GetSync(c *gin.Context, apis SyncAPIs) {
+	address := c.Param("address")
+	if address == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(blockatlas.ErrInvalidAddr))
+		return
+	}
+	var since uint64
+	if value := c.Query("since_block"); value != "" {
+		var err error
+		if since, err = strconv.ParseUint(value, 10, 64); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid since_block", errors.Params{"since_block": value})))
+			return
+		}
+	}
+
+	txs, err := apis.Txs.GetTxsByAddress(address)
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
+		return
+	}
+	all := types.Txs(txs).FilterUniqueID().SortByBlock()
+	response := SyncResponse{SinceBlock: since, Block: since, Txs: make([]types.Tx, 0)}
+	for _, tx := range all {
+		if tx.Block != 0 && tx.Block <= since {
+			continue
+		}
+		tx.Direction = tx.GetTransactionDirection(address)
+		response.Txs = append(response.Txs, tx)
+		if tx.Block > response.Block {
+			response.Block = tx.Block
+		}
+	}
+	// A full page of newer transactions may hide older ones still after since_block
+	if len(all) >= types.TxPerPage && len(all) == len(response.Txs) && since > 0 {
+		response.Truncated = true
+	}
+	if apis.Blocks != nil {
+		if height, err := apis.Blocks.CurrentBlockNumber(); err == nil && uint64(height) > response.Block {
+			response.Block = uint64(height)
+		}
+	}
+
+	if transferred := transferredTokens(response.Txs); len(transferred) > 0 && apis.Tokens != nil {
+		tokens, err := apis.Tokens.GetTokenListByAddress(address)
+		if err != nil && !isEmptyResult(err) {
+			renderError(c, err)
+			return
+		}
+		response.Tokens = changedTokens(transferred, tokens)
+	}
+	if hasStakingChange(response.Txs) && apis.Stake != nil {
+		delegations, err := apis.Stake.GetDelegations(address)
+		if err != nil && !isEmptyResult(err) {
+			renderError(c, err)
+			return
+		}
+		if delegations == nil {
+			delegations = make(types.DelegationsPage, 0)
+		}
+		response.Delegations = &delegations
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// transferredTokens returns the tokens of the token transfers by ID, as reported by the transactions
+func transferredTokens(txs []types.Tx) map[string]types.Token {
+	tokens := make(map[string]types.Token)
+	for _, tx := range txs {
+		var token types.Token
+		switch meta := tx.Meta.(type) {
+		case types.TokenTransfer:
+			token = types.Token{Name: meta.Name, Symbol: meta.Symbol, TokenID: meta.TokenID, Decimals: meta.Decimals}
+		case *types.TokenTransfer:
+			token = types.Token{Name: meta.Name, Symbol: meta.Symbol, TokenID: meta.TokenID, Decimals: meta.Decimals}
+		case types.NativeTokenTransfer:
+			token = types.Token{Name: meta.Name, Symbol: meta.Symbol, TokenID: meta.TokenID, Decimals: meta.Decimals}
+		case *types.NativeTokenTransfer:
+			token = types.Token{Name: meta.Name, Symbol: meta.Symbol, TokenID: meta.TokenID, Decimals: meta.Decimals}
+		default:
+			continue
+		}
+		if token.TokenID == "" {
+			continue
+		}
+		token.Coin = tx.Coin
+		tokens[strings.ToLower(token.TokenID)] = token
+	}
+	return tokens
+}
+
+// changedTokens returns the held tokens among the transferred ones, the others with a 0 balance
+func changedTokens(transferred map[string]types.Token, held []types.Token) []types.Token {
+	result := make(types.TokenPage, 0, len(transferred))
+	for _, token := range held {
+		id := strings.ToLower(token.TokenID)
+		if _, ok := transferred[id]; ok {
+			result = append(result, token)
+			delete(transferred, id)
+		}
+	}
+	for _, token := range transferred {
+		token.Balance = "0"
+		result = append(result, token)
+	}
+	return result.SortByID()
+}
+
+func hasStakingChange(txs []types.Tx) bool {
+	for _, tx := range txs {
+		if tx.Event != nil && (tx.Event.Type == types.EventRewardClaimed || tx.Event.Type == types.EventUnbondingComplete) {
+			return true
+		}
+		var action types.AnyAction
+		switch meta := tx.Meta.(type) {
+		case types.AnyAction:
+			action = meta
+		case *types.AnyAction:
+			action = *meta
+		default:
+			continue
+		}
+		switch action.Key {
+		case types.KeyStakeDelegate, types.KeyStakeClaimRewards:
+			return true
+		}
+	}
+	return false
+}
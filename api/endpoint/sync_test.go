@@ -0,0 +1,101 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type tokensAPIMock struct {
+	tokens []types.Token
+}
+
+func (m tokensAPIMock) Coin() coin.Coin { return coin.Ethereum() }
+
+func (m tokensAPIMock) GetTokenListByAddress(address string) (types.TokenPage, error) {
+	return m.tokens, nil
+}
+
+type stakeAPIMock struct {
+	blockatlas.StakeAPI
+	delegations types.DelegationsPage
+}
+
+func (m stakeAPIMock) GetDelegations(address string) (types.DelegationsPage, error) {
+	return m.delegations, nil
+}
+
+func syncRouter(apis SyncAPIs) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v3/ethereum/sync/:address", func(c *gin.Context) { GetSync(c, apis) })
+	return router
+}
+
+func TestGetSync(t *testing.T) {
+	txs := []types.Tx{
+		{ID: "pending", Coin: coin.ETH, From: "0xa", To: "0xb", Fee: "1", Type: types.TxTransfer, Meta: types.Transfer{Value: "1"}},
+		{ID: "token", Coin: coin.ETH, From: "0xb", To: "0xa", Fee: "1", Block: 12, Type: types.TxTokenTransfer,
+			Meta: types.TokenTransfer{Symbol: "DAI", TokenID: "0xDai", Decimals: 18, Value: "1"}},
+		{ID: "sold", Coin: coin.ETH, From: "0xa", To: "0xb", Fee: "1", Block: 11, Type: types.TxTokenTransfer,
+			Meta: &types.TokenTransfer{Symbol: "USDC", TokenID: "0xusdc", Decimals: 6, Value: "5"}},
+		{ID: "old", Coin: coin.ETH, From: "0xa", To: "0xb", Fee: "1", Block: 10, Type: types.TxAnyAction,
+			Meta: types.AnyAction{Key: types.KeyStakeDelegate, Value: "1"}},
+	}
+	apis := SyncAPIs{
+		Txs:    txAPIMock{txs: txs},
+		Tokens: tokensAPIMock{tokens: []types.Token{{Symbol: "DAI", TokenID: "0xdai", Coin: coin.ETH, Balance: "3"}, {Symbol: "UNI", TokenID: "0xuni"}}},
+		Stake:  stakeAPIMock{},
+	}
+
+	w := serve(syncRouter(apis), http.MethodGet, "/v3/ethereum/sync/0xa?since_block=10", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response SyncResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint64(10), response.SinceBlock)
+	assert.Equal(t, uint64(12), response.Block)
+	if assert.Len(t, response.Txs, 3) {
+		assert.Equal(t, "pending", response.Txs[0].ID)
+		assert.Equal(t, types.DirectionOutgoing, response.Txs[0].Direction)
+		assert.Equal(t, "token", response.Txs[1].ID)
+		assert.Equal(t, "sold", response.Txs[2].ID)
+	}
+	assert.Equal(t, []types.Token{
+		{Symbol: "DAI", TokenID: "0xdai", Coin: coin.ETH, Balance: "3"},
+		{Symbol: "USDC", TokenID: "0xusdc", Decimals: 6, Coin: coin.ETH, Balance: "0"},
+	}, response.Tokens)
+	assert.Nil(t, response.Delegations)
+	assert.False(t, response.Truncated)
+
+	w = serve(syncRouter(apis), http.MethodGet, "/v3/ethereum/sync/0xa", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	response = SyncResponse{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Txs, 4)
+	if assert.NotNil(t, response.Delegations) {
+		assert.Empty(t, *response.Delegations)
+	}
+
+	w = serve(syncRouter(apis), http.MethodGet, "/v3/ethereum/sync/0xa?since_block=latest", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetSync_Truncated(t *testing.T) {
+	txs := make([]types.Tx, 0, types.TxPerPage)
+	for i := 0; i < types.TxPerPage; i++ {
+		txs = append(txs, types.Tx{ID: string(rune('a' + i)), Block: uint64(100 + i), Fee: "1", Type: types.TxTransfer, Meta: types.Transfer{Value: "1"}})
+	}
+	w := serve(syncRouter(SyncAPIs{Txs: txAPIMock{txs: txs}}), http.MethodGet, "/v3/ethereum/sync/0xa?since_block=50", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response SyncResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Truncated)
+	assert.Equal(t, uint64(100+types.TxPerPage-1), response.Block)
+	assert.Empty(t, response.Tokens)
+}
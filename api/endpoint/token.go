@@ -3,6 +3,7 @@ package endpoint
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
 	"net/http"
 	"strconv"
 	"sync"
@@ -11,28 +12,70 @@ import (
 
 // @Summary Get Tokens
 // @ID tokens
-// @Description Get tokens from the address
+// @Description Get the tokens held by the address, with their balance in the smallest unit when the provider reports it
 // @Accept json
 // @Produce json
 // @Tags Transactions
 // @Param coin path string true "the coin name" default(ethereum)
 // @Param address path string true "the query address" default(0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB)
-// @Success 200 {object} blockatlas.CollectionPage
+// @Param cursor query string false "The next_page of the previous page"
+// @Param limit query int false "Tokens per page, 100 by default"
+// @Success 200 {object} blockatlas.PageResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /v2/{coin}/tokens/{address} [get]
 func GetTokensByAddress(c *gin.Context, tokenAPI blockatlas.TokensAPI) {
 	address := c.Param("address")
 	if address == "" {
-		c.JSON(http.StatusOK, blockatlas.TxPage{})
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(blockatlas.ErrInvalidAddr))
 		return
 	}
 
-	result, err := tokenAPI.GetTokenListByAddress(address)
+	cursor, ok := bindPage(c, blockatlas.DefaultPageLimit)
+	if !ok {
+		return
+	}
+
+	result, err := TokenList(address, tokenAPI)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		renderError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, blockatlas.DocsResponse{Docs: &result})
+	start, end, next := cursor.Bounds(len(result))
+	c.JSON(http.StatusOK, pageResponse(result[start:end], end-start, next))
+}
+
+// @Summary Get Address Tokens
+// @ID address_tokens
+// @Description Get the tokens held by the address with their symbol, name, decimals, contract and balance, the same page as /v2/{coin}/tokens/{address}
+// @Accept json
+// @Produce json
+// @Tags Transactions
+// @Param coin path string true "the coin name" default(ethereum)
+// @Param address path string true "the query address" default(0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB)
+// @Param cursor query string false "The next_page of the previous page"
+// @Param limit query int false "Tokens per page, 100 by default"
+// @Success 200 {object} blockatlas.PageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v1/{coin}/address/{address}/tokens [get]
+func GetAddressTokens(c *gin.Context, tokenAPI blockatlas.TokensAPI) {
+	GetTokensByAddress(c, tokenAPI)
+}
+
+// TokenList returns the tokens of the address sorted by ID, served by the REST and the gRPC APIs
+func TokenList(address string, tokenAPI blockatlas.TokensAPI) (types.TokenPage, error) {
+	result, err := tokenAPI.GetTokenListByAddress(address)
+	if err != nil && !isEmptyResult(err) {
+		return nil, err
+	}
+	if result == nil {
+		result = make(types.TokenPage, 0)
+	}
+	result.SortByID()
+	return result, nil
 }
 
 // @Description Get tokens
@@ -42,6 +85,7 @@ func GetTokensByAddress(c *gin.Context, tokenAPI blockatlas.TokensAPI) {
 // @Produce json
 // @Tags Transactions
 // @Param data body string true "Payload" default({"60": ["0xb3624367b1ab37daef42e1a3a2ced012359659b0"]})
+// @Param stream query bool false "Write the tokens as newline-delimited JSON"
 // @Success 200 {object} blockatlas.ResultsResponse
 // @Router /v2/tokens [post]
 func GetTokens(c *gin.Context, apis map[uint]blockatlas.TokensAPI) {
@@ -50,8 +94,10 @@ func GetTokens(c *gin.Context, apis map[uint]blockatlas.TokensAPI) {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	stream := newStreamWriter(c)
 	result := make(blockatlas.TokenPage, 0)
-	for coinStr, addresses := range query {
+	for _, coinStr := range types.SortedKeys(query) {
+		addresses := query[coinStr]
 		coinNum, err := strconv.ParseUint(coinStr, 10, 32)
 		if err != nil {
 			continue
@@ -62,8 +108,19 @@ func GetTokens(c *gin.Context, apis map[uint]blockatlas.TokensAPI) {
 		}
 
 		tokens := getTokens(api, addresses)
+		if stream != nil {
+			for _, token := range tokens {
+				if !stream.Write(token) {
+					return
+				}
+			}
+			continue
+		}
 		result = append(result, tokens...)
 	}
+	if stream != nil {
+		return
+	}
 	c.JSON(http.StatusOK, blockatlas.ResultsResponse{Total: len(result), Results: &result})
 }
 
@@ -111,5 +168,5 @@ func getTokens(tokenAPI blockatlas.TokensAPI, addresses []string) blockatlas.Tok
 		result = append(result, page...)
 	}
 
-	return result
+	return result.SortByID()
 }
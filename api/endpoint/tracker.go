@@ -0,0 +1,60 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+type (
+	// Tracker returns the last block parsed by the observer, by coin handle
+	Tracker interface {
+		GetLastParsedBlockNumber(coin string, ctx context.Context) (int64, error)
+	}
+
+	// BlockNumber is the chain head of a coin with the last block parsed by the observer. Lag is the
+	// number of blocks between them, the confirmations the observer waits for included.
+	BlockNumber struct {
+		Coin       uint  `json:"coin"`
+		Current    int64 `json:"current"`
+		LastParsed int64 `json:"last_parsed"`
+		Lag        int64 `json:"lag"`
+	}
+)
+
+// @Summary Get block number
+// @ID block_number
+// @Description Get the current block of the chain with the last block parsed by the observer, to monitor its lag
+// @Produce json
+// @Tags Observer
+// @Param coin path string true "the coin name" default(ethereum)
+// @Success 200 {object} endpoint.BlockNumber
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v1/block-number/{coin} [get]
+func GetBlockNumber(c *gin.Context, apis map[string]blockatlas.BlockAPI, tracker Tracker) {
+	api, ok := apis[c.Param("coin")]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, errorResponse(errors.E("unknown coin")))
+		return
+	}
+	current, err := api.CurrentBlockNumber()
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	lastParsed, err := tracker.GetLastParsedBlockNumber(api.Coin().Handle, c.Request.Context())
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	lag := current - lastParsed
+	if lag < 0 {
+		lag = 0
+	}
+	c.JSON(http.StatusOK, BlockNumber{Coin: api.Coin().ID, Current: current, LastParsed: lastParsed, Lag: lag})
+}
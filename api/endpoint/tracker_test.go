@@ -0,0 +1,36 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type trackerMock map[string]int64
+
+func (m trackerMock) GetLastParsedBlockNumber(coin string, ctx context.Context) (int64, error) {
+	return m[coin], nil
+}
+
+func TestGetBlockNumber(t *testing.T) {
+	apis := map[string]blockatlas.BlockAPI{"ethereum": mockBlockAPI{blocks: map[int64]*types.Block{1: {}, 2: {}, 3: {}, 4: {}, 5: {}}}}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/block-number/:coin", func(c *gin.Context) { GetBlockNumber(c, apis, trackerMock{"ethereum": 2}) })
+
+	w := serve(router, http.MethodGet, "/v1/block-number/ethereum", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response BlockNumber
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, BlockNumber{Coin: coin.ETH, Current: 5, LastParsed: 2, Lag: 3}, response)
+
+	w = serve(router, http.MethodGet, "/v1/block-number/bitcoin", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
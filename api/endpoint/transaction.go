@@ -6,7 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
-	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
 )
 
 // @Summary Get Transactions
@@ -17,80 +17,71 @@ import (
 // @Tags Transactions
 // @Param coin path string true "the coin name" default(tezos)
 // @Param address path string true "the query address" default(tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q)
+// @Param token query string false "Only the transfers of the token contract, e.g. an ERC-20, BEP-20 or TRC-20"
+// @Param include_notes query bool false "Merge the notes of the X-API-Key owner"
+// @Param stream query bool false "Write the transactions as newline-delimited JSON"
+// @Param cursor query string false "The next_page of the previous page"
+// @Param limit query int false "Transactions per page, 25 by default"
+// @Param wait query string false "Hold the v2 request until a new transaction of the address or the timeout, e.g. 30s"
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /v1/{coin}/{address} [get]
+// @Failure 501 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /v2/{coin}/transactions/{address} [get]
-func GetTransactionsHistory(c *gin.Context, txAPI blockatlas.TxAPI, tokenTxAPI blockatlas.TokenTxAPI) {
+func GetTransactionsHistory(c *gin.Context, txAPI blockatlas.TxAPI, tokenTxAPI blockatlas.TokenTxAPI, notes *TxNotes) {
 	address := c.Param("address")
 	if address == "" {
 		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(blockatlas.ErrInvalidAddr))
 		return
 	}
 	token := c.Query("token")
+	cursor, ok := bindPage(c, types.TxPerPage)
+	if !ok {
+		return
+	}
 
+	page, err := TransactionHistory(address, token, txAPI, tokenTxAPI)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+	page, next := cursor.Txs(page)
+	if !attachTxNotes(c, page, notes) {
+		return
+	}
+	renderTxPage(c, page, next)
+}
+
+// TransactionHistory returns the transactions of the address, of the token contract with a token, without
+// duplicates, newest first and with their direction. Served by the REST and the gRPC APIs.
+func TransactionHistory(address, token string, txAPI blockatlas.TxAPI, tokenTxAPI blockatlas.TokenTxAPI) (types.TxPage, error) {
 	var (
 		txs []blockatlas.Tx
 		err error
 	)
-
 	switch {
 	case token == "" && txAPI != nil:
 		txs, err = txAPI.GetTxsByAddress(address)
 	case token != "" && tokenTxAPI != nil:
 		txs, err = tokenTxAPI.GetTokenTxsByAddress(address, token)
 	default:
-		c.AbortWithStatusJSON(
-			http.StatusInternalServerError,
-			errorResponse(errors.E("Failed to find api for that coin")),
-		)
-		return
+		return nil, blockatlas.ErrNotSupported
 	}
-
-	if err != nil {
-		switch err {
-		case blockatlas.ErrInvalidAddr:
-			c.AbortWithStatusJSON(
-				http.StatusBadRequest,
-				errorResponse(blockatlas.ErrInvalidAddr),
-			)
-			return
-		case blockatlas.ErrNotFound:
-			c.AbortWithStatusJSON(
-				http.StatusNotFound,
-				errorResponse(blockatlas.ErrNotFound),
-			)
-			return
-		case blockatlas.ErrSourceConn:
-			c.AbortWithStatusJSON(
-				http.StatusServiceUnavailable,
-				errorResponse(blockatlas.ErrSourceConn),
-			)
-			return
-		default:
-			c.AbortWithStatusJSON(
-				http.StatusInternalServerError,
-				errorResponse(err),
-			)
-			return
-		}
+	if err != nil && !isEmptyResult(err) {
+		return nil, err
 	}
 	var (
 		page        = make(blockatlas.TxPage, 0)
-		filteredTxs = blockatlas.Txs(txs).FilterUniqueID().SortByDate()
+		filteredTxs = types.Txs(txs).FilterUniqueID().SortByBlock()
 	)
 	for _, tx := range filteredTxs {
 		tx.Direction = tx.GetTransactionDirection(address)
 		page = append(page, tx)
 	}
-
 	if token != "" {
 		page = filterTransactionsByToken(token, page)
 	}
-
-	if len(page) > blockatlas.TxPerPage {
-		page = page[0:blockatlas.TxPerPage]
-	}
-	c.JSON(http.StatusOK, &page)
+	return page, nil
 }
 
 // @Summary Get Transactions by XPUB
@@ -101,54 +92,52 @@ func GetTransactionsHistory(c *gin.Context, txAPI blockatlas.TxAPI, tokenTxAPI b
 // @Tags Transactions
 // @Param coin path string true "the coin name" default(bitcoin)
 // @Param xpub path string true "the xpub key" default(zpub6ruK9k6YGm8BRHWvTiQcrEPnFkuRDJhR7mPYzV2LDvjpLa5CuGgrhCYVZjMGcLcFqv9b2WvsFtY2Gb3xq8NVq8qhk9veozrA2W9QaWtihrC)
+// @Param include_notes query bool false "Merge the notes of the X-API-Key owner"
+// @Param stream query bool false "Write the transactions as newline-delimited JSON"
+// @Param cursor query string false "The next_page of the previous page"
+// @Param limit query int false "Transactions per page, 25 by default"
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /v1/{coin}/{address} [get]
+// @Failure 501 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /v2/{coin}/transactions/xpub/{xpub} [get]
-func GetTransactionsByXpub(c *gin.Context, api blockatlas.TxUtxoAPI) {
+func GetTransactionsByXpub(c *gin.Context, api blockatlas.TxUtxoAPI, notes *TxNotes) {
 	xPubKey := c.Param("xpub")
 	if xPubKey == "" {
 		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(blockatlas.ErrInvalidKey))
 		return
 	}
+	cursor, ok := bindPage(c, types.TxPerPage)
+	if !ok {
+		return
+	}
 
 	txs, err := api.GetTxsByXpub(xPubKey)
-	if err != nil {
-		switch err {
-		case blockatlas.ErrInvalidKey:
-			c.AbortWithStatusJSON(
-				http.StatusBadRequest,
-				errorResponse(blockatlas.ErrInvalidKey),
-			)
-			return
-		case blockatlas.ErrNotFound:
-			c.AbortWithStatusJSON(
-				http.StatusNotFound,
-				errorResponse(blockatlas.ErrNotFound),
-			)
-			return
-		case blockatlas.ErrSourceConn:
-			c.AbortWithStatusJSON(
-				http.StatusServiceUnavailable,
-				errorResponse(blockatlas.ErrSourceConn),
-			)
-			return
-		default:
-			c.AbortWithStatusJSON(
-				http.StatusInternalServerError,
-				errorResponse(err),
-			)
-			return
-		}
+	if err != nil && !isEmptyResult(err) {
+		renderError(c, err)
+		return
 	}
-	var (
-		filteredTxs = blockatlas.Txs(txs).FilterUniqueID().SortByDate()
-		page        = blockatlas.TxPage(filteredTxs)
-	)
+	filteredTxs := types.Txs(txs).FilterUniqueID().SortByBlock()
+	page, next := cursor.Txs(types.TxPage(filteredTxs))
+	if !attachTxNotes(c, page, notes) {
+		return
+	}
+	renderTxPage(c, page, next)
+}
 
-	if len(page) > blockatlas.TxPerPage {
-		page = page[0:blockatlas.TxPerPage]
+// renderTxPage writes the page with the cursor of the next one, one transaction per line with ?stream=true
+func renderTxPage(c *gin.Context, page types.TxPage, next string) {
+	setNextPage(c, next)
+	stream := newStreamWriter(c)
+	if stream == nil {
+		c.JSON(http.StatusOK, pageResponse(page, len(page), next))
+		return
+	}
+	for i := range page {
+		if !stream.Write(&page[i]) {
+			return
+		}
 	}
-	c.JSON(http.StatusOK, &page)
 }
 
 func filterTransactionsByToken(token string, txs blockatlas.TxPage) blockatlas.TxPage {
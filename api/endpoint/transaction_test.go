@@ -2,8 +2,13 @@ package endpoint
 
 import (
 	"encoding/json"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -20,3 +25,96 @@ func Test_filterTransactionsByToken(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, wantedTransactions, string(rawResult))
 }
+
+func Test_renderTxPage(t *testing.T) {
+	var page types.TxPage
+	assert.Nil(t, json.Unmarshal([]byte(beforeTransactions), &page))
+	router := gin.New()
+	router.GET("/txs", func(c *gin.Context) {
+		renderTxPage(c, page, "next")
+	})
+
+	w := serve(router, http.MethodGet, "/txs", "", nil)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"next_page":"next"`)
+
+	w = serve(router, http.MethodGet, "/txs?stream=true", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, contentTypeNDJSON, w.Header().Get("Content-Type"))
+	assert.Equal(t, "next", w.Header().Get(NextPageHeader))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, len(page))
+	var tx types.Tx
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &tx))
+	assert.Equal(t, page[0].ID, tx.ID)
+}
+
+type tokenTxAPIMock struct {
+	txs []types.Tx
+}
+
+func (m tokenTxAPIMock) Coin() coin.Coin { return coin.Ethereum() }
+
+func (m tokenTxAPIMock) GetTokenTxsByAddress(address, token string) (types.TxPage, error) {
+	return m.txs, nil
+}
+
+func TestGetTransactionsHistory_Token(t *testing.T) {
+	txs := []types.Tx{
+		{ID: "1", Block: 2, From: "0xa", Fee: "0", Type: types.TxTokenTransfer, Meta: types.TokenTransfer{TokenID: "0xDAI", From: "0xa", To: "0xb", Value: "1"}},
+		{ID: "2", Block: 1, From: "0xb", Fee: "0", Type: types.TxTokenTransfer, Meta: types.TokenTransfer{TokenID: "0xusdc", From: "0xb", To: "0xa", Value: "2"}},
+	}
+	router := gin.New()
+	router.GET("/v2/ethereum/transactions/:address", func(c *gin.Context) {
+		GetTransactionsHistory(c, txAPIMock{}, tokenTxAPIMock{txs: txs}, nil)
+	})
+	router.GET("/v2/bitcoin/transactions/:address", func(c *gin.Context) {
+		GetTransactionsHistory(c, txAPIMock{}, nil, nil)
+	})
+
+	w := serve(router, http.MethodGet, "/v2/ethereum/transactions/0xa?token=0xdai", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var page struct {
+		Docs []types.Tx `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 1)
+	assert.Equal(t, "1", page.Docs[0].ID)
+	assert.Equal(t, types.DirectionOutgoing, page.Docs[0].Direction)
+
+	w = serve(router, http.MethodGet, "/v2/bitcoin/transactions/0xa?token=0xdai", "", nil)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
+
+func TestGetTransactionsHistory_Page(t *testing.T) {
+	txs := []types.Tx{
+		{ID: "1", Block: 3, Fee: "0", Type: types.TxTransfer, Meta: types.Transfer{Value: "1"}},
+		{ID: "2", Block: 2, Fee: "0", Type: types.TxTransfer, Meta: types.Transfer{Value: "1"}},
+		{ID: "3", Block: 1, Fee: "0", Type: types.TxTransfer, Meta: types.Transfer{Value: "1"}},
+	}
+	router := gin.New()
+	router.GET("/v2/ethereum/transactions/:address", func(c *gin.Context) {
+		GetTransactionsHistory(c, txAPIMock{txs: txs}, nil, nil)
+	})
+	var page struct {
+		Docs     []types.Tx `json:"docs"`
+		NextPage string     `json:"next_page"`
+	}
+
+	w := serve(router, http.MethodGet, "/v2/ethereum/transactions/0xa?limit=2", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 2)
+	assert.NotEmpty(t, page.NextPage)
+
+	w = serve(router, http.MethodGet, "/v2/ethereum/transactions/0xa?cursor="+page.NextPage, "", nil)
+	page.NextPage = ""
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 1)
+	assert.Equal(t, "3", page.Docs[0].ID)
+	assert.Empty(t, page.NextPage)
+
+	w = serve(router, http.MethodGet, "/v2/ethereum/transactions/0xa?cursor=bad", "", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), CodeInvalidCursor)
+}
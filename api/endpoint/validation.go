@@ -0,0 +1,88 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// Codes of the rejected requests, the validation failures are <empty|too_many|invalid>_<field>
+const (
+	CodeInvalidJSON     = "invalid_json"
+	CodeRequestTooLarge = "request_too_large"
+	CodeUnknownProvider = "unknown_provider"
+	CodeInvalidXpub     = "invalid_xpub"
+	CodeInvalidCurrency = "invalid_currency"
+	// CodePricesDisabled rejects the ?currency= of the instances without price source
+	CodePricesDisabled = "prices_disabled"
+)
+
+// MaxRequestSize is the largest body of the validated requests, in bytes
+const MaxRequestSize = 64 << 10
+
+// bindValid decodes the JSON body into obj, rejecting the malformed and the oversized bodies
+// through the errors rendered by middleware.RenderErrors. The binding tags are checked by validate.
+func bindValid(c *gin.Context, obj interface{}) bool {
+	if c.Request.ContentLength > MaxRequestSize {
+		abortTooLarge(c)
+		return false
+	}
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, MaxRequestSize)
+	if err := json.NewDecoder(body).Decode(obj); err != nil {
+		// MaxBytesReader has no error type to check
+		if strings.Contains(err.Error(), "request body too large") {
+			abortTooLarge(c)
+			return false
+		}
+		middleware.AbortWithRequestError(c, http.StatusBadRequest, CodeInvalidJSON, err)
+		return false
+	}
+	return true
+}
+
+func abortTooLarge(c *gin.Context) {
+	middleware.AbortWithRequestError(c, http.StatusRequestEntityTooLarge, CodeRequestTooLarge,
+		errors.E(fmt.Sprintf("request larger than %d bytes", MaxRequestSize)))
+}
+
+// validate checks the binding tags of obj, the first failure is rejected with 422
+func validate(c *gin.Context, obj interface{}) bool {
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return true
+	}
+	code := "invalid_request"
+	if fields, ok := err.(validator.ValidationErrors); ok && len(fields) > 0 {
+		code, err = fieldError(fields[0])
+	}
+	middleware.AbortWithRequestError(c, http.StatusUnprocessableEntity, code, err)
+	return false
+}
+
+// fieldError names the failure of a field: an empty or too long list, or an invalid element
+func fieldError(fe validator.FieldError) (string, error) {
+	field := strings.ToLower(fe.Field())
+	if i := strings.Index(field, "["); i >= 0 {
+		return "invalid_" + field[:i], errors.E(fmt.Sprintf("invalid %s: %v", field[:i], fe.Value()))
+	}
+	switch fe.Tag() {
+	case "required", "min":
+		return "empty_" + field, errors.E(field + " is required")
+	case "max":
+		return "too_many_" + field, errors.E(fmt.Sprintf("too many %s, at most %s", field, fe.Param()))
+	default:
+		return "invalid_" + field, errors.E("invalid " + field)
+	}
+}
+
+// abortUnknownProvider rejects the request for a provider not served
+func abortUnknownProvider(c *gin.Context, id string) {
+	middleware.AbortWithRequestError(c, http.StatusNotFound, CodeUnknownProvider, errors.E("unknown provider "+id))
+}
@@ -0,0 +1,61 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+func TestLendingValidation(t *testing.T) {
+	apis := map[string]blockatlas.LendingAPI{"compound": accountsLendingAPI{}}
+	router := lendingRouter(apis)
+	router.POST("/v1/lending/account/:provider", func(c *gin.Context) { ServeAccount(c, apis, nil) })
+
+	tooManyAddresses := make([]string, 101)
+	for i := range tooManyAddresses {
+		tooManyAddresses[i] = address1
+	}
+	for _, tt := range []struct {
+		name, path string
+		body       interface{}
+		status     int
+		code       string
+	}{
+		{"empty assets", "/v1/lending/rates", map[string]interface{}{"assets": []string{}}, http.StatusUnprocessableEntity, "empty_assets"},
+		{"no assets", "/v1/lending/rates", map[string]interface{}{}, http.StatusUnprocessableEntity, "empty_assets"},
+		{"blank asset", "/v1/lending/rates", map[string]interface{}{"assets": []string{"DAI", ""}}, http.StatusUnprocessableEntity, "invalid_assets"},
+		{"malformed", "/v1/lending/rates", "{", http.StatusBadRequest, CodeInvalidJSON},
+		{"unknown provider", "/v1/lending/account/maker", map[string]interface{}{"addresses": []string{address1}}, http.StatusNotFound, CodeUnknownProvider},
+		{"invalid address", "/v1/lending/account/compound", map[string]interface{}{"addresses": []string{address1, "0x1"}}, http.StatusUnprocessableEntity, "invalid_addresses"},
+		{"no addresses", "/v1/lending/account/compound", map[string]interface{}{"addresses": []string{}}, http.StatusUnprocessableEntity, "empty_addresses"},
+		{"too many addresses", "/v1/lending/account/compound", map[string]interface{}{"addresses": tooManyAddresses}, http.StatusUnprocessableEntity, "too_many_addresses"},
+		{"malformed wallet", "/v1/lending/account/compound", map[string]interface{}{"addresses": []interface{}{1}}, http.StatusBadRequest, CodeInvalidJSON},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var w *httptest.ResponseRecorder
+			if raw, ok := tt.body.(string); ok {
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(raw)))
+			} else {
+				w = serve(router, http.MethodPost, tt.path, "", tt.body)
+			}
+			assert.Equal(t, tt.status, w.Code)
+			var res ErrorResponse
+			assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+			assert.Equal(t, tt.code, res.Error.Code)
+			assert.NotEmpty(t, res.Error.Message)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	body := `{"assets": ["` + strings.Repeat("A", MaxRequestSize) + `"]}`
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/lending/rates", strings.NewReader(body)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), CodeRequestTooLarge)
+}
@@ -0,0 +1,62 @@
+package endpoint
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/mq"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// WorkersRequest sets the size of the worker pool of the notifier
+type WorkersRequest struct {
+	Size int `json:"size"`
+}
+
+// @Summary Get autoscaling recommendation
+// @ID autoscaling_recommendation
+// @Description Get the depth of the queue of the notifier, the throughput of its workers and the recommended number of workers
+// @Produce json
+// @Tags Observer
+// @Success 200 {object} mq.QueueStats
+// @Router /autoscaling/recommendation [get]
+func GetAutoscalingRecommendation(c *gin.Context, scaler *mq.Autoscaler) {
+	c.JSON(http.StatusOK, scaler.Stats())
+}
+
+// @Summary Get notifier workers
+// @ID admin_workers
+// @Description Get the size of the worker pool of the notifier with its busy workers and the messages handled
+// @Produce json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Success 200 {object} mq.PoolStats
+// @Router /admin/workers [get]
+func GetWorkers(c *gin.Context, pool *mq.WorkerPool) {
+	c.JSON(http.StatusOK, pool.Stats())
+}
+
+// @Summary Resize notifier workers
+// @ID admin_workers_resize
+// @Description Set the size of the worker pool of the notifier until the restart, the messages of the removed workers finish
+// @Accept json
+// @Produce json
+// @Tags Admin
+// @Param X-API-Key header string true "Admin API key"
+// @Param request body endpoint.WorkersRequest true "Number of workers"
+// @Success 200 {object} mq.PoolStats
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/workers [put]
+func ResizeWorkers(c *gin.Context, pool *mq.WorkerPool) {
+	var req WorkersRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Size < 1 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(errors.E("invalid size", errors.Params{"size": req.Size})))
+		return
+	}
+	pool.Resize(req.Size)
+	c.JSON(http.StatusOK, pool.Stats())
+}
@@ -0,0 +1,33 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/mq"
+)
+
+func TestResizeWorkers(t *testing.T) {
+	pool := mq.NewWorkerPool(4)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/workers", func(c *gin.Context) { GetWorkers(c, pool) })
+	router.PUT("/admin/workers", func(c *gin.Context) { ResizeWorkers(c, pool) })
+
+	w := serve(router, http.MethodPut, "/admin/workers", "", WorkersRequest{Size: 12})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stats mq.PoolStats
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, 12, stats.Size)
+
+	w = serve(router, http.MethodPut, "/admin/workers", "", WorkersRequest{Size: 0})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(router, http.MethodGet, "/admin/workers", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, mq.PoolStats{Size: 12}, stats)
+}
@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"encoding/json"
+
+	"github.com/trustwallet/blockatlas/api/grpc/pb"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func toTx(tx types.Tx) *pb.Tx {
+	m := &pb.Tx{
+		Id:        tx.ID,
+		Coin:      uint32(tx.Coin),
+		From:      tx.From,
+		To:        tx.To,
+		Fee:       string(tx.Fee),
+		Date:      tx.Date,
+		Block:     tx.Block,
+		Status:    string(tx.Status),
+		Error:     tx.Error,
+		Sequence:  tx.Sequence,
+		Type:      string(tx.Type),
+		Direction: string(tx.Direction),
+		Memo:      tx.Memo,
+		Inputs:    toTxOutputs(tx.Inputs),
+		Outputs:   toTxOutputs(tx.Outputs),
+	}
+	switch meta := tx.Meta.(type) {
+	case nil:
+	case types.Transfer:
+		m.Metadata = &pb.Tx_Transfer{Transfer: toTransfer(meta)}
+	case *types.Transfer:
+		m.Metadata = &pb.Tx_Transfer{Transfer: toTransfer(*meta)}
+	case types.TokenTransfer:
+		m.Metadata = &pb.Tx_TokenTransfer{TokenTransfer: toTokenTransfer(meta)}
+	case *types.TokenTransfer:
+		m.Metadata = &pb.Tx_TokenTransfer{TokenTransfer: toTokenTransfer(*meta)}
+	case types.NativeTokenTransfer:
+		m.Metadata = &pb.Tx_TokenTransfer{TokenTransfer: toTokenTransfer(types.TokenTransfer(meta))}
+	case *types.NativeTokenTransfer:
+		m.Metadata = &pb.Tx_TokenTransfer{TokenTransfer: toTokenTransfer(types.TokenTransfer(*meta))}
+	default:
+		if raw, err := json.Marshal(meta); err == nil {
+			m.Metadata = &pb.Tx_MetadataJson{MetadataJson: raw}
+		}
+	}
+	return m
+}
+
+func toTxOutputs(outputs []types.TxOutput) []*pb.TxOutput {
+	if len(outputs) == 0 {
+		return nil
+	}
+	result := make([]*pb.TxOutput, 0, len(outputs))
+	for _, o := range outputs {
+		result = append(result, &pb.TxOutput{Address: o.Address, Value: string(o.Value)})
+	}
+	return result
+}
+
+func toTransfer(t types.Transfer) *pb.Transfer {
+	return &pb.Transfer{Value: string(t.Value), Symbol: t.Symbol, Decimals: uint32(t.Decimals)}
+}
+
+func toTokenTransfer(t types.TokenTransfer) *pb.TokenTransfer {
+	return &pb.TokenTransfer{
+		Name:     t.Name,
+		Symbol:   t.Symbol,
+		TokenId:  t.TokenID,
+		Decimals: uint32(t.Decimals),
+		Value:    string(t.Value),
+		From:     t.From,
+		To:       t.To,
+	}
+}
+
+func toTokens(tokens types.TokenPage) *pb.TokensResponse {
+	result := &pb.TokensResponse{Tokens: make([]*pb.Token, 0, len(tokens))}
+	for _, t := range tokens {
+		result.Tokens = append(result.Tokens, &pb.Token{
+			Name:     t.Name,
+			Symbol:   t.Symbol,
+			Decimals: uint32(t.Decimals),
+			TokenId:  t.TokenID,
+			Coin:     uint32(t.Coin),
+			Type:     string(t.Type),
+			Balance:  t.Balance,
+		})
+	}
+	return result
+}
+
+func toDelegations(r types.DelegationResponse) *pb.DelegationsResponse {
+	result := &pb.DelegationsResponse{Address: r.Address, Balance: r.Balance}
+	for _, d := range r.Delegations {
+		delegation := &pb.Delegation{
+			Validator: &pb.Validator{
+				Id:      d.Delegator.ID,
+				Status:  d.Delegator.Status,
+				Name:    d.Delegator.Info.Name,
+				Image:   d.Delegator.Info.Image,
+				Website: d.Delegator.Info.Website,
+			},
+			Value:  d.Value,
+			Status: string(d.Status),
+		}
+		switch meta := d.Metadata.(type) {
+		case types.DelegationMetaDataPending:
+			delegation.AvailableDate = int64(meta.AvailableDate)
+		case *types.DelegationMetaDataPending:
+			delegation.AvailableDate = int64(meta.AvailableDate)
+		}
+		result.Delegations = append(result.Delegations, delegation)
+	}
+	return result
+}
+
+func toLendingProviders(r types.LendingProvidersResponse) *pb.LendingProvidersResponse {
+	result := &pb.LendingProvidersResponse{}
+	for _, p := range r.Providers {
+		provider := &pb.LendingProvider{
+			Id: p.ID,
+			Info: &pb.ProviderInfo{
+				Id:          p.Info.ID,
+				Description: p.Info.Description,
+				Image:       p.Info.Image,
+				Website:     p.Info.Website,
+				RiskTier:    string(p.Info.RiskTier),
+			},
+			Type: string(p.Type),
+		}
+		for _, a := range p.Assets {
+			provider.Assets = append(provider.Assets, &pb.AssetInfo{
+				Symbol:        a.Symbol,
+				Chain:         a.Chain,
+				Description:   a.Description,
+				YieldPeriod:   a.YieldPeriod,
+				MinimumAmount: string(a.MinimumAmount),
+				Decimals:      uint32(a.Decimals),
+				Apy:           a.APY,
+				Status:        string(a.Status),
+				Lockup:        a.Lockup,
+				Tvl:           a.TVL,
+			})
+		}
+		result.Providers = append(result.Providers, provider)
+	}
+	for _, e := range r.Errors {
+		result.Errors = append(result.Errors, &pb.ProviderError{Provider: e.Provider, Error: e.Error, Timeout: e.Timeout})
+	}
+	return result
+}
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BlockAtlasClient is the client API for BlockAtlas service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BlockAtlasClient interface {
+	// GetTransactions streams the transactions of the address newest first, of the token contract with a token:
+	// GET /v2/{coin}/transactions/{address}
+	GetTransactions(ctx context.Context, in *TransactionsRequest, opts ...grpc.CallOption) (BlockAtlas_GetTransactionsClient, error)
+	// GetTokens lists the tokens of the address: GET /v2/{coin}/tokens/{address}
+	GetTokens(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*TokensResponse, error)
+	// GetDelegations lists the delegations of the address: GET /v2/{coin}/staking/delegations/{address}
+	GetDelegations(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*DelegationsResponse, error)
+	// GetLendingProviders lists the lending providers: GET /v1/lending/providers
+	GetLendingProviders(ctx context.Context, in *LendingProvidersRequest, opts ...grpc.CallOption) (*LendingProvidersResponse, error)
+}
+
+type blockAtlasClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockAtlasClient(cc grpc.ClientConnInterface) BlockAtlasClient {
+	return &blockAtlasClient{cc}
+}
+
+func (c *blockAtlasClient) GetTransactions(ctx context.Context, in *TransactionsRequest, opts ...grpc.CallOption) (BlockAtlas_GetTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BlockAtlas_ServiceDesc.Streams[0], "/blockatlas.v1.BlockAtlas/GetTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockAtlasGetTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockAtlas_GetTransactionsClient interface {
+	Recv() (*Tx, error)
+	grpc.ClientStream
+}
+
+type blockAtlasGetTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockAtlasGetTransactionsClient) Recv() (*Tx, error) {
+	m := new(Tx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockAtlasClient) GetTokens(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*TokensResponse, error) {
+	out := new(TokensResponse)
+	err := c.cc.Invoke(ctx, "/blockatlas.v1.BlockAtlas/GetTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockAtlasClient) GetDelegations(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*DelegationsResponse, error) {
+	out := new(DelegationsResponse)
+	err := c.cc.Invoke(ctx, "/blockatlas.v1.BlockAtlas/GetDelegations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockAtlasClient) GetLendingProviders(ctx context.Context, in *LendingProvidersRequest, opts ...grpc.CallOption) (*LendingProvidersResponse, error) {
+	out := new(LendingProvidersResponse)
+	err := c.cc.Invoke(ctx, "/blockatlas.v1.BlockAtlas/GetLendingProviders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlockAtlasServer is the server API for BlockAtlas service.
+// All implementations must embed UnimplementedBlockAtlasServer
+// for forward compatibility
+type BlockAtlasServer interface {
+	// GetTransactions streams the transactions of the address newest first, of the token contract with a token:
+	// GET /v2/{coin}/transactions/{address}
+	GetTransactions(*TransactionsRequest, BlockAtlas_GetTransactionsServer) error
+	// GetTokens lists the tokens of the address: GET /v2/{coin}/tokens/{address}
+	GetTokens(context.Context, *AddressRequest) (*TokensResponse, error)
+	// GetDelegations lists the delegations of the address: GET /v2/{coin}/staking/delegations/{address}
+	GetDelegations(context.Context, *AddressRequest) (*DelegationsResponse, error)
+	// GetLendingProviders lists the lending providers: GET /v1/lending/providers
+	GetLendingProviders(context.Context, *LendingProvidersRequest) (*LendingProvidersResponse, error)
+	mustEmbedUnimplementedBlockAtlasServer()
+}
+
+// UnimplementedBlockAtlasServer must be embedded to have forward compatible implementations.
+type UnimplementedBlockAtlasServer struct {
+}
+
+func (UnimplementedBlockAtlasServer) GetTransactions(*TransactionsRequest, BlockAtlas_GetTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedBlockAtlasServer) GetTokens(context.Context, *AddressRequest) (*TokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokens not implemented")
+}
+func (UnimplementedBlockAtlasServer) GetDelegations(context.Context, *AddressRequest) (*DelegationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDelegations not implemented")
+}
+func (UnimplementedBlockAtlasServer) GetLendingProviders(context.Context, *LendingProvidersRequest) (*LendingProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLendingProviders not implemented")
+}
+func (UnimplementedBlockAtlasServer) mustEmbedUnimplementedBlockAtlasServer() {}
+
+// UnsafeBlockAtlasServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlockAtlasServer will
+// result in compilation errors.
+type UnsafeBlockAtlasServer interface {
+	mustEmbedUnimplementedBlockAtlasServer()
+}
+
+func RegisterBlockAtlasServer(s grpc.ServiceRegistrar, srv BlockAtlasServer) {
+	s.RegisterService(&BlockAtlas_ServiceDesc, srv)
+}
+
+func _BlockAtlas_GetTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockAtlasServer).GetTransactions(m, &blockAtlasGetTransactionsServer{stream})
+}
+
+type BlockAtlas_GetTransactionsServer interface {
+	Send(*Tx) error
+	grpc.ServerStream
+}
+
+type blockAtlasGetTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockAtlasGetTransactionsServer) Send(m *Tx) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlockAtlas_GetTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAtlasServer).GetTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockatlas.v1.BlockAtlas/GetTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAtlasServer).GetTokens(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockAtlas_GetDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAtlasServer).GetDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockatlas.v1.BlockAtlas/GetDelegations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAtlasServer).GetDelegations(ctx, req.(*AddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockAtlas_GetLendingProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LendingProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockAtlasServer).GetLendingProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockatlas.v1.BlockAtlas/GetLendingProviders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockAtlasServer).GetLendingProviders(ctx, req.(*LendingProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BlockAtlas_ServiceDesc is the grpc.ServiceDesc for BlockAtlas service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlockAtlas_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blockatlas.v1.BlockAtlas",
+	HandlerType: (*BlockAtlasServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTokens",
+			Handler:    _BlockAtlas_GetTokens_Handler,
+		},
+		{
+			MethodName: "GetDelegations",
+			Handler:    _BlockAtlas_GetDelegations_Handler,
+		},
+		{
+			MethodName: "GetLendingProviders",
+			Handler:    _BlockAtlas_GetLendingProviders_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetTransactions",
+			Handler:       _BlockAtlas_GetTransactions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "blockatlas.proto",
+}
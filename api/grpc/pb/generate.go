@@ -0,0 +1,5 @@
+// Package pb holds the messages and service stubs generated from blockatlas.proto, regenerated with
+// protoc, protoc-gen-go and protoc-gen-go-grpc after every change of the proto file
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative blockatlas.proto
@@ -0,0 +1,201 @@
+// Package grpc serves the BlockAtlas service of pb/blockatlas.proto to the backend consumers on its own port.
+// The calls are answered by the handlers of the REST endpoints, the stubs are generated in the pb package.
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/trustwallet/blockatlas/api/endpoint"
+	"github.com/trustwallet/blockatlas/api/grpc/pb"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/services/lending"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+)
+
+// Server answers the methods of the BlockAtlas service
+type Server struct {
+	pb.UnimplementedBlockAtlasServer
+	platforms map[string]blockatlas.Platform
+	lending   map[string]blockatlas.LendingAPI
+	cache     *lending.InfoCache
+	// unavailable fails the calls to the platforms failing their start-up check
+	unavailable func(handle string) error
+}
+
+// NewServer serves the platforms by handle and the lending providers by ID, the provider info read from
+// the cache when set
+func NewServer(platforms map[string]blockatlas.Platform, lendingAPIs map[string]blockatlas.LendingAPI, cache *lending.InfoCache) *Server {
+	return &Server{platforms: platforms, lending: lendingAPIs, cache: cache, unavailable: platform.Unavailable}
+}
+
+// GRPCServer returns the gRPC server of the service, recovering and logging the failed calls. The gzip
+// compression is accepted, the messages are limited to the size of the REST request bodies.
+func (s *Server) GRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.MaxRecvMsgSize(endpoint.MaxRequestSize),
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	}, opts...)
+	server := grpc.NewServer(opts...)
+	pb.RegisterBlockAtlasServer(server, s)
+	return server
+}
+
+func (s *Server) GetTransactions(req *pb.TransactionsRequest, stream pb.BlockAtlas_GetTransactionsServer) error {
+	p, err := s.platform(req.Coin)
+	if err != nil {
+		return err
+	}
+	if req.Address == "" {
+		return errorStatus(blockatlas.ErrInvalidAddr)
+	}
+	txAPI, _ := p.(blockatlas.TxAPI)
+	tokenTxAPI, _ := p.(blockatlas.TokenTxAPI)
+	var txs types.TxPage
+	err = await(stream.Context(), func() (err error) {
+		txs, err = endpoint.TransactionHistory(req.Address, req.Token, txAPI, tokenTxAPI)
+		return
+	})
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		if err := stream.Send(toTx(tx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) GetTokens(ctx context.Context, req *pb.AddressRequest) (*pb.TokensResponse, error) {
+	p, err := s.platform(req.Coin)
+	if err != nil {
+		return nil, err
+	}
+	tokenAPI, ok := p.(blockatlas.TokensAPI)
+	if !ok {
+		return nil, errorStatus(blockatlas.ErrNotSupported)
+	}
+	if req.Address == "" {
+		return nil, errorStatus(blockatlas.ErrInvalidAddr)
+	}
+	var tokens types.TokenPage
+	err = await(ctx, func() (err error) {
+		tokens, err = endpoint.TokenList(req.Address, tokenAPI)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toTokens(tokens), nil
+}
+
+func (s *Server) GetDelegations(ctx context.Context, req *pb.AddressRequest) (*pb.DelegationsResponse, error) {
+	p, err := s.platform(req.Coin)
+	if err != nil {
+		return nil, err
+	}
+	stakeAPI, ok := p.(blockatlas.StakeAPI)
+	if !ok {
+		return nil, errorStatus(blockatlas.ErrNotSupported)
+	}
+	var delegations types.DelegationResponse
+	err = await(ctx, func() (err error) {
+		delegations, err = endpoint.Delegations(req.Address, stakeAPI)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDelegations(delegations), nil
+}
+
+func (s *Server) GetLendingProviders(ctx context.Context, req *pb.LendingProvidersRequest) (*pb.LendingProvidersResponse, error) {
+	return toLendingProviders(endpoint.LendingProviders(s.lending, s.cache, ctx)), nil
+}
+
+// platform returns the platform of the coin handle, NotFound for the coins not served by this instance
+func (s *Server) platform(handle string) (blockatlas.Platform, error) {
+	p, ok := s.platforms[handle]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown coin "+handle)
+	}
+	if err := s.unavailable(handle); err != nil {
+		return nil, status.Error(codes.Unavailable, handle+" is unavailable, retry later")
+	}
+	return p, nil
+}
+
+// await runs the platform call until it answers or the call ends, with the deadline or the client gone:
+// the platforms don't take a context, their late answers are dropped
+func await(ctx context.Context, call func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- call()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return errorStatus(err)
+		}
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// errorStatus maps the HTTP status of the platform errors of the REST API to a gRPC code
+func errorStatus(err error) error {
+	code := codes.Internal
+	switch endpoint.ErrorStatus(err) {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusNotImplemented:
+		code = codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	case http.StatusGatewayTimeout:
+		code = codes.DeadlineExceeded
+	}
+	return status.Error(code, err.Error())
+}
+
+func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer recoverCall(info.FullMethod, &err)
+	resp, err = handler(ctx, req)
+	logCall(info.FullMethod, err)
+	return
+}
+
+func streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverCall(info.FullMethod, &err)
+	err = handler(srv, stream)
+	logCall(info.FullMethod, err)
+	return
+}
+
+// recoverCall answers Internal to the calls panicking
+func recoverCall(method string, err *error) {
+	if r := recover(); r != nil {
+		logger.Error("gRPC call panicked", logger.Params{"method": method, "panic": r, "stack": string(debug.Stack())})
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+func logCall(method string, err error) {
+	switch status.Code(err) {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown:
+		logger.Error(err, "gRPC call failed", logger.Params{"method": method})
+	default:
+		logger.Warn("gRPC call failed", logger.Params{"method": method, "code": status.Code(err).String(), "err": err})
+	}
+}
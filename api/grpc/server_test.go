@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/api/grpc/pb"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type platformMock struct{}
+
+func (platformMock) Coin() coin.Coin {
+	return coin.Coins[coin.ETH]
+}
+
+func (platformMock) GetTxsByAddress(address string) (types.TxPage, error) {
+	return types.TxPage{
+		{ID: "0x1", Coin: coin.ETH, From: address, To: "0xb", Block: 1, Type: types.TxTransfer, Meta: types.Transfer{Value: "10", Symbol: "ETH", Decimals: 18}},
+		{ID: "0x2", Coin: coin.ETH, From: "0xb", To: address, Block: 2, Type: types.TxContractCall, Meta: types.ContractCall{Input: "0x", Value: "0"}},
+		{ID: "0x1", Coin: coin.ETH, From: address, To: "0xb", Block: 1},
+	}, nil
+}
+
+func (platformMock) GetTokenListByAddress(address string) (types.TokenPage, error) {
+	if address == "0xslow" {
+		time.Sleep(time.Second)
+	}
+	return types.TokenPage{{Name: "Dai", Symbol: "DAI", Decimals: 18, TokenID: "0x6b17", Coin: coin.ETH, Type: types.TokenTypeERC20}}, nil
+}
+
+type lendingMock struct{}
+
+func (lendingMock) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	return types.LendingProvider{ID: "compound", Type: types.ProviderTypeLending, Assets: []types.AssetInfo{{Symbol: "DAI", APY: 2.5}}}, nil
+}
+
+func (lendingMock) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	return types.LendingRates{}, nil
+}
+
+func (lendingMock) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	return &[]types.AccountLendingContracts{}, nil
+}
+
+func newTestClient(t *testing.T, unavailable error) (pb.BlockAtlasClient, func()) {
+	s := NewServer(map[string]blockatlas.Platform{"ethereum": platformMock{}}, map[string]blockatlas.LendingAPI{"compound": lendingMock{}}, nil)
+	s.unavailable = func(handle string) error { return unavailable }
+	server := s.GRPCServer()
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	assert.Nil(t, err)
+	return pb.NewBlockAtlasClient(conn), func() {
+		_ = conn.Close()
+		server.Stop()
+	}
+}
+
+func TestServer_GetTransactions(t *testing.T) {
+	client, stop := newTestClient(t, nil)
+	defer stop()
+
+	stream, err := client.GetTransactions(context.Background(), &pb.TransactionsRequest{Coin: "ethereum", Address: "0xa"})
+	assert.Nil(t, err)
+	txs := make([]*pb.Tx, 0)
+	for {
+		tx, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		txs = append(txs, tx)
+	}
+	assert.Len(t, txs, 2)
+	assert.Equal(t, "0x2", txs[0].Id)
+	assert.Equal(t, "incoming", txs[0].Direction)
+	assert.Equal(t, []byte(`{"input":"0x","value":"0"}`), txs[0].GetMetadataJson())
+	assert.Equal(t, "0x1", txs[1].Id)
+	assert.Equal(t, "10", txs[1].GetTransfer().Value)
+	assert.Equal(t, "ETH", txs[1].GetTransfer().Symbol)
+
+	stream, err = client.GetTransactions(context.Background(), &pb.TransactionsRequest{Coin: "ethereum", Address: "0xa", Token: "0x6b17"})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	stream, err = client.GetTransactions(context.Background(), &pb.TransactionsRequest{Coin: "bitcoin", Address: "0xa"})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "unknown coin bitcoin", status.Convert(err).Message())
+}
+
+func TestServer_GetTokens(t *testing.T) {
+	client, stop := newTestClient(t, nil)
+	defer stop()
+
+	res, err := client.GetTokens(context.Background(), &pb.AddressRequest{Coin: "ethereum", Address: "0xa"}, grpc.UseCompressor(gzip.Name))
+	assert.Nil(t, err)
+	if assert.Len(t, res.Tokens, 1) {
+		assert.Equal(t, "DAI", res.Tokens[0].Symbol)
+		assert.Equal(t, "0x6b17", res.Tokens[0].TokenId)
+	}
+
+	_, err = client.GetDelegations(context.Background(), &pb.AddressRequest{Coin: "ethereum", Address: "0xa"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	providers, err := client.GetLendingProviders(context.Background(), &pb.LendingProvidersRequest{})
+	assert.Nil(t, err)
+	if assert.Len(t, providers.Providers, 1) {
+		assert.Equal(t, "compound", providers.Providers[0].Id)
+		assert.Equal(t, 2.5, providers.Providers[0].Assets[0].Apy)
+	}
+}
+
+func TestServer_Deadline(t *testing.T) {
+	client, stop := newTestClient(t, nil)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	_, err := client.GetTokens(ctx, &pb.AddressRequest{Coin: "ethereum", Address: "0xslow"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestServer_Unavailable(t *testing.T) {
+	client, stop := newTestClient(t, blockatlas.ErrSourceConn)
+	defer stop()
+
+	_, err := client.GetTokens(context.Background(), &pb.AddressRequest{Coin: "ethereum", Address: "0xa"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "ethereum is unavailable, retry later", status.Convert(err).Message())
+}
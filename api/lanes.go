@@ -0,0 +1,41 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/api/endpoint"
+	"github.com/trustwallet/blockatlas/api/middleware"
+)
+
+// APIKeyHeader identifies the exchange and backend consumers
+const APIKeyHeader = "X-API-Key"
+
+// batchRoutes are the bulk endpoints of RegisterBatchAPI, fanning out to many upstream requests
+var batchRoutes = map[string]bool{
+	"/v2/tokens":                  true,
+	"/v2/staking/delegations":     true,
+	"/v2/staking/list":            true,
+	"/v3/staking/list":            true,
+	"/v3/collectibles/categories": true,
+	"/v4/collectibles/categories": true,
+	"/v1/export/:coin":            true,
+}
+
+// LaneClassifier sends the requests of the batch API keys, the bulk endpoints and the
+// streamed responses to the batch lane, the wallet calls to the interactive one
+func LaneClassifier(batchKeys []string) func(c *gin.Context) middleware.Lane {
+	keys := make(map[string]bool, len(batchKeys))
+	for _, key := range batchKeys {
+		keys[key] = true
+	}
+	return func(c *gin.Context) middleware.Lane {
+		if key := c.GetHeader(APIKeyHeader); key != "" && keys[key] {
+			return middleware.LaneBatch
+		}
+		if batchRoutes[strings.TrimSuffix(c.FullPath(), "/")] || c.Query(endpoint.StreamParam) == "true" {
+			return middleware.LaneBatch
+		}
+		return middleware.LaneInteractive
+	}
+}
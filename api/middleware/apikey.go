@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAPIKey rejects the requests without one of the keys in the header
+func RequireAPIKey(header string, keys []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			allowed[key] = true
+		}
+	}
+	return func(c *gin.Context) {
+		if !allowed[c.GetHeader(header)] {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": gin.H{"message": "invalid API key"}})
+			return
+		}
+		c.Next()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/patrickmn/go-cache"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/errors"
 	"github.com/trustwallet/blockatlas/pkg/logger"
 	"io/ioutil"
@@ -33,18 +34,22 @@ type cacheResponse struct {
 	Status int
 	Header http.Header
 	Data   []byte
+	// Time and Sources are the provenance of the response, served again on the hits
+	Time    time.Time
+	Sources []blockatlas.Source
 }
 
 type cachedWriter struct {
 	gin.ResponseWriter
-	status  int
-	written bool
-	expire  time.Duration
-	key     string
+	status     int
+	written    bool
+	expire     time.Duration
+	key        string
+	provenance *blockatlas.Provenance
 }
 
-func newCachedWriter(expire time.Duration, writer gin.ResponseWriter, key string) *cachedWriter {
-	return &cachedWriter{writer, 0, false, expire, key}
+func newCachedWriter(expire time.Duration, writer gin.ResponseWriter, key string, provenance *blockatlas.Provenance) *cachedWriter {
+	return &cachedWriter{writer, 0, false, expire, key, provenance}
 }
 
 func (w *cachedWriter) WriteHeader(code int) {
@@ -73,6 +78,8 @@ func (w *cachedWriter) Write(data []byte) (int, error) {
 		w.Status(),
 		w.Header(),
 		data,
+		time.Now(),
+		w.provenance.Sources(),
 	}
 	b, err := json.Marshal(val)
 	if err != nil {
@@ -94,6 +101,8 @@ func (w *cachedWriter) WriteString(data string) (n int, err error) {
 		w.Status(),
 		w.Header(),
 		[]byte(data),
+		time.Now(),
+		w.provenance.Sources(),
 	}
 	b, err := json.Marshal(val)
 	if err != nil {
@@ -137,8 +146,10 @@ func (mc *memCache) getCache(key string) (cacheResponse, error) {
 	return result, nil
 }
 
+// generateKey hashes the URL, the body and the Accept header of the request,
+// the responses negotiated in another content type are cached apart
 func generateKey(c *gin.Context) string {
-	url := c.Request.URL.String()
+	url := c.Request.URL.String() + c.GetHeader("Accept")
 	var b []byte
 	if c.Request.Body != nil {
 		b, _ = ioutil.ReadAll(c.Request.Body)
@@ -155,9 +166,10 @@ func CacheMiddleware(expiration time.Duration, handle gin.HandlerFunc) gin.Handl
 		defer c.Next()
 		key := generateKey(c)
 		cacheControlValue := uint(expiration.Seconds())
+		provenance := blockatlas.ProvenanceFrom(c.Request.Context())
 		mc, err := memoryCache.getCache(key)
 		if err != nil || mc.Data == nil {
-			writer := newCachedWriter(expiration, c.Writer, key)
+			writer := newCachedWriter(expiration, c.Writer, key, provenance)
 
 			writer.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", cacheControlValue))
 
@@ -166,8 +178,11 @@ func CacheMiddleware(expiration time.Duration, handle gin.HandlerFunc) gin.Handl
 			if c.IsAborted() {
 				memoryCache.deleteCache(key)
 			}
+			provenance.Cached(blockatlas.CacheMiss, time.Now())
 			return
 		}
+		provenance.Record(mc.Sources...)
+		provenance.Cached(blockatlas.CacheHit, mc.Time)
 
 		c.Writer.WriteHeader(mc.Status)
 		for k, vals := range mc.Header {
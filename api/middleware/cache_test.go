@@ -25,7 +25,7 @@ func TestWrite(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 
-	writer := newCachedWriter(time.Second*3, c.Writer, "mykey")
+	writer := newCachedWriter(time.Second*3, c.Writer, "mykey", nil)
 	c.Writer = writer
 
 	c.Writer.WriteHeader(http.StatusNoContent)
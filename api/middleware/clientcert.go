@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireClientCert accepts the requests with a verified TLS client certificate whose subject common name
+// or one of its DNS names is allowed, any verified certificate with no allowed names
+func RequireClientCert(allowed []string) gin.HandlerFunc {
+	names := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		if name != "" {
+			names[name] = true
+		}
+	}
+	return func(c *gin.Context) {
+		state := c.Request.TLS
+		if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": gin.H{"message": "client certificate required"}})
+			return
+		}
+		if len(names) == 0 {
+			c.Next()
+			return
+		}
+		cert := state.VerifiedChains[0][0]
+		if names[cert.Subject.CommonName] {
+			c.Next()
+			return
+		}
+		for _, name := range cert.DNSNames {
+			if names[name] {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": gin.H{"message": "client certificate not allowed"}})
+	}
+}
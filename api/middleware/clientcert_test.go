@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireClientCert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin", RequireClientCert([]string{"ops", "deploy.internal"}), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/any", RequireClientCert(nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	serve := func(path string, cert *x509.Certificate) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if cert != nil {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, serve("/admin", &x509.Certificate{Subject: pkix.Name{CommonName: "ops"}}))
+	assert.Equal(t, http.StatusOK, serve("/admin", &x509.Certificate{Subject: pkix.Name{CommonName: "ci"}, DNSNames: []string{"deploy.internal"}}))
+	assert.Equal(t, http.StatusForbidden, serve("/admin", &x509.Certificate{Subject: pkix.Name{CommonName: "ci"}}))
+	assert.Equal(t, http.StatusUnauthorized, serve("/admin", nil))
+	assert.Equal(t, http.StatusOK, serve("/any", &x509.Certificate{Subject: pkix.Name{CommonName: "ci"}}))
+}
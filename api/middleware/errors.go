@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestError is a request rejected by a handler, rendered by RenderErrors with its status and
+// machine-readable code
+type RequestError struct {
+	Status int
+	Code   string
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+// AbortWithRequestError stops the handlers with the error, rendered by RenderErrors
+func AbortWithRequestError(c *gin.Context, status int, code string, err error) {
+	_ = c.Error(&RequestError{Status: status, Code: code, Err: err})
+	c.Abort()
+}
+
+// RenderErrors renders the last error of the handlers not written yet as {"error": {"message", "code"}},
+// with the status of a RequestError or 500
+func RenderErrors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+		status, details := http.StatusInternalServerError, gin.H{"message": last.Error()}
+		if e, ok := last.Err.(*RequestError); ok {
+			status = e.Status
+			details["code"] = e.Code
+		}
+		c.JSON(status, gin.H{"error": details})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RenderErrors())
+	router.GET("/rejected", func(c *gin.Context) {
+		AbortWithRequestError(c, http.StatusUnprocessableEntity, "empty_assets", errors.New("assets is required"))
+	})
+	router.GET("/failed", func(c *gin.Context) { _ = c.Error(errors.New("boom")) })
+	router.GET("/written", func(c *gin.Context) {
+		_ = c.Error(errors.New("logged"))
+		c.String(http.StatusOK, "ok")
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	w := serve("/rejected")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"error": {"message": "assets is required", "code": "empty_assets"}}`, w.Body.String())
+
+	w = serve("/failed")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error": {"message": "boom"}}`, w.Body.String())
+
+	w = serve("/written")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ok", w.Body.String())
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	LaneInteractive Lane = "interactive"
+	LaneBatch       Lane = "batch"
+
+	// LaneKey is the context key of the lane serving the request
+	LaneKey = "lane"
+)
+
+type (
+	// Lane is a class of traffic with its own pool of request workers
+	Lane string
+
+	LaneConfig struct {
+		// Workers is the number of requests of the lane served at once, 0 is unlimited
+		Workers int `mapstructure:"workers"`
+		// QueueTimeout is how long a request waits for a worker before a 503
+		QueueTimeout time.Duration `mapstructure:"queue_timeout"`
+	}
+
+	lanePool struct {
+		workers chan struct{}
+		timeout time.Duration
+	}
+)
+
+// PriorityLanes serves each lane of requests with its own workers, so the fan-out of
+// heavy batch consumers never holds the workers (and upstream requests) of wallet calls
+func PriorityLanes(classify func(c *gin.Context) Lane, lanes map[Lane]LaneConfig) gin.HandlerFunc {
+	pools := make(map[Lane]*lanePool, len(lanes))
+	for lane, config := range lanes {
+		if config.Workers <= 0 {
+			continue
+		}
+		pools[lane] = &lanePool{workers: make(chan struct{}, config.Workers), timeout: config.QueueTimeout}
+	}
+	return func(c *gin.Context) {
+		lane := classify(c)
+		c.Set(LaneKey, lane)
+		c.Header("X-Traffic-Lane", string(lane))
+		pool, ok := pools[lane]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		timer := time.NewTimer(pool.timeout)
+		defer timer.Stop()
+		select {
+		case pool.workers <- struct{}{}:
+			defer func() { <-pool.workers }()
+			c.Next()
+		case <-timer.C:
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error": gin.H{"message": "too many " + string(lane) + " requests, retry later"},
+			})
+		case <-c.Request.Context().Done():
+			c.Abort()
+		}
+	}
+}
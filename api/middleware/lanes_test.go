@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityLanes(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		started = make(chan struct{})
+	)
+	router := gin.New()
+	router.Use(PriorityLanes(func(c *gin.Context) Lane {
+		if c.FullPath() == "/batch" {
+			return LaneBatch
+		}
+		return LaneInteractive
+	}, map[Lane]LaneConfig{
+		LaneBatch:       {Workers: 1, QueueTimeout: time.Millisecond * 20},
+		LaneInteractive: {Workers: 1, QueueTimeout: time.Second},
+	}))
+	router.GET("/batch", func(c *gin.Context) {
+		started <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	})
+	router.GET("/wallet", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	first := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/batch", nil))
+		close(done)
+	}()
+	<-started
+
+	// The batch lane is full, the interactive one is not
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/batch", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/wallet", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "interactive", w.Header().Get("X-Traffic-Lane"))
+
+	close(release)
+	<-done
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "batch", first.Header().Get("X-Traffic-Lane"))
+}
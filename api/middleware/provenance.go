@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	// ProvenanceQuery asks for the sources of a response
+	ProvenanceQuery = "provenance"
+	// ProvenanceHeader carries the sources of the responses which are not a JSON object
+	ProvenanceHeader = "X-Provenance"
+)
+
+type (
+	// SourceResolver returns the section of the response of a request and the URL of the upstream serving it,
+	// empty for the routes without a platform
+	SourceResolver func(c *gin.Context) (section, upstream string)
+
+	// ProvenanceResponse lists the sources of the sections of a response
+	ProvenanceResponse struct {
+		Sections []blockatlas.Source `json:"sections"`
+	}
+
+	bufferedWriter struct {
+		gin.ResponseWriter
+		body bytes.Buffer
+	}
+)
+
+// Provenance annotates the responses of the requests with ?provenance=true with the upstream, fetch time and
+// cache status of their sections, added to the JSON objects as "provenance" and in the X-Provenance header
+// otherwise. The query is removed before the handlers, the API cache serves these requests like the others.
+// The upstream requests made without the request context are attributed to the upstream of the resolver.
+func Provenance(resolve SourceResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+		if query.Get(ProvenanceQuery) != "true" {
+			c.Next()
+			return
+		}
+		query.Del(ProvenanceQuery)
+		c.Request.URL.RawQuery = query.Encode()
+
+		start := time.Now()
+		ctx, provenance := blockatlas.WithProvenance(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		section, upstream := resolve(c)
+		sources := provenance.Sources()
+		if len(sources) == 0 && upstream != "" {
+			sources = []blockatlas.Source{{FetchedAt: start}}
+		}
+		for i := range sources {
+			if sources[i].Section == "" {
+				sources[i].Section = section
+			}
+			if sources[i].Upstream == "" && upstream != "" {
+				source := blockatlas.UpstreamSource(upstream)
+				sources[i].Upstream, sources[i].Node = source.Upstream, source.Node
+			}
+			if sources[i].Cache == "" {
+				sources[i].Cache = blockatlas.CacheNone
+			}
+		}
+		if sources == nil {
+			sources = []blockatlas.Source{}
+		}
+
+		body := writer.body.Bytes()
+		annotation, err := json.Marshal(ProvenanceResponse{Sections: sources})
+		if err != nil {
+			logger.Error(err, "Failed to marshal provenance")
+		} else if annotated, ok := annotate(body, annotation); ok {
+			body = annotated
+			writer.Header().Del("Content-Length")
+		} else {
+			writer.Header().Set(ProvenanceHeader, string(annotation))
+		}
+		if len(body) == 0 {
+			writer.ResponseWriter.WriteHeaderNow()
+			return
+		}
+		if _, err := writer.ResponseWriter.Write(body); err != nil {
+			logger.Error(err, "Failed to write response")
+		}
+	}
+}
+
+// annotate adds the provenance as the last field of a JSON object
+func annotate(body, provenance []byte) ([]byte, bool) {
+	body = bytes.TrimRight(body, " \t\r\n")
+	if len(body) < 2 || body[0] != '{' || body[len(body)-1] != '}' {
+		return nil, false
+	}
+	var out bytes.Buffer
+	out.Write(body[:len(body)-1])
+	if len(bytes.TrimSpace(body[1:len(body)-1])) > 0 {
+		out.WriteByte(',')
+	}
+	out.WriteString(`"provenance":`)
+	out.Write(provenance)
+	out.WriteByte('}')
+	return out.Bytes(), true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// Flush keeps the streamed responses in the buffer, they are written at once with their provenance
+func (w *bufferedWriter) Flush() {}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+func provenanceRouter(handler gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(Provenance(func(c *gin.Context) (string, string) {
+		return "ethereum", "https://node.example.com/api?key=secret"
+	}))
+	router.GET("/v2/ethereum/tokens/:address", handler)
+	return router
+}
+
+func TestProvenance(t *testing.T) {
+	var query string
+	router := provenanceRouter(func(c *gin.Context) {
+		query = c.Request.URL.RawQuery
+		c.JSON(http.StatusOK, gin.H{"total": 1})
+	})
+
+	w := performRequest("GET", "/v2/ethereum/tokens/0x1?limit=5&provenance=true", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "limit=5", query)
+
+	var body struct {
+		Total      int                `json:"total"`
+		Provenance ProvenanceResponse `json:"provenance"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 1, body.Total)
+	if assert.Len(t, body.Provenance.Sections, 1) {
+		s := body.Provenance.Sections[0]
+		assert.Equal(t, "ethereum", s.Section)
+		assert.Equal(t, "node.example.com", s.Upstream)
+		assert.Equal(t, blockatlas.NodeHash("https://node.example.com/api?key=secret"), s.Node)
+		assert.Equal(t, blockatlas.CacheNone, s.Cache)
+		assert.False(t, s.FetchedAt.IsZero())
+	}
+	assert.NotContains(t, w.Body.String(), "secret")
+
+	w = performRequest("GET", "/v2/ethereum/tokens/0x1", router)
+	assert.Equal(t, `{"total":1}`, w.Body.String())
+	assert.Empty(t, w.Header().Get(ProvenanceHeader))
+}
+
+func TestProvenance_Header(t *testing.T) {
+	router := provenanceRouter(func(c *gin.Context) {
+		c.JSON(http.StatusOK, []int{1, 2})
+	})
+
+	w := performRequest("GET", "/v2/ethereum/tokens/0x1?provenance=true", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[1,2]", w.Body.String())
+	var provenance ProvenanceResponse
+	assert.Nil(t, json.Unmarshal([]byte(w.Header().Get(ProvenanceHeader)), &provenance))
+	assert.Len(t, provenance.Sections, 1)
+}
+
+func TestProvenance_Cache(t *testing.T) {
+	var calls int
+	router := provenanceRouter(CacheMiddleware(time.Minute, func(c *gin.Context) {
+		calls++
+		blockatlas.ProvenanceFrom(c.Request.Context()).Record(blockatlas.UpstreamSource("https://explorer.example.com"))
+		c.JSON(http.StatusOK, gin.H{})
+	}))
+
+	sources := func(w *httptest.ResponseRecorder) []blockatlas.Source {
+		var body struct {
+			Provenance ProvenanceResponse `json:"provenance"`
+		}
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Provenance.Sections
+	}
+
+	miss := sources(performRequest("GET", "/v2/ethereum/tokens/0xcache?provenance=true", router))
+	hit := sources(performRequest("GET", "/v2/ethereum/tokens/0xcache?provenance=true", router))
+	assert.Equal(t, 1, calls)
+	if assert.Len(t, miss, 1) && assert.Len(t, hit, 1) {
+		assert.Equal(t, blockatlas.CacheMiss, miss[0].Cache)
+		assert.Equal(t, "explorer.example.com", miss[0].Upstream)
+		assert.Equal(t, blockatlas.CacheHit, hit[0].Cache)
+		assert.Equal(t, "explorer.example.com", hit[0].Upstream)
+		assert.True(t, miss[0].FetchedAt.Equal(hit[0].FetchedAt))
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+		ok   bool
+	}{
+		{"object", `{"a":1}`, `{"a":1,"provenance":{}}`, true},
+		{"empty object", "{}\n", `{"provenance":{}}`, true},
+		{"array", `[1]`, "", false},
+		{"empty", ``, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := annotate([]byte(tt.body), []byte(`{}`))
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
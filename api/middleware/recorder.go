@@ -0,0 +1,273 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+const (
+	DefaultRecordings  = 200
+	DefaultMaxBodySize = 64 << 10
+
+	redacted = "[redacted]"
+)
+
+type (
+	// Exchange is a recorded request with its response, secrets redacted
+	Exchange struct {
+		Time            time.Time     `json:"time"`
+		APIKey          string        `json:"api_key"`
+		Method          string        `json:"method"`
+		URL             string        `json:"url"`
+		Status          int           `json:"status"`
+		Duration        time.Duration `json:"duration"`
+		RequestHeaders  http.Header   `json:"request_headers"`
+		RequestBody     string        `json:"request_body,omitempty"`
+		ResponseHeaders http.Header   `json:"response_headers"`
+		ResponseBody    string        `json:"response_body,omitempty"`
+		Truncated       bool          `json:"truncated,omitempty"`
+
+		key string
+	}
+
+	// Recorder keeps the last exchanges of the API keys opted in to the debug recording
+	Recorder struct {
+		mu        sync.RWMutex
+		keys      map[string]bool
+		exchanges []Exchange
+		next      int
+		maxBody   int
+	}
+
+	recordingWriter struct {
+		gin.ResponseWriter
+		body      bytes.Buffer
+		max       int
+		truncated bool
+	}
+)
+
+// redactedHeaders are never recorded, the names are canonical
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// NewRecorder keeps the last size exchanges of the keys, with the first maxBody bytes of the bodies
+func NewRecorder(size, maxBody int, keys []string) *Recorder {
+	if size <= 0 {
+		size = DefaultRecordings
+	}
+	if maxBody <= 0 {
+		maxBody = DefaultMaxBodySize
+	}
+	r := &Recorder{keys: make(map[string]bool), exchanges: make([]Exchange, 0, size), maxBody: maxBody}
+	for _, key := range keys {
+		r.Enable(key)
+	}
+	return r
+}
+
+// Enable records the exchanges of the API key from now on
+func (r *Recorder) Enable(key string) {
+	if key == "" {
+		return
+	}
+	r.mu.Lock()
+	r.keys[key] = true
+	r.mu.Unlock()
+}
+
+// Disable stops recording the API key, its recorded exchanges are kept
+func (r *Recorder) Disable(key string) {
+	r.mu.Lock()
+	delete(r.keys, key)
+	r.mu.Unlock()
+}
+
+// Enabled tells whether the exchanges of the API key are recorded
+func (r *Recorder) Enabled(key string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.keys[key]
+}
+
+// Exchanges returns the recorded exchanges, of the API key when set, the most recent first
+func (r *Recorder) Exchanges(key string) []Exchange {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]Exchange, 0, len(r.exchanges))
+	for i := 1; i <= len(r.exchanges); i++ {
+		e := r.exchanges[(r.next-i+len(r.exchanges))%len(r.exchanges)]
+		if key == "" || e.key == key {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Clear drops the recorded exchanges
+func (r *Recorder) Clear() {
+	r.mu.Lock()
+	r.exchanges = r.exchanges[:0]
+	r.next = 0
+	r.mu.Unlock()
+}
+
+func (r *Recorder) add(e Exchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.exchanges) < cap(r.exchanges) {
+		r.exchanges = append(r.exchanges, e)
+	} else {
+		r.exchanges[r.next] = e
+	}
+	r.next = (r.next + 1) % cap(r.exchanges)
+}
+
+// RecordRequests records the requests of the API keys enabled in the recorder with their responses,
+// the other requests are served untouched
+func RecordRequests(header string, recorder *Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(header)
+		if key == "" || !recorder.Enabled(key) {
+			c.Next()
+			return
+		}
+
+		e := Exchange{
+			Time:           time.Now(),
+			APIKey:         blockatlas.KeyLabel(key),
+			Method:         c.Request.Method,
+			URL:            redactURL(c.Request.URL),
+			RequestHeaders: redactHeaders(c.Request.Header),
+			key:            key,
+		}
+		if c.Request.Body != nil {
+			raw, err := ioutil.ReadAll(c.Request.Body)
+			c.Request.Body = ioutil.NopCloser(bytes.NewReader(raw))
+			if err == nil {
+				e.RequestBody, e.Truncated = recordBody(raw, recorder.maxBody)
+			}
+		}
+		writer := &recordingWriter{ResponseWriter: c.Writer, max: recorder.maxBody}
+		c.Writer = writer
+
+		c.Next()
+
+		e.Status = writer.Status()
+		e.Duration = time.Since(e.Time)
+		e.ResponseHeaders = redactHeaders(writer.Header())
+		var truncated bool
+		e.ResponseBody, truncated = recordBody(writer.body.Bytes(), recorder.maxBody)
+		e.Truncated = e.Truncated || truncated || writer.truncated
+		recorder.add(e)
+	}
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.capture(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *recordingWriter) capture(data []byte) {
+	left := w.max - w.body.Len()
+	if len(data) > left {
+		data = data[:left]
+		w.truncated = true
+	}
+	w.body.Write(data)
+}
+
+// recordBody redacts the secret string fields of a JSON body, other bodies are kept as is, up to max bytes
+func recordBody(raw []byte, max int) (string, bool) {
+	if len(raw) == 0 {
+		return "", false
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err == nil {
+		if out, err := json.Marshal(redactValue(value)); err == nil {
+			raw = out
+		}
+	}
+	if len(raw) > max {
+		return string(raw[:max]), true
+	}
+	return string(raw), false
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			// Objects under a secret name are kept, like the token of a token transfer
+			if _, ok := field.(string); ok && isSecret(name) {
+				v[name] = redacted
+			} else {
+				v[name] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
+func redactHeaders(headers http.Header) http.Header {
+	result := make(http.Header, len(headers))
+	for name, values := range headers {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			result[name] = []string{redacted}
+			continue
+		}
+		result[name] = append([]string(nil), values...)
+	}
+	return result
+}
+
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	for name := range query {
+		if isSecret(name) {
+			query.Set(name, redacted)
+		}
+	}
+	result := *u
+	result.RawQuery = query.Encode()
+	return result.RequestURI()
+}
+
+// isSecret tells whether a field or parameter holds a credential, by name: the push and channel
+// tokens, API keys, passwords and signatures
+func isSecret(name string) bool {
+	name = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+	switch name {
+	case "token", "apikey", "authorization", "signature", "mnemonic", "seed":
+		return true
+	}
+	for _, suffix := range []string{"secret", "password", "privatekey", "accesstoken", "refreshtoken", "devicetoken"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+func TestRecordRequests(t *testing.T) {
+	recorder := NewRecorder(2, 0, []string{"client-1234"})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RecordRequests("X-API-Key", recorder))
+	router.POST("/v1/subscriptions", func(c *gin.Context) {
+		body, _ := ioutil.ReadAll(c.Request.Body)
+		c.Header("Set-Cookie", "session=1")
+		c.Data(http.StatusCreated, "application/json", body)
+	})
+
+	serve := func(key, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("User-Agent", "wallet")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"token":"device","client_secret":"s","coin":60,"transfer":{"token":{"symbol":"DAI"}}}`
+	w := serve("client-1234", "/v1/subscriptions?api_key=k&coin=60", body)
+	assert.Equal(t, body, w.Body.String())
+	serve("other", "/v1/subscriptions", body)
+
+	exchanges := recorder.Exchanges("")
+	assert.Len(t, exchanges, 1)
+	e := exchanges[0]
+	assert.Equal(t, blockatlas.KeyLabel("client-1234"), e.APIKey)
+	assert.Equal(t, "/v1/subscriptions?api_key=%5Bredacted%5D&coin=60", e.URL)
+	assert.Equal(t, http.StatusCreated, e.Status)
+	assert.Equal(t, []string{redacted}, e.RequestHeaders["X-Api-Key"])
+	assert.Equal(t, []string{redacted}, e.RequestHeaders["Authorization"])
+	assert.Equal(t, []string{"wallet"}, e.RequestHeaders["User-Agent"])
+	assert.Equal(t, []string{redacted}, e.ResponseHeaders["Set-Cookie"])
+	redactedBody := `{"client_secret":"[redacted]","coin":60,"token":"[redacted]","transfer":{"token":{"symbol":"DAI"}}}`
+	assert.Equal(t, redactedBody, e.RequestBody)
+	assert.Equal(t, redactedBody, e.ResponseBody)
+
+	// The oldest exchange is dropped once the buffer is full
+	serve("client-1234", "/v1/subscriptions?coin=1", "plain")
+	serve("client-1234", "/v1/subscriptions?coin=2", "plain")
+	exchanges = recorder.Exchanges("client-1234")
+	assert.Len(t, exchanges, 2)
+	assert.Equal(t, "/v1/subscriptions?coin=2", exchanges[0].URL)
+	assert.Equal(t, "/v1/subscriptions?coin=1", exchanges[1].URL)
+	assert.Equal(t, "plain", exchanges[0].RequestBody)
+	assert.Empty(t, recorder.Exchanges("other"))
+
+	recorder.Disable("client-1234")
+	serve("client-1234", "/v1/subscriptions?coin=3", "plain")
+	assert.Len(t, recorder.Exchanges(""), 2)
+	recorder.Clear()
+	assert.Empty(t, recorder.Exchanges(""))
+}
+
+func TestRecordRequests_Truncated(t *testing.T) {
+	recorder := NewRecorder(1, 4, []string{"client"})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RecordRequests("X-API-Key", recorder))
+	router.GET("/v1/export", func(c *gin.Context) { c.String(http.StatusOK, "123456789") })
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/export", nil)
+	req.Header.Set("X-API-Key", "client")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "123456789", w.Body.String())
+
+	e := recorder.Exchanges("client")[0]
+	assert.Equal(t, "1234", e.ResponseBody)
+	assert.True(t, e.Truncated)
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	// SignatureHeader carries the base64 Ed25519 signature of the response body
+	SignatureHeader = "X-Signature"
+	// SignatureKeyHeader names the key of the signature, for the verifiers rotating keys
+	SignatureKeyHeader = "X-Signature-Key"
+)
+
+// ResponseSigner signs the response bodies with an Ed25519 key
+type ResponseSigner struct {
+	key ed25519.PrivateKey
+	// KeyID is sent with the signatures, the first 8 bytes of the SHA-256 of the public key in hex by default
+	KeyID string
+}
+
+// NewResponseSigner reads the base64 Ed25519 private key, or its 32 bytes seed
+func NewResponseSigner(privateKey, keyID string) (*ResponseSigner, error) {
+	raw, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return nil, errors.E(err, "invalid signing key")
+	}
+	var key ed25519.PrivateKey
+	switch len(raw) {
+	case ed25519.SeedSize:
+		key = ed25519.NewKeyFromSeed(raw)
+	case ed25519.PrivateKeySize:
+		key = ed25519.PrivateKey(raw)
+	default:
+		return nil, errors.E("invalid signing key size", errors.Params{"size": len(raw)})
+	}
+	signer := &ResponseSigner{key: key, KeyID: keyID}
+	if signer.KeyID == "" {
+		sum := sha256.Sum256(signer.PublicKey())
+		signer.KeyID = hex.EncodeToString(sum[:8])
+	}
+	return signer, nil
+}
+
+// PublicKey returns the key verifying the signatures
+func (s *ResponseSigner) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign returns the base64 signature of the body
+func (s *ResponseSigner) Sign(body []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, body))
+}
+
+// VerifyResponse tells if the base64 signature of the X-Signature header is the one of the body
+func VerifyResponse(publicKey ed25519.PublicKey, body []byte, signature string) bool {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(publicKey, body, sig)
+}
+
+// SignResponses adds the detached signature of the body to the responses, for the aggregators verifying them
+// through the caches in between. The body is buffered to be signed, the streamed responses are written at once.
+// The responses without body, or written before the end of the handlers, are not signed.
+func SignResponses(signer *ResponseSigner) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		body := writer.body.Bytes()
+		if len(body) == 0 {
+			writer.ResponseWriter.WriteHeaderNow()
+			return
+		}
+		if !writer.ResponseWriter.Written() {
+			writer.Header().Set(SignatureHeader, signer.Sign(body))
+			writer.Header().Set(SignatureKeyHeader, signer.KeyID)
+		}
+		if _, err := writer.ResponseWriter.Write(body); err != nil {
+			logger.Error(err, "Failed to write response")
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSigningSeed = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
+
+func TestNewResponseSigner(t *testing.T) {
+	signer, err := NewResponseSigner(testSigningSeed, "")
+	assert.Nil(t, err)
+	assert.Len(t, signer.KeyID, 16)
+
+	seed, _ := base64.StdEncoding.DecodeString(testSigningSeed)
+	key := base64.StdEncoding.EncodeToString(ed25519.NewKeyFromSeed(seed))
+	other, err := NewResponseSigner(key, "2020-10")
+	assert.Nil(t, err)
+	assert.Equal(t, "2020-10", other.KeyID)
+	assert.Equal(t, signer.PublicKey(), other.PublicKey())
+
+	_, err = NewResponseSigner("AAEC", "")
+	assert.NotNil(t, err)
+	_, err = NewResponseSigner("not base64", "")
+	assert.NotNil(t, err)
+}
+
+func TestSignResponses(t *testing.T) {
+	signer, err := NewResponseSigner(testSigningSeed, "")
+	assert.Nil(t, err)
+	router := gin.New()
+	router.Use(SignResponses(signer))
+	router.GET("/v2/ethereum/tokens/:address", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"total": 1})
+	})
+	router.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	w := performRequest("GET", "/v2/ethereum/tokens/0x1", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"total":1}`, w.Body.String())
+	assert.Equal(t, signer.KeyID, w.Header().Get(SignatureKeyHeader))
+	signature := w.Header().Get(SignatureHeader)
+	assert.True(t, VerifyResponse(signer.PublicKey(), w.Body.Bytes(), signature))
+	assert.False(t, VerifyResponse(signer.PublicKey(), []byte(`{"total":2}`), signature))
+	assert.False(t, VerifyResponse(signer.PublicKey(), w.Body.Bytes(), "invalid"))
+
+	w = performRequest("GET", "/empty", router)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get(SignatureHeader))
+}
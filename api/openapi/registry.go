@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const contentTypeJSON = "application/json"
+
+type (
+	// Operation describes a registered route, its inputs and its response model
+	Operation struct {
+		Method   string
+		Path     string
+		ID       string
+		Summary  string
+		Tags     []string
+		Query    []Param
+		Headers  []Param
+		Request  interface{}
+		Response interface{}
+		// Produces lists the content types the response is negotiated in besides JSON
+		Produces []string
+	}
+
+	Param struct {
+		Name        string
+		Description string
+		Required    bool
+	}
+
+	// Registry registers gin routes and keeps their descriptors
+	// to build an OpenAPI document matching the served handlers
+	Registry struct {
+		sync.RWMutex
+		operations []Operation
+	}
+
+	basePather interface {
+		BasePath() string
+	}
+)
+
+func NewRegistry() *Registry {
+	return &Registry{operations: make([]Operation, 0)}
+}
+
+// Handle mounts the handlers on the router and records the operation
+func (r *Registry) Handle(router gin.IRouter, op Operation, handlers ...gin.HandlerFunc) {
+	router.Handle(op.Method, op.Path, handlers...)
+	if bp, ok := router.(basePather); ok {
+		op.Path = joinPaths(bp.BasePath(), op.Path)
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.operations = append(r.operations, op)
+}
+
+func (r *Registry) GET(router gin.IRouter, op Operation, handlers ...gin.HandlerFunc) {
+	op.Method = http.MethodGet
+	r.Handle(router, op, handlers...)
+}
+
+func (r *Registry) POST(router gin.IRouter, op Operation, handlers ...gin.HandlerFunc) {
+	op.Method = http.MethodPost
+	r.Handle(router, op, handlers...)
+}
+
+func (r *Registry) PUT(router gin.IRouter, op Operation, handlers ...gin.HandlerFunc) {
+	op.Method = http.MethodPut
+	r.Handle(router, op, handlers...)
+}
+
+func (r *Registry) DELETE(router gin.IRouter, op Operation, handlers ...gin.HandlerFunc) {
+	op.Method = http.MethodDelete
+	r.Handle(router, op, handlers...)
+}
+
+func (r *Registry) Operations() []Operation {
+	r.RLock()
+	defer r.RUnlock()
+	result := make([]Operation, len(r.operations))
+	copy(result, r.operations)
+	return result
+}
+
+// Document generates the OpenAPI 3.0 document for all registered operations
+func (r *Registry) Document(title, version string) Document {
+	builder := newSchemaBuilder()
+	doc := Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]PathItem),
+	}
+
+	operations := r.Operations()
+	sort.SliceStable(operations, func(i, j int) bool {
+		return operations[i].Path < operations[j].Path
+	})
+	for _, op := range operations {
+		path, params := convertPath(op.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(op.Method)] = buildOperation(op, params, builder)
+	}
+	doc.Components = Components{Schemas: builder.components}
+	return doc
+}
+
+// Handler serves the OpenAPI document as JSON
+func (r *Registry) Handler(title, version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, r.Document(title, version))
+	}
+}
+
+func buildOperation(op Operation, pathParams []string, builder *schemaBuilder) *OperationObject {
+	result := &OperationObject{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Tags:        op.Tags,
+		Parameters:  make([]ParameterObject, 0, len(pathParams)+len(op.Query)),
+		Responses: map[string]Response{
+			"200": {
+				Description: "OK",
+				Content:     map[string]MediaType{contentTypeJSON: {Schema: builder.schemaOf(op.Response)}},
+			},
+			"default": {Description: "Error"},
+		},
+	}
+	for _, name := range pathParams {
+		result.Parameters = append(result.Parameters, ParameterObject{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	for _, q := range op.Query {
+		result.Parameters = append(result.Parameters, parameter(q, "query"))
+	}
+	for _, h := range op.Headers {
+		result.Parameters = append(result.Parameters, parameter(h, "header"))
+	}
+	for _, contentType := range op.Produces {
+		result.Responses["200"].Content[contentType] = result.Responses["200"].Content[contentTypeJSON]
+	}
+	if op.Request != nil {
+		result.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{contentTypeJSON: {Schema: builder.schemaOf(op.Request)}},
+		}
+	}
+	return result
+}
+
+func parameter(p Param, in string) ParameterObject {
+	return ParameterObject{
+		Name:        p.Name,
+		In:          in,
+		Description: p.Description,
+		Required:    p.Required,
+		Schema:      &Schema{Type: "string"},
+	}
+}
+
+// convertPath turns a gin path (/v2/:coin/*any) into an OpenAPI one (/v2/{coin}/{any})
+func convertPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	params := make([]string, 0)
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			name := s[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func joinPaths(base, path string) string {
+	if base == "" || base == "/" {
+		if !strings.HasPrefix(path, "/") {
+			return "/" + path
+		}
+		return path
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func Test_convertPath(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantPath   string
+		wantParams []string
+	}{
+		{"/v1/ethereum/:address", "/v1/ethereum/{address}", []string{"address"}},
+		{"/v3/ethereum/collections/:owner/collection/:collection_id", "/v3/ethereum/collections/{owner}/collection/{collection_id}", []string{"owner", "collection_id"}},
+		{"/swagger/*any", "/swagger/{any}", []string{"any"}},
+		{"/v2/tokens", "/v2/tokens", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, params := convertPath(tt.path)
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantParams, params)
+		})
+	}
+}
+
+func TestRegistry_Document(t *testing.T) {
+	router := gin.New()
+	registry := NewRegistry()
+	registry.GET(router, Operation{
+		Path:     "/v2/ethereum/transactions/:address",
+		ID:       "tx_v2_ethereum",
+		Query:    []Param{{Name: "token"}},
+		Response: types.TxPage{},
+	}, func(c *gin.Context) {})
+	registry.POST(router.Group("/v2"), Operation{
+		Path:     "/tokens",
+		ID:       "tokens",
+		Request:  map[string][]string{},
+		Response: types.DocsResponse{Docs: types.TokenPage{}},
+	}, func(c *gin.Context) {})
+
+	doc := registry.Document("test", "1")
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Len(t, doc.Paths, 2)
+
+	txOp := doc.Paths["/v2/ethereum/transactions/{address}"]["get"]
+	assert.Equal(t, "tx_v2_ethereum", txOp.OperationID)
+	assert.Len(t, txOp.Parameters, 2)
+	assert.Equal(t, "path", txOp.Parameters[0].In)
+	assert.Equal(t, "query", txOp.Parameters[1].In)
+
+	txSchema := txOp.Responses["200"].Content[contentTypeJSON].Schema
+	assert.Equal(t, "object", txSchema.Type)
+	assert.Equal(t, "array", txSchema.Properties["docs"].Type)
+	assert.Equal(t, "#/components/schemas/Tx", txSchema.Properties["docs"].Items.Ref)
+	assert.Equal(t, "integer", doc.Components.Schemas["Tx"].Properties["block"].Type)
+	assert.Equal(t, "string", doc.Components.Schemas["Tx"].Properties["fee"].Type)
+
+	tokensOp := doc.Paths["/v2/tokens"]["post"]
+	assert.NotNil(t, tokensOp.RequestBody)
+	tokensSchema := tokensOp.Responses["200"].Content[contentTypeJSON].Schema
+	assert.Equal(t, "#/components/schemas/Token", tokensSchema.Properties["docs"].Items.Ref)
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	router := gin.New()
+	registry := NewRegistry()
+	registry.GET(router, Operation{Path: "/", ID: "status"}, func(c *gin.Context) {})
+	router.GET("/openapi.json", registry.Handler("test", "1"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc Document
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "test", doc.Info.Title)
+	assert.Contains(t, doc.Paths, "/")
+}
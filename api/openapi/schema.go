@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+)
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+type schemaBuilder struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf returns the schema for the value, following concrete values
+// stored in interface fields (e.g. DocsResponse.Docs)
+func (b *schemaBuilder) schemaOf(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return b.schemaOfValue(reflect.ValueOf(v))
+}
+
+func (b *schemaBuilder) schemaOfValue(v reflect.Value) *Schema {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return b.schemaOfType(v.Type())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct && hasInterfaceFields(v.Type()) {
+		return b.structSchema(v.Type(), v)
+	}
+	return b.schemaOfType(v.Type())
+}
+
+func (b *schemaBuilder) schemaOfType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s, ok := b.marshalerSchema(t); ok {
+		return s
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return b.componentRef(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// marshalerSchema infers the schema of types with custom JSON marshalling
+// from their encoded zero value, so wrapped pages (e.g. TxPage) are described
+// the way they are served
+func (b *schemaBuilder) marshalerSchema(t reflect.Type) (*Schema, bool) {
+	if !t.Implements(marshalerType) && !reflect.PtrTo(t).Implements(marshalerType) {
+		return nil, false
+	}
+	zero := reflect.New(t)
+	if t.Kind() == reflect.Slice {
+		zero.Elem().Set(reflect.MakeSlice(t, 0, 0))
+	}
+	raw, err := json.Marshal(zero.Interface())
+	if err != nil {
+		return nil, false
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, false
+	}
+	var elem reflect.Type
+	if t.Kind() == reflect.Slice {
+		elem = t.Elem()
+	}
+	return b.jsonSchema(decoded, elem), true
+}
+
+func (b *schemaBuilder) jsonSchema(v interface{}, elem reflect.Type) *Schema {
+	switch v := v.(type) {
+	case bool:
+		return &Schema{Type: "boolean"}
+	case float64:
+		return &Schema{Type: "number"}
+	case string:
+		return &Schema{Type: "string"}
+	case []interface{}:
+		if elem != nil {
+			return &Schema{Type: "array", Items: b.schemaOfType(elem)}
+		}
+		return &Schema{Type: "array", Items: &Schema{}}
+	case map[string]interface{}:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for name, value := range v {
+			s.Properties[name] = b.jsonSchema(value, elem)
+		}
+		return s
+	default:
+		return &Schema{}
+	}
+}
+
+func (b *schemaBuilder) componentRef(t reflect.Type) *Schema {
+	name, ok := b.names[t]
+	if !ok {
+		name = b.componentName(t)
+		b.names[t] = name
+		// Reserve the name first to support recursive types
+		b.components[name] = &Schema{}
+		*b.components[name] = *b.structSchema(t, reflect.Value{})
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (b *schemaBuilder) componentName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		name = "Object"
+	}
+	if _, taken := b.components[name]; taken {
+		name = strings.Title(path.Base(t.PkgPath())) + name
+	}
+	return name
+}
+
+func (b *schemaBuilder) structSchema(t reflect.Type, v reflect.Value) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		var fieldValue reflect.Value
+		if v.IsValid() {
+			fieldValue = v.Field(i)
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := b.embeddedSchema(field.Type, fieldValue)
+			for k, p := range embedded.Properties {
+				s.Properties[k] = p
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if fieldValue.IsValid() && fieldValue.Kind() == reflect.Interface && !fieldValue.IsNil() {
+			s.Properties[name] = b.schemaOfValue(fieldValue)
+			continue
+		}
+		s.Properties[name] = b.schemaOfType(field.Type)
+	}
+	return s
+}
+
+func (b *schemaBuilder) embeddedSchema(t reflect.Type, v reflect.Value) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		v = reflect.Value{}
+	}
+	if t.Kind() != reflect.Struct {
+		return &Schema{}
+	}
+	return b.structSchema(t, v)
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	return strings.Split(tag, ",")[0], false
+}
+
+func hasInterfaceFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type.Kind() == reflect.Interface {
+			return true
+		}
+	}
+	return false
+}
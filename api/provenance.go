@@ -0,0 +1,22 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/api/middleware"
+)
+
+// ProvenanceResolver attributes the responses of the coin routes, /<version>/<handle>/..., to the handle and
+// its API URL in upstreams, the other responses to their route
+func ProvenanceResolver(upstreams map[string]string) middleware.SourceResolver {
+	return func(c *gin.Context) (string, string) {
+		segments := strings.Split(strings.Trim(c.Request.URL.Path, "/"), "/")
+		if len(segments) > 1 && strings.HasPrefix(segments[0], "v") {
+			if upstream, ok := upstreams[segments[1]]; ok {
+				return segments[1], upstream
+			}
+		}
+		return c.FullPath(), ""
+	}
+}
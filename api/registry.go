@@ -6,34 +6,272 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/trustwallet/blockatlas/api/endpoint"
 	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/api/openapi"
+	"github.com/trustwallet/blockatlas/internal"
+	"github.com/trustwallet/blockatlas/mq"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
 	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/services/assets"
+	"github.com/trustwallet/blockatlas/services/blockcache"
+	"github.com/trustwallet/blockatlas/services/lending"
+	"github.com/trustwallet/blockatlas/services/market"
+	"github.com/trustwallet/blockatlas/services/monitor"
+	"github.com/trustwallet/blockatlas/services/observer/screening"
+	"github.com/trustwallet/blockatlas/services/observer/tenant"
 	"time"
 )
 
-func RegisterTransactionsAPI(router gin.IRouter, api blockatlas.Platform) {
+// Routes keeps the descriptors of every route registered by the API,
+// used to generate the OpenAPI document
+var Routes = openapi.NewRegistry()
+
+var (
+	tokenQuery  = openapi.Param{Name: "token", Description: "Filter transactions by token ID"}
+	notesQuery  = openapi.Param{Name: "include_notes", Description: "Merge the notes of the X-API-Key owner"}
+	streamQuery = openapi.Param{Name: endpoint.StreamParam, Description: "Write the items as newline-delimited JSON with true"}
+	msgpack     = []string{endpoint.ContentTypeMsgPack}
+	waitQuery   = openapi.Param{Name: endpoint.WaitParam, Description: "Hold the request until a new transaction of the address, e.g. 30s"}
+	cursorQuery = openapi.Param{Name: "cursor", Description: "The next_page cursor of the previous page"}
+	limitQuery  = openapi.Param{Name: "limit", Description: "Items per page, at most 500"}
+)
+
+// subscriptionScreener is set by EnableScreening, the subscriptions are queued without screening otherwise
+var subscriptionScreener screening.Screener
+
+// EnableScreening screens the subscriptions added or updated by POST /observers/v1/subscriptions,
+// before RegisterSubscriptionsAPI
+func EnableScreening(screener screening.Screener) {
+	subscriptionScreener = screener
+}
+
+// txWaiter is set by EnableLongPoll before the routes are registered, ?wait= answers 501 otherwise
+var (
+	txWaiter        endpoint.TxWaiter
+	longPollMaxWait time.Duration
+)
+
+// blockCaches are the caches of the platforms by handle, set by EnableBlockCache before the routes are registered
+var (
+	blockCaches                        map[string]*blockcache.Cache
+	blockHeightInterval, blockCacheTTL time.Duration
+)
+
+// EnableBlockCache serves the transactions and tokens of the platforms with a block height from
+// the responses of the same height, requesting the height every heightInterval
+func EnableBlockCache(heightInterval, ttl time.Duration) {
+	blockCaches = make(map[string]*blockcache.Cache)
+	blockHeightInterval, blockCacheTTL = heightInterval, ttl
+}
+
+// blockCacheOf returns the cache of the platform, nil without EnableBlockCache or block height
+func blockCacheOf(api blockatlas.Platform) *blockcache.Cache {
+	if blockCaches == nil {
+		return nil
+	}
+	blockAPI, ok := api.(blockatlas.BlockAPI)
+	if !ok {
+		return nil
+	}
+	handle := api.Coin().Handle
+	if c, ok := blockCaches[handle]; ok {
+		return c
+	}
+	c := blockcache.New(blockAPI, blockHeightInterval, blockCacheTTL)
+	blockCaches[handle] = c
+	return c
+}
+
+// providerInfoCache serves /v1/lending/providers, set by EnableProviderInfoCache before the routes are registered
+var providerInfoCache *lending.InfoCache
+
+// EnableProviderInfoCache serves the info of the lending providers from the cache until it expires
+func EnableProviderInfoCache(cache *lending.InfoCache) {
+	providerInfoCache = cache
+}
+
+// priceSource converts the lending contracts to the ?currency= of /v1/lending/account, set by EnablePrices
+var priceSource market.PriceSource
+
+// EnablePrices values the lending contracts in the requested currency with the prices of the source
+func EnablePrices(source market.PriceSource) {
+	priceSource = source
+}
+
+// EnableLongPoll lets the v2 transactions requests wait up to maxWait for a new transaction
+func EnableLongPoll(waiter endpoint.TxWaiter, maxWait time.Duration) {
+	txWaiter, longPollMaxWait = waiter, maxWait
+}
+
+// RegisterTransactionsAPI serves the transactions of the platform, with the notes of the API keys on include_notes
+// when notes is set
+func RegisterTransactionsAPI(router gin.IRouter, api blockatlas.Platform, notes *endpoint.TxNotes) {
 	handle := api.Coin().Handle
+	cache := blockCacheOf(api)
 	txUtxoAPI, ok := api.(blockatlas.TxUtxoAPI)
 	if ok {
-		router.GET("/v1/"+handle+"/address/:address", func(c *gin.Context) {
-			endpoint.GetTransactionsHistory(c, txUtxoAPI, nil)
+		txUtxoAPI = cache.TxUtxoAPI(txUtxoAPI)
+		Routes.GET(router, openapi.Operation{
+			Path:     "/v1/" + handle + "/address/:address",
+			ID:       "tx_v1_" + handle,
+			Summary:  "Get Transactions",
+			Tags:     []string{"Transactions"},
+			Query:    []openapi.Param{notesQuery, streamQuery, cursorQuery, limitQuery},
+			Response: blockatlas.PageResponse{Docs: []types.Tx{}},
+		}, func(c *gin.Context) {
+			endpoint.GetTransactionsHistory(c, txUtxoAPI, nil, notes)
 		})
-		router.GET("/v1/"+handle+"/xpub/:xpub", func(c *gin.Context) {
-			endpoint.GetTransactionsByXpub(c, txUtxoAPI)
+		Routes.GET(router, openapi.Operation{
+			Path:     "/v1/" + handle + "/xpub/:xpub",
+			ID:       "tx_xpub_v1_" + handle,
+			Summary:  "Get Transactions by XPUB",
+			Tags:     []string{"Transactions"},
+			Query:    []openapi.Param{notesQuery, streamQuery, cursorQuery, limitQuery},
+			Response: blockatlas.PageResponse{Docs: []types.Tx{}},
+		}, func(c *gin.Context) {
+			endpoint.GetTransactionsByXpub(c, txUtxoAPI, notes)
 		})
-		router.GET("/v2/"+handle+"/transactions/xpub/:xpub", func(c *gin.Context) {
-			endpoint.GetTransactionsByXpub(c, txUtxoAPI)
+		Routes.GET(router, openapi.Operation{
+			Path:     "/v2/" + handle + "/transactions/xpub/:xpub",
+			ID:       "tx_xpub_v2_" + handle,
+			Summary:  "Get Transactions by XPUB",
+			Tags:     []string{"Transactions"},
+			Query:    []openapi.Param{notesQuery, streamQuery, cursorQuery, limitQuery},
+			Response: blockatlas.PageResponse{Docs: []types.Tx{}},
+		}, func(c *gin.Context) {
+			endpoint.GetTransactionsByXpub(c, txUtxoAPI, notes)
 		})
 		return
 	}
 	txAPI, okTxApi := api.(blockatlas.TxAPI)
 	tokenTxAPI, okTokenTxApi := api.(blockatlas.TokenTxAPI)
 	if okTxApi || okTokenTxApi {
-		router.GET("/v1/"+handle+"/:address", func(c *gin.Context) {
-			endpoint.GetTransactionsHistory(c, txAPI, tokenTxAPI)
+		txAPI, tokenTxAPI = cache.TxAPI(txAPI), cache.TokenTxAPI(tokenTxAPI)
+		Routes.GET(router, openapi.Operation{
+			Path:     "/v1/" + handle + "/:address",
+			ID:       "tx_v1_" + handle,
+			Summary:  "Get Transactions",
+			Tags:     []string{"Transactions"},
+			Query:    []openapi.Param{tokenQuery, notesQuery, streamQuery, cursorQuery, limitQuery},
+			Response: blockatlas.PageResponse{Docs: []types.Tx{}},
+		}, func(c *gin.Context) {
+			endpoint.GetTransactionsHistory(c, txAPI, tokenTxAPI, notes)
+		})
+		Routes.GET(router, openapi.Operation{
+			Path:     "/v2/" + handle + "/transactions/:address",
+			ID:       "tx_v2_" + handle,
+			Summary:  "Get Transactions",
+			Tags:     []string{"Transactions"},
+			Query:    []openapi.Param{tokenQuery, notesQuery, streamQuery, waitQuery, cursorQuery, limitQuery},
+			Response: blockatlas.PageResponse{Docs: []types.Tx{}},
+		}, endpoint.LongPoll(txWaiter, api.Coin().ID, longPollMaxWait), func(c *gin.Context) {
+			endpoint.GetTransactionsHistory(c, txAPI, tokenTxAPI, notes)
+		})
+	}
+}
+
+func RegisterSummaryAPI(router gin.IRouter, api blockatlas.Platform) {
+	txAPI, ok := api.(blockatlas.TxAPI)
+	if !ok {
+		return
+	}
+	txAPI = blockCacheOf(api).TxAPI(txAPI)
+	handle := api.Coin().Handle
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v2/" + handle + "/summary/:address",
+		ID:       "summary_" + handle,
+		Summary:  "Get Account Summary",
+		Tags:     []string{"Transactions"},
+		Response: types.AccountSummary{},
+	}, middleware.CacheMiddleware(time.Minute*10, func(c *gin.Context) {
+		endpoint.GetAccountSummary(c, txAPI)
+	}))
+}
+
+// RegisterSyncAPI serves the changes of an address since a block to the wallets syncing it
+func RegisterSyncAPI(router gin.IRouter, api blockatlas.Platform) {
+	txAPI, ok := api.(blockatlas.TxAPI)
+	if !ok {
+		return
+	}
+	cache := blockCacheOf(api)
+	apis := endpoint.SyncAPIs{Txs: cache.TxAPI(txAPI)}
+	if tokenAPI, ok := api.(blockatlas.TokensAPI); ok {
+		apis.Tokens = cache.TokensAPI(tokenAPI)
+	}
+	if stakeAPI, ok := api.(blockatlas.StakeAPI); ok {
+		apis.Stake = stakeAPI
+	}
+	if blockAPI, ok := api.(blockatlas.BlockAPI); ok {
+		apis.Blocks = blockAPI
+	}
+	handle := api.Coin().Handle
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v3/" + handle + "/sync/:address",
+		ID:       "sync_v3_" + handle,
+		Summary:  "Get changes of an address",
+		Tags:     []string{"Transactions"},
+		Query:    []openapi.Param{{Name: "since_block", Description: "Block of the previous sync, 0 for everything"}},
+		Response: endpoint.SyncResponse{},
+	}, func(c *gin.Context) {
+		endpoint.GetSync(c, apis)
+	})
+}
+
+// RegisterBlockbookAPI serves the address, tx and utxo routes of Blockbook v2 for the wallet backends written
+// for it, each one when the platform supports it
+func RegisterBlockbookAPI(router gin.IRouter, api blockatlas.Platform) {
+	cache := blockCacheOf(api)
+	apis := endpoint.BlockbookAPIs{}
+	if txAPI, ok := api.(blockatlas.TxAPI); ok {
+		apis.Txs = cache.TxAPI(txAPI)
+	}
+	if lookupAPI, ok := api.(blockatlas.TxLookupAPI); ok {
+		apis.Lookup = lookupAPI
+	}
+	if utxoAPI, ok := api.(blockatlas.UtxoAPI); ok {
+		apis.Utxos = utxoAPI
+	}
+	if balanceAPI, ok := api.(blockatlas.BalanceAPI); ok {
+		apis.Balance = balanceAPI
+	}
+	if blockAPI, ok := api.(blockatlas.BlockAPI); ok {
+		apis.Blocks = blockAPI
+	}
+	handle := api.Coin().Handle
+	prefix := "/blockbook/" + handle + "/api/v2"
+	if apis.Txs != nil {
+		Routes.GET(router, openapi.Operation{
+			Path:     prefix + "/address/:address",
+			ID:       "blockbook_address_" + handle,
+			Summary:  "Get address (Blockbook)",
+			Tags:     []string{"Blockbook"},
+			Query:    []openapi.Param{{Name: "details", Description: "basic, txids (default) or txs"}},
+			Response: endpoint.BlockbookAddress{},
+		}, func(c *gin.Context) {
+			endpoint.GetBlockbookAddress(c, apis)
 		})
-		router.GET("/v2/"+handle+"/transactions/:address", func(c *gin.Context) {
-			endpoint.GetTransactionsHistory(c, txAPI, tokenTxAPI)
+	}
+	if apis.Lookup != nil {
+		Routes.GET(router, openapi.Operation{
+			Path:     prefix + "/tx/:txid",
+			ID:       "blockbook_tx_" + handle,
+			Summary:  "Get transaction (Blockbook)",
+			Tags:     []string{"Blockbook"},
+			Response: endpoint.BlockbookTx{},
+		}, func(c *gin.Context) {
+			endpoint.GetBlockbookTx(c, apis)
+		})
+	}
+	if apis.Utxos != nil {
+		Routes.GET(router, openapi.Operation{
+			Path:     prefix + "/utxo/:address",
+			ID:       "blockbook_utxo_" + handle,
+			Summary:  "Get unspent outputs (Blockbook)",
+			Tags:     []string{"Blockbook"},
+			Response: []endpoint.BlockbookUtxo{},
+		}, func(c *gin.Context) {
+			endpoint.GetBlockbookUtxo(c, apis)
 		})
 	}
 }
@@ -43,10 +281,28 @@ func RegisterTokensAPI(router gin.IRouter, api blockatlas.Platform) {
 	if !ok {
 		return
 	}
+	tokenAPI = blockCacheOf(api).TokensAPI(tokenAPI)
 	handle := tokenAPI.Coin().Handle
-	router.GET("/v2/"+handle+"/tokens/:address", func(c *gin.Context) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v2/" + handle + "/tokens/:address",
+		ID:       "tokens_" + handle,
+		Summary:  "Get Tokens",
+		Tags:     []string{"Tokens"},
+		Query:    []openapi.Param{cursorQuery, limitQuery},
+		Response: blockatlas.PageResponse{Docs: []types.Token{}},
+	}, func(c *gin.Context) {
 		endpoint.GetTokensByAddress(c, tokenAPI)
 	})
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/" + handle + "/address/:address/tokens",
+		ID:       "address_tokens_" + handle,
+		Summary:  "Get Address Tokens",
+		Tags:     []string{"Tokens"},
+		Query:    []openapi.Param{cursorQuery, limitQuery},
+		Response: blockatlas.PageResponse{Docs: []types.Token{}},
+	}, func(c *gin.Context) {
+		endpoint.GetAddressTokens(c, tokenAPI)
+	})
 }
 
 func RegisterStakeAPI(router gin.IRouter, api blockatlas.Platform) {
@@ -55,54 +311,786 @@ func RegisterStakeAPI(router gin.IRouter, api blockatlas.Platform) {
 		return
 	}
 	handle := api.Coin().Handle
-	router.GET("/v2/"+handle+"/staking/validators", middleware.CacheMiddleware(time.Hour, func(c *gin.Context) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v2/" + handle + "/staking/validators",
+		ID:       "validators_" + handle,
+		Summary:  "Get Validators",
+		Tags:     []string{"Staking"},
+		Response: types.DocsResponse{Docs: types.StakeValidators{}},
+	}, middleware.CacheMiddleware(time.Hour, func(c *gin.Context) {
 		endpoint.GetValidators(c, stakeAPI)
 	}))
-	router.GET("/v2/"+handle+"/staking/delegations/:address", func(c *gin.Context) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v2/" + handle + "/staking/delegations/:address",
+		ID:       "delegations_" + handle,
+		Summary:  "Get Stake Delegations",
+		Tags:     []string{"Staking"},
+		Response: types.DelegationResponse{},
+	}, func(c *gin.Context) {
 		endpoint.GetStakingDelegationsForSpecificCoin(c, stakeAPI)
 	})
 }
 
+// Fee estimates are served from the API cache for this long
+const feesCache = time.Second * 30
+
+func RegisterFeeAPI(router gin.IRouter, api blockatlas.Platform) {
+	feeAPI, ok := api.(blockatlas.FeeAPI)
+	if !ok {
+		return
+	}
+	handle := api.Coin().Handle
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v2/" + handle + "/fees",
+		ID:       "fees_" + handle,
+		Summary:  "Get Fee Estimates",
+		Tags:     []string{"Fees"},
+		Response: types.FeeEstimates{},
+	}, middleware.CacheMiddleware(feesCache, func(c *gin.Context) {
+		endpoint.GetFeeEstimates(c, feeAPI)
+	}))
+}
+
+func RegisterSimulationAPI(router gin.IRouter, api blockatlas.Platform) {
+	simulationAPI, ok := api.(blockatlas.SimulationAPI)
+	if !ok {
+		return
+	}
+	handle := api.Coin().Handle
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v2/" + handle + "/simulate",
+		ID:       "simulate_" + handle,
+		Summary:  "Simulate a transaction",
+		Tags:     []string{"Transactions"},
+		Request:  types.SimulationRequest{},
+		Response: types.Simulation{},
+	}, func(c *gin.Context) {
+		endpoint.SimulateTx(c, simulationAPI)
+	})
+}
+
 func RegisterCollectionsAPI(router gin.IRouter, api blockatlas.CollectionsAPI) {
 	handle := api.Coin().Handle
-	router.GET("/v3/"+handle+"/collections/:owner/collection/:collection_id", func(c *gin.Context) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v3/" + handle + "/collections/:owner/collection/:collection_id",
+		ID:       "collection_v3_" + handle,
+		Summary:  "Get Collection",
+		Tags:     []string{"Collections"},
+		Query:    []openapi.Param{cursorQuery, limitQuery},
+		Response: blockatlas.PageResponse{Docs: []types.CollectibleV3{}},
+		Produces: msgpack,
+	}, func(c *gin.Context) {
 		endpoint.GetCollectiblesForSpecificCollectionAndOwnerV3(c, api)
 	})
-	router.GET("/v3/"+handle+"/collections/:owner", func(c *gin.Context) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v3/" + handle + "/collections/:owner",
+		ID:       "collections_v3_" + handle,
+		Summary:  "Get Collections",
+		Tags:     []string{"Collections"},
+		Query:    []openapi.Param{cursorQuery, limitQuery},
+		Response: blockatlas.PageResponse{Docs: []types.CollectionV3{}},
+		Produces: msgpack,
+	}, func(c *gin.Context) {
 		endpoint.GetCollectiblesForOwnerV3(c, api)
 	})
-	router.GET("/v4/"+handle+"/collections/:owner/collection/:collection_id", func(c *gin.Context) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v4/" + handle + "/collections/:owner",
+		ID:       "collections_v4_" + handle,
+		Summary:  "Get Collections",
+		Tags:     []string{"Collections"},
+		Query:    []openapi.Param{cursorQuery, limitQuery},
+		Response: blockatlas.PageResponse{Docs: []types.Collection{}},
+	}, func(c *gin.Context) {
+		endpoint.GetCollectionsForOwner(c, api)
+	})
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v4/" + handle + "/collections/:owner/collection/:collection_id",
+		ID:       "collection_v4_" + handle,
+		Summary:  "Get Collection",
+		Tags:     []string{"Collections"},
+		Query:    []openapi.Param{cursorQuery, limitQuery},
+		Response: blockatlas.PageResponse{Docs: []types.Collectible{}},
+	}, func(c *gin.Context) {
 		endpoint.GetCollectiblesForSpecificCollectionAndOwner(c, api)
 	})
 }
 
+// The v3 staking list is served from the API cache for this long
+const stakingListCache = time.Hour * 10
+
 func RegisterBatchAPI(router gin.IRouter) {
-	router.GET("/v3/staking/list", middleware.CacheMiddleware(time.Hour*10, func(c *gin.Context) {
-		endpoint.GetStakeInfoForCoins(c, platform.StakeAPIs)
-	}))
-	router.POST("/v2/staking/delegations", func(c *gin.Context) {
+	stakingList := endpoint.NewStakingListCache(stakingListCache)
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v3/staking/list",
+		ID:       "staking_list_v3",
+		Summary:  "Get staking info by coin ID",
+		Tags:     []string{"Staking"},
+		Query:    []openapi.Param{{Name: "coins", Description: "Comma separated list of coins", Required: true}},
+		Response: types.DocsResponse{Docs: types.StakingBatchPage{}},
+		Produces: msgpack,
+	}, func(c *gin.Context) {
+		endpoint.GetStakeInfoForCoins(c, platform.StakeAPIs, stakingList)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v2/staking/delegations",
+		ID:       "batch_delegations",
+		Summary:  "Get Multiple Stake Delegations",
+		Tags:     []string{"Staking"},
+		Query:    []openapi.Param{streamQuery},
+		Request:  endpoint.AddressesRequest{},
+		Response: types.DocsResponse{Docs: types.DelegationsBatchPage{}},
+	}, func(c *gin.Context) {
 		endpoint.GetStakeDelegationsWithAllInfoForBatch(c, platform.StakeAPIs)
 	})
-	router.POST("/v2/staking/list", middleware.CacheMiddleware(time.Hour, func(c *gin.Context) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/staking/:coin/validators",
+		ID:       "validator_details",
+		Summary:  "Get Validator Details",
+		Tags:     []string{"Staking"},
+		Response: types.DocsResponse{Docs: []types.ValidatorDetails{}},
+	}, middleware.CacheMiddleware(time.Hour, func(c *gin.Context) {
+		endpoint.GetValidatorDetails(c, platform.StakeAPIs, assets.GetValidatorDetails)
+	}))
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/staking/:coin/delegations/:address",
+		ID:       "delegations_summary",
+		Summary:  "Get Stake Delegations Summary",
+		Tags:     []string{"Staking"},
+		Response: types.DelegationsSummary{},
+	}, func(c *gin.Context) {
+		endpoint.GetStakingDelegationsSummary(c, platform.StakeAPIs)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/staking/delegations",
+		ID:       "batch_delegations_v1",
+		Summary:  "Get Stake Delegations of Multiple Coins",
+		Tags:     []string{"Staking"},
+		Request:  endpoint.AddressesRequest{},
+		Response: types.DocsResponse{Docs: []types.DelegationsBatchItem{}},
+	}, func(c *gin.Context) {
+		endpoint.GetStakeDelegationsBatch(c, platform.StakeAPIs)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v2/staking/list",
+		ID:       "batch_staking_list",
+		Summary:  "Get Multiple Stake Infos",
+		Tags:     []string{"Staking"},
+		Request:  endpoint.CoinsRequest{},
+		Response: types.DocsResponse{Docs: types.StakingBatchPage{}},
+	}, middleware.CacheMiddleware(time.Hour, func(c *gin.Context) {
 		endpoint.GetStakeInfoForBatch(c, platform.StakeAPIs)
 	}))
-	router.POST("/v3/collectibles/categories", func(c *gin.Context) {
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v3/collectibles/categories",
+		ID:       "collection_categories_v3",
+		Summary:  "Get list of collections",
+		Tags:     []string{"Collections"},
+		Query:    []openapi.Param{streamQuery},
+		Request:  map[string][]string{},
+		Response: types.CollectionPageV3{},
+		Produces: msgpack,
+	}, func(c *gin.Context) {
 		endpoint.GetCollectionCategoriesFromListV3(c, platform.CollectionsAPIs)
 	})
-	router.POST("/v4/collectibles/categories", func(c *gin.Context) {
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v4/collectibles/categories",
+		ID:       "collection_categories_v4",
+		Summary:  "Get list of collections",
+		Tags:     []string{"Collections"},
+		Query:    []openapi.Param{streamQuery},
+		Request:  map[string][]string{},
+		Response: types.CollectionPage{},
+	}, func(c *gin.Context) {
 		endpoint.GetCollectionCategoriesFromList(c, platform.CollectionsAPIs)
 	})
-	router.POST("/v2/tokens", func(c *gin.Context) {
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v2/tokens",
+		ID:       "tokens_batch",
+		Summary:  "Get list of tokens by map: coin -> [addresses]",
+		Tags:     []string{"Tokens"},
+		Query:    []openapi.Param{streamQuery},
+		Request:  map[string][]string{},
+		Response: types.ResultsResponse{Results: types.TokenPage{}},
+	}, func(c *gin.Context) {
 		endpoint.GetTokens(c, platform.TokensAPIs)
 	})
 }
 
+func RegisterLendingAPI(router gin.IRouter, apis map[string]blockatlas.LendingAPI) {
+	errs := middleware.RenderErrors()
+
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/lending/providers",
+		ID:       "lending_providers",
+		Summary:  "Get lending providers",
+		Tags:     []string{"Lending"},
+		Query:    []openapi.Param{{Name: "no_cache", Description: "Fetch the info from the providers with true, bypassing the info cache"}},
+		Response: types.LendingProvidersResponse{},
+	}, errs, func(c *gin.Context) {
+		endpoint.ServeProviders(c, apis, providerInfoCache)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/lending/rates",
+		ID:       "lending_rates",
+		Summary:  "Get lending rates",
+		Tags:     []string{"Lending"},
+		Query:    []openapi.Param{{Name: "include_inactive", Description: "Include the paused, frozen and deprecated markets with true"}},
+		Request:  types.RatesRequest{},
+		Response: types.LendingRates{},
+	}, errs, func(c *gin.Context) {
+		endpoint.ServeRates(c, apis)
+	})
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/lending/best-rates",
+		ID:       "lending_best_rates",
+		Summary:  "Get best lending rates",
+		Tags:     []string{"Lending"},
+		Query:    []openapi.Param{{Name: "assets", Description: "Comma-separated asset symbols, e.g. DAI,USDC", Required: true}},
+		Response: types.BestRatesResponse{},
+	}, errs, func(c *gin.Context) {
+		endpoint.ServeBestRates(c, apis)
+	})
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/lending/compare",
+		ID:       "lending_compare",
+		Summary:  "Compare savings products",
+		Tags:     []string{"Lending"},
+		Query:    []openapi.Param{{Name: "asset", Description: "Asset symbol, e.g. USDC", Required: true}},
+		Response: types.LendingComparison{},
+	}, errs, func(c *gin.Context) {
+		endpoint.ServeComparison(c, lending.Providers)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/lending/account/:provider",
+		ID:       "lending_account",
+		Summary:  "Get lending account",
+		Tags:     []string{"Lending"},
+		Query:    []openapi.Param{{Name: "currency", Description: "Fiat currency of the contract values, e.g. usd"}},
+		Request:  types.AccountRequest{},
+		Response: []types.AccountLendingContracts{},
+	}, errs, func(c *gin.Context) {
+		endpoint.ServeAccount(c, apis, priceSource)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/lending/account/:provider/earnings",
+		ID:       "lending_account_earnings",
+		Summary:  "Get lending earnings",
+		Tags:     []string{"Lending"},
+		Query:    []openapi.Param{{Name: "interval", Description: "Spacing of the history points, e.g. 24h"}, cursorQuery, limitQuery},
+		Request:  types.AccountRequest{},
+		Response: blockatlas.PageResponse{Docs: []types.AccountLendingEarnings{}},
+	}, errs, func(c *gin.Context) {
+		endpoint.ServeAccountEarnings(c, apis)
+	})
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/lending/queue/:provider/:asset",
+		ID:       "lending_queue",
+		Summary:  "Get withdrawal queue",
+		Tags:     []string{"Lending"},
+		Response: types.WithdrawalQueue{},
+	}, errs, func(c *gin.Context) {
+		endpoint.ServeWithdrawalQueue(c, apis)
+	})
+}
+
+// RegisterMarketAPI serves the tickers and the price charts of the market providers
+func RegisterMarketAPI(router gin.IRouter, tickers endpoint.TickerSource, charts endpoint.ChartSource) {
+	Routes.GET(router, openapi.Operation{
+		Path:    "/v1/market/ticker",
+		ID:      "market_ticker",
+		Summary: "Get market tickers",
+		Tags:    []string{"Market"},
+		Query: []openapi.Param{
+			{Name: "coins", Description: "Comma-separated coin symbols, e.g. BTC,ETH", Required: true},
+			{Name: "currency", Description: "Currency of the prices, USD by default"},
+		},
+		Response: types.DocsResponse{Docs: []market.Ticker{}},
+	}, func(c *gin.Context) {
+		endpoint.GetMarketTickers(c, tickers)
+	})
+
+	Routes.GET(router, openapi.Operation{
+		Path:    "/v1/market/charts",
+		ID:      "market_charts",
+		Summary: "Get market charts",
+		Tags:    []string{"Market"},
+		Query: []openapi.Param{
+			{Name: "coin", Description: "Coin id", Required: true},
+			{Name: "currency", Description: "Currency of the prices, USD by default"},
+			{Name: "time_start", Description: "Unix time of the first price, 24 hours ago by default"},
+			{Name: "max_items", Description: "Maximum number of points, 64 by default and 1000 at most"},
+		},
+		Response: market.Chart{},
+	}, func(c *gin.Context) {
+		endpoint.GetMarketCharts(c, charts)
+	})
+}
+
+// RegisterRatesAPI serves the fiat rates the prices in the other currencies are converted with
+func RegisterRatesAPI(router gin.IRouter, rates endpoint.RatesSource) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/market/rates",
+		ID:       "market_rates",
+		Summary:  "Get fiat rates",
+		Tags:     []string{"Market"},
+		Query:    []openapi.Param{{Name: "currency", Description: "Base currency of the rates, USD by default"}},
+		Response: market.Rates{},
+	}, func(c *gin.Context) {
+		endpoint.GetMarketRates(c, rates)
+	})
+}
+
+// RegisterLendingAlertsAPI stores the lending rate alerts posted to webhooks by the notifier,
+// for the holders of the lending alerts API keys
+func RegisterLendingAlertsAPI(router gin.IRouter, apis map[string]blockatlas.LendingAPI, storage endpoint.LendingAlertStorage, keys []string) {
+	auth := middleware.RequireAPIKey(APIKeyHeader, keys)
+	headers := []openapi.Param{{Name: APIKeyHeader, Description: "Lending alerts API key", Required: true}}
+
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/lending/alerts",
+		ID:       "lending_alert",
+		Summary:  "Create lending alert",
+		Tags:     []string{"Lending"},
+		Headers:  headers,
+		Request:  types.LendingAlertRequest{},
+		Response: types.LendingAlertRequest{},
+	}, auth, func(c *gin.Context) {
+		endpoint.CreateLendingAlert(c, apis, storage)
+	})
+	Routes.DELETE(router, openapi.Operation{
+		Path:    "/v1/lending/alerts",
+		ID:      "lending_alert_delete",
+		Summary: "Delete lending alert",
+		Tags:    []string{"Lending"},
+		Headers: headers,
+		Request: types.LendingAlertRequest{},
+	}, auth, func(c *gin.Context) {
+		endpoint.DeleteLendingAlert(c, apis, storage)
+	})
+}
+
+// RegisterExportAPI streams the transactions of the block ranges to the holders of the export API keys
+func RegisterExportAPI(router gin.IRouter, apis map[string]blockatlas.BlockAPI, keys []string) {
+	Routes.GET(router, openapi.Operation{
+		Path:    "/v1/export/:coin",
+		ID:      "export_blocks",
+		Summary: "Export transactions of a block range as CSV or NDJSON",
+		Tags:    []string{"Transactions"},
+		Query: []openapi.Param{
+			{Name: "from", Description: "First block", Required: true},
+			{Name: "to", Description: "Last block", Required: true},
+			{Name: "format", Description: "csv (default) or ndjson"},
+		},
+		Headers: []openapi.Param{{Name: APIKeyHeader, Description: "Export API key", Required: true}},
+	}, middleware.RequireAPIKey(APIKeyHeader, keys), func(c *gin.Context) {
+		endpoint.ExportBlocks(c, apis)
+	})
+}
+
+// RegisterAdminAPI lists and resets the upstream circuit breakers for the holders of the admin API keys
+func RegisterAdminAPI(router gin.IRouter, keys []string) {
+	auth := middleware.RequireAPIKey(APIKeyHeader, keys)
+	headers := []openapi.Param{{Name: APIKeyHeader, Description: "Admin API key", Required: true}}
+
+	Routes.GET(router, openapi.Operation{
+		Path:     "/admin/breakers",
+		ID:       "admin_breakers",
+		Summary:  "Get circuit breakers",
+		Tags:     []string{"Admin"},
+		Headers:  headers,
+		Response: types.DocsResponse{Docs: []blockatlas.CircuitBreaker{}},
+	}, auth, endpoint.GetCircuitBreakers)
+	Routes.POST(router, openapi.Operation{
+		Path:     "/admin/breakers/:host/reset",
+		ID:       "admin_breaker_reset",
+		Summary:  "Reset circuit breaker",
+		Tags:     []string{"Admin"},
+		Headers:  headers,
+		Response: blockatlas.CircuitBreaker{},
+	}, auth, endpoint.ResetCircuitBreaker)
+}
+
+// RegisterAutoscalingAPI serves the depth of the notifier queue with the recommended number of workers
+func RegisterAutoscalingAPI(router gin.IRouter, scaler *mq.Autoscaler) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/autoscaling/recommendation",
+		ID:       "autoscaling_recommendation",
+		Summary:  "Get autoscaling recommendation",
+		Tags:     []string{"Observer"},
+		Response: mq.QueueStats{},
+	}, func(c *gin.Context) {
+		endpoint.GetAutoscalingRecommendation(c, scaler)
+	})
+}
+
+// RegisterWorkersAPI lists and resizes the worker pool of the notifier for the holders of the admin API keys
+func RegisterWorkersAPI(router gin.IRouter, pool *mq.WorkerPool, keys []string) {
+	auth := middleware.RequireAPIKey(APIKeyHeader, keys)
+	headers := []openapi.Param{{Name: APIKeyHeader, Description: "Admin API key", Required: true}}
+	Routes.GET(router, openapi.Operation{
+		Path:     "/admin/workers",
+		ID:       "admin_workers",
+		Summary:  "Get notifier workers",
+		Tags:     []string{"Admin"},
+		Headers:  headers,
+		Response: mq.PoolStats{},
+	}, auth, func(c *gin.Context) {
+		endpoint.GetWorkers(c, pool)
+	})
+	Routes.PUT(router, openapi.Operation{
+		Path:     "/admin/workers",
+		ID:       "admin_workers_resize",
+		Summary:  "Resize notifier workers",
+		Tags:     []string{"Admin"},
+		Headers:  headers,
+		Request:  endpoint.WorkersRequest{},
+		Response: mq.PoolStats{},
+	}, auth, func(c *gin.Context) {
+		endpoint.ResizeWorkers(c, pool)
+	})
+}
+
+// RegisterMonitorAPI lists the synthetic checks of the API for the holders of the admin API keys
+func RegisterMonitorAPI(router gin.IRouter, m endpoint.SyntheticMonitor, keys []string) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/admin/monitor",
+		ID:       "admin_monitor",
+		Summary:  "Get synthetic checks",
+		Tags:     []string{"Admin"},
+		Headers:  []openapi.Param{{Name: APIKeyHeader, Description: "Admin API key", Required: true}},
+		Response: types.DocsResponse{Docs: []monitor.Result{}},
+	}, middleware.RequireAPIKey(APIKeyHeader, keys), func(c *gin.Context) {
+		endpoint.GetMonitorResults(c, m)
+	})
+}
+
+// RegisterRecordingsAPI lists the requests recorded for the debug API keys and starts or stops recording
+// a key, for the holders of the admin API keys
+func RegisterRecordingsAPI(router gin.IRouter, recorder *middleware.Recorder, keys []string) {
+	auth := middleware.RequireAPIKey(APIKeyHeader, keys)
+	headers := []openapi.Param{{Name: APIKeyHeader, Description: "Admin API key", Required: true}}
+
+	Routes.GET(router, openapi.Operation{
+		Path:     "/admin/recordings",
+		ID:       "admin_recordings",
+		Summary:  "Get recorded requests",
+		Tags:     []string{"Admin"},
+		Headers:  headers,
+		Query:    []openapi.Param{{Name: "api_key", Description: "Recorded API key"}},
+		Response: types.DocsResponse{Docs: []middleware.Exchange{}},
+	}, auth, func(c *gin.Context) {
+		endpoint.GetRecordings(c, recorder)
+	})
+	Routes.DELETE(router, openapi.Operation{
+		Path:    "/admin/recordings",
+		ID:      "admin_recordings_clear",
+		Summary: "Clear recorded requests",
+		Tags:    []string{"Admin"},
+		Headers: headers,
+	}, auth, func(c *gin.Context) {
+		endpoint.ClearRecordings(c, recorder)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:    "/admin/recordings/keys",
+		ID:      "admin_recording_key_add",
+		Summary: "Start recording an API key",
+		Tags:    []string{"Admin"},
+		Headers: headers,
+		Request: endpoint.RecordingKeyRequest{},
+	}, auth, func(c *gin.Context) {
+		endpoint.AddRecordingKey(c, recorder)
+	})
+	Routes.DELETE(router, openapi.Operation{
+		Path:    "/admin/recordings/keys",
+		ID:      "admin_recording_key_delete",
+		Summary: "Stop recording an API key",
+		Tags:    []string{"Admin"},
+		Headers: headers,
+		Request: endpoint.RecordingKeyRequest{},
+	}, auth, func(c *gin.Context) {
+		endpoint.DeleteRecordingKey(c, recorder)
+	})
+}
+
+// RegisterLendingAdminAPI refreshes the cached lending providers on demand for the holders of the admin API keys
+func RegisterLendingAdminAPI(router gin.IRouter, apis map[string]blockatlas.LendingAPI, keys []string) {
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/lending/providers/:provider/refresh",
+		ID:       "lending_provider_refresh",
+		Summary:  "Refresh lending provider",
+		Tags:     []string{"Lending"},
+		Headers:  []openapi.Param{{Name: APIKeyHeader, Description: "Admin API key", Required: true}},
+		Response: lending.CachedProvider{},
+	}, middleware.RequireAPIKey(APIKeyHeader, keys), middleware.RenderErrors(), func(c *gin.Context) {
+		endpoint.RefreshProvider(c, apis, lending.Providers)
+	})
+}
+
+// RegisterBlockNumberAPI serves the chain head and the last block parsed of the observed coins, monitoring the lag
+// of the parser. Under /v1/block-number since /v1/<coin>/<segment> is the transactions route of the coins.
+func RegisterBlockNumberAPI(router gin.IRouter, apis map[string]blockatlas.BlockAPI, tracker endpoint.Tracker) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/block-number/:coin",
+		ID:       "block_number",
+		Summary:  "Get block number",
+		Tags:     []string{"Observer"},
+		Response: endpoint.BlockNumber{},
+	}, func(c *gin.Context) {
+		endpoint.GetBlockNumber(c, apis, tracker)
+	})
+}
+
+// RegisterReplayAPI publishes again the recorded notifications for the holders of the replay API keys
+func RegisterReplayAPI(router gin.IRouter, replayer endpoint.NotificationReplayer, keys []string) {
+	Routes.POST(router, openapi.Operation{
+		Path:     "/observers/v1/replay",
+		ID:       "replay_notifications",
+		Summary:  "Replay notifications of a time window",
+		Tags:     []string{"Observer"},
+		Headers:  []openapi.Param{{Name: APIKeyHeader, Description: "Replay API key", Required: true}},
+		Request:  types.ReplayRequest{},
+		Response: types.ReplayResponse{},
+	}, middleware.RequireAPIKey(APIKeyHeader, keys), func(c *gin.Context) {
+		endpoint.ReplayNotifications(c, replayer)
+	})
+}
+
+// RegisterSubscriptionsAPI queues the subscription events of the holders of the subscriptions API keys,
+// the keys of the tenants act for their tenant and the other keys for the default tenant
+func RegisterSubscriptionsAPI(router gin.IRouter, publisher endpoint.SubscriptionPublisher, storage endpoint.QuotaStorage, tenants *tenant.Registry, keys []string) {
+	auth := middleware.RequireAPIKey(APIKeyHeader, append(keys, tenants.Keys()...))
+	tenantOf := func(c *gin.Context) tenant.Tenant {
+		name, _ := tenants.ByKey(c.GetHeader(APIKeyHeader))
+		return tenants.Get(name)
+	}
+	headers := []openapi.Param{{Name: APIKeyHeader, Description: "Subscriptions API key", Required: true}}
+
+	Routes.POST(router, openapi.Operation{
+		Path:     "/observers/v1/subscriptions",
+		ID:       "publish_subscriptions",
+		Summary:  "Subscribe addresses",
+		Tags:     []string{"Observer"},
+		Headers:  headers,
+		Request:  types.SubscriptionEvent{},
+		Response: types.SubscriptionsResponse{},
+	}, auth, func(c *gin.Context) {
+		endpoint.PublishSubscriptions(c, publisher, subscriptionScreener, tenantOf(c).Name)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/observers/v1/subscriptions/renew",
+		ID:       "renew_subscriptions",
+		Summary:  "Renew subscriptions",
+		Tags:     []string{"Observer"},
+		Headers:  headers,
+		Request:  types.SubscriptionEvent{},
+		Response: types.SubscriptionsResponse{},
+	}, auth, func(c *gin.Context) {
+		endpoint.RenewSubscriptions(c, publisher, tenantOf(c).Name)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/observer/subscriptions",
+		ID:       "subscribe_webhook",
+		Summary:  "Subscribe addresses to a webhook",
+		Tags:     []string{"Observer"},
+		Headers:  headers,
+		Request:  types.WebhookSubscriptionRequest{},
+		Response: types.SubscriptionsResponse{},
+	}, auth, func(c *gin.Context) {
+		endpoint.SubscribeWebhook(c, publisher, subscriptionScreener, tenantOf(c).Name)
+	})
+	Routes.DELETE(router, openapi.Operation{
+		Path:     "/v1/observer/subscriptions",
+		ID:       "unsubscribe_webhook",
+		Summary:  "Unsubscribe addresses from a webhook",
+		Tags:     []string{"Observer"},
+		Headers:  headers,
+		Request:  types.WebhookSubscriptionRequest{},
+		Response: types.SubscriptionsResponse{},
+	}, auth, func(c *gin.Context) {
+		endpoint.UnsubscribeWebhook(c, publisher, tenantOf(c).Name)
+	})
+	Routes.GET(router, openapi.Operation{
+		Path:     "/observers/v1/quota",
+		ID:       "quota_usage",
+		Summary:  "Quota usage",
+		Tags:     []string{"Observer"},
+		Headers:  headers,
+		Response: types.QuotaUsage{},
+	}, auth, func(c *gin.Context) {
+		endpoint.GetQuotaUsage(c, storage, tenantOf(c))
+	})
+}
+
 func RegisterDomainAPI(router gin.IRouter) {
-	router.GET("/ns/lookup", endpoint.GetAddressByCoinAndDomain)
-	router.GET("/v2/ns/lookup", endpoint.GetAddressByCoinAndDomainBatch)
+	Routes.GET(router, openapi.Operation{
+		Path:    "/ns/lookup",
+		ID:      "lookup",
+		Summary: "Lookup ENS/ZNS to address",
+		Tags:    []string{"Naming"},
+		Query: []openapi.Param{
+			{Name: "name", Description: "Domain name", Required: true},
+			{Name: "coin", Description: "Coin ID", Required: true},
+		},
+		Response: types.Resolved{},
+	}, endpoint.GetAddressByCoinAndDomain)
+	Routes.GET(router, openapi.Operation{
+		Path:    "/v2/ns/lookup",
+		ID:      "lookup_batch",
+		Summary: "Lookup ENS/ZNS to address",
+		Tags:    []string{"Naming"},
+		Query: []openapi.Param{
+			{Name: "name", Description: "Domain name", Required: true},
+			{Name: "coins", Description: "Comma separated list of coin IDs", Required: true},
+		},
+		Response: []types.Resolved{},
+	}, endpoint.GetAddressByCoinAndDomainBatch)
+}
+
+var authHeader = openapi.Param{Name: "Authorization", Description: "Bearer device token", Required: true}
+
+func RegisterAddressBookAPI(router gin.IRouter, storage endpoint.AddressBookStorage) {
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/addressbook/tokens",
+		ID:       "addressbook_token",
+		Summary:  "Create an address book device token",
+		Tags:     []string{"Address Book"},
+		Headers:  []openapi.Param{{Name: "Authorization", Description: "Bearer token of the address book to add the device to"}},
+		Request:  endpoint.AddressBookTokenRequest{},
+		Response: types.AddressBookToken{},
+	}, func(c *gin.Context) {
+		endpoint.CreateAddressBookToken(c, storage)
+	})
+
+	auth := endpoint.RequireAddressBook(storage)
+	Routes.POST(router, openapi.Operation{
+		Path:    "/v1/addressbook/tokens/revoke",
+		ID:      "addressbook_token_delete",
+		Summary: "Revoke the device token",
+		Tags:    []string{"Address Book"},
+		Headers: []openapi.Param{authHeader},
+	}, auth, func(c *gin.Context) {
+		endpoint.DeleteAddressBookToken(c, storage)
+	})
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/addressbook",
+		ID:       "addressbook",
+		Summary:  "Get address book entries",
+		Tags:     []string{"Address Book"},
+		Query:    []openapi.Param{{Name: "since", Description: "Unix timestamp of the last sync"}},
+		Headers:  []openapi.Param{authHeader},
+		Response: types.AddressBookPage{},
+	}, auth, func(c *gin.Context) {
+		endpoint.GetAddressBook(c, storage)
+	})
+	Routes.POST(router, openapi.Operation{
+		Path:     "/v1/addressbook",
+		ID:       "addressbook_add",
+		Summary:  "Add an address book entry",
+		Tags:     []string{"Address Book"},
+		Headers:  []openapi.Param{authHeader},
+		Request:  endpoint.AddressBookEntryRequest{},
+		Response: types.AddressBookEntry{},
+	}, auth, func(c *gin.Context) {
+		endpoint.AddAddressBookEntry(c, storage)
+	})
+	Routes.PUT(router, openapi.Operation{
+		Path:     "/v1/addressbook/:id",
+		ID:       "addressbook_update",
+		Summary:  "Update an address book entry",
+		Tags:     []string{"Address Book"},
+		Headers:  []openapi.Param{authHeader},
+		Request:  endpoint.AddressBookEntryRequest{},
+		Response: types.AddressBookEntry{},
+	}, auth, func(c *gin.Context) {
+		endpoint.UpdateAddressBookEntry(c, storage)
+	})
+	Routes.DELETE(router, openapi.Operation{
+		Path:    "/v1/addressbook/:id",
+		ID:      "addressbook_delete",
+		Summary: "Delete an address book entry",
+		Tags:    []string{"Address Book"},
+		Headers: []openapi.Param{authHeader},
+	}, auth, func(c *gin.Context) {
+		endpoint.DeleteAddressBookEntry(c, storage)
+	})
+}
+
+// RegisterTxNotesAPI serves the transaction notes of the holders of the notes API keys
+func RegisterTxNotesAPI(router gin.IRouter, notes *endpoint.TxNotes) {
+	auth := middleware.RequireAPIKey(APIKeyHeader, notes.APIKeys)
+	headers := []openapi.Param{{Name: APIKeyHeader, Description: "Notes API key", Required: true}}
+	storage := notes.Storage
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/notes/:coin",
+		ID:       "tx_notes",
+		Summary:  "Get transaction notes",
+		Tags:     []string{"Transactions"},
+		Headers:  headers,
+		Response: []types.TxNote{},
+	}, auth, func(c *gin.Context) {
+		endpoint.GetTxNotes(c, storage)
+	})
+	Routes.PUT(router, openapi.Operation{
+		Path:     "/v1/notes/:coin/:hash",
+		ID:       "tx_note_set",
+		Summary:  "Set a transaction note",
+		Tags:     []string{"Transactions"},
+		Headers:  headers,
+		Request:  endpoint.TxNoteRequest{},
+		Response: types.TxNote{},
+	}, auth, func(c *gin.Context) {
+		endpoint.SetTxNote(c, storage)
+	})
+	Routes.DELETE(router, openapi.Operation{
+		Path:    "/v1/notes/:coin/:hash",
+		ID:      "tx_note_delete",
+		Summary: "Delete a transaction note",
+		Tags:    []string{"Transactions"},
+		Headers: headers,
+	}, auth, func(c *gin.Context) {
+		endpoint.DeleteTxNote(c, storage)
+	})
+}
+
+// RegisterCapabilitiesAPI lists the features of the registered platforms
+func RegisterCapabilitiesAPI(router gin.IRouter) {
+	capabilities := platform.Capabilities()
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/capabilities",
+		ID:       "capabilities",
+		Summary:  "Get coin capabilities",
+		Tags:     []string{"Info"},
+		Response: []blockatlas.CoinCapabilities{},
+	}, func(c *gin.Context) {
+		endpoint.GetCapabilities(c, capabilities, platform.DeploymentMode())
+	})
+}
+
+// RegisterSigningKeyAPI serves the public key of the response signatures
+func RegisterSigningKeyAPI(router gin.IRouter, signer *middleware.ResponseSigner) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/signing-key",
+		ID:       "signing_key",
+		Summary:  "Get the response signing key",
+		Tags:     []string{"Info"},
+		Response: endpoint.SigningKey{},
+	}, func(c *gin.Context) {
+		endpoint.GetSigningKey(c, signer)
+	})
+}
+
+func RegisterErrorsAPI(router gin.IRouter) {
+	Routes.GET(router, openapi.Operation{
+		Path:     "/v1/errors",
+		ID:       "error_codes",
+		Summary:  "Get error codes",
+		Tags:     []string{"Info"},
+		Response: []endpoint.ErrorCatalogEntry{},
+	}, endpoint.GetErrorCatalog)
 }
 
 func RegisterBasicAPI(router gin.IRouter) {
 	router.GET("/", endpoint.GetStatus)
 	router.GET("/metrics", ginprom.PromHandler(promhttp.Handler()))
+	router.GET("/openapi.json", Routes.Handler("Block Atlas API", internal.Build))
 }
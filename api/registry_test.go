@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type tokensPlatformMock struct{}
+
+func (m tokensPlatformMock) Coin() coin.Coin { return coin.Ethereum() }
+
+func (m tokensPlatformMock) GetTxsByAddress(address string) (types.TxPage, error) {
+	return types.TxPage{{ID: "0x1", From: address, Fee: "0", Type: types.TxTransfer, Meta: types.Transfer{Value: "1"}}}, nil
+}
+
+func (m tokensPlatformMock) GetTokenListByAddress(address string) (types.TokenPage, error) {
+	return types.TokenPage{{Name: "Tether", Symbol: "USDT", Decimals: 6, TokenID: "0xdac17f958d2ee523a2206206994597c13d831ec7", Balance: "1000000"}}, nil
+}
+
+func TestRegisterTokensAPI_AddressTokens(t *testing.T) {
+	router := gin.New()
+	RegisterTransactionsAPI(router, tokensPlatformMock{}, nil)
+	RegisterTokensAPI(router, tokensPlatformMock{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/ethereum/address/0xabc/tokens", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var tokens struct {
+		Docs types.TokenPage `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.Len(t, tokens.Docs, 1)
+	assert.Equal(t, "USDT", tokens.Docs[0].Symbol)
+	assert.Equal(t, "1000000", tokens.Docs[0].Balance)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/ethereum/0xabc", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var txs struct {
+		Docs types.TxPage `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &txs))
+	assert.Len(t, txs.Docs, 1)
+	assert.Equal(t, "0xabc", txs.Docs[0].From)
+}
@@ -1,23 +1,47 @@
+//go:generate go run github.com/swaggo/swag/cmd/swag init --parseDependency -d ../../ -g cmd/api/main.go -o ../../docs
+
 package main
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas/api"
+	"github.com/trustwallet/blockatlas/api/endpoint"
+	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/db"
 	_ "github.com/trustwallet/blockatlas/docs"
 	"github.com/trustwallet/blockatlas/internal"
+	"github.com/trustwallet/blockatlas/mq"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/logger"
 	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/platform/ethereum"
+	"github.com/trustwallet/blockatlas/services/classifier"
+	"github.com/trustwallet/blockatlas/services/indexer"
+	"github.com/trustwallet/blockatlas/services/lending"
+	"github.com/trustwallet/blockatlas/services/longpoll"
+	"github.com/trustwallet/blockatlas/services/market"
+	"github.com/trustwallet/blockatlas/services/monitor"
+	"github.com/trustwallet/blockatlas/services/observer/notifier"
+	"github.com/trustwallet/blockatlas/services/observer/screening"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"time"
 )
 
 const (
 	defaultPort       = "8420"
 	defaultConfigPath = "../../config.yml"
+	prod              = "prod"
 )
 
 var (
 	port, confPath string
 	engine         *gin.Engine
+	database       *db.Instance
+	rates          *market.FiatRates
 )
 
 func init() {
@@ -29,17 +53,379 @@ func init() {
 	engine = internal.InitEngine(viper.GetString("gin.mode"))
 
 	platform.Init(viper.GetStringSlice("platform"))
+
+	if viper.GetBool("indexer.enabled") || viper.GetBool("addressbook.enabled") || viper.GetBool("notes.enabled") || viper.GetBool("observer.replay.enabled") ||
+		viper.GetBool("observer.subscriptions.api.enabled") || viper.GetBool("observer.block_number.enabled") || viper.GetBool("lending.alerts.enabled") ||
+		(viper.GetBool("market.enabled") && viper.GetString("market.store") == "postgres") {
+		database = initDatabase()
+	}
+
+	var index ethereum.ExplorerBackend
+	if viper.GetBool("indexer.enabled") {
+		var coins map[string]indexer.CoinConfig
+		if err := viper.UnmarshalKey("indexer.coins", &coins); err != nil {
+			logger.Fatal(err, "Invalid indexer coins config")
+		}
+		storage, err := indexer.NewStorage(database, coins)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		index = storage
+	}
+	platform.InitExplorers(index)
+
+	if viper.GetBool("observer.replay.enabled") || viper.GetBool("observer.subscriptions.api.enabled") || viper.GetBool("longpoll.enabled") {
+		initRabbitMQ()
+	}
+	if viper.GetBool("observer.replay.enabled") {
+		initReplay()
+	}
+	if viper.GetBool("observer.subscriptions.api.enabled") {
+		if err := mq.Subscriptions.Declare(); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	if viper.GetBool("longpoll.enabled") {
+		initLongPoll()
+	}
+	if viper.GetBool("upstream.block_cache.enabled") {
+		api.EnableBlockCache(viper.GetDuration("upstream.block_cache.height_interval"), viper.GetDuration("upstream.block_cache.ttl"))
+	}
+	if viper.GetBool("lending.info_cache.enabled") {
+		initProviderInfoCache()
+	}
+	if viper.GetString("market.rates.name") != "" {
+		rates = initRates()
+	}
+	if pricesAPI := viper.GetString("lending.prices_api"); pricesAPI != "" {
+		checkSelfHosted("lending prices", pricesAPI)
+		var prices market.PriceSource = market.NewCoinGecko(pricesAPI)
+		if rates != nil {
+			prices = market.ConvertedPrices{Source: prices, Rates: rates}
+		}
+		api.EnablePrices(prices)
+	}
+
+	if path := viper.GetString("labels.path"); path != "" {
+		if err := classifier.Labels.LoadLabels(path); err != nil {
+			logger.Fatal(err)
+		}
+	}
+}
+
+// initDatabase connects to Postgres, only needed by the optional token index, address book, replays, quotas, lending alerts and block numbers
+func initDatabase() *db.Instance {
+	pgUri := viper.GetString("postgres.uri")
+	database, err := db.New(pgUri, prod)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
+	internal.InitReplica(database, prod)
+	return database
+}
+
+// initRabbitMQ connects to the observer queues, only needed by the replays, the subscriptions API and the long polling
+func initRabbitMQ() {
+	internal.InitRabbitMQ(viper.GetString("observer.rabbitmq.uri"), viper.GetInt("observer.rabbitmq.consumer.prefetch_count"))
+	go mq.FatalWorker(time.Second * 10)
+}
+
+// initReplay declares the notifications queue the replays are published to
+func initReplay() {
+	if err := mq.TxNotifications.Declare(); err != nil {
+		logger.Fatal(err)
+	}
+	if limit := viper.GetUint("observer.push_notifications_batch_limit"); limit > 0 {
+		notifier.MaxPushNotificationsBatchLimit = limit
+	}
+}
+
+// initLongPoll feeds the waiting transactions requests with the batches of the parser,
+// set before the routes are registered
+func initLongPoll() {
+	if err := mq.NewTransactions.Declare(); err != nil {
+		logger.Fatal(err)
+	}
+	messages, err := mq.NewTransactions.Subscribe()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	hub := longpoll.NewHub()
+	go hub.Run(messages, context.Background())
+	maxWait := viper.GetDuration("longpoll.max_wait")
+	if maxWait <= 0 {
+		maxWait = longpoll.DefaultMaxWait
+	}
+	api.EnableLongPoll(hub, maxWait)
+}
+
+// initScreening screens the subscriptions of the API with the rules of observer.subscriptions.screening,
+// then its callout URL when set
+func initScreening() {
+	if !viper.GetBool("observer.subscriptions.screening.enabled") {
+		return
+	}
+	var rules screening.Rules
+	if err := viper.UnmarshalKey("observer.subscriptions.screening", &rules); err != nil {
+		logger.Fatal(err, "invalid subscription screening rules")
+	}
+	screener := screening.Chain{rules}
+	if url := viper.GetString("observer.subscriptions.screening.callout.url"); url != "" {
+		callout := &screening.Callout{
+			Request:  blockatlas.InitJSONClient(url),
+			FailOpen: viper.GetBool("observer.subscriptions.screening.callout.fail_open"),
+		}
+		if timeout := viper.GetDuration("observer.subscriptions.screening.callout.timeout"); timeout > 0 {
+			callout.HttpClient = &http.Client{Timeout: timeout}
+		}
+		screener = append(screener, callout)
+	}
+	api.EnableScreening(screener)
+	logger.Info("Subscription screening", logger.Params{"addresses": len(rules.Addresses), "webhook_hosts": len(rules.WebhookHosts), "callout": len(screener) > 1})
+}
+
+// initProviderInfoCache keeps the lending provider info in memory, or in Redis with lending.info_cache.redis
+func initProviderInfoCache() {
+	store := lending.NewMemoryStore()
+	if uri := viper.GetString("lending.info_cache.redis"); uri != "" {
+		redis, err := lending.NewRedisStore(uri)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		store = redis
+	}
+	ttls := make(map[string]time.Duration)
+	for id, value := range viper.GetStringMapString("lending.info_cache.ttls") {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			logger.Fatal(err, "invalid lending info ttl", logger.Params{"lending_provider": id})
+		}
+		ttls[id] = ttl
+	}
+	api.EnableProviderInfoCache(lending.NewInfoCache(store, viper.GetDuration("lending.info_cache.ttl"), ttls))
+}
+
+// initRates converts the prices of the market and lending endpoints with the fiat rates of market.rates
+func initRates() *market.FiatRates {
+	var config market.ProviderConfig
+	if err := viper.UnmarshalKey("market.rates", &config); err != nil {
+		logger.Fatal(err, "invalid market rates")
+	}
+	checkSelfHosted(config.Name, config.API)
+	provider, err := market.NewRatesProvider(config)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	logger.Info("Fiat rates enabled", logger.Params{"provider": provider.Name()})
+	return market.NewFiatRates(provider, viper.GetDuration("market.rates.ttl"))
+}
+
+// initMarket pulls the tickers of market.providers into the market.store in the background,
+// the providers with a price history serving the charts in the same priority
+func initMarket() (*market.Tickers, *market.Charts) {
+	var configs []market.ProviderConfig
+	if err := viper.UnmarshalKey("market.providers", &configs); err != nil {
+		logger.Fatal(err, "invalid market providers")
+	}
+	providers := make([]market.TickerProvider, 0, len(configs))
+	charts := market.NewCharts()
+	for _, config := range configs {
+		checkSelfHosted(config.Name, config.API)
+		provider, err := market.NewTickerProvider(config)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		providers = append(providers, provider)
+		if chart, ok := provider.(market.ChartProvider); ok {
+			charts.Providers = append(charts.Providers, chart)
+		}
+	}
+
+	var store market.TickerStore = market.NewMemoryStore()
+	switch viper.GetString("market.store") {
+	case "redis":
+		redis, err := lending.NewRedisStore(viper.GetString("market.redis"))
+		if err != nil {
+			logger.Fatal(err)
+		}
+		store = market.NewKVStore(redis, viper.GetDuration("market.ttl"))
+	case "postgres":
+		store = market.PostgresStore{Database: database}
+	}
+
+	tickers := market.NewTickers(store, viper.GetDuration("market.max_age"), providers...)
+	tickers.Rates, charts.Rates = rates, rates
+	go tickers.Run(viper.GetStringSlice("market.coins"), viper.GetStringSlice("market.currencies"),
+		viper.GetDuration("market.interval"), context.Background())
+	logger.Info("Market tickers enabled", logger.Params{"providers": len(providers), "charts": len(charts.Providers), "store": viper.GetString("market.store")})
+	return tickers, charts
+}
+
+// checkSelfHosted stops the start-up in self-hosted mode when the API of the provider is a third-party one
+func checkSelfHosted(provider, api string) {
+	if platform.SelfHosted && !platform.IsSelfHostedURL(api) {
+		logger.Fatal("Third-party API in self-hosted mode", logger.Params{"provider": provider, "api": api})
+	}
+}
+
+// initLanes serves the batch consumers and the wallet calls with separate request workers
+func initLanes() {
+	var lanes map[middleware.Lane]middleware.LaneConfig
+	if err := viper.UnmarshalKey("lanes.pools", &lanes); err != nil {
+		logger.Fatal(err, "invalid lanes")
+	}
+	engine.Use(middleware.PriorityLanes(api.LaneClassifier(viper.GetStringSlice("lanes.batch_api_keys")), lanes))
+	logger.Info("Priority lanes enabled", logger.Params{"lanes": lanes})
+}
+
+// initRecording records the requests of the debug.recording.api_keys with their responses, retrieved
+// and switched to other keys on the admin routes
+func initRecording() *middleware.Recorder {
+	recorder := middleware.NewRecorder(viper.GetInt("debug.recording.size"), viper.GetInt("debug.recording.max_body"),
+		viper.GetStringSlice("debug.recording.api_keys"))
+	engine.Use(middleware.RecordRequests(api.APIKeyHeader, recorder))
+	logger.Warn("Debug recording enabled, the requests of the recorded API keys are kept in memory")
+	return recorder
+}
+
+// initSigning signs the response bodies with the Ed25519 key of signing.private_key, before the provenance
+// middleware so the annotated bodies are the ones signed
+func initSigning() *middleware.ResponseSigner {
+	signer, err := middleware.NewResponseSigner(viper.GetString("signing.private_key"), viper.GetString("signing.key_id"))
+	if err != nil {
+		logger.Fatal(err, "invalid signing.private_key")
+	}
+	engine.Use(middleware.SignResponses(signer))
+	logger.Info("Response signing", logger.Params{"key_id": signer.KeyID})
+	return signer
 }
 
+// @title Block Atlas API
+// @version 1.0
+// @description Transactions, tokens, staking, collections and lending markets of the supported coins
+// @BasePath /
 func main() {
+	var recorder *middleware.Recorder
+	if viper.GetBool("debug.recording.enabled") {
+		recorder = initRecording()
+	}
+	if viper.GetBool("lanes.enabled") {
+		initLanes()
+	}
+	var signer *middleware.ResponseSigner
+	if viper.GetBool("signing.enabled") {
+		signer = initSigning()
+	}
+	if viper.GetBool("debug.provenance") {
+		engine.Use(middleware.Provenance(api.ProvenanceResolver(platform.Upstreams)))
+	}
+	var notes *endpoint.TxNotes
+	if viper.GetBool("notes.enabled") {
+		notes = &endpoint.TxNotes{Storage: database, APIKeys: viper.GetStringSlice("notes.api_keys")}
+	}
 	switch viper.GetString("rest_api") {
 	case "swagger":
 		api.SetupSwaggerAPI(engine)
 	case "platform":
-		api.SetupPlatformAPI(engine)
+		api.SetupPlatformAPI(engine, notes)
+	case "market":
+		api.SetupMarketAPI(engine)
 	default:
 		api.SetupSwaggerAPI(engine)
-		api.SetupPlatformAPI(engine)
+		api.SetupPlatformAPI(engine, notes)
+	}
+	if len(platform.LendingAPIs) > 0 {
+		interval := viper.GetDuration("lending.refresh_interval")
+		if interval <= 0 {
+			interval = lending.DefaultRefreshInterval
+		}
+		go lending.RunCacheRefresher(lending.Providers, platform.LendingAPIs, interval, context.Background())
+	}
+	if viper.GetBool("lending.alerts.enabled") && len(platform.LendingAPIs) > 0 {
+		api.RegisterLendingAlertsAPI(engine, platform.LendingAPIs, database, viper.GetStringSlice("lending.alerts.api_keys"))
+	}
+	if viper.GetBool("market.enabled") {
+		tickers, charts := initMarket()
+		api.RegisterMarketAPI(engine, tickers, charts)
+	}
+	if rates != nil {
+		api.RegisterRatesAPI(engine, rates)
+	}
+	if viper.GetBool("export.enabled") {
+		api.RegisterExportAPI(engine, platform.BlockAPIs, viper.GetStringSlice("export.api_keys"))
+	}
+	admin := engine
+	var adminServer *http.Server
+	if viper.GetBool("admin.enabled") {
+		admin, adminServer = internal.InitAdminServer(engine)
+		api.RegisterAdminAPI(admin, viper.GetStringSlice("admin.api_keys"))
+		if len(platform.LendingAPIs) > 0 {
+			api.RegisterLendingAdminAPI(admin, platform.LendingAPIs, viper.GetStringSlice("admin.api_keys"))
+		}
+		if recorder != nil {
+			api.RegisterRecordingsAPI(admin, recorder, viper.GetStringSlice("admin.api_keys"))
+		}
+	}
+	if viper.GetBool("observer.replay.enabled") {
+		api.RegisterReplayAPI(engine, notifier.Replayer{Database: database}, viper.GetStringSlice("observer.replay.api_keys"))
+	}
+	if viper.GetBool("observer.subscriptions.api.enabled") {
+		initScreening()
+		api.RegisterSubscriptionsAPI(engine, mq.Subscriptions, database, internal.InitTenants(), viper.GetStringSlice("observer.subscriptions.api.api_keys"))
+	}
+	if viper.GetBool("observer.block_number.enabled") {
+		api.RegisterBlockNumberAPI(engine, platform.BlockAPIs, database)
+	}
+	if viper.GetBool("addressbook.enabled") {
+		api.RegisterAddressBookAPI(engine, database)
+	}
+	if notes != nil {
+		api.RegisterTxNotesAPI(engine, notes)
+	}
+	if viper.GetBool("monitor.enabled") {
+		initMonitor(admin)
+	}
+	if signer != nil {
+		api.RegisterSigningKeyAPI(engine, signer)
+	}
+	if viper.GetBool("grpc.enabled") {
+		grpcServer := initGRPC()
+		defer grpcServer.GracefulStop()
+	}
+	internal.SetupGracefulShutdown(port, engine, adminServer)
+}
+
+// initGRPC serves the gRPC service on grpc.port until the shutdown
+func initGRPC() *grpc.Server {
+	listener, err := net.Listen("tcp", ":"+viper.GetString("grpc.port"))
+	if err != nil {
+		logger.Fatal(err, "gRPC listener failed")
+	}
+	server := api.SetupGRPCAPI().GRPCServer()
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logger.Fatal(err, "gRPC server failed")
+		}
+	}()
+	logger.Info("gRPC listener", logger.Params{"bind": viper.GetString("grpc.port")})
+	return server
+}
+
+// initMonitor calls the critical endpoints of this instance every monitor.interval, listed
+// on /admin/monitor of the admin engine with admin.enabled
+func initMonitor(admin *gin.Engine) {
+	interval := viper.GetDuration("monitor.interval")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	timeout := viper.GetDuration("monitor.timeout")
+	if timeout <= 0 {
+		timeout = time.Second * 10
+	}
+	m := monitor.New("http://127.0.0.1:"+port, monitor.Checks(platform.Platforms), timeout)
+	if viper.GetBool("admin.enabled") {
+		api.RegisterMonitorAPI(admin, m, viper.GetStringSlice("admin.api_keys"))
 	}
-	internal.SetupGracefulShutdown(port, engine)
+	go monitor.RunMonitor(m, interval, context.Background())
 }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/internal"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/services/indexer"
+	"github.com/trustwallet/blockatlas/services/retention"
+)
+
+const (
+	defaultConfigPath = "../../config.yml"
+	prod              = "prod"
+)
+
+var (
+	confPath    string
+	database    *db.Instance
+	coins       map[string]indexer.CoinConfig
+	interval    time.Duration
+	blocksBatch int64
+)
+
+func init() {
+	_, confPath = internal.ParseArgs("", defaultConfigPath)
+
+	internal.InitConfig(confPath)
+	logger.InitLogger()
+
+	if err := viper.UnmarshalKey("indexer.coins", &coins); err != nil {
+		logger.Fatal(err, "Invalid indexer coins config")
+	}
+	if len(coins) == 0 {
+		logger.Fatal("No coins to index")
+	}
+
+	interval = viper.GetDuration("indexer.interval")
+	blocksBatch = viper.GetInt64("indexer.blocks_batch")
+	if blocksBatch < indexer.MinBlocksBatch {
+		blocksBatch = indexer.MinBlocksBatch
+	}
+
+	pgUri := viper.GetString("postgres.uri")
+	var err error
+	database, err = db.New(pgUri, prod)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
+	time.Sleep(time.Millisecond)
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	for handle, config := range coins {
+		c, ok := indexer.CoinByHandle(handle)
+		if !ok {
+			logger.Fatal("Unknown coin handle", logger.Params{"handle": handle})
+		}
+		config.Tokens = config.IndexedTokens(c.ID)
+		if len(config.Tokens) == 0 {
+			logger.Fatal("No tokens to index", logger.Params{"handle": handle})
+		}
+		rpcURL := platform.GetRpcVar(c.ID)
+		if rpcURL == "" {
+			logger.Fatal("Missing RPC url", logger.Params{"handle": handle})
+		}
+		go indexer.RunIndexer(indexer.Params{
+			Ctx:         ctx,
+			Coin:        c,
+			RpcURL:      rpcURL,
+			Tokens:      config.Tokens,
+			Interval:    interval,
+			BlocksBatch: blocksBatch,
+			StartBlock:  config.StartBlock,
+			Database:    database,
+		})
+		logger.Info("Token indexer params", logger.Params{
+			"coin":         handle,
+			"tokens":       len(config.Tokens),
+			"start_block":  config.StartBlock,
+			"blocks_batch": blocksBatch,
+			"interval":     interval,
+		})
+	}
+
+	if viper.GetBool("retention.enabled") {
+		internal.RunRetention(database, []retention.Table{retention.TokenTransfers(database)}, ctx)
+	}
+
+	internal.SetupGracefulShutdownForObserver(cancel)
+}
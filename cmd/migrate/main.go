@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/migrations"
+	"github.com/trustwallet/blockatlas/internal"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	defaultConfigPath = "../../config.yml"
+	prod              = "prod"
+)
+
+var (
+	confPath string
+	database *db.Instance
+)
+
+func init() {
+	_, confPath = internal.ParseArgs("", defaultConfigPath)
+
+	internal.InitConfig(confPath)
+	logger.InitLogger()
+
+	var err error
+	database, err = db.Open(viper.GetString("postgres.uri"), prod)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	internal.InitKeyring(database)
+}
+
+// Usage: migrate -c config.yml [up | down <steps> | version | rotate-secrets | decrypt-secrets]
+func main() {
+	defer database.Gorm.Close()
+	ctx := context.Background()
+
+	command := flag.Arg(0)
+	switch command {
+	case "", "up":
+		applied, err := database.MigrateUp(ctx)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Database migrated", logger.Params{"applied": applied, "version": migrations.Latest()})
+	case "down":
+		steps := 1
+		if arg := flag.Arg(1); arg != "" {
+			var err error
+			if steps, err = strconv.Atoi(arg); err != nil || steps < 1 {
+				logger.Fatal("Invalid number of steps", logger.Params{"steps": arg})
+			}
+		}
+		reverted, err := database.MigrateDown(steps, ctx)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Database migrations reverted", logger.Params{"reverted": reverted})
+	case "version":
+		version, err := database.SchemaVersion(ctx)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Database schema version", logger.Params{"version": version, "latest": migrations.Latest()})
+	case "rotate-secrets":
+		changed, err := database.RotateChannelTokens(ctx)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Channel tokens encrypted with the current key", logger.Params{"changed": changed})
+	case "decrypt-secrets":
+		changed, err := database.DecryptChannelTokens(ctx)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Channel tokens decrypted", logger.Params{"changed": changed})
+	default:
+		logger.Fatal("Unknown command, expected up, down, version, rotate-secrets or decrypt-secrets", logger.Params{"command": command})
+	}
+}
@@ -3,11 +3,19 @@ package main
 import (
 	"context"
 	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/api"
 	"github.com/trustwallet/blockatlas/db"
 	"github.com/trustwallet/blockatlas/internal"
 	"github.com/trustwallet/blockatlas/mq"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/services/lending"
 	"github.com/trustwallet/blockatlas/services/observer/notifier"
+	"github.com/trustwallet/blockatlas/services/observer/notifier/push"
+	"github.com/trustwallet/blockatlas/services/retention"
+	"io/ioutil"
+	"net/http"
 	"time"
 )
 
@@ -34,19 +42,20 @@ func init() {
 
 	internal.InitRabbitMQ(mqHost, prefetchCount)
 
-	if err := mq.RawTransactions.Declare(); err != nil {
-		logger.Fatal(err)
+	declarers := []mq.Declarer{mq.RawTransactions, mq.TxNotifications, mq.SubscriptionsExpiring}
+	notifier.Tenants = internal.InitTenants()
+	for _, name := range notifier.Tenants.Names() {
+		declarers = append(declarers, notifier.NotificationsQueue(name))
 	}
-
-	if err := mq.TxNotifications.Declare(); err != nil {
-		logger.Fatal(err)
+	if viper.GetBool("observer.channels.webhook.enabled") && viper.GetBool("observer.channels.webhook.dead_letter") {
+		declarers = append(declarers, mq.WebhookDeadLetters)
 	}
-
-	var err error
-	database, err = db.New(pgUri, prod)
-	if err != nil {
-		logger.Fatal(err)
+	for _, d := range declarers {
+		if err := d.Declare(); err != nil {
+			logger.Fatal(err)
+		}
 	}
+	notifier.RecordUsage = !viper.GetBool("snapshot.enabled")
 
 	if maxPushNotificationsBatchLimit == 0 {
 		notifier.MaxPushNotificationsBatchLimit = notifier.DefaultPushNotificationsBatchLimit
@@ -56,18 +65,192 @@ func init() {
 
 	logger.Info("maxPushNotificationsBatchLimit ", logger.Params{"limit": maxPushNotificationsBatchLimit})
 
-	go mq.FatalWorker(time.Second * 10)
-	go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
+	initDrivers()
+	initTemplates()
+
+	if viper.GetBool("observer.dedup.enabled") {
+		notifier.Dedup = notifier.NewTxDedup(viper.GetDuration("observer.dedup.ttl"))
+	}
+
+	if viper.GetBool("observer.balances.enabled") {
+		platform.Init(viper.GetStringSlice("platform"))
+		notifier.Balances = platform.BalanceAPIs
+	}
+
+	if viper.GetBool("observer.rabbitmq.reconnect") {
+		go mq.RestoreConnectionWorker(mqHost, time.Second*10, declarers...)
+	} else {
+		go mq.FatalWorker(time.Second * 10)
+	}
+	if database = internal.InitMemoryDatabase(); database == nil {
+		var err error
+		database, err = db.New(pgUri, prod)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
+		internal.InitReplica(database, prod)
+		internal.InitKeyring(database)
+	}
 
 	time.Sleep(time.Millisecond)
 }
 
+func initDrivers() {
+	if viper.GetBool("observer.channels.fcm.enabled") {
+		notifier.Drivers[types.ChannelFCM] = push.NewFCM(viper.GetString("observer.channels.fcm.server_key"))
+	}
+	if viper.GetBool("observer.channels.apns.enabled") {
+		key, err := ioutil.ReadFile(viper.GetString("observer.channels.apns.key_path"))
+		if err != nil {
+			logger.Fatal(err, "failed to read apns key")
+		}
+		driver, err := push.NewAPNs(
+			key,
+			viper.GetString("observer.channels.apns.key_id"),
+			viper.GetString("observer.channels.apns.team_id"),
+			viper.GetString("observer.channels.apns.topic"),
+			viper.GetBool("observer.channels.apns.production"),
+		)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		notifier.Drivers[types.ChannelAPNs] = driver
+	}
+	if viper.GetBool("observer.channels.telegram.enabled") {
+		notifier.Drivers[types.ChannelTelegram] = push.NewTelegram(viper.GetString("observer.channels.telegram.bot_token"))
+	}
+	if viper.GetBool("observer.channels.slack.enabled") {
+		notifier.Drivers[types.ChannelSlack] = push.NewSlack()
+	}
+	if viper.GetBool("observer.channels.webhook.enabled") {
+		retries := push.DefaultWebhookRetries
+		if viper.IsSet("observer.channels.webhook.retries") {
+			retries = viper.GetInt("observer.channels.webhook.retries")
+		}
+		backoff := viper.GetDuration("observer.channels.webhook.backoff")
+		if backoff <= 0 {
+			backoff = push.DefaultWebhookBackoff
+		}
+		webhook := push.NewWebhook(retries, backoff)
+		if viper.GetBool("faults.enabled") {
+			webhook.DropRate = viper.GetFloat64("faults.webhook_drop_rate")
+		}
+		if viper.GetBool("observer.channels.webhook.dead_letter") {
+			webhook.DeadLetters = mq.WebhookDeadLetters
+		}
+		notifier.Drivers[types.ChannelWebhook] = webhook
+	}
+	for provider := range notifier.Drivers {
+		logger.Info("Channel enabled", logger.Params{"provider": provider})
+	}
+}
+
+func initTemplates() {
+	defaultLocale := viper.GetString("observer.templates.default_locale")
+	if defaultLocale == "" {
+		defaultLocale = notifier.DefaultLocale
+	}
+	notifier.MessageTemplates = notifier.NewTemplates(defaultLocale)
+
+	var templates map[string]map[string]notifier.Template
+	if err := viper.UnmarshalKey("observer.templates.locales", &templates); err != nil {
+		logger.Fatal(err, "invalid notification templates")
+	}
+	if err := notifier.MessageTemplates.AddTemplates(templates); err != nil {
+		logger.Fatal(err)
+	}
+}
+
 func main() {
 	defer mq.Close()
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go mq.RawTransactions.RunConsumerWithCancelAndDbConn(notifier.RunNotifier, database, ctx)
+	size := viper.GetInt("observer.workers.size")
+	if size <= 0 {
+		size = viper.GetInt("observer.rabbitmq.consumer.prefetch_count")
+	}
+	pool := mq.NewWorkerPool(size)
+	scaler := mq.NewAutoscaler(mq.RawTransactions, pool,
+		viper.GetInt("observer.workers.min"),
+		viper.GetInt("observer.workers.max"),
+		viper.GetDuration("observer.workers.target_drain"),
+	)
+	go mq.RawTransactions.RunConsumerWithPool(notifier.RunNotifier, database, pool, ctx)
+	go scaler.Run(viper.GetDuration("observer.workers.sample_interval"), ctx)
+
+	// The digests are kept in Postgres
+	if viper.GetBool("observer.digest.enabled") && !viper.GetBool("snapshot.enabled") {
+		interval := viper.GetDuration("observer.digest.interval")
+		if interval <= 0 {
+			interval = notifier.DefaultDigestInterval
+		}
+		go notifier.RunDigestScheduler(database, interval, ctx)
+	}
+
+	// The expiries of the subscriptions are kept in Postgres
+	if !viper.GetBool("snapshot.enabled") {
+		interval := viper.GetDuration("observer.subscriptions.cleanup_interval")
+		if interval <= 0 {
+			interval = notifier.DefaultExpiryInterval
+		}
+		notice := viper.GetDuration("observer.subscriptions.notice")
+		if notice <= 0 {
+			notice = notifier.DefaultExpiryNotice
+		}
+		go notifier.RunExpiryWorker(database, interval, notice, ctx)
+	}
 
-	internal.SetupGracefulShutdownForObserver(cancel)
+	// The notification history is kept in Postgres
+	if viper.GetBool("observer.replay.enabled") && !viper.GetBool("snapshot.enabled") {
+		retention := viper.GetDuration("observer.replay.retention")
+		if retention <= 0 {
+			retention = notifier.DefaultHistoryRetention
+		}
+		notifier.KeepHistory = true
+		go notifier.RunHistoryCleanup(database, retention, ctx)
+	}
+
+	// The notification history is kept in Postgres
+	if viper.GetBool("retention.enabled") && !viper.GetBool("snapshot.enabled") {
+		internal.RunRetention(database, []retention.Table{retention.NotificationHistory(database)}, ctx)
+	}
+
+	// The lending rate alerts are kept in Postgres
+	if viper.GetBool("observer.lending_alerts.enabled") && !viper.GetBool("snapshot.enabled") {
+		interval := viper.GetDuration("observer.lending_alerts.interval")
+		if interval <= 0 {
+			interval = lending.DefaultRefreshInterval
+		}
+		platform.InitLending()
+		senders := lending.AlertSenders{
+			Rates: func(alerts []lending.RateAlert, ctx context.Context) {
+				notifier.SendRateAlerts(database, alerts, ctx)
+			},
+			Liquidations: func(alerts []lending.LiquidationAlert, ctx context.Context) {
+				notifier.SendLiquidationAlerts(database, alerts, ctx)
+			},
+		}
+		go lending.RunRefresher(database, platform.LendingAPIs, interval, senders, ctx)
+	}
+
+	internal.SetupGracefulShutdownForObserver(cancel, initListener(scaler))
+}
+
+// initListener serves the metrics and the autoscaling recommendation on observer.listener.port, with the
+// resizing of the worker pool with admin.enabled. Nil without port.
+func initListener(scaler *mq.Autoscaler) *http.Server {
+	port := viper.GetString("observer.listener.port")
+	if port == "" {
+		return nil
+	}
+	engine := internal.InitEngine(viper.GetString("gin.mode"))
+	api.RegisterBasicAPI(engine)
+	api.RegisterAutoscalingAPI(engine, scaler)
+	if viper.GetBool("admin.enabled") {
+		api.RegisterWorkersAPI(engine, scaler.Pool, viper.GetStringSlice("admin.api_keys"))
+	}
+	logger.Info("Notifier listener", logger.Params{"bind": port})
+	return &http.Server{Addr: ":" + port, Handler: engine}
 }
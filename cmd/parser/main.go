@@ -9,6 +9,7 @@ import (
 	"github.com/trustwallet/blockatlas/mq"
 	"github.com/trustwallet/blockatlas/pkg/logger"
 	"github.com/trustwallet/blockatlas/platform"
+	"github.com/trustwallet/blockatlas/services/observer/heads"
 	"github.com/trustwallet/blockatlas/services/observer/parser"
 	"os"
 	"os/signal"
@@ -25,6 +26,7 @@ const (
 var (
 	confPath                                                   string
 	backlogTime, minInterval, maxInterval, fetchBlocksInterval time.Duration
+	adaptivePolling                                            bool
 	maxBackLogBlocks                                           int64
 	txsBatchLimit                                              uint
 	database                                                   *db.Instance
@@ -43,8 +45,14 @@ func init() {
 	internal.InitRabbitMQ(mqHost, prefetchCount)
 	platform.Init(platformHandles)
 
-	if err := mq.RawTransactions.Declare(); err != nil {
-		logger.Fatal(err)
+	declarers := []mq.Declarer{mq.RawTransactions}
+	if viper.GetBool("longpoll.enabled") {
+		declarers = append(declarers, mq.NewTransactions)
+	}
+	for _, d := range declarers {
+		if err := d.Declare(); err != nil {
+			logger.Fatal(err)
+		}
 	}
 
 	if len(platform.BlockAPIs) == 0 {
@@ -57,19 +65,26 @@ func init() {
 	backlogTime = viper.GetDuration("observer.backlog")
 	minInterval = viper.GetDuration("observer.block_poll.min")
 	maxInterval = viper.GetDuration("observer.block_poll.max")
+	adaptivePolling = viper.GetBool("observer.block_poll.adaptive")
 	fetchBlocksInterval = viper.GetDuration("observer.fetch_blocks_interval")
 	maxBackLogBlocks = viper.GetInt64("observer.backlog_max_blocks")
 	if minInterval >= maxInterval {
 		logger.Fatal("minimum block polling interval cannot be greater or equal than maximum")
 	}
-	var err error
-	database, err = db.New(pgUri, prod)
-	if err != nil {
-		logger.Fatal(err)
+	if viper.GetBool("observer.rabbitmq.reconnect") {
+		go mq.RestoreConnectionWorker(mqHost, time.Second*10, declarers...)
+	} else {
+		go mq.FatalWorker(time.Second * 10)
+	}
+	if database = internal.InitMemoryDatabase(); database == nil {
+		var err error
+		database, err = db.New(pgUri, prod)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
+		internal.InitReplica(database, prod)
 	}
-
-	go mq.FatalWorker(time.Second * 10)
-	go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
 	time.Sleep(time.Millisecond)
 }
 
@@ -104,6 +119,20 @@ func main() {
 
 		coinCancel[coin.Handle] = cancel
 
+		if contracts := viper.GetStringMapString("observer.lending_contracts." + coin.Handle); len(contracts) > 0 {
+			parser.AddLendingContracts(coin.ID, contracts)
+		}
+		var bridges map[string]parser.Bridge
+		if err := viper.UnmarshalKey("observer.bridge_contracts."+coin.Handle, &bridges); err != nil {
+			logger.Fatal(err, "invalid bridge contracts", logger.Params{"coin": coin.Handle})
+		}
+		if len(bridges) > 0 {
+			parser.AddBridgeContracts(coin.ID, bridges)
+		}
+		if key := "observer.unbonding_periods." + coin.Handle; viper.IsSet(key) {
+			parser.SetUnbondingPeriod(coin.ID, viper.GetDuration(key))
+		}
+
 		params := parser.Params{
 			Ctx:                   ctx,
 			Api:                   api,
@@ -115,6 +144,24 @@ func main() {
 			StopChannel:           stopChannel,
 			TxBatchLimit:          txsBatchLimit,
 			Database:              database,
+			ReorgDepth:            viper.GetInt64("observer.reorg_depth"),
+		}
+		if key := "observer.reorg_depths." + coin.Handle; viper.IsSet(key) {
+			params.ReorgDepth = viper.GetInt64(key)
+		}
+		if viper.GetBool("longpoll.enabled") {
+			params.Feed = mq.NewTransactions
+		}
+		if adaptivePolling {
+			params.MinInterval = minInterval
+			params.MaxInterval = maxInterval
+		}
+		if key := "observer.websocket." + coin.Handle; viper.IsSet(key) {
+			var subscription heads.Subscription
+			if err := viper.UnmarshalKey(key, &subscription); err != nil || subscription.URL == "" {
+				logger.Fatal(err, "invalid websocket subscription", logger.Params{"coin": coin.Handle})
+			}
+			params.Heads = subscription.Run(ctx)
 		}
 
 		go parser.RunParser(params)
@@ -146,6 +193,9 @@ func main() {
 			break
 		}
 	}
+	if err := database.SaveSnapshot(context.Background()); err != nil {
+		logger.Error(err, "Failed to save the snapshots")
+	}
 
 	logger.Info("Exiting gracefully")
 }
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type (
+	check struct {
+		name     string
+		path     string
+		validate func(body json.RawMessage) error
+	}
+
+	txPageResponse struct {
+		Total  int        `json:"total"`
+		Docs   []types.Tx `json:"docs"`
+		Status bool       `json:"status"`
+	}
+
+	docsResponse struct {
+		Docs json.RawMessage `json:"docs"`
+	}
+)
+
+func (c check) run(client *blockatlas.Request) error {
+	var body json.RawMessage
+	if err := client.Get(&body, c.path, nil); err != nil {
+		return err
+	}
+	return c.validate(body)
+}
+
+func basicChecks() []check {
+	return []check{
+		{name: "status", path: "", validate: validateStatus},
+		{name: "openapi", path: "openapi.json", validate: validateOpenAPI},
+	}
+}
+
+func validateStatus(body json.RawMessage) error {
+	var status struct {
+		Status bool `json:"status"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return errors.E(err, "invalid status response")
+	}
+	if !status.Status {
+		return errors.E("status is false")
+	}
+	return nil
+}
+
+func validateOpenAPI(body json.RawMessage) error {
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return errors.E(err, "invalid openapi document")
+	}
+	if doc.OpenAPI == "" || len(doc.Paths) == 0 {
+		return errors.E("openapi document has no paths")
+	}
+	return nil
+}
+
+func validateTxPage(coin uint) func(body json.RawMessage) error {
+	return func(body json.RawMessage) error {
+		var page txPageResponse
+		if err := json.Unmarshal(body, &page); err != nil {
+			return errors.E(err, "invalid transactions page")
+		}
+		if !page.Status {
+			return errors.E("transactions page status is false")
+		}
+		if page.Total != len(page.Docs) {
+			return errors.E("transactions page total mismatch", errors.Params{"total": page.Total, "docs": len(page.Docs)})
+		}
+		for _, tx := range page.Docs {
+			if tx.ID == "" {
+				return errors.E("transaction without id")
+			}
+			if tx.Coin != coin {
+				return errors.E("transaction of another coin", errors.Params{"id": tx.ID, "coin": tx.Coin})
+			}
+		}
+		return nil
+	}
+}
+
+func validateTokens(coin uint) func(body json.RawMessage) error {
+	return func(body json.RawMessage) error {
+		var tokens types.TokenPage
+		if err := unmarshalDocs(body, &tokens); err != nil {
+			return err
+		}
+		for _, t := range tokens {
+			if t.TokenID == "" {
+				return errors.E("token without id", errors.Params{"symbol": t.Symbol})
+			}
+			if t.Coin != coin {
+				return errors.E("token of another coin", errors.Params{"token": t.TokenID, "coin": t.Coin})
+			}
+		}
+		return nil
+	}
+}
+
+func validateValidators(body json.RawMessage) error {
+	var validators types.StakeValidators
+	if err := unmarshalDocs(body, &validators); err != nil {
+		return err
+	}
+	if len(validators) == 0 {
+		return errors.E("empty validators list")
+	}
+	for _, v := range validators {
+		if v.ID == "" {
+			return errors.E("validator without id")
+		}
+	}
+	return nil
+}
+
+func validateDelegations(coin uint) func(body json.RawMessage) error {
+	return func(body json.RawMessage) error {
+		var delegations types.DelegationResponse
+		if err := json.Unmarshal(body, &delegations); err != nil {
+			return errors.E(err, "invalid delegations response")
+		}
+		if delegations.Coin == nil || delegations.Coin.Coin != coin {
+			return errors.E("delegations response without matching coin")
+		}
+		if delegations.Address == "" {
+			return errors.E("delegations response without address")
+		}
+		return nil
+	}
+}
+
+func unmarshalDocs(body json.RawMessage, result interface{}) error {
+	var docs docsResponse
+	if err := json.Unmarshal(body, &docs); err != nil {
+		return errors.E(err, "invalid docs response")
+	}
+	if docs.Docs == nil {
+		return errors.E("response without docs")
+	}
+	if err := json.Unmarshal(docs.Docs, result); err != nil {
+		return errors.E(err, "invalid docs content")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/internal"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/platform"
+)
+
+const (
+	defaultConfigPath = "../../config.yml"
+	defaultHost       = "http://localhost:8420"
+	// defaultXpubs is the sample zpub of the Bitcoin xpub endpoints
+	defaultXpubs = "bitcoin=zpub6ruK9k6YGm8BRHWvTiQcrEPnFkuRDJhR7mPYzV2LDvjpLa5CuGgrhCYVZjMGcLcFqv9b2WvsFtY2Gb3xq8NVq8qhk9veozrA2W9QaWtihrC"
+)
+
+var (
+	confPath, host, xpubs string
+	timeout               time.Duration
+)
+
+func init() {
+	flag.StringVar(&host, "host", defaultHost, "base URL of the deployment to verify")
+	flag.DurationVar(&timeout, "timeout", time.Second*30, "timeout of every request")
+	flag.StringVar(&xpubs, "xpubs", defaultXpubs, "comma-separated handle=xpub of the UTXO coins to check by xpub")
+	_, confPath = internal.ParseArgs("", defaultConfigPath)
+
+	internal.InitConfig(confPath)
+	logger.InitLogger()
+
+	platform.Init(viper.GetStringSlice("platform"))
+}
+
+func main() {
+	client := blockatlas.InitJSONClient(host)
+	client.HttpClient = &http.Client{Timeout: timeout}
+	client.ErrorHandler = func(res *http.Response, uri string) error {
+		if res.StatusCode != http.StatusOK {
+			return errors.E("unexpected status code", errors.Params{"status": res.StatusCode, "url": uri})
+		}
+		return nil
+	}
+
+	checks := append(basicChecks(), platformChecks(platform.Platforms, parseXpubs(xpubs))...)
+	logger.Info("Running smoke tests", logger.Params{"host": host, "checks": len(checks)})
+
+	failed := 0
+	for _, c := range checks {
+		start := time.Now()
+		err := c.run(&client)
+		params := logger.Params{"check": c.name, "path": c.path, "duration": time.Since(start).String()}
+		if err != nil {
+			failed++
+			logger.Error(err, params)
+			continue
+		}
+		logger.Info("Passed", params)
+	}
+
+	logger.Info("Smoke tests finished", logger.Params{"total": len(checks), "failed": failed})
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// parseXpubs reads the handle=xpub pairs of the -xpubs flag
+func parseXpubs(value string) map[string]string {
+	samples := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+			samples[parts[0]] = parts[1]
+		}
+	}
+	return samples
+}
+
+func platformChecks(platforms map[string]blockatlas.Platform, xpubs map[string]string) []check {
+	handles := make([]string, 0, len(platforms))
+	for handle := range platforms {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+
+	checks := make([]check, 0)
+	for _, handle := range handles {
+		p := platforms[handle]
+		c := p.Coin()
+		address := c.SampleAddr
+		if address == "" {
+			logger.Warn("No sample address, skipping address checks", logger.Params{"coin": handle})
+		}
+		if _, ok := p.(blockatlas.TxUtxoAPI); ok {
+			// The UTXO coins serve the transactions of an address on v1 and of an xpub on v2
+			if address != "" {
+				checks = append(checks, check{
+					name:     fmt.Sprintf("%s transactions", handle),
+					path:     fmt.Sprintf("v1/%s/address/%s", handle, address),
+					validate: validateTxPage(c.ID),
+				})
+			}
+			if xpub, ok := xpubs[handle]; ok {
+				checks = append(checks, check{
+					name:     fmt.Sprintf("%s xpub transactions", handle),
+					path:     fmt.Sprintf("v2/%s/transactions/xpub/%s", handle, xpub),
+					validate: validateTxPage(c.ID),
+				})
+			} else {
+				logger.Warn("No sample xpub, skipping xpub checks", logger.Params{"coin": handle})
+			}
+		} else if _, ok := p.(blockatlas.TxAPI); ok && address != "" {
+			checks = append(checks, check{
+				name:     fmt.Sprintf("%s transactions", handle),
+				path:     fmt.Sprintf("v2/%s/transactions/%s", handle, address),
+				validate: validateTxPage(c.ID),
+			})
+		}
+		if _, ok := p.(blockatlas.TokensAPI); ok && address != "" {
+			checks = append(checks, check{
+				name:     fmt.Sprintf("%s tokens", handle),
+				path:     fmt.Sprintf("v2/%s/tokens/%s", handle, address),
+				validate: validateTokens(c.ID),
+			})
+		}
+		if _, ok := p.(blockatlas.StakeAPI); ok {
+			checks = append(checks, check{
+				name:     fmt.Sprintf("%s validators", handle),
+				path:     fmt.Sprintf("v2/%s/staking/validators", handle),
+				validate: validateValidators,
+			})
+			if address != "" {
+				checks = append(checks, check{
+					name:     fmt.Sprintf("%s delegations", handle),
+					path:     fmt.Sprintf("v2/%s/staking/delegations/%s", handle, address),
+					validate: validateDelegations(c.ID),
+				})
+			}
+		}
+	}
+	return checks
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/internal"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	defaultConfigPath = "../../config.yml"
+	prod              = "prod"
+)
+
+var (
+	confPath string
+	database *db.Instance
+)
+
+func init() {
+	_, confPath = internal.ParseArgs("", defaultConfigPath)
+
+	internal.InitConfig(confPath)
+	logger.InitLogger()
+
+	// The memory mode is opened without the snapshot worker, the import saves its snapshots once
+	if viper.GetBool("snapshot.enabled") {
+		url := viper.GetString("snapshot.url")
+		store, err := db.NewSnapshotStore(url, viper.GetString("snapshot.token"))
+		if err != nil {
+			logger.Fatal(err)
+		}
+		if database, err = db.NewMemory(store, context.Background()); err != nil {
+			logger.Fatal(err, "Failed to load the snapshots", logger.Params{"url": url})
+		}
+		return
+	}
+	var err error
+	database, err = db.Open(viper.GetString("postgres.uri"), prod)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	internal.InitKeyring(database)
+}
+
+// Usage: snapshot -c config.yml [export | import] <archive>
+//
+// Moves the subscriptions, trackers, xpub and channel subscriptions of the observer between deployments or
+// storage backends, from and to the snapshots of snapshot.url with snapshot.enabled, Postgres otherwise.
+// The archive holds the channel tokens in plaintext.
+func main() {
+	ctx := context.Background()
+
+	command, path := flag.Arg(0), flag.Arg(1)
+	if path == "" {
+		logger.Fatal("Missing archive path", logger.Params{"command": command})
+	}
+	switch command {
+	case "export":
+		state, err := database.ExportState(ctx)
+		if err != nil {
+			logger.Fatal(err, "Failed to read the observer state")
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		if err := db.WriteStateArchive(file, state); err != nil {
+			logger.Fatal(err, "Failed to write the archive", logger.Params{"path": path})
+		}
+		if err := file.Close(); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Observer state exported", stateParams(state, path))
+	case "import":
+		file, err := os.Open(path)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		defer file.Close()
+		state, err := db.ReadStateArchive(file)
+		if err != nil {
+			logger.Fatal(err, logger.Params{"path": path})
+		}
+		if err := database.ImportState(state, ctx); err != nil {
+			logger.Fatal(err, "Failed to import the observer state")
+		}
+		if err := database.SaveSnapshot(ctx); err != nil {
+			logger.Fatal(err, "Failed to save the snapshots")
+		}
+		logger.Info("Observer state imported", stateParams(state, path))
+	default:
+		logger.Fatal("Unknown command, expected export or import", logger.Params{"command": command})
+	}
+}
+
+func stateParams(state db.State, path string) logger.Params {
+	return logger.Params{
+		"path":                  path,
+		"subscriptions":         len(state.Subscriptions),
+		"trackers":              len(state.Trackers),
+		"xpub_subscriptions":    len(state.XpubSubscriptions),
+		"channel_subscriptions": len(state.ChannelSubscriptions),
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/trustwallet/blockatlas/internal"
 	"github.com/trustwallet/blockatlas/mq"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/platform"
 	"github.com/trustwallet/blockatlas/services/observer/subscriber"
 	"time"
 )
@@ -34,15 +35,20 @@ func init() {
 	prefetchCount := viper.GetInt("observer.rabbitmq.consumer.prefetch_count")
 
 	internal.InitRabbitMQ(mqHost, prefetchCount)
-
-	var err error
-	database, err = db.New(pgUri, prod)
-	if err != nil {
-		logger.Fatal(err)
-	}
+	subscriber.DefaultTTL = viper.GetDuration("observer.subscriptions.ttl")
+	subscriber.Tenants = internal.InitTenants()
 
 	go mq.FatalWorker(time.Second * 10)
-	go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
+	if database = internal.InitMemoryDatabase(); database == nil {
+		var err error
+		database, err = db.New(pgUri, prod)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		go db.RestoreConnectionWorker(database, time.Second*10, pgUri)
+		internal.InitReplica(database, prod)
+		internal.InitKeyring(database)
+	}
 	time.Sleep(time.Millisecond)
 }
 
@@ -53,7 +59,21 @@ func main() {
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// The xpub subscriptions are kept in Postgres
+	if viper.GetBool("observer.xpub.enabled") && !viper.GetBool("snapshot.enabled") {
+		interval := viper.GetDuration("observer.xpub.interval")
+		if interval <= 0 {
+			interval = subscriber.DefaultXpubInterval
+		}
+		platform.Init(viper.GetStringSlice("platform"))
+		subscriber.XpubAPIs = platform.XpubAPIs
+		go subscriber.RunXpubWorker(database, interval, ctx)
+	}
+
 	go mq.Subscriptions.RunConsumerWithCancelAndDbConn(subscriber.RunSubscriber, database, ctx)
 
 	internal.SetupGracefulShutdownForObserver(cancel)
+	if err := database.SaveSnapshot(context.Background()); err != nil {
+		logger.Error(err, "Failed to save the snapshots")
+	}
 }
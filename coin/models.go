@@ -1,11 +1,9 @@
 package coin
 
-type ExternalCoin struct {
-	Coin     uint   `json:"coin"`
-	Symbol   string `json:"symbol"`
-	Name     string `json:"name"`
-	Decimals uint   `json:"decimals"`
-}
+import "github.com/trustwallet/blockatlas/pkg/types"
+
+// ExternalCoin is the coin of the public models
+type ExternalCoin = types.ExternalCoin
 
 func (c *Coin) External() *ExternalCoin {
 	return &ExternalCoin{
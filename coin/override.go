@@ -0,0 +1,51 @@
+package coin
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// entry is a coin of a registry file, in the format of coins.yml
+type entry struct {
+	ID               uint   `yaml:"id"`
+	Handle           string `yaml:"handle"`
+	Symbol           string `yaml:"symbol"`
+	Name             string `yaml:"name"`
+	Decimals         uint   `yaml:"decimals"`
+	BlockTime        int    `yaml:"blockTime"`
+	MinConfirmations int64  `yaml:"minConfirmations"`
+	SampleAddr       string `yaml:"sampleAddress"`
+}
+
+// LoadOverrides replaces the coins of the file in the built-in registry, adding the unknown ones.
+// It is meant to run at start-up, before the registry is read.
+func LoadOverrides(path string) (int, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read coins %s: %v", path, err)
+	}
+	var entries []entry
+	if err := yaml.Unmarshal(raw, &entries); err != nil {
+		return 0, fmt.Errorf("invalid coins %s: %v", path, err)
+	}
+	for _, e := range entries {
+		if e.Handle == "" || e.Symbol == "" {
+			return 0, fmt.Errorf("invalid coins %s: coin %d without handle or symbol", path, e.ID)
+		}
+	}
+	for _, e := range entries {
+		Coins[e.ID] = Coin{
+			ID:               e.ID,
+			Handle:           e.Handle,
+			Symbol:           e.Symbol,
+			Name:             e.Name,
+			Decimals:         e.Decimals,
+			BlockTime:        e.BlockTime,
+			MinConfirmations: e.MinConfirmations,
+			SampleAddr:       e.SampleAddr,
+		}
+	}
+	return len(entries), nil
+}
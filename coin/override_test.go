@@ -0,0 +1,49 @@
+package coin
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadOverrides(t *testing.T) {
+	eth := Coins[ETH]
+	defer func() {
+		Coins[ETH] = eth
+		delete(Coins, 99999)
+	}()
+
+	dir, err := ioutil.TempDir("", "coins")
+	assert.Nil(t, err)
+	path := filepath.Join(dir, "coins.yml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`
+- id: 60
+  symbol: ETH
+  handle: ethereum
+  name: Ethereum
+  decimals: 18
+  blockTime: 12000
+  minConfirmations: 12
+- id: 99999
+  symbol: TST
+  handle: test
+  name: Test
+  decimals: 8
+`), 0600))
+
+	n, err := LoadOverrides(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 12000, Ethereum().BlockTime)
+	assert.Equal(t, int64(12), Ethereum().MinConfirmations)
+	assert.Equal(t, "test", Coins[99999].Handle)
+	assert.Equal(t, Coins[ATOM].Handle, "cosmos")
+
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`- id: 61`), 0600))
+	_, err = LoadOverrides(path)
+	assert.NotNil(t, err)
+	_, err = LoadOverrides(filepath.Join(dir, "missing.yml"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	ModeMinimal      = "minimal"
+	ModeFull         = "full"
+	ModeObserverOnly = "observer-only"
+	ModeMarketOnly   = "market-only"
+)
+
+type preset map[string]interface{}
+
+// subsystems are the switches of the optional subsystems, every preset sets all of them
+var subsystems = preset{
+	"lanes.enabled":                            false,
+	"longpoll.enabled":                         false,
+	"addressbook.enabled":                      false,
+	"notes.enabled":                            false,
+	"export.enabled":                           false,
+	"admin.enabled":                            false,
+	"snapshot.enabled":                         false,
+	"grpc.enabled":                             false,
+	"signing.enabled":                          false,
+	"monitor.enabled":                          false,
+	"faults.enabled":                           false,
+	"debug.recording.enabled":                  false,
+	"observer.subscriptions.api.enabled":       false,
+	"observer.subscriptions.screening.enabled": false,
+	"observer.replay.enabled":                  false,
+	"observer.digest.enabled":                  false,
+	"observer.xpub.enabled":                    false,
+	"observer.balances.enabled":                false,
+	"observer.lending_alerts.enabled":          false,
+	"observer.block_number.enabled":            false,
+	"observer.channels.webhook.enabled":        false,
+	"lending.info_cache.enabled":               false,
+	"lending.alerts.enabled":                   false,
+	"market.enabled":                           false,
+	"retention.enabled":                        false,
+	"upstream.block_cache.enabled":             false,
+	"upstream.circuit_breaker.failures":        0,
+}
+
+// configured are the switches the presets keep from the config file: the channel drivers needing
+// their credentials and the notifier dedup, on by default
+var configured = []string{
+	"observer.dedup.enabled",
+	"observer.channels.fcm.enabled",
+	"observer.channels.apns.enabled",
+	"observer.channels.telegram.enabled",
+	"observer.channels.slack.enabled",
+}
+
+// presets are the subsystems wired up by each mode, on top of the subsystems switched off
+var presets = map[string]preset{
+	// The coin endpoints, the observer keeps its subscriptions in snapshots instead of Postgres
+	ModeMinimal: {
+		"rest_api":         "platform",
+		"snapshot.enabled": true,
+	},
+	// Every subsystem, the export, admin and recording endpoints stay closed until their api_keys are set.
+	// Without their own config, the response signing, the screening and the faults stay off.
+	ModeFull: {
+		"rest_api":                           "all",
+		"lanes.enabled":                      true,
+		"longpoll.enabled":                   true,
+		"addressbook.enabled":                true,
+		"notes.enabled":                      true,
+		"export.enabled":                     true,
+		"admin.enabled":                      true,
+		"grpc.enabled":                       true,
+		"monitor.enabled":                    true,
+		"debug.recording.enabled":            true,
+		"observer.subscriptions.api.enabled": true,
+		"observer.replay.enabled":            true,
+		"observer.digest.enabled":            true,
+		"observer.xpub.enabled":              true,
+		"observer.balances.enabled":          true,
+		"observer.lending_alerts.enabled":    true,
+		"observer.block_number.enabled":      true,
+		"observer.channels.webhook.enabled":  true,
+		"lending.info_cache.enabled":         true,
+		"lending.alerts.enabled":             true,
+		"market.enabled":                     true,
+		"retention.enabled":                  true,
+		"upstream.block_cache.enabled":       true,
+		"upstream.circuit_breaker.failures":  5,
+	},
+	// The parser, subscriber and notifier, the API serves the subscription and replay endpoints only
+	ModeObserverOnly: {
+		"rest_api":                           "swagger",
+		"observer.subscriptions.api.enabled": true,
+		"observer.replay.enabled":            true,
+		"observer.digest.enabled":            true,
+		"observer.xpub.enabled":              true,
+		"observer.balances.enabled":          true,
+		"observer.channels.webhook.enabled":  true,
+	},
+	// The lending markets (/v1/lending) and tickers (/v1/market) endpoints, without the coin endpoints and the observer
+	ModeMarketOnly: {
+		"rest_api":                   "market",
+		"lending.info_cache.enabled": true,
+		"market.enabled":             true,
+	},
+}
+
+// Modes returns the names of the presets
+func Modes() []string {
+	modes := make([]string, 0, len(presets))
+	for mode := range presets {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	return modes
+}
+
+// ApplyPreset sets the subsystem switches of the mode over the config file, the ATLAS_ environment
+// variables still override them. An empty mode keeps the config file as is.
+func ApplyPreset(mode string) error {
+	if mode == "" {
+		return nil
+	}
+	values, ok := presets[mode]
+	if !ok {
+		return errors.E("unknown mode", errors.Params{"mode": mode, "modes": Modes()})
+	}
+	for key, value := range subsystems {
+		if _, ok := values[key]; !ok {
+			set(key, value)
+		}
+	}
+	for key, value := range values {
+		set(key, value)
+	}
+	logger.Info("Config preset", logger.Params{"mode": mode})
+	return nil
+}
+
+func set(key string, value interface{}) {
+	if _, ok := os.LookupEnv(envKey(key)); ok {
+		return
+	}
+	viper.Set(key, value)
+}
+
+func envKey(key string) string {
+	return "ATLAS_" + strings.ToUpper(strings.NewReplacer(".", "_").Replace(key))
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPreset(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("addressbook.enabled", true)
+	viper.Set("rest_api", "all")
+
+	assert.Nil(t, ApplyPreset(ModeMarketOnly))
+	assert.Equal(t, "market", viper.GetString("rest_api"))
+	assert.False(t, viper.GetBool("addressbook.enabled"))
+	assert.False(t, viper.GetBool("observer.replay.enabled"))
+
+	// The environment variables win over the preset
+	os.Setenv("ATLAS_LANES_ENABLED", "false")
+	defer os.Unsetenv("ATLAS_LANES_ENABLED")
+	assert.Nil(t, ApplyPreset(ModeFull))
+	assert.True(t, viper.GetBool("addressbook.enabled"))
+	assert.True(t, viper.GetBool("longpoll.enabled"))
+	assert.Equal(t, 5, viper.GetInt("upstream.circuit_breaker.failures"))
+	assert.False(t, viper.GetBool("lanes.enabled"))
+
+	assert.NotNil(t, ApplyPreset("everything"))
+	assert.Nil(t, ApplyPreset(""))
+	assert.Equal(t, "all", viper.GetString("rest_api"))
+}
+
+func TestModes(t *testing.T) {
+	assert.Equal(t, []string{ModeFull, ModeMarketOnly, ModeMinimal, ModeObserverOnly}, Modes())
+}
+
+func TestSubsystems(t *testing.T) {
+	file := viper.New()
+	file.SetConfigFile("../config.yml")
+	assert.Nil(t, file.ReadInConfig())
+
+	// Every switch of the config file is set by the presets or listed as kept from the config file
+	for _, key := range file.AllKeys() {
+		if !strings.HasSuffix(key, ".enabled") {
+			continue
+		}
+		_, ok := subsystems[key]
+		assert.True(t, ok || contains(configured, key), key)
+	}
+	for _, values := range presets {
+		for key := range values {
+			_, ok := subsystems[key]
+			assert.True(t, ok || key == "rest_api", key)
+		}
+	}
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/models"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// CreateAddressBook creates an address book authenticated by the token hash
+func (i *Instance) CreateAddressBook(tokenHash, device string, ctx context.Context) (uint, error) {
+	var book models.AddressBook
+	err := apmgorm.WithContext(ctx, i.Gorm).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&book).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.AddressBookToken{Hash: tokenHash, AddressBookID: book.ID, Device: device}).Error
+	})
+	return book.ID, err
+}
+
+func (i *Instance) AddAddressBookToken(bookID uint, tokenHash, device string, ctx context.Context) error {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	return g.Create(&models.AddressBookToken{Hash: tokenHash, AddressBookID: bookID, Device: device}).Error
+}
+
+func (i *Instance) DeleteAddressBookToken(tokenHash string, ctx context.Context) error {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	return g.Where("hash = ?", tokenHash).Delete(&models.AddressBookToken{}).Error
+}
+
+// GetAddressBookID returns the address book of the token hash, gorm.ErrRecordNotFound if unknown
+func (i *Instance) GetAddressBookID(tokenHash string, ctx context.Context) (uint, error) {
+	var token models.AddressBookToken
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	if err := g.Where("hash = ?", tokenHash).First(&token).Error; err != nil {
+		return 0, err
+	}
+	return token.AddressBookID, nil
+}
+
+// GetAddressBookEntries returns the entries updated after since, including the deleted ones.
+// A zero since returns the current entries only.
+func (i *Instance) GetAddressBookEntries(bookID uint, since time.Time, ctx context.Context) ([]models.AddressBookEntry, error) {
+	g := apmgorm.WithContext(ctx, i.Gorm).Where("address_book_id = ?", bookID)
+	if !since.IsZero() {
+		g = g.Unscoped().Where("updated_at > ?", since)
+	}
+	var entries []models.AddressBookEntry
+	if err := g.Order("updated_at").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (i *Instance) AddAddressBookEntry(entry *models.AddressBookEntry, ctx context.Context) error {
+	return apmgorm.WithContext(ctx, i.Gorm).Create(entry).Error
+}
+
+// UpdateAddressBookEntry updates the entry if it belongs to the address book
+func (i *Instance) UpdateAddressBookEntry(entry *models.AddressBookEntry, ctx context.Context) error {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	result := g.Model(entry).
+		Where("address_book_id = ?", entry.AddressBookID).
+		Updates(map[string]interface{}{"coin": entry.Coin, "address": entry.Address, "label": entry.Label, "memo": entry.Memo})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return g.Where("address_book_id = ?", entry.AddressBookID).First(entry, entry.ID).Error
+}
+
+// DeleteAddressBookEntry marks the entry of the address book as deleted,
+// updated_at is bumped as well so the deletion is part of the next sync
+func (i *Instance) DeleteAddressBookEntry(bookID, id uint, ctx context.Context) error {
+	now := time.Now()
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	result := g.Model(&models.AddressBookEntry{}).
+		Where("address_book_id = ? AND id = ?", bookID, id).
+		UpdateColumns(map[string]interface{}{"deleted_at": now, "updated_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// GetBlockHashes returns the hashes of the blocks of the coin from the number, by number. None in memory mode.
+func (i *Instance) GetBlockHashes(coin string, from int64, ctx context.Context) (map[int64]string, error) {
+	if i.memory != nil {
+		return nil, nil
+	}
+	var hashes []models.BlockHash
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	if err := g.Where("coin = ? AND number >= ?", coin, from).Find(&hashes).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[int64]string, len(hashes))
+	for _, h := range hashes {
+		result[h.Number] = h.Hash
+	}
+	return result, nil
+}
+
+// SaveBlockHashes records the hashes of the parsed blocks of the coin and deletes the ones before keepFrom
+func (i *Instance) SaveBlockHashes(coin string, hashes map[int64]string, keepFrom int64, ctx context.Context) error {
+	if i.memory != nil || len(hashes) == 0 {
+		return nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for number, hash := range hashes {
+		err := g.
+			Set("gorm:insert_option", "ON CONFLICT (coin, number) DO UPDATE SET hash = excluded.hash").
+			Create(&models.BlockHash{Coin: coin, Number: number, Hash: hash}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return g.Where("coin = ? AND number < ?", coin, keepFrom).Delete(&models.BlockHash{}).Error
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// saveBridgeTransfer inserts the side of the transfer, or sets it on the row of the message saved by
+// the parser of the other network, and returns the row with both sides
+const saveBridgeTransfer = `INSERT INTO bridge_transfers
+	(message_id, protocol, source_coin, source_tx_id, destination_coin, destination_tx_id, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (message_id) DO UPDATE SET
+	source_coin = CASE WHEN excluded.source_tx_id = '' THEN bridge_transfers.source_coin ELSE excluded.source_coin END,
+	source_tx_id = CASE WHEN excluded.source_tx_id = '' THEN bridge_transfers.source_tx_id ELSE excluded.source_tx_id END,
+	destination_coin = CASE WHEN excluded.destination_tx_id = '' THEN bridge_transfers.destination_coin ELSE excluded.destination_coin END,
+	destination_tx_id = CASE WHEN excluded.destination_tx_id = '' THEN bridge_transfers.destination_tx_id ELSE excluded.destination_tx_id END,
+	updated_at = excluded.updated_at
+RETURNING message_id, protocol, source_coin, source_tx_id, destination_coin, destination_tx_id`
+
+// SaveBridgeTransfer saves the side of the bridge transfer set on the transfer, and returns the transfer
+// with the other side when its parser saved it. Only the given side in memory mode.
+func (i *Instance) SaveBridgeTransfer(transfer models.BridgeTransfer, ctx context.Context) (types.BridgeTransfer, error) {
+	if i.memory != nil {
+		return bridgeTransferRecord(transfer), nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var saved models.BridgeTransfer
+	err := g.Raw(saveBridgeTransfer, transfer.MessageID, transfer.Protocol, transfer.SourceCoin, transfer.SourceTxID,
+		transfer.DestinationCoin, transfer.DestinationTxID, time.Now()).Scan(&saved).Error
+	if err != nil {
+		return types.BridgeTransfer{}, err
+	}
+	return bridgeTransferRecord(saved), nil
+}
+
+// GetBridgeTransfer returns the bridge transfer sent or delivered by the transaction, nil for
+// the transactions of no known transfer
+func (i *Instance) GetBridgeTransfer(coin uint, txID string, ctx context.Context) (*types.BridgeTransfer, error) {
+	if i.memory != nil {
+		return nil, nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var transfer models.BridgeTransfer
+	err := g.
+		Where("(source_coin = ? AND source_tx_id = ?) OR (destination_coin = ? AND destination_tx_id = ?)", coin, txID, coin, txID).
+		First(&transfer).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	record := bridgeTransferRecord(transfer)
+	return &record, nil
+}
+
+func bridgeTransferRecord(t models.BridgeTransfer) types.BridgeTransfer {
+	record := types.BridgeTransfer{Protocol: t.Protocol, MessageID: t.MessageID}
+	if t.SourceTxID != "" {
+		record.Source = &types.BridgeLeg{Coin: t.SourceCoin, TxID: t.SourceTxID}
+	}
+	if t.DestinationTxID != "" {
+		record.Destination = &types.BridgeLeg{Coin: t.DestinationCoin, TxID: t.DestinationTxID}
+	}
+	return record
+}
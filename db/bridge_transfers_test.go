@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestInstance_SaveBridgeTransfer(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+
+	// The destination is saved after the source, the row of the message has both sides
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bridge_transfers`)).
+		WithArgs("2/00aa/7", "wormhole", 0, "", 501, "sig", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "protocol", "source_coin", "source_tx_id", "destination_coin", "destination_tx_id"}).
+			AddRow("2/00aa/7", "wormhole", 60, "0xhash", 501, "sig"))
+	transfer, err := i.SaveBridgeTransfer(models.BridgeTransfer{
+		MessageID: "2/00aa/7", Protocol: "wormhole", DestinationCoin: 501, DestinationTxID: "sig",
+	}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, types.BridgeTransfer{
+		Protocol:    "wormhole",
+		MessageID:   "2/00aa/7",
+		Source:      &types.BridgeLeg{Coin: 60, TxID: "0xhash"},
+		Destination: &types.BridgeLeg{Coin: 501, TxID: "sig"},
+	}, transfer)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInstance_GetBridgeTransfer(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bridge_transfers" WHERE ((source_coin = $1 AND source_tx_id = $2) OR (destination_coin = $3 AND destination_tx_id = $4))`)).
+		WithArgs(60, "0xhash", 60, "0xhash").
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "protocol", "source_coin", "source_tx_id"}).
+			AddRow("2/00aa/7", "wormhole", 60, "0xhash"))
+	transfer, err := i.GetBridgeTransfer(60, "0xhash", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, &types.BridgeTransfer{
+		Protocol:  "wormhole",
+		MessageID: "2/00aa/7",
+		Source:    &types.BridgeLeg{Coin: 60, TxID: "0xhash"},
+	}, transfer)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "bridge_transfers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id"}))
+	transfer, err = i.GetBridgeTransfer(60, "0xother", context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, transfer)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/secrets"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+const rawChannelSubscriptionsInsert = `INSERT INTO channel_subscriptions(coin,address,provider,token_hash,token,locale,digest,expires_at) VALUES %s ON CONFLICT (coin,address,provider,token_hash) DO UPDATE SET token = excluded.token, locale = excluded.locale, digest = excluded.digest, expires_at = excluded.expires_at, expiry_notified = channel_subscriptions.expiry_notified AND channel_subscriptions.expires_at IS NOT DISTINCT FROM excluded.expires_at`
+
+func (i *Instance) GetChannelSubscriptions(coin uint, addresses []string, ctx context.Context) ([]models.ChannelSubscription, error) {
+	if len(addresses) == 0 {
+		return nil, errors.E("Empty addresses")
+	}
+	if i.memory != nil {
+		return nil, nil
+	}
+	g := apmgorm.WithContext(ctx, i.reader())
+	var subscriptions []models.ChannelSubscription
+	err := g.
+		Where("address in (?) AND coin = ?", addresses, coin).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return i.openTokens(subscriptions), nil
+}
+
+func (i *Instance) AddChannelSubscriptions(subscriptions []models.ChannelSubscription, ctx context.Context) error {
+	if len(subscriptions) == 0 {
+		return errors.E("Empty subscriptions")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for lo := 0; lo < len(subscriptions); lo += batchLimit {
+		hi := lo + batchLimit
+		if hi > len(subscriptions) {
+			hi = len(subscriptions)
+		}
+		var (
+			valueStrings []string
+			valueArgs    []interface{}
+		)
+		for _, s := range subscriptions[lo:hi] {
+			token, err := i.sealToken(s.Token)
+			if err != nil {
+				return err
+			}
+			valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?)")
+			valueArgs = append(valueArgs, s.Coin, s.Address, s.Provider, secrets.Hash(s.Token), token, s.Locale, s.Digest, s.ExpiresAt)
+		}
+		smt := fmt.Sprintf(rawChannelSubscriptionsInsert, strings.Join(valueStrings, ","))
+		if err := g.Exec(smt, valueArgs...).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Instance) DeleteChannelSubscriptions(subscriptions []models.ChannelSubscription, ctx context.Context) error {
+	if len(subscriptions) == 0 {
+		return errors.E("Empty subscriptions")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.
+			Where("coin = ? AND address = ? AND provider = ? AND token_hash = ?", s.Coin, s.Address, s.Provider, secrets.Hash(s.Token)).
+			Delete(&models.ChannelSubscription{}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteChannelTokens removes every subscription and lending alert of the tokens rejected by the provider
+func (i *Instance) DeleteChannelTokens(provider string, tokens []string, ctx context.Context) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	hashes := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		hashes = append(hashes, secrets.Hash(t))
+	}
+	if err := g.Where("provider = ? AND token_hash in (?)", provider, hashes).Delete(&models.ChannelSubscription{}).Error; err != nil {
+		return err
+	}
+	if err := g.Where("channel = ? AND token in (?)", provider, tokens).Delete(&models.LendingRateAlert{}).Error; err != nil {
+		return err
+	}
+	return g.Where("channel = ? AND token in (?)", provider, tokens).Delete(&models.LiquidationAlert{}).Error
+}
+
+// RotateChannelTokens encrypts the stored channel tokens with the current key of the keyring,
+// rewrapping the ones encrypted with another key and encrypting the plaintext ones. It returns how many changed.
+func (i *Instance) RotateChannelTokens(ctx context.Context) (int, error) {
+	if i.keyring == nil {
+		return 0, errors.E("no keyring to encrypt the channel tokens")
+	}
+	return i.rewriteChannelTokens(i.keyring.Rotate, ctx)
+}
+
+// DecryptChannelTokens stores the channel tokens in plaintext again, before turning the encryption off
+func (i *Instance) DecryptChannelTokens(ctx context.Context) (int, error) {
+	return i.rewriteChannelTokens(func(token string) (string, bool, error) {
+		if !secrets.IsEncrypted(token) {
+			return token, false, nil
+		}
+		plaintext, err := i.openToken(token)
+		return plaintext, err == nil, err
+	}, ctx)
+}
+
+// rewriteChannelTokens updates the stored tokens by batches, the order of the primary key keeps the pages stable
+func (i *Instance) rewriteChannelTokens(rewrite func(token string) (string, bool, error), ctx context.Context) (int, error) {
+	if i.memory != nil {
+		return 0, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	changed := 0
+	for offset := 0; ; offset += batchLimit {
+		var subscriptions []models.ChannelSubscription
+		err := g.Order("coin, address, provider, token_hash").
+			Offset(offset).Limit(batchLimit).
+			Find(&subscriptions).Error
+		if err != nil {
+			return changed, err
+		}
+		for _, s := range subscriptions {
+			token, ok, err := rewrite(s.Token)
+			if err != nil {
+				return changed, errors.E(err, errors.Params{"coin": s.Coin, "address": s.Address, "provider": s.Provider})
+			}
+			if !ok {
+				continue
+			}
+			err = g.Model(&models.ChannelSubscription{}).
+				Where("coin = ? AND address = ? AND provider = ? AND token_hash = ?", s.Coin, s.Address, s.Provider, s.TokenHash).
+				Update("token", token).Error
+			if err != nil {
+				return changed, err
+			}
+			changed++
+		}
+		if len(subscriptions) < batchLimit {
+			return changed, nil
+		}
+	}
+}
+
+// openTokens decrypts the tokens of the subscriptions, leaving out the ones the keyring can't decrypt
+func (i *Instance) openTokens(subscriptions []models.ChannelSubscription) []models.ChannelSubscription {
+	result := make([]models.ChannelSubscription, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		token, err := i.openToken(s.Token)
+		if err != nil {
+			logger.Error(err, "Failed to decrypt the channel token", logger.Params{"coin": s.Coin, "address": s.Address, "provider": s.Provider})
+			continue
+		}
+		s.Token = token
+		result = append(result, s)
+	}
+	return result
+}
+
+func (i *Instance) openToken(token string) (string, error) {
+	if !secrets.IsEncrypted(token) {
+		return token, nil
+	}
+	if i.keyring == nil {
+		return "", errors.E("encrypted channel token without keyring")
+	}
+	return i.keyring.Decrypt(token)
+}
+
+func (i *Instance) sealToken(token string) (string, error) {
+	if i.keyring == nil {
+		return token, nil
+	}
+	return i.keyring.Encrypt(token)
+}
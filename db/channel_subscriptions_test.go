@@ -0,0 +1,91 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/secrets"
+)
+
+type encryptedArg struct{}
+
+func (encryptedArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && secrets.IsEncrypted(s)
+}
+
+func testKeyring(t *testing.T, current string, ids ...string) *secrets.Keyring {
+	keys := make(map[string]secrets.MasterKey, len(ids))
+	for i, id := range ids {
+		key, err := secrets.NewAESKey(bytes.Repeat([]byte{byte(i + 1)}, 32))
+		assert.Nil(t, err)
+		keys[id] = key
+	}
+	keyring, err := secrets.NewKeyring(current, keys)
+	assert.Nil(t, err)
+	return keyring
+}
+
+func TestInstance_ChannelTokensEncrypted(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+	i.UseKeyring(testKeyring(t, "k1", "k1"))
+	ctx := context.Background()
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO channel_subscriptions(coin,address,provider,token_hash,token,locale,digest,expires_at)`)).
+		WithArgs(60, "0xa", "fcm", secrets.Hash("device"), encryptedArg{}, "en", "", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err := i.AddChannelSubscriptions([]models.ChannelSubscription{{Coin: 60, Address: "0xa", Provider: "fcm", Token: "device", Locale: "en"}}, ctx)
+	assert.Nil(t, err)
+
+	stored, err := i.keyring.Encrypt("device")
+	assert.Nil(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "channel_subscriptions" WHERE (address in ($1) AND coin = $2)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"coin", "address", "provider", "token_hash", "token"}).
+			AddRow(60, "0xa", "fcm", secrets.Hash("device"), stored).
+			AddRow(60, "0xa", "telegram", secrets.Hash("chat"), "chat").
+			AddRow(60, "0xa", "slack", secrets.Hash("hook"), "enc:v1:unknown:AA:AA"))
+	subscriptions, err := i.GetChannelSubscriptions(60, []string{"0xa"}, ctx)
+	assert.Nil(t, err)
+	// The plaintext tokens stored before the encryption are read as they are, the undecryptable ones left out
+	assert.Len(t, subscriptions, 2)
+	assert.Equal(t, "device", subscriptions[0].Token)
+	assert.Equal(t, "chat", subscriptions[1].Token)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInstance_RotateChannelTokens(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+	i.UseKeyring(testKeyring(t, "k1", "k1"))
+	current, err := i.keyring.Encrypt("current")
+	assert.Nil(t, err)
+	i.UseKeyring(testKeyring(t, "k2", "k1", "k2"))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "channel_subscriptions" ORDER BY coin, address, provider, token_hash`)).
+		WillReturnRows(sqlmock.NewRows([]string{"coin", "address", "provider", "token_hash", "token"}).
+			AddRow(60, "0xa", "fcm", secrets.Hash("current"), current).
+			AddRow(60, "0xb", "fcm", secrets.Hash("plain"), "plain"))
+	for _, hash := range []string{secrets.Hash("current"), secrets.Hash("plain")} {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "channel_subscriptions" SET "token" = $1`)).
+			WithArgs(encryptedArg{}, 60, sqlmock.AnyArg(), "fcm", hash).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	changed, err := i.RotateChannelTokens(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, changed)
+	assert.Nil(t, mock.ExpectationsWereMet())
+
+	_, err = (&Instance{Gorm: db}).RotateChannelTokens(context.Background())
+	assert.NotNil(t, err)
+}
@@ -1,9 +1,10 @@
 package db
 
 import (
+	"context"
 	"github.com/jinzhu/gorm"
-	"github.com/trustwallet/blockatlas/db/models"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/secrets"
 	"go.elastic.co/apm/module/apmgorm"
 	_ "go.elastic.co/apm/module/apmgorm/dialects/postgres"
 	"time"
@@ -11,9 +12,31 @@ import (
 
 type Instance struct {
 	Gorm *gorm.DB
+	// Replica serves the read-only lookups of the observer when configured
+	Replica *gorm.DB
+	// memory replaces Postgres for the subscriptions and trackers in the snapshot mode
+	memory *memoryStore
+	// keyring encrypts the channel tokens, nil stores them in plaintext
+	keyring *secrets.Keyring
 }
 
+// New connects to the database, the services refuse to start on a schema
+// older or newer than the migrations of the build
 func New(uri, env string) (*Instance, error) {
+	i, err := Open(uri, env)
+	if err != nil {
+		return nil, err
+	}
+	if env == "prod" {
+		if err := i.CheckSchemaVersion(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// Open connects to the database without checking its schema, used by the migrate command
+func Open(uri, env string) (*Instance, error) {
 	var (
 		g   *gorm.DB
 		err error
@@ -28,29 +51,55 @@ func New(uri, env string) (*Instance, error) {
 		return nil, err
 	}
 
-	g.AutoMigrate(
-		&models.Subscription{},
-		&models.Tracker{},
-	)
+	return &Instance{Gorm: g}, nil
+}
 
-	i := &Instance{Gorm: g}
+// ConnectReplica routes the subscription lookups of the notifier and subscriber to a read replica
+func (i *Instance) ConnectReplica(uri, env string) error {
+	replica, err := Open(uri, env)
+	if err != nil {
+		return err
+	}
+	i.Replica = replica.Gorm
+	return nil
+}
 
-	return i, nil
+// UseKeyring encrypts the channel tokens stored from now on, the stored ones are read with any key of the keyring
+func (i *Instance) UseKeyring(keyring *secrets.Keyring) {
+	i.keyring = keyring
+}
+
+// reader returns the replica if configured, the primary otherwise
+func (i *Instance) reader() *gorm.DB {
+	if i.Replica != nil {
+		return i.Replica
+	}
+	return i.Gorm
 }
 
 func RestoreConnectionWorker(database *Instance, timeout time.Duration, uri string) {
 	logger.Info("Run PG RestoreConnectionWorker")
+	restoreConnection(func() *gorm.DB { return database.Gorm }, func(g *gorm.DB) { database.Gorm = g }, timeout, uri)
+}
+
+func RestoreReplicaConnectionWorker(database *Instance, timeout time.Duration, uri string) {
+	logger.Info("Run PG replica RestoreConnectionWorker")
+	restoreConnection(func() *gorm.DB { return database.Replica }, func(g *gorm.DB) { database.Replica = g }, timeout, uri)
+}
+
+func restoreConnection(get func() *gorm.DB, set func(*gorm.DB), timeout time.Duration, uri string) {
 	for {
-		if err := database.Gorm.DB().Ping(); err != nil {
+		if err := get().DB().Ping(); err != nil {
 			for {
 				logger.Warn("PG is not available now")
 				logger.Warn("Trying to connect to PG...")
-				database.Gorm, err = gorm.Open("postgres", uri)
+				g, err := gorm.Open("postgres", uri)
 				if err != nil {
 					logger.Warn("PG is still unavailable:", err.Error())
 					time.Sleep(timeout)
 					continue
 				} else {
+					set(g)
 					logger.Info("PG connection restored")
 					break
 				}
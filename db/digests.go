@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/secrets"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+func (i *Instance) AddDigestEntries(entries []models.DigestEntry, ctx context.Context) error {
+	if len(entries) == 0 {
+		return errors.E("Empty digest entries")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, e := range entries {
+		err := g.
+			Set("gorm:insert_option", "ON CONFLICT (coin, address, tx_id, direction) DO NOTHING").
+			Create(&e).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDueDigestSubscriptions returns the subscriptions of the digest period last summarized before the given time
+func (i *Instance) GetDueDigestSubscriptions(digest string, sentBefore time.Time, ctx context.Context) ([]models.ChannelSubscription, error) {
+	if i.memory != nil {
+		return nil, nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var subscriptions []models.ChannelSubscription
+	err := g.
+		Where("digest = ? AND digest_sent_at <= ?", digest, sentBefore).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return i.openTokens(subscriptions), nil
+}
+
+func (i *Instance) GetDigestEntries(coin uint, address string, since time.Time, ctx context.Context) ([]models.DigestEntry, error) {
+	if i.memory != nil {
+		return nil, nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var entries []models.DigestEntry
+	err := g.
+		Where("coin = ? AND address = ? AND created_at > ?", coin, address, since).
+		Order("created_at").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (i *Instance) SetDigestSentAt(subscriptions []models.ChannelSubscription, sentAt time.Time, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.
+			Model(&models.ChannelSubscription{}).
+			Where("coin = ? AND address = ? AND provider = ? AND token_hash = ?", s.Coin, s.Address, s.Provider, secrets.Hash(s.Token)).
+			Update("digest_sent_at", sentAt).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteDigestEntries removes the entries saved before the given time, they are part of every due digest
+func (i *Instance) DeleteDigestEntries(before time.Time, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	return g.Where("created_at < ?", before).Delete(&models.DigestEntry{}).Error
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// AddLendingRateAlerts creates the alerts, the existing ones get the new thresholds and keep their state
+func (i *Instance) AddLendingRateAlerts(alerts []models.LendingRateAlert, ctx context.Context) error {
+	if len(alerts) == 0 {
+		return errors.E("Empty lending alerts")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, a := range alerts {
+		err := g.
+			Set("gorm:insert_option", "ON CONFLICT (provider, asset, channel, token) DO UPDATE SET "+
+				"locale = excluded.locale, above = excluded.above, below = excluded.below, change = excluded.change").
+			Create(&a).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Instance) DeleteLendingRateAlerts(alerts []models.LendingRateAlert, ctx context.Context) error {
+	if len(alerts) == 0 {
+		return errors.E("Empty lending alerts")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, a := range alerts {
+		err := g.
+			Where("provider = ? AND asset = ? AND channel = ? AND token = ?", a.Provider, a.Asset, a.Channel, a.Token).
+			Delete(&models.LendingRateAlert{}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Instance) GetLendingRateAlerts(provider string, ctx context.Context) ([]models.LendingRateAlert, error) {
+	if i.memory != nil {
+		return nil, nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var alerts []models.LendingRateAlert
+	if err := g.Where("provider = ?", provider).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// SetLendingRateAlertsState saves the APY of the last evaluation and notification of the alerts
+func (i *Instance) SetLendingRateAlertsState(alerts []models.LendingRateAlert, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, a := range alerts {
+		err := g.
+			Model(&models.LendingRateAlert{}).
+			Where("provider = ? AND asset = ? AND channel = ? AND token = ?", a.Provider, a.Asset, a.Channel, a.Token).
+			Updates(map[string]interface{}{"apy": a.APY, "notified_apy": a.NotifiedAPY, "evaluated_at": a.EvaluatedAt}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddLiquidationAlerts creates the alerts, the existing ones get the new threshold and keep their state
+func (i *Instance) AddLiquidationAlerts(alerts []models.LiquidationAlert, ctx context.Context) error {
+	if len(alerts) == 0 {
+		return errors.E("Empty liquidation alerts")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, a := range alerts {
+		err := g.
+			Set("gorm:insert_option", "ON CONFLICT (provider, address, channel, token) DO UPDATE SET "+
+				"locale = excluded.locale, health_factor = excluded.health_factor").
+			Create(&a).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Instance) DeleteLiquidationAlerts(alerts []models.LiquidationAlert, ctx context.Context) error {
+	if len(alerts) == 0 {
+		return errors.E("Empty liquidation alerts")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, a := range alerts {
+		err := g.
+			Where("provider = ? AND address = ? AND channel = ? AND token = ?", a.Provider, a.Address, a.Channel, a.Token).
+			Delete(&models.LiquidationAlert{}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Instance) GetLiquidationAlerts(provider string, ctx context.Context) ([]models.LiquidationAlert, error) {
+	if i.memory != nil {
+		return nil, nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var alerts []models.LiquidationAlert
+	if err := g.Where("provider = ?", provider).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (i *Instance) SetLiquidationAlertsNotified(alerts []models.LiquidationAlert, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, a := range alerts {
+		err := g.
+			Model(&models.LiquidationAlert{}).
+			Where("provider = ? AND address = ? AND channel = ? AND token = ?", a.Provider, a.Address, a.Channel, a.Token).
+			Update("notified", a.Notified).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
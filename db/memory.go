@@ -0,0 +1,252 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	subscriptionsSnapshot = "subscriptions.json"
+	trackersSnapshot      = "trackers.json"
+)
+
+// ErrMemoryMode is returned by the storage needing Postgres (channel subscriptions,
+// digests, pending unbondings) when the instance runs in memory
+var ErrMemoryMode = errors.E("Not available in memory mode")
+
+type (
+	// memoryStore keeps the subscriptions and trackers of the in-memory mode,
+	// each kind is snapshotted to its own object by the service changing it
+	memoryStore struct {
+		sync.Mutex
+		store         SnapshotStore
+		subscriptions map[subscriptionKey]models.Subscription
+		// tenants having subscriptions, the addresses are looked up for each one
+		tenants  map[string]bool
+		trackers map[string]int64
+		dirty    map[string]bool
+	}
+
+	subscriptionKey struct {
+		coin    uint
+		address string
+		tenant  string
+	}
+)
+
+// NewMemory returns an instance keeping the subscriptions and trackers in memory, loaded from
+// the latest snapshots of the store. Changes are lost until RunSnapshotWorker saves them.
+func NewMemory(store SnapshotStore, ctx context.Context) (*Instance, error) {
+	m := &memoryStore{
+		store:         store,
+		subscriptions: make(map[subscriptionKey]models.Subscription),
+		tenants:       map[string]bool{"": true},
+		trackers:      make(map[string]int64),
+		dirty:         make(map[string]bool),
+	}
+	if err := m.load(ctx); err != nil {
+		return nil, err
+	}
+	return &Instance{memory: m}, nil
+}
+
+// RunSnapshotWorker saves the snapshots changed by this service and reloads the others
+// (e.g. the notifier picks up the subscriptions saved by the subscriber) every interval
+func RunSnapshotWorker(database *Instance, interval time.Duration, ctx context.Context) {
+	if database.memory == nil {
+		return
+	}
+	logger.Info("Run snapshot worker", logger.Params{"interval": interval})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := database.SaveSnapshot(context.Background()); err != nil {
+				logger.Error(err, "Failed to save the snapshots on shutdown")
+			}
+			return
+		case <-ticker.C:
+			if err := database.SaveSnapshot(ctx); err != nil {
+				logger.Error(err, "Failed to save the snapshots")
+			}
+			if err := database.memory.load(ctx); err != nil {
+				logger.Error(err, "Failed to reload the snapshots")
+			}
+		}
+	}
+}
+
+// SaveSnapshot saves the snapshots changed since the last save, a no-op out of the memory mode
+func (i *Instance) SaveSnapshot(ctx context.Context) error {
+	if i.memory == nil {
+		return nil
+	}
+	return i.memory.save(ctx)
+}
+
+func (m *memoryStore) getSubscriptions(coin uint, addresses []string) []models.Subscription {
+	m.Lock()
+	defer m.Unlock()
+	result := make([]models.Subscription, 0)
+	for _, address := range addresses {
+		for tenant := range m.tenants {
+			if s, ok := m.subscriptions[subscriptionKey{coin: coin, address: address, tenant: tenant}]; ok {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
+
+func (m *memoryStore) countSubscriptions(tenant string) int64 {
+	m.Lock()
+	defer m.Unlock()
+	var count int64
+	for key := range m.subscriptions {
+		if key.tenant == tenant {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *memoryStore) addSubscriptions(subscriptions []models.Subscription) {
+	m.Lock()
+	defer m.Unlock()
+	for _, s := range subscriptions {
+		key := subscriptionKey{coin: s.Coin, address: s.Address, tenant: s.Tenant}
+		if s.CreatedAt.IsZero() {
+			s.CreatedAt = time.Now()
+		}
+		m.subscriptions[key] = s
+		m.tenants[s.Tenant] = true
+	}
+	m.dirty[subscriptionsSnapshot] = true
+}
+
+func (m *memoryStore) renewSubscriptions(subscriptions []models.Subscription, expiresAt *time.Time) {
+	m.Lock()
+	defer m.Unlock()
+	for _, s := range subscriptions {
+		key := subscriptionKey{coin: s.Coin, address: s.Address, tenant: s.Tenant}
+		if stored, ok := m.subscriptions[key]; ok {
+			stored.ExpiresAt, stored.ExpiryNotified = expiresAt, false
+			m.subscriptions[key] = stored
+		}
+	}
+	m.dirty[subscriptionsSnapshot] = true
+}
+
+func (m *memoryStore) deleteSubscriptions(subscriptions []models.Subscription) {
+	m.Lock()
+	defer m.Unlock()
+	for _, s := range subscriptions {
+		delete(m.subscriptions, subscriptionKey{coin: s.Coin, address: s.Address, tenant: s.Tenant})
+	}
+	m.dirty[subscriptionsSnapshot] = true
+}
+
+func (m *memoryStore) getHeight(coin string) int64 {
+	m.Lock()
+	defer m.Unlock()
+	return m.trackers[coin]
+}
+
+func (m *memoryStore) setHeight(coin string, height int64) {
+	m.Lock()
+	defer m.Unlock()
+	m.trackers[coin] = height
+	m.dirty[trackersSnapshot] = true
+}
+
+// state returns a copy of the subscriptions and trackers
+func (m *memoryStore) state() ([]models.Subscription, map[string]int64) {
+	m.Lock()
+	defer m.Unlock()
+	subscriptions := make([]models.Subscription, 0, len(m.subscriptions))
+	for _, s := range m.subscriptions {
+		subscriptions = append(subscriptions, s)
+	}
+	trackers := make(map[string]int64, len(m.trackers))
+	for coin, height := range m.trackers {
+		trackers[coin] = height
+	}
+	return subscriptions, trackers
+}
+
+// load replaces the snapshots not changed since the last save with the stored ones
+func (m *memoryStore) load(ctx context.Context) error {
+	subscriptions, err := m.store.Load(subscriptionsSnapshot, ctx)
+	if err != nil {
+		return err
+	}
+	trackers, err := m.store.Load(trackersSnapshot, ctx)
+	if err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	if subscriptions != nil && !m.dirty[subscriptionsSnapshot] {
+		var list []models.Subscription
+		if err := json.Unmarshal(subscriptions, &list); err != nil {
+			return errors.E(err, "Invalid subscriptions snapshot")
+		}
+		m.subscriptions = make(map[subscriptionKey]models.Subscription, len(list))
+		for _, s := range list {
+			m.subscriptions[subscriptionKey{coin: s.Coin, address: s.Address, tenant: s.Tenant}] = s
+			m.tenants[s.Tenant] = true
+		}
+	}
+	if trackers != nil && !m.dirty[trackersSnapshot] {
+		heights := make(map[string]int64)
+		if err := json.Unmarshal(trackers, &heights); err != nil {
+			return errors.E(err, "Invalid trackers snapshot")
+		}
+		m.trackers = heights
+	}
+	return nil
+}
+
+// save writes the snapshots changed since the last save
+func (m *memoryStore) save(ctx context.Context) error {
+	m.Lock()
+	snapshots := make(map[string]interface{})
+	if m.dirty[subscriptionsSnapshot] {
+		list := make([]models.Subscription, 0, len(m.subscriptions))
+		for _, s := range m.subscriptions {
+			list = append(list, s)
+		}
+		snapshots[subscriptionsSnapshot] = list
+	}
+	if m.dirty[trackersSnapshot] {
+		heights := make(map[string]int64, len(m.trackers))
+		for coin, height := range m.trackers {
+			heights[coin] = height
+		}
+		snapshots[trackersSnapshot] = heights
+	}
+	m.dirty = make(map[string]bool)
+	m.Unlock()
+
+	for name, snapshot := range snapshots {
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		if err := m.store.Save(name, data, ctx); err != nil {
+			m.Lock()
+			m.dirty[name] = true
+			m.Unlock()
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+)
+
+func TestMemory_Snapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+	store := &FileSnapshotStore{Dir: dir}
+
+	subscriber, err := NewMemory(store, ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, subscriber.AddSubscriptions([]models.Subscription{
+		{Coin: 60, Address: "0xa"},
+		{Coin: 60, Address: "0xb"},
+	}, ctx))
+	assert.Nil(t, subscriber.AddSubscriptions([]models.Subscription{{Coin: 60, Address: "0xa"}}, ctx))
+	assert.Nil(t, subscriber.DeleteSubscriptions([]models.Subscription{{Coin: 60, Address: "0xb"}}, ctx))
+	assert.Nil(t, subscriber.SaveSnapshot(ctx))
+
+	parser, err := NewMemory(store, ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, parser.SetLastParsedBlockNumber("memory_test", 10, ctx))
+	assert.Nil(t, parser.SaveSnapshot(ctx))
+
+	notifier, err := NewMemory(store, ctx)
+	assert.Nil(t, err)
+	subscriptions, err := notifier.GetSubscriptions(60, []string{"0xa", "0xb"}, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, subscriptions, 1)
+	assert.Equal(t, "0xa", subscriptions[0].Address)
+	assert.Equal(t, int64(10), notifier.memory.getHeight("memory_test"))
+
+	// The notifier never overwrites the subscriptions of the subscriber
+	assert.Nil(t, subscriber.DeleteSubscriptions([]models.Subscription{{Coin: 60, Address: "0xa"}}, ctx))
+	assert.Nil(t, subscriber.SaveSnapshot(ctx))
+	assert.Nil(t, notifier.SaveSnapshot(ctx))
+	assert.Nil(t, notifier.memory.load(ctx))
+	subscriptions, err = notifier.GetSubscriptions(60, []string{"0xa"}, ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, subscriptions)
+}
+
+func TestMemory_PostgresOnly(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewMemory(&FileSnapshotStore{Dir: os.TempDir() + "/missing_snapshots"}, ctx)
+	assert.Nil(t, err)
+
+	channels, err := i.GetChannelSubscriptions(60, []string{"0xa"}, ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, channels)
+	assert.Equal(t, ErrMemoryMode, i.AddChannelSubscriptions([]models.ChannelSubscription{{Coin: 60}}, ctx))
+	assert.Nil(t, i.DeletePendingUnbondings(nil, ctx))
+}
+
+func TestMemory_RenewSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewMemory(&FileSnapshotStore{Dir: os.TempDir() + "/missing_snapshots"}, ctx)
+	assert.Nil(t, err)
+
+	expiresAt := time.Unix(1600000000, 0)
+	assert.Nil(t, i.AddSubscriptions([]models.Subscription{{Coin: 60, Address: "0xa", ExpiresAt: &expiresAt, ExpiryNotified: true}}, ctx))
+
+	renewed := expiresAt.Add(24 * time.Hour)
+	assert.Nil(t, i.RenewSubscriptions([]models.Subscription{{Coin: 60, Address: "0xa"}, {Coin: 60, Address: "0xb"}}, &renewed, ctx))
+	subscriptions, err := i.GetSubscriptions(60, []string{"0xa", "0xb"}, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, subscriptions, 1, "renewing doesn't add subscriptions")
+	assert.Equal(t, renewed, *subscriptions[0].ExpiresAt)
+	assert.False(t, subscriptions[0].ExpiryNotified)
+
+	_, err = i.DeleteExpiredSubscriptions(renewed, ctx)
+	assert.Equal(t, ErrMemoryMode, err)
+}
+
+func TestHTTPSnapshotStore(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		objects = make(map[string][]byte)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	store, err := NewSnapshotStore(server.URL+"/bucket/", "secret")
+	assert.Nil(t, err)
+	data, err := store.Load(trackersSnapshot, ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, data)
+
+	assert.Nil(t, store.Save(trackersSnapshot, []byte(`{"bitcoin":1}`), ctx))
+	data, err = store.Load(trackersSnapshot, ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"bitcoin":1}`, string(data))
+	assert.Contains(t, objects, "/bucket/trackers.json")
+
+	_, err = NewSnapshotStore("ftp://bucket", "")
+	assert.NotNil(t, err)
+}
+
+func TestMemory_Tenants(t *testing.T) {
+	ctx := context.Background()
+	i, err := NewMemory(&FileSnapshotStore{Dir: os.TempDir() + "/missing_snapshots"}, ctx)
+	assert.Nil(t, err)
+
+	assert.Nil(t, i.AddSubscriptions([]models.Subscription{
+		{Coin: 60, Address: "0xa"},
+		{Coin: 60, Address: "0xa", Tenant: "exchange"},
+		{Coin: 60, Address: "0xb", Tenant: "exchange"},
+	}, ctx))
+	subscriptions, err := i.GetSubscriptions(60, []string{"0xa"}, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, subscriptions, 2, "each tenant has its own subscription of the address")
+
+	count, err := i.CountSubscriptions("exchange", ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+
+	assert.Nil(t, i.DeleteSubscriptions([]models.Subscription{{Coin: 60, Address: "0xa", Tenant: "exchange"}}, ctx))
+	subscriptions, err = i.GetSubscriptions(60, []string{"0xa"}, ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []models.Subscription{{CreatedAt: subscriptions[0].CreatedAt, Coin: 60, Address: "0xa"}}, subscriptions)
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/migrations"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+const (
+	createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, name varchar(128), applied_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP)`
+	// migrationsLockID is the advisory lock serializing the migrations of concurrent runners
+	migrationsLockID = 20200701
+)
+
+// SchemaVersion returns the last migration applied to the database, 0 for a new database
+func (i *Instance) SchemaVersion(ctx context.Context) (uint, error) {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	if err := g.Exec(createSchemaMigrations).Error; err != nil {
+		return 0, err
+	}
+	return schemaVersion(g)
+}
+
+// CheckSchemaVersion fails if the database is not migrated to the version of this build
+func (i *Instance) CheckSchemaVersion(ctx context.Context) error {
+	version, err := i.SchemaVersion(ctx)
+	if err != nil {
+		return errors.E(err, "failed to read the schema version")
+	}
+	if latest := migrations.Latest(); version != latest {
+		return errors.E("database schema is not up to date, run the migrate command",
+			errors.Params{"version": version, "expected": latest})
+	}
+	return nil
+}
+
+// MigrateUp applies the pending migrations, each one in its own transaction
+func (i *Instance) MigrateUp(ctx context.Context) (int, error) {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	if err := g.Exec(createSchemaMigrations).Error; err != nil {
+		return 0, err
+	}
+	applied := 0
+	for _, m := range migrations.All() {
+		ok, err := migrate(g, func(version uint) bool { return version+1 == m.Version }, func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", m.Version, m.Name).Error
+		})
+		if err != nil {
+			return applied, errors.E(err, "migration failed", errors.Params{"version": m.Version, "name": m.Name})
+		}
+		if ok {
+			applied++
+			logger.Info("Applied migration", logger.Params{"version": m.Version, "name": m.Name})
+		}
+	}
+	return applied, nil
+}
+
+// MigrateDown reverts the given number of migrations, starting from the last applied
+func (i *Instance) MigrateDown(steps int, ctx context.Context) (int, error) {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	all := migrations.All()
+	reverted := 0
+	for j := len(all) - 1; j >= 0 && reverted < steps; j-- {
+		m := all[j]
+		ok, err := migrate(g, func(version uint) bool { return version == m.Version }, func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return err
+			}
+			return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version).Error
+		})
+		if err != nil {
+			return reverted, errors.E(err, "migration revert failed", errors.Params{"version": m.Version, "name": m.Name})
+		}
+		if ok {
+			reverted++
+			logger.Info("Reverted migration", logger.Params{"version": m.Version, "name": m.Name})
+		}
+	}
+	return reverted, nil
+}
+
+// migrate runs the change in a transaction holding the migrations lock,
+// if the current version still matches once the lock is taken
+func migrate(g *gorm.DB, matches func(version uint) bool, change func(tx *gorm.DB) error) (bool, error) {
+	tx := g.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationsLockID).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	version, err := schemaVersion(tx)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if !matches(version) {
+		tx.Rollback()
+		return false, nil
+	}
+	if err := change(tx); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return true, tx.Commit().Error
+}
+
+func schemaVersion(g *gorm.DB) (uint, error) {
+	var version uint
+	row := g.Raw("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Row()
+	if err := row.Scan(&version); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
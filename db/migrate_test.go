@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/migrations"
+)
+
+func expectVersion(mock sqlmock.Sqlmock, version uint) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+}
+
+func TestInstance_MigrateUp(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+
+	mock.ExpectExec(regexp.QuoteMeta(createSchemaMigrations)).WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, m := range migrations.All() {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1)`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectVersion(mock, m.Version-1)
+		mock.ExpectExec(regexp.QuoteMeta(m.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`)).
+			WithArgs(m.Version, m.Name).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+	}
+
+	applied, err := i.MigrateUp(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations.All()), applied)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInstance_MigrateUp_Applied(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+
+	mock.ExpectExec(regexp.QuoteMeta(createSchemaMigrations)).WillReturnResult(sqlmock.NewResult(0, 0))
+	for range migrations.All() {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock($1)`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectVersion(mock, migrations.Latest())
+		mock.ExpectRollback()
+	}
+
+	applied, err := i.MigrateUp(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, applied)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInstance_CheckSchemaVersion(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+
+	mock.ExpectExec(regexp.QuoteMeta(createSchemaMigrations)).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectVersion(mock, migrations.Latest())
+	assert.Nil(t, i.CheckSchemaVersion(context.Background()))
+
+	mock.ExpectExec(regexp.QuoteMeta(createSchemaMigrations)).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectVersion(mock, 0)
+	assert.NotNil(t, i.CheckSchemaVersion(context.Background()))
+}
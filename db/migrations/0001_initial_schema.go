@@ -0,0 +1,137 @@
+package migrations
+
+// The subscriptions and trackers tables were managed by gorm AutoMigrate before, IF NOT EXISTS lets existing
+// databases adopt this version and their columns added since are added with ADD COLUMN IF NOT EXISTS.
+// Reverting keeps these adopted tables and their rows.
+func init() {
+	register(1, "initial_schema", `
+CREATE TABLE IF NOT EXISTS subscriptions (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	coin bigint NOT NULL,
+	address varchar(128) NOT NULL,
+	PRIMARY KEY (coin, address)
+);
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS locale varchar(16);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_coin ON subscriptions (coin);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_address ON subscriptions (address);
+
+CREATE TABLE IF NOT EXISTS trackers (
+	updated_at timestamp with time zone,
+	coin varchar(64) NOT NULL,
+	height bigint,
+	PRIMARY KEY (coin)
+);
+
+CREATE TABLE IF NOT EXISTS token_transfers (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	coin bigint NOT NULL,
+	tx_hash varchar(128) NOT NULL,
+	log_index bigint NOT NULL,
+	block_number bigint,
+	timestamp bigint,
+	token varchar(128),
+	name text,
+	symbol text,
+	decimals bigint,
+	from_address varchar(128),
+	to_address varchar(128),
+	value text,
+	PRIMARY KEY (coin, tx_hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_block_number ON token_transfers (block_number);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_token ON token_transfers (token);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_from_address ON token_transfers (from_address);
+CREATE INDEX IF NOT EXISTS idx_token_transfers_to_address ON token_transfers (to_address);
+
+CREATE TABLE IF NOT EXISTS address_books (
+	id bigserial PRIMARY KEY,
+	created_at timestamp with time zone
+);
+
+CREATE TABLE IF NOT EXISTS address_book_tokens (
+	hash varchar(64) NOT NULL PRIMARY KEY,
+	address_book_id bigint,
+	device varchar(128),
+	created_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_address_book_tokens_address_book_id ON address_book_tokens (address_book_id);
+
+CREATE TABLE IF NOT EXISTS address_book_entries (
+	id bigserial PRIMARY KEY,
+	address_book_id bigint,
+	coin bigint,
+	address varchar(128),
+	label varchar(256),
+	memo varchar(256),
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone
+);
+CREATE INDEX IF NOT EXISTS idx_address_book_entries_address_book_id ON address_book_entries (address_book_id);
+CREATE INDEX IF NOT EXISTS idx_address_book_entries_updated_at ON address_book_entries (updated_at);
+CREATE INDEX IF NOT EXISTS idx_address_book_entries_deleted_at ON address_book_entries (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tx_notes (
+	address_book_id bigint NOT NULL,
+	coin bigint NOT NULL,
+	hash varchar(128) NOT NULL,
+	note varchar(1024),
+	tags varchar(512),
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	PRIMARY KEY (address_book_id, coin, hash)
+);
+
+CREATE TABLE IF NOT EXISTS channel_subscriptions (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	coin bigint NOT NULL,
+	address varchar(128) NOT NULL,
+	provider varchar(16) NOT NULL,
+	token varchar(512) NOT NULL,
+	locale varchar(16),
+	digest varchar(8),
+	digest_sent_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (coin, address, provider, token)
+);
+CREATE INDEX IF NOT EXISTS idx_channel_subscriptions_coin ON channel_subscriptions (coin);
+CREATE INDEX IF NOT EXISTS idx_channel_subscriptions_address ON channel_subscriptions (address);
+CREATE INDEX IF NOT EXISTS idx_channel_subscriptions_token ON channel_subscriptions (token);
+
+CREATE TABLE IF NOT EXISTS pending_unbondings (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	coin bigint NOT NULL,
+	tx_id varchar(128) NOT NULL,
+	delegator varchar(128),
+	validator varchar(128),
+	value varchar(64),
+	symbol varchar(16),
+	decimals bigint,
+	completes_at timestamp with time zone,
+	PRIMARY KEY (coin, tx_id)
+);
+CREATE INDEX IF NOT EXISTS idx_pending_unbondings_completes_at ON pending_unbondings (completes_at);
+
+CREATE TABLE IF NOT EXISTS digest_entries (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	coin bigint NOT NULL,
+	address varchar(128) NOT NULL,
+	tx_id varchar(128) NOT NULL,
+	direction varchar(16) NOT NULL,
+	symbol varchar(16),
+	decimals bigint,
+	value varchar(80),
+	PRIMARY KEY (coin, address, tx_id, direction)
+);
+CREATE INDEX IF NOT EXISTS idx_digest_entries_created_at ON digest_entries (created_at);
+`, `
+DROP TABLE IF EXISTS digest_entries;
+DROP TABLE IF EXISTS pending_unbondings;
+DROP TABLE IF EXISTS channel_subscriptions;
+DROP TABLE IF EXISTS tx_notes;
+DROP TABLE IF EXISTS address_book_entries;
+DROP TABLE IF EXISTS address_book_tokens;
+DROP TABLE IF EXISTS address_books;
+DROP TABLE IF EXISTS token_transfers;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS locale;
+`)
+}
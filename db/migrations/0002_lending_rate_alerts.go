@@ -0,0 +1,25 @@
+package migrations
+
+func init() {
+	register(2, "lending_rate_alerts", `
+CREATE TABLE lending_rate_alerts (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	provider varchar(32) NOT NULL,
+	asset varchar(16) NOT NULL,
+	channel varchar(16) NOT NULL,
+	token varchar(512) NOT NULL,
+	locale varchar(16),
+	above double precision,
+	below double precision,
+	change double precision,
+	apy double precision,
+	notified_apy double precision,
+	evaluated_at timestamp with time zone,
+	PRIMARY KEY (provider, asset, channel, token)
+);
+CREATE INDEX idx_lending_rate_alerts_provider ON lending_rate_alerts (provider);
+CREATE INDEX idx_lending_rate_alerts_token ON lending_rate_alerts (token);
+`, `
+DROP TABLE IF EXISTS lending_rate_alerts;
+`)
+}
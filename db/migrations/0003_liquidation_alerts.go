@@ -0,0 +1,21 @@
+package migrations
+
+func init() {
+	register(3, "liquidation_alerts", `
+CREATE TABLE liquidation_alerts (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	provider varchar(32) NOT NULL,
+	address varchar(128) NOT NULL,
+	channel varchar(16) NOT NULL,
+	token varchar(512) NOT NULL,
+	locale varchar(16),
+	health_factor double precision,
+	notified boolean DEFAULT false,
+	PRIMARY KEY (provider, address, channel, token)
+);
+CREATE INDEX idx_liquidation_alerts_provider ON liquidation_alerts (provider);
+CREATE INDEX idx_liquidation_alerts_token ON liquidation_alerts (token);
+`, `
+DROP TABLE IF EXISTS liquidation_alerts;
+`)
+}
@@ -0,0 +1,20 @@
+package migrations
+
+func init() {
+	register(4, "notification_history", `
+CREATE TABLE notification_records (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	coin bigint NOT NULL,
+	address varchar(128) NOT NULL,
+	tx_id varchar(128) NOT NULL,
+	direction varchar(16) NOT NULL,
+	date bigint,
+	payload text,
+	PRIMARY KEY (coin, address, tx_id, direction)
+);
+CREATE INDEX idx_notification_records_created_at ON notification_records (created_at);
+CREATE INDEX idx_notification_records_date ON notification_records (date);
+`, `
+DROP TABLE IF EXISTS notification_records;
+`)
+}
@@ -0,0 +1,13 @@
+package migrations
+
+func init() {
+	register(5, "subscription_expiry", `
+ALTER TABLE subscriptions ADD COLUMN expires_at timestamp with time zone, ADD COLUMN expiry_notified boolean DEFAULT false;
+CREATE INDEX idx_subscriptions_expires_at ON subscriptions (expires_at);
+ALTER TABLE channel_subscriptions ADD COLUMN expires_at timestamp with time zone, ADD COLUMN expiry_notified boolean DEFAULT false;
+CREATE INDEX idx_channel_subscriptions_expires_at ON channel_subscriptions (expires_at);
+`, `
+ALTER TABLE channel_subscriptions DROP COLUMN IF EXISTS expires_at, DROP COLUMN IF EXISTS expiry_notified;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS expires_at, DROP COLUMN IF EXISTS expiry_notified;
+`)
+}
@@ -0,0 +1,22 @@
+package migrations
+
+func init() {
+	register(6, "xpub_subscriptions", `
+CREATE TABLE xpub_subscriptions (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	coin bigint NOT NULL,
+	xpub varchar(512) NOT NULL,
+	locale varchar(16),
+	expires_at timestamp with time zone,
+	addresses bigint DEFAULT 0,
+	synced_at timestamp with time zone,
+	PRIMARY KEY (coin, xpub)
+);
+CREATE INDEX idx_xpub_subscriptions_expires_at ON xpub_subscriptions (expires_at);
+ALTER TABLE subscriptions ADD COLUMN xpub varchar(512) DEFAULT '';
+CREATE INDEX idx_subscriptions_xpub ON subscriptions (xpub);
+`, `
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS xpub;
+DROP TABLE IF EXISTS xpub_subscriptions;
+`)
+}
@@ -0,0 +1,26 @@
+package migrations
+
+func init() {
+	register(7, "tenants", `
+ALTER TABLE subscriptions ADD COLUMN tenant varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE subscriptions DROP CONSTRAINT subscriptions_pkey, ADD PRIMARY KEY (coin, address, tenant);
+CREATE INDEX idx_subscriptions_tenant ON subscriptions (tenant);
+ALTER TABLE xpub_subscriptions ADD COLUMN tenant varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE xpub_subscriptions DROP CONSTRAINT xpub_subscriptions_pkey, ADD PRIMARY KEY (coin, xpub, tenant);
+CREATE TABLE tenant_usages (
+	tenant varchar(64) NOT NULL,
+	day varchar(10) NOT NULL,
+	notifications bigint DEFAULT 0,
+	throttled bigint DEFAULT 0,
+	PRIMARY KEY (tenant, day)
+);
+`, `
+DROP TABLE IF EXISTS tenant_usages;
+DELETE FROM xpub_subscriptions WHERE tenant <> '';
+ALTER TABLE xpub_subscriptions DROP CONSTRAINT xpub_subscriptions_pkey, ADD PRIMARY KEY (coin, xpub);
+ALTER TABLE xpub_subscriptions DROP COLUMN tenant;
+DELETE FROM subscriptions WHERE tenant <> '';
+ALTER TABLE subscriptions DROP CONSTRAINT subscriptions_pkey, ADD PRIMARY KEY (coin, address);
+ALTER TABLE subscriptions DROP COLUMN tenant;
+`)
+}
@@ -0,0 +1,11 @@
+package migrations
+
+func init() {
+	register(8, "retention", `
+CREATE INDEX IF NOT EXISTS idx_token_transfers_coin_timestamp ON token_transfers (coin, timestamp);
+CREATE INDEX IF NOT EXISTS idx_notification_records_coin_date ON notification_records (coin, date);
+`, `
+DROP INDEX IF EXISTS idx_notification_records_coin_date;
+DROP INDEX IF EXISTS idx_token_transfers_coin_timestamp;
+`)
+}
@@ -0,0 +1,23 @@
+package migrations
+
+func init() {
+	register(9, "encrypted_channel_tokens", `
+ALTER TABLE channel_subscriptions ADD COLUMN token_hash varchar(64);
+UPDATE channel_subscriptions SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex');
+ALTER TABLE channel_subscriptions ALTER COLUMN token_hash SET NOT NULL, ALTER COLUMN token TYPE text,
+	DROP CONSTRAINT channel_subscriptions_pkey, ADD PRIMARY KEY (coin, address, provider, token_hash);
+DROP INDEX IF EXISTS idx_channel_subscriptions_token;
+CREATE INDEX idx_channel_subscriptions_token_hash ON channel_subscriptions (token_hash);
+`, `
+DO $$
+BEGIN
+	IF EXISTS (SELECT 1 FROM channel_subscriptions WHERE token LIKE 'enc:%') THEN
+		RAISE EXCEPTION 'channel_subscriptions has encrypted tokens, run migrate decrypt-secrets first';
+	END IF;
+END $$;
+DROP INDEX IF EXISTS idx_channel_subscriptions_token_hash;
+ALTER TABLE channel_subscriptions DROP CONSTRAINT channel_subscriptions_pkey, ADD PRIMARY KEY (coin, address, provider, token),
+	ALTER COLUMN token TYPE varchar(512), DROP COLUMN token_hash;
+CREATE INDEX IF NOT EXISTS idx_channel_subscriptions_token ON channel_subscriptions (token);
+`)
+}
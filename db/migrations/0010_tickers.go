@@ -0,0 +1,17 @@
+package migrations
+
+func init() {
+	register(10, "tickers", `
+CREATE TABLE tickers (
+	provider varchar(32) NOT NULL,
+	coin varchar(32) NOT NULL,
+	currency varchar(16) NOT NULL,
+	price double precision NOT NULL DEFAULT 0,
+	change24h double precision NOT NULL DEFAULT 0,
+	priced_at bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (provider, coin, currency)
+);
+`, `
+DROP TABLE IF EXISTS tickers;
+`)
+}
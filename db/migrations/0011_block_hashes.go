@@ -0,0 +1,14 @@
+package migrations
+
+func init() {
+	register(11, "block_hashes", `
+CREATE TABLE block_hashes (
+	coin varchar(64) NOT NULL,
+	number bigint NOT NULL,
+	hash varchar(128),
+	PRIMARY KEY (coin, number)
+);
+`, `
+DROP TABLE IF EXISTS block_hashes;
+`)
+}
@@ -0,0 +1,20 @@
+package migrations
+
+func init() {
+	register(12, "bridge_transfers", `
+CREATE TABLE bridge_transfers (
+	created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamp with time zone,
+	message_id varchar(160) NOT NULL PRIMARY KEY,
+	protocol varchar(32),
+	source_coin bigint,
+	source_tx_id varchar(128),
+	destination_coin bigint,
+	destination_tx_id varchar(128)
+);
+CREATE INDEX idx_bridge_transfers_source_tx_id ON bridge_transfers (source_tx_id);
+CREATE INDEX idx_bridge_transfers_destination_tx_id ON bridge_transfers (destination_tx_id);
+`, `
+DROP TABLE IF EXISTS bridge_transfers;
+`)
+}
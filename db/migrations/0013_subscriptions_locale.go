@@ -0,0 +1,13 @@
+package migrations
+
+// The locale is kept by the channel subscriptions of each device, the subscriptions of an address
+// are shared by the subscribers of the tenant
+func init() {
+	register(13, "subscriptions_locale", `
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS locale;
+ALTER TABLE xpub_subscriptions DROP COLUMN IF EXISTS locale;
+`, `
+ALTER TABLE xpub_subscriptions ADD COLUMN IF NOT EXISTS locale varchar(16);
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS locale varchar(16);
+`)
+}
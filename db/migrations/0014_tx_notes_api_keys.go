@@ -0,0 +1,30 @@
+package migrations
+
+// The notes of the address books cannot be moved to an API key, they are dropped
+func init() {
+	register(14, "tx_notes_api_keys", `
+DROP TABLE IF EXISTS tx_notes;
+CREATE TABLE tx_notes (
+	key_hash varchar(64) NOT NULL,
+	coin bigint NOT NULL,
+	hash varchar(128) NOT NULL,
+	note varchar(1024),
+	tags varchar(512),
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	PRIMARY KEY (key_hash, coin, hash)
+);
+`, `
+DROP TABLE IF EXISTS tx_notes;
+CREATE TABLE tx_notes (
+	address_book_id bigint NOT NULL,
+	coin bigint NOT NULL,
+	hash varchar(128) NOT NULL,
+	note varchar(1024),
+	tags varchar(512),
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	PRIMARY KEY (address_book_id, coin, hash)
+);
+`)
+}
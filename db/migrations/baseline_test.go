@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+)
+
+var (
+	alterTable = regexp.MustCompile(`(?s)ALTER TABLE (\w+) ([^;]*);`)
+	addColumn  = regexp.MustCompile(`ADD COLUMN (?:IF NOT EXISTS )?(\w+)`)
+	dropColumn = regexp.MustCompile(`DROP COLUMN (?:IF EXISTS )?(\w+)`)
+	dropTable  = regexp.MustCompile(`DROP TABLE (?:IF EXISTS )?(\w+)`)
+)
+
+// baselineSchema is the schema gorm AutoMigrate created before the migrations
+func baselineSchema() map[string]map[string]bool {
+	return map[string]map[string]bool{
+		"subscriptions": {"created_at": true, "coin": true, "address": true},
+		"trackers":      {"updated_at": true, "coin": true, "height": true},
+	}
+}
+
+// alterColumns applies the columns added and dropped by the ALTER TABLE statements to the tables of the schema
+func alterColumns(schema map[string]map[string]bool, sql string) {
+	for _, statement := range alterTable.FindAllStringSubmatch(sql, -1) {
+		columns, ok := schema[statement[1]]
+		if !ok {
+			continue
+		}
+		for _, column := range addColumn.FindAllStringSubmatch(statement[2], -1) {
+			columns[column[1]] = true
+		}
+		for _, column := range dropColumn.FindAllStringSubmatch(statement[2], -1) {
+			delete(columns, column[1])
+		}
+	}
+}
+
+func modelColumns(model interface{}) []string {
+	var columns []string
+	for _, field := range (&gorm.Scope{Value: model}).GetModelStruct().StructFields {
+		if !field.IsIgnored {
+			columns = append(columns, field.DBName)
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func tableColumns(columns map[string]bool) []string {
+	result := make([]string, 0, len(columns))
+	for column := range columns {
+		result = append(result, column)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestAll_FromBaselineSchema(t *testing.T) {
+	schema := baselineSchema()
+	for _, m := range All() {
+		alterColumns(schema, m.Up)
+	}
+	assert.Equal(t, modelColumns(&models.Subscription{}), tableColumns(schema["subscriptions"]))
+	assert.Equal(t, modelColumns(&models.Tracker{}), tableColumns(schema["trackers"]))
+
+	all := All()
+	for i := len(all) - 1; i >= 0; i-- {
+		alterColumns(schema, all[i].Down)
+		for _, table := range dropTable.FindAllStringSubmatch(all[i].Down, -1) {
+			assert.NotContains(t, baselineSchema(), table[1], "migration %d drops an adopted baseline table", all[i].Version)
+		}
+	}
+	assert.Equal(t, baselineSchema(), schema)
+}
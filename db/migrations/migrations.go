@@ -0,0 +1,48 @@
+// Package migrations keeps the versioned SQL changes of the schema, applied in order by
+// db.MigrateUp. The builds still target Go 1.13, so the SQL lives in Go files instead of
+// embedded .sql files: add a new NNNN_name.go file registering the next version,
+// existing versions must never be edited once released.
+package migrations
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Migration changes the schema from the previous version to Version
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+var migrations = make(map[uint]Migration)
+
+func register(version uint, name, up, down string) {
+	if _, ok := migrations[version]; ok {
+		panic(fmt.Sprintf("duplicate migration version %d", version))
+	}
+	migrations[version] = Migration{Version: version, Name: name, Up: up, Down: down}
+}
+
+// All returns the migrations sorted by version
+func All() []Migration {
+	result := make([]Migration, 0, len(migrations))
+	for _, m := range migrations {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result
+}
+
+// Latest is the schema version expected by this build
+func Latest() uint {
+	var latest uint
+	for version := range migrations {
+		if version > latest {
+			latest = version
+		}
+	}
+	return latest
+}
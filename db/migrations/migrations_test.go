@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	all := All()
+	assert.NotEmpty(t, all)
+	for i, m := range all {
+		assert.Equal(t, uint(i+1), m.Version, "versions must follow each other")
+		assert.NotEmpty(t, m.Name)
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+	}
+	assert.Equal(t, all[len(all)-1].Version, Latest())
+}
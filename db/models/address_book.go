@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type (
+	AddressBook struct {
+		ID        uint `gorm:"primary_key"`
+		CreatedAt time.Time
+	}
+
+	// AddressBookToken only keeps the SHA-256 hash of the device token
+	AddressBookToken struct {
+		Hash          string `gorm:"primary_key; type:varchar(64)"`
+		AddressBookID uint   `sql:"index"`
+		Device        string `gorm:"type:varchar(128)"`
+		CreatedAt     time.Time
+	}
+
+	// AddressBookEntry is soft deleted, so other devices of the user can sync the removal
+	AddressBookEntry struct {
+		ID            uint `gorm:"primary_key"`
+		AddressBookID uint `sql:"index"`
+		Coin          uint
+		Address       string `gorm:"type:varchar(128)"`
+		Label         string `gorm:"type:varchar(256)"`
+		Memo          string `gorm:"type:varchar(256)"`
+		CreatedAt     time.Time
+		UpdatedAt     time.Time  `sql:"index"`
+		DeletedAt     *time.Time `sql:"index"`
+	}
+)
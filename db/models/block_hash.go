@@ -0,0 +1,9 @@
+package models
+
+// BlockHash is the hash of a block parsed recently, compared with the hash of the block at the same number
+// fetched again to detect the reorganizations of the chain
+type BlockHash struct {
+	Coin   string `gorm:"primary_key; type:varchar(64)"`
+	Number int64  `gorm:"primary_key; auto_increment:false"`
+	Hash   string `gorm:"type:varchar(128)"`
+}
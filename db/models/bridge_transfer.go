@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// BridgeTransfer links the transactions sending and delivering a bridge message, each side
+// saved by the parser of its network
+type BridgeTransfer struct {
+	CreatedAt       time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time
+	MessageID       string `gorm:"primary_key; type:varchar(160)"`
+	Protocol        string `gorm:"type:varchar(32)"`
+	SourceCoin      uint
+	SourceTxID      string `gorm:"type:varchar(128)" sql:"index"`
+	DestinationCoin uint
+	DestinationTxID string `gorm:"type:varchar(128)" sql:"index"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ChannelSubscription sends the transactions of the address to a channel token, e.g. a FCM device.
+// The token is stored encrypted when a keyring is set, the subscriptions are looked up by its hash.
+type ChannelSubscription struct {
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	Coin      uint      `gorm:"primary_key; auto_increment:false" sql:"index"`
+	Address   string    `gorm:"primary_key; type:varchar(128)" sql:"index"`
+	Provider  string    `gorm:"primary_key; type:varchar(16)"`
+	TokenHash string    `gorm:"primary_key; type:varchar(64)" sql:"index"`
+	Token     string    `gorm:"type:text"`
+	Locale    string    `gorm:"type:varchar(16)"`
+	// Digest period, the transactions are summarized instead of notified one by one
+	Digest       string    `gorm:"type:varchar(8)"`
+	DigestSentAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	// ExpiresAt is nil for the subscriptions kept until deleted
+	ExpiresAt      *time.Time `sql:"index"`
+	ExpiryNotified bool
+}
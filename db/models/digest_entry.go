@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// DigestEntry is a transaction of an address with digest subscriptions, waiting for the next summary
+type DigestEntry struct {
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" sql:"index"`
+	Coin      uint      `gorm:"primary_key; auto_increment:false"`
+	Address   string    `gorm:"primary_key; type:varchar(128)"`
+	TxID      string    `gorm:"primary_key; type:varchar(128)"`
+	Direction string    `gorm:"primary_key; type:varchar(16)"`
+	Symbol    string    `gorm:"type:varchar(16)"`
+	Decimals  uint
+	Value     string `gorm:"type:varchar(80)"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// LendingRateAlert sends the APY changes of an asset at a lending provider to a channel token
+type LendingRateAlert struct {
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	Provider  string    `gorm:"primary_key; type:varchar(32)" sql:"index"`
+	Asset     string    `gorm:"primary_key; type:varchar(16)"`
+	Channel   string    `gorm:"primary_key; type:varchar(16)"`
+	Token     string    `gorm:"primary_key; type:varchar(512)" sql:"index"`
+	Locale    string    `gorm:"type:varchar(16)"`
+	Above     float64
+	Below     float64
+	Change    float64
+	// APY of the last evaluation, and APY of the last notification the changes are measured from
+	APY         float64
+	NotifiedAPY float64
+	EvaluatedAt *time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// LiquidationAlert sends the liquidation risk of an address at a lending provider to a channel token
+type LiquidationAlert struct {
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	Provider     string    `gorm:"primary_key; type:varchar(32)" sql:"index"`
+	Address      string    `gorm:"primary_key; type:varchar(128)"`
+	Channel      string    `gorm:"primary_key; type:varchar(16)"`
+	Token        string    `gorm:"primary_key; type:varchar(512)" sql:"index"`
+	Locale       string    `gorm:"type:varchar(16)"`
+	HealthFactor float64
+	// Notified until the health factor recovers above the threshold
+	Notified bool
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// NotificationRecord is a notification published by the notifier, kept for the replays
+type NotificationRecord struct {
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" sql:"index"`
+	Coin      uint      `gorm:"primary_key; auto_increment:false"`
+	Address   string    `gorm:"primary_key; type:varchar(128)"`
+	TxID      string    `gorm:"primary_key; type:varchar(128)"`
+	Direction string    `gorm:"primary_key; type:varchar(16)"`
+	// Date of the transaction, the replays select a window of it
+	Date    int64 `sql:"index"`
+	Payload string
+}
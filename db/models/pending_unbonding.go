@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// PendingUnbonding is an undelegation waiting for the unbonding period of the chain to end
+type PendingUnbonding struct {
+	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	Coin        uint      `gorm:"primary_key; auto_increment:false"`
+	TxID        string    `gorm:"primary_key; type:varchar(128)"`
+	Delegator   string    `gorm:"type:varchar(128)"`
+	Validator   string    `gorm:"type:varchar(128)"`
+	Value       string    `gorm:"type:varchar(64)"`
+	Symbol      string    `gorm:"type:varchar(16)"`
+	Decimals    uint
+	CompletesAt time.Time `sql:"index"`
+}
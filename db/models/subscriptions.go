@@ -5,7 +5,14 @@ import (
 )
 
 type Subscription struct {
-	CreatedAt time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
-	Coin      uint       `gorm:"primary_key; column:coin; auto_increment:false" sql:"index"`
-	Address   string     `gorm:"primary_key; column:address; type:varchar(128)" sql:"index"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	Coin      uint      `gorm:"primary_key; column:coin; auto_increment:false" sql:"index"`
+	Address   string    `gorm:"primary_key; column:address; type:varchar(128)" sql:"index"`
+	// ExpiresAt is nil for the subscriptions kept until deleted
+	ExpiresAt      *time.Time `gorm:"column:expires_at" sql:"index"`
+	ExpiryNotified bool       `gorm:"column:expiry_notified"`
+	// Xpub is set on the subscriptions derived from a XpubSubscription
+	Xpub string `gorm:"column:xpub; type:varchar(512)" sql:"index"`
+	// Tenant owning the subscription, empty for the default tenant
+	Tenant string `gorm:"primary_key; column:tenant; type:varchar(64)" sql:"index"`
 }
@@ -0,0 +1,10 @@
+package models
+
+// TenantUsage counts the notifications of a tenant for a day (YYYY-MM-DD)
+type TenantUsage struct {
+	Tenant        string `gorm:"primary_key; type:varchar(64)"`
+	Day           string `gorm:"primary_key; type:varchar(10)"`
+	Notifications int64
+	// Notifications dropped over the rate limit of the tenant
+	Throttled int64
+}
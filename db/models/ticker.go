@@ -0,0 +1,12 @@
+package models
+
+// Ticker is the last price of a coin (by symbol) in a currency reported by a market provider,
+// PricedAt is the Unix time the provider priced it
+type Ticker struct {
+	Provider  string `gorm:"primary_key; type:varchar(32)"`
+	Coin      string `gorm:"primary_key; type:varchar(32)"`
+	Currency  string `gorm:"primary_key; type:varchar(16)"`
+	Price     float64
+	Change24h float64
+	PricedAt  int64
+}
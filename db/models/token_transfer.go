@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type TokenTransfer struct {
+	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	Coin        uint      `gorm:"primary_key; auto_increment:false"`
+	TxHash      string    `gorm:"primary_key; type:varchar(128)"`
+	LogIndex    uint      `gorm:"primary_key; auto_increment:false"`
+	BlockNumber uint64    `sql:"index"`
+	Timestamp   int64
+	Token       string `gorm:"type:varchar(128)" sql:"index"`
+	Name        string
+	Symbol      string
+	Decimals    uint
+	From        string `gorm:"column:from_address; type:varchar(128)" sql:"index"`
+	To          string `gorm:"column:to_address; type:varchar(128)" sql:"index"`
+	Value       string
+}
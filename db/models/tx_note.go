@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// TxNote belongs to the API key that wrote it, by the sha256 of the key.
+// Tags are stored comma separated
+type TxNote struct {
+	KeyHash   string `gorm:"primary_key; type:varchar(64)"`
+	Coin      uint   `gorm:"primary_key; auto_increment:false"`
+	Hash      string `gorm:"primary_key; type:varchar(128)"`
+	Note      string `gorm:"type:varchar(1024)"`
+	Tags      string `gorm:"type:varchar(512)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// XpubSubscription subscribes the addresses derived from an extended public key or an output descriptor
+type XpubSubscription struct {
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	Coin      uint      `gorm:"primary_key; auto_increment:false"`
+	Xpub      string    `gorm:"primary_key; type:varchar(512)"`
+	Tenant    string    `gorm:"primary_key; type:varchar(64)"`
+	// ExpiresAt is nil for the subscriptions kept until deleted
+	ExpiresAt *time.Time `sql:"index"`
+	// Addresses derived at the last sync
+	Addresses int
+	SyncedAt  *time.Time
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+func (i *Instance) AddNotificationRecords(records []models.NotificationRecord, ctx context.Context) error {
+	if len(records) == 0 {
+		return errors.E("Empty notification records")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, r := range records {
+		err := g.
+			Set("gorm:insert_option", "ON CONFLICT (coin, address, tx_id, direction) DO NOTHING").
+			Create(&r).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetNotificationRecords returns the records of the addresses with a transaction date in [from, to], oldest first
+func (i *Instance) GetNotificationRecords(coin uint, addresses []string, from, to int64, ctx context.Context) ([]models.NotificationRecord, error) {
+	if len(addresses) == 0 {
+		return nil, errors.E("Empty addresses")
+	}
+	if i.memory != nil {
+		return nil, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var records []models.NotificationRecord
+	err := g.
+		Where("coin = ? AND address in (?) AND date >= ? AND date <= ?", coin, addresses, from, to).
+		Order("date").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// DeleteNotificationRecords removes the records saved before the given time
+func (i *Instance) DeleteNotificationRecords(before time.Time, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	return g.Where("created_at < ?", before).Delete(&models.NotificationRecord{}).Error
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+func (i *Instance) AddPendingUnbondings(unbondings []models.PendingUnbonding, ctx context.Context) error {
+	if len(unbondings) == 0 {
+		return errors.E("Empty unbondings")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, u := range unbondings {
+		err := g.
+			Set("gorm:insert_option", "ON CONFLICT (coin, tx_id) DO NOTHING").
+			Create(&u).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCompletedUnbondings returns the unbondings of the coin whose period ended before the given time
+func (i *Instance) GetCompletedUnbondings(coin uint, before time.Time, ctx context.Context) ([]models.PendingUnbonding, error) {
+	if i.memory != nil {
+		return nil, nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var unbondings []models.PendingUnbonding
+	err := g.
+		Where("coin = ? AND completes_at <= ?", coin, before).
+		Order("completes_at").
+		Find(&unbondings).Error
+	if err != nil {
+		return nil, err
+	}
+	return unbondings, nil
+}
+
+func (i *Instance) DeletePendingUnbondings(unbondings []models.PendingUnbonding, ctx context.Context) error {
+	if len(unbondings) == 0 {
+		return nil
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, u := range unbondings {
+		err := g.
+			Where("coin = ? AND tx_id = ?", u.Coin, u.TxID).
+			Delete(&models.PendingUnbonding{}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+const (
+	TableTokenTransfers      = "token_transfers"
+	TableNotificationRecords = "notification_records"
+)
+
+// TableSize is the estimated number of rows of a table, and its size on disk with the indexes
+type TableSize struct {
+	Table string
+	Rows  int64
+	Bytes int64
+}
+
+// TokenTransferCoins returns the coins with indexed transfers
+func (i *Instance) TokenTransferCoins(ctx context.Context) ([]uint, error) {
+	return i.storedCoins(&models.TokenTransfer{}, ctx)
+}
+
+// DeleteTokenTransfers removes the transfers of the coin from the blocks before the given time
+func (i *Instance) DeleteTokenTransfers(coin uint, before time.Time, ctx context.Context) (int64, error) {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	result := g.Where("coin = ? AND timestamp < ?", coin, before.Unix()).Delete(&models.TokenTransfer{})
+	return result.RowsAffected, result.Error
+}
+
+// NotificationRecordCoins returns the coins with recorded notifications
+func (i *Instance) NotificationRecordCoins(ctx context.Context) ([]uint, error) {
+	if i.memory != nil {
+		return nil, ErrMemoryMode
+	}
+	return i.storedCoins(&models.NotificationRecord{}, ctx)
+}
+
+// DeleteCoinNotificationRecords removes the records of the coin with a transaction date before the given time
+func (i *Instance) DeleteCoinNotificationRecords(coin uint, before time.Time, ctx context.Context) (int64, error) {
+	if i.memory != nil {
+		return 0, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	result := g.Where("coin = ? AND date < ?", coin, before.Unix()).Delete(&models.NotificationRecord{})
+	return result.RowsAffected, result.Error
+}
+
+func (i *Instance) storedCoins(model interface{}, ctx context.Context) ([]uint, error) {
+	g := apmgorm.WithContext(ctx, i.reader())
+	var coins []uint
+	if err := g.Model(model).Pluck("DISTINCT coin", &coins).Error; err != nil {
+		return nil, err
+	}
+	return coins, nil
+}
+
+// TableSizes returns the sizes of the tables from the statistics of Postgres, the row counts are
+// estimates updated by VACUUM and ANALYZE
+func (i *Instance) TableSizes(tables []string, ctx context.Context) ([]TableSize, error) {
+	if i.memory != nil {
+		return nil, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.reader())
+	rows, err := g.Raw(`SELECT relname, reltuples::bigint, pg_total_relation_size(oid) FROM pg_class WHERE relkind = 'r' AND relname IN (?)`, tables).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	sizes := make([]TableSize, 0, len(tables))
+	for rows.Next() {
+		var s TableSize
+		if err := rows.Scan(&s.Table, &s.Rows, &s.Bytes); err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, s)
+	}
+	return sizes, rows.Err()
+}
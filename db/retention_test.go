@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstance_DeleteTokenTransfers(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT coin FROM "token_transfers"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"coin"}).AddRow(60).AddRow(714))
+	coins, err := i.TokenTransferCoins(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{60, 714}, coins)
+
+	before := time.Unix(1600000000, 0)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "token_transfers" WHERE (coin = $1 AND timestamp < $2)`)).
+		WithArgs(60, before.Unix()).WillReturnResult(sqlmock.NewResult(0, 12))
+	mock.ExpectCommit()
+	deleted, err := i.DeleteTokenTransfers(60, before, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), deleted)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestInstance_TableSizes(t *testing.T) {
+	db, mock := setupDB(t)
+	defer db.Close()
+	i := Instance{Gorm: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT relname, reltuples::bigint, pg_total_relation_size(oid) FROM pg_class`)).
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "reltuples", "size"}).AddRow(TableTokenTransfers, 1200, 65536))
+	sizes, err := i.TableSizes([]string{TableTokenTransfers}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []TableSize{{Table: TableTokenTransfers, Rows: 1200, Bytes: 65536}}, sizes)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+type (
+	// SnapshotStore keeps the snapshots of the in-memory mode, Load returns nil for a missing snapshot
+	SnapshotStore interface {
+		Load(name string, ctx context.Context) ([]byte, error)
+		Save(name string, data []byte, ctx context.Context) error
+	}
+
+	// FileSnapshotStore keeps the snapshots in a local (or mounted) directory
+	FileSnapshotStore struct {
+		Dir string
+	}
+
+	// HTTPSnapshotStore keeps the snapshots as objects under an URL prefix with GET and PUT,
+	// e.g. a Google Cloud Storage or S3 compatible bucket endpoint
+	HTTPSnapshotStore struct {
+		BaseURL string
+		// Token is sent as bearer authorization when set
+		Token  string
+		Client *http.Client
+	}
+)
+
+// NewSnapshotStore returns the store of the URL, file:// for a directory, http(s):// for an object storage prefix
+func NewSnapshotStore(rawURL, token string) (SnapshotStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.E(err, "Invalid snapshot url", errors.Params{"url": rawURL})
+	}
+	switch u.Scheme {
+	case "file":
+		return &FileSnapshotStore{Dir: u.Path}, nil
+	case "http", "https":
+		return &HTTPSnapshotStore{
+			BaseURL: strings.TrimSuffix(rawURL, "/"),
+			Token:   token,
+			Client:  &http.Client{Timeout: time.Second * 30},
+		}, nil
+	default:
+		return nil, errors.E("Unsupported snapshot url scheme", errors.Params{"url": rawURL})
+	}
+}
+
+func (s *FileSnapshotStore) Load(name string, ctx context.Context) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(s.Dir, name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save writes to a temporary file first so a crash never leaves a truncated snapshot
+func (s *FileSnapshotStore) Save(name string, data []byte, ctx context.Context) error {
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(s.Dir, name)
+	if err := ioutil.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (s *HTTPSnapshotStore) Load(name string, ctx context.Context) ([]byte, error) {
+	res, err := s.do(http.MethodGet, name, nil, ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.E("Failed to load snapshot", errors.Params{"name": name, "status": res.StatusCode})
+	}
+	return ioutil.ReadAll(res.Body)
+}
+
+func (s *HTTPSnapshotStore) Save(name string, data []byte, ctx context.Context) error {
+	res, err := s.do(http.MethodPut, name, data, ctx)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.E("Failed to save snapshot", errors.Params{"name": name, "status": res.StatusCode})
+	}
+	return nil
+}
+
+func (s *HTTPSnapshotStore) do(method, name string, body []byte, ctx context.Context) (*http.Response, error) {
+	req, err := http.NewRequest(method, s.BaseURL+"/"+name, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	return s.Client.Do(req)
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+const (
+	xpubSubscriptionsEntry    = "xpub_subscriptions.json"
+	channelSubscriptionsEntry = "channel_subscriptions.json"
+)
+
+// State is the observer state moved between deployments or storage backends by the snapshot command.
+// The channel tokens are in plaintext, sealed again with the current key of the target on import.
+type State struct {
+	Subscriptions        []models.Subscription
+	Trackers             map[string]int64
+	XpubSubscriptions    []models.XpubSubscription
+	ChannelSubscriptions []models.ChannelSubscription
+}
+
+// ExportState returns the subscriptions and trackers of the instance, with the xpub and channel
+// subscriptions kept in Postgres only
+func (i *Instance) ExportState(ctx context.Context) (State, error) {
+	if i.memory != nil {
+		subscriptions, trackers := i.memory.state()
+		return State{Subscriptions: subscriptions, Trackers: trackers}, nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var state State
+	if err := g.Find(&state.Subscriptions).Error; err != nil {
+		return State{}, err
+	}
+	var trackers []models.Tracker
+	if err := g.Find(&trackers).Error; err != nil {
+		return State{}, err
+	}
+	state.Trackers = make(map[string]int64, len(trackers))
+	for _, t := range trackers {
+		state.Trackers[t.Coin] = t.Height
+	}
+	if err := g.Find(&state.XpubSubscriptions).Error; err != nil {
+		return State{}, err
+	}
+	var channels []models.ChannelSubscription
+	if err := g.Find(&channels).Error; err != nil {
+		return State{}, err
+	}
+	state.ChannelSubscriptions = i.openTokens(channels)
+	return state, nil
+}
+
+// ImportState adds the state to the one of the instance, replacing the subscriptions and trackers
+// of the same keys. The memory mode skips the xpub and channel subscriptions, and keeps the rest
+// until SaveSnapshot.
+func (i *Instance) ImportState(state State, ctx context.Context) error {
+	if len(state.Subscriptions) > 0 {
+		if err := i.AddSubscriptions(state.Subscriptions, ctx); err != nil {
+			return err
+		}
+	}
+	for coin, height := range state.Trackers {
+		if err := i.SetLastParsedBlockNumber(coin, height, ctx); err != nil {
+			return err
+		}
+	}
+	if i.memory != nil {
+		if skipped := len(state.XpubSubscriptions) + len(state.ChannelSubscriptions); skipped > 0 {
+			logger.Warn("Xpub and channel subscriptions not imported in memory mode", logger.Params{"skipped": skipped})
+		}
+		return nil
+	}
+	if len(state.XpubSubscriptions) > 0 {
+		if err := i.AddXpubSubscriptions(state.XpubSubscriptions, ctx); err != nil {
+			return err
+		}
+	}
+	if len(state.ChannelSubscriptions) > 0 {
+		if err := i.AddChannelSubscriptions(state.ChannelSubscriptions, ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteStateArchive writes the state as a gzipped tar of JSON files, the subscriptions and trackers
+// in the format of the snapshots of the memory mode
+func WriteStateArchive(w io.Writer, state State) error {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	entries := []struct {
+		name  string
+		value interface{}
+	}{
+		{subscriptionsSnapshot, state.Subscriptions},
+		{trackersSnapshot, state.Trackers},
+		{xpubSubscriptionsEntry, state.XpubSubscriptions},
+		{channelSubscriptionsEntry, state.ChannelSubscriptions},
+	}
+	now := time.Now()
+	for _, e := range entries {
+		data, err := json.Marshal(e.value)
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{Name: e.name, Mode: 0600, Size: int64(len(data)), ModTime: now}
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := archive.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadStateArchive reads an archive of WriteStateArchive, its missing files are empty
+func ReadStateArchive(r io.Reader) (State, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return State{}, errors.E(err, "Invalid state archive")
+	}
+	defer gz.Close()
+	archive := tar.NewReader(gz)
+	var state State
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return state, nil
+		}
+		if err != nil {
+			return State{}, errors.E(err, "Invalid state archive")
+		}
+		var value interface{}
+		switch header.Name {
+		case subscriptionsSnapshot:
+			value = &state.Subscriptions
+		case trackersSnapshot:
+			value = &state.Trackers
+		case xpubSubscriptionsEntry:
+			value = &state.XpubSubscriptions
+		case channelSubscriptionsEntry:
+			value = &state.ChannelSubscriptions
+		default:
+			continue
+		}
+		data, err := ioutil.ReadAll(archive)
+		if err != nil {
+			return State{}, errors.E(err, "Invalid state archive")
+		}
+		if err := json.Unmarshal(data, value); err != nil {
+			return State{}, errors.E(err, "Invalid state archive", errors.Params{"file": header.Name})
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+)
+
+func TestState_Archive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+
+	source, err := NewMemory(&FileSnapshotStore{Dir: dir + "/source"}, ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, source.AddSubscriptions([]models.Subscription{
+		{Coin: 60, Address: "0xa"},
+		{Coin: 60, Address: "0xb", Tenant: "acme"},
+	}, ctx))
+	assert.Nil(t, source.SetLastParsedBlockNumber("state_test", 42, ctx))
+
+	state, err := source.ExportState(ctx)
+	assert.Nil(t, err)
+	state.ChannelSubscriptions = []models.ChannelSubscription{{Coin: 60, Address: "0xa", Provider: "fcm", Token: "device"}}
+	var archive bytes.Buffer
+	assert.Nil(t, WriteStateArchive(&archive, state))
+
+	read, err := ReadStateArchive(&archive)
+	assert.Nil(t, err)
+	assert.Len(t, read.Subscriptions, 2)
+	assert.Equal(t, int64(42), read.Trackers["state_test"])
+	assert.Equal(t, "device", read.ChannelSubscriptions[0].Token)
+	assert.Empty(t, read.XpubSubscriptions)
+
+	target, err := NewMemory(&FileSnapshotStore{Dir: dir + "/target"}, ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, target.ImportState(read, ctx))
+	subscriptions, err := target.GetSubscriptions(60, []string{"0xa"}, ctx)
+	assert.Nil(t, err)
+	assert.Len(t, subscriptions, 1)
+	assert.Equal(t, "0xa", subscriptions[0].Address)
+	assert.Equal(t, int64(1), target.memory.countSubscriptions("acme"))
+	assert.Equal(t, int64(42), target.memory.getHeight("state_test"))
+
+	_, err = ReadStateArchive(bytes.NewBufferString("not an archive"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/secrets"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// RenewSubscriptions sets the expiry of the stored subscriptions, nil keeps them until deleted
+func (i *Instance) RenewSubscriptions(subscriptions []models.Subscription, expiresAt *time.Time, ctx context.Context) error {
+	if len(subscriptions) == 0 {
+		return errors.E("Empty subscriptions")
+	}
+	if i.memory != nil {
+		i.memory.renewSubscriptions(subscriptions, expiresAt)
+		return nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.Model(&models.Subscription{}).
+			Where("coin = ? AND address = ? AND tenant = ?", s.Coin, s.Address, s.Tenant).
+			Updates(map[string]interface{}{"expires_at": expiresAt, "expiry_notified": false}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenewChannelSubscriptions sets the expiry of the stored channel subscriptions, nil keeps them until deleted
+func (i *Instance) RenewChannelSubscriptions(subscriptions []models.ChannelSubscription, expiresAt *time.Time, ctx context.Context) error {
+	if len(subscriptions) == 0 {
+		return errors.E("Empty subscriptions")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.Model(&models.ChannelSubscription{}).
+			Where("coin = ? AND address = ? AND provider = ? AND token_hash = ?", s.Coin, s.Address, s.Provider, secrets.Hash(s.Token)).
+			Updates(map[string]interface{}{"expires_at": expiresAt, "expiry_notified": false}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetExpiringSubscriptions returns the subscriptions expiring before the time whose owners were not notified yet
+func (i *Instance) GetExpiringSubscriptions(before time.Time, ctx context.Context) ([]models.Subscription, error) {
+	if i.memory != nil {
+		return nil, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var subscriptions []models.Subscription
+	err := g.Where("expires_at <= ? AND NOT expiry_notified", before).Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// GetExpiringChannelSubscriptions returns the channel subscriptions expiring before the time whose owners were not notified yet
+func (i *Instance) GetExpiringChannelSubscriptions(before time.Time, ctx context.Context) ([]models.ChannelSubscription, error) {
+	if i.memory != nil {
+		return nil, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var subscriptions []models.ChannelSubscription
+	err := g.Where("expires_at <= ? AND NOT expiry_notified", before).Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return i.openTokens(subscriptions), nil
+}
+
+// SetSubscriptionsExpiryNotified records the expiry notices sent for the subscriptions
+func (i *Instance) SetSubscriptionsExpiryNotified(subscriptions []models.Subscription, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.Model(&models.Subscription{}).
+			Where("coin = ? AND address = ? AND tenant = ?", s.Coin, s.Address, s.Tenant).
+			Update("expiry_notified", true).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetChannelSubscriptionsExpiryNotified records the expiry notices sent for the channel subscriptions
+func (i *Instance) SetChannelSubscriptionsExpiryNotified(subscriptions []models.ChannelSubscription, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.Model(&models.ChannelSubscription{}).
+			Where("coin = ? AND address = ? AND provider = ? AND token_hash = ?", s.Coin, s.Address, s.Provider, secrets.Hash(s.Token)).
+			Update("expiry_notified", true).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteExpiredSubscriptions removes the subscriptions, channel and xpub subscriptions expired at the time,
+// returning how many were deleted
+func (i *Instance) DeleteExpiredSubscriptions(now time.Time, ctx context.Context) (int64, error) {
+	if i.memory != nil {
+		return 0, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	result := g.Where("expires_at <= ?", now).Delete(&models.Subscription{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	deleted := result.RowsAffected
+	result = g.Where("expires_at <= ?", now).Delete(&models.ChannelSubscription{})
+	if result.Error != nil {
+		return deleted, result.Error
+	}
+	deleted += result.RowsAffected
+	result = g.Where("expires_at <= ?", now).Delete(&models.XpubSubscription{})
+	if result.Error != nil {
+		return deleted, result.Error
+	}
+	return deleted + result.RowsAffected, nil
+}
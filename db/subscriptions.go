@@ -15,7 +15,10 @@ func (i *Instance) GetSubscriptions(coin uint, addresses []string, ctx context.C
 	if len(addresses) == 0 {
 		return nil, errors.E("Empty addresses")
 	}
-	g := apmgorm.WithContext(ctx, i.Gorm)
+	if i.memory != nil {
+		return i.memory.getSubscriptions(coin, addresses), nil
+	}
+	g := apmgorm.WithContext(ctx, i.reader())
 	var subscriptionsDataList []models.Subscription
 	err := g.
 		Model(&models.Subscription{}).
@@ -32,6 +35,10 @@ func (i *Instance) AddSubscriptions(subscriptions []models.Subscription, ctx con
 	if len(subscriptions) == 0 {
 		return errors.E("Empty subscriptions")
 	}
+	if i.memory != nil {
+		i.memory.addSubscriptions(subscriptions)
+		return nil
+	}
 
 	subscriptionsBatch := toSubscriptionBatch(subscriptions, batchLimit, ctx)
 	g := apmgorm.WithContext(ctx, i.Gorm)
@@ -49,10 +56,14 @@ func (i *Instance) DeleteSubscriptions(subscriptions []models.Subscription, ctx
 	if len(subscriptions) == 0 {
 		return errors.E("Empty subscriptions")
 	}
+	if i.memory != nil {
+		i.memory.deleteSubscriptions(subscriptions)
+		return nil
+	}
 
 	g := apmgorm.WithContext(ctx, i.Gorm)
 	for _, s := range subscriptions {
-		err := g.Where("coin = ? and address = ?", s.Coin, s.Address).Delete(&models.Subscription{}).Error
+		err := g.Where("coin = ? and address = ? and tenant = ?", s.Coin, s.Address, s.Tenant).Delete(&models.Subscription{}).Error
 		if err != nil {
 			return err
 		}
@@ -60,9 +71,24 @@ func (i *Instance) DeleteSubscriptions(subscriptions []models.Subscription, ctx
 	return nil
 }
 
+// CountSubscriptions returns the number of subscriptions of the tenant
+func (i *Instance) CountSubscriptions(tenant string, ctx context.Context) (int64, error) {
+	if i.memory != nil {
+		return i.memory.countSubscriptions(tenant), nil
+	}
+	g := apmgorm.WithContext(ctx, i.reader())
+	var count int64
+	if err := g.Model(&models.Subscription{}).Where("tenant = ?", tenant).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 const (
-	batchLimit    = 3000
-	rawBulkInsert = `INSERT INTO subscriptions(coin,address) VALUES %s ON CONFLICT DO NOTHING`
+	batchLimit = 3000
+	// Adding a subscription again renews it with the latest expiry, notifying the new expiry again,
+	// and keeps the xpub it was first derived from.
+	rawBulkInsert = `INSERT INTO subscriptions(coin,address,tenant,expires_at,xpub) VALUES %s ON CONFLICT (coin,address,tenant) DO UPDATE SET expires_at = excluded.expires_at, expiry_notified = subscriptions.expiry_notified AND subscriptions.expires_at IS NOT DISTINCT FROM excluded.expires_at`
 )
 
 func bulkCreate(db *gorm.DB, dataList []models.Subscription) error {
@@ -72,10 +98,13 @@ func bulkCreate(db *gorm.DB, dataList []models.Subscription) error {
 	)
 
 	for _, d := range dataList {
-		valueStrings = append(valueStrings, "(?, ?)")
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?)")
 
 		valueArgs = append(valueArgs, d.Coin)
 		valueArgs = append(valueArgs, d.Address)
+		valueArgs = append(valueArgs, d.Tenant)
+		valueArgs = append(valueArgs, d.ExpiresAt)
+		valueArgs = append(valueArgs, d.Xpub)
 	}
 
 	smt := fmt.Sprintf(rawBulkInsert, strings.Join(valueStrings, ","))
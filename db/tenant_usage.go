@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/models"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// AddTenantUsage adds the notifications sent and throttled to the usage of the tenant for the day
+func (i *Instance) AddTenantUsage(tenant, day string, notifications, throttled int64, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	usage := models.TenantUsage{Tenant: tenant, Day: day, Notifications: notifications, Throttled: throttled}
+	return g.
+		Set("gorm:insert_option", "ON CONFLICT (tenant, day) DO UPDATE SET "+
+			"notifications = tenant_usages.notifications + excluded.notifications, throttled = tenant_usages.throttled + excluded.throttled").
+		Create(&usage).Error
+}
+
+// GetTenantUsage returns the usage of the tenant for the day, empty without notifications
+func (i *Instance) GetTenantUsage(tenant, day string, ctx context.Context) (models.TenantUsage, error) {
+	usage := models.TenantUsage{Tenant: tenant, Day: day}
+	if i.memory != nil {
+		return usage, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.reader())
+	err := g.Where("tenant = ? AND day = ?", tenant, day).First(&usage).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return usage, err
+	}
+	return usage, nil
+}
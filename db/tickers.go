@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/models"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// SaveTickers creates or replaces the tickers of the providers
+func (i *Instance) SaveTickers(tickers []models.Ticker, ctx context.Context) error {
+	if len(tickers) == 0 {
+		return nil
+	}
+	return apmgorm.WithContext(ctx, i.Gorm).Transaction(func(tx *gorm.DB) error {
+		for k := range tickers {
+			err := tx.
+				Set("gorm:insert_option", "ON CONFLICT (provider, coin, currency) DO UPDATE SET price = excluded.price, change24h = excluded.change24h, priced_at = excluded.priced_at").
+				Create(&tickers[k]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetTickers returns the tickers of the providers for the coins in the currency
+func (i *Instance) GetTickers(providers, coins []string, currency string, ctx context.Context) ([]models.Ticker, error) {
+	var tickers []models.Ticker
+	err := apmgorm.WithContext(ctx, i.Gorm).
+		Where("provider IN (?) AND coin IN (?) AND currency = ?", providers, coins, currency).
+		Find(&tickers).Error
+	return tickers, err
+}
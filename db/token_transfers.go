@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+const rawTokenTransfersInsert = `INSERT INTO token_transfers(coin,tx_hash,log_index,block_number,timestamp,token,name,symbol,decimals,from_address,to_address,value) VALUES %s ON CONFLICT DO NOTHING`
+
+func (i *Instance) AddTokenTransfers(transfers []models.TokenTransfer, ctx context.Context) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for lo := 0; lo < len(transfers); lo += batchLimit {
+		hi := lo + batchLimit
+		if hi > len(transfers) {
+			hi = len(transfers)
+		}
+		if err := bulkCreateTokenTransfers(g, transfers[lo:hi]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTokenTransfers returns the latest transfers of the address, optionally filtered by token contract
+func (i *Instance) GetTokenTransfers(coin uint, address, token string, limit int, ctx context.Context) ([]models.TokenTransfer, error) {
+	if address == "" {
+		return nil, errors.E("Empty address")
+	}
+	address = strings.ToLower(address)
+	g := apmgorm.WithContext(ctx, i.Gorm).
+		Model(&models.TokenTransfer{}).
+		Where("coin = ? AND (from_address = ? OR to_address = ?)", coin, address, address)
+	if token != "" {
+		g = g.Where("token = ?", strings.ToLower(token))
+	}
+	var transfers []models.TokenTransfer
+	err := g.
+		Order("block_number desc, log_index desc").
+		Limit(limit).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func bulkCreateTokenTransfers(db *gorm.DB, transfers []models.TokenTransfer) error {
+	var (
+		valueStrings []string
+		valueArgs    []interface{}
+	)
+	for _, t := range transfers {
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		valueArgs = append(valueArgs, t.Coin, t.TxHash, t.LogIndex, t.BlockNumber, t.Timestamp,
+			t.Token, t.Name, t.Symbol, t.Decimals, t.From, t.To, t.Value)
+	}
+	smt := fmt.Sprintf(rawTokenTransfersInsert, strings.Join(valueStrings, ","))
+	return db.Exec(smt, valueArgs...).Error
+}
@@ -31,11 +31,16 @@ func (hbm *heightBlockMap) GetHeight(coin string) (int64, bool) {
 	return b, ok
 }
 
+// GetLastParsedBlockNumber reads the tracker from the primary, the parser reading back the height it just wrote
+// would fetch the same blocks again from a lagging replica
 func (i *Instance) GetLastParsedBlockNumber(coin string, ctx context.Context) (int64, error) {
 	height, ok := memoryCache.GetHeight(coin)
 	if ok {
 		return height, nil
 	}
+	if i.memory != nil {
+		return i.memory.getHeight(coin), nil
+	}
 	var tracker models.Tracker
 	g := apmgorm.WithContext(ctx, i.Gorm)
 	if err := g.Where(models.Tracker{Coin: coin}).Find(&tracker).Error; err != nil {
@@ -46,6 +51,10 @@ func (i *Instance) GetLastParsedBlockNumber(coin string, ctx context.Context) (i
 
 func (i *Instance) SetLastParsedBlockNumber(coin string, num int64, ctx context.Context) error {
 	memoryCache.SetHeight(coin, num)
+	if i.memory != nil {
+		i.memory.setHeight(coin, num)
+		return nil
+	}
 	tracker := models.Tracker{
 		Coin:   coin,
 		Height: num,
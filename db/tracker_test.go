@@ -63,3 +63,25 @@ func setupDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
 	d.LogMode(true)
 	return d, mock
 }
+
+func TestHeightBlockMap_GetHeightNotFromReplica(t *testing.T) {
+	primary, primaryMock := setupDB(t)
+	defer primary.Close()
+	replica, replicaMock := setupDB(t)
+	defer replica.Close()
+	primaryMock.ExpectQuery(
+		regexp.QuoteMeta(
+			`SELECT * FROM "trackers"  WHERE ("trackers"."coin" = $1`)).WithArgs("tron").WillReturnRows(sqlmock.NewRows([]string{"coin", "height"}).
+		AddRow("tron", 10))
+	replicaMock.ExpectQuery(
+		regexp.QuoteMeta(
+			`SELECT * FROM "trackers"  WHERE ("trackers"."coin" = $1`)).WithArgs("tron").WillReturnRows(sqlmock.NewRows([]string{"coin", "height"}).
+		AddRow("tron", 7))
+	i := Instance{Gorm: primary, Replica: replica}
+
+	b, err := i.GetLastParsedBlockNumber("tron", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), b)
+	assert.Nil(t, primaryMock.ExpectationsWereMet())
+	assert.NotNil(t, replicaMock.ExpectationsWereMet())
+}
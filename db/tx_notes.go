@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/trustwallet/blockatlas/db/models"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// SetTxNote creates or replaces the note of the transaction
+func (i *Instance) SetTxNote(note *models.TxNote, ctx context.Context) error {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	return g.
+		Set("gorm:insert_option", "ON CONFLICT (key_hash, coin, hash) DO UPDATE SET note = excluded.note, tags = excluded.tags, updated_at = excluded.updated_at").
+		Create(note).Error
+}
+
+// DeleteTxNote returns gorm.ErrRecordNotFound if the transaction has no note
+func (i *Instance) DeleteTxNote(keyHash string, coin uint, hash string, ctx context.Context) error {
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	result := g.Where("key_hash = ? AND coin = ? AND hash = ?", keyHash, coin, hash).Delete(&models.TxNote{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetTxNotes returns the notes of the coin, limited to the given hashes if any
+func (i *Instance) GetTxNotes(keyHash string, coin uint, hashes []string, ctx context.Context) ([]models.TxNote, error) {
+	g := apmgorm.WithContext(ctx, i.Gorm).Where("key_hash = ? AND coin = ?", keyHash, coin)
+	if len(hashes) > 0 {
+		g = g.Where("hash IN (?)", hashes)
+	}
+	var notes []models.TxNote
+	if err := g.Order("updated_at desc").Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"go.elastic.co/apm/module/apmgorm"
+)
+
+// AddXpubSubscriptions creates the xpub subscriptions, the existing ones get the new expiry
+func (i *Instance) AddXpubSubscriptions(subscriptions []models.XpubSubscription, ctx context.Context) error {
+	if len(subscriptions) == 0 {
+		return errors.E("Empty xpub subscriptions")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.
+			Set("gorm:insert_option", "ON CONFLICT (coin, xpub, tenant) DO UPDATE SET expires_at = excluded.expires_at").
+			Create(&s).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteXpubSubscriptions removes the xpub subscriptions with the subscriptions of their derived addresses
+func (i *Instance) DeleteXpubSubscriptions(subscriptions []models.XpubSubscription, ctx context.Context) error {
+	if len(subscriptions) == 0 {
+		return errors.E("Empty xpub subscriptions")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		if err := g.Where("coin = ? AND xpub = ? AND tenant = ?", s.Coin, s.Xpub, s.Tenant).Delete(&models.XpubSubscription{}).Error; err != nil {
+			return err
+		}
+		if err := g.Where("coin = ? AND xpub = ? AND tenant = ?", s.Coin, s.Xpub, s.Tenant).Delete(&models.Subscription{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenewXpubSubscriptions sets the expiry of the xpub subscriptions and of their derived addresses
+func (i *Instance) RenewXpubSubscriptions(subscriptions []models.XpubSubscription, expiresAt *time.Time, ctx context.Context) error {
+	if len(subscriptions) == 0 {
+		return errors.E("Empty xpub subscriptions")
+	}
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	for _, s := range subscriptions {
+		err := g.Model(&models.XpubSubscription{}).
+			Where("coin = ? AND xpub = ? AND tenant = ?", s.Coin, s.Xpub, s.Tenant).
+			Update("expires_at", expiresAt).Error
+		if err != nil {
+			return err
+		}
+		err = g.Model(&models.Subscription{}).
+			Where("coin = ? AND xpub = ? AND tenant = ?", s.Coin, s.Xpub, s.Tenant).
+			Updates(map[string]interface{}{"expires_at": expiresAt, "expiry_notified": false}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetXpubSubscriptions returns every xpub subscription, to derive their new addresses
+func (i *Instance) GetXpubSubscriptions(ctx context.Context) ([]models.XpubSubscription, error) {
+	if i.memory != nil {
+		return nil, ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	var subscriptions []models.XpubSubscription
+	if err := g.Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// SetXpubSubscriptionSynced records the number of addresses derived from the xpub
+func (i *Instance) SetXpubSubscriptionSynced(subscription models.XpubSubscription, addresses int, now time.Time, ctx context.Context) error {
+	if i.memory != nil {
+		return ErrMemoryMode
+	}
+	g := apmgorm.WithContext(ctx, i.Gorm)
+	return g.Model(&models.XpubSubscription{}).
+		Where("coin = ? AND xpub = ? AND tenant = ?", subscription.Coin, subscription.Xpub, subscription.Tenant).
+		Updates(map[string]interface{}{"addresses": addresses, "synced_at": now}).Error
+}
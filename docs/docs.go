@@ -25,70 +25,61 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/ns/lookup": {
+        "/admin/breakers": {
             "get": {
-                "description": "Lookup ENS/ZNS to find registered addresses",
+                "description": "Get the state, failure counts and last error of the circuit breaker of every upstream host",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Naming"
+                    "Admin"
                 ],
-                "summary": "Lookup .eth / .zil addresses",
-                "operationId": "lookup",
+                "summary": "Get circuit breakers",
+                "operationId": "admin_breakers",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "string name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "string coin",
-                        "name": "coin",
-                        "in": "query"
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.Resolved"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                            "$ref": "#/definitions/types.DocsResponse"
                         }
                     }
                 }
             }
         },
-        "/v2/ns/lookup": {
-            "get": {
-                "description": "Lookup ENS/ZNS to find registered addresses for multiple coins",
+        "/admin/breakers/{host}/reset": {
+            "post": {
+                "description": "Close the circuit breaker of the upstream host",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Naming"
+                    "Admin"
                 ],
-                "summary": "Lookup .eth / .zil addresses",
-                "operationId": "lookup",
+                "summary": "Reset circuit breaker",
+                "operationId": "admin_breaker_reset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "string name",
-                        "name": "name",
-                        "in": "query"
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "List of coins",
-                        "name": "coins",
-                        "in": "query",
+                        "default": "api.etherscan.io",
+                        "description": "Upstream host",
+                        "name": "host",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -96,14 +87,11 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/blockatlas.Resolved"
-                            }
+                            "$ref": "#/definitions/blockatlas.CircuitBreaker"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/endpoint.ErrorResponse"
                         }
@@ -111,141 +99,187 @@ var doc = `{
                 }
             }
         },
-        "/v2/staking/delegations": {
-            "post": {
-                "description": "Get Stake Delegations for multiple coins",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/monitor": {
+            "get": {
+                "description": "Get the last synthetic check of the critical endpoints of every coin, flagged when the endpoint fails while its upstream answers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staking"
+                    "Admin"
                 ],
-                "summary": "Get Multiple Stake Delegations",
-                "operationId": "batch_delegations",
+                "summary": "Get synthetic checks",
+                "operationId": "admin_monitor",
                 "parameters": [
                     {
-                        "description": "Validators addresses and coins",
-                        "name": "delegations",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/endpoint.AddressesRequest"
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.DelegationsBatchPage"
+                            "$ref": "#/definitions/types.DocsResponse"
                         }
                     }
                 }
             }
         },
-        "/v2/staking/list": {
-            "post": {
-                "description": "Get Stake Delegations for multiple coins",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/recordings": {
+            "get": {
+                "description": "Get the last requests and responses recorded for the debug API keys, secrets redacted, the most recent first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staking"
+                    "Admin"
                 ],
-                "summary": "Get Multiple Stake Delegations",
-                "operationId": "batch_delegations",
+                "summary": "Get recorded requests",
+                "operationId": "admin_recordings",
                 "parameters": [
                     {
-                        "description": "Validators addresses and coins",
-                        "name": "delegations",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/endpoint.AddressesRequest"
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Recorded API key",
+                        "name": "api_key",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.DelegationsBatchPage"
+                            "$ref": "#/definitions/types.DocsResponse"
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Drop the recorded requests and responses, the API keys stay recorded",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear recorded requests",
+                "operationId": "admin_recordings_clear",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {}
+                }
             }
         },
-        "/v2/tokens": {
+        "/admin/recordings/keys": {
             "post": {
-                "description": "Get tokens",
+                "description": "Record the requests and responses of the API key from now on, until the instance restarts or the key is removed",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Start recording an API key",
+                "operationId": "admin_recording_key_add",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "API key to record",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.RecordingKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop recording the requests of the API key, its recorded requests are kept",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Admin"
                 ],
-                "summary": "Get list of tokens by map: coin -\u003e [addresses]",
-                "operationId": "tokens_v3",
+                "summary": "Stop recording an API key",
+                "operationId": "admin_recording_key_delete",
                 "parameters": [
                     {
-                        "default": "{\"60\": [\"0xb3624367b1ab37daef42e1a3a2ced012359659b0\"]}",
-                        "description": "Payload",
-                        "name": "data",
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "API key to stop recording",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/endpoint.RecordingKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.ResultsResponse"
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v2/{coin}/staking/delegations/{address}": {
+        "/admin/workers": {
             "get": {
-                "description": "Get stake delegations from the address",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the size of the worker pool of the notifier with its busy workers and the messages handled",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staking"
+                    "Admin"
                 ],
-                "summary": "Get Stake Delegations",
-                "operationId": "delegations",
+                "summary": "Get notifier workers",
+                "operationId": "admin_workers",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "tron",
-                        "description": "the coin name",
-                        "name": "coin",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "TPJYCz8ppZNyvw7pTwmjajcx4Kk1MmEUhD",
-                        "description": "the query address",
-                        "name": "address",
-                        "in": "path",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -253,21 +287,13 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.DelegationResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                            "$ref": "#/definitions/mq.PoolStats"
                         }
                     }
                 }
-            }
-        },
-        "/v2/{coin}/staking/validators": {
-            "get": {
-                "description": "Get validators from the address",
+            },
+            "put": {
+                "description": "Set the size of the worker pool of the notifier until the restart, the messages of the removed workers finish",
                 "consumes": [
                     "application/json"
                 ],
@@ -275,29 +301,37 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Staking"
+                    "Admin"
                 ],
-                "summary": "Get Validators",
-                "operationId": "validators",
+                "summary": "Resize notifier workers",
+                "operationId": "admin_workers_resize",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "cosmos",
-                        "description": "the coin name",
-                        "name": "coin",
-                        "in": "path",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Number of workers",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.WorkersRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.DocsResponse"
+                            "$ref": "#/definitions/mq.PoolStats"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/endpoint.ErrorResponse"
                         }
@@ -305,24 +339,42 @@ var doc = `{
                 }
             }
         },
-        "/v2/{coin}/tokens/{address}": {
+        "/autoscaling/recommendation": {
             "get": {
-                "description": "Get tokens from the address",
-                "consumes": [
+                "description": "Get the depth of the queue of the notifier, the throughput of its workers and the recommended number of workers",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Observer"
+                ],
+                "summary": "Get autoscaling recommendation",
+                "operationId": "autoscaling_recommendation",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mq.QueueStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/blockbook/{coin}/api/v2/address/{address}": {
+            "get": {
+                "description": "Get the balance and the latest transactions of the address in the Blockbook v2 format,\nfor the wallet backends written for Blockbook",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Blockbook"
                 ],
-                "summary": "Get Tokens",
-                "operationId": "tokens",
+                "summary": "Get address (Blockbook)",
+                "operationId": "blockbook_address",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "ethereum",
+                        "default": "bitcoin",
                         "description": "the coin name",
                         "name": "coin",
                         "in": "path",
@@ -330,43 +382,45 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "default": "0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB",
                         "description": "the query address",
                         "name": "address",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "basic, txids (default) or txs",
+                        "name": "details",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.CollectionPage"
+                            "$ref": "#/definitions/endpoint.BlockbookAddress"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                            "$ref": "#/definitions/endpoint.BlockbookError"
                         }
                     }
                 }
             }
         },
-        "/v2/{coin}/transactions/xpub/{xpub}": {
+        "/blockbook/{coin}/api/v2/tx/{txid}": {
             "get": {
-                "description": "Get transactions from XPUB address",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the transaction in the Blockbook v2 format",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Blockbook"
                 ],
-                "summary": "Get Transactions by XPUB",
-                "operationId": "tx_xpub_v2",
+                "summary": "Get transaction (Blockbook)",
+                "operationId": "blockbook_tx",
                 "parameters": [
                     {
                         "type": "string",
@@ -378,41 +432,43 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "default": "zpub6ruK9k6YGm8BRHWvTiQcrEPnFkuRDJhR7mPYzV2LDvjpLa5CuGgrhCYVZjMGcLcFqv9b2WvsFtY2Gb3xq8NVq8qhk9veozrA2W9QaWtihrC",
-                        "description": "the xpub key",
-                        "name": "xpub",
+                        "description": "the transaction ID",
+                        "name": "txid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "500": {
-                        "description": "Internal Server Error",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                            "$ref": "#/definitions/endpoint.BlockbookTx"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.BlockbookError"
                         }
                     }
                 }
             }
         },
-        "/v2/{coin}/transactions/{address}": {
+        "/blockbook/{coin}/api/v2/utxo/{address}": {
             "get": {
-                "description": "Get transactions from the address",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the unspent outputs of the address in the Blockbook v2 format",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Transactions"
+                    "Blockbook"
                 ],
-                "summary": "Get Transactions",
-                "operationId": "tx_v2",
+                "summary": "Get unspent outputs (Blockbook)",
+                "operationId": "blockbook_utxo",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "tezos",
+                        "default": "bitcoin",
                         "description": "the coin name",
                         "name": "coin",
                         "in": "path",
@@ -420,7 +476,6 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "default": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q",
                         "description": "the query address",
                         "name": "address",
                         "in": "path",
@@ -428,47 +483,58 @@ var doc = `{
                     }
                 ],
                 "responses": {
-                    "500": {
-                        "description": "Internal Server Error",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/endpoint.BlockbookUtxo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.BlockbookError"
                         }
                     }
                 }
             }
         },
-        "/v3/staking/list": {
+        "/ns/lookup": {
             "get": {
-                "description": "Get staking info by coin ID",
+                "description": "Lookup ENS/ZNS to find registered addresses",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Staking"
+                    "Naming"
                 ],
-                "summary": "Get staking info by coin ID",
-                "operationId": "batch_info",
+                "summary": "Lookup .eth / .zil addresses",
+                "operationId": "lookup",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "List of coins",
-                        "name": "coins",
-                        "in": "query",
-                        "required": true
+                        "description": "string name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "string coin",
+                        "name": "coin",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/blockatlas.DelegationsBatchPage"
-                            }
+                            "$ref": "#/definitions/blockatlas.Resolved"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/endpoint.ErrorResponse"
                         }
@@ -476,9 +542,39 @@ var doc = `{
                 }
             }
         },
-        "/v4/collectibles/categories": {
+        "/observers/v1/quota": {
+            "get": {
+                "description": "Get the subscriptions and the notifications of the day of the tenant of the API key, with its limits",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observer"
+                ],
+                "summary": "Quota usage",
+                "operationId": "quota_usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscriptions API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.QuotaUsage"
+                        }
+                    }
+                }
+            }
+        },
+        "/observers/v1/replay": {
             "post": {
-                "description": "Get collection categories",
+                "description": "Publish again the notifications of the addresses for the transactions of a time window, after a consumer outage",
                 "consumes": [
                     "application/json"
                 ],
@@ -486,19 +582,25 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Observer"
                 ],
-                "summary": "Get list of collections from a specific coin and addresses",
-                "operationId": "collection_categories_v4",
+                "summary": "Replay notifications",
+                "operationId": "replay_notifications",
                 "parameters": [
                     {
-                        "default": "{\"60\": [\"0xb3624367b1ab37daef42e1a3a2ced012359659b0\"]}",
-                        "description": "Payload",
-                        "name": "data",
+                        "type": "string",
+                        "description": "Replay API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscriptions and unix time window",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/types.ReplayRequest"
                         }
                     }
                 ],
@@ -506,15 +608,15 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.DocsResponse"
+                            "$ref": "#/definitions/types.ReplayResponse"
                         }
                     }
                 }
             }
         },
-        "/v4/{coin}/collections/{owner}/collection/{collection_id}": {
-            "get": {
-                "description": "Get a collection from the address",
+        "/observers/v1/subscriptions": {
+            "post": {
+                "description": "Queue a subscription event (AddSubscription, UpdateSubscription or DeleteSubscription) of the tenant of the API key",
                 "consumes": [
                     "application/json"
                 ],
@@ -522,41 +624,214 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Collections"
+                    "Observer"
                 ],
-                "summary": "Get Collection",
-                "operationId": "collection_v4",
+                "summary": "Subscribe addresses",
+                "operationId": "publish_subscriptions",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "ethereum",
-                        "description": "the coin name",
-                        "name": "coin",
-                        "in": "path",
+                        "description": "Subscriptions API key",
+                        "name": "X-API-Key",
+                        "in": "header",
                         "required": true
                     },
+                    {
+                        "description": "Subscription event",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscriptionEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscriptionsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/observers/v1/subscriptions/renew": {
+            "post": {
+                "description": "Extend the expiry of the subscriptions (and of the channel ones with a channel) by the ttl in seconds, or by the default ttl of the observer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observer"
+                ],
+                "summary": "Renew subscriptions",
+                "operationId": "renew_subscriptions",
+                "parameters": [
                     {
                         "type": "string",
-                        "default": "0x0875BCab22dE3d02402bc38aEe4104e1239374a7",
-                        "description": "the query address",
-                        "name": "owner",
-                        "in": "path",
+                        "description": "Subscriptions API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscriptions, channel and ttl",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscriptionEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscriptionsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/addressbook": {
+            "get": {
+                "description": "Get the entries changed after ` + "`" + `since` + "`" + `, deleted entries are included when ` + "`" + `since` + "`" + ` is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address Book"
+                ],
+                "summary": "Get address book entries",
+                "operationId": "addressbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer device token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp of the last sync",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AddressBookPage"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address Book"
+                ],
+                "summary": "Add an address book entry",
+                "operationId": "addressbook_add",
+                "parameters": [
                     {
                         "type": "string",
-                        "default": "0x06012c8cf97bead5deae237070f9587f8e7a266d",
-                        "description": "the query collection",
-                        "name": "collection_id",
-                        "in": "path",
+                        "description": "Bearer device token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Entry",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.AddressBookEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AddressBookEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/addressbook/tokens": {
+            "post": {
+                "description": "Creates a token for a new address book, or for a new device of the authenticated address book",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address Book"
+                ],
+                "summary": "Create an address book device token",
+                "operationId": "addressbook_token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer device token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Device name",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.AddressBookTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/blockatlas.CollectionPage"
+                            "$ref": "#/definitions/types.AddressBookToken"
                         }
                     },
                     "500": {
@@ -567,55 +842,3894 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "blockatlas.Collection": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "coin": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "external_link": {
-                    "type": "string"
-                },
-                "id": {
+        },
+        "/v1/addressbook/tokens/revoke": {
+            "post": {
+                "tags": [
+                    "Address Book"
+                ],
+                "summary": "Revoke the device token",
+                "operationId": "addressbook_token_delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer device token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/addressbook/{id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address Book"
+                ],
+                "summary": "Update an address book entry",
+                "operationId": "addressbook_update",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer device token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entry",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.AddressBookEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AddressBookEntry"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "Address Book"
+                ],
+                "summary": "Delete an address book entry",
+                "operationId": "addressbook_delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer device token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/block-number/{coin}": {
+            "get": {
+                "description": "Get the current block of the chain with the last block parsed by the observer, to monitor its lag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observer"
+                ],
+                "summary": "Get block number",
+                "operationId": "block_number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.BlockNumber"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/capabilities": {
+            "get": {
+                "description": "Get the features served for each configured coin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Info"
+                ],
+                "summary": "Get coin capabilities",
+                "operationId": "capabilities",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/blockatlas.CoinCapabilities"
+                            }
+                        },
+                        "headers": {
+                            "X-Deployment-Mode": {
+                                "type": "string",
+                                "description": "self_hosted when the upstreams are restricted to the hosts of the operator, default otherwise"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/errors": {
+            "get": {
+                "description": "Get the machine-readable codes of the error responses, with their status and retryability",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Info"
+                ],
+                "summary": "Get error codes",
+                "operationId": "error_codes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/endpoint.ErrorCatalogEntry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/export/{coin}": {
+            "get": {
+                "description": "Stream the normalized transactions of a block range, with the events known to the observer, for audits",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Export transactions",
+                "operationId": "export_blocks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coin handle, e.g. ethereum",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First block",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last block",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default) or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {}
+                }
+            }
+        },
+        "/v1/lending/account/{provider}": {
+            "post": {
+                "description": "Get the lending contracts of the addresses at the provider. With sub-wallets among the addresses,\n{\"wallet\": \"savings\", \"addresses\": [...]} or {\"wallet\": \"savings\", \"xpub\": \"xpub...\"}, the contracts are grouped by wallet ID.\nThe principal and the earned interest of the contracts come from the provider or its deposit history.\nWith a currency, the contracts get the value of their current amount at the price of their asset on its chain.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Get lending account",
+                "operationId": "lending_account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency of the contract values, e.g. usd",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Addresses and assets",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.AccountLendingContracts"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lending/account/{provider}/earnings": {
+            "post": {
+                "description": "Get the interest accrued by the addresses, reconstructed from the rate history of the provider",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Get lending earnings",
+                "operationId": "lending_account_earnings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spacing of the history points, e.g. 24h",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_page of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "History points of each asset per page, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Addresses and assets",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.AccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.PageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lending/alerts": {
+            "post": {
+                "description": "Post the APY of the asset at the provider to the callback URL when it goes above or below the thresholds,\nor moves by more than change percent. Creating an existing alert updates its thresholds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Create lending alert",
+                "operationId": "lending_alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lending alerts API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Alert and callback URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.LendingAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.LendingAlertRequest"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop posting the alert of the asset at the provider to the callback URL",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Delete lending alert",
+                "operationId": "lending_alert_delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lending alerts API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Alert and callback URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.LendingAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {}
+                }
+            }
+        },
+        "/v1/lending/best-rates": {
+            "get": {
+                "description": "Get the highest deposit APY of the assets across the providers, with the provider offering it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Get best lending rates",
+                "operationId": "lending_best_rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated asset symbols, e.g. DAI,USDC",
+                        "name": "assets",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.BestRatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lending/compare": {
+            "get": {
+                "description": "Compare the savings products of an asset across the providers, normalized from the cached provider data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Compare savings products",
+                "operationId": "lending_compare",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset symbol, e.g. USDC",
+                        "name": "asset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.LendingComparison"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lending/providers": {
+            "get": {
+                "description": "Get the lending providers and the assets they accept, with the errors of the providers failing or timing out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Get lending providers",
+                "operationId": "lending_providers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Fetch the info from the providers, bypassing the info cache",
+                        "name": "no_cache",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.LendingProvidersResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lending/providers/{provider}/refresh": {
+            "post": {
+                "description": "Fetch the info of the provider at once and update the cache served by /v1/lending/compare",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Refresh lending provider",
+                "operationId": "lending_provider_refresh",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lending.CachedProvider"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lending/queue/{provider}/{asset}": {
+            "get": {
+                "description": "Get the exit queue of the asset at the provider, for the providers whose withdrawals wait",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Get withdrawal queue",
+                "operationId": "lending_queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider ID",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset symbol",
+                        "name": "asset",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.WithdrawalQueue"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/lending/rates": {
+            "post": {
+                "description": "Get the current rates of the assets from all the providers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lending"
+                ],
+                "summary": "Get lending rates",
+                "operationId": "lending_rates",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the paused, frozen and deprecated markets",
+                        "name": "include_inactive",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Assets",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.RatesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.LendingRates"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/market/charts": {
+            "get": {
+                "description": "Get the price history of the coin from the first market provider by priority with one,\nmerged server-side into max_items periods at most",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Market"
+                ],
+                "summary": "Get market charts",
+                "operationId": "market_charts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "Coin id",
+                        "name": "coin",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency of the prices, USD by default",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix time of the first price, 24 hours ago by default",
+                        "name": "time_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of points, 64 by default and 1000 at most",
+                        "name": "max_items",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/market.Chart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/market/rates": {
+            "get": {
+                "description": "Get the units of each fiat currency worth one unit of the currency, the rates the prices in the\nother currencies are converted with",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Market"
+                ],
+                "summary": "Get fiat rates",
+                "operationId": "market_rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base currency of the rates, USD by default",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/market.Rates"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/market/ticker": {
+            "get": {
+                "description": "Get the price and 24 hours change of the coins, from the first market provider by priority with a recent ticker",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Market"
+                ],
+                "summary": "Get market tickers",
+                "operationId": "market_ticker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated coin symbols, e.g. BTC,ETH",
+                        "name": "coins",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency of the prices, USD by default",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.DocsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notes/{coin}": {
+            "get": {
+                "description": "Get the notes of the coin transactions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get transaction notes",
+                "operationId": "tx_notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notes API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "the coin id",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/types.TxNote"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/notes/{coin}/{hash}": {
+            "put": {
+                "description": "Creates or replaces the note and tags of the transaction",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Set a transaction note",
+                "operationId": "tx_note_set",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notes API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "the coin id",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.TxNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.TxNote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Delete a transaction note",
+                "operationId": "tx_note_delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notes API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "the coin id",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/observer/subscriptions": {
+            "post": {
+                "description": "Post the normalized transactions of the addresses to the webhook of the tenant of the API key,\nwith retries and a dead-letter queue for the deliveries failing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observer"
+                ],
+                "summary": "Subscribe addresses to a webhook",
+                "operationId": "subscribe_webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscriptions API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Addresses by coin and webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscriptionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop posting the transactions of the addresses to the webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Observer"
+                ],
+                "summary": "Unsubscribe addresses from a webhook",
+                "operationId": "unsubscribe_webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscriptions API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Addresses by coin and webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/types.SubscriptionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/signing-key": {
+            "get": {
+                "description": "Get the base64 Ed25519 public key verifying the X-Signature header of the responses, named by X-Signature-Key",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Info"
+                ],
+                "summary": "Get the response signing key",
+                "operationId": "signing_key",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.SigningKey"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staking/delegations": {
+            "post": {
+                "description": "Get the stake delegations of every address in one call, the addresses are queried concurrently.\nThe items answer in the order of the request, with an error instead of the delegations for the\nunknown coins, the failures and the coins not answering within the batch timeout.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get Stake Delegations of Multiple Coins",
+                "operationId": "batch_delegations_v1",
+                "parameters": [
+                    {
+                        "description": "Addresses and coins",
+                        "name": "delegations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.AddressesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.DocsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "docs": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/types.DelegationsBatchItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staking/{coin}/delegations/{address}": {
+            "get": {
+                "description": "Get the staking position of the address: its delegations, the totals staked and unbonding,\nthe rewards not withdrawn yet when the coin reports them and the unbonding period in seconds",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get Stake Delegations Summary",
+                "operationId": "delegations_summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "cosmos",
+                        "description": "the coin handle or ID",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "cosmos135qla4294zxarqhhgxsx0sw56yssa3z0f78pm0",
+                        "description": "the query address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.DelegationsSummary"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staking/{coin}/validators": {
+            "get": {
+                "description": "Get the validators of the coin with their metadata, annual reward, commission and status (active, inactive or jailed)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get Validator Details",
+                "operationId": "validator_details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "cosmos",
+                        "description": "the coin handle or ID",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.DocsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "docs": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/types.ValidatorDetails"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/{coin}/address/{address}/tokens": {
+            "get": {
+                "description": "Get the tokens held by the address with their symbol, name, decimals, contract and balance, the same page as /v2/{coin}/tokens/{address}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Address Tokens",
+                "operationId": "address_tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB",
+                        "description": "the query address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_page of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tokens per page, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.PageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/ns/lookup": {
+            "get": {
+                "description": "Lookup ENS/ZNS to find registered addresses for multiple coins",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Naming"
+                ],
+                "summary": "Lookup .eth / .zil addresses for multiple coins",
+                "operationId": "lookup_batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "string name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List of coins",
+                        "name": "coins",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/blockatlas.Resolved"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/staking/delegations": {
+            "post": {
+                "description": "Get Stake Delegations for multiple coins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get Multiple Stake Delegations",
+                "operationId": "batch_delegations",
+                "parameters": [
+                    {
+                        "description": "Validators addresses and coins",
+                        "name": "delegations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.AddressesRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Write the delegations as newline-delimited JSON",
+                        "name": "stream",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.DelegationsBatchPage"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/staking/list": {
+            "post": {
+                "description": "Get the staking info of multiple coins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get Multiple Stake Info",
+                "operationId": "batch_staking_list",
+                "parameters": [
+                    {
+                        "description": "Coins",
+                        "name": "coins",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.CoinsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.DelegationsBatchPage"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/tokens": {
+            "post": {
+                "description": "Get tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get list of tokens by map: coin -\u003e [addresses]",
+                "operationId": "tokens_v3",
+                "parameters": [
+                    {
+                        "default": "{\"60\": [\"0xb3624367b1ab37daef42e1a3a2ced012359659b0\"]}",
+                        "description": "Payload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Write the tokens as newline-delimited JSON",
+                        "name": "stream",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.ResultsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/fees": {
+            "get": {
+                "description": "Get the fee rates of the coin by priority with the expected confirmation time, read from the\nmempool fee histogram when the coin has a fee API and from the estimates of the node otherwise",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Fees"
+                ],
+                "summary": "Get Fee Estimates",
+                "operationId": "fees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "bitcoin",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.FeeEstimates"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/simulate": {
+            "post": {
+                "description": "Dry-run an unsigned transaction on the node of the coin to preview it before signing: the balance\nchanges with the fee, the token transfers it emits, or the reason it fails with success false",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Simulate a transaction",
+                "operationId": "simulate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The unsigned transaction",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.SimulationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.Simulation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/staking/delegations/{address}": {
+            "get": {
+                "description": "Get stake delegations from the address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get Stake Delegations",
+                "operationId": "delegations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "tron",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "TPJYCz8ppZNyvw7pTwmjajcx4Kk1MmEUhD",
+                        "description": "the query address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.DelegationResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/staking/validators": {
+            "get": {
+                "description": "Get validators from the address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get Validators",
+                "operationId": "validators",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "cosmos",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.DocsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/summary/{address}": {
+            "get": {
+                "description": "Get the class (exchange, contract, miner, wallet) and the latest activity of the address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Account Summary",
+                "operationId": "account_summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB",
+                        "description": "the query address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.AccountSummary"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/tokens/{address}": {
+            "get": {
+                "description": "Get the tokens held by the address, with their balance in the smallest unit when the provider reports it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Tokens",
+                "operationId": "tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB",
+                        "description": "the query address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_page of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tokens per page, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.PageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/transactions/xpub/{xpub}": {
+            "get": {
+                "description": "Get transactions from XPUB address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transactions by XPUB",
+                "operationId": "tx_xpub_v2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "bitcoin",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "zpub6ruK9k6YGm8BRHWvTiQcrEPnFkuRDJhR7mPYzV2LDvjpLa5CuGgrhCYVZjMGcLcFqv9b2WvsFtY2Gb3xq8NVq8qhk9veozrA2W9QaWtihrC",
+                        "description": "the xpub key",
+                        "name": "xpub",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Merge the notes of the X-API-Key owner",
+                        "name": "include_notes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Write the transactions as newline-delimited JSON",
+                        "name": "stream",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_page of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transactions per page, 25 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{coin}/transactions/{address}": {
+            "get": {
+                "description": "Get transactions from the address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get Transactions",
+                "operationId": "tx_v2",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "tezos",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "tz1WCd2jm4uSt4vntk4vSuUWoZQGhLcDuR9q",
+                        "description": "the query address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the transfers of the token contract, e.g. an ERC-20, BEP-20 or TRC-20",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Merge the notes of the X-API-Key owner",
+                        "name": "include_notes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Write the transactions as newline-delimited JSON",
+                        "name": "stream",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_page of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transactions per page, 25 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hold the v2 request until a new transaction of the address or the timeout, e.g. 30s",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v3/staking/list": {
+            "get": {
+                "description": "Get staking info by coin ID",
+                "produces": [
+                    "application/json",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Staking"
+                ],
+                "summary": "Get staking info by coin ID",
+                "operationId": "batch_info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List of coins",
+                        "name": "coins",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/blockatlas.DelegationsBatchPage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v3/{coin}/sync/{address}": {
+            "get": {
+                "description": "Get the transactions, token balances and staking delegations of the address changed since the block,\nthe compact alternative to pulling the full history on every sync",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Get changes of an address",
+                "operationId": "sync_v3",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB",
+                        "description": "the query address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Block of the previous sync, 0 for everything",
+                        "name": "since_block",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v4/collectibles/categories": {
+            "post": {
+                "description": "Get collection categories",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get list of collections from a specific coin and addresses",
+                "operationId": "collection_categories_v4",
+                "parameters": [
+                    {
+                        "default": "{\"60\": [\"0xb3624367b1ab37daef42e1a3a2ced012359659b0\"]}",
+                        "description": "Payload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Write the collections as newline-delimited JSON",
+                        "name": "stream",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.DocsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v4/{coin}/collections/{owner}": {
+            "get": {
+                "description": "Get the NFT collections of the address, with the number of collectibles held in each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get Collections",
+                "operationId": "collections_v4",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0x0875BCab22dE3d02402bc38aEe4104e1239374a7",
+                        "description": "the query address",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_page of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Collections per page, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.PageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v4/{coin}/collections/{owner}/collection/{collection_id}": {
+            "get": {
+                "description": "Get a collection from the address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get Collection",
+                "operationId": "collection_v4",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ethereum",
+                        "description": "the coin name",
+                        "name": "coin",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0x0875BCab22dE3d02402bc38aEe4104e1239374a7",
+                        "description": "the query address",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0x06012c8cf97bead5deae237070f9587f8e7a266d",
+                        "description": "the query collection",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The next_page of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Collectibles per page, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/blockatlas.PageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/endpoint.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "blockatlas.CircuitBreaker": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "description": "Failures is the count of consecutive failures, reset by a success",
+                    "type": "integer"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_failure_at": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "total_failures": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blockatlas.CoinCapabilities": {
+            "type": "object",
+            "properties": {
+                "balances": {
+                    "type": "boolean"
+                },
+                "blocks": {
+                    "type": "boolean"
+                },
+                "broadcast": {
+                    "type": "boolean"
+                },
+                "coin": {
+                    "type": "integer"
+                },
+                "collectibles": {
+                    "type": "boolean"
+                },
+                "fees": {
+                    "type": "boolean"
+                },
+                "handle": {
+                    "type": "string"
+                },
+                "mempool": {
+                    "type": "boolean"
+                },
+                "self_hosted": {
+                    "description": "SelfHosted is set when every upstream of the coin is served by the operator",
+                    "type": "boolean"
+                },
+                "simulation": {
+                    "type": "boolean"
+                },
+                "staking": {
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "token_transactions": {
+                    "type": "boolean"
+                },
+                "tokens": {
+                    "type": "boolean"
+                },
+                "transactions": {
+                    "type": "boolean"
+                },
+                "xpub": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "blockatlas.DelegationResponse": {
+            "$ref": "#/definitions/types.DelegationResponse"
+        },
+        "blockatlas.DelegationsBatchPage": {
+            "$ref": "#/definitions/types.DelegationsBatchPage"
+        },
+        "blockatlas.DocsResponse": {
+            "$ref": "#/definitions/types.DocsResponse"
+        },
+        "blockatlas.PageResponse": {
+            "type": "object",
+            "properties": {
+                "docs": {
+                    "type": "object"
+                },
+                "next_page": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "blockatlas.Resolved": {
+            "$ref": "#/definitions/types.Resolved"
+        },
+        "blockatlas.ResultsResponse": {
+            "$ref": "#/definitions/types.ResultsResponse"
+        },
+        "endpoint.AddressBatchRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "coin": {
+                    "type": "integer"
+                }
+            }
+        },
+        "endpoint.AddressBookEntryRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "label"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "coin": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "memo": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.AddressBookTokenRequest": {
+            "type": "object",
+            "properties": {
+                "device": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.AddressesRequest": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/endpoint.AddressBatchRequest"
+            }
+        },
+        "endpoint.BlockNumber": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "type": "integer"
+                },
+                "current": {
+                    "type": "integer"
+                },
+                "lag": {
+                    "type": "integer"
+                },
+                "last_parsed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "endpoint.BlockbookAddress": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string"
+                },
+                "itemsOnPage": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/endpoint.BlockbookTx"
+                    }
+                },
+                "txids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "txs": {
+                    "type": "integer"
+                },
+                "unconfirmedBalance": {
+                    "type": "string"
+                },
+                "unconfirmedTxs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "endpoint.BlockbookError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.BlockbookTokenTransfer": {
+            "type": "object",
+            "properties": {
+                "decimals": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.BlockbookTx": {
+            "type": "object",
+            "properties": {
+                "blockHeight": {
+                    "type": "integer"
+                },
+                "blockTime": {
+                    "type": "integer"
+                },
+                "confirmations": {
+                    "type": "integer"
+                },
+                "fees": {
+                    "type": "string"
+                },
+                "tokenTransfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/endpoint.BlockbookTokenTransfer"
+                    }
+                },
+                "txid": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "vin": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/endpoint.BlockbookVout"
+                    }
+                },
+                "vout": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/endpoint.BlockbookVout"
+                    }
+                }
+            }
+        },
+        "endpoint.BlockbookUtxo": {
+            "type": "object",
+            "properties": {
+                "confirmations": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "txid": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "vout": {
+                    "type": "integer"
+                }
+            }
+        },
+        "endpoint.BlockbookVout": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isAddress": {
+                    "type": "boolean"
+                },
+                "n": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.CoinBatchRequest": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "type": "integer"
+                }
+            }
+        },
+        "endpoint.CoinsRequest": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/endpoint.CoinBatchRequest"
+            }
+        },
+        "endpoint.ErrorCatalogEntry": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "retryable": {
+                    "description": "Retryable tells whether the same request may succeed later",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "endpoint.ErrorDetails": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the machine-readable reason of the rejected requests, see middleware.RenderErrors",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/endpoint.ErrorDetails"
+                }
+            }
+        },
+        "endpoint.RecordingKeyRequest": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.SigningKey": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "endpoint.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "block": {
+                    "type": "integer"
+                },
+                "delegations": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.DelegationsPage"
+                },
+                "since_block": {
+                    "type": "integer"
+                },
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Token"
+                    }
+                },
+                "truncated": {
+                    "type": "boolean"
+                },
+                "txs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.Tx"
+                    }
+                }
+            }
+        },
+        "endpoint.TxNoteRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "endpoint.WorkersRequest": {
+            "type": "object",
+            "properties": {
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "lending.CachedProvider": {
+            "type": "object",
+            "properties": {
+                "info": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.LendingProvider"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt is the time of the last successful fetch, the info is kept when a fetch fails",
+                    "type": "string"
+                }
+            }
+        },
+        "market.Chart": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/market.ChartPoint"
+                    }
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "market.ChartPoint": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "integer"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "market.Rates": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mq.PoolStats": {
+            "type": "object",
+            "properties": {
+                "busy": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "mq.QueueStats": {
+            "type": "object",
+            "properties": {
+                "busy": {
+                    "type": "integer"
+                },
+                "consumers": {
+                    "type": "integer"
+                },
+                "depth": {
+                    "description": "Depth is the count of the messages waiting in the queue, not delivered yet",
+                    "type": "integer"
+                },
+                "queue": {
+                    "type": "string"
+                },
+                "recommended": {
+                    "type": "integer"
+                },
+                "sampled_at": {
+                    "type": "string"
+                },
+                "throughput": {
+                    "description": "Throughput is the messages per second handled by the pool since the previous sample",
+                    "type": "number"
+                },
+                "workers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.AccountLendingContracts": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "borrows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BorrowPosition"
+                    }
+                },
+                "contracts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LendingContract"
+                    }
+                },
+                "risk": {
+                    "description": "Risk of the collateralized positions, for the providers with borrow markets",
+                    "type": "object",
+                    "$ref": "#/definitions/types.LiquidationRisk"
+                }
+            }
+        },
+        "types.AccountRequest": {
+            "type": "object",
+            "required": [
+                "assets"
+            ],
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "assets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.AccountSummary": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "class": {
+                    "type": "string"
+                },
+                "coin": {
+                    "type": "integer"
+                },
+                "counterparties": {
+                    "type": "integer"
+                },
+                "label": {
+                    "description": "Name of the labeled addresses",
+                    "type": "string"
+                },
+                "last_activity": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.AddressBookEntry": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "coin": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.AddressBookPage": {
+            "type": "object",
+            "properties": {
+                "docs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AddressBookEntry"
+                    }
+                },
+                "timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.AddressBookToken": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.AssetInfo": {
+            "type": "object",
+            "properties": {
+                "apy": {
+                    "type": "number"
+                },
+                "borrow": {
+                    "description": "Borrow market of the asset, for the providers lending it",
+                    "type": "object",
+                    "$ref": "#/definitions/types.BorrowInfo"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "lockup": {
+                    "description": "Lockup is the time (seconds) the deposits can't be withdrawn, TVL the deposited value in USD",
+                    "type": "integer"
+                },
+                "minimum_amount": {
+                    "type": "string"
+                },
+                "rewards": {
+                    "description": "Rewards splits APY into the interest and the liquidity mining rewards, when the provider pays any",
+                    "type": "object",
+                    "$ref": "#/definitions/types.RewardsBreakdown"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "tvl": {
+                    "type": "number"
+                },
+                "yield_period": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.BalanceChange": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BestRate": {
+            "type": "object",
+            "properties": {
+                "apy": {
+                    "type": "number"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BestRatesResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ProviderError"
+                    }
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BestRate"
+                    }
+                }
+            }
+        },
+        "types.BorrowInfo": {
+            "type": "object",
+            "properties": {
+                "apy": {
+                    "type": "number"
+                },
+                "available_liquidity": {
+                    "type": "string"
+                },
+                "utilization": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.BorrowPosition": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "type": "string"
+                },
+                "current_amount": {
+                    "type": "string"
+                },
+                "current_apy": {
+                    "type": "number"
+                },
+                "start_amount": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BridgeLeg": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "type": "integer"
+                },
+                "tx_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.BridgeTransfer": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.BridgeLeg"
+                },
+                "message_id": {
+                    "description": "MessageID identifies the message on both networks, e.g. chain/emitter/sequence for Wormhole",
+                    "type": "string"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.BridgeLeg"
+                }
+            }
+        },
+        "types.Channel": {
+            "type": "object",
+            "properties": {
+                "digest": {
+                    "description": "Digest replaces the notification of each transaction with a periodic summary (optional)",
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Delegation": {
+            "type": "object",
+            "properties": {
+                "delegator": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.StakeValidator"
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.DelegationResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string"
+                },
+                "coin": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.ExternalCoin"
+                },
+                "delegations": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.DelegationsPage"
+                },
+                "details": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.StakingDetails"
+                }
+            }
+        },
+        "types.DelegationsBatchItem": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "coin": {
+                    "type": "integer"
+                },
+                "delegations": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.DelegationResponse"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "timeout": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.DelegationsBatchPage": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/types.DelegationResponse"
+            }
+        },
+        "types.DelegationsPage": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/types.Delegation"
+            }
+        },
+        "types.DelegationsSummary": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string"
+                },
+                "coin": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.ExternalCoin"
+                },
+                "delegations": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.DelegationsPage"
+                },
+                "details": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.StakingDetails"
+                },
+                "rewards": {
+                    "type": "string"
+                },
+                "staked": {
+                    "type": "string"
+                },
+                "unbonding": {
+                    "type": "string"
+                },
+                "unbonding_period": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.DocsResponse": {
+            "type": "object",
+            "properties": {
+                "docs": {
+                    "type": "object"
+                }
+            }
+        },
+        "types.ExternalCoin": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "type": "integer"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.FeeEstimate": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "type": "integer"
+                },
+                "confirmation_time": {
+                    "type": "integer"
+                },
+                "percentile": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.FeeEstimates": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "type": "integer"
+                },
+                "estimates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.FeeEstimate"
+                    }
+                },
+                "histogram": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.FeeHistogram"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.FeeHistogram": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/types.FeeHistogramBucket"
+            }
+        },
+        "types.FeeHistogramBucket": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number"
+                },
+                "vsize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.FiatValue": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.LendingAlertRequest": {
+            "type": "object",
+            "properties": {
+                "above": {
+                    "type": "number"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "below": {
+                    "type": "number"
+                },
+                "callback_url": {
+                    "type": "string"
+                },
+                "change": {
+                    "type": "number"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LendingAssetRates": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "type": "string"
+                },
+                "max_apy": {
+                    "type": "number"
+                },
+                "min_borrow_apy": {
+                    "description": "MinBorrowAPY is the lowest borrow APY of the asset, for the providers lending it",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LendingComparison": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "type": "string"
+                },
+                "disclaimers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SavingsProduct"
+                    }
+                }
+            }
+        },
+        "types.LendingContract": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "type": "string"
+                },
+                "current_amount": {
+                    "type": "string"
+                },
+                "current_apy": {
+                    "type": "number"
+                },
+                "earned": {
+                    "type": "string"
+                },
+                "principal": {
+                    "description": "Principal is the deposited minus the withdrawn amount and Earned the interest accrued on top of it,\nfor the providers reporting the accrued interest or the deposit history",
+                    "type": "string"
+                },
+                "shares": {
+                    "type": "string"
+                },
+                "start_amount": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value of CurrentAmount in the requested currency, when the asset could be priced",
+                    "type": "object",
+                    "$ref": "#/definitions/types.FiatValue"
+                },
+                "vault": {
+                    "description": "Vault holding the deposit and the Shares of the address in it, for the yield providers",
+                    "type": "string"
+                },
+                "withdrawal": {
+                    "description": "Withdrawal availability, for the providers with an exit queue (e.g. liquid staking)",
+                    "type": "object",
+                    "$ref": "#/definitions/types.Withdrawal"
+                }
+            }
+        },
+        "types.LendingProvider": {
+            "type": "object",
+            "properties": {
+                "assets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.AssetInfo"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.ProviderInfo"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LendingProviders": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/types.LendingProvider"
+            }
+        },
+        "types.LendingProvidersResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ProviderError"
+                    }
+                },
+                "providers": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.LendingProviders"
+                }
+            }
+        },
+        "types.LendingRateAlert": {
+            "type": "object",
+            "properties": {
+                "above": {
+                    "type": "number"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "below": {
+                    "type": "number"
+                },
+                "change": {
+                    "type": "number"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LendingRates": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/types.LendingAssetRates"
+            }
+        },
+        "types.LiquidationAlert": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "health_factor": {
+                    "type": "number"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.LiquidationPrice": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "types.LiquidationRisk": {
+            "type": "object",
+            "properties": {
+                "collateral_ratio": {
+                    "type": "number"
+                },
+                "health_factor": {
+                    "type": "number"
+                },
+                "liquidation_prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LiquidationPrice"
+                    }
+                }
+            }
+        },
+        "types.ProviderError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "timeout": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "types.ProviderInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "risk_tier": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.QuotaUsage": {
+            "type": "object",
+            "properties": {
+                "max_notifications_per_minute": {
+                    "type": "integer"
+                },
+                "max_subscriptions": {
+                    "type": "integer"
+                },
+                "notifications_today": {
+                    "description": "Notifications sent and dropped over the rate limit during the current UTC day",
+                    "type": "integer"
+                },
+                "subscriptions": {
+                    "type": "integer"
+                },
+                "tenant": {
+                    "type": "string"
+                },
+                "throttled_today": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.RatesRequest": {
+            "type": "object",
+            "required": [
+                "assets"
+            ],
+            "properties": {
+                "assets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.ReplayRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "subscriptions": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.Subscriptions"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.ReplayResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.Resolved": {
+            "type": "object",
+            "properties": {
+                "coin": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.ResultsResponse": {
+            "type": "object",
+            "properties": {
+                "docs": {
+                    "type": "object"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.RewardAPR": {
+            "type": "object",
+            "properties": {
+                "apr": {
+                    "type": "number"
+                },
+                "claimable": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.RewardsBreakdown": {
+            "type": "object",
+            "properties": {
+                "base_apy": {
+                    "type": "number"
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.RewardAPR"
+                    }
+                }
+            }
+        },
+        "types.SavingsProduct": {
+            "type": "object",
+            "properties": {
+                "apy": {
+                    "type": "number"
+                },
+                "base_apy": {
+                    "type": "number"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "lockup": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reward_apr": {
+                    "type": "number"
+                },
+                "risk_tier": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 },
-                "image_url": {
-                    "type": "string"
+                "tvl": {
+                    "type": "number"
                 },
-                "name": {
+                "type": {
                     "type": "string"
                 },
-                "total": {
+                "updated_at": {
                     "type": "integer"
                 }
             }
         },
-        "blockatlas.CollectionPage": {
-            "type": "array",
-            "items": {
-                "$ref": "#/definitions/blockatlas.Collection"
-            }
-        },
-        "blockatlas.Delegation": {
+        "types.SimulatedTransfer": {
             "type": "object",
             "properties": {
-                "delegator": {
-                    "type": "object",
-                    "$ref": "#/definitions/blockatlas.StakeValidator"
+                "from": {
+                    "type": "string"
                 },
-                "metadata": {
-                    "type": "object"
+                "to": {
+                    "type": "string"
                 },
-                "status": {
+                "token": {
                     "type": "string"
                 },
                 "value": {
@@ -623,91 +4737,84 @@ var doc = `{
                 }
             }
         },
-        "blockatlas.DelegationResponse": {
+        "types.Simulation": {
             "type": "object",
             "properties": {
-                "address": {
+                "balance_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BalanceChange"
+                    }
+                },
+                "coin": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "balance": {
+                "fee": {
                     "type": "string"
                 },
-                "coin": {
-                    "type": "object",
-                    "$ref": "#/definitions/coin.ExternalCoin"
+                "gas": {
+                    "type": "string"
                 },
-                "delegations": {
-                    "type": "object",
-                    "$ref": "#/definitions/blockatlas.DelegationsPage"
+                "success": {
+                    "type": "boolean"
                 },
-                "details": {
-                    "type": "object",
-                    "$ref": "#/definitions/blockatlas.StakingDetails"
-                }
-            }
-        },
-        "blockatlas.DelegationsBatchPage": {
-            "type": "array",
-            "items": {
-                "$ref": "#/definitions/blockatlas.DelegationResponse"
-            }
-        },
-        "blockatlas.DelegationsPage": {
-            "type": "array",
-            "items": {
-                "$ref": "#/definitions/blockatlas.Delegation"
-            }
-        },
-        "blockatlas.DocsResponse": {
-            "type": "object",
-            "properties": {
-                "docs": {
-                    "type": "object"
+                "token_transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.SimulatedTransfer"
+                    }
                 }
             }
         },
-        "blockatlas.Resolved": {
+        "types.SimulationRequest": {
             "type": "object",
+            "required": [
+                "from"
+            ],
             "properties": {
-                "coin": {
-                    "type": "integer"
+                "data": {
+                    "type": "string"
                 },
-                "result": {
+                "from": {
                     "type": "string"
-                }
-            }
-        },
-        "blockatlas.ResultsResponse": {
-            "type": "object",
-            "properties": {
-                "docs": {
-                    "type": "object"
                 },
-                "total": {
-                    "type": "integer"
+                "gas": {
+                    "type": "string"
+                },
+                "gas_price": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
                 }
             }
         },
-        "blockatlas.StakeValidator": {
+        "types.StakeValidator": {
             "type": "object",
             "properties": {
                 "details": {
                     "type": "object",
-                    "$ref": "#/definitions/blockatlas.StakingDetails"
+                    "$ref": "#/definitions/types.StakingDetails"
                 },
                 "id": {
                     "type": "string"
                 },
                 "info": {
                     "type": "object",
-                    "$ref": "#/definitions/blockatlas.StakeValidatorInfo"
+                    "$ref": "#/definitions/types.StakeValidatorInfo"
                 },
                 "status": {
                     "type": "boolean"
                 }
             }
         },
-        "blockatlas.StakeValidatorInfo": {
+        "types.StakeValidatorInfo": {
             "type": "object",
             "properties": {
                 "description": {
@@ -724,7 +4831,7 @@ var doc = `{
                 }
             }
         },
-        "blockatlas.StakingDetails": {
+        "types.StakingDetails": {
             "type": "object",
             "properties": {
                 "locktime": {
@@ -735,14 +4842,14 @@ var doc = `{
                 },
                 "reward": {
                     "type": "object",
-                    "$ref": "#/definitions/blockatlas.StakingReward"
+                    "$ref": "#/definitions/types.StakingReward"
                 },
                 "type": {
                     "type": "string"
                 }
             }
         },
-        "blockatlas.StakingReward": {
+        "types.StakingReward": {
             "type": "object",
             "properties": {
                 "annual": {
@@ -750,9 +4857,78 @@ var doc = `{
                 }
             }
         },
-        "coin.ExternalCoin": {
+        "types.SubscriptionEvent": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "description": "Channel delivers the notifications of these subscriptions directly instead of the notifications queue",
+                    "type": "object",
+                    "$ref": "#/definitions/types.Channel"
+                },
+                "lending_alerts": {
+                    "description": "LendingAlerts are sent to the Channel when the rates of the lending providers change",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LendingRateAlert"
+                    }
+                },
+                "liquidation_alerts": {
+                    "description": "LiquidationAlerts are sent to the Channel when the borrow positions of the addresses are at risk",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.LiquidationAlert"
+                    }
+                },
+                "locale": {
+                    "description": "Locale of the messages rendered for the Channel, e.g. \"en\" or \"pt-BR\"",
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "subscriptions": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.Subscriptions"
+                },
+                "tenant": {
+                    "description": "Tenant owning the subscriptions, set by the API from the API key of the request",
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "TTL is the number of seconds the subscriptions are kept without a renewal,\n0 for the default of the observer",
+                    "type": "integer"
+                },
+                "xpubs": {
+                    "description": "Xpubs are the extended public keys or output descriptors (coin: keys) whose derived\naddresses are subscribed, including the ones derived later",
+                    "type": "object",
+                    "$ref": "#/definitions/types.Subscriptions"
+                }
+            }
+        },
+        "types.Subscriptions": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "array",
+                "items": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.SubscriptionsResponse": {
+            "type": "object",
+            "properties": {
+                "subscriptions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.Token": {
             "type": "object",
             "properties": {
+                "balance": {
+                    "description": "Balance is the amount held by the address in the smallest unit of the token,\nempty when the provider of the coin doesn't report it",
+                    "type": "string"
+                },
                 "coin": {
                     "type": "integer"
                 },
@@ -764,40 +4940,236 @@ var doc = `{
                 },
                 "symbol": {
                     "type": "string"
+                },
+                "token_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "endpoint.AddressBatchRequest": {
+        "types.Tx": {
             "type": "object",
             "properties": {
-                "address": {
+                "block": {
+                    "description": "Height of the block the transaction was included in",
+                    "type": "integer"
+                },
+                "coin": {
+                    "description": "SLIP-44 coin index of the platform",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "Unix timestamp of the block the transaction was included in",
+                    "type": "integer"
+                },
+                "direction": {
+                    "description": "Transaction Direction",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Empty if the transaction \"completed\" or \"pending\", else error explaining why the transaction failed (optional)",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "Action recognized by the parser, e.g. a lending deposit (optional)",
+                    "type": "object",
+                    "$ref": "#/definitions/types.TxEvent"
+                },
+                "fee": {
+                    "description": "Transaction fee (native currency)",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "Address of the transaction sender",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique identifier",
+                    "type": "string"
+                },
+                "inputs": {
+                    "description": "Input addresses",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.TxOutput"
+                    }
+                },
+                "memo": {
+                    "description": "Meta data object",
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "note": {
+                    "description": "Private note of the requesting user, only set with ` + "`" + `include_notes=true` + "`" + `",
+                    "type": "object",
+                    "$ref": "#/definitions/types.TxNote"
+                },
+                "outputs": {
+                    "description": "Output addresses",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.TxOutput"
+                    }
+                },
+                "sequence": {
+                    "description": "Transaction nonce or sequence",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status of the transaction e.g: \"completed\", \"pending\", \"error\"",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "Address of the transaction recipient",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type of metadata",
+                    "type": "string"
+                }
+            }
+        },
+        "types.TxEvent": {
+            "type": "object",
+            "properties": {
+                "decimals": {
+                    "type": "integer"
+                },
+                "network": {
+                    "description": "Other network of the bridge transfers, e.g. \"polygon\"",
+                    "type": "string"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "transfer": {
+                    "description": "Transfer links the transactions of both networks of the bridge transfers with a known message",
+                    "type": "object",
+                    "$ref": "#/definitions/types.BridgeTransfer"
+                },
+                "type": {
                     "type": "string"
                 },
+                "validator": {
+                    "description": "Validator of the staking events",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.TxNote": {
+            "type": "object",
+            "properties": {
                 "coin": {
                     "type": "integer"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "endpoint.AddressesRequest": {
-            "type": "array",
-            "items": {
-                "$ref": "#/definitions/endpoint.AddressBatchRequest"
+        "types.TxOutput": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
             }
         },
-        "endpoint.ErrorDetails": {
+        "types.ValidatorDetails": {
             "type": "object",
             "properties": {
-                "message": {
+                "commission": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reward": {
+                    "type": "object",
+                    "$ref": "#/definitions/types.StakingReward"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "website": {
                     "type": "string"
                 }
             }
         },
-        "endpoint.ErrorResponse": {
+        "types.WebhookSubscriptionRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "subscriptions": {
                     "type": "object",
-                    "$ref": "#/definitions/endpoint.ErrorDetails"
+                    "$ref": "#/definitions/types.Subscriptions"
+                },
+                "ttl": {
+                    "type": "integer"
+                },
+                "webhook": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.Withdrawal": {
+            "type": "object",
+            "properties": {
+                "estimated_wait": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "types.WithdrawalQueue": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "estimated_wait": {
+                    "type": "integer"
+                },
+                "length": {
+                    "type": "integer"
                 }
             }
         }
@@ -815,12 +5187,12 @@ type swaggerInfo struct {
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = swaggerInfo{
-	Version:     "",
+	Version:     "1.0",
 	Host:        "",
-	BasePath:    "",
+	BasePath:    "/",
 	Schemes:     []string{},
-	Title:       "",
-	Description: "",
+	Title:       "Block Atlas API",
+	Description: "Transactions, tokens, staking, collections and lending markets of the supported coins",
 }
 
 type s struct{}
@@ -0,0 +1,63 @@
+package docs
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// annotatedOperations returns the operation IDs of the handler annotations, by "<method> <path>"
+func annotatedOperations(t *testing.T) map[string]string {
+	files, err := filepath.Glob("../api/endpoint/*.go")
+	assert.Nil(t, err)
+	operations := make(map[string]string)
+	ids := make(map[string]string)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		raw, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		var id string
+		for _, line := range strings.Split(string(raw), "\n") {
+			fields := strings.Fields(strings.TrimPrefix(line, "//"))
+			switch {
+			case !strings.HasPrefix(line, "//"):
+				id = ""
+			case len(fields) == 2 && fields[0] == "@ID":
+				id = fields[1]
+			case len(fields) == 3 && fields[0] == "@Router":
+				route := strings.Trim(fields[2], "[]") + " " + fields[1]
+				if other, ok := ids[id]; ok {
+					t.Errorf("duplicate @ID %s of %s and %s", id, other, route)
+				}
+				ids[id] = route
+				operations[route] = id
+			}
+		}
+	}
+	return operations
+}
+
+func TestSwagger_InSync(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}
+	raw, err := ioutil.ReadFile("swagger.json")
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(raw, &spec))
+
+	generated := make(map[string]string)
+	for path, methods := range spec.Paths {
+		for method, op := range methods {
+			generated[method+" "+path] = op.OperationID
+		}
+	}
+	assert.Equal(t, annotatedOperations(t), generated, "the swagger docs are outdated, run make swag")
+}
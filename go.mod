@@ -1,6 +1,6 @@
 module github.com/trustwallet/blockatlas
 
-go 1.14
+go 1.16
 
 require (
 	github.com/Azure/go-ansiterm v0.0.0-20170929234023-d6e3b3328b78 // indirect
@@ -17,7 +17,9 @@ require (
 	github.com/docker/go-units v0.4.0 // indirect
 	github.com/elastic/go-sysinfo v1.3.0 // indirect
 	github.com/elastic/go-windows v1.0.1 // indirect
-	github.com/gin-gonic/gin v1.6.3
+	github.com/gin-gonic/gin v1.7.7
+	github.com/go-playground/validator/v10 v10.4.1
+	github.com/golang/protobuf v1.4.2
 	github.com/gotestyourself/gotestyourself v2.2.0+incompatible // indirect
 	github.com/jinzhu/gorm v1.9.15
 	github.com/mitchellh/mapstructure v1.3.3
@@ -35,7 +37,9 @@ require (
 	github.com/stretchr/testify v1.6.1
 	github.com/swaggo/gin-swagger v1.2.0
 	github.com/swaggo/swag v1.6.7
+	github.com/trustwallet/blockatlas/pkg/types v1.0.0
 	github.com/trustwallet/ens-coincodec v1.0.6
+	github.com/ugorji/go/codec v1.1.7
 	go.elastic.co/apm v1.8.0
 	go.elastic.co/apm/module/apmgin v1.8.0
 	go.elastic.co/apm/module/apmgorm v1.8.0
@@ -43,10 +47,15 @@ require (
 	go.elastic.co/apm/module/apmlogrus v1.8.0
 	go.elastic.co/fastjson v1.1.0 // indirect
 	go.uber.org/atomic v1.6.0
-	golang.org/x/crypto v0.0.0-20200510223506-06a226fb4e37
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/net v0.0.0-20200506145744-7e3656a0809f
 	golang.org/x/tools v0.0.0-20200513175351-0951661448da // indirect
+	google.golang.org/grpc v1.38.0
+	google.golang.org/protobuf v1.25.0
 	gopkg.in/yaml.v2 v2.3.0
 	gotest.tools v2.2.0+incompatible // indirect
 	howett.net/plist v0.0.0-20200419221736-3b63eb3a43b5 // indirect
 )
+
+// pkg/types is its own module, versioned with the pkg/types/vX.Y.Z tags
+replace github.com/trustwallet/blockatlas/pkg/types => ./pkg/types
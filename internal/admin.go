@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+// InitAdminServer returns the engine to register the admin routes on. With admin.listener.port set they get
+// their own engine and server, over TLS with admin.listener.tls.cert and key, and with mutual TLS when
+// admin.listener.tls.client_ca is set. Otherwise the admin routes stay on the API engine, with a nil server.
+func InitAdminServer(engine *gin.Engine) (*gin.Engine, *http.Server) {
+	port := viper.GetString("admin.listener.port")
+	if port == "" {
+		return engine, nil
+	}
+	admin := InitEngine(viper.GetString("gin.mode"))
+	server := &http.Server{Addr: ":" + port, Handler: admin}
+
+	cert, key := viper.GetString("admin.listener.tls.cert"), viper.GetString("admin.listener.tls.key")
+	clientCA := viper.GetString("admin.listener.tls.client_ca")
+	if cert == "" && key == "" {
+		if clientCA != "" {
+			logger.Fatal("admin.listener.tls.client_ca needs the cert and key of the listener")
+		}
+		logger.Warn("Admin listener without TLS", logger.Params{"bind": port})
+		return admin, server
+	}
+	config, err := AdminTLSConfig(cert, key, clientCA)
+	if err != nil {
+		logger.Fatal(err, "invalid admin listener TLS")
+	}
+	server.TLSConfig = config
+	if clientCA != "" {
+		admin.Use(middleware.RequireClientCert(viper.GetStringSlice("admin.listener.tls.allowed_clients")))
+	}
+	logger.Info("Admin listener", logger.Params{"bind": port, "mtls": clientCA != ""})
+	return admin, server
+}
+
+// AdminTLSConfig serves the certificate of the files, requiring a client certificate signed by one
+// of the CAs of the clientCA file when set
+func AdminTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if clientCAFile == "" {
+		return config, nil
+	}
+	raw, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, errors.E("no certificate in the client CA file", errors.Params{"file": clientCAFile})
+	}
+	config.ClientCAs = pool
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return config, nil
+}
@@ -1,21 +1,33 @@
 package internal
 
 import (
+	"context"
 	"flag"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas/api/middleware"
+	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/config"
+	"github.com/trustwallet/blockatlas/db"
 	"github.com/trustwallet/blockatlas/mq"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/secrets"
+	"github.com/trustwallet/blockatlas/services/observer/tenant"
+	"github.com/trustwallet/blockatlas/services/retention"
+	"github.com/trustwallet/blockatlas/services/tokens"
 	"go.elastic.co/apm/module/apmgin"
 
 	"path/filepath"
+	"strings"
 	"time"
 )
 
 var (
 	Build = "dev"
 	Date  = time.Now().String()
+
+	// mode is the config preset of the -mode flag, ATLAS_MODE or the mode key otherwise
+	mode string
 )
 
 func ParseArgs(defaultPort, defaultConfigPath string) (string, string) {
@@ -25,6 +37,7 @@ func ParseArgs(defaultPort, defaultConfigPath string) (string, string) {
 
 	flag.StringVar(&port, "p", defaultPort, "port for api")
 	flag.StringVar(&confPath, "c", defaultConfigPath, "config file for api")
+	flag.StringVar(&mode, "mode", "", "config preset: minimal, full, observer-only or market-only")
 	flag.Parse()
 
 	return port, confPath
@@ -37,6 +50,31 @@ func InitConfig(confPath string) {
 	}
 
 	config.LoadConfig(confPath)
+	if mode == "" {
+		mode = viper.GetString("mode")
+	}
+	if err := config.ApplyPreset(mode); err != nil {
+		logger.Fatal(err)
+	}
+	initStaticData()
+}
+
+// initStaticData replaces the coin registry and the top tokens built into the binary with the files
+// of static.coins_path and static.tokens_path
+func initStaticData() {
+	if path := viper.GetString("static.coins_path"); path != "" {
+		n, err := coin.LoadOverrides(path)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Coin registry overridden", logger.Params{"path": path, "coins": n})
+	}
+	if path := viper.GetString("static.tokens_path"); path != "" {
+		if err := tokens.Tokens.Load(path); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Top tokens overridden", logger.Params{"path": path})
+	}
 }
 
 func InitEngine(ginMode string) *gin.Engine {
@@ -58,3 +96,88 @@ func InitRabbitMQ(rabbitURI string, prefetchCount int) {
 	}
 	mq.PrefetchCount = prefetchCount
 }
+
+// InitReplica routes the read-only queries to the replica when postgres.read_uri is set
+func InitReplica(database *db.Instance, env string) {
+	readUri := viper.GetString("postgres.read_uri")
+	if readUri == "" {
+		return
+	}
+	if err := database.ConnectReplica(readUri, env); err != nil {
+		logger.Fatal("Failed to connect to PG replica", logger.Params{"err": err})
+	}
+	go db.RestoreReplicaConnectionWorker(database, time.Second*10, readUri)
+}
+
+// InitKeyring encrypts the channel tokens of the database with the master keys of secrets when secrets.current_key is set
+func InitKeyring(database *db.Instance) {
+	current := strings.ToLower(viper.GetString("secrets.current_key"))
+	if current == "" {
+		return
+	}
+	keys, err := secrets.ParseKeys(viper.GetStringMapString("secrets.master_keys"))
+	if err != nil {
+		logger.Fatal(err, "invalid secrets master keys")
+	}
+	keyring, err := secrets.NewKeyring(current, keys)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	database.UseKeyring(keyring)
+	logger.Info("Channel tokens encrypted", logger.Params{"key": current})
+}
+
+// InitMemoryDatabase returns the in-memory instance of the snapshot mode, nil when snapshot.enabled is off.
+// The services save the last changes with SaveSnapshot on shutdown.
+func InitMemoryDatabase() *db.Instance {
+	if !viper.GetBool("snapshot.enabled") {
+		return nil
+	}
+	url := viper.GetString("snapshot.url")
+	store, err := db.NewSnapshotStore(url, viper.GetString("snapshot.token"))
+	if err != nil {
+		logger.Fatal(err)
+	}
+	ctx := context.Background()
+	database, err := db.NewMemory(store, ctx)
+	if err != nil {
+		logger.Fatal(err, "Failed to load the snapshots", logger.Params{"url": url})
+	}
+	interval := viper.GetDuration("snapshot.interval")
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	go db.RunSnapshotWorker(database, interval, ctx)
+	logger.Info("Running in memory with snapshots", logger.Params{"url": url, "interval": interval})
+	return database
+}
+
+// InitTenants returns the tenants of the observer configured under observer.tenants
+func InitTenants() *tenant.Registry {
+	var tenants []tenant.Tenant
+	if err := viper.UnmarshalKey("observer.tenants", &tenants); err != nil {
+		logger.Fatal(err, "invalid observer tenants")
+	}
+	for _, t := range tenants {
+		logger.Info("Observer tenant", logger.Params{"tenant": t.Name, "quota": t.Quota})
+	}
+	return tenant.NewRegistry(tenants)
+}
+
+// RunRetention prunes the tables by the retention policy of the config until ctx is done
+func RunRetention(database *db.Instance, tables []retention.Table, ctx context.Context) {
+	var policy retention.Policy
+	if err := viper.UnmarshalKey("retention", &policy); err != nil {
+		logger.Fatal(err, "invalid retention policy")
+	}
+	interval := viper.GetDuration("retention.interval")
+	if interval <= 0 {
+		interval = retention.DefaultInterval
+	}
+	names := make([]string, 0, len(tables))
+	for _, t := range tables {
+		names = append(names, t.Name)
+	}
+	logger.Info("Retention enabled", logger.Params{"tables": names, "default_days": policy.Default, "coins": policy.Coins, "interval": interval})
+	go retention.RunPruner(tables, database, policy, interval, ctx)
+}
@@ -11,7 +11,8 @@ import (
 	"time"
 )
 
-func SetupGracefulShutdown(port string, engine *gin.Engine) {
+// SetupGracefulShutdown serves the engine on the port, and the other servers (e.g. the admin listener) next to it
+func SetupGracefulShutdown(port string, engine *gin.Engine, servers ...*http.Server) {
 	server := &http.Server{
 		Addr:    ":" + port,
 		Handler: engine,
@@ -24,6 +25,14 @@ func SetupGracefulShutdown(port string, engine *gin.Engine) {
 			logger.Fatal("Server Shutdown: ", err)
 		}
 	}()
+	for _, s := range servers {
+		if s == nil {
+			continue
+		}
+		s := s
+		defer shutdown(s, ctx)
+		go listen(s)
+	}
 
 	signalForExit := make(chan os.Signal, 1)
 	signal.Notify(signalForExit,
@@ -44,12 +53,44 @@ func SetupGracefulShutdown(port string, engine *gin.Engine) {
 	logger.Info("Waiting for all jobs to stop")
 }
 
-func SetupGracefulShutdownForObserver(cancel context.CancelFunc) {
+// SetupGracefulShutdownForObserver serves the servers (e.g. the notifier listener) until the stop signal,
+// then cancels the workers
+func SetupGracefulShutdownForObserver(cancel context.CancelFunc, servers ...*http.Server) {
+	for _, s := range servers {
+		if s != nil {
+			go listen(s)
+		}
+	}
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	cancel()
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	for _, s := range servers {
+		if s != nil {
+			shutdown(s, ctx)
+		}
+	}
 	logger.Info("Shutdown ...")
 	time.Sleep(time.Second * 5)
 	logger.Info("Exiting  gracefully")
 }
+
+func listen(s *http.Server) {
+	var err error
+	if s.TLSConfig != nil {
+		err = s.ListenAndServeTLS("", "")
+	} else {
+		err = s.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		logger.Fatal("Application failed", err)
+	}
+}
+
+func shutdown(s *http.Server, ctx context.Context) {
+	if err := s.Shutdown(ctx); err != nil {
+		logger.Error(err, "Server Shutdown", logger.Params{"bind": s.Addr})
+	}
+}
@@ -0,0 +1,180 @@
+package mq
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	DefaultSampleInterval = time.Second * 10
+	// DefaultTargetDrain is the time the recommended workers take to drain the waiting messages
+	DefaultTargetDrain = time.Minute
+)
+
+var (
+	consumed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atlas",
+		Name:      "mq_consumed_total",
+		Help:      "Messages handled by the worker pool, by queue.",
+	}, []string{"queue"})
+	queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas",
+		Name:      "mq_queue_depth",
+		Help:      "Messages waiting in the queue, updated every sample interval.",
+	}, []string{"queue"})
+	throughput = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas",
+		Name:      "mq_consumer_throughput",
+		Help:      "Messages per second handled by the worker pool over the last sample interval, by queue.",
+	}, []string{"queue"})
+	workers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas",
+		Name:      "mq_workers",
+		Help:      "Workers of the pool by queue and state: size, busy or recommended.",
+	}, []string{"queue", "state"})
+)
+
+func init() {
+	prometheus.MustRegister(consumed, queueDepth, throughput, workers)
+}
+
+// QueueStats is the last sample of a queue and its worker pool, with the recommended number of workers
+type QueueStats struct {
+	Queue string `json:"queue"`
+	// Depth is the count of the messages waiting in the queue, not delivered yet
+	Depth     int `json:"depth"`
+	Consumers int `json:"consumers"`
+	// Throughput is the messages per second handled by the pool since the previous sample
+	Throughput  float64   `json:"throughput"`
+	Workers     int       `json:"workers"`
+	Busy        int       `json:"busy"`
+	Recommended int       `json:"recommended"`
+	SampledAt   time.Time `json:"sampled_at"`
+}
+
+// Autoscaler samples the depth of a queue and the throughput of its worker pool, and recommends
+// the number of workers draining the queue in TargetDrain, between Min and Max. It only recommends,
+// the pool is resized by the admin API or by the orchestrator of the consumers.
+type Autoscaler struct {
+	Queue       Queue
+	Pool        *WorkerPool
+	Min         int
+	Max         int
+	TargetDrain time.Duration
+
+	mu        sync.Mutex
+	stats     QueueStats
+	processed uint64
+	inspect   func(q Queue) (depth, consumers int, err error)
+}
+
+func NewAutoscaler(queue Queue, pool *WorkerPool, min, max int, targetDrain time.Duration) *Autoscaler {
+	if targetDrain <= 0 {
+		targetDrain = DefaultTargetDrain
+	}
+	return &Autoscaler{Queue: queue, Pool: pool, Min: min, Max: max, TargetDrain: targetDrain, inspect: Queue.Inspect}
+}
+
+// Inspect returns the count of the messages waiting in the queue and of its consumers
+func (q Queue) Inspect() (depth, consumers int, err error) {
+	state, err := channel().QueueInspect(string(q))
+	if err != nil {
+		return 0, 0, err
+	}
+	return state.Messages, state.Consumers, nil
+}
+
+// Run samples the queue every interval until the end of ctx
+func (a *Autoscaler) Run(interval time.Duration, ctx context.Context) {
+	if interval <= 0 {
+		interval = DefaultSampleInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := a.sample(time.Now()); err != nil {
+			logger.Error(err, "Failed to inspect queue", logger.Params{"queue": a.Queue})
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stats returns the last sample, with the current size of the pool
+func (a *Autoscaler) Stats() QueueStats {
+	a.mu.Lock()
+	stats := a.stats
+	a.mu.Unlock()
+	pool := a.Pool.Stats()
+	stats.Queue, stats.Workers, stats.Busy = string(a.Queue), pool.Size, pool.Busy
+	return stats
+}
+
+func (a *Autoscaler) sample(now time.Time) error {
+	depth, consumers, err := a.inspect(a.Queue)
+	if err != nil {
+		return err
+	}
+	pool := a.Pool.Stats()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var rate float64
+	if elapsed := now.Sub(a.stats.SampledAt); !a.stats.SampledAt.IsZero() && elapsed > 0 {
+		rate = float64(pool.Processed-a.processed) / elapsed.Seconds()
+	}
+	a.processed = pool.Processed
+	a.stats = QueueStats{
+		Queue:       string(a.Queue),
+		Depth:       depth,
+		Consumers:   consumers,
+		Throughput:  rate,
+		Workers:     pool.Size,
+		Busy:        pool.Busy,
+		Recommended: Recommend(depth, rate, pool.Size, pool.Busy, a.Min, a.Max, a.TargetDrain),
+		SampledAt:   now,
+	}
+
+	queue := string(a.Queue)
+	queueDepth.WithLabelValues(queue).Set(float64(depth))
+	throughput.WithLabelValues(queue).Set(rate)
+	workers.WithLabelValues(queue, "size").Set(float64(pool.Size))
+	workers.WithLabelValues(queue, "busy").Set(float64(pool.Busy))
+	workers.WithLabelValues(queue, "recommended").Set(float64(a.stats.Recommended))
+	return nil
+}
+
+// Recommend returns the number of workers handling the incoming messages and draining the depth
+// waiting in the queue in drain, at the per-worker throughput of the current workers. An empty queue
+// needs the busy workers, a queue not draining without throughput twice the workers. The result is
+// kept between min and max, max 0 having no limit.
+func Recommend(depth int, throughput float64, workers, busy, min, max int, drain time.Duration) int {
+	var n int
+	switch {
+	case depth == 0:
+		n = busy
+	case throughput <= 0:
+		n = workers * 2
+	default:
+		perWorker := throughput / float64(workers)
+		n = int(math.Ceil((throughput + float64(depth)/drain.Seconds()) / perWorker))
+	}
+	if min < 1 {
+		min = 1
+	}
+	if max > 0 && n > max {
+		n = max
+	}
+	if n < min {
+		n = min
+	}
+	return n
+}
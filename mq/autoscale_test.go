@@ -0,0 +1,69 @@
+package mq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	pool := NewWorkerPool(1)
+	ctx := context.Background()
+	assert.True(t, pool.acquire(ctx))
+
+	acquired := make(chan bool)
+	go func() { acquired <- pool.acquire(ctx) }()
+	select {
+	case <-acquired:
+		t.Fatal("acquired a worker of a full pool")
+	case <-time.After(time.Millisecond * 20):
+	}
+	pool.Resize(2)
+	assert.True(t, <-acquired)
+	assert.Equal(t, PoolStats{Size: 2, Busy: 2}, pool.Stats())
+
+	pool.release()
+	pool.release()
+	assert.Equal(t, PoolStats{Size: 2, Busy: 0, Processed: 2}, pool.Stats())
+
+	pool.Resize(0)
+	assert.True(t, pool.acquire(ctx))
+	canceled, cancel := context.WithCancel(ctx)
+	go func() { acquired <- pool.acquire(canceled) }()
+	cancel()
+	assert.False(t, <-acquired)
+}
+
+func TestRecommend(t *testing.T) {
+	drain := time.Minute
+	// 4 workers handling 10 msg/s need 4 more to drain 600 messages in a minute
+	assert.Equal(t, 8, Recommend(600, 10, 4, 4, 1, 0, drain))
+	assert.Equal(t, 6, Recommend(600, 10, 4, 4, 1, 6, drain))
+	assert.Equal(t, 2, Recommend(0, 10, 4, 2, 1, 0, drain))
+	assert.Equal(t, 3, Recommend(0, 0, 4, 0, 3, 0, drain))
+	assert.Equal(t, 8, Recommend(10, 0, 4, 4, 1, 0, drain))
+}
+
+func TestAutoscaler_sample(t *testing.T) {
+	pool := NewWorkerPool(2)
+	a := NewAutoscaler(RawTransactions, pool, 1, 10, time.Minute)
+	a.inspect = func(q Queue) (int, int, error) { return 120, 1, nil }
+
+	now := time.Now()
+	assert.Nil(t, a.sample(now))
+	assert.Equal(t, 4, a.Stats().Recommended)
+
+	for i := 0; i < 20; i++ {
+		pool.acquire(context.Background())
+		pool.release()
+	}
+	assert.Nil(t, a.sample(now.Add(time.Second*10)))
+	stats := a.Stats()
+	assert.Equal(t, "rawTransactions", stats.Queue)
+	assert.Equal(t, 120, stats.Depth)
+	assert.Equal(t, float64(2), stats.Throughput)
+	assert.Equal(t, 4, stats.Recommended)
+	assert.Equal(t, 2, stats.Workers)
+}
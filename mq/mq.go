@@ -5,6 +5,7 @@ import (
 	"github.com/streadway/amqp"
 	"github.com/trustwallet/blockatlas/db"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"sync"
 	"time"
 )
 
@@ -12,36 +13,69 @@ var (
 	PrefetchCount int
 	amqpChan      *amqp.Channel
 	conn          *amqp.Connection
+	// connMu guards the connection and its channel, replaced by RestoreConnectionWorker
+	connMu sync.RWMutex
 )
 
 type (
 	Queue              string
+	Exchange           string
 	Consumer           func(amqp.Delivery)
 	ConsumerWithDbConn func(*db.Instance, amqp.Delivery)
 	MessageChannel     <-chan amqp.Delivery
+
+	// Declarer is a queue or an exchange, declared again on a restored connection
+	Declarer interface {
+		Declare() error
+	}
 )
 
 const (
-	TxNotifications Queue = "txNotifications"
-	Subscriptions   Queue = "subscriptions"
-	RawTransactions Queue = "rawTransactions"
+	TxNotifications       Queue = "txNotifications"
+	Subscriptions         Queue = "subscriptions"
+	RawTransactions       Queue = "rawTransactions"
+	SubscriptionsExpiring Queue = "subscriptionsExpiring"
+	WebhookDeadLetters    Queue = "webhookDeadLetters"
 )
 
-func Init(uri string) (err error) {
-	conn, err = amqp.Dial(uri)
+// NewTransactions fans the transactions batches of the parser out to every API instance
+const NewTransactions Exchange = "newTransactions"
+
+func Init(uri string) error {
+	c, err := amqp.Dial(uri)
 	if err != nil {
 		return err
 	}
-	amqpChan, err = conn.Channel()
-	return err
+	ch, err := c.Channel()
+	if err != nil {
+		return err
+	}
+	connMu.Lock()
+	defer connMu.Unlock()
+	conn, amqpChan = c, ch
+	return nil
+}
+
+func channel() *amqp.Channel {
+	connMu.RLock()
+	defer connMu.RUnlock()
+	return amqpChan
+}
+
+func isClosed() bool {
+	connMu.RLock()
+	defer connMu.RUnlock()
+	return conn.IsClosed()
 }
 
 func Close() {
-	err := amqpChan.Close()
+	err := channel().Close()
 	if err != nil {
 		logger.Error(err)
 	}
 
+	connMu.RLock()
+	defer connMu.RUnlock()
 	err = conn.Close()
 	if err != nil {
 		logger.Error(err)
@@ -53,18 +87,48 @@ func (mc MessageChannel) GetMessage() amqp.Delivery {
 }
 
 func (q Queue) Declare() error {
-	_, err := amqpChan.QueueDeclare(string(q), true, false, false, false, nil)
+	_, err := channel().QueueDeclare(string(q), true, false, false, false, nil)
 	return err
 }
 
 func (q Queue) Publish(body []byte) error {
-	return amqpChan.Publish("", string(q), false, false, amqp.Publishing{
+	return channel().Publish("", string(q), false, false, amqp.Publishing{
 		DeliveryMode: amqp.Persistent,
 		ContentType:  "text/plain",
 		Body:         body,
 	})
 }
 
+func (e Exchange) Declare() error {
+	return channel().ExchangeDeclare(string(e), amqp.ExchangeFanout, true, false, false, false, nil)
+}
+
+// Publish sends the message to the queues bound to the exchange, it is dropped when none is
+func (e Exchange) Publish(body []byte) error {
+	return channel().Publish(string(e), "", false, false, amqp.Publishing{
+		ContentType: "text/plain",
+		Body:        body,
+	})
+}
+
+// Subscribe binds a private queue to the exchange, deleted with the connection,
+// its messages are acknowledged on delivery
+func (e Exchange) Subscribe() (MessageChannel, error) {
+	ch := channel()
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.QueueBind(q.Name, "", string(e), false, nil); err != nil {
+		return nil, err
+	}
+	messages, err := ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
 func RunConsumerForChannelWithCancelAndDbConn(consumer ConsumerWithDbConn, messageChannel MessageChannel, database *db.Instance, ctx context.Context) {
 	for {
 		select {
@@ -81,29 +145,41 @@ func RunConsumerForChannelWithCancelAndDbConn(consumer ConsumerWithDbConn, messa
 }
 
 func (q Queue) GetMessageChannel() MessageChannel {
-	messageChannel, err := amqpChan.Consume(
-		string(q),
-		"",
-		false,
-		false,
-		false,
-		false,
-		nil,
-	)
+	messageChannel, err := q.consume()
 	if err != nil {
 		logger.Fatal("MQ issue " + err.Error())
 	}
+	return messageChannel
+}
 
-	err = amqpChan.Qos(
-		PrefetchCount,
-		0,
-		true,
-	)
-	if err != nil {
-		logger.Fatal("No qos limit ", err)
+func (q Queue) consume() (MessageChannel, error) {
+	ch := channel()
+	if err := ch.Qos(PrefetchCount, 0, true); err != nil {
+		return nil, err
 	}
+	return ch.Consume(string(q), "", false, false, false, false, nil)
+}
 
-	return messageChannel
+// resume consumes the queue again once RestoreConnectionWorker restored the connection, false when ctx ends first
+func (q Queue) resume(ctx context.Context) (MessageChannel, bool) {
+	logger.Warn("MQ consumer disconnected", logger.Params{"queue": q})
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(time.Second):
+		}
+		if isClosed() {
+			continue
+		}
+		messageChannel, err := q.consume()
+		if err != nil {
+			logger.Warn("MQ consumer not resumed", logger.Params{"queue": q, "err": err})
+			continue
+		}
+		logger.Info("MQ consumer resumed", logger.Params{"queue": q})
+		return messageChannel, true
+	}
 }
 
 func (q Queue) RunConsumer(consumer Consumer) {
@@ -120,7 +196,14 @@ func (q Queue) RunConsumerWithCancel(consumer Consumer, ctx context.Context) {
 		case <-ctx.Done():
 			logger.Info("Consumer stopped")
 			return
-		case message := <-messageChannel:
+		case message, ok := <-messageChannel:
+			if !ok {
+				if messageChannel, ok = q.resume(ctx); !ok {
+					logger.Info("Consumer stopped")
+					return
+				}
+				continue
+			}
 			if message.Body == nil {
 				continue
 			}
@@ -136,7 +219,14 @@ func (q Queue) RunConsumerWithCancelAndDbConn(consumer ConsumerWithDbConn, datab
 		case <-ctx.Done():
 			logger.Info("Consumer stopped")
 			return
-		case message := <-messageChannel:
+		case message, ok := <-messageChannel:
+			if !ok {
+				if messageChannel, ok = q.resume(ctx); !ok {
+					logger.Info("Consumer stopped")
+					return
+				}
+				continue
+			}
 			if message.Body == nil {
 				continue
 			}
@@ -145,10 +235,13 @@ func (q Queue) RunConsumerWithCancelAndDbConn(consumer ConsumerWithDbConn, datab
 	}
 }
 
-func RestoreConnectionWorker(uri string, queue Queue, timeout time.Duration) {
+// RestoreConnectionWorker connects again to uri when the connection closes, every timeout until it succeeds,
+// and declares the queues and exchanges again. The consumers of the queues resume on the new connection,
+// publishing fails until then.
+func RestoreConnectionWorker(uri string, timeout time.Duration, declarers ...Declarer) {
 	logger.Info("Run MQ RestoreConnectionWorker")
 	for {
-		if conn.IsClosed() {
+		if isClosed() {
 			for {
 				logger.Warn("MQ is not available now")
 				logger.Warn("Trying to connect to MQ...")
@@ -157,24 +250,32 @@ func RestoreConnectionWorker(uri string, queue Queue, timeout time.Duration) {
 					time.Sleep(timeout)
 					continue
 				}
-				if err := queue.Declare(); err != nil {
-					logger.Warn("Can't declare queues:", queue)
+				if err := declare(declarers); err != nil {
+					logger.Warn("Can't declare queues:", err)
 					time.Sleep(timeout)
 					continue
-				} else {
-					logger.Info("MQ connection restored")
-					break
 				}
+				logger.Info("MQ connection restored")
+				break
 			}
 		}
 		time.Sleep(timeout)
 	}
 }
 
+func declare(declarers []Declarer) error {
+	for _, d := range declarers {
+		if err := d.Declare(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func FatalWorker(timeout time.Duration) {
 	logger.Info("Run MQ FatalWorker")
 	for {
-		if conn.IsClosed() {
+		if isClosed() {
 			logger.Fatal("MQ is not available now")
 		}
 		time.Sleep(timeout)
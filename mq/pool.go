@@ -0,0 +1,122 @@
+package mq
+
+import (
+	"context"
+	"sync"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+// WorkerPool bounds the messages of a queue handled at once, its size can change while consuming.
+// Workers above the prefetch count stay idle, the broker doesn't deliver them more messages.
+type WorkerPool struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	size      int
+	busy      int
+	processed uint64
+}
+
+// PoolStats is the size of a worker pool with its workers handling a message and the count of the handled messages
+type PoolStats struct {
+	Size      int    `json:"size"`
+	Busy      int    `json:"busy"`
+	Processed uint64 `json:"processed"`
+}
+
+// NewWorkerPool returns a pool of size workers, at least one
+func NewWorkerPool(size int) *WorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	p := &WorkerPool{size: size}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// Resize sets the number of workers, at least one. The messages handled by the removed workers finish.
+func (p *WorkerPool) Resize(size int) {
+	if size < 1 {
+		size = 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.size = size
+	p.cond.Broadcast()
+}
+
+func (p *WorkerPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return PoolStats{Size: p.size, Busy: p.busy, Processed: p.processed}
+}
+
+// acquire waits for an idle worker, false when ctx ends first
+func (p *WorkerPool) acquire(ctx context.Context) bool {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.mu.Lock()
+			p.cond.Broadcast()
+			p.mu.Unlock()
+		case <-done:
+		}
+	}()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.busy >= p.size {
+		if ctx.Err() != nil {
+			return false
+		}
+		p.cond.Wait()
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+	p.busy++
+	return true
+}
+
+func (p *WorkerPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.busy--
+	p.processed++
+	p.cond.Signal()
+}
+
+// RunConsumerWithPool handles the messages of the queue with the workers of the pool until the end of ctx
+func (q Queue) RunConsumerWithPool(consumer ConsumerWithDbConn, database *db.Instance, pool *WorkerPool, ctx context.Context) {
+	messageChannel := q.GetMessageChannel()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Consumer stopped")
+			return
+		case message, ok := <-messageChannel:
+			if !ok {
+				if messageChannel, ok = q.resume(ctx); !ok {
+					logger.Info("Consumer stopped")
+					return
+				}
+				continue
+			}
+			if message.Body == nil {
+				continue
+			}
+			if !pool.acquire(ctx) {
+				logger.Info("Consumer stopped")
+				return
+			}
+			go func() {
+				defer pool.release()
+				consumer(database, message)
+				consumed.WithLabelValues(string(q)).Inc()
+			}()
+		}
+	}
+}
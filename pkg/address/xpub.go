@@ -0,0 +1,40 @@
+package address
+
+import (
+	"encoding/hex"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+// EthereumAddressesFromXpub derives the first count addresses of the external chain (0/i)
+// of an account extended public key, e.g. the key of m/44'/60'/0'
+func EthereumAddressesFromXpub(xpub string, count int) ([]string, error) {
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, errors.E(err, "invalid extended key")
+	}
+	if key.IsPrivate() {
+		return nil, errors.E("private extended key, the extended public key is expected")
+	}
+	external, err := key.Child(0)
+	if err != nil {
+		return nil, errors.E(err, "failed to derive the external chain")
+	}
+	addresses := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		child, err := external.Child(uint32(i))
+		if err != nil {
+			return nil, errors.E(err, "failed to derive address", errors.Params{"index": i})
+		}
+		pub, err := child.ECPubKey()
+		if err != nil {
+			return nil, errors.E(err, "invalid public key", errors.Params{"index": i})
+		}
+		sha := sha3.NewLegacyKeccak256()
+		_, _ = sha.Write(pub.SerializeUncompressed()[1:])
+		addresses = append(addresses, EIP55Checksum(hex.EncodeToString(sha.Sum(nil)[12:])))
+	}
+	return addresses, nil
+}
@@ -0,0 +1,20 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEthereumAddressesFromXpub(t *testing.T) {
+	// m/44'/60'/0' of the "abandon ... about" test mnemonic
+	xpub := "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt"
+	addresses, err := EthereumAddressesFromXpub(xpub, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0x9858EfFD232B4033E47d90003D41EC34EcaEda94", "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"}, addresses)
+
+	_, err = EthereumAddressesFromXpub("xpub-invalid", 2)
+	assert.NotNil(t, err)
+	_, err = EthereumAddressesFromXpub("xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi", 2)
+	assert.NotNil(t, err)
+}
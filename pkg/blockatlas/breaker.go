@@ -0,0 +1,170 @@
+package blockatlas
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// CircuitBreaker is the state of the breaker of an upstream host, listed by /admin/breakers
+type CircuitBreaker struct {
+	Host  string       `json:"host"`
+	State BreakerState `json:"state"`
+	// Failures is the count of consecutive failures, reset by a success
+	Failures      int        `json:"failures"`
+	TotalFailures int64      `json:"total_failures"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	OpenedAt      *time.Time `json:"opened_at,omitempty"`
+}
+
+// breakers stops the requests of every Request to the upstream hosts failing in a row,
+// a single request probes the host once the cooldown is over
+var breakers = newBreakerSet()
+
+type breakerSet struct {
+	sync.Mutex
+	// threshold is the count of consecutive failures opening a breaker, 0 disables the breakers
+	threshold int
+	cooldown  time.Duration
+	hosts     map[string]*hostBreaker
+	now       func() time.Time
+}
+
+type hostBreaker struct {
+	CircuitBreaker
+	probing bool
+}
+
+func newBreakerSet() *breakerSet {
+	return &breakerSet{hosts: make(map[string]*hostBreaker), now: time.Now}
+}
+
+// SetCircuitBreakers opens the breaker of an upstream host after threshold consecutive
+// failures (0 disables the breakers), the requests fail fast until the cooldown is over
+func SetCircuitBreakers(threshold int, cooldown time.Duration) {
+	breakers.Lock()
+	defer breakers.Unlock()
+	breakers.threshold = threshold
+	breakers.cooldown = cooldown
+	breakers.hosts = make(map[string]*hostBreaker)
+}
+
+// CircuitBreakers returns the breakers of the upstream hosts requested so far, sorted by host
+func CircuitBreakers() []CircuitBreaker {
+	return breakers.list()
+}
+
+// ResetCircuitBreaker closes the breaker of the host, false if the host has none
+func ResetCircuitBreaker(host string) bool {
+	return breakers.reset(host)
+}
+
+// allow fails fast when the breaker of the host of the URL is open, the returned func records the outcome
+// of the request. The failures once ctx is done are the caller's (client gone, provider timeout), not the host's.
+func (s *breakerSet) allow(rawURL string, ctx context.Context) (func(error), error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return func(error) {}, nil
+	}
+	host := strings.ToLower(u.Hostname())
+
+	s.Lock()
+	defer s.Unlock()
+	if s.threshold <= 0 {
+		return func(error) {}, nil
+	}
+	b, ok := s.hosts[host]
+	if !ok {
+		b = &hostBreaker{CircuitBreaker: CircuitBreaker{Host: host, State: BreakerClosed}}
+		s.hosts[host] = b
+	}
+	switch b.State {
+	case BreakerOpen:
+		if s.now().Sub(*b.OpenedAt) < s.cooldown {
+			return nil, errors.E("upstream circuit breaker open", errors.TypePlatformRequest, errors.Params{"host": host})
+		}
+		b.State = BreakerHalfOpen
+		b.probing = true
+	case BreakerHalfOpen:
+		if b.probing {
+			return nil, errors.E("upstream circuit breaker half-open", errors.TypePlatformRequest, errors.Params{"host": host})
+		}
+		b.probing = true
+	}
+	return func(err error) {
+		if err != nil && ctx.Err() != nil {
+			s.release(b)
+			return
+		}
+		s.record(b, err)
+	}, nil
+}
+
+// release lets another request probe the host, without outcome
+func (s *breakerSet) release(b *hostBreaker) {
+	s.Lock()
+	defer s.Unlock()
+	b.probing = false
+}
+
+func (s *breakerSet) record(b *hostBreaker, err error) {
+	s.Lock()
+	defer s.Unlock()
+	b.probing = false
+	if err == nil {
+		b.State = BreakerClosed
+		b.Failures = 0
+		b.OpenedAt = nil
+		return
+	}
+	now := s.now()
+	b.Failures++
+	b.TotalFailures++
+	b.LastError = err.Error()
+	b.LastFailureAt = &now
+	if b.State == BreakerHalfOpen || b.Failures >= s.threshold {
+		b.State = BreakerOpen
+		b.OpenedAt = &now
+	}
+}
+
+func (s *breakerSet) list() []CircuitBreaker {
+	s.Lock()
+	defer s.Unlock()
+	result := make([]CircuitBreaker, 0, len(s.hosts))
+	for _, b := range s.hosts {
+		result = append(result, b.CircuitBreaker)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Host < result[j].Host
+	})
+	return result
+}
+
+func (s *breakerSet) reset(host string) bool {
+	s.Lock()
+	defer s.Unlock()
+	b, ok := s.hosts[strings.ToLower(host)]
+	if !ok {
+		return false
+	}
+	b.State = BreakerClosed
+	b.Failures = 0
+	b.OpenedAt = nil
+	b.probing = false
+	return true
+}
@@ -0,0 +1,120 @@
+package blockatlas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+func TestBreakerSet(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	s := newBreakerSet()
+	s.threshold = 2
+	s.cooldown = time.Minute
+	s.now = func() time.Time { return now }
+	failure := errors.E("connection refused")
+
+	for i := 0; i < 2; i++ {
+		record, err := s.allow("https://API.example.com/v1/txs", context.Background())
+		assert.Nil(t, err)
+		record(failure)
+	}
+	_, err := s.allow("https://api.example.com/v1/blocks", context.Background())
+	assert.True(t, errors.Is(err, errors.TypePlatformRequest))
+
+	// Other hosts have their own breaker
+	record, err := s.allow("https://other.example.com", context.Background())
+	assert.Nil(t, err)
+	record(nil)
+
+	breakers := s.list()
+	assert.Len(t, breakers, 2)
+	assert.Equal(t, "api.example.com", breakers[0].Host)
+	assert.Equal(t, BreakerOpen, breakers[0].State)
+	assert.Equal(t, 2, breakers[0].Failures)
+	assert.Equal(t, failure.Error(), breakers[0].LastError)
+	assert.Equal(t, BreakerClosed, breakers[1].State)
+
+	// A single request probes the host after the cooldown
+	now = now.Add(time.Minute)
+	probe, err := s.allow("https://api.example.com", context.Background())
+	assert.Nil(t, err)
+	_, err = s.allow("https://api.example.com", context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, BreakerHalfOpen, s.list()[0].State)
+	probe(failure)
+	assert.Equal(t, BreakerOpen, s.list()[0].State)
+
+	now = now.Add(time.Minute)
+	probe, err = s.allow("https://api.example.com", context.Background())
+	assert.Nil(t, err)
+	probe(nil)
+	assert.Equal(t, BreakerClosed, s.list()[0].State)
+	assert.Equal(t, 0, s.list()[0].Failures)
+	assert.Equal(t, int64(3), s.list()[0].TotalFailures)
+}
+
+func TestBreakerSet_Reset(t *testing.T) {
+	s := newBreakerSet()
+	s.threshold = 1
+	s.cooldown = time.Hour
+
+	record, err := s.allow("https://api.example.com", context.Background())
+	assert.Nil(t, err)
+	record(errors.E("timeout"))
+	_, err = s.allow("https://api.example.com", context.Background())
+	assert.NotNil(t, err)
+
+	assert.False(t, s.reset("unknown.example.com"))
+	assert.True(t, s.reset("API.example.com"))
+	_, err = s.allow("https://api.example.com", context.Background())
+	assert.Nil(t, err)
+}
+
+func TestBreakerSet_Disabled(t *testing.T) {
+	s := newBreakerSet()
+	for i := 0; i < 10; i++ {
+		record, err := s.allow("https://api.example.com", context.Background())
+		assert.Nil(t, err)
+		record(errors.E("timeout"))
+	}
+	assert.Empty(t, s.list())
+}
+
+func TestBreakerSet_Cancelled(t *testing.T) {
+	s := newBreakerSet()
+	s.threshold = 1
+	s.cooldown = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for i := 0; i < 3; i++ {
+		record, err := s.allow("https://api.example.com", ctx)
+		assert.Nil(t, err)
+		record(context.Canceled)
+	}
+	assert.Equal(t, BreakerClosed, s.list()[0].State)
+	assert.Equal(t, int64(0), s.list()[0].TotalFailures)
+}
+
+func TestExecute_CancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	SetCircuitBreakers(1, time.Hour)
+	defer SetCircuitBreakers(0, 0)
+	client := InitJSONClient(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var result map[string]interface{}
+	assert.NotNil(t, client.GetWithContext(&result, "", nil, ctx))
+	assert.Nil(t, client.GetWithContext(&result, "", nil, context.Background()))
+	assert.Equal(t, BreakerClosed, CircuitBreakers()[0].State)
+}
@@ -0,0 +1,42 @@
+package blockatlas
+
+// CoinCapabilities lists the features served for a coin
+type CoinCapabilities struct {
+	Coin              uint   `json:"coin"`
+	Handle            string `json:"handle"`
+	Symbol            string `json:"symbol"`
+	Transactions      bool   `json:"transactions"`
+	TokenTransactions bool   `json:"token_transactions"`
+	Xpub              bool   `json:"xpub"`
+	Tokens            bool   `json:"tokens"`
+	Balances          bool   `json:"balances"`
+	Staking           bool   `json:"staking"`
+	Fees              bool   `json:"fees"`
+	Broadcast         bool   `json:"broadcast"`
+	Simulation        bool   `json:"simulation"`
+	Collectibles      bool   `json:"collectibles"`
+	Mempool           bool   `json:"mempool"`
+	Blocks            bool   `json:"blocks"`
+	// SelfHosted is set when every upstream of the coin is served by the operator
+	SelfHosted bool `json:"self_hosted"`
+}
+
+// NewCoinCapabilities returns the features implemented by the platform, the collectibles are
+// served by the separate CollectionsAPIs
+func NewCoinCapabilities(p Platform) CoinCapabilities {
+	c := p.Coin()
+	caps := CoinCapabilities{Coin: c.ID, Handle: c.Handle, Symbol: c.Symbol}
+	_, caps.Transactions = p.(TxAPI)
+	_, caps.TokenTransactions = p.(TokenTxAPI)
+	_, caps.Xpub = p.(TxUtxoAPI)
+	_, caps.Tokens = p.(TokensAPI)
+	_, caps.Balances = p.(BalanceAPI)
+	_, caps.Staking = p.(StakeAPI)
+	_, caps.Fees = p.(FeeAPI)
+	_, caps.Broadcast = p.(BroadcastAPI)
+	_, caps.Simulation = p.(SimulationAPI)
+	_, caps.Collectibles = p.(CollectionsAPI)
+	_, caps.Mempool = p.(MempoolAPI)
+	_, caps.Blocks = p.(BlockAPI)
+	return caps
+}
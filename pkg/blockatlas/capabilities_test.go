@@ -0,0 +1,34 @@
+package blockatlas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+)
+
+type (
+	mockPlatform struct{}
+
+	mockTxPlatform struct {
+		mockPlatform
+	}
+)
+
+func (mockPlatform) Coin() coin.Coin {
+	return coin.Coins[coin.ETH]
+}
+
+func (mockTxPlatform) GetTxsByAddress(address string) (TxPage, error) {
+	return nil, nil
+}
+
+func (mockTxPlatform) Broadcast(rawTx string) (string, error) {
+	return "", nil
+}
+
+func TestNewCoinCapabilities(t *testing.T) {
+	assert.Equal(t, CoinCapabilities{Coin: 60, Handle: "ethereum", Symbol: "ETH"}, NewCoinCapabilities(mockPlatform{}))
+	assert.Equal(t, CoinCapabilities{Coin: 60, Handle: "ethereum", Symbol: "ETH", Transactions: true, Broadcast: true},
+		NewCoinCapabilities(mockTxPlatform{}))
+}
@@ -103,12 +103,42 @@ func (r *Request) Execute(method string, url string, body io.Reader, result inte
 		req.Header.Set(key, value)
 	}
 
+	release, err := hostLimits.acquire(url, ctx)
+	if err != nil {
+		return errors.E(err, errors.TypePlatformRequest)
+	}
+	defer release()
+
+	record, err := breakers.allow(url, ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := faults.inject(url, ctx); err != nil {
+		record(err)
+		return errors.E(err, errors.TypePlatformRequest)
+	}
+
 	c := apmhttp.WrapClient(r.HttpClient)
 
 	res, err := c.Do(req.WithContext(ctx))
 	if err != nil {
+		record(err)
 		return errors.E(err, errors.TypePlatformRequest)
 	}
+	if res.StatusCode >= http.StatusInternalServerError {
+		record(errors.E(fmt.Sprintf("upstream status %d", res.StatusCode)))
+	} else {
+		record(nil)
+	}
+
+	if p := ProvenanceFrom(ctx); p != nil {
+		node := r.BaseUrl
+		if node == "" {
+			node = url
+		}
+		p.Record(UpstreamSource(node))
+	}
 
 	err = r.ErrorHandler(res, url)
 	if err != nil {
@@ -123,6 +153,9 @@ func (r *Request) Execute(method string, url string, body io.Reader, result inte
 	if err != nil {
 		return errors.E(err, errors.TypePlatformUnmarshal)
 	}
+	if driftDetection {
+		checkSchemaDrift(url, b, result)
+	}
 	return err
 }
 
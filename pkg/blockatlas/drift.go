@@ -0,0 +1,186 @@
+package blockatlas
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	driftUnknownField   = "unknown_field"
+	driftMissingField   = "missing_required"
+	requiredFieldOption = "required"
+)
+
+var (
+	// schemaDrift counts the upstream response fields not matching the models, by provider host.
+	// Every unknown field is counted once, the count settles after the fields the models
+	// ignore are seen and grows again when an upstream adds fields.
+	schemaDrift = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atlas",
+		Name:      "upstream_schema_drift_total",
+		Help:      "Upstream response fields not matching the platform models.",
+	}, []string{"host", "kind"})
+
+	driftDetection bool
+	unmarshaler    = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	seenDrift      sync.Map
+)
+
+func init() {
+	prometheus.MustRegister(schemaDrift)
+}
+
+// SetDriftDetection enables the checks of the upstream responses against the models they
+// are decoded to: fields unknown to the model and fields tagged `json:"name,required"` missing
+func SetDriftDetection(enabled bool) {
+	driftDetection = enabled
+}
+
+func checkSchemaDrift(uri string, data []byte, result interface{}) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return
+	}
+	host := uri
+	if u, err := url.Parse(uri); err == nil {
+		host = u.Hostname()
+	}
+	model := reflect.TypeOf(result)
+
+	unknown := make(map[string]bool)
+	unknownFields(model, raw, "", unknown)
+	for path := range unknown {
+		key := host + "|" + model.String() + "|" + path
+		if _, seen := seenDrift.LoadOrStore(key, true); seen {
+			continue
+		}
+		schemaDrift.WithLabelValues(host, driftUnknownField).Inc()
+		logger.Warn("Upstream response field unknown to the model", logger.Params{"host": host, "model": model.String(), "field": path})
+	}
+
+	missing := make(map[string]bool)
+	missingRequired(reflect.ValueOf(result), "", missing)
+	for path := range missing {
+		schemaDrift.WithLabelValues(host, driftMissingField).Inc()
+		logger.Error("Upstream response misses a required field", logger.Params{"host": host, "model": model.String(), "field": path, "uri": uri})
+	}
+}
+
+// unknownFields collects the paths of the JSON object keys without a field in the model
+func unknownFields(t reflect.Type, v interface{}, path string, result map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshaler) {
+		return
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for key, value := range v {
+				field, ok := fields[strings.ToLower(key)]
+				if !ok {
+					result[path+"."+key] = true
+					continue
+				}
+				unknownFields(field.Type, value, path+"."+key, result)
+			}
+		case reflect.Map:
+			for _, value := range v {
+				unknownFields(t.Elem(), value, path+".*", result)
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for _, value := range v {
+			unknownFields(t.Elem(), value, path+"[]", result)
+		}
+	}
+}
+
+// jsonFields returns the fields of the struct by lowercase JSON name, the way encoding/json matches them
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _ := parseJSONTag(field)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, f := range jsonFields(embedded) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = f
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field
+	}
+	return fields
+}
+
+// missingRequired collects the paths of the required fields left empty by the response
+func missingRequired(v reflect.Value, path string, result map[string]bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" && !field.Anonymous {
+				continue
+			}
+			name, required := parseJSONTag(field)
+			if name == "" {
+				name = field.Name
+			}
+			if required && v.Field(i).IsZero() {
+				result[path+"."+name] = true
+				continue
+			}
+			missingRequired(v.Field(i), path+"."+name, result)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			missingRequired(v.Index(i), path+"[]", result)
+		}
+	}
+}
+
+func parseJSONTag(field reflect.StructField) (string, bool) {
+	parts := strings.Split(field.Tag.Get("json"), ",")
+	for _, option := range parts[1:] {
+		if option == requiredFieldOption {
+			return parts[0], true
+		}
+	}
+	return parts[0], false
+}
@@ -0,0 +1,63 @@
+package blockatlas
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	driftPage struct {
+		Docs  []driftDoc         `json:"docs"`
+		Extra map[string]driftOp `json:"extra"`
+	}
+
+	driftDoc struct {
+		driftBase
+		ID    string    `json:"id,required"`
+		Value string    `json:"value"`
+		Ops   []driftOp `json:"operations"`
+	}
+
+	driftBase struct {
+		Coin uint `json:"coin"`
+	}
+
+	driftOp struct {
+		Type string `json:"type,required"`
+	}
+)
+
+func Test_unknownFields(t *testing.T) {
+	raw := map[string]interface{}{
+		"docs": []interface{}{
+			map[string]interface{}{"id": "1", "coin": 60, "VALUE": "1", "gas": "21000", "operations": []interface{}{
+				map[string]interface{}{"type": "transfer", "contract": "0x"},
+			}},
+		},
+		"extra": map[string]interface{}{"a": map[string]interface{}{"type": "b", "c": 1}},
+		"total": 1,
+	}
+	result := make(map[string]bool)
+	unknownFields(reflect.TypeOf(&driftPage{}), raw, "", result)
+	assert.Equal(t, map[string]bool{
+		".docs[].gas":                   true,
+		".docs[].operations[].contract": true,
+		".extra.*.c":                    true,
+		".total":                        true,
+	}, result)
+}
+
+func Test_missingRequired(t *testing.T) {
+	page := &driftPage{Docs: []driftDoc{
+		{ID: "1", Ops: []driftOp{{Type: "transfer"}}},
+		{Ops: []driftOp{{}}},
+	}}
+	result := make(map[string]bool)
+	missingRequired(reflect.ValueOf(page), "", result)
+	assert.Equal(t, map[string]bool{
+		".docs[].id":                true,
+		".docs[].operations[].type": true,
+	}, result)
+}
@@ -14,4 +14,7 @@ var (
 
 	// ErrInvalidKey signals that the requested key is invalid
 	ErrInvalidKey = errors.New("invalid key")
+
+	// ErrNotSupported signals that the platform doesn't support the requested call
+	ErrNotSupported = errors.New("not supported by the platform")
 )
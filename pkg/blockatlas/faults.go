@@ -0,0 +1,81 @@
+package blockatlas
+
+import (
+	"context"
+	"math/rand"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// HostFaults overrides the faults injected in the requests to an upstream host
+type HostFaults struct {
+	Host string `mapstructure:"host"`
+	// ErrorRate is the share of the requests failing, from 0 to 1
+	ErrorRate float64       `mapstructure:"error_rate"`
+	Latency   time.Duration `mapstructure:"latency"`
+}
+
+// faults delays and fails the requests of every Request to the upstream hosts on purpose,
+// for the resilience tests of the staging instances
+var faults = newFaultInjector()
+
+type faultInjector struct {
+	sync.Mutex
+	latency   time.Duration
+	errorRate float64
+	hosts     map[string]HostFaults
+	random    func() float64
+}
+
+func newFaultInjector() *faultInjector {
+	return &faultInjector{hosts: make(map[string]HostFaults), random: rand.Float64}
+}
+
+// SetFaults delays the upstream requests by a random time up to latency and fails the errorRate share
+// of them without sending them, the hosts can have their own faults. Zero values disable the injection.
+func SetFaults(latency time.Duration, errorRate float64, hosts []HostFaults) {
+	faults.Lock()
+	defer faults.Unlock()
+	faults.latency = latency
+	faults.errorRate = errorRate
+	faults.hosts = make(map[string]HostFaults, len(hosts))
+	for _, h := range hosts {
+		faults.hosts[strings.ToLower(h.Host)] = h
+	}
+}
+
+// inject waits the random latency of the host of the URL and returns the simulated upstream error, if any
+func (f *faultInjector) inject(rawURL string, ctx context.Context) error {
+	latency, errorRate, delay, fail := f.draw(rawURL)
+	if latency > 0 {
+		select {
+		case <-time.After(time.Duration(delay * float64(latency))):
+		case <-ctx.Done():
+			return errors.E(ctx.Err(), "injected upstream latency", errors.Params{"url": rawURL})
+		}
+	}
+	if fail < errorRate {
+		return errors.E("injected upstream fault", errors.Params{"url": rawURL})
+	}
+	return nil
+}
+
+// draw returns the faults of the host of the URL with the random numbers deciding them
+func (f *faultInjector) draw(rawURL string) (latency time.Duration, errorRate, delay, fail float64) {
+	f.Lock()
+	defer f.Unlock()
+	latency, errorRate = f.latency, f.errorRate
+	if u, err := url.Parse(rawURL); err == nil {
+		if h, ok := f.hosts[strings.ToLower(u.Hostname())]; ok {
+			latency, errorRate = h.Latency, h.ErrorRate
+		}
+	}
+	if latency <= 0 && errorRate <= 0 {
+		return 0, 0, 0, 1
+	}
+	return latency, errorRate, f.random(), f.random()
+}
@@ -0,0 +1,40 @@
+package blockatlas
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultInjector(t *testing.T) {
+	f := newFaultInjector()
+	ctx := context.Background()
+	assert.Nil(t, f.inject("https://api.example.com/v1/txs", ctx))
+
+	draws := []float64{0.5, 0.2}
+	f.random = func() float64 {
+		n := draws[0]
+		draws = append(draws[1:], n)
+		return n
+	}
+	f.errorRate = 0.3
+	f.hosts["slow.example.com"] = HostFaults{Host: "slow.example.com", Latency: time.Hour}
+	f.hosts["down.example.com"] = HostFaults{Host: "down.example.com", ErrorRate: 1}
+
+	assert.NotNil(t, f.inject("https://api.example.com/v1/txs", ctx))
+	assert.NotNil(t, f.inject("https://DOWN.example.com", ctx))
+
+	// The latency of a host ends with the context, without error rate of its own
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	start := time.Now()
+	assert.NotNil(t, f.inject("https://slow.example.com", timeout))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	f.random = func() float64 { return 0 }
+	f.hosts["slow.example.com"] = HostFaults{Host: "slow.example.com", Latency: time.Hour}
+	f.errorRate = 0
+	assert.Nil(t, f.inject("https://slow.example.com", ctx))
+}
@@ -0,0 +1,86 @@
+package blockatlas
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// HostConcurrency overrides the concurrency limit of an upstream host
+type HostConcurrency struct {
+	Host string `mapstructure:"host"`
+	Max  int    `mapstructure:"max"`
+}
+
+// hostLimits bounds the requests in flight of every Request to each upstream host,
+// the fan-out of the batch endpoints waits for a slot instead of bursting the upstream
+var hostLimits = newHostLimiter()
+
+type hostLimiter struct {
+	sync.Mutex
+	defaultMax int
+	overrides  map[string]int
+	semaphores map[string]chan struct{}
+}
+
+func newHostLimiter() *hostLimiter {
+	return &hostLimiter{
+		overrides:  make(map[string]int),
+		semaphores: make(map[string]chan struct{}),
+	}
+}
+
+// SetHostConcurrency limits the concurrent requests to each upstream host to max
+// (0 is unlimited), the hosts can have their own limit
+func SetHostConcurrency(max int, hosts []HostConcurrency) {
+	hostLimits.Lock()
+	defer hostLimits.Unlock()
+	hostLimits.defaultMax = max
+	hostLimits.overrides = make(map[string]int, len(hosts))
+	for _, h := range hosts {
+		hostLimits.overrides[strings.ToLower(h.Host)] = h.Max
+	}
+	hostLimits.semaphores = make(map[string]chan struct{})
+}
+
+// acquire waits for a slot of the host of the URL, the returned func releases it
+func (l *hostLimiter) acquire(rawURL string, ctx context.Context) (func(), error) {
+	semaphore := l.semaphore(rawURL)
+	if semaphore == nil {
+		return func() {}, nil
+	}
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, errors.E(ctx.Err(), "upstream host concurrency limit", errors.Params{"url": rawURL})
+	}
+}
+
+func (l *hostLimiter) semaphore(rawURL string) chan struct{} {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+
+	l.Lock()
+	defer l.Unlock()
+	if s, ok := l.semaphores[host]; ok {
+		return s
+	}
+	max, ok := l.overrides[host]
+	if !ok {
+		max = l.defaultMax
+	}
+	if max <= 0 {
+		l.semaphores[host] = nil
+		return nil
+	}
+	s := make(chan struct{}, max)
+	l.semaphores[host] = s
+	return s
+}
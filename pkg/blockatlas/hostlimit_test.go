@@ -0,0 +1,50 @@
+package blockatlas
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostLimiter(t *testing.T) {
+	l := newHostLimiter()
+	l.defaultMax = 2
+	l.overrides["slow.example.com"] = 1
+	l.overrides["free.example.com"] = 0
+	ctx := context.Background()
+
+	var releases []func()
+	for i := 0; i < 2; i++ {
+		release, err := l.acquire("https://api.example.com/v1/txs?address=a", ctx)
+		assert.Nil(t, err)
+		releases = append(releases, release)
+	}
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	_, err := l.acquire("https://API.example.com/v1/blocks", timeout)
+	assert.NotNil(t, err)
+
+	// Other hosts have their own slots
+	release, err := l.acquire("https://slow.example.com", ctx)
+	assert.Nil(t, err)
+	release()
+	for i := 0; i < 10; i++ {
+		_, err := l.acquire("https://free.example.com", ctx)
+		assert.Nil(t, err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		release, err := l.acquire("https://api.example.com", ctx)
+		assert.Nil(t, err)
+		release()
+	}()
+	releases[0]()
+	wg.Wait()
+	releases[1]()
+}
@@ -33,8 +33,9 @@ type (
 	}
 
 	RpcError struct {
-		Code    int    `json:"code"`
-		Message string `json:"message"`
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    interface{} `json:"data,omitempty"`
 	}
 )
 
@@ -0,0 +1,117 @@
+package blockatlas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+var (
+	// ErrKeysExhausted is returned when every key of the pool reached its rate limit
+	ErrKeysExhausted = errors.E("all api keys are rate limited")
+
+	// keyRequests and keyRateLimited follow the usage of the API keys, labelled by KeyLabel
+	keyRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atlas",
+		Name:      "upstream_key_requests_total",
+		Help:      "Upstream requests served by an API key of a key pool.",
+	}, []string{"key"})
+	keyRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atlas",
+		Name:      "upstream_key_rate_limited_total",
+		Help:      "Upstream rejections of an API key of a key pool for its rate limit.",
+	}, []string{"key"})
+)
+
+func init() {
+	prometheus.MustRegister(keyRequests, keyRateLimited)
+}
+
+type (
+	// KeyPool rotates API keys in round-robin, skipping keys that used
+	// their requests of the current second or were rejected by the provider
+	KeyPool struct {
+		sync.Mutex
+		keys     []*apiKey
+		next     int
+		rate     int
+		cooldown time.Duration
+		now      func() time.Time
+	}
+
+	apiKey struct {
+		value        string
+		window       time.Time
+		used         int
+		blockedUntil time.Time
+		label        string
+	}
+)
+
+// NewKeyPool creates a pool allowing `rate` requests per second and key, 0 disables the limit.
+// Keys rejected by the provider are skipped for the cooldown duration.
+func NewKeyPool(keys []string, rate int, cooldown time.Duration) *KeyPool {
+	pool := &KeyPool{rate: rate, cooldown: cooldown, now: time.Now}
+	for _, k := range keys {
+		if k != "" {
+			pool.keys = append(pool.keys, &apiKey{value: k, label: KeyLabel(k)})
+		}
+	}
+	return pool
+}
+
+func (p *KeyPool) Len() int {
+	return len(p.keys)
+}
+
+// Next returns the next key able to serve a request
+func (p *KeyPool) Next() (string, error) {
+	p.Lock()
+	defer p.Unlock()
+	if len(p.keys) == 0 {
+		return "", nil
+	}
+	now := p.now()
+	for i := 0; i < len(p.keys); i++ {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if now.Before(k.blockedUntil) {
+			continue
+		}
+		if now.Sub(k.window) >= time.Second {
+			k.window = now
+			k.used = 0
+		}
+		if p.rate > 0 && k.used >= p.rate {
+			continue
+		}
+		k.used++
+		keyRequests.WithLabelValues(k.label).Inc()
+		p.next = (p.next + i + 1) % len(p.keys)
+		return k.value, nil
+	}
+	return "", ErrKeysExhausted
+}
+
+// RateLimited reports that the provider rejected the key
+func (p *KeyPool) RateLimited(key string) {
+	p.Lock()
+	defer p.Unlock()
+	for _, k := range p.keys {
+		if k.value == key {
+			keyRateLimited.WithLabelValues(k.label).Inc()
+			k.blockedUntil = p.now().Add(p.cooldown)
+			return
+		}
+	}
+}
+
+// KeyLabel identifies an API key in the metrics and logs without revealing any of it: the first
+// 8 hex characters of its SHA-256
+func KeyLabel(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,70 @@
+package blockatlas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyPool_Next(t *testing.T) {
+	now := time.Unix(1580306107, 0)
+	pool := NewKeyPool([]string{"key1", "key2", ""}, 2, time.Minute)
+	pool.now = func() time.Time { return now }
+	assert.Equal(t, 2, pool.Len())
+
+	var keys []string
+	for i := 0; i < 4; i++ {
+		key, err := pool.Next()
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"key1", "key2", "key1", "key2"}, keys)
+
+	_, err := pool.Next()
+	assert.Equal(t, ErrKeysExhausted, err)
+
+	now = now.Add(time.Second)
+	key, err := pool.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "key1", key)
+}
+
+func TestKeyPool_RateLimited(t *testing.T) {
+	now := time.Unix(1580306107, 0)
+	pool := NewKeyPool([]string{"key1-abcd", "key2-efgh"}, 0, time.Minute)
+	pool.now = func() time.Time { return now }
+	requests1 := testutil.ToFloat64(keyRequests.WithLabelValues(KeyLabel("key1-abcd")))
+	requests2 := testutil.ToFloat64(keyRequests.WithLabelValues(KeyLabel("key2-efgh")))
+	rateLimited1 := testutil.ToFloat64(keyRateLimited.WithLabelValues(KeyLabel("key1-abcd")))
+	rateLimited2 := testutil.ToFloat64(keyRateLimited.WithLabelValues(KeyLabel("key2-efgh")))
+
+	pool.RateLimited("key1-abcd")
+	for i := 0; i < 3; i++ {
+		key, err := pool.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, "key2-efgh", key)
+	}
+
+	now = now.Add(time.Minute)
+	key, _ := pool.Next()
+	assert.Equal(t, "key1-abcd", key)
+
+	assert.Equal(t, requests1+1, testutil.ToFloat64(keyRequests.WithLabelValues(KeyLabel("key1-abcd"))))
+	assert.Equal(t, requests2+3, testutil.ToFloat64(keyRequests.WithLabelValues(KeyLabel("key2-efgh"))))
+	assert.Equal(t, rateLimited1+1, testutil.ToFloat64(keyRateLimited.WithLabelValues(KeyLabel("key1-abcd"))))
+	assert.Equal(t, rateLimited2, testutil.ToFloat64(keyRateLimited.WithLabelValues(KeyLabel("key2-efgh"))))
+}
+
+func TestKeyPool_Empty(t *testing.T) {
+	key, err := NewKeyPool(nil, 1, time.Minute).Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "", key)
+}
+
+func TestKeyLabel(t *testing.T) {
+	assert.Equal(t, "7b508dac", KeyLabel("limited"))
+	// The keys sharing a prefix get their own label
+	assert.NotEqual(t, KeyLabel("pk_live_1"), KeyLabel("pk_live_2"))
+}
@@ -0,0 +1,112 @@
+package blockatlas
+
+import (
+	"context"
+	"errors"
+
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// LendingError is the failure of a call to a lending provider. Timeout and Canceled tell the calls
+// stopped by the end of their context apart from the failures of the provider.
+type LendingError struct {
+	Err      error
+	Timeout  bool
+	Canceled bool
+}
+
+func (e *LendingError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LendingError) Unwrap() error {
+	return e.Err
+}
+
+// NewLendingError wraps the error of a lending call made with ctx, nil without error
+func NewLendingError(err error, ctx context.Context) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*LendingError); ok {
+		return e
+	}
+	return &LendingError{
+		Err:      err,
+		Timeout:  ctx.Err() == context.DeadlineExceeded,
+		Canceled: ctx.Err() == context.Canceled,
+	}
+}
+
+// IsLendingTimeout reports whether err is a lending call stopped by the deadline of its context
+func IsLendingTimeout(err error) bool {
+	var e *LendingError
+	return errors.As(err, &e) && e.Timeout
+}
+
+// LegacyLendingAPI is a lending provider written without context, served through AdaptLendingAPI
+type LegacyLendingAPI interface {
+	GetProviderInfo() (types.LendingProvider, error)
+	GetCurrentLendingRates(assets []string) (types.LendingRates, error)
+	GetAccountLendingContracts(req types.AccountRequest) (*[]types.AccountLendingContracts, error)
+}
+
+// AdaptLendingAPI serves a provider without context as a LendingAPI: the calls stop waiting for the
+// provider at the end of their context and its late answers are dropped. The optional LendingEventsAPI,
+// LendingHistoryAPI and LendingQueueAPI are not adapted, they have to take the context.
+func AdaptLendingAPI(api LegacyLendingAPI) LendingAPI {
+	return legacyLendingAPI{api: api}
+}
+
+type legacyLendingAPI struct {
+	api LegacyLendingAPI
+}
+
+func (l legacyLendingAPI) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	value, err := await(ctx, func() (interface{}, error) {
+		return l.api.GetProviderInfo()
+	})
+	if err != nil {
+		return types.LendingProvider{}, err
+	}
+	return value.(types.LendingProvider), nil
+}
+
+func (l legacyLendingAPI) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	value, err := await(ctx, func() (interface{}, error) {
+		return l.api.GetCurrentLendingRates(assets)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(types.LendingRates), nil
+}
+
+func (l legacyLendingAPI) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	value, err := await(ctx, func() (interface{}, error) {
+		return l.api.GetAccountLendingContracts(req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*[]types.AccountLendingContracts), nil
+}
+
+// await runs the call until it answers or the context ends, whichever comes first
+func await(ctx context.Context, call func() (interface{}, error)) (interface{}, error) {
+	type result struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := call()
+		done <- result{value: value, err: err}
+	}()
+	select {
+	case r := <-done:
+		return r.value, NewLendingError(r.err, ctx)
+	case <-ctx.Done():
+		return nil, NewLendingError(ctx.Err(), ctx)
+	}
+}
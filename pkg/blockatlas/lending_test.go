@@ -0,0 +1,77 @@
+package blockatlas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type legacyLendingMock struct {
+	delay time.Duration
+	err   error
+}
+
+func (m legacyLendingMock) GetProviderInfo() (types.LendingProvider, error) {
+	time.Sleep(m.delay)
+	return types.LendingProvider{ID: "legacy"}, m.err
+}
+
+func (m legacyLendingMock) GetCurrentLendingRates(assets []string) (types.LendingRates, error) {
+	time.Sleep(m.delay)
+	return types.LendingRates{{Asset: "DAI", MaxAPY: 3}}, m.err
+}
+
+func (m legacyLendingMock) GetAccountLendingContracts(req types.AccountRequest) (*[]types.AccountLendingContracts, error) {
+	time.Sleep(m.delay)
+	return &[]types.AccountLendingContracts{{Address: req.Addresses[0]}}, m.err
+}
+
+func TestAdaptLendingAPI(t *testing.T) {
+	api := AdaptLendingAPI(legacyLendingMock{})
+	info, err := api.GetProviderInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "legacy", info.ID)
+	rates, err := api.GetCurrentLendingRates([]string{"DAI"}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 3.0, rates[0].MaxAPY)
+	accounts, err := api.GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x1"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "0x1", (*accounts)[0].Address)
+
+	_, err = AdaptLendingAPI(legacyLendingMock{err: ErrSourceConn}).GetProviderInfo(context.Background())
+	var lendingErr *LendingError
+	assert.True(t, errors.As(err, &lendingErr))
+	assert.True(t, errors.Is(err, ErrSourceConn))
+	assert.False(t, lendingErr.Timeout)
+}
+
+func TestAdaptLendingAPI_Deadline(t *testing.T) {
+	api := AdaptLendingAPI(legacyLendingMock{delay: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	start := time.Now()
+	_, err := api.GetCurrentLendingRates(nil, ctx)
+	assert.True(t, IsLendingTimeout(err))
+	assert.Less(t, int64(time.Since(start)), int64(time.Millisecond*500))
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	_, err = api.GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x1"}}, canceled)
+	assert.False(t, IsLendingTimeout(err))
+	assert.True(t, err.(*LendingError).Canceled)
+}
+
+func TestNewLendingError(t *testing.T) {
+	assert.Nil(t, NewLendingError(nil, context.Background()))
+
+	err := NewLendingError(ErrSourceConn, context.Background())
+	assert.Equal(t, ErrSourceConn.Error(), err.Error())
+	assert.False(t, IsLendingTimeout(err))
+	assert.Equal(t, err, NewLendingError(err, context.Background()), "not wrapped twice")
+	assert.False(t, IsLendingTimeout(ErrSourceConn))
+}
@@ -0,0 +1,137 @@
+package blockatlas
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	// DefaultPageLimit is the size of the pages of the lists without ?limit=, the transactions
+	// default to TxPerPage
+	DefaultPageLimit = 100
+	MaxPageLimit     = 500
+)
+
+// pendingBlock orders the pending transactions, of block 0, before the mined ones in the cursors
+const pendingBlock = math.MaxUint64
+
+// ErrInvalidPage signals an unknown next_page cursor or a limit out of range
+var ErrInvalidPage = errors.New("invalid page cursor or limit")
+
+type (
+	// Page is the position of a request in a list, carried from one page to the next by the
+	// opaque next_page cursor
+	Page struct {
+		Limit int `json:"l"`
+		// Offset is the count of items on the previous pages
+		Offset int `json:"o,omitempty"`
+		// Block and Skip are the block-based cursor of the transactions: the page starts after the
+		// Skip first transactions of Block, the newer blocks being on the previous pages
+		Block uint64 `json:"b,omitempty"`
+		Skip  int    `json:"s,omitempty"`
+	}
+
+	// PageResponse is a page of a list endpoint, NextPage is the cursor of the following page,
+	// empty on the last one
+	PageResponse struct {
+		Total    int         `json:"total"`
+		Docs     interface{} `json:"docs"`
+		Status   bool        `json:"status"`
+		NextPage string      `json:"next_page,omitempty"`
+	}
+)
+
+// ParsePage returns the page of the ?cursor= and ?limit= of a request, the first page of defaultLimit
+// items without them. The limit of the request replaces the one of the cursor.
+func ParsePage(cursor, limit string, defaultLimit int) (Page, error) {
+	page := Page{Limit: defaultLimit}
+	if cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return Page{}, ErrInvalidPage
+		}
+		if err := json.Unmarshal(raw, &page); err != nil || page.Offset < 0 || page.Skip < 0 {
+			return Page{}, ErrInvalidPage
+		}
+	}
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return Page{}, ErrInvalidPage
+		}
+		page.Limit = n
+	}
+	if page.Limit < 1 || page.Limit > MaxPageLimit {
+		return Page{}, ErrInvalidPage
+	}
+	return page, nil
+}
+
+// Cursor encodes the page as the opaque next_page value
+func (p Page) Cursor() string {
+	raw, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Bounds returns the indexes of the items of the page in a list of total items,
+// with the cursor of the next page, empty if it is the last one
+func (p Page) Bounds(total int) (start, end int, next string) {
+	start = p.Offset
+	if start > total {
+		start = total
+	}
+	end = start + p.Limit
+	if end >= total {
+		return start, total, ""
+	}
+	return start, end, Page{Limit: p.Limit, Offset: end}.Cursor()
+}
+
+// Txs returns the transactions of the page, sorted by descending block, with the block-based cursor
+// of the next page. New transactions of the head don't shift the next pages.
+func (p Page) Txs(txs types.TxPage) (types.TxPage, string) {
+	page := make(types.TxPage, 0, p.Limit)
+	var (
+		last    uint64
+		skipped int
+	)
+	for i, tx := range txs {
+		block := cursorBlock(tx)
+		if p.Block != 0 && block > p.Block {
+			continue
+		}
+		if block == p.Block && skipped < p.Skip {
+			skipped++
+			continue
+		}
+		if len(page) == p.Limit {
+			return page, p.nextTxs(last, txs[:i])
+		}
+		page = append(page, tx)
+		last = block
+	}
+	return page, ""
+}
+
+// nextTxs is the cursor after the last block of the page, skipping its transactions already served
+func (p Page) nextTxs(last uint64, served types.TxPage) string {
+	next := Page{Limit: p.Limit, Block: last}
+	for _, tx := range served {
+		if cursorBlock(tx) == last {
+			next.Skip++
+		}
+	}
+	return next.Cursor()
+}
+
+func cursorBlock(tx types.Tx) uint64 {
+	if tx.Block == 0 {
+		return pendingBlock
+	}
+	return tx.Block
+}
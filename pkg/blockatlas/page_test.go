@@ -0,0 +1,72 @@
+package blockatlas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestParsePage(t *testing.T) {
+	page, err := ParsePage("", "", 25)
+	assert.Nil(t, err)
+	assert.Equal(t, Page{Limit: 25}, page)
+
+	page, err = ParsePage(Page{Limit: 10, Offset: 20}.Cursor(), "", 25)
+	assert.Nil(t, err)
+	assert.Equal(t, Page{Limit: 10, Offset: 20}, page)
+
+	page, err = ParsePage(Page{Limit: 10, Block: 7, Skip: 1}.Cursor(), "5", 25)
+	assert.Nil(t, err)
+	assert.Equal(t, Page{Limit: 5, Block: 7, Skip: 1}, page)
+
+	for _, tt := range [][2]string{{"not a cursor", ""}, {"", "0"}, {"", "501"}, {"", "ten"}, {Page{Limit: 1, Offset: -1}.Cursor(), ""}} {
+		_, err := ParsePage(tt[0], tt[1], 25)
+		assert.Equal(t, ErrInvalidPage, err, tt)
+	}
+}
+
+func TestPage_Bounds(t *testing.T) {
+	start, end, next := Page{Limit: 2}.Bounds(5)
+	assert.Equal(t, [2]int{0, 2}, [2]int{start, end})
+	page, err := ParsePage(next, "", 25)
+	assert.Nil(t, err)
+	assert.Equal(t, Page{Limit: 2, Offset: 2}, page)
+
+	start, end, next = Page{Limit: 2, Offset: 4}.Bounds(5)
+	assert.Equal(t, [2]int{4, 5}, [2]int{start, end})
+	assert.Empty(t, next)
+
+	start, end, next = Page{Limit: 2, Offset: 8}.Bounds(5)
+	assert.Equal(t, [2]int{5, 5}, [2]int{start, end})
+	assert.Empty(t, next)
+}
+
+func TestPage_Txs(t *testing.T) {
+	txs := types.TxPage{{ID: "pending", Block: 0}, {ID: "a", Block: 9}, {ID: "b", Block: 9}, {ID: "c", Block: 9}, {ID: "d", Block: 5}}
+	ids := func(page types.TxPage) []string {
+		result := make([]string, 0)
+		for _, tx := range page {
+			result = append(result, tx.ID)
+		}
+		return result
+	}
+
+	page, next := Page{Limit: 3}.Txs(txs)
+	assert.Equal(t, []string{"pending", "a", "b"}, ids(page))
+	cursor, err := ParsePage(next, "", 25)
+	assert.Nil(t, err)
+	assert.Equal(t, Page{Limit: 3, Block: 9, Skip: 2}, cursor)
+
+	// A new transaction of the head doesn't shift the next page
+	txs = append(types.TxPage{txs[0], {ID: "new", Block: 10}}, txs[1:]...)
+	page, next = cursor.Txs(txs)
+	assert.Equal(t, []string{"c", "d"}, ids(page))
+	assert.Empty(t, next)
+
+	page, next = Page{Limit: 1}.Txs(txs)
+	assert.Equal(t, []string{"pending"}, ids(page))
+	cursor, _ = ParsePage(next, "", 25)
+	page, _ = cursor.Txs(txs)
+	assert.Equal(t, []string{"new"}, ids(page))
+}
@@ -1,7 +1,10 @@
 package blockatlas
 
 import (
+	"context"
+
 	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
 )
 
 type (
@@ -23,6 +26,18 @@ type (
 		GetTxsByAddress(address string) (TxPage, error)
 	}
 
+	// TxLookupAPI provides a transaction by its ID
+	TxLookupAPI interface {
+		Platform
+		GetTx(id string) (*Tx, error)
+	}
+
+	// UtxoAPI provides the unspent outputs of an address (Bitcoin-style)
+	UtxoAPI interface {
+		Platform
+		GetUtxos(address string) ([]types.Utxo, error)
+	}
+
 	// TokenTxAPI provides token transaction lookups
 	TokenTxAPI interface {
 		Platform
@@ -35,12 +50,26 @@ type (
 		GetTxsByXpub(xpub string) (TxPage, error)
 	}
 
+	// XpubAPI derives the used addresses of an extended public key or an output descriptor,
+	// with the unused ones within the gap limit (Bitcoin-style)
+	XpubAPI interface {
+		Platform
+		GetAddressesFromXpub(xpub string) ([]string, error)
+	}
+
 	// TokensAPI provides token lookups
 	TokensAPI interface {
 		Platform
 		GetTokenListByAddress(address string) (TokenPage, error)
 	}
 
+	// BalanceAPI provides the current balance of an address in the smallest unit, of the token
+	// with a token ID, of the native coin otherwise
+	BalanceAPI interface {
+		Platform
+		GetBalance(address, token string) (string, error)
+	}
+
 	// StakingAPI provides staking information
 	StakeAPI interface {
 		Platform
@@ -51,6 +80,50 @@ type (
 		GetActiveValidators() (StakeValidators, error)
 	}
 
+	// StakeRewardsAPI provides the staking rewards of an address not withdrawn yet, in the smallest unit
+	StakeRewardsAPI interface {
+		StakeAPI
+		GetPendingRewards(address string) (string, error)
+	}
+
+	// FeeAPI provides the current network fee estimates, in the smallest unit of the coin by priority
+	FeeAPI interface {
+		Platform
+		GetFeeEstimates() (types.FeeEstimates, error)
+	}
+
+	// BroadcastAPI submits signed transactions, returning their hash
+	BroadcastAPI interface {
+		Platform
+		Broadcast(rawTx string) (string, error)
+	}
+
+	// SimulationAPI dry-runs unsigned transactions, returning their balance changes and token transfers
+	SimulationAPI interface {
+		Platform
+		Simulate(tx types.SimulationRequest) (types.Simulation, error)
+	}
+
+	// MempoolAPI provides the transactions of an address not in a block yet
+	MempoolAPI interface {
+		Platform
+		GetPendingTxsByAddress(address string) (TxPage, error)
+	}
+
+	// BridgeMessageAPI provides the bridge message sent or delivered by a transaction of a bridge_transfer
+	// event, nil for the transactions without a message of a known protocol
+	BridgeMessageAPI interface {
+		Platform
+		GetBridgeMessage(tx types.Tx) (*BridgeMessage, error)
+	}
+
+	// BridgeMessage is the message of a bridge transfer, Delivered by its destination transaction
+	BridgeMessage struct {
+		Protocol  string
+		ID        string
+		Delivered bool
+	}
+
 	CollectionsAPI interface {
 		Platform
 		GetCollections(owner string) (CollectionPage, error)
@@ -65,6 +138,33 @@ type (
 		Lookup(coins []uint64, name string) ([]Resolved, error)
 	}
 
+	// LendingAPI provides the markets and the account positions of a lending provider. The calls stop at the
+	// end of the context and fail with a *LendingError, see AdaptLendingAPI for the providers without context.
+	LendingAPI interface {
+		GetProviderInfo(ctx context.Context) (types.LendingProvider, error)
+		GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error)
+		GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error)
+	}
+
+	// LendingEventsAPI provides the deposits and withdrawals of an address, the principal of its contracts
+	LendingEventsAPI interface {
+		LendingAPI
+		GetAccountLendingEvents(address string, ctx context.Context) ([]types.LendingEvent, error)
+	}
+
+	// LendingHistoryAPI provides the history needed to reconstruct the earnings of an account,
+	// for providers not reporting the earned amounts
+	LendingHistoryAPI interface {
+		LendingEventsAPI
+		GetLendingRateHistory(asset string, from, to int64, ctx context.Context) ([]types.LendingRatePoint, error)
+	}
+
+	// LendingQueueAPI provides the exit queue of the providers whose withdrawals wait, e.g. liquid staking
+	LendingQueueAPI interface {
+		LendingAPI
+		GetWithdrawalQueue(asset string, ctx context.Context) (types.WithdrawalQueue, error)
+	}
+
 	Platforms map[string]Platform
 
 	CollectionsAPIs map[uint]CollectionsAPI
@@ -0,0 +1,106 @@
+package blockatlas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+	// CacheNone is the status of the sections served without a cache
+	CacheNone = "none"
+)
+
+type (
+	// Source is where a section of a response comes from, to debug the data discrepancies reported by users
+	Source struct {
+		Section string `json:"section"`
+		// Upstream is the host of the node or explorer, Node the hash of its URL keeping the credentials out
+		Upstream  string    `json:"upstream,omitempty"`
+		Node      string    `json:"node,omitempty"`
+		FetchedAt time.Time `json:"fetched_at"`
+		Cache     string    `json:"cache"`
+	}
+
+	// Provenance collects the sources of a response, requested with ?provenance=true
+	Provenance struct {
+		sync.Mutex
+		sources []Source
+	}
+
+	provenanceKey struct{}
+)
+
+// WithProvenance returns the context recording the upstream requests made with it
+func WithProvenance(ctx context.Context) (context.Context, *Provenance) {
+	p := &Provenance{}
+	return context.WithValue(ctx, provenanceKey{}, p), p
+}
+
+// ProvenanceFrom returns the provenance of the context, nil when it was not requested
+func ProvenanceFrom(ctx context.Context) *Provenance {
+	p, _ := ctx.Value(provenanceKey{}).(*Provenance)
+	return p
+}
+
+// UpstreamSource returns the source of a response fetched now from the URL
+func UpstreamSource(rawURL string) Source {
+	s := Source{Node: NodeHash(rawURL), FetchedAt: time.Now()}
+	if u, err := url.Parse(rawURL); err == nil {
+		s.Upstream = u.Hostname()
+	}
+	return s
+}
+
+// NodeHash returns a short hash of the URL of a node, telling the nodes apart without revealing their keys
+func NodeHash(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(rawURL))
+	return hex.EncodeToString(sum[:6])
+}
+
+// Record adds the sources, a nil provenance records nothing
+func (p *Provenance) Record(sources ...Source) {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.sources = append(p.sources, sources...)
+}
+
+// Cached sets the cache status of the sources without one, or records a source fetched at fetchedAt
+// when none was recorded
+func (p *Provenance) Cached(status string, fetchedAt time.Time) {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	if len(p.sources) == 0 {
+		p.sources = append(p.sources, Source{FetchedAt: fetchedAt, Cache: status})
+		return
+	}
+	for i := range p.sources {
+		if p.sources[i].Cache == "" {
+			p.sources[i].Cache = status
+		}
+	}
+}
+
+// Sources returns a copy of the recorded sources
+func (p *Provenance) Sources() []Source {
+	if p == nil {
+		return nil
+	}
+	p.Lock()
+	defer p.Unlock()
+	return append([]Source(nil), p.sources...)
+}
@@ -0,0 +1,59 @@
+package blockatlas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenance_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := InitJSONClient(server.URL)
+
+	var result map[string]interface{}
+	assert.Nil(t, client.Get(&result, "", nil))
+
+	ctx, provenance := WithProvenance(context.Background())
+	assert.Equal(t, provenance, ProvenanceFrom(ctx))
+	assert.Nil(t, client.GetWithContext(&result, "blocks", nil, ctx))
+
+	sources := provenance.Sources()
+	if assert.Len(t, sources, 1) {
+		assert.Equal(t, "127.0.0.1", sources[0].Upstream)
+		assert.Equal(t, NodeHash(server.URL), sources[0].Node)
+		assert.Empty(t, sources[0].Cache)
+	}
+}
+
+func TestProvenance_Cached(t *testing.T) {
+	var none *Provenance
+	none.Record(Source{})
+	none.Cached(CacheHit, time.Now())
+	assert.Nil(t, none.Sources())
+	assert.Nil(t, ProvenanceFrom(context.Background()))
+
+	fetchedAt := time.Unix(1600000000, 0)
+	_, p := WithProvenance(context.Background())
+	p.Cached(CacheHit, fetchedAt)
+	assert.Equal(t, []Source{{FetchedAt: fetchedAt, Cache: CacheHit}}, p.Sources())
+
+	_, p = WithProvenance(context.Background())
+	p.Record(Source{Upstream: "a", Cache: CacheNone}, Source{Upstream: "b"})
+	p.Cached(CacheMiss, fetchedAt)
+	sources := p.Sources()
+	assert.Equal(t, CacheNone, sources[0].Cache)
+	assert.Equal(t, CacheMiss, sources[1].Cache)
+}
+
+func TestNodeHash(t *testing.T) {
+	assert.Empty(t, NodeHash(""))
+	assert.Len(t, NodeHash("https://node.example.com?key=secret"), 12)
+	assert.NotEqual(t, NodeHash("https://a.example.com"), NodeHash("https://b.example.com"))
+}
@@ -0,0 +1,147 @@
+package blockatlas
+
+import "github.com/trustwallet/blockatlas/pkg/types"
+
+// The public models used to live in this package and were moved to pkg/types,
+// which follows semantic versioning. The aliases below keep existing imports
+// compiling; new code should import pkg/types directly.
+
+// Deprecated: models from pkg/types/tx.go, use the types package instead.
+type (
+	Direction             = types.Direction
+	Status                = types.Status
+	TokenType             = types.TokenType
+	TransactionType       = types.TransactionType
+	KeyType               = types.KeyType
+	KeyTitle              = types.KeyTitle
+	Block                 = types.Block
+	TxPage                = types.TxPage
+	Amount                = types.Amount
+	Tx                    = types.Tx
+	TxOutput              = types.TxOutput
+	Utxo                  = types.Utxo
+	Transfer              = types.Transfer
+	NativeTokenTransfer   = types.NativeTokenTransfer
+	TokenTransfer         = types.TokenTransfer
+	CollectibleTransfer   = types.CollectibleTransfer
+	TokenSwap             = types.TokenSwap
+	ContractCall          = types.ContractCall
+	Currency              = types.Currency
+	MultiCurrencyTransfer = types.MultiCurrencyTransfer
+	AnyAction             = types.AnyAction
+	TokenPage             = types.TokenPage
+	Token                 = types.Token
+	Txs                   = types.Txs
+)
+
+// Deprecated: use the constants from the types package instead.
+const (
+	StatusCompleted         = types.StatusCompleted
+	StatusPending           = types.StatusPending
+	StatusError             = types.StatusError
+	DirectionOutgoing       = types.DirectionOutgoing
+	DirectionIncoming       = types.DirectionIncoming
+	DirectionSelf           = types.DirectionSelf
+	TokenTypeERC20          = types.TokenTypeERC20
+	TokenTypeBEP2           = types.TokenTypeBEP2
+	TokenTypeBEP8           = types.TokenTypeBEP8
+	TokenTypeTRC10          = types.TokenTypeTRC10
+	TokenTypeETC20          = types.TokenTypeETC20
+	TokenTypePOA20          = types.TokenTypePOA20
+	TokenTypeTRC20          = types.TokenTypeTRC20
+	TokenTypeCLO20          = types.TokenTypeCLO20
+	TokenTypeGO20           = types.TokenTypeGO20
+	TokenTypeWAN20          = types.TokenTypeWAN20
+	TokenTypeTT20           = types.TokenTypeTT20
+	TxTransfer              = types.TxTransfer
+	TxNativeTokenTransfer   = types.TxNativeTokenTransfer
+	TxTokenTransfer         = types.TxTokenTransfer
+	TxCollectibleTransfer   = types.TxCollectibleTransfer
+	TxTokenSwap             = types.TxTokenSwap
+	TxContractCall          = types.TxContractCall
+	TxAnyAction             = types.TxAnyAction
+	TxMultiCurrencyTransfer = types.TxMultiCurrencyTransfer
+	KeyPlaceOrder           = types.KeyPlaceOrder
+	KeyCancelOrder          = types.KeyCancelOrder
+	KeyIssueToken           = types.KeyIssueToken
+	KeyBurnToken            = types.KeyBurnToken
+	KeyMintToken            = types.KeyMintToken
+	KeyApproveToken         = types.KeyApproveToken
+	KeyStakeDelegate        = types.KeyStakeDelegate
+	KeyStakeClaimRewards    = types.KeyStakeClaimRewards
+	KeyTitlePlaceOrder      = types.KeyTitlePlaceOrder
+	KeyTitleCancelOrder     = types.KeyTitleCancelOrder
+	AnyActionDelegation     = types.AnyActionDelegation
+	AnyActionUndelegation   = types.AnyActionUndelegation
+	AnyActionClaimRewards   = types.AnyActionClaimRewards
+	TxPerPage               = types.TxPerPage
+)
+
+// Deprecated: models from pkg/types/staking.go, use the types package instead.
+type (
+	ValidatorPage             = types.ValidatorPage
+	DelegationsPage           = types.DelegationsPage
+	DelegationsBatchPage      = types.DelegationsBatchPage
+	StakingBatchPage          = types.StakingBatchPage
+	StakeValidators           = types.StakeValidators
+	DelegationStatus          = types.DelegationStatus
+	DelegationType            = types.DelegationType
+	ValidatorMap              = types.ValidatorMap
+	StakingReward             = types.StakingReward
+	StakingDetails            = types.StakingDetails
+	Validator                 = types.Validator
+	Delegation                = types.Delegation
+	DelegationMetaDataPending = types.DelegationMetaDataPending
+	StakeValidatorInfo        = types.StakeValidatorInfo
+	StakeValidator            = types.StakeValidator
+	DelegationResponse        = types.DelegationResponse
+	StakingResponse           = types.StakingResponse
+)
+
+// Deprecated: use the constants from the types package instead.
+const (
+	DelegationStatusActive  = types.DelegationStatusActive
+	DelegationStatusPending = types.DelegationStatusPending
+	DelegationTypeAuto      = types.DelegationTypeAuto
+	DelegationTypeDelegate  = types.DelegationTypeDelegate
+	DefaultAnnualReward     = types.DefaultAnnualReward
+)
+
+// Deprecated: models from pkg/types/collectibles.go, use the types package instead.
+type (
+	CollectionV3      = types.CollectionV3
+	Collection        = types.Collection
+	CollectionPageV3  = types.CollectionPageV3
+	CollectionPage    = types.CollectionPage
+	Collectible       = types.Collectible
+	CollectiblePage   = types.CollectiblePage
+	CollectibleV3     = types.CollectibleV3
+	CollectiblePageV3 = types.CollectiblePageV3
+)
+
+// Deprecated: models from pkg/types/observer.go, use the types package instead.
+type (
+	Subscriptions         = types.Subscriptions
+	SubscriptionOperation = types.SubscriptionOperation
+	SubscriptionEvent     = types.SubscriptionEvent
+	Subscription          = types.Subscription
+	CoinStatus            = types.CoinStatus
+	Observer              = types.Observer
+)
+
+// Deprecated: models from pkg/types/models.go, use the types package instead.
+type (
+	DocsResponse    = types.DocsResponse
+	ResultsResponse = types.ResultsResponse
+)
+
+// Deprecated: models from pkg/types/naming.go, use the types package instead.
+type (
+	Resolved = types.Resolved
+)
+
+// Deprecated: use types.InferDirection instead.
+var InferDirection = types.InferDirection
+
+// Deprecated: use types.InferValue instead.
+var InferValue = types.InferValue
@@ -0,0 +1,230 @@
+// Package secrets encrypts the secrets stored by the services with envelope encryption:
+// every secret is encrypted with its own data key, wrapped by a master key of the keyring.
+// Rotating the master key only rewraps the data keys.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const (
+	// prefix marks the encrypted values, followed by the master key ID, the wrapped data key and the ciphertext
+	prefix      = "enc:v1:"
+	dataKeySize = 32
+)
+
+var encoding = base64.RawURLEncoding
+
+type (
+	// MasterKey wraps and unwraps the data keys, a key of the config or of a KMS
+	MasterKey interface {
+		Wrap(dataKey []byte) ([]byte, error)
+		Unwrap(wrapped []byte) ([]byte, error)
+	}
+
+	// Keyring encrypts with its current master key and decrypts with any of its keys
+	Keyring struct {
+		current string
+		keys    map[string]MasterKey
+	}
+
+	aesKey struct {
+		aead cipher.AEAD
+	}
+)
+
+// NewAESKey returns a master key wrapping the data keys with AES-256-GCM
+func NewAESKey(key []byte) (MasterKey, error) {
+	if len(key) != 32 {
+		return nil, errors.E("master key must be 32 bytes", errors.Params{"length": len(key)})
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &aesKey{aead: aead}, nil
+}
+
+func (k *aesKey) Wrap(dataKey []byte) ([]byte, error) { return seal(k.aead, dataKey) }
+
+func (k *aesKey) Unwrap(wrapped []byte) ([]byte, error) { return open(k.aead, wrapped) }
+
+// NewKeyring encrypts with the current key, the others only decrypt the values not rotated yet
+func NewKeyring(current string, keys map[string]MasterKey) (*Keyring, error) {
+	for id := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, errors.E("invalid master key ID", errors.Params{"id": id})
+		}
+	}
+	if _, ok := keys[current]; !ok {
+		return nil, errors.E("unknown current master key", errors.Params{"id": current})
+	}
+	return &Keyring{current: current, keys: keys}, nil
+}
+
+// ParseKeys decodes the base64 AES-256 master keys of the config by ID
+func ParseKeys(encoded map[string]string) (map[string]MasterKey, error) {
+	keys := make(map[string]MasterKey, len(encoded))
+	for id, value := range encoded {
+		raw, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, errors.E(err, "invalid master key", errors.Params{"id": id})
+		}
+		key, err := NewAESKey(raw)
+		if err != nil {
+			return nil, errors.E(err, errors.Params{"id": id})
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// Current returns the ID of the master key encrypting the new values
+func (k *Keyring) Current() string {
+	return k.current
+}
+
+// Encrypt encrypts the value with a new data key wrapped by the current master key
+func (k *Keyring) Encrypt(value string) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(aead, []byte(value))
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := k.keys[k.current].Wrap(dataKey)
+	if err != nil {
+		return "", err
+	}
+	return format(k.current, wrapped, ciphertext), nil
+}
+
+// Decrypt returns the plaintext of an encrypted value, the values stored before the encryption as they are
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	id, wrapped, ciphertext, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := k.unwrap(id, wrapped)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, ciphertext)
+	if err != nil {
+		return "", errors.E(err, "failed to decrypt the value", errors.Params{"key": id})
+	}
+	return string(plaintext), nil
+}
+
+// Rotate rewraps the data key of the value with the current master key, encrypting the plaintext values.
+// It reports whether the value changed.
+func (k *Keyring) Rotate(value string) (string, bool, error) {
+	if !IsEncrypted(value) {
+		encrypted, err := k.Encrypt(value)
+		return encrypted, err == nil, err
+	}
+	id, wrapped, ciphertext, err := parse(value)
+	if err != nil {
+		return "", false, err
+	}
+	if id == k.current {
+		return value, false, nil
+	}
+	dataKey, err := k.unwrap(id, wrapped)
+	if err != nil {
+		return "", false, err
+	}
+	rewrapped, err := k.keys[k.current].Wrap(dataKey)
+	if err != nil {
+		return "", false, err
+	}
+	return format(k.current, rewrapped, ciphertext), true, nil
+}
+
+func (k *Keyring) unwrap(id string, wrapped []byte) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, errors.E("unknown master key", errors.Params{"key": id})
+	}
+	dataKey, err := key.Unwrap(wrapped)
+	if err != nil {
+		return nil, errors.E(err, "failed to unwrap the data key", errors.Params{"key": id})
+	}
+	return dataKey, nil
+}
+
+// IsEncrypted reports whether the value was encrypted by a keyring
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Hash returns the hex SHA-256 of the value, to look up the encrypted values by their plaintext
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func format(id string, wrapped, ciphertext []byte) string {
+	return prefix + id + ":" + encoding.EncodeToString(wrapped) + ":" + encoding.EncodeToString(ciphertext)
+}
+
+func parse(value string) (id string, wrapped, ciphertext []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", nil, nil, errors.E("malformed encrypted value")
+	}
+	if wrapped, err = encoding.DecodeString(parts[1]); err != nil {
+		return "", nil, nil, errors.E(err, "malformed encrypted value")
+	}
+	if ciphertext, err = encoding.DecodeString(parts[2]); err != nil {
+		return "", nil, nil, errors.E(err, "malformed encrypted value")
+	}
+	return parts[0], wrapped, ciphertext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal prepends the random nonce to the ciphertext
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.E("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,97 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func keyring(t *testing.T, current string, ids ...string) *Keyring {
+	encoded := make(map[string]string, len(ids))
+	for i, id := range ids {
+		encoded[id] = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{byte(i + 1)}, 32))
+	}
+	keys, err := ParseKeys(encoded)
+	assert.Nil(t, err)
+	k, err := NewKeyring(current, keys)
+	assert.Nil(t, err)
+	return k
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	k := keyring(t, "2020-07", "2020-07")
+	token := "https://hooks.slack.com/services/T000/B000/XXXX"
+
+	encrypted, err := k.Encrypt(token)
+	assert.Nil(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "hooks.slack.com")
+
+	// Every value has its own data key and nonce
+	again, err := k.Encrypt(token)
+	assert.Nil(t, err)
+	assert.NotEqual(t, encrypted, again)
+
+	decrypted, err := k.Decrypt(encrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, token, decrypted)
+
+	// The values stored before the encryption are read as they are
+	plain, err := k.Decrypt("fcm-token")
+	assert.Nil(t, err)
+	assert.Equal(t, "fcm-token", plain)
+
+	_, err = k.Decrypt(encrypted[:len(encrypted)-4] + "AAAA")
+	assert.NotNil(t, err)
+	_, err = k.Decrypt("enc:v1:2020-07:malformed")
+	assert.NotNil(t, err)
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	old := keyring(t, "old", "old")
+	encrypted, err := old.Encrypt("fcm-token")
+	assert.Nil(t, err)
+
+	k := keyring(t, "new", "old", "new")
+	rotated, changed, err := k.Rotate(encrypted)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Contains(t, rotated, "enc:v1:new:")
+	decrypted, err := k.Decrypt(rotated)
+	assert.Nil(t, err)
+	assert.Equal(t, "fcm-token", decrypted)
+
+	// Already under the current key
+	_, changed, err = k.Rotate(rotated)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+
+	// Plaintext values are encrypted
+	encryptedPlain, changed, err := k.Rotate("chat-id")
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.True(t, IsEncrypted(encryptedPlain))
+
+	// The old key is gone once every value is rotated
+	_, err = keyring(t, "new", "new").Decrypt(encrypted)
+	assert.NotNil(t, err)
+}
+
+func TestNewKeyring(t *testing.T) {
+	keys, err := ParseKeys(map[string]string{"a": base64.StdEncoding.EncodeToString(make([]byte, 32))})
+	assert.Nil(t, err)
+	_, err = NewKeyring("b", keys)
+	assert.NotNil(t, err)
+
+	_, err = ParseKeys(map[string]string{"short": base64.StdEncoding.EncodeToString(make([]byte, 16))})
+	assert.NotNil(t, err)
+	_, err = ParseKeys(map[string]string{"invalid": "not base64!"})
+	assert.NotNil(t, err)
+}
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Hash(""))
+	assert.Len(t, Hash("fcm-token"), 64)
+}
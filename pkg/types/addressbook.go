@@ -0,0 +1,26 @@
+package types
+
+type (
+	// AddressBookEntry is a labeled contact synced between the devices of a user
+	AddressBookEntry struct {
+		ID        uint   `json:"id"`
+		Coin      uint   `json:"coin"`
+		Address   string `json:"address"`
+		Label     string `json:"label"`
+		Memo      string `json:"memo,omitempty"`
+		UpdatedAt int64  `json:"updated_at"`
+		Deleted   bool   `json:"deleted,omitempty"`
+	}
+
+	// AddressBookPage lists the entries changed since the requested time,
+	// Timestamp is the value to send as `since` on the next sync
+	AddressBookPage struct {
+		Docs      []AddressBookEntry `json:"docs"`
+		Timestamp int64              `json:"timestamp"`
+	}
+
+	// AddressBookToken authenticates a device, it is only returned on creation
+	AddressBookToken struct {
+		Token string `json:"token"`
+	}
+)
@@ -1,4 +1,4 @@
-package blockatlas
+package types
 
 type (
 	CollectionV3 struct {
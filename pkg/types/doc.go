@@ -0,0 +1,10 @@
+// Package types contains the public Block Atlas models (transactions, tokens,
+// staking, collectibles and observer payloads) shared by the API, the observer
+// services and external Go consumers.
+//
+// The package is its own module, github.com/trustwallet/blockatlas/pkg/types,
+// released with the pkg/types/vX.Y.Z tags independently from the rest of the
+// repository, and depends on none of its packages. Within a major version
+// fields and methods are only ever added, never renamed or removed. Breaking
+// changes bump the major version, with the /vN suffix of the module path.
+package types
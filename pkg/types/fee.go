@@ -0,0 +1,42 @@
+package types
+
+const (
+	FeePriorityFast   FeePriority = "fast"
+	FeePriorityNormal FeePriority = "normal"
+	FeePrioritySlow   FeePriority = "slow"
+
+	// FeeSourceMempool are the rates read from the mempool histogram, FeeSourceNode the estimates of the node
+	FeeSourceMempool FeeSource = "mempool"
+	FeeSourceNode    FeeSource = "node"
+)
+
+type (
+	FeePriority string
+	FeeSource   string
+
+	// FeeHistogramBucket is the virtual size of the mempool transactions paying at least Rate, down to the
+	// rate of the next bucket. The histogram is ordered by decreasing rate.
+	FeeHistogramBucket struct {
+		Rate  float64 `json:"rate"`
+		VSize int64   `json:"vsize"`
+	}
+
+	FeeHistogram []FeeHistogramBucket
+
+	// FeeEstimate is the rate to pay for a priority, in the smallest unit of the coin per virtual byte.
+	// Percentile is the share of the mempool paying more, ConfirmationTime the expected wait in seconds.
+	FeeEstimate struct {
+		Priority         FeePriority `json:"priority"`
+		Rate             int64       `json:"rate"`
+		Percentile       float64     `json:"percentile,omitempty"`
+		Blocks           int         `json:"blocks"`
+		ConfirmationTime int64       `json:"confirmation_time"`
+	}
+
+	FeeEstimates struct {
+		Coin      uint          `json:"coin"`
+		Source    FeeSource     `json:"source"`
+		Estimates []FeeEstimate `json:"estimates"`
+		Histogram FeeHistogram  `json:"histogram,omitempty"`
+	}
+)
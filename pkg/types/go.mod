@@ -0,0 +1,8 @@
+module github.com/trustwallet/blockatlas/pkg/types
+
+go 1.16
+
+require (
+	github.com/deckarep/golang-set v1.7.1
+	github.com/stretchr/testify v1.6.1
+)
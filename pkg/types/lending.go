@@ -0,0 +1,369 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	ProviderTypeLending ProviderType = "lending"
+	ProviderTypeStaking ProviderType = "staking"
+	// ProviderTypeYield are the vaults deploying the deposits to the strategies of other protocols
+	ProviderTypeYield ProviderType = "yield"
+
+	LendingDeposit  LendingEventType = "deposit"
+	LendingWithdraw LendingEventType = "withdraw"
+
+	MarketActive     MarketStatus = "active"
+	MarketPaused     MarketStatus = "paused"
+	MarketFrozen     MarketStatus = "frozen"
+	MarketDeprecated MarketStatus = "deprecated"
+
+	RiskLow    RiskTier = "low"
+	RiskMedium RiskTier = "medium"
+	RiskHigh   RiskTier = "high"
+
+	WithdrawalInstant WithdrawalType = "instant"
+	WithdrawalQueued  WithdrawalType = "queued"
+)
+
+type (
+	ProviderType     string
+	LendingEventType string
+
+	// MarketStatus is the state of the market of an asset at a provider: paused markets reject every operation,
+	// frozen ones only new deposits and borrows, deprecated ones are being retired. Empty is active.
+	MarketStatus string
+
+	WithdrawalType string
+
+	// RiskTier is the assessment of a provider: its audits, track record and custody of the deposits
+	RiskTier string
+
+	// LendingProvider describes a lending protocol and the assets it accepts
+	LendingProvider struct {
+		ID     string       `json:"id"`
+		Info   ProviderInfo `json:"info"`
+		Type   ProviderType `json:"type"`
+		Assets []AssetInfo  `json:"assets"`
+	}
+
+	ProviderInfo struct {
+		ID          string   `json:"id"`
+		Description string   `json:"description"`
+		Image       string   `json:"image"`
+		Website     string   `json:"website"`
+		RiskTier    RiskTier `json:"risk_tier,omitempty"`
+	}
+
+	// AssetInfo is the supply market of an asset at a provider, APY is a percentage
+	AssetInfo struct {
+		Symbol        string       `json:"symbol"`
+		Chain         string       `json:"chain"`
+		Description   string       `json:"description"`
+		YieldPeriod   int64        `json:"yield_period"`
+		MinimumAmount Amount       `json:"minimum_amount"`
+		Decimals      uint         `json:"decimals"`
+		APY           float64      `json:"apy"`
+		Status        MarketStatus `json:"status,omitempty"`
+		// Lockup is the time (seconds) the deposits can't be withdrawn, TVL the deposited value in USD
+		Lockup int64   `json:"lockup,omitempty"`
+		TVL    float64 `json:"tvl,omitempty"`
+		// Borrow market of the asset, for the providers lending it
+		Borrow *BorrowInfo `json:"borrow,omitempty"`
+		// Rewards splits APY into the interest and the liquidity mining rewards, when the provider pays any
+		Rewards *RewardsBreakdown `json:"rewards,omitempty"`
+	}
+
+	// RewardsBreakdown separates the interest of the supply (BaseAPY) from the reward tokens
+	// of the liquidity mining, often illiquid governance tokens valued at their market price
+	RewardsBreakdown struct {
+		BaseAPY float64     `json:"base_apy"`
+		Rewards []RewardAPR `json:"rewards"`
+	}
+
+	// RewardAPR is the rate of a reward token, Claimable false while the rewards are locked or vesting
+	RewardAPR struct {
+		Token     string  `json:"token"`
+		APR       float64 `json:"apr"`
+		Claimable bool    `json:"claimable"`
+	}
+
+	// BorrowInfo is the borrow market of an asset at a provider, APY and Utilization (borrowed share
+	// of the supply) are percentages and AvailableLiquidity is in the asset's smallest unit
+	BorrowInfo struct {
+		APY                float64 `json:"apy"`
+		Utilization        float64 `json:"utilization"`
+		AvailableLiquidity Amount  `json:"available_liquidity"`
+	}
+
+	LendingRates []LendingAssetRates
+
+	LendingProviders []LendingProvider
+
+	// LendingProvidersResponse lists the providers answering in time, and the errors of the others
+	LendingProvidersResponse struct {
+		Providers LendingProviders `json:"providers"`
+		Errors    []ProviderError  `json:"errors"`
+	}
+
+	// ProviderError is the failure of a provider, Timeout when it didn't answer in time
+	ProviderError struct {
+		Provider string `json:"provider"`
+		Error    string `json:"error"`
+		Timeout  bool   `json:"timeout,omitempty"`
+	}
+
+	// BestRate is the highest deposit APY of the asset across the providers
+	BestRate struct {
+		Asset    string  `json:"asset"`
+		Provider string  `json:"provider"`
+		APY      float64 `json:"apy"`
+	}
+
+	// BestRatesResponse lists the best rate of the assets found at a provider answering in time,
+	// in the requested order, and the errors of the others
+	BestRatesResponse struct {
+		Rates  []BestRate      `json:"rates"`
+		Errors []ProviderError `json:"errors"`
+	}
+
+	LendingAssetRates struct {
+		Asset  string  `json:"asset"`
+		MaxAPY float64 `json:"max_apy"`
+		// MinBorrowAPY is the lowest borrow APY of the asset, for the providers lending it
+		MinBorrowAPY float64      `json:"min_borrow_apy,omitempty"`
+		Status       MarketStatus `json:"status,omitempty"`
+	}
+
+	// LendingRateAlert notifies a channel when the APY of the asset at the provider goes above Above or
+	// below Below, or moves by more than Change percent of the APY last notified. Zero values are not checked.
+	LendingRateAlert struct {
+		Provider string  `json:"provider"`
+		Asset    string  `json:"asset"`
+		Above    float64 `json:"above,omitempty"`
+		Below    float64 `json:"below,omitempty"`
+		Change   float64 `json:"change,omitempty"`
+	}
+
+	// LendingAlertRequest posts the lending rate alert to the https CallbackURL when it triggers
+	LendingAlertRequest struct {
+		LendingRateAlert
+		CallbackURL string `json:"callback_url"`
+		Locale      string `json:"locale,omitempty"`
+	}
+
+	RatesRequest struct {
+		Assets []string `json:"assets" binding:"min=1,max=50,dive,required"`
+	}
+
+	// AccountRequest selects the addresses of an account, and optionally the assets. The addresses are
+	// sent alone or as sub-wallets, {"wallet": "savings", "addresses": [...]} or {"wallet": "savings", "xpub": "xpub..."},
+	// read into Wallets. The binding tags are checked once the addresses of the sub-wallets are resolved.
+	AccountRequest struct {
+		Addresses []string        `json:"addresses" binding:"min=1,max=100,dive,eth_addr"`
+		Wallets   []AccountWallet `json:"-"`
+		Assets    []string        `json:"assets" binding:"max=50,dive,required"`
+	}
+
+	// AccountWallet is a sub-wallet of a multi-account wallet, with its addresses or the extended
+	// public key they derive from
+	AccountWallet struct {
+		ID        string   `json:"wallet"`
+		Addresses []string `json:"addresses,omitempty"`
+		Xpub      string   `json:"xpub,omitempty"`
+	}
+
+	// WalletLendingContracts are the contracts of the addresses of a sub-wallet, Wallet is empty
+	// for the addresses sent alone
+	WalletLendingContracts struct {
+		Wallet   string                    `json:"wallet"`
+		Accounts []AccountLendingContracts `json:"accounts"`
+	}
+
+	AccountLendingContracts struct {
+		Address   string            `json:"address"`
+		Contracts []LendingContract `json:"contracts"`
+		Borrows   []BorrowPosition  `json:"borrows,omitempty"`
+		// Risk of the collateralized positions, for the providers with borrow markets
+		Risk *LiquidationRisk `json:"risk,omitempty"`
+	}
+
+	// LiquidationRisk of an account: the positions are liquidated when HealthFactor, the collateral value
+	// weighted by the liquidation thresholds over the borrowed value, falls below 1
+	LiquidationRisk struct {
+		HealthFactor      float64            `json:"health_factor"`
+		CollateralRatio   float64            `json:"collateral_ratio"`
+		LiquidationPrices []LiquidationPrice `json:"liquidation_prices,omitempty"`
+	}
+
+	// LiquidationPrice is the price of a collateral asset liquidating the account, the other prices unchanged
+	LiquidationPrice struct {
+		Asset string  `json:"asset"`
+		Price float64 `json:"price"`
+	}
+
+	// LiquidationAlert notifies a channel when the health factor of the address at the provider falls below HealthFactor
+	LiquidationAlert struct {
+		Provider     string  `json:"provider"`
+		Address      string  `json:"address"`
+		HealthFactor float64 `json:"health_factor"`
+	}
+
+	LendingContract struct {
+		Asset         string  `json:"asset"`
+		StartAmount   Amount  `json:"start_amount"`
+		CurrentAmount Amount  `json:"current_amount"`
+		CurrentAPY    float64 `json:"current_apy"`
+		// Principal is the deposited minus the withdrawn amount and Earned the interest accrued on top of it,
+		// for the providers reporting the accrued interest or the deposit history
+		Principal Amount `json:"principal,omitempty"`
+		Earned    Amount `json:"earned,omitempty"`
+		// Vault holding the deposit and the Shares of the address in it, for the yield providers
+		Vault  string `json:"vault,omitempty"`
+		Shares Amount `json:"shares,omitempty"`
+		// Withdrawal availability, for the providers with an exit queue (e.g. liquid staking)
+		Withdrawal *Withdrawal `json:"withdrawal,omitempty"`
+		// Value of CurrentAmount in the requested currency, when the asset could be priced
+		Value *FiatValue `json:"value,omitempty"`
+	}
+
+	// FiatValue is an amount converted at Price, the price of one unit of the asset in Currency at UpdatedAt
+	FiatValue struct {
+		Currency  string  `json:"currency"`
+		Amount    float64 `json:"amount"`
+		Price     float64 `json:"price"`
+		UpdatedAt int64   `json:"updated_at"`
+	}
+
+	// Withdrawal is how the deposit of a contract can be withdrawn, EstimatedWait in seconds when queued
+	Withdrawal struct {
+		Type          WithdrawalType `json:"type"`
+		EstimatedWait int64          `json:"estimated_wait,omitempty"`
+	}
+
+	// WithdrawalQueue is the exit queue of an asset at a provider: Length pending requests withdrawing
+	// Amount in the asset's smallest unit, a new request waiting EstimatedWait seconds
+	WithdrawalQueue struct {
+		Asset         string `json:"asset"`
+		Length        int64  `json:"length"`
+		Amount        Amount `json:"amount"`
+		EstimatedWait int64  `json:"estimated_wait"`
+	}
+
+	// LendingComparison compares the savings products of an asset across the providers
+	LendingComparison struct {
+		Asset       string           `json:"asset"`
+		Products    []SavingsProduct `json:"products"`
+		Disclaimers []string         `json:"disclaimers"`
+	}
+
+	// SavingsProduct is the supply market of an asset at a provider, normalized for the comparison:
+	// APY is BaseAPY plus RewardAPR, UpdatedAt the time its data was fetched from the provider
+	SavingsProduct struct {
+		Provider  string       `json:"provider"`
+		Type      ProviderType `json:"type"`
+		Chain     string       `json:"chain"`
+		APY       float64      `json:"apy"`
+		BaseAPY   float64      `json:"base_apy"`
+		RewardAPR float64      `json:"reward_apr"`
+		Lockup    int64        `json:"lockup"`
+		RiskTier  RiskTier     `json:"risk_tier,omitempty"`
+		TVL       float64      `json:"tvl,omitempty"`
+		Status    MarketStatus `json:"status,omitempty"`
+		UpdatedAt int64        `json:"updated_at"`
+		Notes     []string     `json:"notes,omitempty"`
+	}
+
+	// BorrowPosition is the debt of an address in an asset, CurrentAmount with the accrued interest
+	BorrowPosition struct {
+		Asset         string  `json:"asset"`
+		StartAmount   Amount  `json:"start_amount"`
+		CurrentAmount Amount  `json:"current_amount"`
+		CurrentAPY    float64 `json:"current_apy"`
+	}
+
+	// LendingRatePoint is the APY (percentage) of an asset from Date until the next point
+	LendingRatePoint struct {
+		Date int64   `json:"date"`
+		APY  float64 `json:"apy"`
+	}
+
+	// LendingEvent is a deposit to or a withdrawal from a provider, Value in the asset's smallest unit
+	LendingEvent struct {
+		Type  LendingEventType `json:"type"`
+		Asset string           `json:"asset"`
+		Value Amount           `json:"value"`
+		Date  int64            `json:"date"`
+		Hash  string           `json:"hash,omitempty"`
+	}
+
+	AccountLendingEarnings struct {
+		Address  string            `json:"address"`
+		Earnings []LendingEarnings `json:"earnings"`
+	}
+
+	// LendingEarnings is the interest accrued by the deposits of an asset, reconstructed from the rate history:
+	// Principal is the deposited minus the withdrawn value, Earned the interest on top of it
+	LendingEarnings struct {
+		Asset     string          `json:"asset"`
+		Principal Amount          `json:"principal"`
+		Balance   Amount          `json:"balance"`
+		Earned    Amount          `json:"earned"`
+		History   []EarningsPoint `json:"history"`
+	}
+
+	EarningsPoint struct {
+		Date    int64  `json:"date"`
+		Balance Amount `json:"balance"`
+		Earned  Amount `json:"earned"`
+	}
+)
+
+// RewardAPR is the sum of the reward token rates, the claimable ones only with claimableOnly
+func (r RewardsBreakdown) RewardAPR(claimableOnly bool) float64 {
+	var total float64
+	for _, reward := range r.Rewards {
+		if claimableOnly && !reward.Claimable {
+			continue
+		}
+		total += reward.APR
+	}
+	return total
+}
+
+// AcceptsDeposits is false for the markets a deposit would be rejected by or stuck in
+func (s MarketStatus) AcceptsDeposits() bool {
+	return s == "" || s == MarketActive
+}
+
+// UnmarshalJSON reads the sub-wallets of the addresses into Wallets, the addresses sent alone into Addresses
+func (r *AccountRequest) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Addresses []json.RawMessage `json:"addresses"`
+		Assets    []string          `json:"assets"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = AccountRequest{Assets: raw.Assets}
+	if raw.Addresses != nil {
+		r.Addresses = make([]string, 0, len(raw.Addresses))
+	}
+	for _, item := range raw.Addresses {
+		var address string
+		if err := json.Unmarshal(item, &address); err == nil {
+			r.Addresses = append(r.Addresses, address)
+			continue
+		}
+		var wallet AccountWallet
+		if err := json.Unmarshal(item, &wallet); err != nil {
+			return fmt.Errorf("invalid address or wallet %s: %w", item, err)
+		}
+		if wallet.ID == "" || (len(wallet.Addresses) == 0 && wallet.Xpub == "") {
+			return fmt.Errorf("a wallet needs an ID, and addresses or an xpub: %s", item)
+		}
+		r.Wallets = append(r.Wallets, wallet)
+	}
+	return nil
+}
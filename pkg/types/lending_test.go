@@ -0,0 +1,36 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardsBreakdown_RewardAPR(t *testing.T) {
+	r := RewardsBreakdown{
+		BaseAPY: 2.5,
+		Rewards: []RewardAPR{{Token: "COMP", APR: 1.5, Claimable: true}, {Token: "stkAAVE", APR: 3}},
+	}
+	assert.Equal(t, 4.5, r.RewardAPR(false))
+	assert.Equal(t, 1.5, r.RewardAPR(true))
+	assert.Equal(t, 0.0, RewardsBreakdown{BaseAPY: 2.5}.RewardAPR(false))
+}
+
+func TestAccountRequest_UnmarshalJSON(t *testing.T) {
+	var req AccountRequest
+	body := `{"addresses": ["0x1", {"wallet": "savings", "addresses": ["0x2", "0x3"]}, {"wallet": "ledger", "xpub": "xpub6D"}], "assets": ["DAI"]}`
+	assert.Nil(t, json.Unmarshal([]byte(body), &req))
+	assert.Equal(t, AccountRequest{
+		Addresses: []string{"0x1"},
+		Wallets:   []AccountWallet{{ID: "savings", Addresses: []string{"0x2", "0x3"}}, {ID: "ledger", Xpub: "xpub6D"}},
+		Assets:    []string{"DAI"},
+	}, req)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"addresses": ["0x1"]}`), &req))
+	assert.Equal(t, AccountRequest{Addresses: []string{"0x1"}}, req)
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"addresses": [{"addresses": ["0x2"]}]}`), &req))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"addresses": [{"wallet": "empty"}]}`), &req))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"addresses": [1]}`), &req))
+}
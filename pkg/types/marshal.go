@@ -1,9 +1,8 @@
-package blockatlas
+package types
 
 import (
 	"encoding/json"
-	"github.com/trustwallet/blockatlas/pkg/errors"
-	"github.com/trustwallet/blockatlas/pkg/numbers"
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -45,7 +44,7 @@ func (t *Tx) UnmarshalJSON(data []byte) error {
 	case TxAnyAction:
 		t.Meta = new(AnyAction)
 	default:
-		return errors.E("unsupported tx type", errors.Params{"type": t.Type})
+		return fmt.Errorf("unsupported tx type %q", t.Type)
 	}
 
 	err := json.Unmarshal(raw, t.Meta)
@@ -77,7 +76,7 @@ func (t *Tx) MarshalJSON() ([]byte, error) {
 	case AnyAction, *AnyAction:
 		t.Type = TxAnyAction
 	default:
-		return nil, errors.E("unsupported tx metadata", errors.Params{"meta": t.Meta})
+		return nil, fmt.Errorf("unsupported tx metadata %T", t.Meta)
 	}
 
 	// Set status to completed by default
@@ -90,7 +89,7 @@ func (t *Tx) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON reads an amount from a JSON string or number.
-// Comma separators get dropped with address.DecimalToSatoshis.
+// Comma separators get dropped with decimalToSatoshis.
 func (a *Amount) UnmarshalJSON(data []byte) error {
 	var n json.Number
 	err := json.Unmarshal(data, &n)
@@ -99,10 +98,10 @@ func (a *Amount) UnmarshalJSON(data []byte) error {
 	}
 	str := string(n)
 	if !matchNumber.MatchString(str) {
-		return errors.E("not a regular decimal number", errors.Params{"str": str})
+		return fmt.Errorf("not a regular decimal number: %q", str)
 	}
 	if strings.ContainsRune(str, '.') {
-		str, _ = numbers.DecimalToSatoshis(str)
+		str = decimalToSatoshis(str)
 	}
 	*a = Amount(str)
 	return nil
@@ -197,3 +196,24 @@ func (r CollectiblePageV3) MarshalJSON() ([]byte, error) {
 	page.Status = true
 	return json.Marshal(page)
 }
+
+// MarshalJSON returns the wrapped response object with its cache_control block
+func (r CachedResponse) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(r.Response)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("cached response is not an object: %w", err)
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage)
+	}
+	cacheControl, err := json.Marshal(r.CacheControl)
+	if err != nil {
+		return nil, err
+	}
+	fields["cache_control"] = cacheControl
+	return json.Marshal(fields)
+}
@@ -1,10 +1,9 @@
-package blockatlas
+package types
 
 import (
 	"bytes"
 	"encoding/json"
 	"github.com/stretchr/testify/assert"
-	"github.com/trustwallet/blockatlas/coin"
 	"reflect"
 	"sort"
 	"testing"
@@ -27,7 +26,7 @@ var txJSON = []byte(`{
 
 var txModel = Tx{
 	ID:     "14beb212aaefd06d7c6c0b25fc5ec242a2de2725af0a2827c105e743222cacd6",
-	Coin:   coin.NIM,
+	Coin:   coinNIM,
 	From:   "NQ11 P00L 2HYP TUK8 VY6L 2N22 MMBU MHHR BSAA",
 	To:     "NQ86 2H8F YGU5 RM77 QSN9 LYLH C56A CYYR 0MLA",
 	Fee:    "138",
@@ -95,3 +94,16 @@ func TestSortTxPage(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedResponse_MarshalJSON(t *testing.T) {
+	page := CollectionPageV3{{Id: "1"}}
+	got, err := json.Marshal(CachedResponse{Response: &page, CacheControl: CacheControl{CacheCollections: 600}})
+	assert.Nil(t, err)
+	var decoded map[string]interface{}
+	assert.Nil(t, json.Unmarshal(got, &decoded))
+	assert.Equal(t, float64(1), decoded["total"])
+	assert.Equal(t, map[string]interface{}{"collections": float64(600)}, decoded["cache_control"])
+
+	_, err = json.Marshal(CachedResponse{Response: []string{}})
+	assert.NotNil(t, err)
+}
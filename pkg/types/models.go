@@ -0,0 +1,29 @@
+package types
+
+type (
+	DocsResponse struct {
+		Docs interface{} `json:"docs"`
+	}
+
+	ResultsResponse struct {
+		Total   int         `json:"total"`
+		Results interface{} `json:"docs"`
+	}
+
+	CacheDataType string
+
+	// CacheControl suggests how many seconds clients keep each data type of a v3 response
+	CacheControl map[CacheDataType]int64
+
+	// CachedResponse adds the cache_control block to the JSON object of the wrapped response
+	CachedResponse struct {
+		Response     interface{}
+		CacheControl CacheControl
+	}
+)
+
+const (
+	CacheCollections  CacheDataType = "collections"
+	CacheCollectibles CacheDataType = "collectibles"
+	CacheStaking      CacheDataType = "staking"
+)
@@ -1,4 +1,4 @@
-package blockatlas
+package types
 
 type Resolved struct {
 	Result string `json:"result"`
@@ -0,0 +1,10 @@
+package types
+
+// TxNote is a private note attached by a user to a transaction
+type TxNote struct {
+	Coin      uint     `json:"coin"`
+	Hash      string   `json:"hash"`
+	Note      string   `json:"note"`
+	Tags      []string `json:"tags"`
+	UpdatedAt int64    `json:"updated_at"`
+}
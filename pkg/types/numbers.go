@@ -0,0 +1,36 @@
+package types
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The amount helpers of pkg/numbers used by the models, the module doesn't depend on the rest of the repository
+
+// decimalToSatoshis removes the decimal point of a decimal number, "0.1" is "01" and gets "1"
+func decimalToSatoshis(dec string) string {
+	out := strings.TrimSpace(dec)
+	out = strings.Replace(out, ".", "", 1)
+	// trim left 0's but keep last
+	if l := len(out); l >= 2 {
+		out = strings.TrimLeft(out[:l-1], "0") + out[l-1:l]
+	}
+	return out
+}
+
+// addAmount sums two integer amounts, the decimal ones read as 8 decimals
+func addAmount(left, right string) string {
+	return strconv.FormatInt(parseAmount(left)+parseAmount(right), 10)
+}
+
+func parseAmount(amount string) int64 {
+	if value, err := strconv.ParseInt(amount, 10, 64); err == nil {
+		return value
+	}
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(value * math.Pow10(8))
+}
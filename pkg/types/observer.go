@@ -0,0 +1,153 @@
+package types
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	ChannelFCM      ChannelProvider = "fcm"
+	ChannelAPNs     ChannelProvider = "apns"
+	ChannelTelegram ChannelProvider = "telegram"
+	ChannelSlack    ChannelProvider = "slack"
+	ChannelWebhook  ChannelProvider = "webhook"
+
+	DigestDaily  DigestPeriod = "daily"
+	DigestWeekly DigestPeriod = "weekly"
+)
+
+type (
+	Subscriptions map[string][]string
+
+	SubscriptionOperation string
+
+	SubscriptionEvent struct {
+		Subscriptions Subscriptions         `json:"subscriptions"`
+		Operation     SubscriptionOperation `json:"operation"`
+		// Channel delivers the notifications of these subscriptions directly instead of the notifications queue
+		Channel *Channel `json:"channel,omitempty"`
+		// Locale of the messages rendered for the Channel, e.g. "en" or "pt-BR"
+		Locale string `json:"locale,omitempty"`
+		// LendingAlerts are sent to the Channel when the rates of the lending providers change
+		LendingAlerts []LendingRateAlert `json:"lending_alerts,omitempty"`
+		// LiquidationAlerts are sent to the Channel when the borrow positions of the addresses are at risk
+		LiquidationAlerts []LiquidationAlert `json:"liquidation_alerts,omitempty"`
+		// Xpubs are the extended public keys or output descriptors (coin: keys) whose derived
+		// addresses are subscribed, including the ones derived later
+		Xpubs Subscriptions `json:"xpubs,omitempty"`
+		// TTL is the number of seconds the subscriptions are kept without a renewal,
+		// 0 for the default of the observer
+		TTL int64 `json:"ttl,omitempty"`
+		// Tenant owning the subscriptions, set by the API from the API key of the request
+		Tenant string `json:"tenant,omitempty"`
+	}
+
+	ChannelProvider string
+
+	// DigestPeriod is how often the activity of a digest subscription is summarized
+	DigestPeriod string
+
+	// Channel is the destination of the notifications: a FCM/APNs device token,
+	// a Telegram chat id, a Slack webhook URL or the callback URL of a webhook
+	Channel struct {
+		Provider ChannelProvider `json:"provider"`
+		Token    string          `json:"token"`
+		// Digest replaces the notification of each transaction with a periodic summary (optional)
+		Digest DigestPeriod `json:"digest,omitempty"`
+	}
+
+	Subscription struct {
+		Coin    uint   `json:"coin"`
+		Address string `json:"address"`
+		Locale  string `json:"locale,omitempty"`
+		Tenant  string `json:"tenant,omitempty"`
+	}
+
+	// WebhookSubscriptionRequest subscribes the addresses (coin: addresses) to the transactions posted to the
+	// webhook, an https callback URL. TTL is as in SubscriptionEvent.
+	WebhookSubscriptionRequest struct {
+		Subscriptions Subscriptions `json:"subscriptions"`
+		Webhook       string        `json:"webhook"`
+		TTL           int64         `json:"ttl,omitempty"`
+	}
+
+	// ReplayRequest asks the notifications of the subscriptions (coin: addresses) for the transactions
+	// dated from From to To (unix timestamps) to be published again
+	ReplayRequest struct {
+		Subscriptions Subscriptions `json:"subscriptions"`
+		From          int64         `json:"from"`
+		To            int64         `json:"to"`
+	}
+
+	ReplayResponse struct {
+		Notifications int `json:"notifications"`
+	}
+
+	// SubscriptionExpiry is published to the subscriptionsExpiring queue ahead of the expiry
+	// of a subscription not renewed, ExpiresAt is a unix timestamp
+	SubscriptionExpiry struct {
+		Coin      uint   `json:"coin"`
+		Address   string `json:"address"`
+		ExpiresAt int64  `json:"expires_at"`
+		// Xpub the address was derived from, for the addresses of an xpub subscription
+		Xpub   string `json:"xpub,omitempty"`
+		Tenant string `json:"tenant,omitempty"`
+	}
+
+	// SubscriptionsResponse is the number of subscriptions queued
+	SubscriptionsResponse struct {
+		Subscriptions int `json:"subscriptions"`
+	}
+
+	// QuotaUsage is the usage of the quota of a tenant, the limits are 0 when unlimited
+	QuotaUsage struct {
+		Tenant                    string `json:"tenant"`
+		Subscriptions             int64  `json:"subscriptions"`
+		MaxSubscriptions          int64  `json:"max_subscriptions"`
+		MaxNotificationsPerMinute int    `json:"max_notifications_per_minute"`
+		// Notifications sent and dropped over the rate limit during the current UTC day
+		NotificationsToday int64 `json:"notifications_today"`
+		ThrottledToday     int64 `json:"throttled_today"`
+	}
+
+	CoinStatus struct {
+		Height int64  `json:"height"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	Observer struct {
+		Status  bool   `json:"status"`
+		Message string `json:"message"`
+	}
+)
+
+func (e *SubscriptionEvent) ParseSubscriptions(s Subscriptions) []Subscription {
+	subs := make([]Subscription, 0)
+	for coinStr, perCoin := range s {
+		coin, err := strconv.Atoi(coinStr)
+		if err != nil {
+			continue
+		}
+		for _, addr := range perCoin {
+			subs = append(subs, Subscription{
+				Coin:    uint(coin),
+				Address: addr,
+				Locale:  e.Locale,
+				Tenant:  e.Tenant,
+			})
+		}
+	}
+	return subs
+}
+
+// Duration returns the length of the digest period, false for unknown periods
+func (p DigestPeriod) Duration() (time.Duration, bool) {
+	switch p {
+	case DigestDaily:
+		return 24 * time.Hour, true
+	case DigestWeekly:
+		return 7 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
@@ -1,4 +1,4 @@
-package blockatlas
+package types
 
 import (
 	"github.com/stretchr/testify/assert"
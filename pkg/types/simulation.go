@@ -0,0 +1,41 @@
+package types
+
+type (
+	// SimulationRequest is an unsigned transaction to dry-run, the amounts in the smallest unit of the coin.
+	// Gas and GasPrice are estimated by the node when empty, To is empty for a contract creation.
+	SimulationRequest struct {
+		From     string `json:"from" binding:"required"`
+		To       string `json:"to,omitempty"`
+		Value    string `json:"value,omitempty" binding:"omitempty,numeric"`
+		Data     string `json:"data,omitempty" binding:"omitempty,hexadecimal"`
+		Gas      string `json:"gas,omitempty" binding:"omitempty,numeric"`
+		GasPrice string `json:"gas_price,omitempty" binding:"omitempty,numeric"`
+	}
+
+	// BalanceChange is the signed amount an address receives, of the Token contract or of the native coin
+	BalanceChange struct {
+		Address string `json:"address"`
+		Token   string `json:"token,omitempty"`
+		Amount  string `json:"amount"`
+	}
+
+	// SimulatedTransfer is a token transfer the transaction would emit
+	SimulatedTransfer struct {
+		Token string `json:"token"`
+		From  string `json:"from"`
+		To    string `json:"to"`
+		Value string `json:"value"`
+	}
+
+	// Simulation is the expected outcome of a transaction. Fee is the most it pays, Gas times the gas price,
+	// Error the failure reason returned by the node when it would fail.
+	Simulation struct {
+		Coin           uint                `json:"coin"`
+		Success        bool                `json:"success"`
+		Error          string              `json:"error,omitempty"`
+		Gas            string              `json:"gas"`
+		Fee            string              `json:"fee"`
+		BalanceChanges []BalanceChange     `json:"balance_changes"`
+		TokenTransfers []SimulatedTransfer `json:"token_transfers"`
+	}
+)
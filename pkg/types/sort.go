@@ -0,0 +1,55 @@
+package types
+
+import "sort"
+
+// SortByBlock orders the transactions newest first by block, then date, sequence and hash,
+// the same transactions always come in the same order. Transactions without block come first.
+func (t Txs) SortByBlock() Txs {
+	sort.Slice(t, func(i, j int) bool {
+		a, b := &t[i], &t[j]
+		if a.Block != b.Block {
+			if a.Block == 0 || b.Block == 0 {
+				return a.Block == 0
+			}
+			return a.Block > b.Block
+		}
+		if a.Date != b.Date {
+			return a.Date > b.Date
+		}
+		if a.Sequence != b.Sequence {
+			return a.Sequence > b.Sequence
+		}
+		return a.ID < b.ID
+	})
+	return t
+}
+
+// SortByID orders the tokens by coin, then token ID
+func (p TokenPage) SortByID() TokenPage {
+	sort.Slice(p, func(i, j int) bool {
+		if p[i].Coin != p[j].Coin {
+			return p[i].Coin < p[j].Coin
+		}
+		return p[i].TokenID < p[j].TokenID
+	})
+	return p
+}
+
+// SortByID orders the lending providers by ID
+func (p LendingProviders) SortByID() LendingProviders {
+	sort.Slice(p, func(i, j int) bool {
+		return p[i].ID < p[j].ID
+	})
+	return p
+}
+
+// SortedKeys returns the keys of a batch request (coin -> addresses) in order,
+// the batch endpoints iterate them instead of the map
+func SortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ids(txs Txs) []string {
+	result := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		result = append(result, tx.ID)
+	}
+	return result
+}
+
+func TestTxs_SortByBlock(t *testing.T) {
+	txs := Txs{
+		{ID: "c", Block: 10, Date: 100},
+		{ID: "pending", Block: 0, Date: 90},
+		{ID: "b", Block: 10, Date: 100},
+		{ID: "old", Block: 9, Date: 200},
+		{ID: "nonce-2", Block: 11, Date: 110, Sequence: 2},
+		{ID: "nonce-3", Block: 11, Date: 110, Sequence: 3},
+		{ID: "later", Block: 10, Date: 101},
+	}
+	want := []string{"pending", "nonce-3", "nonce-2", "later", "b", "c", "old"}
+	assert.Equal(t, want, ids(txs.SortByBlock()))
+
+	// The order doesn't depend on the order of the input
+	reversed := make(Txs, len(txs))
+	for i := range txs {
+		reversed[len(txs)-1-i] = txs[i]
+	}
+	assert.Equal(t, want, ids(reversed.SortByBlock()))
+}
+
+func TestTokenPage_SortByID(t *testing.T) {
+	page := TokenPage{
+		{Coin: 714, TokenID: "BUSD-BD1"},
+		{Coin: 60, TokenID: "0xdac17f958d2ee523a2206206994597c13d831ec7"},
+		{Coin: 60, TokenID: "0x6b175474e89094c44da98b954eedeac495271d0f"},
+	}
+	page.SortByID()
+	assert.Equal(t, "0x6b175474e89094c44da98b954eedeac495271d0f", page[0].TokenID)
+	assert.Equal(t, "0xdac17f958d2ee523a2206206994597c13d831ec7", page[1].TokenID)
+	assert.Equal(t, "BUSD-BD1", page[2].TokenID)
+}
+
+func TestLendingProviders_SortByID(t *testing.T) {
+	providers := LendingProviders{{ID: "compound"}, {ID: "aave"}, {ID: "cream"}}
+	providers.SortByID()
+	assert.Equal(t, LendingProviders{{ID: "aave"}, {ID: "compound"}, {ID: "cream"}}, providers)
+}
+
+func TestSortedKeys(t *testing.T) {
+	keys := SortedKeys(map[string][]string{"714": nil, "60": nil, "118": nil})
+	assert.Equal(t, []string{"118", "60", "714"}, keys)
+}
@@ -0,0 +1,135 @@
+package types
+
+const (
+	DelegationStatusActive  DelegationStatus = "active"
+	DelegationStatusPending DelegationStatus = "pending"
+
+	ValidatorStatusActive   ValidatorStatus = "active"
+	ValidatorStatusInactive ValidatorStatus = "inactive"
+	ValidatorStatusJailed   ValidatorStatus = "jailed"
+
+	DelegationTypeAuto     DelegationType = "auto"
+	DelegationTypeDelegate DelegationType = "delegate"
+
+	DefaultAnnualReward = 0
+)
+
+type (
+	ValidatorPage        []Validator
+	DelegationsPage      []Delegation
+	DelegationsBatchPage []DelegationResponse
+	StakingBatchPage     []StakingResponse
+	StakeValidators      []StakeValidator
+
+	DelegationStatus string
+	DelegationType   string
+	ValidatorStatus  string
+
+	ValidatorMap map[string]StakeValidator
+
+	StakingReward struct {
+		Annual float64 `json:"annual"`
+	}
+
+	StakingDetails struct {
+		Reward        StakingReward  `json:"reward"`
+		LockTime      int            `json:"locktime"`
+		MinimumAmount Amount         `json:"minimum_amount"`
+		Type          DelegationType `json:"type"`
+	}
+
+	Validator struct {
+		ID      string         `json:"id"`
+		Status  bool           `json:"status"`
+		Details StakingDetails `json:"details"`
+		// Commission is the share of the rewards kept by the validator in percent, when the chain reports it
+		Commission float64 `json:"commission,omitempty"`
+		Jailed     bool    `json:"jailed,omitempty"`
+	}
+
+	// ValidatorDetails is a validator of the chain with its metadata from the validators registry,
+	// the annual reward is the one of the delegators in percent
+	ValidatorDetails struct {
+		ID          string          `json:"id"`
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Image       string          `json:"image"`
+		Website     string          `json:"website"`
+		Reward      StakingReward   `json:"reward"`
+		Commission  float64         `json:"commission"`
+		Status      ValidatorStatus `json:"status"`
+	}
+
+	Delegation struct {
+		Delegator StakeValidator   `json:"delegator"`
+		Value     string           `json:"value"`
+		Status    DelegationStatus `json:"status"`
+		Metadata  interface{}      `json:"metadata,omitempty"`
+	}
+
+	DelegationMetaDataPending struct {
+		AvailableDate uint `json:"available_date"`
+	}
+
+	StakeValidatorInfo struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Image       string `json:"image"`
+		Website     string `json:"website"`
+	}
+
+	StakeValidator struct {
+		ID      string             `json:"id"`
+		Status  bool               `json:"status"`
+		Info    StakeValidatorInfo `json:"info,omitempty"`
+		Details StakingDetails     `json:"details,omitempty"`
+	}
+
+	DelegationResponse struct {
+		Delegations DelegationsPage `json:"delegations"`
+		Balance     string          `json:"balance"`
+		Address     string          `json:"address"`
+		StakingResponse
+	}
+
+	// DelegationsSummary is the staking position of an address: its delegations with the totals staked and
+	// unbonding, the rewards not withdrawn yet when the coin reports them and the unbonding period in seconds
+	DelegationsSummary struct {
+		Staked          string `json:"staked"`
+		Unbonding       string `json:"unbonding"`
+		Rewards         string `json:"rewards,omitempty"`
+		UnbondingPeriod int    `json:"unbonding_period"`
+		DelegationResponse
+	}
+
+	// DelegationsBatchItem is the answer for an address of a delegations batch: its delegations, or the
+	// error of its coin with Timeout when the coin didn't answer in time
+	DelegationsBatchItem struct {
+		Coin        uint                `json:"coin"`
+		Address     string              `json:"address"`
+		Delegations *DelegationResponse `json:"delegations,omitempty"`
+		Error       string              `json:"error,omitempty"`
+		Timeout     bool                `json:"timeout,omitempty"`
+	}
+
+	// ExternalCoin is the coin of the staking responses
+	ExternalCoin struct {
+		Coin     uint   `json:"coin"`
+		Symbol   string `json:"symbol"`
+		Name     string `json:"name"`
+		Decimals uint   `json:"decimals"`
+	}
+
+	StakingResponse struct {
+		Coin    *ExternalCoin  `json:"coin"`
+		Details StakingDetails `json:"details"`
+	}
+)
+
+func (sv StakeValidators) ToMap() ValidatorMap {
+	validators := make(ValidatorMap)
+	for _, v := range sv {
+		validators[v.ID] = v
+	}
+	return validators
+}
@@ -0,0 +1,32 @@
+package types
+
+const (
+	AddressExchange AddressClass = "exchange"
+	AddressContract AddressClass = "contract"
+	AddressMiner    AddressClass = "miner"
+	AddressWallet   AddressClass = "wallet"
+	AddressUnknown  AddressClass = "unknown"
+)
+
+type (
+	// AddressClass is the kind of owner of an address, inferred from its activity
+	AddressClass string
+
+	// AddressLabel is a known address of the labels dataset
+	AddressLabel struct {
+		Class AddressClass `json:"class"`
+		Name  string       `json:"name,omitempty"`
+	}
+
+	// AccountSummary describes an address from its latest transactions
+	AccountSummary struct {
+		Coin    uint         `json:"coin"`
+		Address string       `json:"address"`
+		Class   AddressClass `json:"class"`
+		// Name of the labeled addresses
+		Label          string `json:"label,omitempty"`
+		Transactions   int    `json:"transactions"`
+		Counterparties int    `json:"counterparties"`
+		LastActivity   int64  `json:"last_activity,omitempty"`
+	}
+)
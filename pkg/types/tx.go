@@ -1,9 +1,7 @@
-package blockatlas
+package types
 
 import (
 	mapset "github.com/deckarep/golang-set"
-	"github.com/trustwallet/blockatlas/coin"
-	"github.com/trustwallet/blockatlas/pkg/numbers"
 	"sort"
 )
 
@@ -37,6 +35,14 @@ const (
 	TxAnyAction             TransactionType = "any_action"
 	TxMultiCurrencyTransfer TransactionType = "multi_currency_transfer"
 
+	EventLendingDeposit  EventType = "lending_deposit"
+	EventLendingWithdraw EventType = "lending_withdraw"
+
+	EventRewardClaimed     EventType = "reward_claimed"
+	EventUnbondingComplete EventType = "unbonding_complete"
+
+	EventBridgeTransfer EventType = "bridge_transfer"
+
 	KeyPlaceOrder        KeyType = "place_order"
 	KeyCancelOrder       KeyType = "cancel_order"
 	KeyIssueToken        KeyType = "issue_token"
@@ -62,6 +68,7 @@ type (
 	Status          string
 	TokenType       string
 	TransactionType string
+	EventType       string
 	KeyType         string
 	KeyTitle        string
 
@@ -111,6 +118,41 @@ type (
 		// Meta data object
 		Memo string      `json:"memo"`
 		Meta interface{} `json:"metadata"`
+		// Private note of the requesting user, only set with `include_notes=true`
+		Note *TxNote `json:"note,omitempty"`
+		// Action recognized by the parser, e.g. a lending deposit (optional)
+		Event *TxEvent `json:"event,omitempty"`
+	}
+
+	// TxEvent describes what the transaction does for protocols known by the observer
+	TxEvent struct {
+		Type     EventType `json:"type"`
+		Protocol string    `json:"protocol,omitempty"`
+		// Validator of the staking events
+		Validator string `json:"validator,omitempty"`
+		// Other network of the bridge transfers, e.g. "polygon"
+		Network  string `json:"network,omitempty"`
+		Value    Amount `json:"value,omitempty"`
+		Symbol   string `json:"symbol,omitempty"`
+		Decimals uint   `json:"decimals,omitempty"`
+		// Transfer links the transactions of both networks of the bridge transfers with a known message
+		Transfer *BridgeTransfer `json:"transfer,omitempty"`
+	}
+
+	// BridgeTransfer is a transfer between two networks: the message of the bridge sent by the source
+	// transaction and delivered by the destination one, a side is missing until its network is parsed
+	BridgeTransfer struct {
+		Protocol string `json:"protocol"`
+		// MessageID identifies the message on both networks, e.g. chain/emitter/sequence for Wormhole
+		MessageID   string     `json:"message_id"`
+		Source      *BridgeLeg `json:"source,omitempty"`
+		Destination *BridgeLeg `json:"destination,omitempty"`
+	}
+
+	// BridgeLeg is the transaction of a network of a bridge transfer
+	BridgeLeg struct {
+		Coin uint   `json:"coin"`
+		TxID string `json:"tx_id"`
 	}
 
 	TxOutput struct {
@@ -118,6 +160,15 @@ type (
 		Value   Amount `json:"value"`
 	}
 
+	// Utxo is an unspent output of an address (Bitcoin-style), Height is 0 while unconfirmed
+	Utxo struct {
+		TxID          string `json:"txid"`
+		Vout          uint32 `json:"vout"`
+		Value         Amount `json:"value"`
+		Height        uint64 `json:"height,omitempty"`
+		Confirmations uint64 `json:"confirmations"`
+	}
+
 	// Transfer describes the transfer of currency native to the platform
 	Transfer struct {
 		Value    Amount `json:"value"`
@@ -205,6 +256,9 @@ type (
 		TokenID  string    `json:"token_id"`
 		Coin     uint      `json:"coin"`
 		Type     TokenType `json:"type"`
+		// Balance is the amount held by the address in the smallest unit of the token,
+		// empty when the provider of the coin doesn't report it
+		Balance string `json:"balance,omitempty"`
 	}
 
 	Txs []Tx
@@ -301,14 +355,16 @@ func determineTransactionDirection(address, from, to string) Direction {
 	return DirectionOutgoing
 }
 
-func (t *Tx) InferUtxoValue(address string, coinIndex uint) {
+// InferUtxoValue sets the transfer of the UTXO transaction to the value received or sent by the address,
+// in the coin of symbol and decimals
+func (t *Tx) InferUtxoValue(address, symbol string, decimals uint) {
 	if len(t.Inputs) > 0 && len(t.Outputs) > 0 {
 		addressSet := mapset.NewSet(address)
 		value := InferValue(t, t.Direction, addressSet)
 		t.Meta = Transfer{
 			Value:    value,
-			Symbol:   coin.Coins[coinIndex].Symbol,
-			Decimals: coin.Coins[coinIndex].Decimals,
+			Symbol:   symbol,
+			Decimals: decimals,
 		}
 	}
 }
@@ -345,7 +401,7 @@ func InferValue(tx *Tx, direction Direction, addressSet mapset.Set) Amount {
 			if !addressSet.Contains(output.Address) {
 				continue
 			}
-			value := numbers.AddAmount(string(amount), string(output.Value))
+			value := addAmount(string(amount), string(output.Value))
 			amount = Amount(value)
 		}
 		value = amount
@@ -1,16 +1,23 @@
-package blockatlas
+package types
 
 import (
 	mapset "github.com/deckarep/golang-set"
 	"github.com/stretchr/testify/assert"
-	"github.com/trustwallet/blockatlas/coin"
 	"sort"
 	"testing"
 )
 
+// The coin IDs of the tests, the module doesn't depend on the coin package of the repository
+const (
+	coinBTC  = 0
+	coinDOGE = 3
+	coinNIM  = 242
+	coinBNB  = 714
+)
+
 var transferDst1 = Tx{
 	ID:     "1681EE543FB4B5A628EF21D746E031F018E226D127044A4F9BA5EE2542A44555",
-	Coin:   coin.BNB,
+	Coin:   coinBNB,
 	From:   "tbnb1fhr04azuhcj0dulm7ka40y0cqjlafwae9k9gk2",
 	To:     "tbnb1sylyjw032eajr9cyllp26n04300qzzre38qyv5",
 	Fee:    "125000",
@@ -27,7 +34,7 @@ var transferDst1 = Tx{
 
 var nativeTransferDst1 = Tx{
 	ID:     "95CF63FAA27579A9B6AF84EF8B2DFEAC29627479E9C98E7F5AE4535E213FA4C9",
-	Coin:   coin.BNB,
+	Coin:   coinBNB,
 	From:   "tbnb1ttyn4csghfgyxreu7lmdu3lcplhqhxtzced45a",
 	To:     "tbnb12hlquylu78cjylk5zshxpdj6hf3t0tahwjt3ex",
 	Fee:    "125000",
@@ -47,7 +54,7 @@ var nativeTransferDst1 = Tx{
 
 var utxoTransferDst1 = Tx{
 	ID:   "zpub6ruK9k6YGm8BRHWvTiQcrEPnFkuRDJhR7mPYzV2LDvjpLa5CuGgrhCYVZjMGcLcFqv9b2WvsFtY2Gb3xq8NVq8qhk9veozrA2W9QaWtihrC",
-	Coin: coin.BTC,
+	Coin: coinBTC,
 	Inputs: []TxOutput{
 		{
 			Address: "bc1qhn03cww757mnnlpkdvvfkaydxqygm86nvkm92h",
@@ -77,7 +84,7 @@ var utxoTransferDst1 = Tx{
 
 var utxoTransferDst2 = Tx{
 	ID:   "zpub6ruK9k6YGm8BRHWvTiQcrEPnFkuRDJhR7mPYzV2LDvjpLa5CuGgrhCYVZjMGcLcFqv9b2WvsFtY2Gb3xq8NVq8qhk9veozrA2W9QaWtihrC",
-	Coin: coin.BTC,
+	Coin: coinBTC,
 	Inputs: []TxOutput{
 		{
 			Address: "bc1q6e8sdxlgc7ekqkqyevtrx8wshfv7sg66z3z6ce",
@@ -315,11 +322,11 @@ func Test_inferUtxoValue(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			expect := Transfer{
 				Value:    tt.wantAmount,
-				Symbol:   coin.Coins[tt.args.coinIndex].Symbol,
-				Decimals: coin.Coins[tt.args.coinIndex].Decimals,
+				Symbol:   "BTC",
+				Decimals: 8,
 			}
 			tt.args.tx.Direction = tt.args.tx.GetTransactionDirection(tt.args.address)
-			if tt.args.tx.InferUtxoValue(tt.args.address, tt.args.coinIndex); tt.args.tx.Meta != expect {
+			if tt.args.tx.InferUtxoValue(tt.args.address, "BTC", 8); tt.args.tx.Meta != expect {
 				t.Errorf("inferUtxoValue() = %v, want %v", tt.args.tx.Meta, expect)
 			}
 		})
@@ -361,21 +368,21 @@ func TestInferDirection(t *testing.T) {
 			btcInputs1,
 			btcOutputs1,
 			DirectionOutgoing,
-			coin.BTC,
+			coinBTC,
 		},
 		{
 			btcSet,
 			btcInputs2,
 			btcOutputs2,
 			DirectionIncoming,
-			coin.BTC,
+			coinBTC,
 		},
 		{
 			dogeSet,
 			dogeInputs,
 			dogeOutputs,
 			DirectionIncoming,
-			coin.DOGE,
+			coinDOGE,
 		},
 	}
 
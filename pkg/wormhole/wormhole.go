@@ -0,0 +1,55 @@
+// Package wormhole identifies the messages of the Wormhole bridge, the same on every network:
+// a message is sent by an emitter of a chain with a sequence, and delivered as a VAA signed by the guardians.
+package wormhole
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// Protocol is the protocol of the bridge_transfer events of Wormhole
+const Protocol = "wormhole"
+
+// Wormhole chain IDs of the networks
+const (
+	ChainSolana   uint16 = 1
+	ChainEthereum uint16 = 2
+)
+
+const (
+	vaaHeaderLength    = 6
+	vaaSignatureLength = 66
+	vaaBodyLength      = 51
+)
+
+// Message identifies a message on both networks
+type Message struct {
+	EmitterChain   uint16
+	EmitterAddress [32]byte
+	Sequence       uint64
+}
+
+// ID is the emitter chain/emitter address/sequence ID of the message, used by the Wormhole explorers
+func (m Message) ID() string {
+	return fmt.Sprintf("%d/%s/%d", m.EmitterChain, hex.EncodeToString(m.EmitterAddress[:]), m.Sequence)
+}
+
+// ParseVAA reads the message of a VAA: the header with the guardian signatures, then the body
+// with the timestamp, nonce, emitter chain and address, sequence, consistency level and payload, big-endian
+func ParseVAA(vaa []byte) (Message, error) {
+	if len(vaa) < vaaHeaderLength {
+		return Message{}, errors.E("VAA too short", errors.Params{"length": len(vaa)})
+	}
+	body := vaaHeaderLength + int(vaa[5])*vaaSignatureLength
+	if len(vaa) < body+vaaBodyLength {
+		return Message{}, errors.E("VAA too short", errors.Params{"length": len(vaa), "signatures": vaa[5]})
+	}
+	var m Message
+	m.EmitterChain = binary.BigEndian.Uint16(vaa[body+8:])
+	copy(m.EmitterAddress[:], vaa[body+10:body+42])
+	m.Sequence = binary.BigEndian.Uint64(vaa[body+42:])
+	return m, nil
+}
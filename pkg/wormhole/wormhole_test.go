@@ -0,0 +1,28 @@
+package wormhole
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// vaa is a VAA of the Solana token bridge with one signature: version 1, guardian set 1,
+// emitter chain 1, sequence 74165
+var vaa, _ = hex.DecodeString("01" + "00000001" + "01" + strings.Repeat("ab", vaaSignatureLength) +
+	"61d8b1f3" + "00000000" + "0001" + "ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5" +
+	"00000000000121b5" + "20" + "01")
+
+func TestParseVAA(t *testing.T) {
+	m, err := ParseVAA(vaa)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainSolana, m.EmitterChain)
+	assert.Equal(t, uint64(74165), m.Sequence)
+	assert.Equal(t, "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/74165", m.ID())
+
+	_, err = ParseVAA(vaa[:len(vaa)-2])
+	assert.NotNil(t, err)
+	_, err = ParseVAA(vaa[:3])
+	assert.NotNil(t, err)
+}
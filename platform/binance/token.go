@@ -3,6 +3,7 @@ package binance
 import (
 	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/numbers"
 	"strings"
 )
 
@@ -51,10 +52,23 @@ func normalizeToken(srcToken *Balance, tokens *TokenList) (blockatlas.Token, boo
 		Decimals: uint(decimalPlaces(token.TotalSupply)),
 		Type:     blockatlas.TokenTypeBEP2,
 	}
+	result.Balance = srcToken.total(int(result.Decimals))
 
 	return result, true
 }
 
+// total is the free, frozen and locked balance in the smallest unit of a token of decimals
+func (balance *Balance) total(decimals int) string {
+	total := "0"
+	for _, value := range [3]string{balance.Free, balance.Frozen, balance.Locked} {
+		if value == "" {
+			continue
+		}
+		total = numbers.AddAmount(total, numbers.DecimalExp(value, decimals))
+	}
+	return total
+}
+
 // decimalPlaces count the decimals places.
 func decimalPlaces(v string) int {
 	s := strings.Split(v, ".")
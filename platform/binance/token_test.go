@@ -79,6 +79,7 @@ type testToken struct {
 	name        string
 	apiResponse string
 	expected    blockatlas.Token
+	balance     string
 	tokens      string
 	ok          bool
 }
@@ -90,6 +91,7 @@ func TestNormalizeToken(t *testing.T) {
 			apiResponse: myToken,
 			tokens:      tokenList,
 			expected:    tokenDst,
+			balance:     "1719938841739",
 			ok:          true,
 		},
 		{
@@ -104,6 +106,7 @@ func TestNormalizeToken(t *testing.T) {
 			apiResponse: myTokenFreeZero,
 			tokens:      tokenList,
 			expected:    tokenDst,
+			balance:     "100000000",
 			ok:          true,
 		},
 		{
@@ -111,6 +114,7 @@ func TestNormalizeToken(t *testing.T) {
 			apiResponse: myTokenFrozenAndFreeZero,
 			tokens:      tokenList,
 			expected:    tokenDst,
+			balance:     "1",
 			ok:          true,
 		},
 	}
@@ -126,7 +130,9 @@ func TestNormalizeToken(t *testing.T) {
 
 			tk, ok := normalizeToken(&srcToken, &srcTokens)
 			assert.Equal(t, testToken.ok, ok, "token: token could not be normalized")
-			assert.Equal(t, testToken.expected, tk, "token: token don't equal")
+			expected := testToken.expected
+			expected.Balance = testToken.balance
+			assert.Equal(t, expected, tk, "token: token don't equal")
 		})
 	}
 }
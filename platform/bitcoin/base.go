@@ -7,14 +7,21 @@ import (
 
 type Platform struct {
 	client    Client
+	mempool   *MempoolClient
 	CoinIndex uint
 }
 
-func Init(coin uint, api string) *Platform {
-	return &Platform{
+// Init serves the coin from the Blockbook API, the fee rates are read from the mempool of the
+// mempool.space compatible feeAPI when it is set
+func Init(coin uint, api, feeAPI string) *Platform {
+	p := &Platform{
 		CoinIndex: coin,
 		client:    Client{blockatlas.InitClient(api)},
 	}
+	if feeAPI != "" {
+		p.mempool = &MempoolClient{blockatlas.InitClient(feeAPI)}
+	}
+	return p
 }
 
 func (p *Platform) Coin() coin.Coin {
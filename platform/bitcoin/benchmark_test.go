@@ -0,0 +1,51 @@
+package bitcoin
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/trustwallet/blockatlas/coin"
+)
+
+// bigUtxoTx inflates the outgoing fixture to a consolidation-like transaction
+// with the given number of inputs and outputs
+func bigUtxoTx(b *testing.B, size int) Transaction {
+	var tx Transaction
+	if err := json.Unmarshal([]byte(outgoingTx), &tx); err != nil {
+		b.Fatal(err)
+	}
+	vin, vout := tx.Vin[0], tx.Vout[0]
+	tx.Vin = make([]Output, size)
+	tx.Vout = make([]Output, size)
+	for i := 0; i < size; i++ {
+		tx.Vin[i] = vin
+		tx.Vin[i].Addresses = []string{vin.Addresses[0] + strconv.Itoa(i)}
+		tx.Vout[i] = vout
+		tx.Vout[i].Addresses = []string{vout.Addresses[0] + strconv.Itoa(i)}
+	}
+	return tx
+}
+
+func BenchmarkNormalizeTransaction_BigUtxo(b *testing.B) {
+	tx := bigUtxoTx(b, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		normalizeTransaction(tx, coin.BTC)
+	}
+}
+
+func BenchmarkNormalizeTransfer_BigUtxo(b *testing.B) {
+	tx := bigUtxoTx(b, 1000)
+	addressSet := mapset.NewSet()
+	for i := 0; i < 20; i++ {
+		addressSet.Add(tx.Vout[i*50].Addresses[0])
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		normalizeTransfer(tx, coin.BTC, addressSet)
+	}
+}
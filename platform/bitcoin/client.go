@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
 )
 
 type Client struct {
@@ -21,6 +22,16 @@ func (c *Client) GetTransactions(address string) (transactions TransactionsList,
 	return transactions, err
 }
 
+func (c *Client) GetTransaction(id string) (transaction Transaction, err error) {
+	err = c.Get(&transaction, fmt.Sprintf("v2/tx/%s", id), nil)
+	return transaction, err
+}
+
+func (c *Client) GetUtxos(address string) (utxos []Utxo, err error) {
+	err = c.Get(&utxos, fmt.Sprintf("v2/utxo/%s", url.PathEscape(address)), nil)
+	return utxos, err
+}
+
 func (c *Client) GetTransactionsByXpub(xpub string) (transactions TransactionsList, err error) {
 	path := fmt.Sprintf("v2/xpub/%s", xpub)
 	args := url.Values{
@@ -32,8 +43,9 @@ func (c *Client) GetTransactionsByXpub(xpub string) (transactions TransactionsLi
 	return transactions, err
 }
 
+// GetAddressesFromXpub also accepts the output descriptors supported by the Blockbook backend
 func (c *Client) GetAddressesFromXpub(xpub string) (tokens []Token, err error) {
-	path := fmt.Sprintf("v2/xpub/%s", xpub)
+	path := fmt.Sprintf("v2/xpub/%s", url.PathEscape(xpub))
 	args := url.Values{
 		"pageSize": {strconv.Itoa(blockatlas.TxPerPage)},
 		"details":  {"txs"},
@@ -57,3 +69,27 @@ func (c *Client) GetBlockNumber() (status BlockchainStatus, err error) {
 	err = c.Get(&status, "v2", nil)
 	return status, err
 }
+
+// EstimateFee returns the estimate of the node to confirm within the blocks, in coins per kilobyte
+func (c *Client) EstimateFee(blocks int) (fee FeeEstimate, err error) {
+	path := fmt.Sprintf("v2/estimatefee/%d", blocks)
+	err = c.Get(&fee, path, nil)
+	return fee, err
+}
+
+// MempoolClient reads the mempool of a mempool.space compatible API
+type MempoolClient struct {
+	blockatlas.Request
+}
+
+func (c *MempoolClient) GetFeeHistogram() (types.FeeHistogram, error) {
+	var mempool Mempool
+	if err := c.Get(&mempool, "mempool", nil); err != nil {
+		return nil, err
+	}
+	histogram := make(types.FeeHistogram, 0, len(mempool.FeeHistogram))
+	for _, bucket := range mempool.FeeHistogram {
+		histogram = append(histogram, types.FeeHistogramBucket{Rate: bucket[0], VSize: int64(bucket[1])})
+	}
+	return histogram, nil
+}
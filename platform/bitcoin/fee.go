@@ -0,0 +1,111 @@
+package bitcoin
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	// maxBlockVSize is the virtual size of a full block
+	maxBlockVSize = 1000000
+	// minFeeRate is the minimum relay fee, in satoshis per virtual byte
+	minFeeRate = 1
+)
+
+// feeTargets are the blocks to wait for a confirmation by priority
+var feeTargets = []struct {
+	priority types.FeePriority
+	blocks   int
+}{
+	{types.FeePriorityFast, 1},
+	{types.FeePriorityNormal, 3},
+	{types.FeePrioritySlow, 6},
+}
+
+// GetFeeEstimates reads the rates from the mempool histogram of the fee API when it is set,
+// from the estimates of the node otherwise or when the fee API fails
+func (p *Platform) GetFeeEstimates() (types.FeeEstimates, error) {
+	if p.mempool != nil {
+		histogram, err := p.mempool.GetFeeHistogram()
+		if err == nil {
+			return types.FeeEstimates{
+				Coin:      p.CoinIndex,
+				Source:    types.FeeSourceMempool,
+				Estimates: EstimateFromHistogram(histogram, p.Coin().BlockTime),
+				Histogram: histogram,
+			}, nil
+		}
+		logger.Error(err, "Fee histogram unavailable, using the node estimates", logger.Params{"coin": p.Coin().Handle})
+	}
+	estimates := make([]types.FeeEstimate, 0, len(feeTargets))
+	for _, target := range feeTargets {
+		fee, err := p.client.EstimateFee(target.blocks)
+		if err != nil {
+			return types.FeeEstimates{}, err
+		}
+		rate, err := feeRateFromNode(fee.Result, p.Coin().Decimals)
+		if err != nil {
+			return types.FeeEstimates{}, err
+		}
+		estimates = append(estimates, types.FeeEstimate{
+			Priority:         target.priority,
+			Rate:             rate,
+			Blocks:           target.blocks,
+			ConfirmationTime: confirmationTime(target.blocks, p.Coin().BlockTime),
+		})
+	}
+	return types.FeeEstimates{Coin: p.CoinIndex, Source: types.FeeSourceNode, Estimates: estimates}, nil
+}
+
+// EstimateFromHistogram returns the rate to outbid the mempool transactions filling the blocks of each target,
+// the minimum rate when they fit in them. The block time is in milliseconds.
+func EstimateFromHistogram(histogram types.FeeHistogram, blockTime int) []types.FeeEstimate {
+	var total int64
+	for _, bucket := range histogram {
+		total += bucket.VSize
+	}
+	estimates := make([]types.FeeEstimate, 0, len(feeTargets))
+	for _, target := range feeTargets {
+		estimate := types.FeeEstimate{
+			Priority:         target.priority,
+			Rate:             minFeeRate,
+			Blocks:           target.blocks,
+			ConfirmationTime: confirmationTime(target.blocks, blockTime),
+		}
+		if total > 0 {
+			estimate.Percentile = 100
+		}
+		var ahead int64
+		for _, bucket := range histogram {
+			if ahead+bucket.VSize > int64(target.blocks*maxBlockVSize) {
+				estimate.Rate = int64(math.Max(math.Ceil(bucket.Rate), minFeeRate))
+				estimate.Percentile = math.Round(float64(ahead)/float64(total)*10000) / 100
+				break
+			}
+			ahead += bucket.VSize
+		}
+		estimates = append(estimates, estimate)
+	}
+	return estimates
+}
+
+// feeRateFromNode converts the estimate of the node, in coins per kilobyte, to the smallest unit per byte
+func feeRateFromNode(fee string, decimals uint) (int64, error) {
+	perKB, err := strconv.ParseFloat(fee, 64)
+	if err != nil {
+		return 0, errors.E(err, "invalid fee estimate", errors.Params{"fee": fee})
+	}
+	rate := int64(math.Ceil(perKB * math.Pow10(int(decimals)) / 1000))
+	if rate < minFeeRate {
+		rate = minFeeRate
+	}
+	return rate, nil
+}
+
+func confirmationTime(blocks, blockTime int) int64 {
+	return int64(blocks) * int64(blockTime) / 1000
+}
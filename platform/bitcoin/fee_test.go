@@ -0,0 +1,75 @@
+package bitcoin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const mempoolSrc = `{"count":12000,"vsize":4000000,"total_fee":53000000,"fee_histogram":[[80.5,500000],[40.1,700000],[20,1000000],[10.2,800000],[5,1000000]]}`
+
+func TestEstimateFromHistogram(t *testing.T) {
+	histogram := types.FeeHistogram{
+		{Rate: 80.5, VSize: 500000}, {Rate: 40.1, VSize: 700000}, {Rate: 20, VSize: 1000000}, {Rate: 10.2, VSize: 800000}, {Rate: 5, VSize: 1000000},
+	}
+	expected := []types.FeeEstimate{
+		{Priority: types.FeePriorityFast, Rate: 41, Percentile: 12.5, Blocks: 1, ConfirmationTime: 600},
+		{Priority: types.FeePriorityNormal, Rate: 5, Percentile: 75, Blocks: 3, ConfirmationTime: 1800},
+		{Priority: types.FeePrioritySlow, Rate: 1, Percentile: 100, Blocks: 6, ConfirmationTime: 3600},
+	}
+	assert.Equal(t, expected, EstimateFromHistogram(histogram, 600000))
+
+	empty := EstimateFromHistogram(nil, 600000)
+	assert.Equal(t, int64(1), empty[0].Rate)
+	assert.Equal(t, float64(0), empty[0].Percentile)
+}
+
+func TestFeeRateFromNode(t *testing.T) {
+	rate, err := feeRateFromNode("0.00012345", 8)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(13), rate)
+	rate, err = feeRateFromNode("0.000001", 8)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), rate)
+	_, err = feeRateFromNode("-", 8)
+	assert.NotNil(t, err)
+}
+
+func TestPlatform_GetFeeEstimates(t *testing.T) {
+	var mempoolUp = true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/mempool" && mempoolUp:
+			_, _ = w.Write([]byte(mempoolSrc))
+		case r.URL.Path == "/v2/estimatefee/1":
+			_, _ = w.Write([]byte(`{"result":"0.0003"}`))
+		case r.URL.Path == "/v2/estimatefee/3" || r.URL.Path == "/v2/estimatefee/6":
+			_, _ = w.Write([]byte(`{"result":"0.0001"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	p := Init(coin.BTC, server.URL, server.URL)
+	estimates, err := p.GetFeeEstimates()
+	assert.Nil(t, err)
+	assert.Equal(t, types.FeeSourceMempool, estimates.Source)
+	assert.Len(t, estimates.Histogram, 5)
+	assert.Equal(t, int64(41), estimates.Estimates[0].Rate)
+
+	mempoolUp = false
+	estimates, err = p.GetFeeEstimates()
+	assert.Nil(t, err)
+	assert.Equal(t, types.FeeSourceNode, estimates.Source)
+	assert.Equal(t, []int64{30, 10, 10}, []int64{estimates.Estimates[0].Rate, estimates.Estimates[1].Rate, estimates.Estimates[2].Rate})
+	assert.Nil(t, estimates.Histogram)
+
+	estimates, err = Init(coin.BTC, server.URL, "").GetFeeEstimates()
+	assert.Nil(t, err)
+	assert.Equal(t, types.FeeSourceNode, estimates.Source)
+}
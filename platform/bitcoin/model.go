@@ -80,6 +80,14 @@ type Token struct {
 	Balance   string `json:"balance"`
 }
 
+type Utxo struct {
+	TxID          string `json:"txid"`
+	Vout          uint32 `json:"vout"`
+	Value         string `json:"value"`
+	Height        int64  `json:"height"`
+	Confirmations uint64 `json:"confirmations"`
+}
+
 type BlockchainStatus struct {
 	Backend Backend `json:"backend"`
 }
@@ -95,3 +103,14 @@ func (transaction Transaction) GetBlockHeight() uint64 {
 	}
 	return 0
 }
+
+type FeeEstimate struct {
+	Result string `json:"result"`
+}
+
+// Mempool is the mempool summary, the histogram buckets are [rate, vsize] by decreasing rate
+type Mempool struct {
+	Count        int64        `json:"count"`
+	VSize        int64        `json:"vsize"`
+	FeeHistogram [][2]float64 `json:"fee_histogram"`
+}
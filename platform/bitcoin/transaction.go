@@ -7,6 +7,7 @@ import (
 	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/numbers"
+	"github.com/trustwallet/blockatlas/pkg/types"
 )
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
@@ -29,6 +30,35 @@ func (p *Platform) GetTxsByXpub(xpub string) (blockatlas.TxPage, error) {
 	return txPage, nil
 }
 
+func (p *Platform) GetTx(id string) (*types.Tx, error) {
+	transaction, err := p.client.GetTransaction(id)
+	if err != nil {
+		return nil, err
+	}
+	tx := normalizeTransaction(transaction, p.CoinIndex)
+	return &tx, nil
+}
+
+func (p *Platform) GetUtxos(address string) ([]types.Utxo, error) {
+	utxos, err := p.client.GetUtxos(address)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeUtxos(utxos), nil
+}
+
+func normalizeUtxos(utxos []Utxo) []types.Utxo {
+	result := make([]types.Utxo, 0, len(utxos))
+	for _, u := range utxos {
+		utxo := types.Utxo{TxID: u.TxID, Vout: u.Vout, Value: types.Amount(u.Value), Confirmations: u.Confirmations}
+		if u.Height > 0 {
+			utxo.Height = uint64(u.Height)
+		}
+		result = append(result, utxo)
+	}
+	return result
+}
+
 func (p *Platform) getTxsByXpub(xpub string) ([]blockatlas.Tx, error) {
 	sourceTxs, err := p.client.GetTransactionsByXpub(xpub)
 
@@ -3,6 +3,8 @@ package bitcoin
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
 )
 
 const outgoingTx = `{
@@ -377,3 +380,34 @@ func TestParseOutputs(t *testing.T) {
 		})
 	}
 }
+
+func TestPlatform_GetTxAndUtxos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/tx/df63ddab7d4eed2fb6cb40d4d0519e7e5ac7cf5ad556b2edbd45963ea1a2931c":
+			_, _ = w.Write([]byte(outgoingTx))
+		case "/v2/utxo/3QJmV3qfvL9SuYo34YihAf3sRCW3qSinyC":
+			_, _ = w.Write([]byte(`[{"txid":"a1","vout":1,"value":"1000","height":585094,"confirmations":10},{"txid":"b2","vout":0,"value":"500","confirmations":0}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := Init(coin.BTC, server.URL, "")
+	tx, err := p.GetTx("df63ddab7d4eed2fb6cb40d4d0519e7e5ac7cf5ad556b2edbd45963ea1a2931c")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(585094), tx.Block)
+	assert.Equal(t, types.Amount("100188"), tx.Fee)
+	assert.Len(t, tx.Outputs, 1)
+
+	utxos, err := p.GetUtxos("3QJmV3qfvL9SuYo34YihAf3sRCW3qSinyC")
+	assert.Nil(t, err)
+	assert.Equal(t, []types.Utxo{
+		{TxID: "a1", Vout: 1, Value: "1000", Height: 585094, Confirmations: 10},
+		{TxID: "b2", Vout: 0, Value: "500"},
+	}, utxos)
+
+	_, err = p.GetTx("unknown")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,24 @@
+package platform
+
+import (
+	"sort"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+// Capabilities returns the features of the registered platforms, by coin ID
+func Capabilities() []blockatlas.CoinCapabilities {
+	capabilities := make([]blockatlas.CoinCapabilities, 0, len(Platforms))
+	for _, p := range Platforms {
+		caps := blockatlas.NewCoinCapabilities(p)
+		if _, ok := CollectionsAPIs[caps.Coin]; ok {
+			caps.Collectibles = true
+		}
+		caps.SelfHosted = isSelfHostedPlatform(caps.Handle)
+		capabilities = append(capabilities, caps)
+	}
+	sort.Slice(capabilities, func(i, j int) bool {
+		return capabilities[i].Coin < capabilities[j].Coin
+	})
+	return capabilities
+}
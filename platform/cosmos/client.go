@@ -85,6 +85,15 @@ func (c *Client) GetUnbondingDelegations(address string) (delegations UnbondingD
 	return
 }
 
+func (c *Client) GetRewards(address string) (rewards DelegatorRewards, err error) {
+	path := fmt.Sprintf("distribution/delegators/%s/rewards", address)
+	err = c.Get(&rewards, path, nil)
+	if err != nil {
+		logger.Error(err, "Cosmos: Failed to get rewards for address")
+	}
+	return
+}
+
 func (c *Client) GetAccount(address string) (result AuthAccount, err error) {
 	path := fmt.Sprintf("auth/accounts/%s", address)
 	err = c.Get(&result, path, nil)
@@ -156,6 +156,7 @@ type Validators struct {
 
 type Validator struct {
 	Status     int              `json:"status"`
+	Jailed     bool             `json:"jailed"`
 	Address    string           `json:"operator_address"`
 	Commission CosmosCommission `json:"commission"`
 }
@@ -197,6 +198,13 @@ type UnbondingDelegationEntry struct {
 	Balance          string `json:"balance"`
 }
 
+// DelegatorRewards are the rewards of a delegator not withdrawn yet, the amounts are decimal
+type DelegatorRewards struct {
+	Result struct {
+		Total []Amount `json:"total"`
+	} `json:"result"`
+}
+
 type StakingPool struct {
 	Pool Pool `json:"result"`
 }
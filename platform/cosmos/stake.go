@@ -7,6 +7,7 @@ import (
 	"github.com/trustwallet/blockatlas/pkg/logger"
 	"github.com/trustwallet/blockatlas/services/assets"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -119,6 +120,24 @@ func (p *Platform) UndelegatedBalance(address string) (string, error) {
 	return "0", nil
 }
 
+func (p *Platform) GetPendingRewards(address string) (string, error) {
+	rewards, err := p.client.GetRewards(address)
+	if err != nil {
+		return "0", err
+	}
+	return NormalizeRewards(rewards.Result.Total, p.Denom()), nil
+}
+
+// NormalizeRewards returns the rewards of the denom, without the fraction of the smallest unit
+func NormalizeRewards(total []Amount, denom DenomType) string {
+	for _, amount := range total {
+		if amount.Denom == string(denom) {
+			return strings.Split(amount.Quantity, ".")[0]
+		}
+	}
+	return "0"
+}
+
 func NormalizeDelegations(delegations []Delegation, validators blockatlas.ValidatorMap) []blockatlas.Delegation {
 	results := make([]blockatlas.Delegation, 0)
 	for _, v := range delegations {
@@ -164,9 +183,12 @@ func NormalizeUnbondingDelegations(delegations []UnbondingDelegation, validators
 
 func normalizeValidator(v Validator, p Pool, inflation float64) (validator blockatlas.Validator) {
 	reward := CalculateAnnualReward(p, inflation, v)
+	commission, _ := strconv.ParseFloat(v.Commission.Commision.Rate, 64)
 	return blockatlas.Validator{
-		Status: v.Status == 2,
-		ID:     v.Address,
+		Status:     v.Status == 2,
+		ID:         v.Address,
+		Commission: commission * 100,
+		Jailed:     v.Jailed,
 		Details: blockatlas.StakingDetails{
 			Reward:        blockatlas.StakingReward{Annual: reward},
 			MinimumAmount: minimumAmount,
@@ -71,8 +71,9 @@ func TestNormalizeValidator(t *testing.T) {
 	var v Validator
 	_ = json.Unmarshal([]byte(validatorSrc), &v)
 	expected := blockatlas.Validator{
-		Status: true,
-		ID:     v.Address,
+		Status:     true,
+		ID:         v.Address,
+		Commission: 7.04,
 		Details: blockatlas.StakingDetails{
 			Reward:        blockatlas.StakingReward{Annual: 462.6619201898575},
 			LockTime:      lockTime,
@@ -147,3 +148,27 @@ func TestNormalizeUnbondingDelegations(t *testing.T) {
 	result := NormalizeUnbondingDelegations(delegations, validatorMap)
 	assert.Equal(t, expected, result)
 }
+
+const rewardsSrc = `
+{
+  "height": "2345061",
+  "result": {
+    "rewards": [
+      {
+        "validator_address": "cosmosvaloper1qwl879nx9t6kef4supyazayf7vjhennyh568ys",
+        "reward": [{"denom": "uatom", "amount": "1514.482100000000000000"}]
+      }
+    ],
+    "total": [{"denom": "ukava", "amount": "3.5"}, {"denom": "uatom", "amount": "1514.482100000000000000"}]
+  }
+}`
+
+func TestNormalizeRewards(t *testing.T) {
+	var rewards DelegatorRewards
+	err := json.Unmarshal([]byte(rewardsSrc), &rewards)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1514", NormalizeRewards(rewards.Result.Total, DenomAtom))
+	assert.Equal(t, "3", NormalizeRewards(rewards.Result.Total, DenomKava))
+	assert.Equal(t, "0", NormalizeRewards(nil, DenomAtom))
+}
@@ -0,0 +1,43 @@
+package ethereum
+
+import (
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/address"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/numbers"
+)
+
+// balanceOfSelector is the ERC-20 balanceOf(address) function selector
+const balanceOfSelector = "0x70a08231"
+
+// GetBalance returns the balance of the address from the RPC node, of the ERC-20 contract
+// with a token, of the native coin otherwise
+func (p *Platform) GetBalance(addr, token string) (string, error) {
+	if p.RpcURL == "" {
+		return "", errors.E("no rpc endpoint", errors.Params{"coin": p.Coin().Handle})
+	}
+	var result string
+	if token == "" {
+		if err := p.rpc.RpcCall(&result, "eth_getBalance", []interface{}{addr, "latest"}); err != nil {
+			return "", err
+		}
+	} else {
+		params := []interface{}{
+			map[string]interface{}{"to": token, "data": balanceOfData(addr)},
+			"latest",
+		}
+		if err := p.rpc.RpcCall(&result, "eth_call", params); err != nil {
+			return "", err
+		}
+		if result == "0x" {
+			return "", errors.E("not a token contract", errors.Params{"token": token})
+		}
+	}
+	return numbers.HexToDecimal(result)
+}
+
+func balanceOfData(addr string) string {
+	hex := strings.ToLower(address.Remove0x(addr))
+	return balanceOfSelector + strings.Repeat("0", 64-len(hex)) + hex
+}
@@ -0,0 +1,42 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+func TestPlatform_GetBalance(t *testing.T) {
+	var calls []blockatlas.RpcRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req blockatlas.RpcRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		calls = append(calls, req)
+		result := "0x1fbad5f2e25570000"
+		if req.Method == "eth_call" {
+			result = "0x00000000000000000000000000000000000000000000000000000000000f4240"
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + result + `"}`))
+	}))
+	defer server.Close()
+
+	p := Init(coin.ETH, "", server.URL)
+	balance, err := p.GetBalance("0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "36582000000000000000", balance)
+
+	balance, err = p.GetBalance("0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1", "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000", balance)
+	assert.Equal(t, "eth_call", calls[1].Method)
+	call := calls[1].Params.([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "0x70a082310000000000000000000000007d8bf18c7ce84b3e175b339c4ca93aed1dd166f1", call["data"])
+
+	_, err = (&Platform{CoinIndex: coin.ETH}).GetBalance("0x0", "")
+	assert.NotNil(t, err)
+}
@@ -15,6 +15,8 @@ type Platform struct {
 	client      EthereumClient
 	collectible collection.Client
 	ens         ens.RpcClient
+	rpc         blockatlas.Request
+	explorer    ExplorerBackend
 }
 
 func Init(coinType uint, api, rpc string) *Platform {
@@ -22,6 +24,7 @@ func Init(coinType uint, api, rpc string) *Platform {
 		CoinIndex: coinType,
 		RpcURL:    rpc,
 		ens:       ens.RpcClient{Request: blockatlas.InitJSONClient(rpc)},
+		rpc:       blockatlas.InitJSONClient(rpc),
 		client:    &trustray.Client{Request: blockatlas.InitClient(api)},
 	}
 }
@@ -31,6 +34,7 @@ func InitWithBlockbook(coinType uint, blockbookApi, rpc string) *Platform {
 		CoinIndex: coinType,
 		RpcURL:    rpc,
 		ens:       ens.RpcClient{Request: blockatlas.InitJSONClient(rpc)},
+		rpc:       blockatlas.InitJSONClient(rpc),
 		client:    &blockbook.Client{Request: blockatlas.InitClient(blockbookApi)},
 	}
 }
@@ -0,0 +1,114 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const (
+	statusOK = "OK"
+
+	// MaxValidatorsPerRequest is the number of validators the explorer accepts in a request
+	MaxValidatorsPerRequest = 100
+
+	storeCache = time.Hour
+)
+
+// Client calls a beaconcha.in-compatible explorer of the Ethereum consensus layer
+type Client struct {
+	blockatlas.Request
+}
+
+// GetValidators returns the validators by index or public key
+func (c *Client) GetValidators(ids []string) ([]Validator, error) {
+	result := make([]Validator, 0, len(ids))
+	for _, chunk := range chunks(ids) {
+		var validators []Validator
+		if err := c.getList(&validators, "api/v1/validator/"+strings.Join(chunk, ",")); err != nil {
+			return nil, err
+		}
+		result = append(result, validators...)
+	}
+	return result, nil
+}
+
+// GetValidatorIndices returns the validators withdrawing to the address
+func (c *Client) GetValidatorIndices(address string) ([]ValidatorIndex, error) {
+	var indices []ValidatorIndex
+	err := c.getList(&indices, "api/v1/validator/withdrawalCredentials/"+address)
+	return indices, err
+}
+
+// GetAttestations returns the recent attestation duties of the validators by index
+func (c *Client) GetAttestations(indices []int64) ([]Attestation, error) {
+	ids := make([]string, 0, len(indices))
+	for _, index := range indices {
+		ids = append(ids, strconv.FormatInt(index, 10))
+	}
+	result := make([]Attestation, 0)
+	for _, chunk := range chunks(ids) {
+		var attestations []Attestation
+		if err := c.getList(&attestations, "api/v1/validator/"+strings.Join(chunk, ",")+"/attestations"); err != nil {
+			return nil, err
+		}
+		result = append(result, attestations...)
+	}
+	return result, nil
+}
+
+// GetStore returns the latest ETH.STORE, cached for an hour
+func (c *Client) GetStore() (Store, error) {
+	var resp Response
+	if err := c.GetWithCache(&resp, "api/v1/ethstore/latest", nil, storeCache); err != nil {
+		return Store{}, err
+	}
+	var store Store
+	if err := resp.decode(&store); err != nil {
+		return Store{}, err
+	}
+	return store, nil
+}
+
+// getList reads the data of the response into a list, the single object answered for one item included
+func (c *Client) getList(result interface{}, path string) error {
+	var resp Response
+	if err := c.Get(&resp, path, nil); err != nil {
+		return err
+	}
+	data := bytes.TrimSpace(resp.Data)
+	if len(data) > 0 && data[0] == '{' {
+		resp.Data = append(append([]byte{'['}, data...), ']')
+	}
+	return resp.decode(result)
+}
+
+func (r Response) decode(result interface{}) error {
+	if r.Status != statusOK {
+		return errors.E("beacon explorer request failed", errors.TypePlatformError, errors.Params{"status": r.Status})
+	}
+	if len(r.Data) == 0 || bytes.Equal(bytes.TrimSpace(r.Data), []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(r.Data, result); err != nil {
+		return errors.E(err, errors.TypePlatformUnmarshal)
+	}
+	return nil
+}
+
+func chunks(ids []string) [][]string {
+	result := make([][]string, 0, len(ids)/MaxValidatorsPerRequest+1)
+	for len(ids) > MaxValidatorsPerRequest {
+		result = append(result, ids[:MaxValidatorsPerRequest])
+		ids = ids[MaxValidatorsPerRequest:]
+	}
+	if len(ids) > 0 {
+		result = append(result, ids)
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package beacon
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+func TestClient_GetValidators(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if strings.Count(r.URL.Path, ",") == 0 {
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"validatorindex":1,"pubkey":"0xa1","balance":32000000000,"status":"active_online"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","data":[{"validatorindex":2,"balance":31000000000,"status":"exited"}]}`))
+	}))
+	defer server.Close()
+	client := Client{Request: blockatlas.InitClient(server.URL)}
+
+	validators, err := client.GetValidators([]string{"1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []Validator{{Index: 1, Pubkey: "0xa1", Balance: 32000000000, Status: StatusActiveOnline}}, validators)
+
+	ids := make([]string, 0, MaxValidatorsPerRequest*2+1)
+	for i := 0; i <= MaxValidatorsPerRequest*2; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	paths = nil
+	validators, err = client.GetValidators(ids)
+	assert.Nil(t, err)
+	assert.Len(t, paths, 3)
+	assert.Len(t, validators, 3)
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/ethstore/latest") {
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"apr":0.035}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"ERROR: invalid validator argument","data":null}`))
+	}))
+	defer server.Close()
+	client := Client{Request: blockatlas.InitClient(server.URL)}
+
+	_, err := client.GetValidatorIndices("0x0")
+	assert.NotNil(t, err)
+	store, err := client.GetStore()
+	assert.Nil(t, err)
+	assert.Equal(t, 0.035, store.APR)
+}
@@ -0,0 +1,57 @@
+package beacon
+
+import "encoding/json"
+
+const (
+	StatusPending        = "pending"
+	StatusDeposited      = "deposited"
+	StatusActiveOnline   = "active_online"
+	StatusActiveOffline  = "active_offline"
+	StatusExitingOnline  = "exiting_online"
+	StatusExitingOffline = "exiting_offline"
+	StatusExited         = "exited"
+	StatusSlashed        = "slashed"
+
+	AttestationMissed   = 0
+	AttestationIncluded = 1
+)
+
+type (
+	// Response is the envelope of the explorer, data holds an object for a single validator and a list otherwise
+	Response struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+
+	// Validator is a validator of the beacon chain, its balances in Gwei
+	Validator struct {
+		Index                 int64  `json:"validatorindex"`
+		Pubkey                string `json:"pubkey"`
+		Balance               uint64 `json:"balance"`
+		EffectiveBalance      uint64 `json:"effectivebalance"`
+		Status                string `json:"status"`
+		Slashed               bool   `json:"slashed"`
+		ActivationEpoch       int64  `json:"activationepoch"`
+		ExitEpoch             int64  `json:"exitepoch"`
+		WithdrawalCredentials string `json:"withdrawalcredentials"`
+	}
+
+	// ValidatorIndex is a validator withdrawing to an address
+	ValidatorIndex struct {
+		Pubkey string `json:"publickey"`
+		Index  int64  `json:"validatorindex"`
+	}
+
+	// Attestation is the duty of a validator in an epoch, included or missed
+	Attestation struct {
+		Index  int64 `json:"validatorindex"`
+		Epoch  int64 `json:"epoch"`
+		Slot   int64 `json:"attesterslot"`
+		Status int   `json:"status"`
+	}
+
+	// Store is the ETH.STORE of the explorer, the reference APR of the validators as a fraction
+	Store struct {
+		APR float64 `json:"apr"`
+	}
+)
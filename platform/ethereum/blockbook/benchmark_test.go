@@ -0,0 +1,78 @@
+package blockbook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/trustwallet/blockatlas/coin"
+)
+
+const (
+	benchTokenTransferTx = `{
+		"txid": "0xb1a32935f9b015bcfdda1b2e3d281b3780d1a6f7a2d4406e05ec2b826b2349cb",
+		"vin": [{"n": 0, "addresses": ["0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1"], "isAddress": true}],
+		"vout": [{"value": "0", "n": 0, "addresses": ["0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849"], "isAddress": true}],
+		"blockHeight": 8958320,
+		"blockTime": 1574107019,
+		"value": "0",
+		"fees": "227056700000000",
+		"tokenTransfers": [{
+			"type": "ERC20",
+			"from": "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1",
+			"to": "0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849",
+			"token": "0x89d24A6b4CcB1B6fAA2625fE562bDD9a23260359",
+			"name": "Sai Stablecoin v1.0",
+			"symbol": "SAI",
+			"decimals": 18,
+			"value": "1000000000000000000"
+		}],
+		"ethereumSpecific": {"status": 1, "nonce": 12, "gasLimit": 100000, "gasUsed": 54413, "gasPrice": "4172800000"}
+	}`
+	benchTransferTx = `{
+		"txid": "0x2a5d2ed5b876ba1ab1cda3f8a8ed3a5ea4e4e7762d5d5fcf1a31c118a5546f8e",
+		"vin": [{"n": 0, "addresses": ["0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1"], "isAddress": true}],
+		"vout": [{"value": "1500000000000000000", "n": 0, "addresses": ["0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849"], "isAddress": true}],
+		"blockHeight": 8958320,
+		"blockTime": 1574107019,
+		"value": "1500000000000000000",
+		"fees": "21000000000000",
+		"ethereumSpecific": {"status": 1, "nonce": 13, "gasLimit": 21000, "gasUsed": 21000, "gasPrice": "1000000000"}
+	}`
+)
+
+// largeBlock builds an EVM block of n transactions mixing token and native transfers
+func largeBlock(b *testing.B, n int) Block {
+	fixtures := make([]Transaction, 0, 2)
+	for _, src := range []string{benchTokenTransferTx, benchTransferTx} {
+		var tx Transaction
+		if err := json.Unmarshal([]byte(src), &tx); err != nil {
+			b.Fatal(err)
+		}
+		fixtures = append(fixtures, tx)
+	}
+	block := Block{Transactions: make([]Transaction, n)}
+	for i := range block.Transactions {
+		block.Transactions[i] = fixtures[i%len(fixtures)]
+	}
+	return block
+}
+
+func BenchmarkNormalizeTx_LargeBlock(b *testing.B) {
+	block := largeBlock(b, 500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range block.Transactions {
+			normalizeTx(&block.Transactions[j], coin.ETH)
+		}
+	}
+}
+
+func BenchmarkNormalizePage(b *testing.B) {
+	page := &Page{Transactions: largeBlock(b, 500).Transactions}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NormalizePage(page, "0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849", "", coin.ETH)
+	}
+}
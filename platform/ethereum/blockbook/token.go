@@ -33,5 +33,6 @@ func NormalizeToken(srcToken *Token, coinIndex uint) blockatlas.Token {
 		Coin:     coinIndex,
 		Decimals: srcToken.Decimals,
 		Type:     trustray.GetTokenTypeByIndex(coinIndex),
+		Balance:  srcToken.Balance,
 	}
 }
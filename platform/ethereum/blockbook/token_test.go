@@ -32,7 +32,7 @@ func TestNormalizeToken(t *testing.T) {
 					Type:    "ERC20",
 					Name:    "USD//C",
 					TokenID: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
-					Symbol:  "USDC", Decimals: 6, Coin: 60}},
+					Symbol:  "USDC", Decimals: 6, Coin: 60, Balance: "100"}},
 		},
 		{
 			name: "Should not return token with zero balance",
@@ -0,0 +1,126 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/address"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/pkg/wormhole"
+)
+
+const (
+	// wormholeTokenBridge sends the transfers with LogMessagePublished events of wormholeCoreBridge,
+	// and delivers the transfers coming back with the VAA in the input of completeTransfer
+	wormholeTokenBridge = "0x3ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc"
+	wormholeCoreBridge  = "0x98f3c9e6e3face36baad05fe09d375ef1464288b"
+	// logMessagePublishedTopic is LogMessagePublished(address indexed sender, uint64 sequence, uint32 nonce, bytes payload, uint8 consistencyLevel)
+	logMessagePublishedTopic = "0x6eb224fb001ed210e379b335e35efe88672a8ce935d981a6896b27ffdf52a3b2"
+)
+
+// completeTransferSelectors are completeTransfer(bytes), completeTransferAndUnwrapETH(bytes) and
+// completeTransferWithPayload(bytes) of the token bridge, the bytes are the VAA
+var completeTransferSelectors = map[string]bool{
+	"0xc6878519": true,
+	"0xff200cde": true,
+	"0xc3f511c1": true,
+}
+
+type (
+	rpcLog struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	}
+
+	rpcReceipt struct {
+		Logs []rpcLog `json:"logs"`
+	}
+
+	rpcTransaction struct {
+		To    string `json:"to"`
+		Input string `json:"input"`
+	}
+)
+
+// GetBridgeMessage returns the Wormhole message sent by a transfer to the token bridge, from the
+// LogMessagePublished event of its receipt, or delivered by completeTransfer, from the VAA of its input
+func (p *Platform) GetBridgeMessage(tx types.Tx) (*blockatlas.BridgeMessage, error) {
+	if p.CoinIndex != coin.ETH || p.RpcURL == "" {
+		return nil, nil
+	}
+	var receipt rpcReceipt
+	if err := p.rpc.RpcCall(&receipt, "eth_getTransactionReceipt", []string{tx.ID}); err != nil {
+		return nil, err
+	}
+	if m, ok := publishedMessage(receipt.Logs); ok {
+		return &blockatlas.BridgeMessage{Protocol: wormhole.Protocol, ID: m.ID()}, nil
+	}
+	var transaction rpcTransaction
+	if err := p.rpc.RpcCall(&transaction, "eth_getTransactionByHash", []string{tx.ID}); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(transaction.To, wormholeTokenBridge) {
+		return nil, nil
+	}
+	vaa, ok, err := completeTransferVAA(transaction.Input)
+	if err != nil || !ok {
+		return nil, err
+	}
+	m, err := wormhole.ParseVAA(vaa)
+	if err != nil {
+		return nil, err
+	}
+	return &blockatlas.BridgeMessage{Protocol: wormhole.Protocol, ID: m.ID(), Delivered: true}, nil
+}
+
+// publishedMessage finds the message published by the token bridge in the logs, the sequence
+// is the first word of the data
+func publishedMessage(logs []rpcLog) (wormhole.Message, bool) {
+	for _, l := range logs {
+		if !strings.EqualFold(l.Address, wormholeCoreBridge) || len(l.Topics) < 2 ||
+			!strings.EqualFold(l.Topics[0], logMessagePublishedTopic) {
+			continue
+		}
+		sender, err := hex.DecodeString(address.Remove0x(l.Topics[1]))
+		if err != nil || len(sender) != 32 || !strings.EqualFold("0x"+hex.EncodeToString(sender[12:]), wormholeTokenBridge) {
+			continue
+		}
+		data, err := hex.DecodeString(address.Remove0x(l.Data))
+		if err != nil || len(data) < 32 {
+			continue
+		}
+		m := wormhole.Message{EmitterChain: wormhole.ChainEthereum, Sequence: new(big.Int).SetBytes(data[:32]).Uint64()}
+		copy(m.EmitterAddress[:], sender)
+		return m, true
+	}
+	return wormhole.Message{}, false
+}
+
+// completeTransferVAA decodes the bytes argument of the completeTransfer calls: its offset,
+// then its length and content
+func completeTransferVAA(input string) ([]byte, bool, error) {
+	if len(input) < 10 || !completeTransferSelectors[strings.ToLower(input[:10])] {
+		return nil, false, nil
+	}
+	args, err := hex.DecodeString(input[10:])
+	if err != nil {
+		return nil, false, errors.E(err, "invalid completeTransfer input")
+	}
+	if len(args) < 32 {
+		return nil, false, errors.E("completeTransfer input too short")
+	}
+	offset := new(big.Int).SetBytes(args[:32]).Uint64()
+	if offset > uint64(len(args))-32 {
+		return nil, false, errors.E("completeTransfer input too short", errors.Params{"offset": offset})
+	}
+	length := new(big.Int).SetBytes(args[offset : offset+32]).Uint64()
+	if length > uint64(len(args))-offset-32 {
+		return nil, false, errors.E("completeTransfer input too short", errors.Params{"length": length})
+	}
+	return args[offset+32 : offset+32+length], true, nil
+}
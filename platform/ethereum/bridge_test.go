@@ -0,0 +1,91 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	// sentReceipt publishes the sequence 0x1a2b of the token bridge
+	sentReceipt = `{"logs":[
+		{"address":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],"data":"0x01"},
+		{"address":"0x98f3c9e6e3face36baad05fe09d375ef1464288b","topics":["0x6eb224fb001ed210e379b335e35efe88672a8ce935d981a6896b27ffdf52a3b2","0x0000000000000000000000003ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc"],
+		 "data":"0x0000000000000000000000000000000000000000000000000000000000001a2b0000000000000000000000000000000000000000000000000000000000000000"}]}`
+	// completeTransferInput delivers the sequence 74165 of the Solana token bridge, with one signature
+	completeTransferInput = "0xc6878519" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000007c" +
+		"0100000001" + "01" + "00000000000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"61d8b1f3000000000001ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f500000000000121b52001" +
+		"00000000"
+)
+
+func TestPlatform_GetBridgeMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req blockatlas.RpcRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		hash := req.Params.([]interface{})[0].(string)
+		result := `null`
+		switch {
+		case req.Method == "eth_getTransactionReceipt" && hash == "0xsent":
+			result = sentReceipt
+		case req.Method == "eth_getTransactionReceipt":
+			result = `{"logs":[]}`
+		case hash == "0xdelivered":
+			result = `{"to":"0x3ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc","input":"` + completeTransferInput + `"}`
+		case hash == "0xother":
+			result = `{"to":"0x3ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc","input":"0x0f5287b0"}`
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+	defer server.Close()
+	p := Init(coin.ETH, "", server.URL)
+
+	message, err := p.GetBridgeMessage(types.Tx{ID: "0xsent"})
+	assert.Nil(t, err)
+	assert.Equal(t, &blockatlas.BridgeMessage{
+		Protocol: "wormhole",
+		ID:       "2/0000000000000000000000003ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc/6699",
+	}, message)
+
+	message, err = p.GetBridgeMessage(types.Tx{ID: "0xdelivered"})
+	assert.Nil(t, err)
+	assert.Equal(t, &blockatlas.BridgeMessage{
+		Protocol:  "wormhole",
+		ID:        "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/74165",
+		Delivered: true,
+	}, message)
+
+	message, err = p.GetBridgeMessage(types.Tx{ID: "0xother"})
+	assert.Nil(t, err)
+	assert.Nil(t, message)
+
+	message, err = Init(coin.CLO, "", server.URL).GetBridgeMessage(types.Tx{ID: "0xsent"})
+	assert.Nil(t, err)
+	assert.Nil(t, message)
+}
+
+func TestCompleteTransferVAA(t *testing.T) {
+	vaa, ok, err := completeTransferVAA(completeTransferInput)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Len(t, vaa, 0x7c)
+
+	_, ok, err = completeTransferVAA("0x0f5287b0")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = completeTransferVAA(completeTransferInput[:10+64+64+20])
+	assert.NotNil(t, err)
+	_, _, err = completeTransferVAA("0xc6878519" + strings.Repeat("f", 64))
+	assert.NotNil(t, err)
+}
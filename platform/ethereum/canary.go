@@ -0,0 +1,223 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// maxDiffIDs limits the transaction ids listed by a diff log
+const maxDiffIDs = 5
+
+type (
+	// CanaryConfig runs a candidate explorer next to the configured ones, read from `<handle>.canary`
+	CanaryConfig struct {
+		Explorer ExplorerConfig `mapstructure:"explorer"`
+		// Split is the percentage of requests served by the canary
+		Split float64 `mapstructure:"split"`
+		// Diff is the percentage of requests also sent to the other side and compared,
+		// the comparison runs after the response and never delays it
+		Diff float64 `mapstructure:"diff"`
+	}
+
+	// canaryExplorer splits the traffic between the current and the candidate backends
+	// and logs the differences of their normalized transactions
+	canaryExplorer struct {
+		current, canary Explorer
+		split, diff     float64
+		random          func() float64
+		pending         sync.WaitGroup
+	}
+
+	txPageDiff struct {
+		missing []string
+		extra   []string
+		changed map[string][]string
+	}
+)
+
+// SetCanary serves a split of the requests with the canary explorer in front of the
+// explorers already set, and compares a sample of the responses of both sides
+func (p *Platform) SetCanary(canary Explorer, split, diff float64) {
+	current := Explorer{Name: ExplorerDefault, Backend: p.client}
+	if p.explorer != nil {
+		current = Explorer{Name: "current", Backend: p.explorer}
+	}
+	p.explorer = &canaryExplorer{current: current, canary: canary, split: split, diff: diff, random: rand.Float64}
+}
+
+func (c *canaryExplorer) GetTransactions(address string, coinIndex uint) (types.TxPage, error) {
+	return c.query(address, func(backend ExplorerBackend) (types.TxPage, error) {
+		return backend.GetTransactions(address, coinIndex)
+	})
+}
+
+func (c *canaryExplorer) GetTokenTxs(address, token string, coinIndex uint) (types.TxPage, error) {
+	return c.query(address, func(backend ExplorerBackend) (types.TxPage, error) {
+		return backend.GetTokenTxs(address, token, coinIndex)
+	})
+}
+
+// query serves the request from the side picked by the split, the canary falls back
+// to the current explorer on failure so a broken candidate never fails a request
+func (c *canaryExplorer) query(address string, call func(ExplorerBackend) (types.TxPage, error)) (types.TxPage, error) {
+	toCanary := c.random()*100 < c.split
+	served, other := c.current, c.canary
+	if toCanary {
+		served, other = c.canary, c.current
+	}
+	txs, err := call(served.Backend)
+	if err != nil && toCanary {
+		logger.Warn("Canary explorer failed, using the current one", logger.Params{"explorer": served.Name, "err": err.Error()})
+		return call(c.current.Backend)
+	}
+	if err != nil || c.random()*100 >= c.diff {
+		return txs, err
+	}
+
+	c.pending.Add(1)
+	go func() {
+		defer c.pending.Done()
+		otherTxs, err := call(other.Backend)
+		if err != nil {
+			logger.Warn("Canary comparison failed", logger.Params{"explorer": other.Name, "address": address, "err": err.Error()})
+			return
+		}
+		currentTxs, canaryTxs := txs, otherTxs
+		if toCanary {
+			currentTxs, canaryTxs = otherTxs, txs
+		}
+		c.logDiff(address, diffTxPages(currentTxs, canaryTxs))
+	}()
+	return txs, nil
+}
+
+func (c *canaryExplorer) logDiff(address string, diff txPageDiff) {
+	params := logger.Params{"current": c.current.Name, "canary": c.canary.Name, "address": address}
+	if diff.empty() {
+		logger.Info("Canary explorer matches", params)
+		return
+	}
+	params["missing"] = len(diff.missing)
+	params["extra"] = len(diff.extra)
+	params["changed"] = len(diff.changed)
+	params["missing_ids"] = firstIDs(diff.missing)
+	params["extra_ids"] = firstIDs(diff.extra)
+	for _, id := range firstIDs(sortedKeys(diff.changed)) {
+		params["changed_"+id] = diff.changed[id]
+	}
+	logger.Warn("Canary explorer differs", params)
+}
+
+// diffTxPages compares the pages by transaction id: the ids missing from and added by
+// the canary, and the JSON fields differing for the transactions in both pages.
+// Only the ids inside the range of blocks of both pages count as missing or extra,
+// explorers returning a different number of transactions are not reported.
+func diffTxPages(current, canary types.TxPage) txPageDiff {
+	diff := txPageDiff{changed: make(map[string][]string)}
+	currentByID := txsByID(current)
+	canaryByID := txsByID(canary)
+	minBlock := maxUint64(oldestBlock(current), oldestBlock(canary))
+
+	for id, tx := range currentByID {
+		candidate, ok := canaryByID[id]
+		if !ok {
+			if tx.Block >= minBlock {
+				diff.missing = append(diff.missing, id)
+			}
+			continue
+		}
+		if fields := changedFields(tx, candidate); len(fields) > 0 {
+			diff.changed[id] = fields
+		}
+	}
+	for id, tx := range canaryByID {
+		if _, ok := currentByID[id]; !ok && tx.Block >= minBlock {
+			diff.extra = append(diff.extra, id)
+		}
+	}
+	sort.Strings(diff.missing)
+	sort.Strings(diff.extra)
+	return diff
+}
+
+func (d txPageDiff) empty() bool {
+	return len(d.missing) == 0 && len(d.extra) == 0 && len(d.changed) == 0
+}
+
+func txsByID(txs types.TxPage) map[string]types.Tx {
+	result := make(map[string]types.Tx, len(txs))
+	for _, tx := range txs {
+		result[tx.ID] = tx
+	}
+	return result
+}
+
+func oldestBlock(txs types.TxPage) uint64 {
+	var oldest uint64
+	for i, tx := range txs {
+		if i == 0 || tx.Block < oldest {
+			oldest = tx.Block
+		}
+	}
+	return oldest
+}
+
+// changedFields returns the top level JSON fields differing between the transactions
+func changedFields(a, b types.Tx) []string {
+	fieldsA, errA := jsonObject(a)
+	fieldsB, errB := jsonObject(b)
+	if errA != nil || errB != nil {
+		return nil
+	}
+	changed := make([]string, 0)
+	for key, value := range fieldsA {
+		if string(fieldsB[key]) != string(value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range fieldsB {
+		if _, ok := fieldsA[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func jsonObject(v interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+func firstIDs(ids []string) []string {
+	if len(ids) > maxDiffIDs {
+		return ids[:maxDiffIDs]
+	}
+	return ids
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,52 @@
+package covalent
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const (
+	DefaultAPI      = "https://api.covalenthq.com"
+	defaultPageSize = "25"
+)
+
+type Client struct {
+	blockatlas.Request
+	Keys    *blockatlas.KeyPool
+	ChainID uint
+}
+
+func (c *Client) GetTxs(address string) ([]Transaction, error) {
+	path := fmt.Sprintf("v1/%d/address/%s/transactions_v2/", c.ChainID, address)
+	return c.getItems(path, url.Values{"no-logs": {"true"}})
+}
+
+func (c *Client) GetTransfers(address, contract string) ([]Transaction, error) {
+	path := fmt.Sprintf("v1/%d/address/%s/transfers_v2/", c.ChainID, address)
+	return c.getItems(path, url.Values{"contract-address": {contract}})
+}
+
+func (c *Client) getItems(path string, query url.Values) ([]Transaction, error) {
+	key, err := c.Keys.Next()
+	if err != nil {
+		return nil, err
+	}
+	query.Set("key", key)
+	query.Set("page-size", defaultPageSize)
+	var resp Response
+	if err := c.Get(&resp, path, query); err != nil {
+		return nil, err
+	}
+	if resp.Error {
+		if resp.ErrorCode == http.StatusTooManyRequests {
+			c.Keys.RateLimited(key)
+		}
+		return nil, errors.E("covalent request failed", errors.TypePlatformError,
+			errors.Params{"message": resp.ErrorMessage, "code": resp.ErrorCode})
+	}
+	return resp.Data.Items, nil
+}
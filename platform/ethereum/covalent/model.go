@@ -0,0 +1,40 @@
+package covalent
+
+import "time"
+
+type (
+	Response struct {
+		Data         Data   `json:"data"`
+		Error        bool   `json:"error"`
+		ErrorMessage string `json:"error_message"`
+		ErrorCode    int    `json:"error_code"`
+	}
+
+	Data struct {
+		Address string        `json:"address"`
+		Items   []Transaction `json:"items"`
+	}
+
+	Transaction struct {
+		BlockSignedAt time.Time  `json:"block_signed_at"`
+		BlockHeight   uint64     `json:"block_height"`
+		TxHash        string     `json:"tx_hash"`
+		Successful    bool       `json:"successful"`
+		FromAddress   string     `json:"from_address"`
+		ToAddress     string     `json:"to_address"`
+		Value         string     `json:"value"`
+		FeesPaid      string     `json:"fees_paid"`
+		Transfers     []Transfer `json:"transfers"`
+	}
+
+	// Transfer is a token transfer listed by the transfers_v2 endpoint
+	Transfer struct {
+		FromAddress          string `json:"from_address"`
+		ToAddress            string `json:"to_address"`
+		ContractDecimals     uint   `json:"contract_decimals"`
+		ContractName         string `json:"contract_name"`
+		ContractTickerSymbol string `json:"contract_ticker_symbol"`
+		ContractAddress      string `json:"contract_address"`
+		Delta                string `json:"delta"`
+	}
+)
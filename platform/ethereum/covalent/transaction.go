@@ -0,0 +1,77 @@
+package covalent
+
+import (
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/address"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func (c *Client) GetTransactions(address string, coinIndex uint) (types.TxPage, error) {
+	srcTxs, err := c.GetTxs(address)
+	if err != nil {
+		return nil, err
+	}
+	txs := make(types.TxPage, 0, len(srcTxs))
+	for _, srcTx := range srcTxs {
+		tx := normalizeTx(srcTx, coinIndex)
+		tx.Meta = types.Transfer{
+			Value:    types.Amount(srcTx.Value),
+			Symbol:   coin.Coins[coinIndex].Symbol,
+			Decimals: coin.Coins[coinIndex].Decimals,
+		}
+		tx.Direction = tx.GetTransactionDirection(address)
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// GetTokenTxs needs the token contract, Covalent only lists transfers of a given contract
+func (c *Client) GetTokenTxs(address, token string, coinIndex uint) (types.TxPage, error) {
+	if token == "" {
+		return nil, errors.E("token is required", errors.TypePlatformRequest)
+	}
+	srcTxs, err := c.GetTransfers(address, token)
+	if err != nil {
+		return nil, err
+	}
+	txs := make(types.TxPage, 0, len(srcTxs))
+	for _, srcTx := range srcTxs {
+		for _, transfer := range srcTx.Transfers {
+			tx := normalizeTx(srcTx, coinIndex)
+			tx.Meta = normalizeTransfer(transfer, coinIndex)
+			tx.Direction = tx.GetTransactionDirection(address)
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func normalizeTx(srcTx Transaction, coinIndex uint) types.Tx {
+	tx := types.Tx{
+		ID:     srcTx.TxHash,
+		Coin:   coinIndex,
+		From:   address.ToEIP55ByCoinID(srcTx.FromAddress, coinIndex),
+		To:     address.ToEIP55ByCoinID(srcTx.ToAddress, coinIndex),
+		Fee:    types.Amount(srcTx.FeesPaid),
+		Date:   srcTx.BlockSignedAt.Unix(),
+		Block:  srcTx.BlockHeight,
+		Status: types.StatusCompleted,
+	}
+	if !srcTx.Successful {
+		tx.Status = types.StatusError
+	}
+	return tx
+}
+
+func normalizeTransfer(transfer Transfer, coinIndex uint) types.TokenTransfer {
+	return types.TokenTransfer{
+		Name:     transfer.ContractName,
+		Symbol:   transfer.ContractTickerSymbol,
+		TokenID:  address.ToEIP55ByCoinID(transfer.ContractAddress, coinIndex),
+		Decimals: transfer.ContractDecimals,
+		Value:    types.Amount(transfer.Delta),
+		From:     address.ToEIP55ByCoinID(transfer.FromAddress, coinIndex),
+		To:       address.ToEIP55ByCoinID(transfer.ToAddress, coinIndex),
+	}
+}
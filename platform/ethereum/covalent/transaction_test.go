@@ -0,0 +1,74 @@
+package covalent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const srcTransfers = `{
+	"data": {
+		"address": "0x7d8bf18c7ce84b3e175b339c4ca93aed1dd166f1",
+		"items": [
+			{
+				"block_signed_at": "2020-01-29T13:55:07Z",
+				"block_height": 9354094,
+				"tx_hash": "0x7777854580f273df61e0162e1a41b3e1e05ab8b9f553036fa9329a90dd7e9ab2",
+				"successful": true,
+				"from_address": "0xc73e0383f3aff3215e6f04b0331d58cecf0ab849",
+				"to_address": "0xf3586684107ce0859c44aa2b2e0fb8cd8731a15a",
+				"value": "0",
+				"fees_paid": "103842000000000",
+				"transfers": [
+					{
+						"from_address": "0xc73e0383f3aff3215e6f04b0331d58cecf0ab849",
+						"to_address": "0x7d8bf18c7ce84b3e175b339c4ca93aed1dd166f1",
+						"contract_decimals": 7,
+						"contract_name": "KaratBank Coin",
+						"contract_ticker_symbol": "KBC",
+						"contract_address": "0xf3586684107ce0859c44aa2b2e0fb8cd8731a15a",
+						"delta": "4291000000"
+					}
+				]
+			}
+		]
+	},
+	"error": false,
+	"error_message": null,
+	"error_code": null
+}`
+
+func TestNormalizeTransfer(t *testing.T) {
+	var resp Response
+	assert.Nil(t, json.Unmarshal([]byte(srcTransfers), &resp))
+	assert.Len(t, resp.Data.Items, 1)
+
+	srcTx := resp.Data.Items[0]
+	tx := normalizeTx(srcTx, coin.ETH)
+	tx.Meta = normalizeTransfer(srcTx.Transfers[0], coin.ETH)
+	tx.Direction = tx.GetTransactionDirection("0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1")
+
+	assert.Equal(t, types.Tx{
+		ID:        "0x7777854580f273df61e0162e1a41b3e1e05ab8b9f553036fa9329a90dd7e9ab2",
+		Coin:      coin.ETH,
+		From:      "0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849",
+		To:        "0xf3586684107CE0859c44aa2b2E0fB8cd8731a15a",
+		Fee:       "103842000000000",
+		Date:      1580306107,
+		Block:     9354094,
+		Status:    types.StatusCompleted,
+		Direction: types.DirectionIncoming,
+		Meta: types.TokenTransfer{
+			Name:     "KaratBank Coin",
+			Symbol:   "KBC",
+			TokenID:  "0xf3586684107CE0859c44aa2b2E0fB8cd8731a15a",
+			Decimals: 7,
+			Value:    "4291000000",
+			From:     "0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849",
+			To:       "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1",
+		},
+	}, tx)
+}
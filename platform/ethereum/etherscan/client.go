@@ -0,0 +1,69 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const (
+	statusOK        = "1"
+	noTransactions  = "No transactions found"
+	defaultPageSize = "25"
+)
+
+// Client calls an Etherscan-compatible API (Etherscan, Blockscout), the base url includes the `/api` path
+type Client struct {
+	blockatlas.Request
+	Keys *blockatlas.KeyPool
+}
+
+func (c *Client) GetTxList(address string) ([]Transaction, error) {
+	return c.getTxs(url.Values{"action": {"txlist"}, "address": {address}})
+}
+
+func (c *Client) GetTokenTxList(address, contract string) ([]Transaction, error) {
+	query := url.Values{"action": {"tokentx"}, "address": {address}}
+	if contract != "" {
+		query.Set("contractaddress", contract)
+	}
+	return c.getTxs(query)
+}
+
+func (c *Client) getTxs(query url.Values) ([]Transaction, error) {
+	query.Set("module", "account")
+	query.Set("sort", "desc")
+	query.Set("page", "1")
+	query.Set("offset", defaultPageSize)
+	key, err := c.Keys.Next()
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		query.Set("apikey", key)
+	}
+	var resp Response
+	if err := c.Get(&resp, "", query); err != nil {
+		return nil, err
+	}
+	if resp.Status != statusOK {
+		if resp.Message == noTransactions {
+			return []Transaction{}, nil
+		}
+		var reason string
+		_ = json.Unmarshal(resp.Result, &reason)
+		if strings.Contains(strings.ToLower(reason), "rate limit") {
+			c.Keys.RateLimited(key)
+		}
+		return nil, errors.E("explorer request failed", errors.TypePlatformError,
+			errors.Params{"message": resp.Message, "reason": reason})
+	}
+	var txs []Transaction
+	if err := json.Unmarshal(resp.Result, &txs); err != nil {
+		return nil, errors.E(err, errors.TypePlatformUnmarshal)
+	}
+	return txs, nil
+}
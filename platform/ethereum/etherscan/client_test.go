@@ -0,0 +1,45 @@
+package etherscan
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+func TestClient_KeyRotation(t *testing.T) {
+	var used []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("apikey")
+		used = append(used, key)
+		if key == "limited" {
+			_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":[]}`))
+	}))
+	defer server.Close()
+
+	keys := blockatlas.NewKeyPool([]string{"limited", "valid"}, 0, time.Minute)
+	client := Client{Request: blockatlas.InitClient(server.URL), Keys: keys}
+
+	_, err := client.GetTxList("0x0")
+	assert.NotNil(t, err)
+	for i := 0; i < 2; i++ {
+		txs, err := client.GetTxList("0x0")
+		assert.Nil(t, err)
+		assert.Empty(t, txs)
+	}
+	assert.Equal(t, []string{"limited", "valid", "valid"}, used)
+	assert.Nil(t, testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(`
+# HELP atlas_upstream_key_rate_limited_total Upstream rejections of an API key of a key pool for its rate limit.
+# TYPE atlas_upstream_key_rate_limited_total counter
+atlas_upstream_key_rate_limited_total{key="7b508dac"} 1
+`), "atlas_upstream_key_rate_limited_total"))
+}
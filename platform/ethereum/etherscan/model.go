@@ -0,0 +1,32 @@
+package etherscan
+
+import "encoding/json"
+
+type (
+	// Response is the envelope of the Etherscan-compatible account module,
+	// result holds an error message instead of the list when the call fails
+	Response struct {
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+
+	Transaction struct {
+		BlockNumber     string `json:"blockNumber"`
+		TimeStamp       string `json:"timeStamp"`
+		Hash            string `json:"hash"`
+		Nonce           string `json:"nonce"`
+		From            string `json:"from"`
+		To              string `json:"to"`
+		Value           string `json:"value"`
+		Gas             string `json:"gas"`
+		GasPrice        string `json:"gasPrice"`
+		GasUsed         string `json:"gasUsed"`
+		IsError         string `json:"isError"`
+		Input           string `json:"input"`
+		ContractAddress string `json:"contractAddress"`
+		TokenName       string `json:"tokenName"`
+		TokenSymbol     string `json:"tokenSymbol"`
+		TokenDecimal    string `json:"tokenDecimal"`
+	}
+)
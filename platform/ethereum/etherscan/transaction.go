@@ -0,0 +1,90 @@
+package etherscan
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/address"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func (c *Client) GetTransactions(address string, coinIndex uint) (types.TxPage, error) {
+	srcTxs, err := c.GetTxList(address)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeTxs(srcTxs, address, coinIndex), nil
+}
+
+func (c *Client) GetTokenTxs(address, token string, coinIndex uint) (types.TxPage, error) {
+	srcTxs, err := c.GetTokenTxList(address, token)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeTxs(srcTxs, address, coinIndex), nil
+}
+
+func normalizeTxs(srcTxs []Transaction, address string, coinIndex uint) types.TxPage {
+	txs := make(types.TxPage, 0, len(srcTxs))
+	for _, srcTx := range srcTxs {
+		tx := normalizeTx(srcTx, coinIndex)
+		tx.Direction = tx.GetTransactionDirection(address)
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func normalizeTx(srcTx Transaction, coinIndex uint) types.Tx {
+	block, _ := strconv.ParseUint(srcTx.BlockNumber, 10, 64)
+	date, _ := strconv.ParseInt(srcTx.TimeStamp, 10, 64)
+	nonce, _ := strconv.ParseUint(srcTx.Nonce, 10, 64)
+
+	tx := types.Tx{
+		ID:       srcTx.Hash,
+		Coin:     coinIndex,
+		From:     address.ToEIP55ByCoinID(srcTx.From, coinIndex),
+		To:       address.ToEIP55ByCoinID(srcTx.To, coinIndex),
+		Fee:      types.Amount(calcFee(srcTx.GasPrice, srcTx.GasUsed)),
+		Date:     date,
+		Block:    block,
+		Status:   types.StatusCompleted,
+		Sequence: nonce,
+	}
+	if srcTx.IsError == "1" {
+		tx.Status = types.StatusError
+	}
+
+	switch {
+	case srcTx.TokenSymbol != "" || srcTx.TokenDecimal != "":
+		decimals, _ := strconv.ParseUint(srcTx.TokenDecimal, 10, 32)
+		tx.Meta = types.TokenTransfer{
+			Name:     srcTx.TokenName,
+			Symbol:   srcTx.TokenSymbol,
+			TokenID:  address.ToEIP55ByCoinID(srcTx.ContractAddress, coinIndex),
+			Decimals: uint(decimals),
+			Value:    types.Amount(srcTx.Value),
+			From:     tx.From,
+			To:       tx.To,
+		}
+	case srcTx.Input == "" || srcTx.Input == "0x":
+		tx.Meta = types.Transfer{
+			Value:    types.Amount(srcTx.Value),
+			Symbol:   coin.Coins[coinIndex].Symbol,
+			Decimals: coin.Coins[coinIndex].Decimals,
+		}
+	default:
+		tx.Meta = types.ContractCall{
+			Input: srcTx.Input,
+			Value: srcTx.Value,
+		}
+	}
+	return tx
+}
+
+func calcFee(gasPrice, gasUsed string) string {
+	var gasPriceBig, gasUsedBig, feeBig big.Int
+	gasPriceBig.SetString(gasPrice, 10)
+	gasUsedBig.SetString(gasUsed, 10)
+	return feeBig.Mul(&gasPriceBig, &gasUsedBig).String()
+}
@@ -0,0 +1,122 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const srcTxs = `[
+	{
+		"blockNumber": "9354093",
+		"timeStamp": "1580306107",
+		"hash": "0x1b3e2ab2794fa09ea3a0e85a7aa2c9a8bcd8fa1c27d25d2d9e5dffe81a0e8e64",
+		"nonce": "12",
+		"from": "0x7d8bf18c7ce84b3e175b339c4ca93aed1dd166f1",
+		"to": "0xc73e0383f3aff3215e6f04b0331d58cecf0ab849",
+		"value": "1000000000000000000",
+		"gas": "21000",
+		"gasPrice": "1000000000",
+		"gasUsed": "21000",
+		"isError": "0",
+		"input": "0x"
+	},
+	{
+		"blockNumber": "9354094",
+		"timeStamp": "1580306120",
+		"hash": "0x7777854580f273df61e0162e1a41b3e1e05ab8b9f553036fa9329a90dd7e9ab2",
+		"nonce": "3",
+		"from": "0xc73e0383f3aff3215e6f04b0331d58cecf0ab849",
+		"to": "0x7d8bf18c7ce84b3e175b339c4ca93aed1dd166f1",
+		"value": "4291000000",
+		"gas": "67497",
+		"gasPrice": "2000000000",
+		"gasUsed": "51921",
+		"isError": "0",
+		"input": "deprecated",
+		"contractAddress": "0xf3586684107ce0859c44aa2b2e0fb8cd8731a15a",
+		"tokenName": "KaratBank Coin",
+		"tokenSymbol": "KBC",
+		"tokenDecimal": "7"
+	},
+	{
+		"blockNumber": "9354095",
+		"timeStamp": "1580306130",
+		"hash": "0x34ab0028a9aa794d5cc12887e7b813cec17889948276b301028f24a408da6da4",
+		"nonce": "13",
+		"from": "0x7d8bf18c7ce84b3e175b339c4ca93aed1dd166f1",
+		"to": "0xf3586684107ce0859c44aa2b2e0fb8cd8731a15a",
+		"value": "0",
+		"gas": "100000",
+		"gasPrice": "1000000000",
+		"gasUsed": "30000",
+		"isError": "1",
+		"input": "0xa9059cbb"
+	}
+]`
+
+func TestNormalizeTxs(t *testing.T) {
+	var txs []Transaction
+	assert.Nil(t, json.Unmarshal([]byte(srcTxs), &txs))
+
+	page := normalizeTxs(txs, "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1", coin.ETH)
+	assert.Equal(t, types.TxPage{
+		{
+			ID:        "0x1b3e2ab2794fa09ea3a0e85a7aa2c9a8bcd8fa1c27d25d2d9e5dffe81a0e8e64",
+			Coin:      coin.ETH,
+			From:      "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1",
+			To:        "0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849",
+			Fee:       "21000000000000",
+			Date:      1580306107,
+			Block:     9354093,
+			Status:    types.StatusCompleted,
+			Sequence:  12,
+			Direction: types.DirectionOutgoing,
+			Meta: types.Transfer{
+				Value:    "1000000000000000000",
+				Symbol:   "ETH",
+				Decimals: 18,
+			},
+		},
+		{
+			ID:        "0x7777854580f273df61e0162e1a41b3e1e05ab8b9f553036fa9329a90dd7e9ab2",
+			Coin:      coin.ETH,
+			From:      "0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849",
+			To:        "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1",
+			Fee:       "103842000000000",
+			Date:      1580306120,
+			Block:     9354094,
+			Status:    types.StatusCompleted,
+			Sequence:  3,
+			Direction: types.DirectionIncoming,
+			Meta: types.TokenTransfer{
+				Name:     "KaratBank Coin",
+				Symbol:   "KBC",
+				TokenID:  "0xf3586684107CE0859c44aa2b2E0fB8cd8731a15a",
+				Decimals: 7,
+				Value:    "4291000000",
+				From:     "0xc73e0383F3Aff3215E6f04B0331D58CeCf0Ab849",
+				To:       "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1",
+			},
+		},
+		{
+			ID:        "0x34ab0028a9aa794d5cc12887e7b813cec17889948276b301028f24a408da6da4",
+			Coin:      coin.ETH,
+			From:      "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1",
+			To:        "0xf3586684107CE0859c44aa2b2E0fB8cd8731a15a",
+			Fee:       "30000000000000",
+			Date:      1580306130,
+			Block:     9354095,
+			Status:    types.StatusError,
+			Sequence:  13,
+			Direction: types.DirectionOutgoing,
+			Meta: types.ContractCall{
+				Input: "0xa9059cbb",
+				Value: "0",
+			},
+		},
+	}, page)
+}
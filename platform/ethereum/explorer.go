@@ -0,0 +1,127 @@
+package ethereum
+
+import (
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/platform/ethereum/blockbook"
+	"github.com/trustwallet/blockatlas/platform/ethereum/covalent"
+	"github.com/trustwallet/blockatlas/platform/ethereum/etherscan"
+	"github.com/trustwallet/blockatlas/platform/ethereum/trustray"
+)
+
+const (
+	ExplorerDefault    = "default"
+	ExplorerTrustray   = "trustray"
+	ExplorerBlockbook  = "blockbook"
+	ExplorerEtherscan  = "etherscan"
+	ExplorerBlockscout = "blockscout"
+	ExplorerCovalent   = "covalent"
+	ExplorerIndexed    = "indexed"
+)
+
+// keyCooldown is how long a key rejected by the explorer is left out of the rotation
+const keyCooldown = time.Minute
+
+// ErrNotSupported is returned by backends serving only part of the history,
+// the next explorer is used without reporting a failure
+var ErrNotSupported = errors.E("not supported by the explorer")
+
+type (
+	// ExplorerBackend is a source of address history
+	ExplorerBackend interface {
+		GetTransactions(address string, coinIndex uint) (types.TxPage, error)
+		GetTokenTxs(address, token string, coinIndex uint) (types.TxPage, error)
+	}
+
+	// ExplorerConfig selects and configures a backend, read from `<handle>.explorers`.
+	// The keys are rotated, each of them allowing `rate` requests per second
+	ExplorerConfig struct {
+		Name    string   `mapstructure:"name"`
+		API     string   `mapstructure:"api"`
+		Key     string   `mapstructure:"key"`
+		Keys    []string `mapstructure:"keys"`
+		Rate    int      `mapstructure:"rate"`
+		ChainID uint     `mapstructure:"chain_id"`
+	}
+
+	Explorer struct {
+		Name    string
+		Backend ExplorerBackend
+	}
+
+	// failoverExplorer queries the backends in order until one of them succeeds
+	failoverExplorer []Explorer
+)
+
+// NewExplorerBackend creates the backend of an explorer API,
+// the default and self-indexed backends are resolved by the caller
+func NewExplorerBackend(config ExplorerConfig) (ExplorerBackend, error) {
+	if config.API == "" && config.Name != ExplorerCovalent {
+		return nil, errors.E("explorer api is missing", errors.Params{"explorer": config.Name})
+	}
+	keys := blockatlas.NewKeyPool(append([]string{config.Key}, config.Keys...), config.Rate, keyCooldown)
+	switch config.Name {
+	case ExplorerTrustray:
+		return &trustray.Client{Request: blockatlas.InitClient(config.API)}, nil
+	case ExplorerBlockbook:
+		return &blockbook.Client{Request: blockatlas.InitClient(config.API)}, nil
+	case ExplorerEtherscan, ExplorerBlockscout:
+		return &etherscan.Client{Request: blockatlas.InitClient(config.API), Keys: keys}, nil
+	case ExplorerCovalent:
+		if config.API == "" {
+			config.API = covalent.DefaultAPI
+		}
+		if config.ChainID == 0 {
+			return nil, errors.E("covalent chain_id is missing")
+		}
+		if keys.Len() == 0 {
+			return nil, errors.E("covalent key is missing")
+		}
+		return &covalent.Client{Request: blockatlas.InitClient(config.API), Keys: keys, ChainID: config.ChainID}, nil
+	default:
+		return nil, errors.E("unknown explorer", errors.Params{"explorer": config.Name})
+	}
+}
+
+// SetExplorers replaces the history source of the platform,
+// the explorers are used in the given order and the next one is tried on failure
+func (p *Platform) SetExplorers(explorers ...Explorer) {
+	p.explorer = failoverExplorer(explorers)
+}
+
+func (p *Platform) DefaultExplorer() ExplorerBackend {
+	return p.client
+}
+
+func (f failoverExplorer) GetTransactions(address string, coinIndex uint) (types.TxPage, error) {
+	return f.query(func(backend ExplorerBackend) (types.TxPage, error) {
+		return backend.GetTransactions(address, coinIndex)
+	})
+}
+
+func (f failoverExplorer) GetTokenTxs(address, token string, coinIndex uint) (types.TxPage, error) {
+	return f.query(func(backend ExplorerBackend) (types.TxPage, error) {
+		return backend.GetTokenTxs(address, token, coinIndex)
+	})
+}
+
+func (f failoverExplorer) query(call func(ExplorerBackend) (types.TxPage, error)) (types.TxPage, error) {
+	var err error = errors.E("no explorer configured")
+	for _, e := range f {
+		var txs types.TxPage
+		txs, err = call(e.Backend)
+		if err == nil {
+			return txs, nil
+		}
+		if err == ErrNotSupported {
+			continue
+		}
+		logger.Warn("Explorer failed, trying the next one", logger.Params{"explorer": e.Name, "err": err.Error()})
+	}
+	return nil, err
+}
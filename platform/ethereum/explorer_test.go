@@ -0,0 +1,119 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type mockExplorer struct {
+	txs   types.TxPage
+	err   error
+	calls int
+}
+
+func (m *mockExplorer) GetTransactions(address string, coinIndex uint) (types.TxPage, error) {
+	m.calls++
+	return m.txs, m.err
+}
+
+func (m *mockExplorer) GetTokenTxs(address, token string, coinIndex uint) (types.TxPage, error) {
+	m.calls++
+	return m.txs, m.err
+}
+
+func TestPlatform_SetExplorers(t *testing.T) {
+	txs := types.TxPage{{ID: "0x1"}}
+	unsupported := &mockExplorer{err: ErrNotSupported}
+	failing := &mockExplorer{err: errors.E("rate limit")}
+	working := &mockExplorer{txs: txs}
+	unused := &mockExplorer{}
+
+	p := &Platform{CoinIndex: coin.ETH}
+	p.SetExplorers(
+		Explorer{Name: ExplorerIndexed, Backend: unsupported},
+		Explorer{Name: ExplorerEtherscan, Backend: failing},
+		Explorer{Name: ExplorerBlockscout, Backend: working},
+		Explorer{Name: ExplorerCovalent, Backend: unused},
+	)
+
+	got, err := p.GetTxsByAddress("0x0")
+	assert.Nil(t, err)
+	assert.Equal(t, txs, got)
+	assert.Equal(t, 1, unsupported.calls)
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 1, working.calls)
+	assert.Equal(t, 0, unused.calls)
+}
+
+func TestPlatform_SetExplorers_AllFailing(t *testing.T) {
+	p := &Platform{CoinIndex: coin.ETH}
+	p.SetExplorers(
+		Explorer{Name: ExplorerEtherscan, Backend: &mockExplorer{err: errors.E("timeout")}},
+		Explorer{Name: ExplorerCovalent, Backend: &mockExplorer{err: errors.E("rate limit")}},
+	)
+	_, err := p.GetTokenTxsByAddress("0x0", "0x1")
+	assert.EqualError(t, err, "rate limit")
+}
+
+func TestNewExplorerBackend(t *testing.T) {
+	_, err := NewExplorerBackend(ExplorerConfig{Name: ExplorerEtherscan, API: "https://api.etherscan.io/api", Key: "key"})
+	assert.Nil(t, err)
+	_, err = NewExplorerBackend(ExplorerConfig{Name: ExplorerCovalent, Key: "key"})
+	assert.NotNil(t, err)
+	_, err = NewExplorerBackend(ExplorerConfig{Name: ExplorerCovalent, Key: "key", ChainID: 1})
+	assert.Nil(t, err)
+	_, err = NewExplorerBackend(ExplorerConfig{Name: "unknown", API: "https://localhost"})
+	assert.NotNil(t, err)
+}
+
+func TestPlatform_SetCanary(t *testing.T) {
+	current := &mockExplorer{txs: types.TxPage{{ID: "0x1", Block: 10}}}
+	canary := &mockExplorer{txs: types.TxPage{{ID: "0x1", Block: 10}}}
+	p := &Platform{CoinIndex: coin.ETH}
+	p.SetExplorers(Explorer{Name: ExplorerEtherscan, Backend: current})
+	p.SetCanary(Explorer{Name: ExplorerCovalent, Backend: canary}, 50, 100)
+	c := p.explorer.(*canaryExplorer)
+
+	c.random = func() float64 { return 0.9 }
+	_, err := p.GetTxsByAddress("0x0")
+	assert.Nil(t, err)
+	c.pending.Wait()
+	assert.Equal(t, 1, current.calls)
+	assert.Equal(t, 1, canary.calls)
+
+	c.random = func() float64 { return 0.1 }
+	_, err = p.GetTxsByAddress("0x0")
+	assert.Nil(t, err)
+	c.pending.Wait()
+	assert.Equal(t, 2, current.calls)
+	assert.Equal(t, 2, canary.calls)
+
+	canary.err = errors.E("timeout")
+	c.diff = 0
+	got, err := p.GetTxsByAddress("0x0")
+	assert.Nil(t, err)
+	assert.Equal(t, current.txs, got)
+	assert.Equal(t, 3, current.calls)
+}
+
+func Test_diffTxPages(t *testing.T) {
+	current := types.TxPage{
+		{ID: "0x1", Block: 5},
+		{ID: "0x2", Block: 10, Fee: "1"},
+		{ID: "0x3", Block: 11},
+	}
+	canary := types.TxPage{
+		{ID: "0x2", Block: 10, Fee: "2"},
+		{ID: "0x4", Block: 12},
+	}
+	diff := diffTxPages(current, canary)
+	assert.Equal(t, []string{"0x3"}, diff.missing)
+	assert.Equal(t, []string{"0x4"}, diff.extra)
+	assert.Equal(t, map[string][]string{"0x2": {"fee"}}, diff.changed)
+
+	assert.True(t, diffTxPages(current, current).empty())
+}
@@ -0,0 +1,272 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/address"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	// transferSelector and transferFromSelector are the ERC-20 transfer(address,uint256) and
+	// transferFrom(address,address,uint256) function selectors
+	transferSelector     = "0xa9059cbb"
+	transferFromSelector = "0x23b872dd"
+
+	// transferTopic is the ERC-20 Transfer(address,address,uint256) event
+	transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	// errorSelector is the Error(string) revert reason of Solidity
+	errorSelector = "08c379a0"
+)
+
+type (
+	// traceCall is a frame of the callTracer of debug_traceCall, with its logs
+	traceCall struct {
+		Logs  []traceLog  `json:"logs"`
+		Calls []traceCall `json:"calls"`
+	}
+
+	traceLog struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	}
+)
+
+// Simulate dry-runs the transaction on the RPC node in one batch: eth_call for the failure reason, eth_estimateGas
+// and eth_gasPrice for the fee, debug_traceCall for the emitted transfers. The nodes without the debug namespace
+// only report the transfers decoded from the ERC-20 transfer and transferFrom calls.
+func (p *Platform) Simulate(tx types.SimulationRequest) (types.Simulation, error) {
+	if p.RpcURL == "" {
+		return types.Simulation{}, errors.E("no rpc endpoint", errors.Params{"coin": p.Coin().Handle})
+	}
+	call, err := callObject(tx)
+	if err != nil {
+		return types.Simulation{}, err
+	}
+	tracer := map[string]interface{}{"tracer": "callTracer", "tracerConfig": map[string]interface{}{"withLog": true}}
+	requests := blockatlas.RpcRequests{
+		{Method: "eth_call", Params: []interface{}{call, "latest"}},
+		{Method: "eth_estimateGas", Params: []interface{}{call}},
+		{Method: "eth_gasPrice", Params: []interface{}{}},
+		{Method: "debug_traceCall", Params: []interface{}{call, "latest", tracer}},
+	}
+	responses, err := p.rpc.RpcBatchCall(requests)
+	if err != nil {
+		return types.Simulation{}, err
+	}
+	byID := make(map[int64]blockatlas.RpcResponse, len(responses))
+	for _, r := range responses {
+		byID[r.Id] = r
+	}
+	callResp, estimateResp, priceResp, traceResp := byID[requests[0].Id], byID[requests[1].Id], byID[requests[2].Id], byID[requests[3].Id]
+
+	simulation := types.Simulation{
+		Coin:           p.CoinIndex,
+		Gas:            "0",
+		Fee:            "0",
+		BalanceChanges: make([]types.BalanceChange, 0),
+		TokenTransfers: make([]types.SimulatedTransfer, 0),
+	}
+	if callResp.Error != nil {
+		simulation.Error = revertReason(callResp.Error)
+		return simulation, nil
+	}
+	gas, ok := new(big.Int).SetString(tx.Gas, 10)
+	if !ok {
+		if estimateResp.Error != nil {
+			simulation.Error = revertReason(estimateResp.Error)
+			return simulation, nil
+		}
+		if gas, err = hexResult(estimateResp); err != nil {
+			return types.Simulation{}, err
+		}
+	}
+	gasPrice, ok := new(big.Int).SetString(tx.GasPrice, 10)
+	if !ok {
+		if gasPrice, err = hexResult(priceResp); err != nil {
+			return types.Simulation{}, err
+		}
+	}
+	fee := new(big.Int).Mul(gas, gasPrice)
+
+	var trace traceCall
+	if traceResp.Error == nil && traceResp.Result != nil && traceResp.GetObject(&trace) == nil {
+		simulation.TokenTransfers = trace.transfers()
+	} else if transfer, ok := decodeTransfer(tx); ok {
+		simulation.TokenTransfers = append(simulation.TokenTransfers, transfer)
+	}
+	simulation.Success = true
+	simulation.Gas = gas.String()
+	simulation.Fee = fee.String()
+	simulation.BalanceChanges = balanceChanges(tx, fee, simulation.TokenTransfers)
+	return simulation, nil
+}
+
+// callObject returns the transaction of eth_call, the decimal amounts converted to hex quantities
+func callObject(tx types.SimulationRequest) (map[string]interface{}, error) {
+	call := map[string]interface{}{"from": tx.From}
+	if tx.To != "" {
+		call["to"] = tx.To
+	}
+	if tx.Data != "" {
+		call["data"] = "0x" + address.Remove0x(tx.Data)
+	}
+	for key, value := range map[string]string{"value": tx.Value, "gas": tx.Gas, "gasPrice": tx.GasPrice} {
+		if value == "" {
+			continue
+		}
+		n, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, errors.E("invalid amount", errors.Params{key: value})
+		}
+		call[key] = "0x" + n.Text(16)
+	}
+	return call, nil
+}
+
+func hexResult(r blockatlas.RpcResponse) (*big.Int, error) {
+	if r.Error != nil {
+		return nil, errors.E(r.Error.Message, errors.Params{"error_code": r.Error.Code})
+	}
+	var result string
+	if err := r.GetObject(&result); err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(result, 0)
+	if !ok {
+		return nil, errors.E("invalid hex", errors.Params{"hex": result})
+	}
+	return n, nil
+}
+
+// revertReason returns the message of the node with the Error(string) reason of its data, when missing from it
+func revertReason(e *blockatlas.RpcError) string {
+	data, _ := e.Data.(string)
+	b, err := hex.DecodeString(address.Remove0x(data))
+	if err != nil || len(b) < 68 || hex.EncodeToString(b[:4]) != errorSelector {
+		return e.Message
+	}
+	size := new(big.Int).SetBytes(b[36:68])
+	if !size.IsInt64() || size.Int64() > int64(len(b)-68) {
+		return e.Message
+	}
+	reason := string(b[68 : 68+size.Int64()])
+	if reason == "" || strings.Contains(e.Message, reason) {
+		return e.Message
+	}
+	return e.Message + ": " + reason
+}
+
+// transfers returns the Transfer events of the frame and of its inner calls, in their order
+func (t traceCall) transfers() []types.SimulatedTransfer {
+	result := make([]types.SimulatedTransfer, 0)
+	for _, l := range t.Logs {
+		// ERC-721 transfers index their token ID as a fourth topic
+		if len(l.Topics) != 3 || strings.ToLower(l.Topics[0]) != transferTopic {
+			continue
+		}
+		value, ok := new(big.Int).SetString(address.Remove0x(l.Data), 16)
+		if !ok {
+			continue
+		}
+		result = append(result, types.SimulatedTransfer{
+			Token: address.EIP55Checksum(l.Address),
+			From:  topicAddress(l.Topics[1]),
+			To:    topicAddress(l.Topics[2]),
+			Value: value.String(),
+		})
+	}
+	for _, c := range t.Calls {
+		result = append(result, c.transfers()...)
+	}
+	return result
+}
+
+// decodeTransfer returns the transfer of an ERC-20 transfer or transferFrom call
+func decodeTransfer(tx types.SimulationRequest) (types.SimulatedTransfer, bool) {
+	data := strings.ToLower(address.Remove0x(tx.Data))
+	if tx.To == "" || len(data) < 8 {
+		return types.SimulatedTransfer{}, false
+	}
+	var words []string
+	for i := 8; i+64 <= len(data); i += 64 {
+		words = append(words, data[i:i+64])
+	}
+	transfer := types.SimulatedTransfer{Token: address.EIP55Checksum(tx.To)}
+	var value string
+	switch "0x" + data[:8] {
+	case transferSelector:
+		if len(words) != 2 {
+			return types.SimulatedTransfer{}, false
+		}
+		transfer.From, transfer.To, value = address.EIP55Checksum(tx.From), topicAddress(words[0]), words[1]
+	case transferFromSelector:
+		if len(words) != 3 {
+			return types.SimulatedTransfer{}, false
+		}
+		transfer.From, transfer.To, value = topicAddress(words[0]), topicAddress(words[1]), words[2]
+	default:
+		return types.SimulatedTransfer{}, false
+	}
+	amount, ok := new(big.Int).SetString(value, 16)
+	if !ok {
+		return types.SimulatedTransfer{}, false
+	}
+	transfer.Value = amount.String()
+	return transfer, true
+}
+
+// balanceChanges sums the value and the fee paid by the sender with the token transfers, by address and token.
+// The changes are in the order of their first transfer, the ones summing to zero are left out.
+func balanceChanges(tx types.SimulationRequest, fee *big.Int, transfers []types.SimulatedTransfer) []types.BalanceChange {
+	type key struct{ address, token string }
+	var order []key
+	sums := make(map[key]*big.Int)
+	add := func(addr, token string, amount *big.Int) {
+		k := key{address.EIP55Checksum(addr), token}
+		if _, ok := sums[k]; !ok {
+			order = append(order, k)
+			sums[k] = new(big.Int)
+		}
+		sums[k].Add(sums[k], amount)
+	}
+	value, ok := new(big.Int).SetString(tx.Value, 10)
+	if !ok {
+		value = new(big.Int)
+	}
+	add(tx.From, "", new(big.Int).Neg(new(big.Int).Add(value, fee)))
+	if tx.To != "" && value.Sign() > 0 {
+		add(tx.To, "", value)
+	}
+	for _, t := range transfers {
+		amount, ok := new(big.Int).SetString(t.Value, 10)
+		if !ok {
+			continue
+		}
+		add(t.From, t.Token, new(big.Int).Neg(amount))
+		add(t.To, t.Token, amount)
+	}
+	changes := make([]types.BalanceChange, 0, len(order))
+	for _, k := range order {
+		if sums[k].Sign() == 0 {
+			continue
+		}
+		changes = append(changes, types.BalanceChange{Address: k.address, Token: k.token, Amount: sums[k].String()})
+	}
+	return changes
+}
+
+// topicAddress returns the address of a 32 bytes word, its last 20 bytes
+func topicAddress(word string) string {
+	word = address.Remove0x(word)
+	if len(word) < 40 {
+		return ""
+	}
+	return address.EIP55Checksum("0x" + word[len(word)-40:])
+}
@@ -0,0 +1,116 @@
+package ethereum
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	simSender   = "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1"
+	simReceiver = "0x0000000000000000000000000000000000000001"
+	simToken    = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	// transfer(0x...01, 1000000)
+	simTransferData = transferSelector +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"00000000000000000000000000000000000000000000000000000000000f4240"
+	// Error(string) "insufficient balance"
+	simRevertData = "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000014" +
+		"696e73756666696369656e742062616c616e6365000000000000000000000000"
+)
+
+func simulationServer(responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []blockatlas.RpcRequest
+		_ = json.NewDecoder(r.Body).Decode(&requests)
+		result := make([]json.RawMessage, 0, len(requests))
+		// answered in reverse order, matched by id
+		for i := len(requests) - 1; i >= 0; i-- {
+			id, _ := json.Marshal(requests[i].Id)
+			body, ok := responses[requests[i].Method]
+			if !ok {
+				body = `"error":{"code":-32601,"message":"the method does not exist"}`
+			}
+			result = append(result, json.RawMessage(`{"jsonrpc":"2.0","id":`+string(id)+`,`+body+`}`))
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+}
+
+func TestPlatform_Simulate(t *testing.T) {
+	server := simulationServer(map[string]string{
+		"eth_call":        `"result":"0x"`,
+		"eth_estimateGas": `"result":"0x5208"`,
+		"eth_gasPrice":    `"result":"0x3b9aca00"`,
+	})
+	defer server.Close()
+
+	p := Init(coin.ETH, "", server.URL)
+	simulation, err := p.Simulate(types.SimulationRequest{From: simSender, To: simReceiver, Value: "1000"})
+	assert.Nil(t, err)
+	assert.Equal(t, types.Simulation{
+		Coin:    coin.ETH,
+		Success: true,
+		Gas:     "21000",
+		Fee:     "21000000000000",
+		BalanceChanges: []types.BalanceChange{
+			{Address: simSender, Amount: "-21000000001000"},
+			{Address: simReceiver, Amount: "1000"},
+		},
+		TokenTransfers: []types.SimulatedTransfer{},
+	}, simulation)
+
+	// without debug_traceCall, the transfer is decoded from the call
+	simulation, err = p.Simulate(types.SimulationRequest{From: simSender, To: simToken, Data: simTransferData, GasPrice: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "21000", simulation.Fee)
+	assert.Equal(t, []types.SimulatedTransfer{{Token: simToken, From: simSender, To: simReceiver, Value: "1000000"}}, simulation.TokenTransfers)
+	assert.Equal(t, []types.BalanceChange{
+		{Address: simSender, Amount: "-21000"},
+		{Address: simSender, Token: simToken, Amount: "-1000000"},
+		{Address: simReceiver, Token: simToken, Amount: "1000000"},
+	}, simulation.BalanceChanges)
+
+	_, err = (&Platform{CoinIndex: coin.ETH}).Simulate(types.SimulationRequest{From: simSender})
+	assert.NotNil(t, err)
+}
+
+func TestPlatform_Simulate_Trace(t *testing.T) {
+	server := simulationServer(map[string]string{
+		"eth_call":        `"result":"0x"`,
+		"eth_estimateGas": `"result":"0x5208"`,
+		"eth_gasPrice":    `"result":"0x1"`,
+		"debug_traceCall": `"result":{"calls":[{"logs":[{"address":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","topics":["` + transferTopic + `",` +
+			`"0x0000000000000000000000007d8bf18c7ce84b3e175b339c4ca93aed1dd166f1","0x0000000000000000000000000000000000000000000000000000000000000001"],` +
+			`"data":"0x00000000000000000000000000000000000000000000000000000000000f4240"}]}]}`,
+	})
+	defer server.Close()
+
+	simulation, err := Init(coin.ETH, "", server.URL).Simulate(types.SimulationRequest{From: simSender, To: simReceiver, Data: "0x01"})
+	assert.Nil(t, err)
+	assert.True(t, simulation.Success)
+	assert.Equal(t, []types.SimulatedTransfer{{Token: simToken, From: simSender, To: simReceiver, Value: "1000000"}}, simulation.TokenTransfers)
+}
+
+func TestPlatform_Simulate_Revert(t *testing.T) {
+	server := simulationServer(map[string]string{
+		"eth_call":     `"error":{"code":3,"message":"execution reverted","data":"` + simRevertData + `"}`,
+		"eth_gasPrice": `"result":"0x1"`,
+	})
+	defer server.Close()
+
+	simulation, err := Init(coin.ETH, "", server.URL).Simulate(types.SimulationRequest{From: simSender, To: simToken, Data: simTransferData})
+	assert.Nil(t, err)
+	assert.False(t, simulation.Success)
+	assert.Equal(t, "execution reverted: insufficient balance", simulation.Error)
+	assert.Empty(t, simulation.BalanceChanges)
+	assert.Empty(t, simulation.TokenTransfers)
+}
@@ -0,0 +1,200 @@
+package ethereum
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/platform/ethereum/beacon"
+)
+
+const (
+	// MinimumStakeAmount is the deposit of a validator, 32 ETH
+	MinimumStakeAmount = "32000000000000000000"
+	// LockTime is the shortest exit of a validator in seconds: 256 epochs of 32 slots of 12 seconds
+	LockTime = 256 * 32 * 12
+
+	gwei = 1000000000
+)
+
+var (
+	validatorIndex  = regexp.MustCompile(`^[0-9]+$`)
+	validatorPubkey = regexp.MustCompile(`^0x[0-9a-fA-F]{96}$`)
+)
+
+type (
+	// StakingPlatform is the Ethereum platform serving the consensus-layer staking: the validators of a
+	// withdrawal address, or given by comma-separated indices or public keys, are its delegations
+	StakingPlatform struct {
+		*Platform
+		beacon beacon.Client
+	}
+
+	// ValidatorMetadata is the state of a validator in the metadata of its delegation, the balances in wei.
+	// Attestation is its latest attestation duty, when the explorer reports one
+	ValidatorMetadata struct {
+		Index            int64                `json:"index"`
+		Pubkey           string               `json:"pubkey"`
+		Status           string               `json:"status"`
+		EffectiveBalance string               `json:"effective_balance"`
+		Slashed          bool                 `json:"slashed,omitempty"`
+		Attestation      *AttestationMetadata `json:"attestation,omitempty"`
+	}
+
+	AttestationMetadata struct {
+		Epoch  int64  `json:"epoch"`
+		Status string `json:"status"`
+	}
+)
+
+func InitWithBeacon(p *Platform, beaconAPI string) *StakingPlatform {
+	return &StakingPlatform{Platform: p, beacon: beacon.Client{Request: blockatlas.InitClient(beaconAPI)}}
+}
+
+func (p *StakingPlatform) GetDetails() types.StakingDetails {
+	return types.StakingDetails{
+		Reward:        types.StakingReward{Annual: p.annualReward()},
+		MinimumAmount: MinimumStakeAmount,
+		LockTime:      LockTime,
+		Type:          types.DelegationTypeDelegate,
+	}
+}
+
+func (p *StakingPlatform) annualReward() float64 {
+	store, err := p.beacon.GetStore()
+	if err != nil {
+		logger.Error(err, "Failed to get the Ethereum staking APR")
+		return types.DefaultAnnualReward
+	}
+	return store.APR * 100
+}
+
+// GetValidators is empty, the stake of the consensus layer is not delegated to validators
+func (p *StakingPlatform) GetValidators() (types.ValidatorPage, error) {
+	return make(types.ValidatorPage, 0), nil
+}
+
+func (p *StakingPlatform) GetActiveValidators() (types.StakeValidators, error) {
+	return make(types.StakeValidators, 0), nil
+}
+
+func (p *StakingPlatform) GetDelegations(address string) (types.DelegationsPage, error) {
+	ids, err := p.validatorIDs(address)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return make(types.DelegationsPage, 0), nil
+	}
+	validators, err := p.beacon.GetValidators(ids)
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]int64, 0, len(validators))
+	for _, v := range validators {
+		indices = append(indices, v.Index)
+	}
+	attestations, err := p.beacon.GetAttestations(indices)
+	if err != nil {
+		logger.Error(err, "Failed to get the attestations of the validators", logger.Params{"address": address})
+	}
+	return NormalizeValidators(validators, latestAttestations(attestations), p.GetDetails()), nil
+}
+
+// UndelegatedBalance is the balance of the withdrawal address, 0 for validators given by index or public key
+func (p *StakingPlatform) UndelegatedBalance(address string) (string, error) {
+	if isValidatorList(address) {
+		return "0", nil
+	}
+	return p.GetBalance(address, "")
+}
+
+// validatorIDs returns the validators of the address, by index or public key
+func (p *StakingPlatform) validatorIDs(address string) ([]string, error) {
+	if isValidatorList(address) {
+		return strings.Split(address, ","), nil
+	}
+	indices, err := p.beacon.GetValidatorIndices(address)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(indices))
+	for _, i := range indices {
+		ids = append(ids, strconv.FormatInt(i.Index, 10))
+	}
+	return ids, nil
+}
+
+func isValidatorList(address string) bool {
+	for _, id := range strings.Split(address, ",") {
+		if !validatorIndex.MatchString(id) && !validatorPubkey.MatchString(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func latestAttestations(attestations []beacon.Attestation) map[int64]beacon.Attestation {
+	latest := make(map[int64]beacon.Attestation)
+	for _, a := range attestations {
+		if l, ok := latest[a.Index]; !ok || a.Epoch > l.Epoch {
+			latest[a.Index] = a
+		}
+	}
+	return latest
+}
+
+// NormalizeValidators maps the validators to delegations: active while attesting, pending before their
+// activation and while exiting. The exited validators already withdrawn are left out.
+func NormalizeValidators(validators []beacon.Validator, attestations map[int64]beacon.Attestation, details types.StakingDetails) types.DelegationsPage {
+	result := make(types.DelegationsPage, 0, len(validators))
+	for _, v := range validators {
+		if v.Balance == 0 && (v.Status == beacon.StatusExited || v.Status == beacon.StatusSlashed) {
+			continue
+		}
+		status := types.DelegationStatusPending
+		active := v.Status == beacon.StatusActiveOnline || v.Status == beacon.StatusActiveOffline
+		if active {
+			status = types.DelegationStatusActive
+		}
+		id := strconv.FormatInt(v.Index, 10)
+		metadata := ValidatorMetadata{
+			Index:            v.Index,
+			Pubkey:           v.Pubkey,
+			Status:           v.Status,
+			EffectiveBalance: toWei(v.EffectiveBalance),
+			Slashed:          v.Slashed,
+		}
+		if a, ok := attestations[v.Index]; ok {
+			metadata.Attestation = &AttestationMetadata{Epoch: a.Epoch, Status: attestationStatus(a.Status)}
+		}
+		result = append(result, types.Delegation{
+			Delegator: types.StakeValidator{
+				ID:      id,
+				Status:  active,
+				Info:    types.StakeValidatorInfo{Name: "Validator " + id, Description: v.Pubkey},
+				Details: details,
+			},
+			Value:    toWei(v.Balance),
+			Status:   status,
+			Metadata: metadata,
+		})
+	}
+	return result
+}
+
+func attestationStatus(status int) string {
+	if status == beacon.AttestationIncluded {
+		return "attested"
+	}
+	return "missed"
+}
+
+func toWei(balance uint64) string {
+	return new(big.Int).Mul(new(big.Int).SetUint64(balance), big.NewInt(gwei)).String()
+}
@@ -0,0 +1,87 @@
+package ethereum
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/platform/ethereum/beacon"
+)
+
+func TestNormalizeValidators(t *testing.T) {
+	validators := []beacon.Validator{
+		{Index: 7, Pubkey: "0xa7", Balance: 32100000000, EffectiveBalance: 32000000000, Status: beacon.StatusActiveOnline},
+		{Index: 8, Pubkey: "0xa8", Balance: 32000000000, EffectiveBalance: 32000000000, Status: beacon.StatusExitingOffline},
+		{Index: 9, Pubkey: "0xa9", Status: beacon.StatusExited},
+	}
+	attestations := latestAttestations([]beacon.Attestation{
+		{Index: 7, Epoch: 100, Status: beacon.AttestationIncluded},
+		{Index: 7, Epoch: 101, Status: beacon.AttestationMissed},
+		{Index: 7, Epoch: 99, Status: beacon.AttestationIncluded},
+	})
+	details := types.StakingDetails{MinimumAmount: MinimumStakeAmount}
+
+	result := NormalizeValidators(validators, attestations, details)
+	if !assert.Len(t, result, 2) {
+		return
+	}
+	assert.Equal(t, types.Delegation{
+		Delegator: types.StakeValidator{
+			ID:      "7",
+			Status:  true,
+			Info:    types.StakeValidatorInfo{Name: "Validator 7", Description: "0xa7"},
+			Details: details,
+		},
+		Value:  "32100000000000000000",
+		Status: types.DelegationStatusActive,
+		Metadata: ValidatorMetadata{
+			Index:            7,
+			Pubkey:           "0xa7",
+			Status:           beacon.StatusActiveOnline,
+			EffectiveBalance: "32000000000000000000",
+			Attestation:      &AttestationMetadata{Epoch: 101, Status: "missed"},
+		},
+	}, result[0])
+	assert.Equal(t, types.DelegationStatusPending, result[1].Status)
+	assert.False(t, result[1].Delegator.Status)
+	assert.Nil(t, result[1].Metadata.(ValidatorMetadata).Attestation)
+}
+
+func TestStakingPlatform_GetDelegations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/v1/validator/withdrawalCredentials/"):
+			_, _ = w.Write([]byte(`{"status":"OK","data":[{"publickey":"0xa7","validatorindex":7}]}`))
+		case r.URL.Path == "/api/v1/validator/7":
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"validatorindex":7,"pubkey":"0xa7","balance":32000000000,"status":"active_online"}}`))
+		case r.URL.Path == "/api/v1/validator/7/attestations":
+			_, _ = w.Write([]byte(`{"status":"OK","data":[{"validatorindex":7,"epoch":10,"status":1}]}`))
+		case r.URL.Path == "/api/v1/ethstore/latest":
+			_, _ = w.Write([]byte(`{"status":"OK","data":{"apr":0.04}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	p := InitWithBeacon(&Platform{CoinIndex: coin.ETH}, server.URL)
+
+	for _, address := range []string{"0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1", "7"} {
+		delegations, err := p.GetDelegations(address)
+		assert.Nil(t, err)
+		if assert.Len(t, delegations, 1) {
+			assert.Equal(t, "32000000000000000000", delegations[0].Value)
+			assert.Equal(t, "attested", delegations[0].Metadata.(ValidatorMetadata).Attestation.Status)
+			assert.Equal(t, 4.0, delegations[0].Delegator.Details.Reward.Annual)
+		}
+	}
+	balance, err := p.UndelegatedBalance("7,0x" + strings.Repeat("ab", 48))
+	assert.Nil(t, err)
+	assert.Equal(t, "0", balance)
+
+	var _ blockatlas.StakeAPI = p
+}
@@ -5,9 +5,15 @@ import (
 )
 
 func (p *Platform) GetTxsByAddress(address string) (blockatlas.TxPage, error) {
+	if p.explorer != nil {
+		return p.explorer.GetTransactions(address, p.CoinIndex)
+	}
 	return p.client.GetTransactions(address, p.CoinIndex)
 }
 
 func (p *Platform) GetTokenTxsByAddress(address string, token string) (blockatlas.TxPage, error) {
+	if p.explorer != nil {
+		return p.explorer.GetTokenTxs(address, token, p.CoinIndex)
+	}
 	return p.client.GetTokenTxs(address, token, p.CoinIndex)
 }
@@ -0,0 +1,49 @@
+package trustray
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// largeBlock builds a block of n transactions cycling through the test fixtures,
+// close to the shape of a busy mainnet block
+func largeBlock(b *testing.B, n int) []Doc {
+	fixtures := []string{tokenTransferSrc, contractCallSrc, transferSrc, failedSrc}
+	docs := make([]Doc, 0, len(fixtures))
+	for _, src := range fixtures {
+		var doc Doc
+		if err := json.Unmarshal([]byte(src), &doc); err != nil {
+			b.Fatal(err)
+		}
+		docs = append(docs, doc)
+	}
+	block := make([]Doc, n)
+	for i := range block {
+		block[i] = docs[i%len(docs)]
+	}
+	return block
+}
+
+func BenchmarkAppendTxs_LargeBlock(b *testing.B) {
+	block := largeBlock(b, 500)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var txs []types.Tx
+		for j := range block {
+			txs = AppendTxs(txs, &block[j], coin.ETH)
+		}
+	}
+}
+
+func BenchmarkNormalizePage(b *testing.B) {
+	page := &Page{Docs: largeBlock(b, 500)}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		normalizePage(page, "0xaA4D790076f1Bf7511a0A0AC498C89e13e1eFE17", coin.ETH)
+	}
+}
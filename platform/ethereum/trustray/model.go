@@ -17,11 +17,11 @@ type TokenPage struct {
 type Doc struct {
 	Ops         []Op   `json:"operations"`
 	Contract    string `json:"contract"`
-	ID          string `json:"id"`
+	ID          string `json:"id,required"`
 	BlockNumber uint64 `json:"blockNumber"`
 	Timestamp   int64  `json:"time"`
 	Nonce       uint64 `json:"nonce"`
-	From        string `json:"from"`
+	From        string `json:"from,required"`
 	To          string `json:"to"`
 	Value       string `json:"value"`
 	Gas         string `json:"gas"`
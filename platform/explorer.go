@@ -0,0 +1,107 @@
+package platform
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/platform/ethereum"
+)
+
+// InitExplorers sets the history source of the EVM platforms listed in `<handle>.explorers`.
+// The self-indexed explorer is available only when the index is given, coins configured
+// in `indexer.coins` use it before their default explorer
+func InitExplorers(index ethereum.ExplorerBackend) {
+	for handle, api := range Platforms {
+		var p *ethereum.Platform
+		switch api := api.(type) {
+		case *ethereum.Platform:
+			p = api
+		case *ethereum.StakingPlatform:
+			p = api.Platform
+		default:
+			continue
+		}
+		initExplorers(handle, p, index)
+		initCanary(handle, p, index)
+	}
+}
+
+func initExplorers(handle string, p *ethereum.Platform, index ethereum.ExplorerBackend) {
+	var configs []ethereum.ExplorerConfig
+	if err := viper.UnmarshalKey(fmt.Sprintf("%s.explorers", handle), &configs); err != nil {
+		logger.Fatal(err, "Invalid explorers config", logger.Params{"handle": handle})
+	}
+	if len(configs) == 0 {
+		if index == nil || !viper.IsSet("indexer.coins."+handle) {
+			return
+		}
+		configs = []ethereum.ExplorerConfig{{Name: ethereum.ExplorerIndexed}, {Name: ethereum.ExplorerDefault}}
+	}
+
+	explorers := make([]ethereum.Explorer, 0, len(configs))
+	for _, config := range configs {
+		backend, err := explorerBackend(p, config, index)
+		if err != nil {
+			logger.Fatal(err, logger.Params{"handle": handle})
+		}
+		explorers = append(explorers, ethereum.Explorer{Name: config.Name, Backend: backend})
+	}
+	p.SetExplorers(explorers...)
+	logger.Info("Explorers", logger.Params{"handle": handle, "count": len(explorers)})
+}
+
+// initCanary puts the explorer of `<handle>.canary` in front of the configured history source
+func initCanary(handle string, p *ethereum.Platform, index ethereum.ExplorerBackend) {
+	key := fmt.Sprintf("%s.canary", handle)
+	if !viper.IsSet(key) {
+		return
+	}
+	var config ethereum.CanaryConfig
+	if err := viper.UnmarshalKey(key, &config); err != nil {
+		logger.Fatal(err, "Invalid canary config", logger.Params{"handle": handle})
+	}
+	backend, err := explorerBackend(p, config.Explorer, index)
+	if err != nil {
+		logger.Fatal(err, logger.Params{"handle": handle})
+	}
+	p.SetCanary(ethereum.Explorer{Name: config.Explorer.Name, Backend: backend}, config.Split, config.Diff)
+	logger.Info("Canary explorer", logger.Params{"handle": handle, "explorer": config.Explorer.Name, "split": config.Split, "diff": config.Diff})
+}
+
+func explorerBackend(p *ethereum.Platform, config ethereum.ExplorerConfig, index ethereum.ExplorerBackend) (ethereum.ExplorerBackend, error) {
+	switch config.Name {
+	case ethereum.ExplorerDefault:
+		return p.DefaultExplorer(), nil
+	case ethereum.ExplorerIndexed:
+		if index == nil {
+			return nil, errors.E("indexed explorer requires indexer.enabled")
+		}
+		return index, nil
+	default:
+		if SelfHosted && !IsSelfHostedURL(config.API) {
+			return nil, errors.E("third-party explorer in self-hosted mode", errors.Params{"explorer": config.Name})
+		}
+		return ethereum.NewExplorerBackend(config)
+	}
+}
+
+// explorerAPIs returns the APIs of the explorers and the canary of the platform
+func explorerAPIs(handle string) []string {
+	var configs []ethereum.ExplorerConfig
+	_ = viper.UnmarshalKey(fmt.Sprintf("%s.explorers", handle), &configs)
+	var canary ethereum.CanaryConfig
+	if key := fmt.Sprintf("%s.canary", handle); viper.IsSet(key) && viper.UnmarshalKey(key, &canary) == nil {
+		configs = append(configs, canary.Explorer)
+	}
+	apis := make([]string, 0, len(configs))
+	for _, config := range configs {
+		switch config.Name {
+		case ethereum.ExplorerDefault, ethereum.ExplorerIndexed:
+		default:
+			apis = append(apis, config.API)
+		}
+	}
+	return apis
+}
@@ -0,0 +1,18 @@
+package aave
+
+import (
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+const ProviderID = "aave"
+
+// Provider serves the reserves of Aave v2 on Ethereum from the Aave v2 subgraph
+type Provider struct {
+	client Client
+}
+
+func Init(api string) *Provider {
+	return &Provider{
+		client: Client{blockatlas.InitJSONClient(api)},
+	}
+}
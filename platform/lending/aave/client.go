@@ -0,0 +1,89 @@
+package aave
+
+import (
+	"context"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const reserveFields = `symbol name decimals underlyingAsset liquidityRate variableBorrowRate availableLiquidity
+	utilizationRate reserveLiquidationThreshold isActive isFrozen price { priceInEth }`
+
+const reservesQuery = `{ reserves(first: 100) { id ` + reserveFields + ` } }`
+
+const userReservesQuery = `query($users: [String!]) {
+	userReserves(where: {user_in: $users}) {
+		user { id }
+		reserve { id ` + reserveFields + ` }
+		currentATokenBalance currentVariableDebt currentStableDebt stableBorrowRate usageAsCollateralEnabledOnUser
+	}
+}`
+
+const userEventsQuery = `query($user: String!) {
+	deposits(first: 1000, where: {user: $user}, orderBy: timestamp) { id amount timestamp reserve { symbol } }
+	redeemUnderlyings(first: 1000, where: {user: $user}, orderBy: timestamp) { id amount timestamp reserve { symbol } }
+}`
+
+type Client struct {
+	blockatlas.Request
+}
+
+func (c *Client) GetReserves(ctx context.Context) ([]Reserve, error) {
+	var response struct {
+		GraphQLResponse
+		Data struct {
+			Reserves []Reserve `json:"reserves"`
+		} `json:"data"`
+	}
+	if err := c.query(&response, &response.GraphQLResponse, GraphQLRequest{Query: reservesQuery}, ctx); err != nil {
+		return nil, err
+	}
+	return response.Data.Reserves, nil
+}
+
+// GetUserReserves returns the positions of the addresses, the subgraph IDs of the users are lowercase
+func (c *Client) GetUserReserves(addresses []string, ctx context.Context) ([]UserReserve, error) {
+	users := make([]string, 0, len(addresses))
+	for _, a := range addresses {
+		users = append(users, strings.ToLower(a))
+	}
+	var response struct {
+		GraphQLResponse
+		Data struct {
+			UserReserves []UserReserve `json:"userReserves"`
+		} `json:"data"`
+	}
+	req := GraphQLRequest{Query: userReservesQuery, Variables: map[string]interface{}{"users": users}}
+	if err := c.query(&response, &response.GraphQLResponse, req, ctx); err != nil {
+		return nil, err
+	}
+	return response.Data.UserReserves, nil
+}
+
+func (c *Client) query(result interface{}, response *GraphQLResponse, req GraphQLRequest, ctx context.Context) error {
+	if err := c.PostWithContext(result, "", req, ctx); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return errors.E("aave subgraph error", errors.TypePlatformRequest, errors.Params{"error": response.Errors[0].Message})
+	}
+	return nil
+}
+
+// GetUserEvents returns the deposits and the withdrawals of the address
+func (c *Client) GetUserEvents(address string, ctx context.Context) (deposits, withdrawals []UserEvent, err error) {
+	var response struct {
+		GraphQLResponse
+		Data struct {
+			Deposits          []UserEvent `json:"deposits"`
+			RedeemUnderlyings []UserEvent `json:"redeemUnderlyings"`
+		} `json:"data"`
+	}
+	req := GraphQLRequest{Query: userEventsQuery, Variables: map[string]interface{}{"user": strings.ToLower(address)}}
+	if err := c.query(&response, &response.GraphQLResponse, req, ctx); err != nil {
+		return nil, nil, err
+	}
+	return response.Data.Deposits, response.Data.RedeemUnderlyings, nil
+}
@@ -0,0 +1,242 @@
+package aave
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/lending"
+)
+
+var providerInfo = types.ProviderInfo{
+	ID:          ProviderID,
+	Description: "Aave v2 liquidity protocol",
+	Image:       "https://aave.com/favicon.ico",
+	Website:     "https://aave.com",
+	RiskTier:    types.RiskLow,
+}
+
+var (
+	ray = new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil))
+	wei = new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+)
+
+func (p *Provider) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	reserves, err := p.client.GetReserves(ctx)
+	if err != nil {
+		return types.LendingProvider{}, blockatlas.NewLendingError(err, ctx)
+	}
+	assets := make([]types.AssetInfo, 0, len(reserves))
+	for _, r := range reserves {
+		assets = append(assets, assetInfo(r))
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Symbol < assets[j].Symbol })
+	return types.LendingProvider{
+		ID:     ProviderID,
+		Info:   providerInfo,
+		Type:   types.ProviderTypeLending,
+		Assets: assets,
+	}, nil
+}
+
+func (p *Provider) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	reserves, err := p.client.GetReserves(ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	rates := make(types.LendingRates, 0, len(reserves))
+	for _, r := range reserves {
+		if !selected(assets, r.Symbol) {
+			continue
+		}
+		rates = append(rates, types.LendingAssetRates{
+			Asset:        r.Symbol,
+			MaxAPY:       percent(r.LiquidityRate),
+			MinBorrowAPY: percent(r.VariableBorrowRate),
+			Status:       status(r),
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Asset < rates[j].Asset })
+	return rates, nil
+}
+
+// GetAccountLendingContracts returns the aToken balances and the debts of the addresses. The subgraph has
+// no principal, the start amounts are the current ones until the deposits of GetAccountLendingEvents are added.
+// The risk counts every reserve, not only the requested assets.
+func (p *Provider) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	result := make([]types.AccountLendingContracts, 0, len(req.Addresses))
+	if len(req.Addresses) == 0 {
+		return &result, nil
+	}
+	userReserves, err := p.client.GetUserReserves(req.Addresses, ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	byUser := make(map[string][]UserReserve, len(req.Addresses))
+	for _, ur := range userReserves {
+		byUser[ur.User.ID] = append(byUser[ur.User.ID], ur)
+	}
+	for _, address := range req.Addresses {
+		result = append(result, accountContracts(address, byUser[strings.ToLower(address)], req.Assets))
+	}
+	return &result, nil
+}
+
+// GetAccountLendingEvents returns the deposits and the withdrawals of the address by date
+func (p *Provider) GetAccountLendingEvents(address string, ctx context.Context) ([]types.LendingEvent, error) {
+	deposits, withdrawals, err := p.client.GetUserEvents(address, ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	events := make([]types.LendingEvent, 0, len(deposits)+len(withdrawals))
+	for _, d := range deposits {
+		events = append(events, lendingEvent(types.LendingDeposit, d))
+	}
+	for _, w := range withdrawals {
+		events = append(events, lendingEvent(types.LendingWithdraw, w))
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date < events[j].Date })
+	return events, nil
+}
+
+func lendingEvent(t types.LendingEventType, e UserEvent) types.LendingEvent {
+	return types.LendingEvent{
+		Type:  t,
+		Asset: e.Reserve.Symbol,
+		Value: types.Amount(e.Amount),
+		Date:  e.Timestamp,
+		Hash:  strings.Split(e.ID, ":")[0],
+	}
+}
+
+func accountContracts(address string, userReserves []UserReserve, assets []string) types.AccountLendingContracts {
+	account := types.AccountLendingContracts{Address: address, Contracts: make([]types.LendingContract, 0)}
+	var (
+		collateral []lending.Collateral
+		borrowed   float64
+	)
+	sort.Slice(userReserves, func(i, j int) bool { return userReserves[i].Reserve.Symbol < userReserves[j].Reserve.Symbol })
+	for _, ur := range userReserves {
+		r := ur.Reserve
+		price := ratio(r.Price.PriceInEth, wei)
+		balance := integer(ur.CurrentATokenBalance)
+		variableDebt, stableDebt := integer(ur.CurrentVariableDebt), integer(ur.CurrentStableDebt)
+		debt := new(big.Int).Add(variableDebt, stableDebt)
+
+		if balance.Sign() > 0 {
+			if ur.UsageAsCollateralEnabledOnUser {
+				collateral = append(collateral, lending.Collateral{
+					Asset:                r.Symbol,
+					Amount:               units(balance, r.Decimals),
+					Price:                price,
+					LiquidationThreshold: ratio(r.ReserveLiquidationThreshold, big.NewFloat(10000)),
+				})
+			}
+			if selected(assets, r.Symbol) {
+				account.Contracts = append(account.Contracts, types.LendingContract{
+					Asset:         r.Symbol,
+					StartAmount:   types.Amount(balance.String()),
+					CurrentAmount: types.Amount(balance.String()),
+					CurrentAPY:    percent(r.LiquidityRate),
+				})
+			}
+		}
+		if debt.Sign() > 0 {
+			borrowed += units(debt, r.Decimals) * price
+			if selected(assets, r.Symbol) {
+				account.Borrows = append(account.Borrows, types.BorrowPosition{
+					Asset:         r.Symbol,
+					StartAmount:   types.Amount(debt.String()),
+					CurrentAmount: types.Amount(debt.String()),
+					CurrentAPY:    borrowAPY(variableDebt, stableDebt, r.VariableBorrowRate, ur.StableBorrowRate),
+				})
+			}
+		}
+	}
+	account.Risk = lending.LiquidationRisk(collateral, borrowed)
+	return account
+}
+
+func assetInfo(r Reserve) types.AssetInfo {
+	return types.AssetInfo{
+		Symbol:        r.Symbol,
+		Chain:         coin.Ethereum().Symbol,
+		Description:   "Aave " + r.Name,
+		MinimumAmount: "0",
+		Decimals:      r.Decimals,
+		APY:           percent(r.LiquidityRate),
+		Status:        status(r),
+		Borrow: &types.BorrowInfo{
+			APY:                percent(r.VariableBorrowRate),
+			Utilization:        ratio(r.UtilizationRate, big.NewFloat(1)) * 100,
+			AvailableLiquidity: types.Amount(integer(r.AvailableLiquidity).String()),
+		},
+	}
+}
+
+// status of the reserve: inactive reserves reject every operation, frozen ones the deposits and borrows
+func status(r Reserve) types.MarketStatus {
+	switch {
+	case !r.IsActive:
+		return types.MarketPaused
+	case r.IsFrozen:
+		return types.MarketFrozen
+	default:
+		return types.MarketActive
+	}
+}
+
+// borrowAPY is the rate of the debt, the variable and stable rates weighted by their debts
+func borrowAPY(variableDebt, stableDebt *big.Int, variableRate, stableRate string) float64 {
+	variable, _ := new(big.Float).SetInt(variableDebt).Float64()
+	stable, _ := new(big.Float).SetInt(stableDebt).Float64()
+	if variable+stable == 0 {
+		return 0
+	}
+	return (variable*percent(variableRate) + stable*percent(stableRate)) / (variable + stable)
+}
+
+func selected(assets []string, symbol string) bool {
+	if len(assets) == 0 {
+		return true
+	}
+	for _, a := range assets {
+		if strings.EqualFold(a, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// percent converts a ray rate to a percentage
+func percent(rate string) float64 {
+	return ratio(rate, ray) * 100
+}
+
+func ratio(value string, unit *big.Float) float64 {
+	v, _, err := big.ParseFloat(value, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(v, unit).Float64()
+	return f
+}
+
+func integer(value string) *big.Int {
+	i, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return i
+}
+
+// units converts an amount in the smallest unit of the asset to the asset
+func units(value *big.Int, decimals uint) float64 {
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(value), new(big.Float).SetInt(exp)).Float64()
+	return f
+}
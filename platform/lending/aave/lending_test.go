@@ -0,0 +1,192 @@
+package aave
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const reservesResponse = `{
+  "data": {
+    "reserves": [
+      {
+        "id": "0x6b175474e89094c44da98b954eedeac495271d0f0xb53c1a33016b2dc2ff3653530bff1848a515c8c5",
+        "symbol": "DAI",
+        "name": "Dai Stablecoin",
+        "decimals": 18,
+        "underlyingAsset": "0x6b175474e89094c44da98b954eedeac495271d0f",
+        "liquidityRate": "35000000000000000000000000",
+        "variableBorrowRate": "50000000000000000000000000",
+        "availableLiquidity": "1000000000000000000000",
+        "utilizationRate": "0.8",
+        "reserveLiquidationThreshold": "8000",
+        "isActive": true,
+        "isFrozen": false,
+        "price": {"priceInEth": "500000000000000"}
+      },
+      {
+        "id": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc20xb53c1a33016b2dc2ff3653530bff1848a515c8c5",
+        "symbol": "WETH",
+        "name": "Wrapped Ether",
+        "decimals": 18,
+        "underlyingAsset": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+        "liquidityRate": "2000000000000000000000000",
+        "variableBorrowRate": "30000000000000000000000000",
+        "availableLiquidity": "5000000000000000000000",
+        "utilizationRate": "0.2",
+        "reserveLiquidationThreshold": "8250",
+        "isActive": true,
+        "isFrozen": true,
+        "price": {"priceInEth": "1000000000000000000"}
+      }
+    ]
+  }
+}`
+
+const userReservesResponse = `{
+  "data": {
+    "userReserves": [
+      {
+        "user": {"id": "0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"},
+        "reserve": {
+          "symbol": "WETH", "name": "Wrapped Ether", "decimals": 18, "liquidityRate": "2000000000000000000000000",
+          "variableBorrowRate": "30000000000000000000000000", "reserveLiquidationThreshold": "8250",
+          "isActive": true, "price": {"priceInEth": "1000000000000000000"}
+        },
+        "currentATokenBalance": "2000000000000000000",
+        "currentVariableDebt": "0",
+        "currentStableDebt": "0",
+        "stableBorrowRate": "0",
+        "usageAsCollateralEnabledOnUser": true
+      },
+      {
+        "user": {"id": "0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"},
+        "reserve": {
+          "symbol": "DAI", "name": "Dai Stablecoin", "decimals": 18, "liquidityRate": "35000000000000000000000000",
+          "variableBorrowRate": "50000000000000000000000000", "reserveLiquidationThreshold": "8000",
+          "isActive": true, "price": {"priceInEth": "500000000000000"}
+        },
+        "currentATokenBalance": "0",
+        "currentVariableDebt": "1000000000000000000000",
+        "currentStableDebt": "1000000000000000000000",
+        "stableBorrowRate": "70000000000000000000000000",
+        "usageAsCollateralEnabledOnUser": false
+      }
+    ]
+  }
+}`
+
+const userEventsResponse = `{
+  "data": {
+    "deposits": [
+      {"id": "0xaa:12", "amount": "1500000000000000000", "timestamp": 1600000000, "reserve": {"symbol": "WETH"}},
+      {"id": "0xcc:3", "amount": "1000000000000000000", "timestamp": 1620000000, "reserve": {"symbol": "WETH"}}
+    ],
+    "redeemUnderlyings": [
+      {"id": "0xbb:7", "amount": "600000000000000000", "timestamp": 1610000000, "reserve": {"symbol": "WETH"}}
+    ]
+  }
+}`
+
+func mockProvider(t *testing.T) (*Provider, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		switch {
+		case strings.Contains(req.Query, "deposits"):
+			assert.Equal(t, "0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9", req.Variables["user"])
+			_, _ = w.Write([]byte(userEventsResponse))
+		case strings.Contains(req.Query, "userReserves"):
+			assert.Equal(t, []interface{}{"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"}, req.Variables["users"])
+			_, _ = w.Write([]byte(userReservesResponse))
+		case strings.Contains(req.Query, "reserves"):
+			_, _ = w.Write([]byte(reservesResponse))
+		}
+	}))
+	return Init(server.URL), server.Close
+}
+
+func TestProvider_GetProviderInfo(t *testing.T) {
+	p, closeServer := mockProvider(t)
+	defer closeServer()
+
+	info, err := p.GetProviderInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, ProviderID, info.ID)
+	assert.Len(t, info.Assets, 2)
+
+	dai := info.Assets[0]
+	assert.Equal(t, "DAI", dai.Symbol)
+	assert.Equal(t, "ETH", dai.Chain)
+	assert.InDelta(t, 3.5, dai.APY, 1e-9)
+	assert.Equal(t, types.MarketActive, dai.Status)
+	assert.InDelta(t, 5, dai.Borrow.APY, 1e-9)
+	assert.InDelta(t, 80, dai.Borrow.Utilization, 1e-9)
+	assert.Equal(t, types.Amount("1000000000000000000000"), dai.Borrow.AvailableLiquidity)
+	assert.Equal(t, types.MarketFrozen, info.Assets[1].Status)
+}
+
+func TestProvider_GetCurrentLendingRates(t *testing.T) {
+	p, closeServer := mockProvider(t)
+	defer closeServer()
+
+	rates, err := p.GetCurrentLendingRates([]string{"weth"}, context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, rates, 1)
+	assert.Equal(t, "WETH", rates[0].Asset)
+	assert.InDelta(t, 0.2, rates[0].MaxAPY, 1e-9)
+	assert.InDelta(t, 3, rates[0].MinBorrowAPY, 1e-9)
+	assert.Equal(t, types.MarketFrozen, rates[0].Status)
+}
+
+func TestProvider_GetAccountLendingContracts(t *testing.T) {
+	p, closeServer := mockProvider(t)
+	defer closeServer()
+
+	accounts, err := p.GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x7D2768dE32b0b80b7a3454c06BdAc94A69DDc7A9"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, *accounts, 1)
+	account := (*accounts)[0]
+	assert.Equal(t, "0x7D2768dE32b0b80b7a3454c06BdAc94A69DDc7A9", account.Address)
+	assert.Len(t, account.Contracts, 1)
+	assert.Equal(t, "WETH", account.Contracts[0].Asset)
+	assert.Equal(t, types.Amount("2000000000000000000"), account.Contracts[0].CurrentAmount)
+	assert.InDelta(t, 0.2, account.Contracts[0].CurrentAPY, 1e-9)
+
+	assert.Len(t, account.Borrows, 1)
+	assert.Equal(t, types.Amount("2000000000000000000000"), account.Borrows[0].CurrentAmount)
+	// Half variable at 5%, half stable at 7%
+	assert.InDelta(t, 6, account.Borrows[0].CurrentAPY, 1e-9)
+
+	// 2 ETH at 0.825 against 2000 DAI at 0.0005 ETH
+	assert.InDelta(t, 1.65, account.Risk.HealthFactor, 1e-9)
+}
+
+func TestProvider_GetAccountLendingEvents(t *testing.T) {
+	p, closeServer := mockProvider(t)
+	defer closeServer()
+
+	events, err := p.GetAccountLendingEvents("0x7D2768dE32b0b80b7a3454c06BdAc94A69DDc7A9", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []types.LendingEvent{
+		{Type: types.LendingDeposit, Asset: "WETH", Value: "1500000000000000000", Date: 1600000000, Hash: "0xaa"},
+		{Type: types.LendingWithdraw, Asset: "WETH", Value: "600000000000000000", Date: 1610000000, Hash: "0xbb"},
+		{Type: types.LendingDeposit, Asset: "WETH", Value: "1000000000000000000", Date: 1620000000, Hash: "0xcc"},
+	}, events)
+}
+
+func TestProvider_SubgraphError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errors": [{"message": "indexing error"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := Init(server.URL).GetProviderInfo(context.Background())
+	assert.NotNil(t, err)
+}
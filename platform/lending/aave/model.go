@@ -0,0 +1,65 @@
+package aave
+
+type (
+	GraphQLRequest struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}
+
+	GraphQLResponse struct {
+		Errors []GraphQLError `json:"errors"`
+	}
+
+	GraphQLError struct {
+		Message string `json:"message"`
+	}
+
+	// Reserve is the market of an asset. The rates are yearly in ray (1e27), the amounts in the asset's
+	// smallest unit, reserveLiquidationThreshold in basis points and priceInEth in wei
+	Reserve struct {
+		ID                          string `json:"id"`
+		Symbol                      string `json:"symbol"`
+		Name                        string `json:"name"`
+		Decimals                    uint   `json:"decimals"`
+		UnderlyingAsset             string `json:"underlyingAsset"`
+		LiquidityRate               string `json:"liquidityRate"`
+		VariableBorrowRate          string `json:"variableBorrowRate"`
+		AvailableLiquidity          string `json:"availableLiquidity"`
+		UtilizationRate             string `json:"utilizationRate"`
+		ReserveLiquidationThreshold string `json:"reserveLiquidationThreshold"`
+		IsActive                    bool   `json:"isActive"`
+		IsFrozen                    bool   `json:"isFrozen"`
+		Price                       Price  `json:"price"`
+	}
+
+	Price struct {
+		PriceInEth string `json:"priceInEth"`
+	}
+
+	// UserReserve is the position of an address in a reserve, in the asset's smallest unit
+	UserReserve struct {
+		User                           User    `json:"user"`
+		Reserve                        Reserve `json:"reserve"`
+		CurrentATokenBalance           string  `json:"currentATokenBalance"`
+		CurrentVariableDebt            string  `json:"currentVariableDebt"`
+		CurrentStableDebt              string  `json:"currentStableDebt"`
+		StableBorrowRate               string  `json:"stableBorrowRate"`
+		UsageAsCollateralEnabledOnUser bool    `json:"usageAsCollateralEnabledOnUser"`
+	}
+
+	User struct {
+		ID string `json:"id"`
+	}
+
+	// UserEvent is a deposit or a withdrawal of an address, the ID is the transaction hash and the log index
+	UserEvent struct {
+		ID        string       `json:"id"`
+		Amount    string       `json:"amount"`
+		Timestamp int64        `json:"timestamp"`
+		Reserve   EventReserve `json:"reserve"`
+	}
+
+	EventReserve struct {
+		Symbol string `json:"symbol"`
+	}
+)
@@ -0,0 +1,18 @@
+package compound
+
+import (
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+const ProviderID = "compound"
+
+// Provider serves the money markets of Compound v2 on Ethereum from the Compound API
+type Provider struct {
+	client Client
+}
+
+func Init(api string) *Provider {
+	return &Provider{
+		client: Client{blockatlas.InitClient(api)},
+	}
+}
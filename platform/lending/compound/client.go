@@ -0,0 +1,41 @@
+package compound
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+type Client struct {
+	blockatlas.Request
+}
+
+// GetCTokens returns the markets, all of them without addresses
+func (c *Client) GetCTokens(addresses []string, ctx context.Context) ([]CToken, error) {
+	var response CTokenResponse
+	query := url.Values{}
+	for _, address := range addresses {
+		query.Add("addresses[]", address)
+	}
+	if err := c.GetWithContext(&response, "ctoken", query, ctx); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, errors.E("compound ctoken error", errors.Params{"error": response.Error})
+	}
+	return response.CTokens, nil
+}
+
+func (c *Client) GetAccounts(addresses []string, ctx context.Context) ([]Account, error) {
+	var response AccountResponse
+	query := url.Values{"addresses[]": addresses}
+	if err := c.GetWithContext(&response, "account", query, ctx); err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, errors.E("compound account error", errors.Params{"error": response.Error})
+	}
+	return response.Accounts, nil
+}
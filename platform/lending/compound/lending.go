@@ -0,0 +1,202 @@
+package compound
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/lending"
+)
+
+var providerInfo = types.ProviderInfo{
+	ID:          ProviderID,
+	Description: "Compound Decentralized Finance Protocol",
+	Image:       "https://compound.finance/images/compound-mark.svg",
+	Website:     "https://compound.finance",
+	RiskTier:    types.RiskLow,
+}
+
+func (p *Provider) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	cTokens, err := p.client.GetCTokens(nil, ctx)
+	if err != nil {
+		return types.LendingProvider{}, blockatlas.NewLendingError(err, ctx)
+	}
+	assets := make([]types.AssetInfo, 0, len(cTokens))
+	for _, t := range cTokens {
+		m, ok := getMarket(t.TokenAddress)
+		if !ok {
+			continue
+		}
+		assets = append(assets, assetInfo(t, m))
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Symbol < assets[j].Symbol })
+	return types.LendingProvider{
+		ID:     ProviderID,
+		Info:   providerInfo,
+		Type:   types.ProviderTypeLending,
+		Assets: assets,
+	}, nil
+}
+
+func (p *Provider) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	cTokens, err := p.client.GetCTokens(nil, ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	rates := make(types.LendingRates, 0, len(cTokens))
+	for _, t := range cTokens {
+		m, ok := getMarket(t.TokenAddress)
+		if !ok || !selected(assets, m.Symbol) {
+			continue
+		}
+		rates = append(rates, types.LendingAssetRates{
+			Asset:        m.Symbol,
+			MaxAPY:       percent(t.SupplyRate),
+			MinBorrowAPY: percent(t.BorrowRate),
+			Status:       m.Status,
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Asset < rates[j].Asset })
+	return rates, nil
+}
+
+// GetAccountLendingContracts returns the supplied and borrowed assets of the addresses, the start amounts
+// are the balances without the interest accrued. The risk counts every market, not only the requested assets.
+func (p *Provider) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	result := make([]types.AccountLendingContracts, 0, len(req.Addresses))
+	if len(req.Addresses) == 0 {
+		return &result, nil
+	}
+	cTokens, err := p.client.GetCTokens(nil, ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	byAddress := make(map[string]CToken, len(cTokens))
+	for _, t := range cTokens {
+		byAddress[strings.ToLower(t.TokenAddress)] = t
+	}
+	accounts, err := p.client.GetAccounts(req.Addresses, ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	for _, a := range accounts {
+		result = append(result, accountContracts(a, byAddress, req.Assets))
+	}
+	return &result, nil
+}
+
+func accountContracts(a Account, cTokens map[string]CToken, assets []string) types.AccountLendingContracts {
+	account := types.AccountLendingContracts{Address: a.Address, Contracts: make([]types.LendingContract, 0)}
+	var (
+		collateral []lending.Collateral
+		borrowed   float64
+	)
+	for _, token := range a.Tokens {
+		m, ok := getMarket(token.Address)
+		if !ok {
+			continue
+		}
+		t := cTokens[strings.ToLower(token.Address)]
+		supply, borrow := decimal(token.SupplyBalanceUnderlying), decimal(token.BorrowBalanceUnderlying)
+		price := t.UnderlyingPrice.Float()
+		if supply.Sign() > 0 {
+			amount, _ := supply.Float64()
+			collateral = append(collateral, lending.Collateral{
+				Asset:                m.Symbol,
+				Amount:               amount,
+				Price:                price,
+				LiquidationThreshold: t.CollateralFactor.Float(),
+			})
+			if selected(assets, m.Symbol) {
+				principal := units(new(big.Float).Sub(supply, decimal(token.LifetimeSupplyInterestAccrued)), m.Decimals)
+				account.Contracts = append(account.Contracts, types.LendingContract{
+					Asset:         m.Symbol,
+					StartAmount:   principal,
+					CurrentAmount: units(supply, m.Decimals),
+					CurrentAPY:    percent(t.SupplyRate),
+					Principal:     principal,
+					Earned:        units(decimal(token.LifetimeSupplyInterestAccrued), m.Decimals),
+				})
+			}
+		}
+		if borrow.Sign() > 0 {
+			amount, _ := borrow.Float64()
+			borrowed += amount * price
+			if selected(assets, m.Symbol) {
+				account.Borrows = append(account.Borrows, types.BorrowPosition{
+					Asset:         m.Symbol,
+					StartAmount:   units(new(big.Float).Sub(borrow, decimal(token.LifetimeBorrowInterestAccrued)), m.Decimals),
+					CurrentAmount: units(borrow, m.Decimals),
+					CurrentAPY:    percent(t.BorrowRate),
+				})
+			}
+		}
+	}
+	account.Risk = lending.LiquidationRisk(collateral, borrowed)
+	return account
+}
+
+func assetInfo(t CToken, m market) types.AssetInfo {
+	return types.AssetInfo{
+		Symbol:        m.Symbol,
+		Chain:         coin.Ethereum().Symbol,
+		Description:   "Compound " + t.UnderlyingName,
+		MinimumAmount: "0",
+		Decimals:      m.Decimals,
+		APY:           percent(t.SupplyRate),
+		Status:        m.Status,
+		Borrow: &types.BorrowInfo{
+			APY:                percent(t.BorrowRate),
+			Utilization:        utilization(t),
+			AvailableLiquidity: units(decimal(t.Cash), m.Decimals),
+		},
+	}
+}
+
+// utilization is the borrowed share of the supply, cash plus borrows minus reserves
+func utilization(t CToken) float64 {
+	borrows := t.TotalBorrows.Float()
+	supply := t.Cash.Float() + borrows - t.Reserves.Float()
+	if supply <= 0 {
+		return 0
+	}
+	return borrows / supply * 100
+}
+
+func selected(assets []string, symbol string) bool {
+	if len(assets) == 0 {
+		return true
+	}
+	for _, a := range assets {
+		if strings.EqualFold(a, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+func percent(rate Value) float64 {
+	return rate.Float() * 100
+}
+
+func decimal(v Value) *big.Float {
+	f, _, err := big.ParseFloat(v.Value, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return new(big.Float)
+	}
+	return f
+}
+
+// units converts an amount of the underlying asset to its smallest unit, 0 when negative
+func units(amount *big.Float, decimals uint) types.Amount {
+	if amount.Sign() <= 0 {
+		return "0"
+	}
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value, _ := new(big.Float).Mul(amount, new(big.Float).SetInt(exp)).Int(nil)
+	return types.Amount(value.String())
+}
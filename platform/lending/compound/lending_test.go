@@ -0,0 +1,166 @@
+package compound
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const cTokensResponse = `{
+  "cToken": [
+    {
+      "token_address": "0x5d3a536e4d6dbd6114cc1ead35777bab948e3643",
+      "symbol": "cDAI",
+      "underlying_symbol": "DAI",
+      "underlying_name": "Dai",
+      "supply_rate": {"value": "0.0312"},
+      "borrow_rate": {"value": "0.0425"},
+      "cash": {"value": "600.5"},
+      "total_borrows": {"value": "400"},
+      "reserves": {"value": "0.5"},
+      "collateral_factor": {"value": "0.75"},
+      "underlying_price": {"value": "0.005"}
+    },
+    {
+      "token_address": "0x4DDC2D193948926D02F9B1FE9E1DAA0718270ED5",
+      "symbol": "cETH",
+      "underlying_symbol": "ETH",
+      "underlying_name": "Ether",
+      "supply_rate": {"value": "0.0021"},
+      "borrow_rate": {"value": "0.0305"},
+      "cash": {"value": "1000"},
+      "total_borrows": {"value": "0"},
+      "reserves": {"value": "0"},
+      "collateral_factor": {"value": "0.75"},
+      "underlying_price": {"value": "1"}
+    },
+    {
+      "token_address": "0x0000000000000000000000000000000000000001",
+      "symbol": "cNEW",
+      "underlying_symbol": "NEW",
+      "supply_rate": {"value": "0.5"}
+    }
+  ],
+  "error": null
+}`
+
+const accountsResponse = `{
+  "accounts": [
+    {
+      "address": "0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9",
+      "health": {"value": "1.5"},
+      "tokens": [
+        {
+          "address": "0x4ddc2d193948926d02f9b1fe9e1daa0718270ed5",
+          "symbol": "cETH",
+          "supply_balance_underlying": {"value": "2.5"},
+          "borrow_balance_underlying": {"value": "0"},
+          "lifetime_supply_interest_accrued": {"value": "0.01"},
+          "lifetime_borrow_interest_accrued": {"value": "0"}
+        },
+        {
+          "address": "0x5d3a536e4d6dbd6114cc1ead35777bab948e3643",
+          "symbol": "cDAI",
+          "supply_balance_underlying": {"value": "0"},
+          "borrow_balance_underlying": {"value": "250.25"},
+          "lifetime_supply_interest_accrued": {"value": "0"},
+          "lifetime_borrow_interest_accrued": {"value": "0.25"}
+        }
+      ]
+    }
+  ],
+  "error": null
+}`
+
+func mockProvider(t *testing.T) (*Provider, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ctoken":
+			_, _ = w.Write([]byte(cTokensResponse))
+		case "/account":
+			assert.Equal(t, []string{"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"}, r.URL.Query()["addresses[]"])
+			_, _ = w.Write([]byte(accountsResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return Init(server.URL), server.Close
+}
+
+func TestProvider_GetProviderInfo(t *testing.T) {
+	p, closeServer := mockProvider(t)
+	defer closeServer()
+
+	info, err := p.GetProviderInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, ProviderID, info.ID)
+	assert.Equal(t, types.ProviderTypeLending, info.Type)
+	assert.Len(t, info.Assets, 2)
+
+	dai := info.Assets[0]
+	assert.Equal(t, "DAI", dai.Symbol)
+	assert.Equal(t, "ETH", dai.Chain)
+	assert.Equal(t, uint(18), dai.Decimals)
+	assert.InDelta(t, 3.12, dai.APY, 1e-9)
+	assert.InDelta(t, 4.25, dai.Borrow.APY, 1e-9)
+	assert.InDelta(t, 40, dai.Borrow.Utilization, 1e-9)
+	assert.Equal(t, types.Amount("600500000000000000000"), dai.Borrow.AvailableLiquidity)
+	assert.Equal(t, "ETH", info.Assets[1].Symbol)
+}
+
+func TestProvider_GetCurrentLendingRates(t *testing.T) {
+	p, closeServer := mockProvider(t)
+	defer closeServer()
+
+	rates, err := p.GetCurrentLendingRates([]string{"dai"}, context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, rates, 1)
+	assert.Equal(t, "DAI", rates[0].Asset)
+	assert.InDelta(t, 3.12, rates[0].MaxAPY, 1e-9)
+	assert.InDelta(t, 4.25, rates[0].MinBorrowAPY, 1e-9)
+
+	rates, err = p.GetCurrentLendingRates(nil, context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, rates, 2)
+}
+
+func TestProvider_GetAccountLendingContracts(t *testing.T) {
+	p, closeServer := mockProvider(t)
+	defer closeServer()
+
+	accounts, err := p.GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, *accounts, 1)
+	account := (*accounts)[0]
+	assert.Equal(t, []types.LendingContract{{
+		Asset:         "ETH",
+		StartAmount:   "2490000000000000000",
+		CurrentAmount: "2500000000000000000",
+		CurrentAPY:    0.21,
+		Principal:     "2490000000000000000",
+		Earned:        "10000000000000000",
+	}}, account.Contracts)
+	assert.Equal(t, []types.BorrowPosition{{
+		Asset:         "DAI",
+		StartAmount:   "250000000000000000000",
+		CurrentAmount: "250250000000000000000",
+		CurrentAPY:    4.25,
+	}}, account.Borrows)
+	// 2.5 ETH at 0.75 against 250.25 DAI at 0.005 ETH
+	assert.InDelta(t, 2.5*0.75/(250.25*0.005), account.Risk.HealthFactor, 1e-9)
+
+	// The risk still counts the collateral of the assets left out
+	accounts, err = p.GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"}, Assets: []string{"DAI"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, (*accounts)[0].Contracts)
+	assert.Len(t, (*accounts)[0].Borrows, 1)
+	assert.NotNil(t, (*accounts)[0].Risk)
+
+	accounts, err = p.GetAccountLendingContracts(types.AccountRequest{}, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, *accounts)
+}
@@ -0,0 +1,34 @@
+package compound
+
+import (
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// market is the underlying asset of a cToken, the Compound API doesn't give its decimals
+type market struct {
+	Symbol   string
+	Decimals uint
+	Status   types.MarketStatus
+}
+
+// markets are the cTokens of Compound v2 on Ethereum by address, the others are skipped
+var markets = map[string]market{
+	"0x4ddc2d193948926d02f9b1fe9e1daa0718270ed5": {Symbol: "ETH", Decimals: 18},                                 // cETH
+	"0x5d3a536e4d6dbd6114cc1ead35777bab948e3643": {Symbol: "DAI", Decimals: 18},                                 // cDAI
+	"0x39aa39c021dfbae8fac545936693ac917d5e7563": {Symbol: "USDC", Decimals: 6},                                 // cUSDC
+	"0xf650c3d88d12db855b8bf7d11be6c55a4e07dcc9": {Symbol: "USDT", Decimals: 6},                                 // cUSDT
+	"0xc11b1268c1a384e55c48c2391d8d480264a3a7f4": {Symbol: "WBTC", Decimals: 8},                                 // cWBTC
+	"0x6c8c6b02e7b2be14d4fa6022dfd6d75921d90e4e": {Symbol: "BAT", Decimals: 18},                                 // cBAT
+	"0xb3319f5d18bc0d84dd1b4825dcde5d5f7266d407": {Symbol: "ZRX", Decimals: 18},                                 // cZRX
+	"0x158079ee67fce2f58472a96584a73c7ab9ac95c1": {Symbol: "REP", Decimals: 18},                                 // cREP
+	"0x35a18000230da775cac24873d00ff85bccded550": {Symbol: "UNI", Decimals: 18},                                 // cUNI
+	"0x70e36f6bf80a52b3b46b3af8e106cc0ed743e8e4": {Symbol: "COMP", Decimals: 18},                                // cCOMP
+	"0xf5dce57282a584d2746faf1593d3121fcac444dc": {Symbol: "SAI", Decimals: 18, Status: types.MarketDeprecated}, // cSAI
+}
+
+func getMarket(cToken string) (market, bool) {
+	m, ok := markets[strings.ToLower(cToken)]
+	return m, ok
+}
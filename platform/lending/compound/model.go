@@ -0,0 +1,60 @@
+package compound
+
+import "strconv"
+
+type (
+	// Value is a decimal number of the Compound API, rates are fractions (0.05 is 5%)
+	// and the amounts are in units of the underlying asset
+	Value struct {
+		Value string `json:"value"`
+	}
+
+	CTokenResponse struct {
+		CTokens []CToken    `json:"cToken"`
+		Error   interface{} `json:"error"`
+	}
+
+	CToken struct {
+		TokenAddress     string `json:"token_address"`
+		Symbol           string `json:"symbol"`
+		UnderlyingSymbol string `json:"underlying_symbol"`
+		UnderlyingName   string `json:"underlying_name"`
+		SupplyRate       Value  `json:"supply_rate"`
+		BorrowRate       Value  `json:"borrow_rate"`
+		Cash             Value  `json:"cash"`
+		TotalBorrows     Value  `json:"total_borrows"`
+		Reserves         Value  `json:"reserves"`
+		CollateralFactor Value  `json:"collateral_factor"`
+		// UnderlyingPrice is the price of the underlying asset in ETH
+		UnderlyingPrice Value `json:"underlying_price"`
+	}
+
+	AccountResponse struct {
+		Accounts []Account   `json:"accounts"`
+		Error    interface{} `json:"error"`
+	}
+
+	Account struct {
+		Address string         `json:"address"`
+		Health  *Value         `json:"health"`
+		Tokens  []AccountToken `json:"tokens"`
+	}
+
+	// AccountToken is the position of an account in a market, the balances are in units of the underlying asset
+	AccountToken struct {
+		Address                       string `json:"address"`
+		Symbol                        string `json:"symbol"`
+		SupplyBalanceUnderlying       Value  `json:"supply_balance_underlying"`
+		BorrowBalanceUnderlying       Value  `json:"borrow_balance_underlying"`
+		LifetimeSupplyInterestAccrued Value  `json:"lifetime_supply_interest_accrued"`
+		LifetimeBorrowInterestAccrued Value  `json:"lifetime_borrow_interest_accrued"`
+	}
+)
+
+func (v Value) Float() float64 {
+	f, err := strconv.ParseFloat(v.Value, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
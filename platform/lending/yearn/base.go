@@ -0,0 +1,21 @@
+package yearn
+
+import (
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+const ProviderID = "yearn"
+
+// Provider serves the Yearn v2 vaults on Ethereum, their APYs from the Yearn API and the positions
+// of the addresses from the Yearn vaults subgraph
+type Provider struct {
+	client   Client
+	subgraph Subgraph
+}
+
+func Init(api, subgraph string) *Provider {
+	return &Provider{
+		client:   Client{blockatlas.InitJSONClient(api)},
+		subgraph: Subgraph{blockatlas.InitJSONClient(subgraph)},
+	}
+}
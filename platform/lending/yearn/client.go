@@ -0,0 +1,55 @@
+package yearn
+
+import (
+	"context"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const positionsQuery = `query($accounts: [String!]) {
+	accountVaultPositions(first: 1000, where: {account_in: $accounts}) {
+		account { id } vault { id } token { symbol decimals } balanceShares balanceTokens balancePosition
+	}
+}`
+
+// Client is the Yearn API of a chain, e.g. https://api.yearn.finance/v1/chains/1
+type Client struct {
+	blockatlas.Request
+}
+
+// Subgraph is the GraphQL endpoint of the Yearn vaults subgraph
+type Subgraph struct {
+	blockatlas.Request
+}
+
+func (c *Client) GetVaults(ctx context.Context) ([]Vault, error) {
+	var vaults []Vault
+	if err := c.GetWithContext(&vaults, "vaults/all", nil, ctx); err != nil {
+		return nil, err
+	}
+	return vaults, nil
+}
+
+// GetPositions returns the vault positions of the addresses, the subgraph IDs of the accounts are lowercase
+func (s *Subgraph) GetPositions(addresses []string, ctx context.Context) ([]Position, error) {
+	accounts := make([]string, 0, len(addresses))
+	for _, a := range addresses {
+		accounts = append(accounts, strings.ToLower(a))
+	}
+	var response struct {
+		GraphQLResponse
+		Data struct {
+			Positions []Position `json:"accountVaultPositions"`
+		} `json:"data"`
+	}
+	req := GraphQLRequest{Query: positionsQuery, Variables: map[string]interface{}{"accounts": accounts}}
+	if err := s.PostWithContext(&response, "", req, ctx); err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, errors.E("yearn subgraph error", errors.TypePlatformRequest, errors.Params{"error": response.Errors[0].Message})
+	}
+	return response.Data.Positions, nil
+}
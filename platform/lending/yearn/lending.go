@@ -0,0 +1,184 @@
+package yearn
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+var providerInfo = types.ProviderInfo{
+	ID:          ProviderID,
+	Description: "Yearn Finance v2 yield vaults",
+	Image:       "https://yearn.finance/favicon.ico",
+	Website:     "https://yearn.finance",
+	RiskTier:    types.RiskMedium,
+}
+
+// GetProviderInfo returns a vault per asset, the one accepting deposits with the largest TVL
+// when several vaults share an underlying token
+func (p *Provider) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	vaults, err := p.client.GetVaults(ctx)
+	if err != nil {
+		return types.LendingProvider{}, blockatlas.NewLendingError(err, ctx)
+	}
+	byAsset := assetVaults(vaults)
+	assets := make([]types.AssetInfo, 0, len(byAsset))
+	for _, v := range byAsset {
+		assets = append(assets, assetInfo(v))
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Symbol < assets[j].Symbol })
+	return types.LendingProvider{
+		ID:     ProviderID,
+		Info:   providerInfo,
+		Type:   types.ProviderTypeYield,
+		Assets: assets,
+	}, nil
+}
+
+func (p *Provider) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	vaults, err := p.client.GetVaults(ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	rates := make(types.LendingRates, 0)
+	for symbol, v := range assetVaults(vaults) {
+		if !selected(assets, symbol) {
+			continue
+		}
+		rates = append(rates, types.LendingAssetRates{Asset: symbol, MaxAPY: v.APY.NetAPY * 100, Status: status(v)})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Asset < rates[j].Asset })
+	return rates, nil
+}
+
+// GetAccountLendingContracts returns a contract per vault position of the addresses, with its shares and
+// the value of the shares in the underlying token. The principal is the deposited minus the withdrawn tokens.
+func (p *Provider) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	result := make([]types.AccountLendingContracts, 0, len(req.Addresses))
+	if len(req.Addresses) == 0 {
+		return &result, nil
+	}
+	vaults, err := p.client.GetVaults(ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	positions, err := p.subgraph.GetPositions(req.Addresses, ctx)
+	if err != nil {
+		return nil, blockatlas.NewLendingError(err, ctx)
+	}
+	apys := make(map[string]float64, len(vaults))
+	for _, v := range vaults {
+		apys[strings.ToLower(v.Address)] = v.APY.NetAPY * 100
+	}
+	byAccount := make(map[string][]Position, len(req.Addresses))
+	for _, position := range positions {
+		byAccount[position.Account.ID] = append(byAccount[position.Account.ID], position)
+	}
+	for _, address := range req.Addresses {
+		account := types.AccountLendingContracts{Address: address, Contracts: make([]types.LendingContract, 0)}
+		for _, position := range byAccount[strings.ToLower(address)] {
+			if integer(position.BalanceShares).Sign() <= 0 || !selected(req.Assets, position.Token.Symbol) {
+				continue
+			}
+			account.Contracts = append(account.Contracts, contract(position, apys[strings.ToLower(position.Vault.ID)]))
+		}
+		sort.Slice(account.Contracts, func(i, j int) bool {
+			ci, cj := account.Contracts[i], account.Contracts[j]
+			if ci.Asset != cj.Asset {
+				return ci.Asset < cj.Asset
+			}
+			return ci.Vault < cj.Vault
+		})
+		result = append(result, account)
+	}
+	return &result, nil
+}
+
+func contract(position Position, apy float64) types.LendingContract {
+	principal, current := integer(position.BalanceTokens), integer(position.BalancePosition)
+	earned := new(big.Int).Sub(current, principal)
+	if earned.Sign() < 0 {
+		earned.SetInt64(0)
+	}
+	return types.LendingContract{
+		Asset:         position.Token.Symbol,
+		StartAmount:   types.Amount(principal.String()),
+		CurrentAmount: types.Amount(current.String()),
+		CurrentAPY:    apy,
+		Principal:     types.Amount(principal.String()),
+		Earned:        types.Amount(earned.String()),
+		Vault:         strings.ToLower(position.Vault.ID),
+		Shares:        types.Amount(integer(position.BalanceShares).String()),
+	}
+}
+
+// assetVaults picks the vault of each underlying token: the vaults accepting deposits first, then the largest TVL
+func assetVaults(vaults []Vault) map[string]Vault {
+	byAsset := make(map[string]Vault)
+	for _, v := range vaults {
+		symbol := v.Token.Symbol
+		if symbol == "" {
+			continue
+		}
+		current, ok := byAsset[symbol]
+		if !ok {
+			byAsset[symbol] = v
+			continue
+		}
+		open, currentOpen := status(v).AcceptsDeposits(), status(current).AcceptsDeposits()
+		if (open && !currentOpen) || (open == currentOpen && v.TVL.TVL > current.TVL.TVL) {
+			byAsset[symbol] = v
+		}
+	}
+	return byAsset
+}
+
+func assetInfo(v Vault) types.AssetInfo {
+	return types.AssetInfo{
+		Symbol:        v.Token.Symbol,
+		Chain:         coin.Ethereum().Symbol,
+		Description:   "Yearn " + v.Name,
+		MinimumAmount: "0",
+		Decimals:      v.Token.Decimals,
+		APY:           v.APY.NetAPY * 100,
+		Status:        status(v),
+		TVL:           v.TVL.TVL,
+	}
+}
+
+// status of the vault: in emergency shutdown it only accepts withdrawals, a vault migrating to a new one is retired
+func status(v Vault) types.MarketStatus {
+	switch {
+	case v.EmergencyShutdown:
+		return types.MarketFrozen
+	case v.Migration != nil && v.Migration.Available:
+		return types.MarketDeprecated
+	default:
+		return types.MarketActive
+	}
+}
+
+func selected(assets []string, symbol string) bool {
+	if len(assets) == 0 {
+		return true
+	}
+	for _, a := range assets {
+		if strings.EqualFold(a, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+func integer(value string) *big.Int {
+	i, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return i
+}
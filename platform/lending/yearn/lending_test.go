@@ -0,0 +1,153 @@
+package yearn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const vaultsResponse = `[
+  {
+    "address": "0x19D3364A399d251E894aC732651be8B0E4e85001",
+    "symbol": "yvDAI",
+    "name": "DAI yVault",
+    "token": {"address": "0x6B175474E89094C44Da98b954EedeAC495271d0F", "symbol": "DAI", "name": "Dai Stablecoin", "decimals": 18},
+    "tvl": {"tvl": 250000000.5},
+    "apy": {"type": "v2:averaged", "net_apy": 0.0412},
+    "emergency_shutdown": false,
+    "migration": {"available": false, "address": "0x19D3364A399d251E894aC732651be8B0E4e85001"}
+  },
+  {
+    "address": "0xdA816459F1AB5631232FE5e97a05BBBb94970c95",
+    "symbol": "yvDAI",
+    "name": "DAI yVault",
+    "token": {"address": "0x6B175474E89094C44Da98b954EedeAC495271d0F", "symbol": "DAI", "name": "Dai Stablecoin", "decimals": 18},
+    "tvl": {"tvl": 900000000},
+    "apy": {"type": "v2:averaged", "net_apy": 0.0389},
+    "emergency_shutdown": false,
+    "migration": {"available": true, "address": "0x19D3364A399d251E894aC732651be8B0E4e85001"}
+  },
+  {
+    "address": "0xa258C4606Ca8206D8aA700cE2143D7db854D168c",
+    "symbol": "yvWETH",
+    "name": "WETH yVault",
+    "token": {"address": "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "symbol": "WETH", "name": "Wrapped Ether", "decimals": 18},
+    "tvl": {"tvl": 120000000},
+    "apy": {"type": "v2:averaged", "net_apy": 0.015},
+    "emergency_shutdown": true
+  }
+]`
+
+const positionsResponse = `{
+  "data": {
+    "accountVaultPositions": [
+      {
+        "account": {"id": "0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"},
+        "vault": {"id": "0x19d3364a399d251e894ac732651be8b0e4e85001"},
+        "token": {"symbol": "DAI", "decimals": 18},
+        "balanceShares": "950000000000000000000",
+        "balanceTokens": "1000000000000000000000",
+        "balancePosition": "1040000000000000000000"
+      },
+      {
+        "account": {"id": "0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"},
+        "vault": {"id": "0xa258c4606ca8206d8aa700ce2143d7db854d168c"},
+        "token": {"symbol": "WETH", "decimals": 18},
+        "balanceShares": "0",
+        "balanceTokens": "0",
+        "balancePosition": "0"
+      }
+    ]
+  }
+}`
+
+func mockProvider(t *testing.T) (*Provider, func()) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vaults/all", r.URL.Path)
+		_, _ = w.Write([]byte(vaultsResponse))
+	}))
+	subgraph := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []interface{}{"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"}, req.Variables["accounts"])
+		_, _ = w.Write([]byte(positionsResponse))
+	}))
+	return Init(api.URL, subgraph.URL), func() {
+		api.Close()
+		subgraph.Close()
+	}
+}
+
+func TestProvider_GetProviderInfo(t *testing.T) {
+	p, closeServers := mockProvider(t)
+	defer closeServers()
+
+	info, err := p.GetProviderInfo(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, ProviderID, info.ID)
+	assert.Equal(t, types.ProviderTypeYield, info.Type)
+	assert.Len(t, info.Assets, 2)
+
+	// The deprecated vault has the largest TVL, the new one accepts deposits
+	dai := info.Assets[0]
+	assert.Equal(t, "DAI", dai.Symbol)
+	assert.Equal(t, "ETH", dai.Chain)
+	assert.InDelta(t, 4.12, dai.APY, 1e-9)
+	assert.Equal(t, 250000000.5, dai.TVL)
+	assert.Equal(t, uint(18), dai.Decimals)
+	assert.Equal(t, types.MarketActive, dai.Status)
+	assert.Equal(t, types.MarketFrozen, info.Assets[1].Status)
+}
+
+func TestProvider_GetCurrentLendingRates(t *testing.T) {
+	p, closeServers := mockProvider(t)
+	defer closeServers()
+
+	rates, err := p.GetCurrentLendingRates([]string{"weth"}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, types.LendingRates{{Asset: "WETH", MaxAPY: 1.5, Status: types.MarketFrozen}}, rates)
+}
+
+func TestProvider_GetAccountLendingContracts(t *testing.T) {
+	p, closeServers := mockProvider(t)
+	defer closeServers()
+
+	accounts, err := p.GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x7D2768dE32b0b80b7a3454c06BdAc94A69DDc7A9"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []types.AccountLendingContracts{{
+		Address: "0x7D2768dE32b0b80b7a3454c06BdAc94A69DDc7A9",
+		Contracts: []types.LendingContract{{
+			Asset:         "DAI",
+			StartAmount:   "1000000000000000000000",
+			CurrentAmount: "1040000000000000000000",
+			CurrentAPY:    4.12,
+			Principal:     "1000000000000000000000",
+			Earned:        "40000000000000000000",
+			Vault:         "0x19d3364a399d251e894ac732651be8b0e4e85001",
+			Shares:        "950000000000000000000",
+		}},
+	}}, *accounts)
+
+	accounts, err = p.GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x7D2768dE32b0b80b7a3454c06BdAc94A69DDc7A9"}, Assets: []string{"WETH"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, (*accounts)[0].Contracts)
+}
+
+func TestProvider_SubgraphError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(vaultsResponse))
+			return
+		}
+		_, _ = w.Write([]byte(`{"errors": [{"message": "indexing error"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := Init(server.URL, server.URL).GetAccountLendingContracts(types.AccountRequest{Addresses: []string{"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9"}}, context.Background())
+	assert.NotNil(t, err)
+}
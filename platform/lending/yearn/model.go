@@ -0,0 +1,71 @@
+package yearn
+
+type (
+	// Vault is a v2 vault of the Yearn API. The APYs are fractions, net of the vault fees, and TVL is in USD
+	Vault struct {
+		Address           string     `json:"address"`
+		Symbol            string     `json:"symbol"`
+		Name              string     `json:"name"`
+		Token             VaultToken `json:"token"`
+		TVL               VaultTVL   `json:"tvl"`
+		APY               VaultAPY   `json:"apy"`
+		EmergencyShutdown bool       `json:"emergency_shutdown"`
+		Migration         *Migration `json:"migration"`
+	}
+
+	// VaultToken is the underlying asset of a vault
+	VaultToken struct {
+		Address  string `json:"address"`
+		Symbol   string `json:"symbol"`
+		Name     string `json:"name"`
+		Decimals uint   `json:"decimals"`
+	}
+
+	VaultTVL struct {
+		TVL float64 `json:"tvl"`
+	}
+
+	VaultAPY struct {
+		Type   string  `json:"type"`
+		NetAPY float64 `json:"net_apy"`
+	}
+
+	// Migration is available once the vault is replaced by a new one
+	Migration struct {
+		Available bool   `json:"available"`
+		Address   string `json:"address"`
+	}
+
+	GraphQLRequest struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}
+
+	GraphQLResponse struct {
+		Errors []GraphQLError `json:"errors"`
+	}
+
+	GraphQLError struct {
+		Message string `json:"message"`
+	}
+
+	// Position is the balance of an address in a vault: its shares, the tokens it deposited minus the
+	// withdrawn ones (balanceTokens) and the current value of its shares (balancePosition), in the token's smallest unit
+	Position struct {
+		Account         Entity `json:"account"`
+		Vault           Entity `json:"vault"`
+		Token           Token  `json:"token"`
+		BalanceShares   string `json:"balanceShares"`
+		BalanceTokens   string `json:"balanceTokens"`
+		BalancePosition string `json:"balancePosition"`
+	}
+
+	Entity struct {
+		ID string `json:"id"`
+	}
+
+	Token struct {
+		Symbol   string `json:"symbol"`
+		Decimals uint   `json:"decimals"`
+	}
+)
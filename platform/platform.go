@@ -18,6 +18,9 @@ import (
 	"github.com/trustwallet/blockatlas/platform/harmony"
 	"github.com/trustwallet/blockatlas/platform/icon"
 	"github.com/trustwallet/blockatlas/platform/iotex"
+	"github.com/trustwallet/blockatlas/platform/lending/aave"
+	"github.com/trustwallet/blockatlas/platform/lending/compound"
+	"github.com/trustwallet/blockatlas/platform/lending/yearn"
 	"github.com/trustwallet/blockatlas/platform/nano"
 	"github.com/trustwallet/blockatlas/platform/near"
 	"github.com/trustwallet/blockatlas/platform/nebulas"
@@ -53,6 +56,11 @@ func GetRpcVar(coinId uint) string {
 	return GetVar(varName)
 }
 
+func GetFeeApiVar(coinId uint) string {
+	varName := fmt.Sprintf("%s.fee_api", GetHandle(coinId))
+	return GetVar(varName)
+}
+
 func GetHandle(coinId uint) string {
 	return coin.Coins[coinId].Handle
 }
@@ -84,20 +92,20 @@ func getAllHandlers() blockatlas.Platforms {
 		coin.Kin().Handle:          stellar.Init(coin.KIN, GetApiVar(coin.KIN)),
 		coin.Cosmos().Handle:       cosmos.Init(coin.ATOM, GetApiVar(coin.ATOM)),
 		coin.Kava().Handle:         cosmos.Init(coin.KAVA, GetApiVar(coin.KAVA)),
-		coin.Bitcoin().Handle:      bitcoin.Init(coin.BTC, GetApiVar(coin.BTC)),
-		coin.Litecoin().Handle:     bitcoin.Init(coin.LTC, GetApiVar(coin.LTC)),
-		coin.Bitcoincash().Handle:  bitcoin.Init(coin.BCH, GetApiVar(coin.BCH)),
-		coin.Zcash().Handle:        bitcoin.Init(coin.ZEC, GetApiVar(coin.ZEC)),
-		coin.Zcoin().Handle:        bitcoin.Init(coin.XZC, GetApiVar(coin.XZC)),
-		coin.Viacoin().Handle:      bitcoin.Init(coin.VIA, GetApiVar(coin.VIA)),
-		coin.Ravencoin().Handle:    bitcoin.Init(coin.RVN, GetApiVar(coin.RVN)),
-		coin.Groestlcoin().Handle:  bitcoin.Init(coin.GRS, GetApiVar(coin.GRS)),
-		coin.Zelcash().Handle:      bitcoin.Init(coin.ZEL, GetApiVar(coin.ZEL)),
-		coin.Decred().Handle:       bitcoin.Init(coin.DCR, GetApiVar(coin.DCR)),
-		coin.Digibyte().Handle:     bitcoin.Init(coin.DGB, GetApiVar(coin.DGB)),
-		coin.Dash().Handle:         bitcoin.Init(coin.DASH, GetApiVar(coin.DASH)),
-		coin.Doge().Handle:         bitcoin.Init(coin.DOGE, GetApiVar(coin.DOGE)),
-		coin.Qtum().Handle:         bitcoin.Init(coin.QTUM, GetApiVar(coin.QTUM)),
+		coin.Bitcoin().Handle:      bitcoin.Init(coin.BTC, GetApiVar(coin.BTC), GetFeeApiVar(coin.BTC)),
+		coin.Litecoin().Handle:     bitcoin.Init(coin.LTC, GetApiVar(coin.LTC), GetFeeApiVar(coin.LTC)),
+		coin.Bitcoincash().Handle:  bitcoin.Init(coin.BCH, GetApiVar(coin.BCH), GetFeeApiVar(coin.BCH)),
+		coin.Zcash().Handle:        bitcoin.Init(coin.ZEC, GetApiVar(coin.ZEC), GetFeeApiVar(coin.ZEC)),
+		coin.Zcoin().Handle:        bitcoin.Init(coin.XZC, GetApiVar(coin.XZC), GetFeeApiVar(coin.XZC)),
+		coin.Viacoin().Handle:      bitcoin.Init(coin.VIA, GetApiVar(coin.VIA), GetFeeApiVar(coin.VIA)),
+		coin.Ravencoin().Handle:    bitcoin.Init(coin.RVN, GetApiVar(coin.RVN), GetFeeApiVar(coin.RVN)),
+		coin.Groestlcoin().Handle:  bitcoin.Init(coin.GRS, GetApiVar(coin.GRS), GetFeeApiVar(coin.GRS)),
+		coin.Zelcash().Handle:      bitcoin.Init(coin.ZEL, GetApiVar(coin.ZEL), GetFeeApiVar(coin.ZEL)),
+		coin.Decred().Handle:       bitcoin.Init(coin.DCR, GetApiVar(coin.DCR), GetFeeApiVar(coin.DCR)),
+		coin.Digibyte().Handle:     bitcoin.Init(coin.DGB, GetApiVar(coin.DGB), GetFeeApiVar(coin.DGB)),
+		coin.Dash().Handle:         bitcoin.Init(coin.DASH, GetApiVar(coin.DASH), GetFeeApiVar(coin.DASH)),
+		coin.Doge().Handle:         bitcoin.Init(coin.DOGE, GetApiVar(coin.DOGE), GetFeeApiVar(coin.DOGE)),
+		coin.Qtum().Handle:         bitcoin.Init(coin.QTUM, GetApiVar(coin.QTUM), GetFeeApiVar(coin.QTUM)),
 		coin.Gochain().Handle:      ethereum.Init(coin.GO, GetApiVar(coin.GO), GetRpcVar(coin.GO)),
 		coin.Thundertoken().Handle: ethereum.Init(coin.TT, GetApiVar(coin.TT), GetRpcVar(coin.TT)),
 		coin.Classic().Handle:      ethereum.Init(coin.ETC, GetApiVar(coin.ETC), GetRpcVar(coin.ETC)),
@@ -105,12 +113,21 @@ func getAllHandlers() blockatlas.Platforms {
 		coin.Callisto().Handle:     ethereum.Init(coin.CLO, GetApiVar(coin.CLO), GetRpcVar(coin.CLO)),
 		coin.Wanchain().Handle:     ethereum.Init(coin.WAN, GetApiVar(coin.WAN), GetRpcVar(coin.WAN)),
 		coin.Tomochain().Handle:    ethereum.Init(coin.TOMO, GetApiVar(coin.TOMO), GetRpcVar(coin.TOMO)),
-		coin.Ethereum().Handle:     ethereum.InitWitCollection(coin.ETH, GetApiVar(coin.ETH), GetRpcVar(coin.ETH), GetVar("ethereum.blockbook_api"), GetVar("ethereum.collections_api"), GetVar("ethereum.collections_api_key")),
+		coin.Ethereum().Handle:     initEthereum(),
 		coin.Near().Handle:         near.Init(GetApiVar(coin.NEAR)),
 		coin.Elrond().Handle:       elrond.Init(coin.ERD, GetApiVar(coin.ERD)),
 	}
 }
 
+// initEthereum serves the consensus-layer staking of ethereum.beacon_api when it is set
+func initEthereum() blockatlas.Platform {
+	p := ethereum.InitWitCollection(coin.ETH, GetApiVar(coin.ETH), GetRpcVar(coin.ETH), GetVar("ethereum.blockbook_api"), GetVar("ethereum.collections_api"), GetVar("ethereum.collections_api_key"))
+	if api := GetVar("ethereum.beacon_api"); api != "" {
+		return ethereum.InitWithBeacon(p, api)
+	}
+	return p
+}
+
 func getCollectionsHandlers() blockatlas.CollectionsAPIs {
 	return blockatlas.CollectionsAPIs{
 		coin.ETH: ethereum.InitWitCollection(coin.ETH, GetApiVar(coin.ETH), GetRpcVar(coin.ETH), GetVar("ethereum.blockbook_api"), GetVar("ethereum.collections_api"), GetVar("ethereum.collections_api_key")),
@@ -124,3 +141,17 @@ func getNamingHandlers() map[uint]blockatlas.NamingServiceAPI {
 		coin.ZIL: zilliqa.Init(GetApiVar(coin.ZIL), GetVar("zilliqa.key"), GetRpcVar(coin.ZIL), GetVar("zilliqa.lookup")),
 	}
 }
+
+func getLendingHandlers() map[string]blockatlas.LendingAPI {
+	handlers := make(map[string]blockatlas.LendingAPI)
+	if api := GetVar("lending.compound.api"); api != "" {
+		handlers[compound.ProviderID] = compound.Init(api)
+	}
+	if api := GetVar("lending.aave.api"); api != "" {
+		handlers[aave.ProviderID] = aave.Init(api)
+	}
+	if api, subgraph := GetVar("lending.yearn.api"), GetVar("lending.yearn.subgraph"); api != "" && subgraph != "" {
+		handlers[yearn.ProviderID] = yearn.Init(api, subgraph)
+	}
+	return handlers
+}
@@ -2,29 +2,44 @@ package platform
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/spf13/viper"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/services/assets"
 )
 
 var (
 	// Platforms contains all registered platforms by handle
 	Platforms map[string]blockatlas.Platform
 
+	// Upstreams contain the API URL of the registered platforms by handle
+	Upstreams map[string]string
+
 	// BlockAPIs contain platforms with block services
 	BlockAPIs map[string]blockatlas.BlockAPI
 
 	// TokensAPIs contain platforms with token services
 	TokensAPIs map[uint]blockatlas.TokensAPI
 
+	// BalanceAPIs contain platforms fetching the balance of an address
+	BalanceAPIs map[uint]blockatlas.BalanceAPI
+
 	// StakeAPIs contain platforms with staking services
 	StakeAPIs map[string]blockatlas.StakeAPI
 
+	// XpubAPIs contain platforms deriving the addresses of an xpub
+	XpubAPIs map[uint]blockatlas.XpubAPI
+
 	// CollectionsAPIs contain platforms which collections services
 	CollectionsAPIs blockatlas.CollectionsAPIs
 
 	// NamingAPIs contain platforms which support naming services
 	NamingAPIs map[uint]blockatlas.NamingServiceAPI
+
+	// LendingAPIs contain the lending providers by provider ID
+	LendingAPIs map[string]blockatlas.LendingAPI
 )
 
 func getActivePlatforms(handles []string) []blockatlas.Platform {
@@ -51,12 +66,19 @@ func getActivePlatforms(handles []string) []blockatlas.Platform {
 }
 
 func Init(platformHandles []string) {
+	initHostConcurrency()
+	blockatlas.SetDriftDetection(viper.GetBool("upstream.drift_detection"))
+	blockatlas.SetCircuitBreakers(viper.GetInt("upstream.circuit_breaker.failures"), viper.GetDuration("upstream.circuit_breaker.cooldown"))
+	initFaults()
 	platformList := getActivePlatforms(platformHandles)
 
 	Platforms = make(map[string]blockatlas.Platform)
+	Upstreams = make(map[string]string)
 	BlockAPIs = make(map[string]blockatlas.BlockAPI)
 	TokensAPIs = make(map[uint]blockatlas.TokensAPI)
+	BalanceAPIs = make(map[uint]blockatlas.BalanceAPI)
 	StakeAPIs = make(map[string]blockatlas.StakeAPI)
+	XpubAPIs = make(map[uint]blockatlas.XpubAPI)
 
 	for _, platform := range platformList {
 		handle := platform.Coin().Handle
@@ -78,17 +100,67 @@ func Init(platformHandles []string) {
 			logger.Fatal("Duplicate handle", p)
 		}
 		Platforms[handle] = platform
+		Upstreams[handle] = viper.GetString(apiURL)
 		if blockAPI, ok := platform.(blockatlas.BlockAPI); ok {
 			BlockAPIs[handle] = blockAPI
 		}
 		if tokenAPI, ok := platform.(blockatlas.TokensAPI); ok {
 			TokensAPIs[platform.Coin().ID] = tokenAPI
 		}
+		if balanceAPI, ok := platform.(blockatlas.BalanceAPI); ok {
+			BalanceAPIs[platform.Coin().ID] = balanceAPI
+		}
 		if stakeAPI, ok := platform.(blockatlas.StakeAPI); ok {
 			StakeAPIs[handle] = stakeAPI
 		}
+		if xpubAPI, ok := platform.(blockatlas.XpubAPI); ok {
+			XpubAPIs[platform.Coin().ID] = xpubAPI
+		}
 	}
 
 	CollectionsAPIs = getCollectionsHandlers()
 	NamingAPIs = getNamingHandlers()
+	InitLending()
+
+	if api := viper.GetString("assets.api"); api != "" {
+		assets.AssetsURL = strings.TrimSuffix(api, "/") + "/"
+	}
+	if err := initSelfHosted(); err != nil {
+		logger.Fatal(err)
+	}
+
+	if viper.GetBool("startup.check") {
+		initStartupCheck()
+	}
+}
+
+// InitLending sets the lending providers alone, for the services not serving the platforms
+func InitLending() {
+	LendingAPIs = getLendingHandlers()
+}
+
+// initFaults injects the upstream latency and errors of the faults section, meant for staging only
+func initFaults() {
+	if !viper.GetBool("faults.enabled") {
+		return
+	}
+	var hosts []blockatlas.HostFaults
+	if err := viper.UnmarshalKey("faults.hosts", &hosts); err != nil {
+		logger.Fatal(err, "invalid fault hosts")
+	}
+	latency, errorRate := viper.GetDuration("faults.latency"), viper.GetFloat64("faults.error_rate")
+	blockatlas.SetFaults(latency, errorRate, hosts)
+	logger.Warn("Fault injection enabled", logger.Params{"latency": latency.String(), "error_rate": errorRate, "hosts": hosts})
+}
+
+func initHostConcurrency() {
+	var hosts []blockatlas.HostConcurrency
+	if err := viper.UnmarshalKey("upstream.hosts", &hosts); err != nil {
+		logger.Fatal(err, "invalid upstream hosts")
+	}
+	max := viper.GetInt("upstream.max_concurrency")
+	blockatlas.SetHostConcurrency(max, hosts)
+	if max > 0 || len(hosts) > 0 {
+		logger.Info("Upstream concurrency limit", logger.Params{"max": max, "hosts": hosts})
+	}
 }
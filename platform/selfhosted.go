@@ -0,0 +1,161 @@
+package platform
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/services/assets"
+)
+
+const (
+	DeploymentDefault    = "default"
+	DeploymentSelfHosted = "self_hosted"
+)
+
+var (
+	// SelfHosted restricts the upstreams to the hosts of the operator, set by deployment.self_hosted
+	SelfHosted bool
+
+	// selfHostedHosts are the public hosts of the operator, with their subdomains, from deployment.hosts
+	selfHostedHosts []string
+
+	// internalSuffixes are the names resolved inside the network of the operator (mDNS, cloud and cluster DNS)
+	internalSuffixes = []string{".local", ".internal", ".lan", ".svc", ".localdomain"}
+
+	privateNetworks = parseNetworks("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7")
+)
+
+// DeploymentMode returns self_hosted when the upstreams are restricted to the hosts of the operator
+func DeploymentMode() string {
+	if SelfHosted {
+		return DeploymentSelfHosted
+	}
+	return DeploymentDefault
+}
+
+// IsSelfHostedURL tells if the URL is served by the operator: a loopback or private address, a name without
+// domain or of an internal domain, or one of deployment.hosts
+func IsSelfHostedURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() {
+			return true
+		}
+		for _, n := range privateNetworks {
+			if n.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	if !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range internalSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	for _, h := range selfHostedHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSelfHosted fails with the config keys whose URL is a third-party API
+func CheckSelfHosted(keys []string) error {
+	thirdParty := make([]string, 0)
+	for _, key := range keys {
+		if !IsSelfHostedURL(viper.GetString(key)) {
+			thirdParty = append(thirdParty, key)
+		}
+	}
+	if len(thirdParty) > 0 {
+		sort.Strings(thirdParty)
+		return errors.E("third-party APIs in self-hosted mode", errors.Params{"keys": thirdParty})
+	}
+	return nil
+}
+
+// initSelfHosted reads the deployment section and, in self-hosted mode, fails unless the active platforms
+// and lending providers only call the hosts of the operator
+func initSelfHosted() error {
+	SelfHosted = viper.GetBool("deployment.self_hosted")
+	selfHostedHosts = make([]string, 0)
+	for _, h := range viper.GetStringSlice("deployment.hosts") {
+		selfHostedHosts = append(selfHostedHosts, strings.ToLower(strings.TrimPrefix(h, ".")))
+	}
+	if !SelfHosted {
+		return nil
+	}
+	keys := make([]string, 0)
+	for handle := range Platforms {
+		keys = append(keys, upstreamKeys(handle)...)
+		keys = append(keys, upstreamKeys("observer.websocket."+handle)...)
+	}
+	if len(LendingAPIs) > 0 {
+		keys = append(keys, upstreamKeys("lending")...)
+	}
+	if err := CheckSelfHosted(keys); err != nil {
+		return err
+	}
+	if len(StakeAPIs) > 0 && !IsSelfHostedURL(assets.AssetsURL) {
+		return errors.E("third-party validators list in self-hosted mode, set assets.api to a mirror")
+	}
+	return nil
+}
+
+// upstreamKeys returns the config keys under the prefix set to an HTTP or WebSocket URL
+func upstreamKeys(prefix string) []string {
+	keys := make([]string, 0)
+	for _, key := range viper.AllKeys() {
+		if !strings.HasPrefix(key, prefix+".") {
+			continue
+		}
+		value := viper.GetString(key)
+		for _, scheme := range []string{"http://", "https://", "ws://", "wss://"} {
+			if strings.HasPrefix(value, scheme) {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// isSelfHostedPlatform tells if every upstream of the platform, its explorers included, is served by the operator
+func isSelfHostedPlatform(handle string) bool {
+	for _, key := range upstreamKeys(handle) {
+		if !IsSelfHostedURL(viper.GetString(key)) {
+			return false
+		}
+	}
+	for _, api := range explorerAPIs(handle) {
+		if !IsSelfHostedURL(api) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, n)
+	}
+	return networks
+}
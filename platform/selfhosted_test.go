@@ -0,0 +1,46 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSelfHostedURL(t *testing.T) {
+	selfHostedHosts = []string{"nodes.example.org"}
+	defer func() { selfHostedHosts = nil }()
+
+	for url, expected := range map[string]bool{
+		"http://localhost:9130":                 true,
+		"http://127.0.0.1:8545":                 true,
+		"http://10.1.2.3/api":                   true,
+		"https://[fd00::1]:443":                 true,
+		"http://blockbook-btc:9130":             true,
+		"http://geth.eth.svc":                   true,
+		"http://geth.default.svc.cluster.local": true,
+		"https://btc.nodes.example.org":         true,
+		"https://nodes.example.org":             true,
+		"https://api.coingecko.com/api/v3":      false,
+		"https://mainnet.infura.io/v3/key":      false,
+		"https://evilnodes.example.org":         false,
+		"http://8.8.8.8":                        false,
+		"":                                      false,
+		"not a url":                             false,
+	} {
+		assert.Equal(t, expected, IsSelfHostedURL(url), url)
+	}
+}
+
+func TestCheckSelfHosted(t *testing.T) {
+	viper.Set("selfhosted_test.api", "http://blockbook:9130")
+	viper.Set("selfhosted_test.rpc", "https://rpc.ankr.com/eth")
+	viper.Set("selfhosted_test.key", "secret")
+	defer viper.Reset()
+
+	keys := upstreamKeys("selfhosted_test")
+	assert.ElementsMatch(t, []string{"selfhosted_test.api", "selfhosted_test.rpc"}, keys)
+	assert.NotNil(t, CheckSelfHosted(keys))
+	assert.Nil(t, CheckSelfHosted([]string{"selfhosted_test.api"}))
+	assert.False(t, isSelfHostedPlatform("selfhosted_test"))
+}
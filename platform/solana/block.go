@@ -0,0 +1,111 @@
+package solana
+
+import (
+	"encoding/binary"
+	"strconv"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	systemProgramId = "11111111111111111111111111111111"
+	// systemTransfer is the index of the Transfer instruction of the system program, followed by the lamports
+	systemTransfer = 2
+)
+
+func (p *Platform) CurrentBlockNumber() (int64, error) {
+	return p.client.GetSlot()
+}
+
+// GetBlockByNumber returns the transactions of the slot: the SOL transfers and the calls of the
+// Wormhole token bridge, the other transactions (votes, programs) are skipped
+func (p *Platform) GetBlockByNumber(num int64) (*blockatlas.Block, error) {
+	block, err := p.client.GetBlock(num)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]blockatlas.Tx, 0)
+	if block != nil {
+		for _, t := range block.Transactions {
+			if tx, ok := p.normalizeTransaction(t, block.BlockTime, uint64(num)); ok {
+				txs = append(txs, tx)
+			}
+		}
+	}
+	return &blockatlas.Block{Number: num, Txs: txs}, nil
+}
+
+func (p *Platform) normalizeTransaction(t BlockTransaction, date int64, slot uint64) (types.Tx, bool) {
+	keys := accountKeys(t)
+	if len(t.Transaction.Signatures) == 0 || len(keys) == 0 {
+		return types.Tx{}, false
+	}
+	c := p.Coin()
+	tx := types.Tx{
+		ID:     t.Transaction.Signatures[0],
+		Coin:   c.ID,
+		From:   keys[0],
+		Fee:    types.Amount(strconv.FormatUint(t.Meta.Fee, 10)),
+		Date:   date,
+		Block:  slot,
+		Status: types.StatusCompleted,
+	}
+	if t.Meta.Err != nil {
+		tx.Status, tx.Error = types.StatusError, "failed"
+	}
+	if instruction, ok := findInstruction(t, keys, tokenBridgeProgramId); ok {
+		tx.To = tokenBridgeProgramId
+		tx.Type = types.TxContractCall
+		tx.Meta = types.ContractCall{Input: instruction.Data, Value: "0"}
+		return tx, true
+	}
+	for _, instruction := range t.Transaction.Message.Instructions {
+		if programId(keys, instruction) != systemProgramId || len(instruction.Accounts) < 2 {
+			continue
+		}
+		data := base58.Decode(instruction.Data)
+		if len(data) < 12 || binary.LittleEndian.Uint32(data) != systemTransfer {
+			continue
+		}
+		from, to := accountKey(keys, instruction.Accounts[0]), accountKey(keys, instruction.Accounts[1])
+		tx.From, tx.To = from, to
+		tx.Type = types.TxTransfer
+		tx.Meta = types.Transfer{
+			Value:    types.Amount(strconv.FormatUint(binary.LittleEndian.Uint64(data[4:]), 10)),
+			Symbol:   c.Symbol,
+			Decimals: c.Decimals,
+		}
+		return tx, true
+	}
+	return types.Tx{}, false
+}
+
+// accountKeys returns the accounts of the message followed by the ones loaded from lookup tables,
+// the order of the instruction indexes
+func accountKeys(t BlockTransaction) []string {
+	keys := append([]string{}, t.Transaction.Message.AccountKeys...)
+	keys = append(keys, t.Meta.LoadedAddresses.Writable...)
+	return append(keys, t.Meta.LoadedAddresses.Readonly...)
+}
+
+func accountKey(keys []string, index int) string {
+	if index < 0 || index >= len(keys) {
+		return ""
+	}
+	return keys[index]
+}
+
+func programId(keys []string, instruction Instruction) string {
+	return accountKey(keys, instruction.ProgramIDIndex)
+}
+
+func findInstruction(t BlockTransaction, keys []string, program string) (Instruction, bool) {
+	for _, instruction := range t.Transaction.Message.Instructions {
+		if programId(keys, instruction) == program {
+			return instruction, true
+		}
+	}
+	return Instruction{}, false
+}
@@ -0,0 +1,153 @@
+package solana
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	sender    = "boot1Z6jb15CLqpaMTn2CxktktwZpRAVAgHZEW6SxQ7"
+	recipient = "5CgQubGD1uwodwCe5UXDADbC69SiqXR8qq6pDMSm7ut5"
+	postedVAA = "7Jxb1zFZkMrjn3GBDHxxmbMYiz7Vgwm2MNBhPK1R1ZzC"
+)
+
+func transferData(lamports uint64) string {
+	data := make([]byte, 12)
+	binary.LittleEndian.PutUint32(data, systemTransfer)
+	binary.LittleEndian.PutUint64(data[4:], lamports)
+	return base58.Encode(data)
+}
+
+func blockTransaction(signature string, keys []string, instructions []Instruction, logs []string) BlockTransaction {
+	return BlockTransaction{
+		Meta:        TransactionMeta{Fee: 5000, LogMessages: logs},
+		Transaction: Transaction{Signatures: []string{signature}, Message: Message{AccountKeys: keys, Instructions: instructions}},
+	}
+}
+
+var (
+	transferTx = blockTransaction("transfer", []string{sender, recipient, systemProgramId},
+		[]Instruction{{ProgramIDIndex: 2, Accounts: []int{0, 1}, Data: transferData(1500000000)}}, nil)
+	voteTx = blockTransaction("vote", []string{sender, "Vote111111111111111111111111111111111111111"},
+		[]Instruction{{ProgramIDIndex: 1, Accounts: []int{0}, Data: "2"}}, nil)
+	bridgeSentTx = blockTransaction("sent", []string{sender, tokenBridgeProgramId},
+		[]Instruction{{ProgramIDIndex: 1, Accounts: []int{0}, Data: base58.Encode([]byte{5, 1})}},
+		[]string{"Program worm2ZoG2kUd4vFXhvjh93UUH596ayRfgQ2MgjNMTth invoke [2]", "Program log: Sequence: 74165"})
+	bridgeDeliveredTx = blockTransaction("delivered", []string{sender, "config", postedVAA, tokenBridgeProgramId},
+		[]Instruction{{ProgramIDIndex: 3, Accounts: []int{0, 1, 2}, Data: base58.Encode([]byte{2})}}, nil)
+)
+
+// postedVAAData is the account of the VAA of the sequence 6699 of the Ethereum token bridge
+func postedVAAData() []byte {
+	data := make([]byte, postedVAALength+4)
+	copy(data, postedVAAMagic)
+	binary.LittleEndian.PutUint64(data[49:], 6699)
+	binary.LittleEndian.PutUint16(data[57:], 2)
+	emitter, _ := hex.DecodeString("0000000000000000000000003ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc")
+	copy(data[59:], emitter)
+	return data
+}
+
+func rpcServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req blockatlas.RpcRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		resp := blockatlas.RpcResponse{JsonRpc: "2.0", Id: req.Id}
+		params, _ := req.Params.([]interface{})
+		switch req.Method {
+		case "getSlot":
+			resp.Result = 1000
+		case "getBlock":
+			if params[0].(float64) == 999 {
+				resp.Error = &blockatlas.RpcError{Code: errorCodeSlotSkipped, Message: "Slot 999 was skipped"}
+				break
+			}
+			resp.Result = Block{BlockTime: 1600000000, Transactions: []BlockTransaction{transferTx, voteTx, bridgeSentTx}}
+		case "getTransaction":
+			switch params[0] {
+			case "sent":
+				resp.Result = bridgeSentTx
+			case "delivered":
+				resp.Result = bridgeDeliveredTx
+			}
+		case "getAccountInfo":
+			assert.Equal(t, postedVAA, params[0])
+			resp.Result = map[string]interface{}{"value": map[string]interface{}{
+				"data": []string{base64.StdEncoding.EncodeToString(postedVAAData()), "base64"},
+			}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestPlatform_GetBlockByNumber(t *testing.T) {
+	server := rpcServer(t)
+	defer server.Close()
+	p := Init(server.URL)
+
+	current, err := p.CurrentBlockNumber()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), current)
+
+	block, err := p.GetBlockByNumber(1000)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), block.Number)
+	assert.Equal(t, []types.Tx{
+		{
+			ID: "transfer", Coin: 501, From: sender, To: recipient, Fee: "5000", Date: 1600000000, Block: 1000,
+			Status: types.StatusCompleted, Type: types.TxTransfer,
+			Meta: types.Transfer{Value: "1500000000", Symbol: "SOL", Decimals: 9},
+		},
+		{
+			ID: "sent", Coin: 501, From: sender, To: tokenBridgeProgramId, Fee: "5000", Date: 1600000000, Block: 1000,
+			Status: types.StatusCompleted, Type: types.TxContractCall,
+			Meta: types.ContractCall{Input: base58.Encode([]byte{5, 1}), Value: "0"},
+		},
+	}, block.Txs)
+
+	block, err = p.GetBlockByNumber(999)
+	assert.Nil(t, err)
+	assert.Empty(t, block.Txs)
+}
+
+func TestPlatform_GetBridgeMessage(t *testing.T) {
+	server := rpcServer(t)
+	defer server.Close()
+	p := Init(server.URL)
+
+	message, err := p.GetBridgeMessage(types.Tx{ID: "sent", To: tokenBridgeProgramId})
+	assert.Nil(t, err)
+	assert.Equal(t, &blockatlas.BridgeMessage{
+		Protocol: "wormhole",
+		ID:       "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/74165",
+	}, message)
+
+	message, err = p.GetBridgeMessage(types.Tx{ID: "delivered", To: tokenBridgeProgramId})
+	assert.Nil(t, err)
+	assert.Equal(t, &blockatlas.BridgeMessage{
+		Protocol:  "wormhole",
+		ID:        "2/0000000000000000000000003ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc/6699",
+		Delivered: true,
+	}, message)
+
+	message, err = p.GetBridgeMessage(types.Tx{ID: "transfer", To: recipient})
+	assert.Nil(t, err)
+	assert.Nil(t, message)
+}
+
+func TestParsePostedVAA(t *testing.T) {
+	_, err := parsePostedVAA([]byte("msg"))
+	assert.NotNil(t, err)
+	_, err = parsePostedVAA(append([]byte("msg"), postedVAAData()[3:]...))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,87 @@
+package solana
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/pkg/wormhole"
+)
+
+const (
+	tokenBridgeProgramId = "wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb"
+	// tokenBridgeEmitter is the emitter account of the token bridge messages, in hex like in the VAAs
+	tokenBridgeEmitter = "ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5"
+	// sequenceLog is logged by the core bridge with the sequence of the message it posts
+	sequenceLog = "Program log: Sequence: "
+	// completeNative and completeWrapped are the instructions of the token bridge delivering a transfer,
+	// the posted VAA is their third account
+	completeNative  = 2
+	completeWrapped = 3
+	postedVAAIndex  = 2
+	// postedVAAMagic starts the posted VAA accounts, followed by version, consistency level, VAA time,
+	// signature set, submission time, nonce, sequence, emitter chain and address, little-endian
+	postedVAAMagic  = "vaa"
+	postedVAALength = 91
+)
+
+// GetBridgeMessage returns the Wormhole message sent by a transfer of the token bridge, from the sequence
+// logged by the core bridge, or delivered by its complete instructions, from the posted VAA account
+func (p *Platform) GetBridgeMessage(tx types.Tx) (*blockatlas.BridgeMessage, error) {
+	if tx.To != tokenBridgeProgramId {
+		return nil, nil
+	}
+	t, err := p.client.GetTransaction(tx.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range t.Meta.LogMessages {
+		if !strings.HasPrefix(log, sequenceLog) {
+			continue
+		}
+		sequence, err := strconv.ParseUint(strings.TrimPrefix(log, sequenceLog), 10, 64)
+		if err != nil {
+			return nil, errors.E(err, "invalid sequence log", errors.Params{"log": log})
+		}
+		m := wormhole.Message{EmitterChain: wormhole.ChainSolana, Sequence: sequence}
+		emitter, _ := hex.DecodeString(tokenBridgeEmitter)
+		copy(m.EmitterAddress[:], emitter)
+		return &blockatlas.BridgeMessage{Protocol: wormhole.Protocol, ID: m.ID()}, nil
+	}
+
+	keys := accountKeys(t)
+	instruction, ok := findInstruction(t, keys, tokenBridgeProgramId)
+	if !ok {
+		return nil, nil
+	}
+	data := base58.Decode(instruction.Data)
+	if len(data) == 0 || (data[0] != completeNative && data[0] != completeWrapped) || len(instruction.Accounts) <= postedVAAIndex {
+		return nil, nil
+	}
+	account, err := p.client.GetAccountData(accountKey(keys, instruction.Accounts[postedVAAIndex]))
+	if err != nil {
+		return nil, err
+	}
+	m, err := parsePostedVAA(account)
+	if err != nil {
+		return nil, err
+	}
+	return &blockatlas.BridgeMessage{Protocol: wormhole.Protocol, ID: m.ID(), Delivered: true}, nil
+}
+
+func parsePostedVAA(data []byte) (wormhole.Message, error) {
+	if len(data) < postedVAALength || !bytes.HasPrefix(data, []byte(postedVAAMagic)) {
+		return wormhole.Message{}, errors.E("not a posted VAA account", errors.Params{"length": len(data)})
+	}
+	var m wormhole.Message
+	m.Sequence = binary.LittleEndian.Uint64(data[49:])
+	m.EmitterChain = binary.LittleEndian.Uint16(data[57:])
+	copy(m.EmitterAddress[:], data[59:91])
+	return m, nil
+}
@@ -1,11 +1,29 @@
 package solana
 
 import (
+	"encoding/base64"
+
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
 )
 
 const stakeProgramId = "Stake11111111111111111111111111111111111111"
 
+// Error codes of the slots without a block, skipped by the leader or no longer stored by the node
+const (
+	errorCodeSlotSkipped       = -32007
+	errorCodeBlockNotAvailable = -32009
+)
+
+// blockConfig requests the finalized blocks with their transactions, the versioned ones included
+var blockConfig = map[string]interface{}{
+	"encoding":                       "json",
+	"transactionDetails":             "full",
+	"rewards":                        false,
+	"commitment":                     "finalized",
+	"maxSupportedTransactionVersion": 0,
+}
+
 type Client struct {
 	blockatlas.Request
 }
@@ -36,3 +54,50 @@ func (c *Client) GetMinimumBalanceForRentExemption() (minimumBalance uint64, err
 	err = c.RpcCall(&minimumBalance, "getMinimumBalanceForRentExemption", []uint64{4008})
 	return
 }
+
+func (c *Client) GetSlot() (slot int64, err error) {
+	err = c.RpcCall(&slot, "getSlot", []interface{}{map[string]string{"commitment": "finalized"}})
+	return
+}
+
+// GetBlock returns the block of the slot, nil for the skipped slots
+func (c *Client) GetBlock(slot int64) (*Block, error) {
+	req := &blockatlas.RpcRequest{JsonRpc: blockatlas.JsonRpcVersion, Method: "getBlock", Params: []interface{}{slot, blockConfig}, Id: slot}
+	var resp *blockatlas.RpcResponse
+	if err := c.Post(&resp, "", req); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		if resp.Error.Code == errorCodeSlotSkipped || resp.Error.Code == errorCodeBlockNotAvailable {
+			return nil, nil
+		}
+		return nil, errors.E("RPC Call error", errors.Params{"method": req.Method, "error_code": resp.Error.Code, "error_message": resp.Error.Message})
+	}
+	var block Block
+	if err := resp.GetObject(&block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+func (c *Client) GetTransaction(signature string) (tx BlockTransaction, err error) {
+	err = c.RpcCall(&tx, "getTransaction", []interface{}{signature, map[string]interface{}{
+		"encoding":                       "json",
+		"commitment":                     "finalized",
+		"maxSupportedTransactionVersion": 0,
+	}})
+	return
+}
+
+// GetAccountData returns the data of the account, requested in base64 as base58 is limited to 128 bytes
+func (c *Client) GetAccountData(pubkey string) ([]byte, error) {
+	var r RpcAccountData
+	err := c.RpcCall(&r, "getAccountInfo", []interface{}{pubkey, map[string]string{"encoding": "base64"}})
+	if err != nil {
+		return nil, err
+	}
+	if r.Value == nil || len(r.Value.Data) == 0 {
+		return nil, errors.E("account not found", errors.Params{"pubkey": pubkey})
+	}
+	return base64.StdEncoding.DecodeString(r.Value.Data[0])
+}
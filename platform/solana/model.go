@@ -69,3 +69,50 @@ type EpochInfo struct {
 	SlotIndex    uint64 `json:"slotIndex"`
 	SlotsInEpoch uint64 `json:"slotsInEpoch"`
 }
+
+type Block struct {
+	BlockTime    int64              `json:"blockTime"`
+	Transactions []BlockTransaction `json:"transactions"`
+}
+
+type BlockTransaction struct {
+	Meta        TransactionMeta `json:"meta"`
+	Transaction Transaction     `json:"transaction"`
+}
+
+type TransactionMeta struct {
+	Err             interface{}     `json:"err"`
+	Fee             uint64          `json:"fee"`
+	LogMessages     []string        `json:"logMessages"`
+	LoadedAddresses LoadedAddresses `json:"loadedAddresses"`
+}
+
+// LoadedAddresses are the accounts of the address lookup tables of the versioned transactions
+type LoadedAddresses struct {
+	Writable []string `json:"writable"`
+	Readonly []string `json:"readonly"`
+}
+
+type Transaction struct {
+	Signatures []string `json:"signatures"`
+	Message    Message  `json:"message"`
+}
+
+type Message struct {
+	AccountKeys  []string      `json:"accountKeys"`
+	Instructions []Instruction `json:"instructions"`
+}
+
+// Instruction references its program and accounts by index in the account keys, the data is base58
+type Instruction struct {
+	ProgramIDIndex int    `json:"programIdIndex"`
+	Accounts       []int  `json:"accounts"`
+	Data           string `json:"data"`
+}
+
+// RpcAccountData is an account with the data encoded as [data, encoding]
+type RpcAccountData struct {
+	Value *struct {
+		Data []string `json:"data"`
+	} `json:"value"`
+}
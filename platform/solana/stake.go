@@ -72,6 +72,7 @@ func normalizeValidator(v VoteAccount, minimumBalance uint64) (validator blockat
 			LockTime:      0,
 			Type:          blockatlas.DelegationTypeDelegate,
 		},
+		Commission: float64(v.Commission),
 	}
 }
 
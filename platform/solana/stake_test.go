@@ -46,6 +46,7 @@ var expectedValidators = []blockatlas.Validator{
 			LockTime:      0,
 			Type:          blockatlas.DelegationTypeDelegate,
 		},
+		Commission: 100,
 	},
 	blockatlas.Validator{
 		Status: true,
@@ -56,6 +57,7 @@ var expectedValidators = []blockatlas.Validator{
 			LockTime:      0,
 			Type:          blockatlas.DelegationTypeDelegate,
 		},
+		Commission: 100,
 	},
 }
 
@@ -0,0 +1,156 @@
+package platform
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	DefaultStartupWorkers = 16
+	DefaultStartupTimeout = time.Second * 10
+)
+
+var errStartupTimeout = errors.E("start-up check timed out")
+
+// unavailable are the errors of the platforms failing their start-up check, by handle
+var unavailable = struct {
+	sync.RWMutex
+	errs map[string]error
+}{errs: make(map[string]error)}
+
+// Unavailable returns the error of the start-up check of the platform, nil when it passed or didn't run
+func Unavailable(handle string) error {
+	unavailable.RLock()
+	defer unavailable.RUnlock()
+	return unavailable.errs[handle]
+}
+
+// UnavailablePlatforms returns the handles of the platforms failing their start-up check, in order
+func UnavailablePlatforms() []string {
+	unavailable.RLock()
+	defer unavailable.RUnlock()
+	handles := make([]string, 0, len(unavailable.errs))
+	for handle := range unavailable.errs {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	return handles
+}
+
+func setAvailability(handle string, err error) {
+	unavailable.Lock()
+	defer unavailable.Unlock()
+	if err == nil {
+		delete(unavailable.errs, handle)
+		return
+	}
+	unavailable.errs[handle] = err
+}
+
+// initStartupCheck checks the platforms with startup.check, the failing ones are checked again
+// every startup.retry_interval until they pass
+func initStartupCheck() {
+	workers := viper.GetInt("startup.workers")
+	if workers <= 0 {
+		workers = DefaultStartupWorkers
+	}
+	timeout := viper.GetDuration("startup.timeout")
+	if timeout <= 0 {
+		timeout = DefaultStartupTimeout
+	}
+	start := time.Now()
+	failed := CheckPlatforms(Platforms, workers, timeout)
+	logger.Info("Platforms start-up check", logger.Params{"platforms": len(Platforms), "unavailable": len(failed), "duration": time.Since(start).String()})
+	if interval := viper.GetDuration("startup.retry_interval"); len(failed) > 0 && interval > 0 {
+		go RunStartupRetries(Platforms, workers, timeout, interval, context.Background())
+	}
+}
+
+// CheckPlatforms runs the start-up check of the platforms concurrently, at most workers at once, each
+// within timeout. The platforms failing it are marked unavailable instead of stopping the start-up,
+// their errors are returned by handle.
+func CheckPlatforms(platforms map[string]blockatlas.Platform, workers int, timeout time.Duration) map[string]error {
+	handles := make(chan string)
+	var (
+		mu     sync.Mutex
+		failed = make(map[string]error)
+		wg     sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for handle := range handles {
+				err := checkPlatform(platforms[handle], timeout)
+				setAvailability(handle, err)
+				if err == nil {
+					continue
+				}
+				logger.Error(err, "Platform unavailable", logger.Params{"platform": handle})
+				mu.Lock()
+				failed[handle] = err
+				mu.Unlock()
+			}
+		}()
+	}
+	for handle := range platforms {
+		handles <- handle
+	}
+	close(handles)
+	wg.Wait()
+	return failed
+}
+
+// checkPlatform fetches the block height of the platforms with blocks, the late answer is dropped
+func checkPlatform(p blockatlas.Platform, timeout time.Duration) error {
+	blockAPI, ok := p.(blockatlas.BlockAPI)
+	if !ok {
+		return nil
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := blockAPI.CurrentBlockNumber()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return errStartupTimeout
+	}
+}
+
+// RunStartupRetries checks the unavailable platforms again every interval, until they all pass
+func RunStartupRetries(platforms map[string]blockatlas.Platform, workers int, timeout, interval time.Duration, ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			retry := make(map[string]blockatlas.Platform)
+			for _, handle := range UnavailablePlatforms() {
+				if p, ok := platforms[handle]; ok {
+					retry[handle] = p
+				}
+			}
+			failed := CheckPlatforms(retry, workers, timeout)
+			for handle := range retry {
+				if _, ok := failed[handle]; !ok {
+					logger.Info("Platform available", logger.Params{"platform": handle})
+				}
+			}
+			if len(failed) == 0 {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package platform
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type checkedPlatform struct {
+	coin  coin.Coin
+	delay time.Duration
+	err   error
+	calls *int32
+}
+
+func (p checkedPlatform) Coin() coin.Coin {
+	return p.coin
+}
+
+func (p checkedPlatform) CurrentBlockNumber() (int64, error) {
+	atomic.AddInt32(p.calls, 1)
+	time.Sleep(p.delay)
+	return 100, p.err
+}
+
+func (p checkedPlatform) GetBlockByNumber(num int64) (*types.Block, error) {
+	return &types.Block{}, nil
+}
+
+func TestCheckPlatforms(t *testing.T) {
+	var calls int32
+	platforms := map[string]blockatlas.Platform{
+		"ethereum": checkedPlatform{coin: coin.Ethereum(), delay: time.Millisecond * 50, calls: &calls},
+		"tron":     checkedPlatform{coin: coin.Tron(), delay: time.Millisecond * 50, calls: &calls},
+		"cosmos":   checkedPlatform{coin: coin.Cosmos(), err: errors.E("connection refused"), calls: &calls},
+		"tezos":    checkedPlatform{coin: coin.Tezos(), delay: time.Second, calls: &calls},
+	}
+	defer func() {
+		for handle := range platforms {
+			setAvailability(handle, nil)
+		}
+	}()
+
+	start := time.Now()
+	failed := CheckPlatforms(platforms, 4, time.Millisecond*200)
+	// Checked at once, the slow platform timing out
+	assert.True(t, time.Since(start) < time.Millisecond*500)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	assert.Len(t, failed, 2)
+	assert.Equal(t, errStartupTimeout, failed["tezos"])
+	assert.Equal(t, []string{"cosmos", "tezos"}, UnavailablePlatforms())
+	assert.Nil(t, Unavailable("ethereum"))
+	assert.NotNil(t, Unavailable("cosmos"))
+
+	platforms["cosmos"] = checkedPlatform{coin: coin.Cosmos(), calls: &calls}
+	failed = CheckPlatforms(map[string]blockatlas.Platform{"cosmos": platforms["cosmos"]}, 1, time.Millisecond*200)
+	assert.Empty(t, failed)
+	assert.Equal(t, []string{"tezos"}, UnavailablePlatforms())
+}
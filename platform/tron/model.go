@@ -73,7 +73,8 @@ type (
 	}
 
 	AssetV2 struct {
-		Key string `json:"key"`
+		Key   string `json:"key"`
+		Value int64  `json:"value"`
 	}
 
 	Votes struct {
@@ -140,6 +141,7 @@ type (
 		Symbol          string `json:"symbol"`
 		Decimals        int    `json:"decimals"`
 		ContractAddress string `json:"contract_address"`
+		Balance         string `json:"balance"`
 	}
 )
 
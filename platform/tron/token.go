@@ -4,6 +4,8 @@ import (
 	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"strconv"
 	"sync"
 )
 
@@ -17,12 +19,7 @@ func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage,
 		return tokenPage, nil
 	}
 
-	var tokenIds []string
-	for _, v := range tokens.Data[0].AssetsV2 {
-		tokenIds = append(tokenIds, v.Key)
-	}
-
-	tokensChan := p.getTokens(tokenIds)
+	tokensChan := p.getTokens(tokens.Data[0].AssetsV2)
 	for info := range tokensChan {
 		tokenPage = append(tokenPage, info)
 	}
@@ -40,38 +37,40 @@ func (p *Platform) GetTokenListByAddress(address string) (blockatlas.TokenPage,
 			TokenID:  t.ContractAddress,
 			Coin:     coin.Tron().ID,
 			Type:     blockatlas.TokenTypeTRC20,
+			Balance:  t.Balance,
 		})
 	}
 
 	return tokenPage, nil
 }
 
-func (p *Platform) getTokens(ids []string) chan blockatlas.Token {
-	tkChan := make(chan blockatlas.Token, len(ids))
+func (p *Platform) getTokens(assets []AssetV2) chan types.Token {
+	tkChan := make(chan types.Token, len(assets))
 	var wg sync.WaitGroup
-	for _, id := range ids {
+	for _, asset := range assets {
 		wg.Add(1)
-		go func(i string, c chan blockatlas.Token) {
+		go func(a AssetV2, c chan types.Token) {
 			defer wg.Done()
-			err := p.getTokensChannel(i, c)
+			err := p.getTokensChannel(a, c)
 			if err != nil {
 				logger.Error(err)
 			}
-		}(id, tkChan)
+		}(asset, tkChan)
 	}
 	wg.Wait()
 	close(tkChan)
 	return tkChan
 }
 
-func (p *Platform) getTokensChannel(id string, tkChan chan blockatlas.Token) error {
-	info, err := p.client.fetchTokenInfo(id)
+func (p *Platform) getTokensChannel(asset AssetV2, tkChan chan types.Token) error {
+	info, err := p.client.fetchTokenInfo(asset.Key)
 	if err != nil || len(info.Data) == 0 {
 		logger.Error(err, "fetchTokenInfo: invalid token")
 		return err
 	}
-	asset := NormalizeToken(info.Data[0])
-	tkChan <- asset
+	token := NormalizeToken(info.Data[0])
+	token.Balance = strconv.FormatInt(asset.Value, 10)
+	tkChan <- token
 	return nil
 }
 
@@ -0,0 +1,110 @@
+// Package blockatlas embeds the Block Atlas endpoints in another Go service:
+//
+//	server := blockatlas.NewServer().
+//		WithPlatforms(ethereum.Init(coin.ETH, api, rpc)).
+//		WithLendingProvider(compound.ProviderID, compound.Init(compoundAPI)).
+//		Use(authMiddleware)
+//	server.Mount(engine.Group("/atlas"))
+//
+// or, for a chi or net/http router, mount server.Handler().
+package blockatlas
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trustwallet/blockatlas/api"
+	"github.com/trustwallet/blockatlas/api/endpoint"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/services/lending"
+)
+
+// Server registers the endpoints of the platforms and providers it is built with,
+// without the config file, the database or the message queue of the Block Atlas services
+type Server struct {
+	platforms   []blockatlas.Platform
+	collections []blockatlas.CollectionsAPI
+	lending     map[string]blockatlas.LendingAPI
+	notes       *endpoint.TxNotes
+	middleware  []gin.HandlerFunc
+}
+
+func NewServer() *Server {
+	return &Server{lending: make(map[string]blockatlas.LendingAPI)}
+}
+
+// WithPlatforms serves the transactions, summary, tokens and staking endpoints of the platforms,
+// by the capabilities each one implements
+func (s *Server) WithPlatforms(platforms ...blockatlas.Platform) *Server {
+	s.platforms = append(s.platforms, platforms...)
+	return s
+}
+
+// WithCollections serves the collectibles endpoints of the platforms
+func (s *Server) WithCollections(apis ...blockatlas.CollectionsAPI) *Server {
+	s.collections = append(s.collections, apis...)
+	return s
+}
+
+// WithLendingProvider serves the provider under /v1/lending, see blockatlas.AdaptLendingAPI for the providers
+// without context
+func (s *Server) WithLendingProvider(id string, provider blockatlas.LendingAPI) *Server {
+	s.lending[id] = provider
+	return s
+}
+
+// WithTxNotes serves the transaction notes of the API keys from the storage, and merges them
+// into the transactions on include_notes
+func (s *Server) WithTxNotes(storage endpoint.TxNotesStorage, apiKeys []string) *Server {
+	s.notes = &endpoint.TxNotes{Storage: storage, APIKeys: apiKeys}
+	return s
+}
+
+// Use runs the middleware before the handlers of the endpoints
+func (s *Server) Use(middleware ...gin.HandlerFunc) *Server {
+	s.middleware = append(s.middleware, middleware...)
+	return s
+}
+
+// Mount registers the endpoints on the router, e.g. a group of an existing gin engine
+func (s *Server) Mount(router gin.IRouter) {
+	group := router.Group("", s.middleware...)
+	for _, p := range s.platforms {
+		api.RegisterTransactionsAPI(group, p, s.notes)
+		api.RegisterSummaryAPI(group, p)
+		api.RegisterTokensAPI(group, p)
+		api.RegisterStakeAPI(group, p)
+		api.RegisterFeeAPI(group, p)
+		api.RegisterSimulationAPI(group, p)
+	}
+	for _, c := range s.collections {
+		api.RegisterCollectionsAPI(group, c)
+	}
+	if len(s.lending) > 0 {
+		api.RegisterLendingAPI(group, s.lending)
+	}
+	if s.notes != nil {
+		api.RegisterTxNotesAPI(group, s.notes)
+	}
+}
+
+// Handler returns the endpoints as an http.Handler, for the routers other than gin
+func (s *Server) Handler() http.Handler {
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+	s.Mount(engine)
+	return engine
+}
+
+// Run refreshes the lending providers cache of /v1/lending/compare every interval until ctx is done
+func (s *Server) Run(interval time.Duration, ctx context.Context) {
+	if len(s.lending) == 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = lending.DefaultRefreshInterval
+	}
+	lending.RunCacheRefresher(lending.Providers, s.lending, interval, ctx)
+}
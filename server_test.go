@@ -0,0 +1,128 @@
+package blockatlas
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type txPlatformMock struct{}
+
+func (txPlatformMock) Coin() coin.Coin { return coin.Coins[coin.ETH] }
+
+func (txPlatformMock) GetTxsByAddress(address string) (types.TxPage, error) {
+	return types.TxPage{{ID: "0x1", Coin: coin.ETH, From: address, Block: 1, Meta: types.Transfer{Value: "1", Decimals: 18, Symbol: "ETH"}}}, nil
+}
+
+type lendingMock struct{}
+
+func (lendingMock) GetProviderInfo() (types.LendingProvider, error) {
+	return types.LendingProvider{ID: "compound"}, nil
+}
+
+func (lendingMock) GetCurrentLendingRates(assets []string) (types.LendingRates, error) {
+	return types.LendingRates{}, nil
+}
+
+func (lendingMock) GetAccountLendingContracts(req types.AccountRequest) (*[]types.AccountLendingContracts, error) {
+	return &[]types.AccountLendingContracts{}, nil
+}
+
+func TestServer_Mount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var calls int
+	server := NewServer().
+		WithPlatforms(txPlatformMock{}).
+		WithLendingProvider("compound", blockatlas.AdaptLendingAPI(lendingMock{})).
+		Use(func(c *gin.Context) { calls++ })
+
+	engine := gin.New()
+	server.Mount(engine.Group("/atlas"))
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/atlas/v2/ethereum/transactions/0xabc", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var page struct {
+		Docs []map[string]interface{} `json:"docs"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Docs, 1)
+	assert.Equal(t, "0x1", page.Docs[0]["id"])
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/atlas/v1/lending/providers", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, calls)
+
+	// Coins not registered aren't served
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/atlas/v2/bitcoin/transactions/0xabc", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestServer_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewServer().WithLendingProvider("compound", blockatlas.AdaptLendingAPI(lendingMock{})).Handler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/lending/providers", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response types.LendingProvidersResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, types.LendingProviders{{ID: "compound"}}, response.Providers)
+}
+
+type txNotesMock map[string]models.TxNote
+
+func (m txNotesMock) SetTxNote(note *models.TxNote, ctx context.Context) error {
+	m[note.KeyHash+note.Hash] = *note
+	return nil
+}
+
+func (m txNotesMock) DeleteTxNote(keyHash string, coin uint, hash string, ctx context.Context) error {
+	delete(m, keyHash+hash)
+	return nil
+}
+
+func (m txNotesMock) GetTxNotes(keyHash string, coin uint, hashes []string, ctx context.Context) ([]models.TxNote, error) {
+	var notes []models.TxNote
+	for _, n := range m {
+		if n.KeyHash == keyHash {
+			notes = append(notes, n)
+		}
+	}
+	return notes, nil
+}
+
+func TestServer_WithTxNotes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	NewServer().WithPlatforms(txPlatformMock{}).WithTxNotes(txNotesMock{}, []string{"first"}).Mount(engine.Group("/first"))
+	NewServer().WithPlatforms(txPlatformMock{}).Mount(engine.Group("/second"))
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-API-Key", "first")
+		engine.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusOK, request(http.MethodPut, "/first/v1/notes/60/0x1", `{"note": "rent"}`).Code)
+	assert.Contains(t, request(http.MethodGet, "/first/v2/ethereum/transactions/0xabc?include_notes=true", "").Body.String(), `"rent"`)
+
+	// The server without notes serves neither the notes nor the routes
+	w := request(http.MethodGet, "/second/v2/ethereum/transactions/0xabc?include_notes=true", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"note"`)
+	assert.Equal(t, http.StatusNotFound, request(http.MethodPut, "/second/v1/notes/60/0x1", `{"note": "rent"}`).Code)
+}
@@ -7,9 +7,8 @@ import (
 	"time"
 )
 
-const (
-	AssetsURL = "https://raw.githubusercontent.com/trustwallet/assets/master/blockchains/"
-)
+// AssetsURL serves the validators of the staking coins, a mirror of the assets repository with assets.api
+var AssetsURL = "https://raw.githubusercontent.com/trustwallet/assets/master/blockchains/"
 
 func fetchValidatorsInfo(coin coin.Coin) (AssetValidators, error) {
 	var results AssetValidators
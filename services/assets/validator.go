@@ -5,6 +5,7 @@ import (
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/errors"
 	"github.com/trustwallet/blockatlas/pkg/numbers"
+	"github.com/trustwallet/blockatlas/pkg/types"
 	"sort"
 )
 
@@ -17,6 +18,17 @@ func GetValidatorsMap(api blockatlas.StakeAPI) (blockatlas.ValidatorMap, error)
 	return results.ToMap(), nil
 }
 
+// GetValidatorDetails returns the validators of the chain enriched from the validators registry, by annual
+// reward. The validators missing from the registry are kept with their ID, the commission of the registry
+// is used for the chains not reporting it.
+func GetValidatorDetails(api blockatlas.StakeAPI) ([]types.ValidatorDetails, error) {
+	assets, validators, err := getValidators(api)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeValidatorDetails(assets, validators, api.Coin()), nil
+}
+
 func getValidators(api blockatlas.StakeAPI) (AssetValidators, blockatlas.ValidatorPage, error) {
 	assetsValidators, err := fetchValidatorsInfo(api.Coin())
 	if err != nil {
@@ -71,3 +83,44 @@ func calculateAnnual(annual float64, commission float64) float64 {
 func getImage(c coin.Coin, ID string) string {
 	return AssetsURL + c.Handle + "/validators/assets/" + ID + "/logo.png"
 }
+
+func normalizeValidatorDetails(assetsValidators AssetValidators, rpcValidators []types.Validator, coin coin.Coin) []types.ValidatorDetails {
+	results := make([]types.ValidatorDetails, 0, len(rpcValidators))
+	assetsMap := assetsValidators.toMap()
+	for _, v := range rpcValidators {
+		asset, ok := assetsMap[v.ID]
+		commission := v.Commission
+		if commission == 0 {
+			commission = asset.Payout.Commission
+		}
+		details := types.ValidatorDetails{
+			ID:         v.ID,
+			Name:       asset.Name,
+			Website:    asset.Website,
+			Reward:     types.StakingReward{Annual: calculateAnnual(v.Details.Reward.Annual, asset.Payout.Commission)},
+			Commission: commission,
+			Status:     validatorStatus(v, asset),
+		}
+		if ok {
+			details.Description = asset.Description
+			details.Image = getImage(coin, v.ID)
+		}
+		results = append(results, details)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Reward.Annual > results[j].Reward.Annual
+	})
+	return results
+}
+
+func validatorStatus(rpcValidator types.Validator, assetValidator AssetValidator) types.ValidatorStatus {
+	switch {
+	case rpcValidator.Jailed:
+		return types.ValidatorStatusJailed
+	case rpcValidator.Status && !assetValidator.Status.Disabled:
+		return types.ValidatorStatusActive
+	default:
+		return types.ValidatorStatusInactive
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
 	"testing"
 )
 
@@ -207,3 +208,21 @@ func Test_normalizeValidators(t *testing.T) {
 		})
 	}
 }
+
+func Test_normalizeValidatorDetails(t *testing.T) {
+	rpcValidators := []types.Validator{
+		{ID: "test1", Status: true, Details: types.StakingDetails{Reward: types.StakingReward{Annual: 10}}},
+		{ID: "test2", Status: true, Commission: 5, Details: types.StakingDetails{Reward: types.StakingReward{Annual: 20}}},
+		{ID: "test3", Status: true, Jailed: true, Details: types.StakingDetails{Reward: types.StakingReward{Annual: 30}}},
+	}
+	assets := AssetValidators{
+		{ID: "test1", Name: "Spider", Website: "https://tw.com", Payout: ValidatorPayout{Commission: 10}},
+		{ID: "test2", Name: "Man", Website: "https://tw.com", Status: ValidatorStatus{Disabled: true}},
+	}
+	expected := []types.ValidatorDetails{
+		{ID: "test3", Reward: types.StakingReward{Annual: 30}, Status: types.ValidatorStatusJailed},
+		{ID: "test2", Name: "Man", Website: "https://tw.com", Image: getImage(cosmosCoin, "test2"), Reward: types.StakingReward{Annual: 20}, Commission: 5, Status: types.ValidatorStatusInactive},
+		{ID: "test1", Name: "Spider", Website: "https://tw.com", Image: getImage(cosmosCoin, "test1"), Reward: types.StakingReward{Annual: 9}, Commission: 10, Status: types.ValidatorStatusActive},
+	}
+	assert.Equal(t, expected, normalizeValidatorDetails(assets, rpcValidators, cosmosCoin))
+}
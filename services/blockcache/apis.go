@@ -0,0 +1,102 @@
+package blockcache
+
+import (
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type (
+	txAPI struct {
+		blockatlas.TxAPI
+		cache *Cache
+	}
+
+	txUtxoAPI struct {
+		blockatlas.TxUtxoAPI
+		cache *Cache
+	}
+
+	tokenTxAPI struct {
+		blockatlas.TokenTxAPI
+		cache *Cache
+	}
+
+	tokensAPI struct {
+		blockatlas.TokensAPI
+		cache *Cache
+	}
+)
+
+// TxAPI serves the transactions of the addresses from the cache, the api itself without cache
+func (c *Cache) TxAPI(api blockatlas.TxAPI) blockatlas.TxAPI {
+	if c == nil || api == nil {
+		return api
+	}
+	return txAPI{TxAPI: api, cache: c}
+}
+
+func (c *Cache) TxUtxoAPI(api blockatlas.TxUtxoAPI) blockatlas.TxUtxoAPI {
+	if c == nil || api == nil {
+		return api
+	}
+	return txUtxoAPI{TxUtxoAPI: api, cache: c}
+}
+
+func (c *Cache) TokenTxAPI(api blockatlas.TokenTxAPI) blockatlas.TokenTxAPI {
+	if c == nil || api == nil {
+		return api
+	}
+	return tokenTxAPI{TokenTxAPI: api, cache: c}
+}
+
+func (c *Cache) TokensAPI(api blockatlas.TokensAPI) blockatlas.TokensAPI {
+	if c == nil || api == nil {
+		return api
+	}
+	return tokensAPI{TokensAPI: api, cache: c}
+}
+
+func (a txAPI) GetTxsByAddress(address string) (types.TxPage, error) {
+	return a.cache.txPage("txs:"+address, func() (types.TxPage, error) {
+		return a.TxAPI.GetTxsByAddress(address)
+	})
+}
+
+func (a txUtxoAPI) GetTxsByAddress(address string) (types.TxPage, error) {
+	return a.cache.txPage("txs:"+address, func() (types.TxPage, error) {
+		return a.TxUtxoAPI.GetTxsByAddress(address)
+	})
+}
+
+func (a txUtxoAPI) GetTxsByXpub(xpub string) (types.TxPage, error) {
+	return a.cache.txPage("xpub:"+xpub, func() (types.TxPage, error) {
+		return a.TxUtxoAPI.GetTxsByXpub(xpub)
+	})
+}
+
+func (a tokenTxAPI) GetTokenTxsByAddress(address, token string) (types.TxPage, error) {
+	return a.cache.txPage("token_txs:"+address+":"+token, func() (types.TxPage, error) {
+		return a.TokenTxAPI.GetTokenTxsByAddress(address, token)
+	})
+}
+
+func (a tokensAPI) GetTokenListByAddress(address string) (types.TokenPage, error) {
+	value, err := a.cache.get("tokens:"+address, func() (interface{}, error) {
+		return a.TokensAPI.GetTokenListByAddress(address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The handlers sort the pages in place, every request gets its copy
+	return append(types.TokenPage(nil), value.(types.TokenPage)...), nil
+}
+
+func (c *Cache) txPage(key string, fetch func() (types.TxPage, error)) (types.TxPage, error) {
+	value, err := c.get(key, func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(types.TxPage(nil), value.(types.TxPage)...), nil
+}
@@ -0,0 +1,91 @@
+package blockcache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+// DefaultTTL keeps the responses without upstream.block_cache.ttl
+const DefaultTTL = time.Minute * 10
+
+var requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "atlas",
+	Name:      "block_cache_requests_total",
+	Help:      "Block-derived upstream calls served from the block cache (hit) or the upstream (miss), by coin.",
+}, []string{"coin", "result"})
+
+func init() {
+	prometheus.MustRegister(requests)
+}
+
+// Cache serves the block-derived responses of an address (transactions, tokens) again while
+// the chain stays at the height they were fetched at. The height is requested at most every
+// heightInterval, a new block is seen that late at worst.
+type Cache struct {
+	blocks         blockatlas.BlockAPI
+	heightInterval time.Duration
+	responses      *cache.Cache
+	now            func() time.Time
+
+	sync.Mutex
+	height    int64
+	checkedAt time.Time
+}
+
+// New keeps the responses of the platform for ttl at most, the old heights expire
+func New(blocks blockatlas.BlockAPI, heightInterval, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{
+		blocks:         blocks,
+		heightInterval: heightInterval,
+		responses:      cache.New(ttl, ttl),
+		now:            time.Now,
+	}
+}
+
+// Height returns the latest block number, requested again after heightInterval
+func (c *Cache) Height() (int64, error) {
+	c.Lock()
+	defer c.Unlock()
+	now := c.now()
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < c.heightInterval {
+		return c.height, nil
+	}
+	height, err := c.blocks.CurrentBlockNumber()
+	if err != nil {
+		return 0, err
+	}
+	c.height, c.checkedAt = height, now
+	return height, nil
+}
+
+// get returns the response of the key at the current height, fetched once per height.
+// Errors are not cached, and the responses are fetched directly while the height is unknown.
+func (c *Cache) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	coin := c.blocks.Coin().Handle
+	height, err := c.Height()
+	if err != nil {
+		logger.Error(err, "Block cache height", logger.Params{"coin": coin})
+		return fetch()
+	}
+	key = fmt.Sprintf("%s:%d", key, height)
+	if value, ok := c.responses.Get(key); ok {
+		requests.WithLabelValues(coin, "hit").Inc()
+		return value, nil
+	}
+	requests.WithLabelValues(coin, "miss").Inc()
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.responses.SetDefault(key, value)
+	return value, nil
+}
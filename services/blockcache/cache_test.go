@@ -0,0 +1,78 @@
+package blockcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type platformMock struct {
+	height  int64
+	heights int
+	calls   int
+	err     error
+}
+
+func (p *platformMock) Coin() coin.Coin { return coin.Coins[coin.ETH] }
+
+func (p *platformMock) CurrentBlockNumber() (int64, error) {
+	p.heights++
+	return p.height, p.err
+}
+
+func (p *platformMock) GetBlockByNumber(num int64) (*types.Block, error) {
+	return nil, nil
+}
+
+func (p *platformMock) GetTxsByAddress(address string) (types.TxPage, error) {
+	p.calls++
+	return types.TxPage{{ID: "0x2", Block: 2}, {ID: "0x1", Block: 1}}, nil
+}
+
+func TestCache_TxAPI(t *testing.T) {
+	platform := &platformMock{height: 10}
+	cache := New(platform, time.Second*3, time.Minute)
+	now := time.Unix(1600000000, 0)
+	cache.now = func() time.Time { return now }
+	api := cache.TxAPI(platform)
+
+	page, err := api.GetTxsByAddress("0xabc")
+	assert.Nil(t, err)
+	assert.Len(t, page, 2)
+	// The handlers sort the page in place, the cached page stays as fetched
+	page[0], page[1] = page[1], page[0]
+
+	page, err = api.GetTxsByAddress("0xabc")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x2", page[0].ID)
+	assert.Equal(t, 1, platform.calls)
+	assert.Equal(t, 1, platform.heights)
+
+	_, _ = api.GetTxsByAddress("0xdef")
+	assert.Equal(t, 2, platform.calls)
+
+	// A new block is seen after the height interval
+	platform.height = 11
+	now = now.Add(time.Second * 3)
+	_, _ = api.GetTxsByAddress("0xabc")
+	assert.Equal(t, 3, platform.calls)
+	assert.Equal(t, 2, platform.heights)
+
+	// Without height, the upstream is called every time
+	platform.err = blockatlas.ErrSourceConn
+	now = now.Add(time.Second * 3)
+	_, _ = api.GetTxsByAddress("0xabc")
+	_, _ = api.GetTxsByAddress("0xabc")
+	assert.Equal(t, 5, platform.calls)
+}
+
+func TestCache_Nil(t *testing.T) {
+	var cache *Cache
+	platform := &platformMock{}
+	assert.Equal(t, platform, cache.TxAPI(platform))
+	assert.Nil(t, New(platform, time.Second, 0).TxAPI(nil))
+}
@@ -0,0 +1,78 @@
+package classifier
+
+import (
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	// minExchangeTxs is the sample size needed to tell an exchange from a busy wallet
+	minExchangeTxs = 20
+	// exchangeCounterpartyRatio is the share of distinct counterparties of an exchange,
+	// wallets mostly send to the same few addresses
+	exchangeCounterpartyRatio = 0.7
+)
+
+// Summarize classifies the address from the labels dataset, or from the heuristics on its transactions
+func Summarize(coin uint, address string, txs []types.Tx) types.AccountSummary {
+	summary := types.AccountSummary{Coin: coin, Address: address, Transactions: len(txs)}
+
+	counterparties := make(map[string]bool)
+	var incoming, outgoing, coinbase, contractCalls int
+	for i := range txs {
+		tx := &txs[i]
+		if tx.Date > summary.LastActivity {
+			summary.LastActivity = tx.Date
+		}
+		switch tx.GetTransactionDirection(address) {
+		case types.DirectionIncoming:
+			incoming++
+			if tx.From == "" && len(tx.Inputs) == 0 {
+				coinbase++
+			}
+		case types.DirectionOutgoing:
+			outgoing++
+		}
+		if isContractActivity(tx, address) {
+			contractCalls++
+		}
+		for _, a := range tx.GetAddresses() {
+			if a != "" && a != address {
+				counterparties[a] = true
+			}
+		}
+	}
+	summary.Counterparties = len(counterparties)
+
+	if label, ok := Labels.Get(coin, address); ok {
+		summary.Class, summary.Label = label.Class, label.Name
+		return summary
+	}
+	switch {
+	case len(txs) == 0:
+		summary.Class = types.AddressUnknown
+	case contractCalls > 0:
+		summary.Class = types.AddressContract
+	case incoming > 0 && coinbase*2 >= incoming:
+		summary.Class = types.AddressMiner
+	case len(txs) >= minExchangeTxs && incoming > 0 && outgoing > 0 &&
+		float64(summary.Counterparties) >= exchangeCounterpartyRatio*float64(len(txs)):
+		summary.Class = types.AddressExchange
+	default:
+		summary.Class = types.AddressWallet
+	}
+	return summary
+}
+
+// isContractActivity tells if the address is called as a contract, or is the token of a transfer
+func isContractActivity(tx *types.Tx, address string) bool {
+	switch meta := tx.Meta.(type) {
+	case types.ContractCall, *types.ContractCall:
+		return tx.To == address && tx.From != address
+	case types.TokenTransfer:
+		return meta.TokenID == address
+	case *types.TokenTransfer:
+		return meta.TokenID == address
+	default:
+		return false
+	}
+}
@@ -0,0 +1,80 @@
+package classifier
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const address = "0x5574Cd97432cEd0D7Caf58ac3c4fEDB2061C98fB"
+
+func transfers(n, counterparties int) []types.Tx {
+	txs := make([]types.Tx, 0, n)
+	for i := 0; i < n; i++ {
+		other := fmt.Sprintf("0x%040d", i%counterparties)
+		tx := types.Tx{ID: fmt.Sprint(i), Coin: coin.ETH, From: other, To: address, Date: int64(1600000000 + i), Meta: types.Transfer{Value: "1"}}
+		if i%2 == 1 {
+			tx.From, tx.To = address, other
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func TestSummarize(t *testing.T) {
+	coinbase := []types.Tx{
+		{ID: "1", Coin: coin.BTC, Outputs: []types.TxOutput{{Address: address, Value: "625000000"}}, Inputs: nil, Direction: types.DirectionIncoming},
+		{ID: "2", Coin: coin.BTC, Direction: types.DirectionIncoming},
+	}
+	contract := []types.Tx{
+		{ID: "1", Coin: coin.ETH, From: "0xa", To: address, Meta: types.ContractCall{Input: "0xa9059cbb"}},
+	}
+	tests := []struct {
+		name string
+		txs  []types.Tx
+		want types.AddressClass
+	}{
+		{"no activity", nil, types.AddressUnknown},
+		{"wallet", transfers(25, 3), types.AddressWallet},
+		{"few transactions", transfers(10, 10), types.AddressWallet},
+		{"exchange", transfers(25, 25), types.AddressExchange},
+		{"miner", coinbase, types.AddressMiner},
+		{"contract", contract, types.AddressContract},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := Summarize(coin.ETH, address, tt.txs)
+			assert.Equal(t, tt.want, summary.Class)
+			assert.Equal(t, len(tt.txs), summary.Transactions)
+		})
+	}
+
+	summary := Summarize(coin.ETH, address, transfers(25, 3))
+	assert.Equal(t, 3, summary.Counterparties)
+	assert.Equal(t, int64(1600000024), summary.LastActivity)
+}
+
+func TestLabels(t *testing.T) {
+	file, err := ioutil.TempFile("", "labels")
+	assert.Nil(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`{"60": {"` + address + `": {"class": "exchange", "name": "Exchange 1"}}}`)
+	assert.Nil(t, err)
+	assert.Nil(t, file.Close())
+
+	Labels = NewLabelSet()
+	defer func() { Labels = NewLabelSet() }()
+	assert.Nil(t, Labels.LoadLabels(file.Name()))
+
+	summary := Summarize(coin.ETH, "0x5574cd97432ced0d7caf58ac3c4fedb2061c98fb", transfers(2, 1))
+	assert.Equal(t, types.AddressExchange, summary.Class)
+	assert.Equal(t, "Exchange 1", summary.Label)
+
+	summary = Summarize(coin.ETC, address, transfers(2, 1))
+	assert.Equal(t, types.AddressWallet, summary.Class)
+}
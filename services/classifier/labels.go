@@ -0,0 +1,63 @@
+package classifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// Labels is the dataset of known addresses, by coin
+var Labels = NewLabelSet()
+
+type LabelSet struct {
+	sync.RWMutex
+	labels map[uint]map[string]types.AddressLabel
+}
+
+func NewLabelSet() *LabelSet {
+	return &LabelSet{labels: make(map[uint]map[string]types.AddressLabel)}
+}
+
+// Add registers the labels of the coin, addresses are matched case-insensitively
+func (s *LabelSet) Add(coin uint, labels map[string]types.AddressLabel) {
+	s.Lock()
+	defer s.Unlock()
+	if s.labels[coin] == nil {
+		s.labels[coin] = make(map[string]types.AddressLabel)
+	}
+	for address, label := range labels {
+		s.labels[coin][strings.ToLower(address)] = label
+	}
+}
+
+func (s *LabelSet) Get(coin uint, address string) (types.AddressLabel, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	label, ok := s.labels[coin][strings.ToLower(address)]
+	return label, ok
+}
+
+// LoadLabels reads a dataset of the form {"<coin id>": {"<address>": {"class": "exchange", "name": "..."}}}
+func (s *LabelSet) LoadLabels(path string) error {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.E(err, "failed to read labels", errors.Params{"path": path})
+	}
+	var dataset map[string]map[string]types.AddressLabel
+	if err := json.Unmarshal(raw, &dataset); err != nil {
+		return errors.E(err, "invalid labels", errors.Params{"path": path})
+	}
+	for coinStr, labels := range dataset {
+		coin, err := strconv.ParseUint(coinStr, 10, 32)
+		if err != nil {
+			return errors.E(err, "invalid labels coin", errors.Params{"coin": coinStr})
+		}
+		s.Add(uint(coin), labels)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package domains
 
 import (
+	"sort"
+
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/errors"
 	"github.com/trustwallet/blockatlas/platform"
@@ -22,11 +24,19 @@ func HandleLookup(name string, coins []uint64) ([]blockatlas.Resolved, error) {
 	return addresses, nil
 }
 
+// findHandlerApis returns the naming services handling the name by coin ID,
+// the first address resolved is always from the same service
 func findHandlerApis(name string, allApis map[uint]blockatlas.NamingServiceAPI) []blockatlas.NamingServiceAPI {
+	coins := make([]uint, 0, len(allApis))
+	for coin := range allApis {
+		coins = append(coins, coin)
+	}
+	sort.Slice(coins, func(i, j int) bool { return coins[i] < coins[j] })
+
 	apis := []blockatlas.NamingServiceAPI{}
-	for _, api := range allApis {
-		if api.CanHandle(name) {
-			apis = append(apis, api)
+	for _, coin := range coins {
+		if allApis[coin].CanHandle(name) {
+			apis = append(apis, allApis[coin])
 		}
 	}
 	return apis
@@ -0,0 +1,214 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/numbers"
+	toptokens "github.com/trustwallet/blockatlas/services/tokens"
+	"go.elastic.co/apm"
+)
+
+type (
+	// Token is an ERC-20 contract whose transfer logs are indexed
+	Token struct {
+		Contract string `mapstructure:"contract"`
+		Name     string `mapstructure:"name"`
+		Symbol   string `mapstructure:"symbol"`
+		Decimals uint   `mapstructure:"decimals"`
+	}
+
+	Params struct {
+		Ctx         context.Context
+		Coin        coin.Coin
+		RpcURL      string
+		Tokens      []Token
+		Interval    time.Duration
+		BlocksBatch int64
+		StartBlock  int64
+		Database    *db.Instance
+	}
+)
+
+const MinBlocksBatch = 10
+
+func trackerKey(c coin.Coin) string {
+	return "token_indexer_" + c.Handle
+}
+
+// RunIndexer ingests the transfer logs of the configured tokens until the context is cancelled
+func RunIndexer(params Params) {
+	client := &rpcClient{Request: blockatlas.InitJSONClient(params.RpcURL)}
+	tokens := make(map[string]Token, len(params.Tokens))
+	for _, t := range params.Tokens {
+		tokens[strings.ToLower(t.Contract)] = t
+	}
+	for {
+		select {
+		case <-params.Ctx.Done():
+			logger.Info(fmt.Sprintf("Token indexer of %s stopped", params.Coin.Handle))
+			return
+		default:
+			caughtUp, err := index(params, client, tokens)
+			if err != nil {
+				logger.Error(err, logger.Params{"coin": params.Coin.Handle})
+			}
+			if caughtUp || err != nil {
+				time.Sleep(params.Interval)
+			}
+		}
+	}
+}
+
+// index processes the next range of blocks and reports whether the indexer reached the chain head
+func index(params Params, client *rpcClient, tokens map[string]Token) (bool, error) {
+	tx := apm.DefaultTracer.StartTransaction("index_token_transfers", "app")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(context.Background(), tx)
+
+	last, err := params.Database.GetLastParsedBlockNumber(trackerKey(params.Coin), ctx)
+	if err != nil {
+		return false, errors.E(err, "tracker didn't return last indexed block")
+	}
+	if last < params.StartBlock {
+		last = params.StartBlock - 1
+	}
+	current, err := client.currentBlockNumber()
+	if err != nil {
+		return false, errors.E(err, "node didn't return chain head number")
+	}
+	current -= params.Coin.MinConfirmations
+	if last >= current {
+		return true, nil
+	}
+
+	from := last + 1
+	to := from + params.BlocksBatch - 1
+	if to > current {
+		to = current
+	}
+	contracts := make([]string, 0, len(tokens))
+	for contract := range tokens {
+		contracts = append(contracts, contract)
+	}
+	logs, err := client.getTransferLogs(from, to, contracts)
+	if err != nil {
+		return false, errors.E(err, "failed to get transfer logs", errors.Params{"from": from, "to": to})
+	}
+	transfers, err := normalizeLogs(logs, params.Coin.ID, tokens, client.getBlockTimestamp)
+	if err != nil {
+		return false, err
+	}
+	if err := params.Database.AddTokenTransfers(transfers, ctx); err != nil {
+		return false, errors.E(err, "failed to save token transfers")
+	}
+	if err := params.Database.SetLastParsedBlockNumber(trackerKey(params.Coin), to, ctx); err != nil {
+		return false, errors.E(err, "failed to save last indexed block")
+	}
+	logger.Info("Indexed token transfers", logger.Params{"coin": params.Coin.Handle, "from": from, "to": to, "transfers": len(transfers)})
+	return to == current, nil
+}
+
+func normalizeLogs(logs []rpcLog, coinIndex uint, tokens map[string]Token, blockTime func(uint64) (int64, error)) ([]models.TokenTransfer, error) {
+	timestamps := make(map[uint64]int64)
+	transfers := make([]models.TokenTransfer, 0, len(logs))
+	for _, l := range logs {
+		transfer, ok := normalizeLog(l, coinIndex, tokens)
+		if !ok {
+			continue
+		}
+		ts, ok := timestamps[transfer.BlockNumber]
+		if !ok {
+			var err error
+			ts, err = blockTime(transfer.BlockNumber)
+			if err != nil {
+				return nil, errors.E(err, "failed to get block timestamp", errors.Params{"block": transfer.BlockNumber})
+			}
+			timestamps[transfer.BlockNumber] = ts
+		}
+		transfer.Timestamp = ts
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+// normalizeLog decodes an ERC-20 Transfer log, ERC-721 transfers (indexed token ID) are skipped
+func normalizeLog(l rpcLog, coinIndex uint, tokens map[string]Token) (models.TokenTransfer, bool) {
+	if l.Removed || len(l.Topics) != 3 || l.Topics[0] != transferTopic {
+		return models.TokenTransfer{}, false
+	}
+	token, ok := tokens[strings.ToLower(l.Address)]
+	if !ok {
+		return models.TokenTransfer{}, false
+	}
+	block, err := parseHexInt(l.BlockNumber)
+	if err != nil {
+		return models.TokenTransfer{}, false
+	}
+	logIndex, err := parseHexInt(l.LogIndex)
+	if err != nil {
+		return models.TokenTransfer{}, false
+	}
+	value, err := numbers.HexToDecimal(l.Data)
+	if err != nil {
+		return models.TokenTransfer{}, false
+	}
+	return models.TokenTransfer{
+		Coin:        coinIndex,
+		TxHash:      l.TxHash,
+		LogIndex:    uint(logIndex),
+		BlockNumber: uint64(block),
+		Token:       strings.ToLower(l.Address),
+		Name:        token.Name,
+		Symbol:      token.Symbol,
+		Decimals:    token.Decimals,
+		From:        topicToAddress(l.Topics[1]),
+		To:          topicToAddress(l.Topics[2]),
+		Value:       value,
+	}, true
+}
+
+// topicToAddress extracts the address from a 32 bytes left-padded topic
+func topicToAddress(topic string) string {
+	if len(topic) < 40 {
+		return ""
+	}
+	return "0x" + strings.ToLower(topic[len(topic)-40:])
+}
+
+// CoinConfig is the indexer configuration of a coin, read from `indexer.coins.<handle>`
+type CoinConfig struct {
+	StartBlock int64   `mapstructure:"start_block"`
+	Tokens     []Token `mapstructure:"tokens"`
+}
+
+// IndexedTokens returns the tokens listed by the config, the top tokens of the coin when it lists none
+func (c CoinConfig) IndexedTokens(coinID uint) []Token {
+	if len(c.Tokens) > 0 {
+		return c.Tokens
+	}
+	top := toptokens.Tokens.List(coinID)
+	result := make([]Token, 0, len(top))
+	for _, t := range top {
+		result = append(result, Token{Contract: t.Contract, Name: t.Name, Symbol: t.Symbol, Decimals: t.Decimals})
+	}
+	return result
+}
+
+// CoinByHandle returns the coin of the `indexer.coins` key
+func CoinByHandle(handle string) (coin.Coin, bool) {
+	for _, c := range coin.Coins {
+		if c.Handle == handle {
+			return c, true
+		}
+	}
+	return coin.Coin{}, false
+}
@@ -0,0 +1,108 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/platform/ethereum"
+)
+
+var (
+	dai = Token{Contract: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Name: "Dai Stablecoin", Symbol: "DAI", Decimals: 18}
+
+	tokens = map[string]Token{"0x6b175474e89094c44da98b954eedeac495271d0f": dai}
+
+	transferLog = rpcLog{
+		Address: "0x6b175474e89094c44da98b954eedeac495271d0f",
+		Topics: []string{
+			transferTopic,
+			"0x000000000000000000000000d35f30d194684a391c63a6deced7d3dd5207c265",
+			"0x000000000000000000000000aa4d790076f1bf7511a0a0ac498c89e13e1efe17",
+		},
+		Data:        "0x0000000000000000000000000000000000000000000000000de0b6b3a7640000",
+		BlockNumber: "0x8a4f48",
+		TxHash:      "0x7777854580f273df61e0162e1a41b3e1e05ab8b9f553036fa9329a90dd7e9ab2",
+		LogIndex:    "0x2",
+	}
+
+	indexedTransfer = models.TokenTransfer{
+		Coin:        coin.ETH,
+		TxHash:      "0x7777854580f273df61e0162e1a41b3e1e05ab8b9f553036fa9329a90dd7e9ab2",
+		LogIndex:    2,
+		BlockNumber: 9064264,
+		Timestamp:   1575331200,
+		Token:       "0x6b175474e89094c44da98b954eedeac495271d0f",
+		Name:        "Dai Stablecoin",
+		Symbol:      "DAI",
+		Decimals:    18,
+		From:        "0xd35f30d194684a391c63a6deced7d3dd5207c265",
+		To:          "0xaa4d790076f1bf7511a0a0ac498c89e13e1efe17",
+		Value:       "1000000000000000000",
+	}
+)
+
+func Test_normalizeLogs(t *testing.T) {
+	nft := transferLog
+	nft.Topics = append(nft.Topics, "0x01")
+	unknown := transferLog
+	unknown.Address = "0xf3586684107ce0859c44aa2b2e0fb8cd8731a15a"
+	removed := transferLog
+	removed.Removed = true
+
+	calls := 0
+	blockTime := func(num uint64) (int64, error) {
+		calls++
+		assert.Equal(t, uint64(9064264), num)
+		return 1575331200, nil
+	}
+
+	transfers, err := normalizeLogs([]rpcLog{transferLog, nft, unknown, removed, transferLog}, coin.ETH, tokens, blockTime)
+	assert.Nil(t, err)
+	assert.Equal(t, []models.TokenTransfer{indexedTransfer, indexedTransfer}, transfers)
+	assert.Equal(t, 1, calls)
+}
+
+func Test_normalizeTransfer(t *testing.T) {
+	tests := []struct {
+		name      string
+		address   string
+		direction types.Direction
+	}{
+		{"Outgoing", "0xd35f30d194684a391c63a6deced7d3dd5207c265", types.DirectionOutgoing},
+		{"Incoming", "0xaa4d790076f1bf7511a0a0ac498c89e13e1efe17", types.DirectionIncoming},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := normalizeTransfer(indexedTransfer, tt.address)
+			assert.Equal(t, tt.direction, tx.Direction)
+			assert.Equal(t, types.TxTokenTransfer, tx.Type)
+			assert.Equal(t, indexedTransfer.TxHash+"-2", tx.ID)
+			assert.Equal(t, types.TokenTransfer{
+				Name:     "Dai Stablecoin",
+				Symbol:   "DAI",
+				TokenID:  "0x6b175474e89094c44da98b954eedeac495271d0f",
+				Decimals: 18,
+				Value:    "1000000000000000000",
+				From:     "0xd35f30d194684a391c63a6deced7d3dd5207c265",
+				To:       "0xaa4d790076f1bf7511a0a0ac498c89e13e1efe17",
+			}, tx.Meta)
+		})
+	}
+}
+
+func TestStorage_GetTokenTxs_NotIndexed(t *testing.T) {
+	s, err := NewStorage(nil, map[string]CoinConfig{"ethereum": {Tokens: []Token{dai}}})
+	assert.Nil(t, err)
+	assert.True(t, s.tokens[coin.ETH]["0x6b175474e89094c44da98b954eedeac495271d0f"])
+
+	_, err = s.GetTokenTxs("0xaa4d790076f1bf7511a0a0ac498c89e13e1efe17", "0xdAC17F958D2ee523a2206206994597C13D831ec7", coin.ETH)
+	assert.Equal(t, ethereum.ErrNotSupported, err)
+	_, err = s.GetTokenTxs("0xaa4d790076f1bf7511a0a0ac498c89e13e1efe17", dai.Contract, coin.ETC)
+	assert.Equal(t, ethereum.ErrNotSupported, err)
+
+	_, err = NewStorage(nil, map[string]CoinConfig{"unknown": {}})
+	assert.NotNil(t, err)
+}
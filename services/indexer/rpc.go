@@ -0,0 +1,81 @@
+package indexer
+
+import (
+	"strconv"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// transferTopic is keccak256("Transfer(address,address,uint256)")
+const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+type (
+	rpcClient struct {
+		blockatlas.Request
+	}
+
+	logFilter struct {
+		FromBlock string     `json:"fromBlock"`
+		ToBlock   string     `json:"toBlock"`
+		Address   []string   `json:"address"`
+		Topics    [][]string `json:"topics"`
+	}
+
+	rpcLog struct {
+		Address     string   `json:"address"`
+		Topics      []string `json:"topics"`
+		Data        string   `json:"data"`
+		BlockNumber string   `json:"blockNumber"`
+		TxHash      string   `json:"transactionHash"`
+		LogIndex    string   `json:"logIndex"`
+		Removed     bool     `json:"removed"`
+	}
+
+	rpcBlock struct {
+		Timestamp string `json:"timestamp"`
+	}
+)
+
+func (c *rpcClient) currentBlockNumber() (int64, error) {
+	var result string
+	if err := c.RpcCall(&result, "eth_blockNumber", []interface{}{}); err != nil {
+		return 0, err
+	}
+	return parseHexInt(result)
+}
+
+func (c *rpcClient) getTransferLogs(from, to int64, contracts []string) ([]rpcLog, error) {
+	var logs []rpcLog
+	filter := logFilter{
+		FromBlock: toHex(from),
+		ToBlock:   toHex(to),
+		Address:   contracts,
+		Topics:    [][]string{{transferTopic}},
+	}
+	err := c.RpcCall(&logs, "eth_getLogs", []interface{}{filter})
+	return logs, err
+}
+
+func (c *rpcClient) getBlockTimestamp(num uint64) (int64, error) {
+	var block *rpcBlock
+	if err := c.RpcCall(&block, "eth_getBlockByNumber", []interface{}{toHex(int64(num)), false}); err != nil {
+		return 0, err
+	}
+	if block == nil {
+		return 0, errors.E("block not found", errors.Params{"block": num})
+	}
+	return parseHexInt(block.Timestamp)
+}
+
+func toHex(num int64) string {
+	return "0x" + strconv.FormatInt(num, 16)
+}
+
+func parseHexInt(hex string) (int64, error) {
+	num, err := strconv.ParseInt(hex, 0, 64)
+	if err != nil {
+		return 0, errors.E(err, "invalid hex number", errors.Params{"hex": hex})
+	}
+	return num, nil
+}
@@ -0,0 +1,91 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/platform/ethereum"
+)
+
+const maxStoredTxs = 25
+
+// Storage serves the token transactions from the indexed transfers
+type Storage struct {
+	Database *db.Instance
+	// coin -> lower case contract of the indexed tokens
+	tokens map[uint]map[string]bool
+}
+
+// NewStorage returns the storage of the tokens indexed with the `indexer.coins` config
+func NewStorage(database *db.Instance, coins map[string]CoinConfig) (*Storage, error) {
+	s := &Storage{Database: database, tokens: make(map[uint]map[string]bool)}
+	for handle, config := range coins {
+		c, ok := CoinByHandle(handle)
+		if !ok {
+			return nil, errors.E("Unknown coin handle", errors.Params{"handle": handle})
+		}
+		s.tokens[c.ID] = make(map[string]bool)
+		for _, t := range config.IndexedTokens(c.ID) {
+			s.tokens[c.ID][strings.ToLower(t.Contract)] = true
+		}
+	}
+	return s, nil
+}
+
+// GetTransactions is not supported, only token transfers are indexed
+func (s *Storage) GetTransactions(address string, coinIndex uint) (types.TxPage, error) {
+	return nil, ethereum.ErrNotSupported
+}
+
+// GetTokenTxs is not supported for the tokens the indexer doesn't ingest, the next explorer serves them
+func (s *Storage) GetTokenTxs(address, token string, coinIndex uint) (types.TxPage, error) {
+	if !s.tokens[coinIndex][strings.ToLower(token)] {
+		return nil, ethereum.ErrNotSupported
+	}
+	transfers, err := s.Database.GetTokenTransfers(coinIndex, address, token, maxStoredTxs, context.Background())
+	if err != nil {
+		return nil, err
+	}
+	txs := make(types.TxPage, 0, len(transfers))
+	for _, t := range transfers {
+		txs = append(txs, normalizeTransfer(t, strings.ToLower(address)))
+	}
+	return txs, nil
+}
+
+// normalizeTransfer builds the transaction from the log, gas is not part of it so the fee is left empty.
+// A transaction can log several transfers of the address, the log index keeps their ids apart.
+func normalizeTransfer(t models.TokenTransfer, address string) types.Tx {
+	direction := types.DirectionIncoming
+	if t.From == address && t.To == address {
+		direction = types.DirectionSelf
+	} else if t.From == address {
+		direction = types.DirectionOutgoing
+	}
+	return types.Tx{
+		ID:        fmt.Sprintf("%s-%d", t.TxHash, t.LogIndex),
+		Coin:      t.Coin,
+		From:      t.From,
+		To:        t.To,
+		Fee:       "0",
+		Date:      t.Timestamp,
+		Block:     t.BlockNumber,
+		Status:    types.StatusCompleted,
+		Type:      types.TxTokenTransfer,
+		Direction: direction,
+		Meta: types.TokenTransfer{
+			Name:     t.Name,
+			Symbol:   t.Symbol,
+			TokenID:  t.Token,
+			Decimals: t.Decimals,
+			Value:    types.Amount(t.Value),
+			From:     t.From,
+			To:       t.To,
+		},
+	}
+}
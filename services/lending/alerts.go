@@ -0,0 +1,54 @@
+package lending
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// RateAlert is a triggered lending rate alert, with the APY it moved from
+type RateAlert struct {
+	models.LendingRateAlert
+	PreviousAPY float64
+}
+
+// LiquidationAlert is a triggered liquidation risk alert, with the health factor of the account
+type LiquidationAlert struct {
+	models.LiquidationAlert
+	Risk types.LiquidationRisk
+}
+
+// AlertSenders deliver the alerts triggered by RunRefresher, the notifier sends them through its channel drivers
+type AlertSenders struct {
+	Rates        func(alerts []RateAlert, ctx context.Context)
+	Liquidations func(alerts []LiquidationAlert, ctx context.Context)
+}
+
+// EvaluateAlert records the new APY of the asset in the alert and returns whether it is triggered, with the
+// APY it moved from. The first evaluation only records the APY, the thresholds trigger when they are crossed
+// and the change is measured from the APY of the last notification.
+func EvaluateAlert(alert *models.LendingRateAlert, apy float64, now time.Time) (float64, bool) {
+	defer func() {
+		alert.APY = apy
+		alert.EvaluatedAt = &now
+	}()
+	if alert.EvaluatedAt == nil {
+		alert.NotifiedAPY = apy
+		return 0, false
+	}
+
+	previous := alert.APY
+	triggered := (alert.Above > 0 && alert.APY <= alert.Above && apy > alert.Above) ||
+		(alert.Below > 0 && alert.APY >= alert.Below && apy < alert.Below)
+	if !triggered && alert.Change > 0 && alert.NotifiedAPY > 0 &&
+		math.Abs(apy-alert.NotifiedAPY)/alert.NotifiedAPY*100 > alert.Change {
+		previous, triggered = alert.NotifiedAPY, true
+	}
+	if triggered {
+		alert.NotifiedAPY = apy
+	}
+	return previous, triggered
+}
@@ -0,0 +1,38 @@
+package lending
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+)
+
+func TestEvaluateAlert(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	alert := models.LendingRateAlert{Above: 5, Below: 2, Change: 50}
+
+	_, ok := EvaluateAlert(&alert, 6, now)
+	assert.False(t, ok, "the first evaluation records the rate")
+	assert.Equal(t, 6.0, alert.NotifiedAPY)
+
+	_, ok = EvaluateAlert(&alert, 4, now)
+	assert.False(t, ok)
+
+	previous, ok := EvaluateAlert(&alert, 5.5, now)
+	assert.True(t, ok, "crossed above")
+	assert.Equal(t, 4.0, previous)
+
+	_, ok = EvaluateAlert(&alert, 7, now)
+	assert.False(t, ok, "still above and within the change")
+
+	previous, ok = EvaluateAlert(&alert, 1.5, now)
+	assert.True(t, ok, "crossed below")
+	assert.Equal(t, 7.0, previous)
+
+	previous, ok = EvaluateAlert(&alert, 2.5, now)
+	assert.True(t, ok, "changed by more than 50% of the notified rate")
+	assert.Equal(t, 1.5, previous)
+	assert.Equal(t, 2.5, alert.NotifiedAPY)
+	assert.Equal(t, 2.5, alert.APY)
+}
@@ -0,0 +1,112 @@
+package lending
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type (
+	// ProviderCache keeps the last info fetched from every lending provider
+	ProviderCache struct {
+		sync.RWMutex
+		providers map[string]CachedProvider
+		// staleAfter is the age of the info missing two refreshes, set by the refreshers
+		staleAfter time.Duration
+	}
+
+	CachedProvider struct {
+		Info types.LendingProvider `json:"info"`
+		// UpdatedAt is the time of the last successful fetch, the info is kept when a fetch fails
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+)
+
+// Providers is refreshed by RunRefresher in the notifier and RunCacheRefresher in the API
+var Providers = NewProviderCache()
+
+func NewProviderCache() *ProviderCache {
+	return &ProviderCache{providers: make(map[string]CachedProvider)}
+}
+
+// Refresh fetches the info of every provider, returning the IDs of the providers updated
+func (c *ProviderCache) Refresh(apis map[string]blockatlas.LendingAPI, now time.Time, ctx context.Context) []string {
+	updated := make([]string, 0, len(apis))
+	for id, api := range apis {
+		if _, err := c.RefreshProvider(id, api, now, ctx); err != nil {
+			logger.Error(err, logger.Params{"lending_provider": id})
+			continue
+		}
+		updated = append(updated, id)
+	}
+	return updated
+}
+
+// RefreshProvider fetches the info of one provider at once, out of the schedule of the refreshers.
+// The cached info is kept when the fetch fails.
+func (c *ProviderCache) RefreshProvider(id string, api blockatlas.LendingAPI, now time.Time, ctx context.Context) (CachedProvider, error) {
+	info, err := api.GetProviderInfo(ctx)
+	if err != nil {
+		return CachedProvider{}, err
+	}
+	c.Set(id, info, now)
+	return CachedProvider{Info: info, UpdatedAt: now}, nil
+}
+
+func (c *ProviderCache) Set(id string, info types.LendingProvider, updatedAt time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.providers[id] = CachedProvider{Info: info, UpdatedAt: updatedAt}
+}
+
+func (c *ProviderCache) Get(id string) (CachedProvider, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	p, ok := c.providers[id]
+	return p, ok
+}
+
+// StaleAfter is the age of the info of a provider failing to refresh, 0 without a refresher
+func (c *ProviderCache) StaleAfter() time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	return c.staleAfter
+}
+
+func (c *ProviderCache) setInterval(interval time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.staleAfter = 2 * interval
+}
+
+// All returns the cached providers by ID
+func (c *ProviderCache) All() map[string]CachedProvider {
+	c.RLock()
+	defer c.RUnlock()
+	result := make(map[string]CachedProvider, len(c.providers))
+	for id, p := range c.providers {
+		result[id] = p
+	}
+	return result
+}
+
+// RunCacheRefresher fills the cache at once, then refreshes it at every interval
+func RunCacheRefresher(cache *ProviderCache, apis map[string]blockatlas.LendingAPI, interval time.Duration, ctx context.Context) {
+	cache.setInterval(interval)
+	cache.Refresh(apis, time.Now(), ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Lending cache refresher stopped")
+			return
+		case now := <-ticker.C:
+			cache.Refresh(apis, now, ctx)
+		}
+	}
+}
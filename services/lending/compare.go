@@ -0,0 +1,82 @@
+package lending
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// comparisonDisclaimers explain the normalization of every comparison
+var comparisonDisclaimers = []string{
+	"Rates are variable and reported by the providers, past rates don't guarantee future ones.",
+	"apy adds the reward_apr of the liquidity mining rewards to the base_apy interest, the rewards are valued at the market price of illiquid tokens.",
+	"Risk tiers assess the protocols, they don't guarantee the deposits.",
+	"tvl is in USD as reported by the provider.",
+}
+
+// Compare builds the comparison of the savings products of the asset from the cached providers,
+// the products of data older than maxAge are flagged as stale (0 for no check)
+func Compare(asset string, providers map[string]CachedProvider, now time.Time, maxAge time.Duration) types.LendingComparison {
+	comparison := types.LendingComparison{
+		Asset:       strings.ToUpper(asset),
+		Products:    make([]types.SavingsProduct, 0),
+		Disclaimers: comparisonDisclaimers,
+	}
+	for id, cached := range providers {
+		for _, a := range cached.Info.Assets {
+			if !strings.EqualFold(a.Symbol, asset) {
+				continue
+			}
+			comparison.Products = append(comparison.Products, toSavingsProduct(id, cached, a, now, maxAge))
+		}
+	}
+	sort.Slice(comparison.Products, func(i, j int) bool {
+		pi, pj := comparison.Products[i], comparison.Products[j]
+		if pi.APY != pj.APY {
+			return pi.APY > pj.APY
+		}
+		return pi.Provider < pj.Provider
+	})
+	return comparison
+}
+
+func toSavingsProduct(id string, cached CachedProvider, asset types.AssetInfo, now time.Time, maxAge time.Duration) types.SavingsProduct {
+	product := types.SavingsProduct{
+		Provider:  id,
+		Type:      cached.Info.Type,
+		Chain:     asset.Chain,
+		APY:       asset.APY,
+		BaseAPY:   asset.APY,
+		Lockup:    asset.Lockup,
+		RiskTier:  cached.Info.Info.RiskTier,
+		TVL:       asset.TVL,
+		Status:    asset.Status,
+		UpdatedAt: cached.UpdatedAt.Unix(),
+	}
+	if asset.Rewards != nil {
+		product.BaseAPY = asset.Rewards.BaseAPY
+		product.RewardAPR = asset.Rewards.RewardAPR(false)
+		if locked := product.RewardAPR - asset.Rewards.RewardAPR(true); locked > 0 {
+			product.Notes = append(product.Notes, fmt.Sprintf("%.2f%% of the reward APR is not claimable yet", locked))
+		}
+	}
+	if cached.Info.Type == types.ProviderTypeYield {
+		product.Notes = append(product.Notes, "The vault deploys the deposits to the strategies of other protocols, the APY is net of the vault fees")
+	}
+	if asset.Lockup > 0 {
+		product.Notes = append(product.Notes, fmt.Sprintf("Deposits are locked for %s", time.Duration(asset.Lockup)*time.Second))
+	}
+	if !asset.Status.AcceptsDeposits() {
+		product.Notes = append(product.Notes, fmt.Sprintf("The market is %s", asset.Status))
+	}
+	if product.RiskTier == "" {
+		product.Notes = append(product.Notes, "The provider has no risk tier")
+	}
+	if maxAge > 0 && now.Sub(cached.UpdatedAt) > maxAge {
+		product.Notes = append(product.Notes, fmt.Sprintf("Stale data, last updated %s ago", now.Sub(cached.UpdatedAt).Truncate(time.Second)))
+	}
+	return product
+}
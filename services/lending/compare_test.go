@@ -0,0 +1,63 @@
+package lending
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestCompare(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	providers := map[string]CachedProvider{
+		"compound": {
+			Info: types.LendingProvider{ID: "compound", Type: types.ProviderTypeLending, Info: types.ProviderInfo{RiskTier: types.RiskLow}, Assets: []types.AssetInfo{
+				{Symbol: "USDC", Chain: "ETH", APY: 4, Rewards: &types.RewardsBreakdown{BaseAPY: 2.5, Rewards: []types.RewardAPR{{Token: "COMP", APR: 1.5, Claimable: true}}}},
+				{Symbol: "DAI", Chain: "ETH", APY: 9},
+			}},
+			UpdatedAt: now.Add(-time.Minute),
+		},
+		"aave": {
+			Info: types.LendingProvider{ID: "aave", Type: types.ProviderTypeLending, Assets: []types.AssetInfo{
+				{Symbol: "usdc", Chain: "ETH", APY: 5, Lockup: 86400, Status: types.MarketFrozen},
+			}},
+			UpdatedAt: now.Add(-time.Hour),
+		},
+	}
+
+	comparison := Compare("usdc", providers, now, 10*time.Minute)
+	assert.Equal(t, "USDC", comparison.Asset)
+	assert.NotEmpty(t, comparison.Disclaimers)
+	assert.Len(t, comparison.Products, 2)
+
+	aave := comparison.Products[0]
+	assert.Equal(t, "aave", aave.Provider)
+	assert.Equal(t, 5.0, aave.BaseAPY)
+	assert.Equal(t, now.Add(-time.Hour).Unix(), aave.UpdatedAt)
+	assert.Equal(t, []string{
+		"Deposits are locked for 24h0m0s",
+		"The market is frozen",
+		"The provider has no risk tier",
+		"Stale data, last updated 1h0m0s ago",
+	}, aave.Notes)
+
+	compound := comparison.Products[1]
+	assert.Equal(t, 4.0, compound.APY)
+	assert.Equal(t, 2.5, compound.BaseAPY)
+	assert.Equal(t, 1.5, compound.RewardAPR)
+	assert.Equal(t, types.RiskLow, compound.RiskTier)
+	assert.Empty(t, compound.Notes)
+
+	providers["yearn"] = CachedProvider{
+		Info: types.LendingProvider{ID: "yearn", Type: types.ProviderTypeYield, Info: types.ProviderInfo{RiskTier: types.RiskMedium}, Assets: []types.AssetInfo{
+			{Symbol: "USDC", Chain: "ETH", APY: 3},
+		}},
+		UpdatedAt: now,
+	}
+	yearn := Compare("USDC", providers, now, 0).Products[2]
+	assert.Equal(t, types.ProviderTypeYield, yearn.Type)
+	assert.Equal(t, []string{"The vault deploys the deposits to the strategies of other protocols, the APY is net of the vault fees"}, yearn.Notes)
+
+	assert.Empty(t, Compare("BTC", providers, now, 0).Products)
+}
@@ -0,0 +1,192 @@
+package lending
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	secondsPerYear = 365 * 24 * 60 * 60
+
+	// maxEarningsPoints bounds the history of an asset, the interval grows for long histories
+	maxEarningsPoints = 1000
+
+	// amountPrecision keeps the smallest unit of 18 decimals tokens exact
+	amountPrecision = 256
+)
+
+// AccountEarnings reconstructs the interest accrued by the addresses at the provider until the given
+// time, from the deposit and withdraw events of the addresses and the rate history of the assets.
+// The history of every asset has a point at each event, rate change and interval (seconds, 0 for none).
+func AccountEarnings(api blockatlas.LendingHistoryAPI, req types.AccountRequest, until, interval int64, ctx context.Context) ([]types.AccountLendingEarnings, error) {
+	eventsByAddress := make(map[string][]types.LendingEvent, len(req.Addresses))
+	from := make(map[string]int64)
+	for _, address := range req.Addresses {
+		events, err := api.GetAccountLendingEvents(address, ctx)
+		if err != nil {
+			return nil, errors.E(err, "Failed to get lending events", errors.Params{"address": address})
+		}
+		events = filterAssets(events, req.Assets)
+		for _, e := range events {
+			if start, ok := from[e.Asset]; !ok || e.Date < start {
+				from[e.Asset] = e.Date
+			}
+		}
+		eventsByAddress[address] = events
+	}
+
+	rates := make(map[string][]types.LendingRatePoint, len(from))
+	for asset, start := range from {
+		history, err := api.GetLendingRateHistory(asset, start, until, ctx)
+		if err != nil {
+			return nil, errors.E(err, "Failed to get lending rate history", errors.Params{"asset": asset})
+		}
+		rates[asset] = history
+	}
+
+	result := make([]types.AccountLendingEarnings, 0, len(req.Addresses))
+	for _, address := range req.Addresses {
+		result = append(result, types.AccountLendingEarnings{
+			Address:  address,
+			Earnings: ReconstructEarnings(eventsByAddress[address], rates, until, interval),
+		})
+	}
+	return result, nil
+}
+
+// ReconstructEarnings compounds the deposits of every asset at its APY over time, the rate of a point
+// holds until the next one and the first known rate is used before it
+func ReconstructEarnings(events []types.LendingEvent, rates map[string][]types.LendingRatePoint, until, interval int64) []types.LendingEarnings {
+	byAsset := make(map[string][]types.LendingEvent)
+	for _, e := range events {
+		byAsset[e.Asset] = append(byAsset[e.Asset], e)
+	}
+	assets := make([]string, 0, len(byAsset))
+	for asset := range byAsset {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	result := make([]types.LendingEarnings, 0, len(assets))
+	for _, asset := range assets {
+		result = append(result, reconstructAsset(asset, byAsset[asset], rates[asset], until, interval))
+	}
+	return result
+}
+
+func reconstructAsset(asset string, events []types.LendingEvent, rates []types.LendingRatePoint, until, interval int64) types.LendingEarnings {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date < events[j].Date })
+	rates = append([]types.LendingRatePoint(nil), rates...)
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Date < rates[j].Date })
+
+	start := events[0].Date
+	if until < events[len(events)-1].Date {
+		until = events[len(events)-1].Date
+	}
+	times := []int64{until}
+	for _, e := range events {
+		times = append(times, e.Date)
+	}
+	for _, r := range rates {
+		if r.Date > start && r.Date < until {
+			times = append(times, r.Date)
+		}
+	}
+	if interval > 0 {
+		if (until-start)/interval > maxEarningsPoints {
+			interval = (until-start)/maxEarningsPoints + 1
+		}
+		for t := start + interval; t < until; t += interval {
+			times = append(times, t)
+		}
+	}
+	times = uniqueSorted(times)
+
+	balance, principal := new(big.Float).SetPrec(amountPrecision), new(big.Float).SetPrec(amountPrecision)
+	earnings := types.LendingEarnings{Asset: asset, History: make([]types.EarningsPoint, 0, len(times))}
+	next, prev := 0, start
+	for _, t := range times {
+		if t > prev {
+			factor := math.Pow(1+rateAt(rates, prev)/100, float64(t-prev)/secondsPerYear)
+			balance.Mul(balance, big.NewFloat(factor))
+			prev = t
+		}
+		for ; next < len(events) && events[next].Date == t; next++ {
+			value, ok := new(big.Float).SetPrec(amountPrecision).SetString(string(events[next].Value))
+			if !ok {
+				continue
+			}
+			switch events[next].Type {
+			case types.LendingDeposit:
+				balance.Add(balance, value)
+				principal.Add(principal, value)
+			case types.LendingWithdraw:
+				balance.Sub(balance, value)
+				principal.Sub(principal, value)
+			}
+			// The rate history underestimating the interest, the account was fully withdrawn
+			if balance.Sign() < 0 {
+				balance.SetInt64(0)
+			}
+		}
+		earnings.History = append(earnings.History, types.EarningsPoint{
+			Date:    t,
+			Balance: amount(balance),
+			Earned:  amount(new(big.Float).Sub(balance, principal)),
+		})
+	}
+	earnings.Principal = amount(principal)
+	earnings.Balance = amount(balance)
+	earnings.Earned = amount(new(big.Float).Sub(balance, principal))
+	return earnings
+}
+
+func rateAt(rates []types.LendingRatePoint, t int64) float64 {
+	if len(rates) == 0 {
+		return 0
+	}
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].Date > t })
+	if i == 0 {
+		return rates[0].APY
+	}
+	return rates[i-1].APY
+}
+
+func filterAssets(events []types.LendingEvent, assets []string) []types.LendingEvent {
+	if len(assets) == 0 {
+		return events
+	}
+	result := make([]types.LendingEvent, 0, len(events))
+	for _, e := range events {
+		for _, asset := range assets {
+			if strings.EqualFold(e.Asset, asset) {
+				result = append(result, e)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func uniqueSorted(values []int64) []int64 {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	result := make([]int64, 0, len(values))
+	for _, v := range values {
+		if len(result) == 0 || v != result[len(result)-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func amount(f *big.Float) types.Amount {
+	rounded, _ := new(big.Float).Add(f, big.NewFloat(0.5*float64(f.Sign()))).Int(nil)
+	return types.Amount(rounded.String())
+}
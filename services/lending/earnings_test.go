@@ -0,0 +1,50 @@
+package lending
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const day = 24 * 60 * 60
+
+func TestReconstructEarnings(t *testing.T) {
+	events := []types.LendingEvent{
+		{Type: types.LendingWithdraw, Asset: "DAI", Value: "500000", Date: 365 * day},
+		{Type: types.LendingDeposit, Asset: "DAI", Value: "1000000", Date: 0},
+	}
+	rates := map[string][]types.LendingRatePoint{
+		"DAI": {{Date: 0, APY: 10}, {Date: 365 * day, APY: 0}},
+	}
+	earnings := ReconstructEarnings(events, rates, 2*365*day, 0)
+	assert.Len(t, earnings, 1)
+	assert.Equal(t, "DAI", earnings[0].Asset)
+	assert.Equal(t, types.Amount("500000"), earnings[0].Principal)
+	assert.Equal(t, types.Amount("600000"), earnings[0].Balance)
+	assert.Equal(t, types.Amount("100000"), earnings[0].Earned)
+	assert.Equal(t, []types.EarningsPoint{
+		{Date: 0, Balance: "1000000", Earned: "0"},
+		{Date: 365 * day, Balance: "600000", Earned: "100000"},
+		{Date: 2 * 365 * day, Balance: "600000", Earned: "100000"},
+	}, earnings[0].History)
+}
+
+func TestReconstructEarnings_Interval(t *testing.T) {
+	events := []types.LendingEvent{{Type: types.LendingDeposit, Asset: "USDC", Value: "100", Date: 0}}
+	earnings := ReconstructEarnings(events, nil, 3*day, day)
+	assert.Len(t, earnings[0].History, 4)
+	assert.Equal(t, types.Amount("0"), earnings[0].Earned)
+
+	earnings = ReconstructEarnings(events, nil, 10000*day, day)
+	assert.True(t, len(earnings[0].History) <= maxEarningsPoints+1)
+}
+
+func Test_rateAt(t *testing.T) {
+	rates := []types.LendingRatePoint{{Date: 10, APY: 1}, {Date: 20, APY: 2}}
+	assert.Equal(t, 1.0, rateAt(rates, 5))
+	assert.Equal(t, 1.0, rateAt(rates, 10))
+	assert.Equal(t, 1.0, rateAt(rates, 19))
+	assert.Equal(t, 2.0, rateAt(rates, 25))
+	assert.Equal(t, 0.0, rateAt(nil, 25))
+}
@@ -0,0 +1,98 @@
+package lending
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// DefaultInfoTTL keeps the info of the providers without lending.info_cache.ttl
+const DefaultInfoTTL = time.Minute * 10
+
+type (
+	// InfoStore keeps the encoded info of the providers until it expires
+	InfoStore interface {
+		Get(key string) ([]byte, bool, error)
+		Set(key string, value []byte, ttl time.Duration) error
+	}
+
+	// InfoCache serves the info of the providers from the store until it expires, the info is fetched
+	// from the provider again after
+	InfoCache struct {
+		store InfoStore
+		ttl   time.Duration
+		// ttls are the expiries of the providers not keeping the default one
+		ttls map[string]time.Duration
+	}
+
+	memoryStore struct {
+		cache *cache.Cache
+	}
+)
+
+func NewInfoCache(store InfoStore, ttl time.Duration, ttls map[string]time.Duration) *InfoCache {
+	if ttl <= 0 {
+		ttl = DefaultInfoTTL
+	}
+	return &InfoCache{store: store, ttl: ttl, ttls: ttls}
+}
+
+// NewMemoryStore keeps the info in the memory of the instance
+func NewMemoryStore() InfoStore {
+	return memoryStore{cache: cache.New(DefaultInfoTTL, DefaultInfoTTL)}
+}
+
+func (s memoryStore) Get(key string) ([]byte, bool, error) {
+	value, ok := s.cache.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	return value.([]byte), true, nil
+}
+
+func (s memoryStore) Set(key string, value []byte, ttl time.Duration) error {
+	s.cache.Set(key, value, ttl)
+	return nil
+}
+
+// TTL is the expiry of the info of the provider
+func (c *InfoCache) TTL(id string) time.Duration {
+	if ttl, ok := c.ttls[id]; ok && ttl > 0 {
+		return ttl
+	}
+	return c.ttl
+}
+
+// GetProviderInfo returns the cached info of the provider, fetched when missing or expired.
+// A failing store is logged and bypassed.
+func (c *InfoCache) GetProviderInfo(id string, api blockatlas.LendingAPI, ctx context.Context) (types.LendingProvider, error) {
+	key := "lending:info:" + id
+	value, ok, err := c.store.Get(key)
+	if err != nil {
+		logger.Error(err, "Lending info cache unavailable", logger.Params{"lending_provider": id})
+	}
+	var info types.LendingProvider
+	if ok && gob.NewDecoder(bytes.NewReader(value)).Decode(&info) == nil {
+		return info, nil
+	}
+
+	info, err = api.GetProviderInfo(ctx)
+	if err != nil {
+		return info, err
+	}
+	// gob keeps the empty amounts the JSON decoding of types.Amount rejects
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(info); err == nil {
+		err = c.store.Set(key, buf.Bytes(), c.TTL(id))
+	}
+	if err != nil {
+		logger.Error(err, "Failed to cache the lending info", logger.Params{"lending_provider": id})
+	}
+	return info, nil
+}
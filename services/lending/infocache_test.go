@@ -0,0 +1,121 @@
+package lending
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type countingLendingAPI struct {
+	calls *int
+	err   error
+}
+
+func (m countingLendingAPI) GetProviderInfo(ctx context.Context) (types.LendingProvider, error) {
+	*m.calls++
+	return types.LendingProvider{ID: "compound", Assets: []types.AssetInfo{{Symbol: "DAI", APY: 3}}}, m.err
+}
+
+func (m countingLendingAPI) GetCurrentLendingRates(assets []string, ctx context.Context) (types.LendingRates, error) {
+	return nil, nil
+}
+
+func (m countingLendingAPI) GetAccountLendingContracts(req types.AccountRequest, ctx context.Context) (*[]types.AccountLendingContracts, error) {
+	return nil, nil
+}
+
+func TestInfoCache(t *testing.T) {
+	c := NewInfoCache(NewMemoryStore(), 0, map[string]time.Duration{"aave": time.Millisecond * 50})
+	assert.Equal(t, DefaultInfoTTL, c.TTL("compound"))
+	assert.Equal(t, time.Millisecond*50, c.TTL("aave"))
+
+	var calls int
+	failing := countingLendingAPI{calls: &calls, err: blockatlas.ErrSourceConn}
+	_, err := c.GetProviderInfo("compound", failing, context.Background())
+	assert.NotNil(t, err)
+	api := countingLendingAPI{calls: &calls}
+	for i := 0; i < 2; i++ {
+		info, err := c.GetProviderInfo("compound", api, context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 3.0, info.Assets[0].APY)
+	}
+	assert.Equal(t, 2, calls, "the errors are not cached")
+
+	calls = 0
+	_, _ = c.GetProviderInfo("aave", api, context.Background())
+	time.Sleep(time.Millisecond * 60)
+	_, _ = c.GetProviderInfo("aave", api, context.Background())
+	assert.Equal(t, 2, calls, "expired")
+}
+
+// serveRedis answers GET and SET from a map, after AUTH with the password
+func serveRedis(t *testing.T, password string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	values := make(map[string][]byte)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		authorized := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(line[1 : len(line)-2])
+			args := make([]string, n)
+			for i := range args {
+				_, _ = r.ReadString('\n')
+				arg, _ := r.ReadString('\n')
+				args[i] = arg[:len(arg)-2]
+			}
+			switch {
+			case args[0] == "AUTH" && args[1] == password:
+				authorized = true
+				_, _ = conn.Write([]byte("+OK\r\n"))
+			case !authorized:
+				_, _ = conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+			case args[0] == "SET":
+				values[args[1]] = []byte(args[2])
+				_, _ = conn.Write([]byte("+OK\r\n"))
+			case args[0] == "GET":
+				value, ok := values[args[1]]
+				if !ok {
+					_, _ = conn.Write([]byte("$-1\r\n"))
+					continue
+				}
+				_, _ = conn.Write([]byte("$" + strconv.Itoa(len(value)) + "\r\n" + string(value) + "\r\n"))
+			}
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestRedisStore(t *testing.T) {
+	addr := serveRedis(t, "secret")
+	store, err := NewRedisStore("redis://:secret@" + addr)
+	assert.Nil(t, err)
+
+	_, ok, err := store.Get("lending:info:compound")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, store.Set("lending:info:compound", []byte(`{"id":"compound"}`), time.Minute))
+	value, ok, err := store.Get("lending:info:compound")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `{"id":"compound"}`, string(value))
+
+	_, err = NewRedisStore("http://" + addr)
+	assert.NotNil(t, err)
+}
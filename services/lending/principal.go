@@ -0,0 +1,77 @@
+package lending
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// AddAccountPrincipal sets the principal and the earned amount of the contracts not reported by the provider,
+// from the deposit and withdraw events of the addresses. A failing address keeps its contracts as they are.
+func AddAccountPrincipal(api blockatlas.LendingEventsAPI, accounts []types.AccountLendingContracts, ctx context.Context) {
+	for i := range accounts {
+		events, err := api.GetAccountLendingEvents(accounts[i].Address, ctx)
+		if err != nil {
+			logger.Error(err, "Failed to get lending events", logger.Params{"address": accounts[i].Address})
+			continue
+		}
+		AddPrincipal(accounts[i].Contracts, events)
+	}
+}
+
+// AddPrincipal sets the principal of the contracts to the deposits minus the withdrawals of their asset,
+// and the earned amount to the current amount above it. The start amount becomes the principal.
+// The contracts of the assets without events are left as they are.
+func AddPrincipal(contracts []types.LendingContract, events []types.LendingEvent) {
+	for i := range contracts {
+		c := &contracts[i]
+		if c.Principal != "" {
+			continue
+		}
+		principal, ok := principalOf(c.Asset, events)
+		if !ok {
+			continue
+		}
+		current, ok := new(big.Int).SetString(string(c.CurrentAmount), 10)
+		if !ok {
+			continue
+		}
+		earned := new(big.Int).Sub(current, principal)
+		if earned.Sign() < 0 {
+			earned.SetInt64(0)
+		}
+		c.Principal = types.Amount(principal.String())
+		c.StartAmount = c.Principal
+		c.Earned = types.Amount(earned.String())
+	}
+}
+
+// principalOf sums the deposits minus the withdrawals of the asset, withdrawing the interest leaves no principal
+func principalOf(asset string, events []types.LendingEvent) (*big.Int, bool) {
+	principal := new(big.Int)
+	found := false
+	for _, e := range events {
+		if !strings.EqualFold(e.Asset, asset) {
+			continue
+		}
+		value, ok := new(big.Int).SetString(string(e.Value), 10)
+		if !ok {
+			continue
+		}
+		found = true
+		switch e.Type {
+		case types.LendingDeposit:
+			principal.Add(principal, value)
+		case types.LendingWithdraw:
+			principal.Sub(principal, value)
+		}
+	}
+	if principal.Sign() < 0 {
+		principal.SetInt64(0)
+	}
+	return principal, found
+}
@@ -0,0 +1,33 @@
+package lending
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestAddPrincipal(t *testing.T) {
+	events := []types.LendingEvent{
+		{Type: types.LendingDeposit, Asset: "dai", Value: "1000", Date: 1},
+		{Type: types.LendingDeposit, Asset: "DAI", Value: "500", Date: 2},
+		{Type: types.LendingWithdraw, Asset: "DAI", Value: "300", Date: 3},
+		{Type: types.LendingDeposit, Asset: "USDC", Value: "100", Date: 1},
+		{Type: types.LendingWithdraw, Asset: "USDC", Value: "150", Date: 2},
+	}
+	contracts := []types.LendingContract{
+		{Asset: "DAI", StartAmount: "1250", CurrentAmount: "1250"},
+		{Asset: "USDC", StartAmount: "20", CurrentAmount: "20"},
+		{Asset: "WETH", StartAmount: "7", CurrentAmount: "7"},
+		// Reported by the provider
+		{Asset: "DAI", StartAmount: "90", CurrentAmount: "100", Principal: "90", Earned: "10"},
+	}
+	AddPrincipal(contracts, events)
+
+	assert.Equal(t, types.LendingContract{Asset: "DAI", StartAmount: "1200", CurrentAmount: "1250", Principal: "1200", Earned: "50"}, contracts[0])
+	// The interest was withdrawn with the deposit
+	assert.Equal(t, types.LendingContract{Asset: "USDC", StartAmount: "0", CurrentAmount: "20", Principal: "0", Earned: "20"}, contracts[1])
+	// No events, no principal
+	assert.Equal(t, types.LendingContract{Asset: "WETH", StartAmount: "7", CurrentAmount: "7"}, contracts[2])
+	assert.Equal(t, types.LendingContract{Asset: "DAI", StartAmount: "90", CurrentAmount: "100", Principal: "90", Earned: "10"}, contracts[3])
+}
@@ -0,0 +1,172 @@
+package lending
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const redisTimeout = time.Second * 2
+
+// RedisStore keeps the info in Redis, shared by the API instances. It speaks the few commands
+// it needs (AUTH, SELECT, GET, SET) over one connection, opened again after a failure.
+type RedisStore struct {
+	addr     string
+	password string
+	db       int
+
+	sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisStore connects to redis://[:password@]host:port[/db]
+func NewRedisStore(uri string) (*RedisStore, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "redis" || u.Host == "" {
+		return nil, errors.E("invalid redis uri", errors.Params{"uri": uri})
+	}
+	s := &RedisStore{addr: u.Host}
+	if u.User != nil {
+		s.password, _ = u.User.Password()
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		if s.db, err = strconv.Atoi(path); err != nil {
+			return nil, errors.E("invalid redis db", errors.Params{"uri": uri})
+		}
+	}
+	s.Lock()
+	defer s.Unlock()
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *RedisStore) Get(key string) ([]byte, bool, error) {
+	reply, err := s.do("GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	return reply.([]byte), true, nil
+}
+
+func (s *RedisStore) Set(key string, value []byte, ttl time.Duration) error {
+	_, err := s.do("SET", key, string(value), "PX", strconv.FormatInt(int64(ttl/time.Millisecond), 10))
+	return err
+}
+
+func (s *RedisStore) connect() error {
+	conn, err := net.DialTimeout("tcp", s.addr, redisTimeout)
+	if err != nil {
+		return errors.E(err, "failed to connect to redis", errors.Params{"addr": s.addr})
+	}
+	s.conn, s.reader = conn, bufio.NewReader(conn)
+	if s.password != "" {
+		if _, err := s.command("AUTH", s.password); err != nil {
+			s.close()
+			return err
+		}
+	}
+	if s.db != 0 {
+		if _, err := s.command("SELECT", strconv.Itoa(s.db)); err != nil {
+			s.close()
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RedisStore) close() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn, s.reader = nil, nil
+}
+
+// do runs the command, reconnecting first if the last one failed
+func (s *RedisStore) do(args ...string) (interface{}, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return nil, err
+		}
+	}
+	reply, err := s.command(args...)
+	if _, ok := err.(redisError); err != nil && !ok {
+		s.close()
+	}
+	return reply, err
+}
+
+func (s *RedisStore) command(args ...string) (interface{}, error) {
+	if err := s.conn.SetDeadline(time.Now().Add(redisTimeout)); err != nil {
+		return nil, err
+	}
+	if err := writeRedisCommand(s.conn, args); err != nil {
+		return nil, err
+	}
+	return readRedisReply(s.reader)
+}
+
+// redisError is an error reply, the connection stays usable
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func writeRedisCommand(w io.Writer, args []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// readRedisReply reads a status, error, integer or bulk reply, nil for the missing keys
+func readRedisReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.E("empty redis reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		value := make([]byte, n+2)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+		return value[:n], nil
+	default:
+		return nil, errors.E("unsupported redis reply", errors.Params{"reply": line})
+	}
+}
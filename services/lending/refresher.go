@@ -0,0 +1,128 @@
+package lending
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"go.elastic.co/apm"
+)
+
+const DefaultRefreshInterval = 5 * time.Minute
+
+// RunRefresher refreshes the provider cache and the accounts of the liquidation alerts at every interval,
+// and passes the triggered alerts to the senders
+func RunRefresher(database *db.Instance, apis map[string]blockatlas.LendingAPI, interval time.Duration, senders AlertSenders, ctx context.Context) {
+	Providers.setInterval(interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Lending refresher stopped")
+			return
+		case now := <-ticker.C:
+			for _, id := range Providers.Refresh(apis, now, ctx) {
+				cached, _ := Providers.Get(id)
+				evaluateRateAlerts(database, id, cached.Info, now, senders.Rates, ctx)
+			}
+			for id, api := range apis {
+				refreshLiquidations(database, id, api, senders.Liquidations, ctx)
+			}
+		}
+	}
+}
+
+func evaluateRateAlerts(database *db.Instance, id string, provider types.LendingProvider, now time.Time, send func([]RateAlert, context.Context), c context.Context) {
+	tx := apm.DefaultTracer.StartTransaction("evaluateRateAlerts", "app")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(c, tx)
+
+	rates := make(map[string]float64, len(provider.Assets))
+	for _, asset := range provider.Assets {
+		rates[strings.ToUpper(asset.Symbol)] = asset.APY
+	}
+
+	alerts, err := database.GetLendingRateAlerts(id, ctx)
+	if err != nil {
+		logger.Error(err, logger.Params{"lending_provider": id})
+		return
+	}
+	if len(alerts) == 0 {
+		return
+	}
+
+	triggered := make([]RateAlert, 0)
+	for i := range alerts {
+		apy, ok := rates[alerts[i].Asset]
+		if !ok {
+			continue
+		}
+		if previous, ok := EvaluateAlert(&alerts[i], apy, now); ok {
+			triggered = append(triggered, RateAlert{LendingRateAlert: alerts[i], PreviousAPY: previous})
+		}
+	}
+	if err := database.SetLendingRateAlertsState(alerts, ctx); err != nil {
+		logger.Error(err, logger.Params{"lending_provider": id})
+		return
+	}
+	if len(triggered) > 0 {
+		send(triggered, ctx)
+		logger.Info("Lending rate alerts sent", logger.Params{"lending_provider": id, "alerts": len(triggered)})
+	}
+}
+
+func refreshLiquidations(database *db.Instance, id string, api blockatlas.LendingAPI, send func([]LiquidationAlert, context.Context), c context.Context) {
+	tx := apm.DefaultTracer.StartTransaction("refreshLiquidations", "app")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(c, tx)
+
+	alerts, err := database.GetLiquidationAlerts(id, ctx)
+	if err != nil {
+		logger.Error(err, logger.Params{"lending_provider": id})
+		return
+	}
+	if len(alerts) == 0 {
+		return
+	}
+	addresses := make([]string, 0, len(alerts))
+	seen := make(map[string]bool)
+	for _, a := range alerts {
+		if !seen[a.Address] {
+			seen[a.Address] = true
+			addresses = append(addresses, a.Address)
+		}
+	}
+	accounts, err := api.GetAccountLendingContracts(types.AccountRequest{Addresses: addresses}, ctx)
+	if err != nil || accounts == nil {
+		logger.Error(err, "Failed to get lending accounts", logger.Params{"lending_provider": id})
+		return
+	}
+	risks := make(map[string]*types.LiquidationRisk, len(*accounts))
+	for _, account := range *accounts {
+		risks[strings.ToLower(account.Address)] = account.Risk
+	}
+
+	triggered := make([]LiquidationAlert, 0)
+	for i := range alerts {
+		risk, ok := risks[strings.ToLower(alerts[i].Address)]
+		if !ok {
+			continue
+		}
+		if EvaluateLiquidationAlert(&alerts[i], risk) {
+			triggered = append(triggered, LiquidationAlert{LiquidationAlert: alerts[i], Risk: *risk})
+		}
+	}
+	if err := database.SetLiquidationAlertsNotified(alerts, ctx); err != nil {
+		logger.Error(err, logger.Params{"lending_provider": id})
+		return
+	}
+	if len(triggered) > 0 {
+		send(triggered, ctx)
+		logger.Info("Liquidation alerts sent", logger.Params{"lending_provider": id, "alerts": len(triggered)})
+	}
+}
@@ -0,0 +1,56 @@
+package lending
+
+import (
+	"sort"
+
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// Collateral is a supplied asset of an account counted as collateral, Price in the unit of the borrowed value
+// and LiquidationThreshold the share of its value that can be borrowed before the liquidation, e.g. 0.8
+type Collateral struct {
+	Asset                string
+	Amount               float64
+	Price                float64
+	LiquidationThreshold float64
+}
+
+// LiquidationRisk computes the risk of the borrowed value against the collateral, nil without borrows
+func LiquidationRisk(collateral []Collateral, borrowed float64) *types.LiquidationRisk {
+	if borrowed <= 0 {
+		return nil
+	}
+	var value, weighted float64
+	for _, c := range collateral {
+		value += c.Amount * c.Price
+		weighted += c.Amount * c.Price * c.LiquidationThreshold
+	}
+	risk := &types.LiquidationRisk{
+		HealthFactor:    weighted / borrowed,
+		CollateralRatio: value / borrowed,
+	}
+	for _, c := range collateral {
+		weight := c.Amount * c.LiquidationThreshold
+		if weight <= 0 {
+			continue
+		}
+		// The other collateral alone covering the borrows, this asset can't liquidate the account
+		price := (borrowed - (weighted - weight*c.Price)) / weight
+		if price <= 0 {
+			continue
+		}
+		risk.LiquidationPrices = append(risk.LiquidationPrices, types.LiquidationPrice{Asset: c.Asset, Price: price})
+	}
+	sort.Slice(risk.LiquidationPrices, func(i, j int) bool { return risk.LiquidationPrices[i].Asset < risk.LiquidationPrices[j].Asset })
+	return risk
+}
+
+// EvaluateLiquidationAlert returns whether the health factor triggers the alert, once until it recovers
+// above the threshold of the alert. Accounts without borrows have no risk.
+func EvaluateLiquidationAlert(alert *models.LiquidationAlert, risk *types.LiquidationRisk) bool {
+	atRisk := risk != nil && risk.HealthFactor < alert.HealthFactor
+	triggered := atRisk && !alert.Notified
+	alert.Notified = atRisk
+	return triggered
+}
@@ -0,0 +1,39 @@
+package lending
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestLiquidationRisk(t *testing.T) {
+	assert.Nil(t, LiquidationRisk([]Collateral{{Asset: "ETH", Amount: 1, Price: 2000, LiquidationThreshold: 0.8}}, 0))
+
+	collateral := []Collateral{
+		{Asset: "ETH", Amount: 2, Price: 2000, LiquidationThreshold: 0.8},
+		{Asset: "DAI", Amount: 1000, Price: 1, LiquidationThreshold: 0.75},
+	}
+	risk := LiquidationRisk(collateral, 3500)
+	assert.InDelta(t, 3950.0/3500, risk.HealthFactor, 1e-9)
+	assert.InDelta(t, 5000.0/3500, risk.CollateralRatio, 1e-9)
+	assert.Len(t, risk.LiquidationPrices, 2)
+	assert.Equal(t, "DAI", risk.LiquidationPrices[0].Asset)
+	assert.InDelta(t, 0.4, risk.LiquidationPrices[0].Price, 1e-9)
+	assert.Equal(t, "ETH", risk.LiquidationPrices[1].Asset)
+	assert.InDelta(t, 1718.75, risk.LiquidationPrices[1].Price, 1e-9)
+
+	risk = LiquidationRisk(collateral, 3000)
+	assert.Len(t, risk.LiquidationPrices, 1, "the ETH alone covers the borrows")
+}
+
+func TestEvaluateLiquidationAlert(t *testing.T) {
+	alert := models.LiquidationAlert{HealthFactor: 1.2}
+	assert.False(t, EvaluateLiquidationAlert(&alert, nil))
+	assert.False(t, EvaluateLiquidationAlert(&alert, &types.LiquidationRisk{HealthFactor: 1.5}))
+	assert.True(t, EvaluateLiquidationAlert(&alert, &types.LiquidationRisk{HealthFactor: 1.1}))
+	assert.False(t, EvaluateLiquidationAlert(&alert, &types.LiquidationRisk{HealthFactor: 1.05}), "notified once")
+	assert.False(t, EvaluateLiquidationAlert(&alert, &types.LiquidationRisk{HealthFactor: 1.3}))
+	assert.True(t, EvaluateLiquidationAlert(&alert, &types.LiquidationRisk{HealthFactor: 1.1}), "rearmed")
+}
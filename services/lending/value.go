@@ -0,0 +1,73 @@
+package lending
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/market"
+)
+
+// AddAccountValues sets the value of the current amount of the contracts in the currency, with the price of
+// their asset on its chain. The assets the provider doesn't list or the source can't price get no value.
+func AddAccountValues(accounts []types.AccountLendingContracts, assets []types.AssetInfo, prices market.PriceSource, currency string, ctx context.Context) {
+	bySymbol := make(map[string]types.AssetInfo, len(assets))
+	for _, a := range assets {
+		bySymbol[strings.ToUpper(a.Symbol)] = a
+	}
+	symbols := make(map[string][]string)
+	for _, account := range accounts {
+		for _, c := range account.Contracts {
+			asset, ok := bySymbol[strings.ToUpper(c.Asset)]
+			if !ok || contains(symbols[asset.Chain], asset.Symbol) {
+				continue
+			}
+			symbols[asset.Chain] = append(symbols[asset.Chain], asset.Symbol)
+		}
+	}
+	priced := make(map[string]map[string]market.Price, len(symbols))
+	for chain, chainSymbols := range symbols {
+		chainPrices, err := prices.GetPrices(chain, chainSymbols, currency, ctx)
+		if err != nil {
+			logger.Error(err, "Failed to get prices", logger.Params{"chain": chain, "currency": currency})
+			continue
+		}
+		priced[chain] = chainPrices
+	}
+	for i := range accounts {
+		for j := range accounts[i].Contracts {
+			c := &accounts[i].Contracts[j]
+			asset, ok := bySymbol[strings.ToUpper(c.Asset)]
+			if !ok {
+				continue
+			}
+			price, ok := priced[asset.Chain][asset.Symbol]
+			if !ok {
+				continue
+			}
+			amount, ok := new(big.Float).SetString(string(c.CurrentAmount))
+			if !ok {
+				continue
+			}
+			units, _ := amount.Quo(amount, big.NewFloat(math.Pow10(int(asset.Decimals)))).Float64()
+			c.Value = &types.FiatValue{
+				Currency:  strings.ToUpper(currency),
+				Amount:    units * price.Value,
+				Price:     price.Value,
+				UpdatedAt: price.UpdatedAt,
+			}
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
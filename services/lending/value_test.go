@@ -0,0 +1,59 @@
+package lending
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/market"
+)
+
+type mockPrices map[string]map[string]market.Price
+
+func (m mockPrices) GetPrices(chain string, symbols []string, currency string, ctx context.Context) (map[string]market.Price, error) {
+	prices, ok := m[chain]
+	if !ok {
+		return nil, errors.E("unknown chain")
+	}
+	result := make(map[string]market.Price)
+	for _, s := range symbols {
+		if p, ok := prices[s]; ok {
+			result[s] = p
+		}
+	}
+	return result, nil
+}
+
+func TestAddAccountValues(t *testing.T) {
+	assets := []types.AssetInfo{
+		{Symbol: "ETH", Chain: "ETH", Decimals: 18},
+		{Symbol: "USDC", Chain: "ETH", Decimals: 6},
+		{Symbol: "COMP", Chain: "ETH", Decimals: 18},
+		{Symbol: "BNB", Chain: "BNB", Decimals: 8},
+	}
+	prices := mockPrices{"ETH": {
+		"ETH":  {Value: 2000, UpdatedAt: 1600000000},
+		"USDC": {Value: 1.5, UpdatedAt: 1600000060},
+	}}
+	accounts := []types.AccountLendingContracts{
+		{Address: "0x1", Contracts: []types.LendingContract{
+			{Asset: "ETH", CurrentAmount: "2500000000000000000"},
+			{Asset: "usdc", CurrentAmount: "100000000"},
+		}},
+		{Address: "0x2", Contracts: []types.LendingContract{
+			{Asset: "COMP", CurrentAmount: "1"},
+			{Asset: "BNB", CurrentAmount: "1"},
+			{Asset: "DAI", CurrentAmount: "1"},
+		}},
+	}
+	AddAccountValues(accounts, assets, prices, "eur", context.Background())
+
+	assert.Equal(t, &types.FiatValue{Currency: "EUR", Amount: 5000, Price: 2000, UpdatedAt: 1600000000}, accounts[0].Contracts[0].Value)
+	assert.Equal(t, &types.FiatValue{Currency: "EUR", Amount: 150, Price: 1.5, UpdatedAt: 1600000060}, accounts[0].Contracts[1].Value)
+	// Not priced, failing chain and unknown asset
+	for _, c := range accounts[1].Contracts {
+		assert.Nil(t, c.Value, c.Asset)
+	}
+}
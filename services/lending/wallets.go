@@ -0,0 +1,78 @@
+package lending
+
+import (
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/address"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// WalletGapLimit is the number of addresses derived from the extended public key of a sub-wallet
+const WalletGapLimit = 20
+
+// ResolveWallets returns the request with the addresses of the sub-wallets added to the addresses,
+// the addresses of the extended public keys derived
+func ResolveWallets(req types.AccountRequest) (types.AccountRequest, error) {
+	if len(req.Wallets) == 0 {
+		return req, nil
+	}
+	resolved := types.AccountRequest{
+		Addresses: append([]string{}, req.Addresses...),
+		Wallets:   make([]types.AccountWallet, 0, len(req.Wallets)),
+		Assets:    req.Assets,
+	}
+	for _, wallet := range req.Wallets {
+		addresses := append([]string{}, wallet.Addresses...)
+		if wallet.Xpub != "" {
+			derived, err := address.EthereumAddressesFromXpub(wallet.Xpub, WalletGapLimit)
+			if err != nil {
+				return req, errors.E(err, errors.Params{"wallet": wallet.ID})
+			}
+			addresses = append(addresses, derived...)
+		}
+		resolved.Addresses = append(resolved.Addresses, addresses...)
+		resolved.Wallets = append(resolved.Wallets, types.AccountWallet{ID: wallet.ID, Addresses: addresses})
+	}
+	return resolved, nil
+}
+
+// GroupByWallet groups the contracts by the sub-wallets of a resolved request, in the order of the
+// request, the addresses sent alone first. An address of several wallets is listed in each.
+func GroupByWallet(req types.AccountRequest, accounts []types.AccountLendingContracts) []types.WalletLendingContracts {
+	inWallet := make(map[string]bool)
+	for _, wallet := range req.Wallets {
+		for _, a := range wallet.Addresses {
+			inWallet[strings.ToLower(a)] = true
+		}
+	}
+	alone := make([]string, 0, len(req.Addresses))
+	for _, a := range req.Addresses {
+		if !inWallet[strings.ToLower(a)] {
+			alone = append(alone, a)
+		}
+	}
+
+	groups := make([]types.WalletLendingContracts, 0, len(req.Wallets)+1)
+	if len(alone) > 0 {
+		groups = append(groups, walletContracts("", alone, accounts))
+	}
+	for _, wallet := range req.Wallets {
+		groups = append(groups, walletContracts(wallet.ID, wallet.Addresses, accounts))
+	}
+	return groups
+}
+
+func walletContracts(id string, addresses []string, accounts []types.AccountLendingContracts) types.WalletLendingContracts {
+	selected := make(map[string]bool, len(addresses))
+	for _, a := range addresses {
+		selected[strings.ToLower(a)] = true
+	}
+	group := types.WalletLendingContracts{Wallet: id, Accounts: make([]types.AccountLendingContracts, 0)}
+	for _, account := range accounts {
+		if selected[strings.ToLower(account.Address)] {
+			group.Accounts = append(group.Accounts, account)
+		}
+	}
+	return group
+}
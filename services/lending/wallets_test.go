@@ -0,0 +1,42 @@
+package lending
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestResolveWallets(t *testing.T) {
+	req := types.AccountRequest{Addresses: []string{"0x1"}}
+	resolved, err := ResolveWallets(req)
+	assert.Nil(t, err)
+	assert.Equal(t, req, resolved)
+
+	req.Wallets = []types.AccountWallet{
+		{ID: "savings", Addresses: []string{"0x2"}},
+		{ID: "hd", Xpub: "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt"},
+	}
+	resolved, err = ResolveWallets(req)
+	assert.Nil(t, err)
+	assert.Len(t, resolved.Addresses, 2+WalletGapLimit)
+	assert.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", resolved.Wallets[1].Addresses[0])
+	assert.Empty(t, req.Wallets[1].Addresses)
+
+	req.Wallets = []types.AccountWallet{{ID: "hd", Xpub: "xpub-invalid"}}
+	_, err = ResolveWallets(req)
+	assert.NotNil(t, err)
+}
+
+func TestGroupByWallet(t *testing.T) {
+	req := types.AccountRequest{
+		Addresses: []string{"0x1", "0xAb", "0x3"},
+		Wallets:   []types.AccountWallet{{ID: "savings", Addresses: []string{"0xAb", "0x3"}}, {ID: "empty", Addresses: []string{"0x4"}}},
+	}
+	accounts := []types.AccountLendingContracts{{Address: "0x3"}, {Address: "0xab"}, {Address: "0x1"}}
+	assert.Equal(t, []types.WalletLendingContracts{
+		{Wallet: "", Accounts: []types.AccountLendingContracts{{Address: "0x1"}}},
+		{Wallet: "savings", Accounts: []types.AccountLendingContracts{{Address: "0x3"}, {Address: "0xab"}}},
+		{Wallet: "empty", Accounts: []types.AccountLendingContracts{}},
+	}, GroupByWallet(req, accounts))
+}
@@ -0,0 +1,96 @@
+package longpoll
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/mq"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type (
+	// Hub wakes the requests waiting for the new transactions of an address
+	Hub struct {
+		sync.Mutex
+		waiters map[waitKey]map[chan struct{}]struct{}
+	}
+
+	waitKey struct {
+		coin    uint
+		address string
+	}
+)
+
+// DefaultMaxWait caps the wait of the requests when longpoll.max_wait is not set
+const DefaultMaxWait = time.Minute
+
+func NewHub() *Hub {
+	return &Hub{waiters: make(map[waitKey]map[chan struct{}]struct{})}
+}
+
+// Wait returns a channel closed on the next transaction of the address,
+// the cancel func must be called once the request is done waiting
+func (h *Hub) Wait(coin uint, address string) (<-chan struct{}, func()) {
+	key := waitKey{coin: coin, address: strings.ToLower(address)}
+	ch := make(chan struct{})
+
+	h.Lock()
+	if h.waiters[key] == nil {
+		h.waiters[key] = make(map[chan struct{}]struct{})
+	}
+	h.waiters[key][ch] = struct{}{}
+	h.Unlock()
+
+	return ch, func() {
+		h.Lock()
+		defer h.Unlock()
+		if _, ok := h.waiters[key][ch]; !ok {
+			return
+		}
+		delete(h.waiters[key], ch)
+		if len(h.waiters[key]) == 0 {
+			delete(h.waiters, key)
+		}
+	}
+}
+
+// Notify wakes the requests waiting for the addresses of the transactions
+func (h *Hub) Notify(txs types.Txs) {
+	h.Lock()
+	defer h.Unlock()
+	for i := range txs {
+		addresses := append(txs[i].GetAddresses(), txs[i].GetUtxoAddresses()...)
+		for _, address := range addresses {
+			key := waitKey{coin: txs[i].Coin, address: strings.ToLower(address)}
+			for ch := range h.waiters[key] {
+				close(ch)
+			}
+			delete(h.waiters, key)
+		}
+	}
+}
+
+// Run feeds the hub with the transactions batches of the parser until the context is done
+func (h *Hub) Run(messages mq.MessageChannel, ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				logger.Error("long polling feed closed")
+				return
+			}
+			var txs types.Txs
+			if err := json.Unmarshal(message.Body, &txs); err != nil {
+				logger.Error(err, "invalid transactions batch")
+				continue
+			}
+			h.Notify(txs)
+		}
+	}
+}
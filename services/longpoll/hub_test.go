@@ -0,0 +1,55 @@
+package longpoll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func closed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHub_Notify(t *testing.T) {
+	hub := NewHub()
+	to, cancelTo := hub.Wait(coin.ETH, "0xABC")
+	defer cancelTo()
+	other, cancelOther := hub.Wait(coin.ETH, "0xdef")
+	defer cancelOther()
+	otherCoin, cancelOtherCoin := hub.Wait(coin.ETC, "0xabc")
+	defer cancelOtherCoin()
+
+	hub.Notify(types.Txs{{
+		Coin: coin.ETH,
+		From: "0x123",
+		To:   "0xabc",
+		Meta: types.Transfer{Value: "1"},
+	}})
+
+	assert.True(t, closed(to))
+	assert.False(t, closed(other))
+	assert.False(t, closed(otherCoin))
+	assert.Len(t, hub.waiters, 2)
+}
+
+func TestHub_WaitCancel(t *testing.T) {
+	hub := NewHub()
+	ch, cancel := hub.Wait(coin.BTC, "bc1q")
+	cancel()
+	cancel()
+	assert.Empty(t, hub.waiters)
+
+	hub.Notify(types.Txs{{
+		Coin:    coin.BTC,
+		Inputs:  []types.TxOutput{{Address: "bc1q"}},
+		Outputs: []types.TxOutput{{Address: "bc1p"}},
+	}})
+	assert.False(t, closed(ch))
+}
@@ -0,0 +1,231 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+// DefaultChartCacheTTL is the time the charts of the providers are reused
+const DefaultChartCacheTTL = time.Minute * 5
+
+type (
+	// ChartPoint is the price of a period starting at Date (Unix time), with its open, high, low and close
+	// when the provider reports them
+	ChartPoint struct {
+		Date  int64   `json:"date"`
+		Price float64 `json:"price"`
+		Open  float64 `json:"open,omitempty"`
+		High  float64 `json:"high,omitempty"`
+		Low   float64 `json:"low,omitempty"`
+		Close float64 `json:"close,omitempty"`
+	}
+
+	// Chart is the price history of a coin in a currency
+	Chart struct {
+		Coin     uint         `json:"coin"`
+		Currency string       `json:"currency"`
+		Provider string       `json:"provider"`
+		Prices   []ChartPoint `json:"prices"`
+	}
+
+	// ChartProvider returns the price history of a coin between start and end (Unix times), oldest first
+	ChartProvider interface {
+		Name() string
+		GetChart(c coin.Coin, currency string, start, end int64, ctx context.Context) ([]ChartPoint, error)
+	}
+
+	// Charts asks the providers in priority order, the first one with a history answers. With Rates, the
+	// charts in the other currencies are the BaseCurrency ones converted at the current rate.
+	Charts struct {
+		Providers []ChartProvider
+		Rates     *FiatRates
+	}
+
+	binanceKline []interface{}
+
+	coinGeckoChart struct {
+		Prices [][]float64 `json:"prices"`
+	}
+)
+
+// binanceIntervals are the kline intervals of the exchange, the shortest one fitting the period in a request is used
+var binanceIntervals = []struct {
+	name   string
+	length time.Duration
+}{
+	{"1m", time.Minute}, {"5m", time.Minute * 5}, {"15m", time.Minute * 15}, {"1h", time.Hour},
+	{"4h", time.Hour * 4}, {"1d", time.Hour * 24}, {"1w", time.Hour * 24 * 7},
+}
+
+const binanceMaxKlines = 1000
+
+func NewCharts(providers ...ChartProvider) *Charts {
+	return &Charts{Providers: providers}
+}
+
+// GetChart returns the history of the first provider pricing the coin, downsampled to maxItems points
+func (c *Charts) GetChart(coinID uint, currency string, start, end int64, maxItems int, ctx context.Context) (Chart, error) {
+	cc, ok := coin.Coins[coinID]
+	if !ok {
+		return Chart{}, errors.E("unknown coin", errors.Params{"coin": coinID})
+	}
+	currency = strings.ToUpper(currency)
+	rate, source := 1.0, currency
+	if c.Rates != nil && currency != BaseCurrency {
+		rates, err := c.Rates.GetRates(time.Now(), ctx)
+		if err != nil {
+			return Chart{}, err
+		}
+		var ok bool
+		if rate, ok = rates.Rate(currency); !ok {
+			return Chart{}, errors.E("unknown currency", errors.Params{"currency": currency})
+		}
+		source = BaseCurrency
+	}
+	for _, p := range c.Providers {
+		points, err := p.GetChart(cc, source, start, end, ctx)
+		if err != nil {
+			logger.Error(err, "Failed to get chart", logger.Params{"provider": p.Name(), "coin": coinID, "currency": currency})
+			continue
+		}
+		if len(points) == 0 {
+			continue
+		}
+		points = Downsample(points, maxItems)
+		if rate != 1 {
+			points = convertPoints(points, rate)
+		}
+		return Chart{Coin: coinID, Currency: currency, Provider: p.Name(), Prices: points}, nil
+	}
+	return Chart{Coin: coinID, Currency: currency, Prices: make([]ChartPoint, 0)}, nil
+}
+
+// Downsample merges the consecutive points into maxItems periods at most: the date and open of the first point,
+// the close and price of the last one, the highest high and the lowest low
+func Downsample(points []ChartPoint, maxItems int) []ChartPoint {
+	if maxItems <= 0 || len(points) <= maxItems {
+		return points
+	}
+	result := make([]ChartPoint, 0, maxItems)
+	for i := 0; i < maxItems; i++ {
+		from, to := i*len(points)/maxItems, (i+1)*len(points)/maxItems
+		bucket := points[from:to]
+		merged := bucket[0]
+		last := bucket[len(bucket)-1]
+		merged.Price, merged.Close = last.Price, last.Close
+		for _, p := range bucket[1:] {
+			if p.High > merged.High {
+				merged.High = p.High
+			}
+			if p.Low != 0 && (merged.Low == 0 || p.Low < merged.Low) {
+				merged.Low = p.Low
+			}
+		}
+		result = append(result, merged)
+	}
+	return result
+}
+
+func convertPoints(points []ChartPoint, rate float64) []ChartPoint {
+	result := make([]ChartPoint, 0, len(points))
+	for _, p := range points {
+		p.Price, p.Open, p.High, p.Low, p.Close = p.Price*rate, p.Open*rate, p.High*rate, p.Low*rate, p.Close*rate
+		result = append(result, p)
+	}
+	return result
+}
+
+// GetChart returns the prices of the coin, CoinGecko choosing the granularity of the range
+func (g *CoinGecko) GetChart(c coin.Coin, currency string, start, end int64, ctx context.Context) ([]ChartPoint, error) {
+	query := url.Values{
+		"vs_currency": {strings.ToLower(currency)},
+		"from":        {strconv.FormatInt(start, 10)},
+		"to":          {strconv.FormatInt(end, 10)},
+	}
+	var chart coinGeckoChart
+	path := fmt.Sprintf("coins/%s/market_chart/range", c.Handle)
+	if err := g.GetWithCacheAndContext(&chart, path, query, DefaultChartCacheTTL, ctx); err != nil {
+		return nil, err
+	}
+	points := make([]ChartPoint, 0, len(chart.Prices))
+	for _, p := range chart.Prices {
+		if len(p) < 2 {
+			continue
+		}
+		points = append(points, ChartPoint{Date: int64(p[0]) / 1000, Price: p[1]})
+	}
+	return points, nil
+}
+
+// GetChart returns the klines of the pair of the coin, with the shortest interval covering the range in one request
+func (b *Binance) GetChart(c coin.Coin, currency string, start, end int64, ctx context.Context) ([]ChartPoint, error) {
+	quote := currency
+	if q, ok := exchangeQuotes[currency]; ok {
+		quote = q
+	}
+	interval := binanceIntervals[len(binanceIntervals)-1].name
+	span := time.Duration(end-start) * time.Second
+	for _, i := range binanceIntervals {
+		if span/i.length <= binanceMaxKlines {
+			interval = i.name
+			break
+		}
+	}
+	query := url.Values{
+		"symbol":    {strings.ToUpper(c.Symbol) + quote},
+		"interval":  {interval},
+		"startTime": {strconv.FormatInt(start*1000, 10)},
+		"endTime":   {strconv.FormatInt(end*1000, 10)},
+		"limit":     {strconv.Itoa(binanceMaxKlines)},
+	}
+	var klines []binanceKline
+	if err := b.GetWithCacheAndContext(&klines, "api/v3/klines", query, DefaultChartCacheTTL, ctx); err != nil {
+		return nil, err
+	}
+	points := make([]ChartPoint, 0, len(klines))
+	for _, k := range klines {
+		if point, ok := k.point(); ok {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+// point reads a kline: open time in milliseconds, then open, high, low and close prices as strings
+func (k binanceKline) point() (ChartPoint, bool) {
+	if len(k) < 5 {
+		return ChartPoint{}, false
+	}
+	openTime, ok := k[0].(float64)
+	if !ok {
+		return ChartPoint{}, false
+	}
+	var prices [4]float64
+	for i := range prices {
+		s, ok := k[i+1].(string)
+		if !ok {
+			return ChartPoint{}, false
+		}
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return ChartPoint{}, false
+		}
+		prices[i] = value
+	}
+	return ChartPoint{
+		Date:  int64(openTime) / 1000,
+		Price: prices[3],
+		Open:  prices[0],
+		High:  prices[1],
+		Low:   prices[2],
+		Close: prices[3],
+	}, true
+}
@@ -0,0 +1,90 @@
+package market
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+type chartProviderMock struct {
+	name   string
+	points []ChartPoint
+	err    error
+}
+
+func (p chartProviderMock) Name() string { return p.name }
+
+func (p chartProviderMock) GetChart(c coin.Coin, currency string, start, end int64, ctx context.Context) ([]ChartPoint, error) {
+	return p.points, p.err
+}
+
+func TestDownsample(t *testing.T) {
+	points := []ChartPoint{
+		{Date: 1, Price: 10, Open: 9, High: 11, Low: 8, Close: 10},
+		{Date: 2, Price: 12, Open: 10, High: 13, Low: 10, Close: 12},
+		{Date: 3, Price: 11, Open: 12, High: 12, Low: 7, Close: 11},
+		{Date: 4, Price: 14, Open: 11, High: 15, Low: 11, Close: 14},
+		{Date: 5, Price: 13, Open: 14, High: 14, Low: 12, Close: 13},
+	}
+	assert.Equal(t, points, Downsample(points, 5))
+	assert.Equal(t, points, Downsample(points, 0))
+	assert.Equal(t, []ChartPoint{
+		{Date: 1, Price: 12, Open: 9, High: 13, Low: 8, Close: 12},
+		{Date: 3, Price: 13, Open: 12, High: 15, Low: 7, Close: 13},
+	}, Downsample(points, 2))
+
+	prices := []ChartPoint{{Date: 1, Price: 1}, {Date: 2, Price: 2}, {Date: 3, Price: 3}, {Date: 4, Price: 4}}
+	assert.Equal(t, []ChartPoint{{Date: 1, Price: 2}, {Date: 3, Price: 4}}, Downsample(prices, 2))
+}
+
+func TestCharts_GetChart(t *testing.T) {
+	charts := NewCharts(
+		chartProviderMock{name: "failing", err: errors.E("unavailable")},
+		chartProviderMock{name: "empty"},
+		chartProviderMock{name: "binance", points: []ChartPoint{{Date: 1, Price: 1}, {Date: 2, Price: 2}, {Date: 3, Price: 3}}},
+	)
+	chart, err := charts.GetChart(coin.ETH, "usd", 0, 10, 1, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, Chart{Coin: coin.ETH, Currency: "USD", Provider: "binance", Prices: []ChartPoint{{Date: 1, Price: 3}}}, chart)
+
+	_, err = charts.GetChart(123456789, "USD", 0, 10, 1, context.Background())
+	assert.NotNil(t, err)
+
+	chart, err = NewCharts().GetChart(coin.ETH, "USD", 0, 10, 1, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, chart.Prices)
+}
+
+func TestCoinGecko_GetChart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/coins/ethereum/market_chart/range", r.URL.Path)
+		assert.Equal(t, "eur", r.URL.Query().Get("vs_currency"))
+		assert.Equal(t, "1600000000", r.URL.Query().Get("from"))
+		_, _ = w.Write([]byte(`{"prices":[[1600000000000,300.5],[1600003600000,301]]}`))
+	}))
+	defer server.Close()
+
+	points, err := NewCoinGecko(server.URL).GetChart(coin.Ethereum(), "EUR", 1600000000, 1600007200, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []ChartPoint{{Date: 1600000000, Price: 300.5}, {Date: 1600003600, Price: 301}}, points)
+}
+
+func TestBinance_GetChart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/klines", r.URL.Path)
+		assert.Equal(t, "ETHUSDT", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "1h", r.URL.Query().Get("interval"))
+		assert.Equal(t, "1600000000000", r.URL.Query().Get("startTime"))
+		_, _ = w.Write([]byte(`[[1600000000000,"300.00","310.00","295.00","305.00","100.0",1600003599999],[1600003600000,"bad"]]`))
+	}))
+	defer server.Close()
+
+	points, err := NewBinance(server.URL).GetChart(coin.Ethereum(), "USD", 1600000000, 1600000000+86400*30, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []ChartPoint{{Date: 1600000000, Price: 305, Open: 300, High: 310, Low: 295, Close: 305}}, points)
+}
@@ -0,0 +1,65 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+type (
+	// CoinMarketCap prices the coins with the latest quotes of the CoinMarketCap pro API
+	CoinMarketCap struct {
+		blockatlas.Request
+	}
+
+	cmcQuotes struct {
+		Data map[string]struct {
+			Symbol string `json:"symbol"`
+			Quote  map[string]struct {
+				Price            float64   `json:"price"`
+				PercentChange24h float64   `json:"percent_change_24h"`
+				LastUpdated      time.Time `json:"last_updated"`
+			} `json:"quote"`
+		} `json:"data"`
+	}
+)
+
+func NewCoinMarketCap(api, key string) *CoinMarketCap {
+	c := &CoinMarketCap{Request: blockatlas.InitJSONClient(api)}
+	c.Headers["X-CMC_PRO_API_KEY"] = key
+	return c
+}
+
+func (c *CoinMarketCap) Name() string {
+	return "coinmarketcap"
+}
+
+func (c *CoinMarketCap) GetTickers(coins []string, currency string, ctx context.Context) ([]Ticker, error) {
+	if len(coins) == 0 {
+		return nil, nil
+	}
+	var quotes cmcQuotes
+	query := url.Values{"symbol": {strings.Join(coins, ",")}, "convert": {currency}}
+	if err := c.GetWithContext(&quotes, "v1/cryptocurrency/quotes/latest", query, ctx); err != nil {
+		return nil, err
+	}
+	tickers := make([]Ticker, 0, len(quotes.Data))
+	for symbol, data := range quotes.Data {
+		quote, ok := data.Quote[currency]
+		if !ok {
+			continue
+		}
+		tickers = append(tickers, Ticker{
+			Coin:      strings.ToUpper(symbol),
+			Currency:  currency,
+			Price:     quote.Price,
+			Change24h: quote.PercentChange24h,
+			Provider:  c.Name(),
+			UpdatedAt: quote.LastUpdated.Unix(),
+		})
+	}
+	return tickers, nil
+}
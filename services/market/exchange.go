@@ -0,0 +1,73 @@
+package market
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+type (
+	// Binance prices the coins with the 24 hour tickers of the Binance exchange, the pairs quoted in USDT
+	// standing for USD
+	Binance struct {
+		blockatlas.Request
+	}
+
+	binanceTicker struct {
+		Symbol             string `json:"symbol"`
+		LastPrice          string `json:"lastPrice"`
+		PriceChangePercent string `json:"priceChangePercent"`
+		CloseTime          int64  `json:"closeTime"`
+	}
+)
+
+// exchangeQuotes are the assets quoting the pairs of the currencies without their own pairs
+var exchangeQuotes = map[string]string{"USD": "USDT"}
+
+func NewBinance(api string) *Binance {
+	return &Binance{Request: blockatlas.InitJSONClient(api)}
+}
+
+func (b *Binance) Name() string {
+	return "binance"
+}
+
+func (b *Binance) GetTickers(coins []string, currency string, ctx context.Context) ([]Ticker, error) {
+	if len(coins) == 0 {
+		return nil, nil
+	}
+	quote := currency
+	if q, ok := exchangeQuotes[currency]; ok {
+		quote = q
+	}
+	pairs := make(map[string]string, len(coins))
+	for _, coin := range coins {
+		pairs[coin+quote] = coin
+	}
+	var all []binanceTicker
+	if err := b.GetWithContext(&all, "api/v3/ticker/24hr", nil, ctx); err != nil {
+		return nil, err
+	}
+	tickers := make([]Ticker, 0, len(coins))
+	for _, t := range all {
+		coin, ok := pairs[t.Symbol]
+		if !ok {
+			continue
+		}
+		price, err := strconv.ParseFloat(t.LastPrice, 64)
+		if err != nil {
+			continue
+		}
+		change, _ := strconv.ParseFloat(t.PriceChangePercent, 64)
+		tickers = append(tickers, Ticker{
+			Coin:      coin,
+			Currency:  currency,
+			Price:     price,
+			Change24h: change,
+			Provider:  b.Name(),
+			UpdatedAt: t.CloseTime / 1000,
+		})
+	}
+	return tickers, nil
+}
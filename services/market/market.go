@@ -0,0 +1,166 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/services/tokens"
+)
+
+// DefaultCacheTTL is the time the prices are reused before asking the source again
+const DefaultCacheTTL = time.Minute
+
+type (
+	// Price of an asset in a currency, UpdatedAt is the Unix time the source priced it
+	Price struct {
+		Value     float64
+		UpdatedAt int64
+	}
+
+	// PriceSource prices the assets of a chain (the symbol of its coin, as in types.AssetInfo), by symbol.
+	// The assets it can't price are left out.
+	PriceSource interface {
+		GetPrices(chain string, symbols []string, currency string, ctx context.Context) (map[string]Price, error)
+	}
+
+	// CoinGecko prices the native coin of the chain and its top tokens (see tokens.Tokens) with the
+	// CoinGecko simple price API, the handle of the coin being its CoinGecko ID and platform
+	CoinGecko struct {
+		blockatlas.Request
+		CacheTTL time.Duration
+	}
+
+	// coinGeckoPrices are the prices by coin ID or contract, then by currency with the last_updated_at time
+	coinGeckoPrices map[string]map[string]float64
+)
+
+func NewCoinGecko(api string) *CoinGecko {
+	return &CoinGecko{Request: blockatlas.InitClient(api), CacheTTL: DefaultCacheTTL}
+}
+
+func (g *CoinGecko) GetPrices(chain string, symbols []string, currency string, ctx context.Context) (map[string]Price, error) {
+	c, ok := coinOf(chain)
+	if !ok {
+		return nil, errors.E("unknown chain", errors.Params{"chain": chain})
+	}
+	currency = strings.ToLower(currency)
+	prices := make(map[string]Price)
+	contracts := make(map[string]string)
+	for _, symbol := range symbols {
+		if strings.EqualFold(symbol, c.Symbol) {
+			result, err := g.get("simple/price", url.Values{"ids": {c.Handle}}, currency, ctx)
+			if err != nil {
+				return nil, err
+			}
+			if price, ok := result.price(c.Handle, currency); ok {
+				prices[symbol] = price
+			}
+			continue
+		}
+		for _, t := range tokens.Tokens.List(c.ID) {
+			if strings.EqualFold(t.Symbol, symbol) {
+				contracts[strings.ToLower(t.Contract)] = symbol
+				break
+			}
+		}
+	}
+	if len(contracts) == 0 {
+		return prices, nil
+	}
+	addresses := make([]string, 0, len(contracts))
+	for contract := range contracts {
+		addresses = append(addresses, contract)
+	}
+	result, err := g.get("simple/token_price/"+c.Handle, url.Values{"contract_addresses": {strings.Join(addresses, ",")}}, currency, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for contract, symbol := range contracts {
+		if price, ok := result.price(contract, currency); ok {
+			prices[symbol] = price
+		}
+	}
+	return prices, nil
+}
+
+func (g *CoinGecko) get(path string, query url.Values, currency string, ctx context.Context) (coinGeckoPrices, error) {
+	query.Set("vs_currencies", currency)
+	query.Set("include_last_updated_at", "true")
+	var result coinGeckoPrices
+	err := g.GetWithCacheAndContext(&result, path, query, g.CacheTTL, ctx)
+	return result, err
+}
+
+func (p coinGeckoPrices) price(id, currency string) (Price, bool) {
+	prices, ok := p[strings.ToLower(id)]
+	if !ok {
+		return Price{}, false
+	}
+	value, ok := prices[currency]
+	if !ok {
+		return Price{}, false
+	}
+	return Price{Value: value, UpdatedAt: int64(prices["last_updated_at"])}, true
+}
+
+// coinOf returns the coin of the chain symbol, the lowest ID when coins share it
+func coinOf(chain string) (coin.Coin, bool) {
+	var (
+		found coin.Coin
+		ok    bool
+	)
+	for _, c := range coin.Coins {
+		if strings.EqualFold(c.Symbol, chain) && (!ok || c.ID < found.ID) {
+			found, ok = c, true
+		}
+	}
+	return found, ok
+}
+
+func (g *CoinGecko) Name() string {
+	return "coingecko"
+}
+
+// GetTickers prices the native coins by symbol, with their change over 24 hours
+func (g *CoinGecko) GetTickers(coins []string, currency string, ctx context.Context) ([]Ticker, error) {
+	ids := make(map[string]string, len(coins))
+	for _, symbol := range coins {
+		if c, ok := coinOf(symbol); ok {
+			ids[c.Handle] = symbol
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	handles := make([]string, 0, len(ids))
+	for handle := range ids {
+		handles = append(handles, handle)
+	}
+	query := url.Values{"ids": {strings.Join(handles, ",")}, "include_24hr_change": {"true"}}
+	vs := strings.ToLower(currency)
+	result, err := g.get("simple/price", query, vs, ctx)
+	if err != nil {
+		return nil, err
+	}
+	tickers := make([]Ticker, 0, len(ids))
+	for handle, symbol := range ids {
+		price, ok := result.price(handle, vs)
+		if !ok {
+			continue
+		}
+		tickers = append(tickers, Ticker{
+			Coin:      symbol,
+			Currency:  currency,
+			Price:     price.Value,
+			Change24h: result[handle][vs+"_24h_change"],
+			Provider:  g.Name(),
+			UpdatedAt: price.UpdatedAt,
+		})
+	}
+	return tickers, nil
+}
@@ -0,0 +1,44 @@
+package market
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	usdcContract = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	ethPriceSrc  = `{"ethereum":{"eur":2000.5,"last_updated_at":1600000000}}`
+	usdcPriceSrc = `{"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48":{"eur":0.85,"last_updated_at":1600000060}}`
+)
+
+func TestCoinGecko_GetPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "eur", r.URL.Query().Get("vs_currencies"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_last_updated_at"))
+		switch r.URL.Path {
+		case "/simple/price":
+			assert.Equal(t, "ethereum", r.URL.Query().Get("ids"))
+			_, _ = w.Write([]byte(ethPriceSrc))
+		case "/simple/token_price/ethereum":
+			assert.Equal(t, usdcContract, r.URL.Query().Get("contract_addresses"))
+			_, _ = w.Write([]byte(usdcPriceSrc))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	prices, err := NewCoinGecko(server.URL).GetPrices("ETH", []string{"ETH", "USDC", "UNKNOWN"}, "EUR", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]Price{
+		"ETH":  {Value: 2000.5, UpdatedAt: 1600000000},
+		"USDC": {Value: 0.85, UpdatedAt: 1600000060},
+	}, prices)
+
+	_, err = NewCoinGecko(server.URL).GetPrices("NOPE", []string{"NOPE"}, "eur", context.Background())
+	assert.NotNil(t, err)
+}
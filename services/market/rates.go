@@ -0,0 +1,206 @@
+package market
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	// BaseCurrency is the currency of the rates kept, and the one the prices are converted from
+	BaseCurrency = "USD"
+	// DefaultRatesTTL is the time the rates are reused before asking the provider again
+	DefaultRatesTTL = time.Hour
+)
+
+type (
+	// Rates are the units of each currency worth one Base, UpdatedAt is the Unix time the provider published them
+	Rates struct {
+		Base      string             `json:"base"`
+		Rates     map[string]float64 `json:"rates"`
+		Provider  string             `json:"provider"`
+		UpdatedAt int64              `json:"updated_at"`
+	}
+
+	// RatesProvider returns the latest fiat rates, in the base currency of the provider
+	RatesProvider interface {
+		Name() string
+		GetRates(ctx context.Context) (Rates, error)
+	}
+
+	// FiatRates keeps the rates of the provider in BaseCurrency for TTL. The previous rates are served
+	// while the provider fails.
+	FiatRates struct {
+		Provider RatesProvider
+		TTL      time.Duration
+
+		mu      sync.Mutex
+		rates   Rates
+		fetched time.Time
+	}
+
+	// ConvertedPrices prices the assets in BaseCurrency with the source, converted to the other currencies
+	// with the rates, so they agree with the other price endpoints
+	ConvertedPrices struct {
+		Source PriceSource
+		Rates  *FiatRates
+	}
+
+	// Fixer reads the rates of the fixer.io API, in EUR on its free plan
+	Fixer struct {
+		blockatlas.Request
+		Key string
+	}
+
+	// OpenExchangeRates reads the rates of the openexchangerates.org API, in USD
+	OpenExchangeRates struct {
+		blockatlas.Request
+		Key string
+	}
+
+	fixerRates struct {
+		Success   bool               `json:"success"`
+		Timestamp int64              `json:"timestamp"`
+		Base      string             `json:"base"`
+		Rates     map[string]float64 `json:"rates"`
+		Error     struct {
+			Code int    `json:"code"`
+			Type string `json:"type"`
+		} `json:"error"`
+	}
+
+	openExchangeRates struct {
+		Timestamp int64              `json:"timestamp"`
+		Base      string             `json:"base"`
+		Rates     map[string]float64 `json:"rates"`
+	}
+)
+
+// NewRatesProvider returns the rates provider of the config: fixer or openexchangerates, with its API key
+func NewRatesProvider(config ProviderConfig) (RatesProvider, error) {
+	if config.API == "" {
+		return nil, errors.E("missing provider api", errors.Params{"provider": config.Name})
+	}
+	switch config.Name {
+	case "fixer":
+		return &Fixer{Request: blockatlas.InitJSONClient(config.API), Key: config.Key}, nil
+	case "openexchangerates":
+		return &OpenExchangeRates{Request: blockatlas.InitJSONClient(config.API), Key: config.Key}, nil
+	default:
+		return nil, errors.E("unknown rates provider", errors.Params{"provider": config.Name})
+	}
+}
+
+func NewFiatRates(provider RatesProvider, ttl time.Duration) *FiatRates {
+	if ttl <= 0 {
+		ttl = DefaultRatesTTL
+	}
+	return &FiatRates{Provider: provider, TTL: ttl}
+}
+
+// GetRates returns the rates in BaseCurrency, asking the provider when they are older than TTL
+func (f *FiatRates) GetRates(now time.Time, ctx context.Context) (Rates, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.fetched.IsZero() && now.Sub(f.fetched) < f.TTL {
+		return f.rates, nil
+	}
+	rates, err := f.Provider.GetRates(ctx)
+	if err == nil {
+		var ok bool
+		if rates, ok = rates.Rebase(BaseCurrency); !ok {
+			err = errors.E("rates without the base currency", errors.Params{"provider": f.Provider.Name(), "base": BaseCurrency})
+		}
+	}
+	if err != nil {
+		if f.fetched.IsZero() {
+			return Rates{}, err
+		}
+		logger.Error(err, "Failed to refresh the fiat rates, serving the previous ones", logger.Params{"provider": f.Provider.Name()})
+		return f.rates, nil
+	}
+	f.rates, f.fetched = rates, now
+	return rates, nil
+}
+
+// Rate returns the units of the currency worth one Base
+func (r Rates) Rate(currency string) (float64, bool) {
+	currency = strings.ToUpper(currency)
+	if currency == r.Base {
+		return 1, true
+	}
+	rate, ok := r.Rates[currency]
+	return rate, ok && rate > 0
+}
+
+// Rebase returns the rates in the base currency, false when it is not rated
+func (r Rates) Rebase(base string) (Rates, bool) {
+	base = strings.ToUpper(base)
+	rate, ok := r.Rate(base)
+	if !ok {
+		return Rates{}, false
+	}
+	rebased := Rates{Base: base, Rates: make(map[string]float64, len(r.Rates)+1), Provider: r.Provider, UpdatedAt: r.UpdatedAt}
+	rebased.Rates[r.Base] = 1 / rate
+	for currency, value := range r.Rates {
+		rebased.Rates[currency] = value / rate
+	}
+	rebased.Rates[base] = 1
+	return rebased, true
+}
+
+func (p ConvertedPrices) GetPrices(chain string, symbols []string, currency string, ctx context.Context) (map[string]Price, error) {
+	if strings.EqualFold(currency, BaseCurrency) {
+		return p.Source.GetPrices(chain, symbols, currency, ctx)
+	}
+	rates, err := p.Rates.GetRates(time.Now(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := rates.Rate(currency)
+	if !ok {
+		return nil, errors.E("unknown currency", errors.Params{"currency": currency})
+	}
+	prices, err := p.Source.GetPrices(chain, symbols, BaseCurrency, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for symbol, price := range prices {
+		price.Value *= rate
+		prices[symbol] = price
+	}
+	return prices, nil
+}
+
+func (f *Fixer) Name() string {
+	return "fixer"
+}
+
+func (f *Fixer) GetRates(ctx context.Context) (Rates, error) {
+	var result fixerRates
+	if err := f.GetWithContext(&result, "latest", url.Values{"access_key": {f.Key}}, ctx); err != nil {
+		return Rates{}, err
+	}
+	if !result.Success {
+		return Rates{}, errors.E("fixer request failed", errors.Params{"code": result.Error.Code, "type": result.Error.Type})
+	}
+	return Rates{Base: strings.ToUpper(result.Base), Rates: result.Rates, Provider: f.Name(), UpdatedAt: result.Timestamp}, nil
+}
+
+func (o *OpenExchangeRates) Name() string {
+	return "openexchangerates"
+}
+
+func (o *OpenExchangeRates) GetRates(ctx context.Context) (Rates, error) {
+	var result openExchangeRates
+	if err := o.GetWithContext(&result, "latest.json", url.Values{"app_id": {o.Key}}, ctx); err != nil {
+		return Rates{}, err
+	}
+	return Rates{Base: strings.ToUpper(result.Base), Rates: result.Rates, Provider: o.Name(), UpdatedAt: result.Timestamp}, nil
+}
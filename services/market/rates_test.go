@@ -0,0 +1,147 @@
+package market
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+type ratesProviderMock struct {
+	rates Rates
+	err   error
+	calls int
+}
+
+func (p *ratesProviderMock) Name() string { return "mock" }
+
+func (p *ratesProviderMock) GetRates(ctx context.Context) (Rates, error) {
+	p.calls++
+	return p.rates, p.err
+}
+
+type priceSourceMock struct {
+	currency string
+}
+
+func (m *priceSourceMock) GetPrices(chain string, symbols []string, currency string, ctx context.Context) (map[string]Price, error) {
+	m.currency = currency
+	return map[string]Price{"ETH": {Value: 300, UpdatedAt: 1600000000}}, nil
+}
+
+func TestRates_Rebase(t *testing.T) {
+	rates := Rates{Base: "EUR", Rates: map[string]float64{"USD": 1.25, "GBP": 0.9}, Provider: "fixer"}
+
+	usd, ok := rates.Rebase("usd")
+	assert.True(t, ok)
+	assert.Equal(t, "USD", usd.Base)
+	assert.Equal(t, 1.0, usd.Rates["USD"])
+	assert.InDelta(t, 0.8, usd.Rates["EUR"], 1e-9)
+	assert.InDelta(t, 0.72, usd.Rates["GBP"], 1e-9)
+
+	rate, ok := usd.Rate("eur")
+	assert.True(t, ok)
+	assert.InDelta(t, 0.8, rate, 1e-9)
+	_, ok = rates.Rebase("JPY")
+	assert.False(t, ok)
+}
+
+func TestFiatRates_GetRates(t *testing.T) {
+	provider := &ratesProviderMock{rates: Rates{Base: "USD", Rates: map[string]float64{"EUR": 0.8}}}
+	rates := NewFiatRates(provider, time.Hour)
+	now := time.Unix(1600000000, 0)
+
+	result, err := rates.GetRates(now, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0.8, result.Rates["EUR"])
+	_, _ = rates.GetRates(now.Add(time.Minute), context.Background())
+	assert.Equal(t, 1, provider.calls)
+
+	provider.err = errors.E("unavailable")
+	result, err = rates.GetRates(now.Add(time.Hour*2), context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0.8, result.Rates["EUR"])
+	assert.Equal(t, 2, provider.calls)
+
+	_, err = NewFiatRates(provider, 0).GetRates(now, context.Background())
+	assert.NotNil(t, err)
+}
+
+func TestConvertedPrices(t *testing.T) {
+	source := &priceSourceMock{}
+	prices := ConvertedPrices{Source: source, Rates: NewFiatRates(&ratesProviderMock{rates: Rates{Base: "USD", Rates: map[string]float64{"EUR": 0.8}}}, 0)}
+
+	result, err := prices.GetPrices("ETH", []string{"ETH"}, "eur", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, BaseCurrency, source.currency)
+	assert.Equal(t, Price{Value: 240, UpdatedAt: 1600000000}, result["ETH"])
+
+	_, err = prices.GetPrices("ETH", []string{"ETH"}, "JPY", context.Background())
+	assert.NotNil(t, err)
+}
+
+func TestTickers_GetTickers_Rates(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	tickers := NewTickers(NewMemoryStore(), time.Minute*10, providerMock{name: "first", tickers: []Ticker{
+		{Coin: "BTC", Currency: "USD", Price: 10000, Provider: "first", UpdatedAt: now.Unix()},
+	}})
+	tickers.Rates = NewFiatRates(&ratesProviderMock{rates: Rates{Base: "USD", Rates: map[string]float64{"EUR": 0.8}}}, 0)
+	tickers.Refresh([]string{"BTC"}, []string{"USD"}, context.Background())
+
+	result, err := tickers.GetTickers([]string{"BTC"}, "EUR", now, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []Ticker{{Coin: "BTC", Currency: "EUR", Price: 8000, Provider: "first", UpdatedAt: now.Unix()}}, result)
+
+	result, err = tickers.GetTickers([]string{"BTC"}, "JPY", now, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
+
+func TestNewRatesProvider(t *testing.T) {
+	for _, name := range []string{"fixer", "openexchangerates"} {
+		p, err := NewRatesProvider(ProviderConfig{Name: name, API: "https://example.com"})
+		assert.Nil(t, err)
+		assert.Equal(t, name, p.Name())
+	}
+	_, err := NewRatesProvider(ProviderConfig{Name: "coingecko", API: "https://example.com"})
+	assert.NotNil(t, err)
+}
+
+func TestFixer_GetRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/latest", r.URL.Path)
+		if r.URL.Query().Get("access_key") != "secret" {
+			_, _ = w.Write([]byte(`{"success":false,"error":{"code":101,"type":"invalid_access_key"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"timestamp":1600000000,"base":"EUR","rates":{"USD":1.25,"EUR":1}}`))
+	}))
+	defer server.Close()
+
+	provider, _ := NewRatesProvider(ProviderConfig{Name: "fixer", API: server.URL, Key: "secret"})
+	rates, err := provider.GetRates(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, Rates{Base: "EUR", Rates: map[string]float64{"USD": 1.25, "EUR": 1}, Provider: "fixer", UpdatedAt: 1600000000}, rates)
+
+	provider, _ = NewRatesProvider(ProviderConfig{Name: "fixer", API: server.URL, Key: "invalid"})
+	_, err = provider.GetRates(context.Background())
+	assert.NotNil(t, err)
+}
+
+func TestOpenExchangeRates_GetRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/latest.json", r.URL.Path)
+		assert.Equal(t, "secret", r.URL.Query().Get("app_id"))
+		_, _ = w.Write([]byte(`{"timestamp":1600000000,"base":"USD","rates":{"EUR":0.8}}`))
+	}))
+	defer server.Close()
+
+	provider, _ := NewRatesProvider(ProviderConfig{Name: "openexchangerates", API: server.URL, Key: "secret"})
+	rates, err := provider.GetRates(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, Rates{Base: "USD", Rates: map[string]float64{"EUR": 0.8}, Provider: "openexchangerates", UpdatedAt: 1600000000}, rates)
+}
@@ -0,0 +1,150 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+// DefaultTickerTTL keeps the tickers in the key-value stores after their last refresh
+const DefaultTickerTTL = time.Hour * 24
+
+type (
+	// KeyValueStore keeps the values until they expire, like the Redis store of the lending info cache
+	KeyValueStore interface {
+		Get(key string) ([]byte, bool, error)
+		Set(key string, value []byte, ttl time.Duration) error
+	}
+
+	// MemoryStore keeps the tickers in the memory of the instance
+	MemoryStore struct {
+		sync.RWMutex
+		tickers map[string]Ticker
+	}
+
+	// KVStore keeps each ticker as JSON under market:ticker:<provider>:<coin>:<currency>, for TTL after
+	// its last refresh, shared by the API instances with Redis
+	KVStore struct {
+		Store KeyValueStore
+		TTL   time.Duration
+	}
+
+	// PostgresStore keeps the tickers in the tickers table
+	PostgresStore struct {
+		Database *db.Instance
+	}
+)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tickers: make(map[string]Ticker)}
+}
+
+func (s *MemoryStore) SaveTickers(tickers []Ticker, ctx context.Context) error {
+	s.Lock()
+	defer s.Unlock()
+	for _, t := range tickers {
+		s.tickers[tickerKey(t.Provider, t.Coin, t.Currency)] = t
+	}
+	return nil
+}
+
+func (s *MemoryStore) GetTickers(providers, coins []string, currency string, ctx context.Context) ([]Ticker, error) {
+	s.RLock()
+	defer s.RUnlock()
+	result := make([]Ticker, 0)
+	for _, provider := range providers {
+		for _, coin := range coins {
+			if t, ok := s.tickers[tickerKey(provider, coin, currency)]; ok {
+				result = append(result, t)
+			}
+		}
+	}
+	return result, nil
+}
+
+func NewKVStore(store KeyValueStore, ttl time.Duration) *KVStore {
+	if ttl <= 0 {
+		ttl = DefaultTickerTTL
+	}
+	return &KVStore{Store: store, TTL: ttl}
+}
+
+func (s *KVStore) SaveTickers(tickers []Ticker, ctx context.Context) error {
+	for _, t := range tickers {
+		value, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		if err := s.Store.Set(tickerKey(t.Provider, t.Coin, t.Currency), value, s.TTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *KVStore) GetTickers(providers, coins []string, currency string, ctx context.Context) ([]Ticker, error) {
+	result := make([]Ticker, 0)
+	for _, provider := range providers {
+		for _, coin := range coins {
+			key := tickerKey(provider, coin, currency)
+			value, ok, err := s.Store.Get(key)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			var t Ticker
+			if err := json.Unmarshal(value, &t); err != nil {
+				logger.Error(err, "Invalid stored ticker", logger.Params{"key": key})
+				continue
+			}
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (s PostgresStore) SaveTickers(tickers []Ticker, ctx context.Context) error {
+	rows := make([]models.Ticker, 0, len(tickers))
+	for _, t := range tickers {
+		rows = append(rows, models.Ticker{
+			Provider:  t.Provider,
+			Coin:      t.Coin,
+			Currency:  t.Currency,
+			Price:     t.Price,
+			Change24h: t.Change24h,
+			PricedAt:  t.UpdatedAt,
+		})
+	}
+	return s.Database.SaveTickers(rows, ctx)
+}
+
+func (s PostgresStore) GetTickers(providers, coins []string, currency string, ctx context.Context) ([]Ticker, error) {
+	rows, err := s.Database.GetTickers(providers, coins, currency, ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Ticker, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, Ticker{
+			Coin:      r.Coin,
+			Currency:  r.Currency,
+			Price:     r.Price,
+			Change24h: r.Change24h,
+			Provider:  r.Provider,
+			UpdatedAt: r.PricedAt,
+		})
+	}
+	return result, nil
+}
+
+func tickerKey(provider, coin, currency string) string {
+	return strings.Join([]string{"market:ticker", provider, coin, currency}, ":")
+}
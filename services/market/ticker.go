@@ -0,0 +1,196 @@
+package market
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	DefaultTickersInterval = time.Minute
+	// DefaultTickerMaxAge is the age after which the ticker of a provider gives way to the next provider
+	DefaultTickerMaxAge = time.Minute * 10
+)
+
+type (
+	// Ticker is the price of a coin (by symbol) in a currency reported by a provider, UpdatedAt is the Unix
+	// time the provider priced it
+	Ticker struct {
+		Coin      string  `json:"coin"`
+		Currency  string  `json:"currency"`
+		Price     float64 `json:"price"`
+		Change24h float64 `json:"change_24h"`
+		Provider  string  `json:"provider"`
+		UpdatedAt int64   `json:"updated_at"`
+	}
+
+	// TickerProvider prices the coins by symbol, the coins it can't price are left out
+	TickerProvider interface {
+		Name() string
+		GetTickers(coins []string, currency string, ctx context.Context) ([]Ticker, error)
+	}
+
+	// ProviderConfig is a ticker provider of the market.providers config: coingecko, coinmarketcap
+	// (with its API key) or binance
+	ProviderConfig struct {
+		Name string `mapstructure:"name"`
+		API  string `mapstructure:"api"`
+		Key  string `mapstructure:"key"`
+	}
+
+	// TickerStore keeps the last ticker of each provider, coin and currency
+	TickerStore interface {
+		SaveTickers(tickers []Ticker, ctx context.Context) error
+		// GetTickers returns the tickers of the providers for the coins in the currency
+		GetTickers(providers, coins []string, currency string, ctx context.Context) ([]Ticker, error)
+	}
+
+	// Tickers pulls the tickers of the providers into the store, and serves the ticker of the first
+	// provider in priority order updated within MaxAge, the most recent one when all are older.
+	// With Rates, the tickers in the other currencies are the BaseCurrency ones converted with them.
+	Tickers struct {
+		Providers []TickerProvider
+		Store     TickerStore
+		MaxAge    time.Duration
+		Rates     *FiatRates
+	}
+)
+
+// NewTickerProvider returns the provider of the config
+func NewTickerProvider(config ProviderConfig) (TickerProvider, error) {
+	if config.API == "" {
+		return nil, errors.E("missing provider api", errors.Params{"provider": config.Name})
+	}
+	switch config.Name {
+	case "coingecko":
+		return NewCoinGecko(config.API), nil
+	case "coinmarketcap":
+		return NewCoinMarketCap(config.API, config.Key), nil
+	case "binance":
+		return NewBinance(config.API), nil
+	default:
+		return nil, errors.E("unknown ticker provider", errors.Params{"provider": config.Name})
+	}
+}
+
+func NewTickers(store TickerStore, maxAge time.Duration, providers ...TickerProvider) *Tickers {
+	if maxAge <= 0 {
+		maxAge = DefaultTickerMaxAge
+	}
+	return &Tickers{Providers: providers, Store: store, MaxAge: maxAge}
+}
+
+// Run refreshes the tickers of the coins in the currencies at every interval until the end of ctx
+func (t *Tickers) Run(coins, currencies []string, interval time.Duration, ctx context.Context) {
+	if interval <= 0 {
+		interval = DefaultTickersInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		t.Refresh(coins, currencies, ctx)
+		select {
+		case <-ctx.Done():
+			logger.Info("Market tickers stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh saves the tickers of every provider, the failing providers are logged and skipped
+func (t *Tickers) Refresh(coins, currencies []string, ctx context.Context) {
+	for _, p := range t.Providers {
+		for _, currency := range currencies {
+			tickers, err := p.GetTickers(normalizeSymbols(coins), strings.ToUpper(currency), ctx)
+			if err != nil {
+				logger.Error(err, "Failed to get tickers", logger.Params{"provider": p.Name(), "currency": currency})
+				continue
+			}
+			if err := t.Store.SaveTickers(tickers, ctx); err != nil {
+				logger.Error(err, "Failed to save tickers", logger.Params{"provider": p.Name(), "currency": currency})
+			}
+		}
+	}
+}
+
+// GetTickers returns the resolved ticker of each coin priced by a provider, in the order of the coins
+func (t *Tickers) GetTickers(coins []string, currency string, now time.Time, ctx context.Context) ([]Ticker, error) {
+	coins, currency = normalizeSymbols(coins), strings.ToUpper(currency)
+	rate, source := 1.0, currency
+	if t.Rates != nil && currency != BaseCurrency {
+		rates, err := t.Rates.GetRates(now, ctx)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if rate, ok = rates.Rate(currency); !ok {
+			return make([]Ticker, 0), nil
+		}
+		source = BaseCurrency
+	}
+	priority := make(map[string]int, len(t.Providers))
+	names := make([]string, 0, len(t.Providers))
+	for i, p := range t.Providers {
+		priority[p.Name()] = i
+		names = append(names, p.Name())
+	}
+	stored, err := t.Store.GetTickers(names, coins, source, ctx)
+	if err != nil {
+		return nil, err
+	}
+	byCoin := make(map[string][]Ticker)
+	for _, ticker := range stored {
+		byCoin[ticker.Coin] = append(byCoin[ticker.Coin], ticker)
+	}
+	result := make([]Ticker, 0, len(coins))
+	for _, coin := range coins {
+		if ticker, ok := t.resolve(byCoin[coin], priority, now); ok {
+			ticker.Price *= rate
+			ticker.Currency = currency
+			result = append(result, ticker)
+		}
+	}
+	return result, nil
+}
+
+func (t *Tickers) resolve(tickers []Ticker, priority map[string]int, now time.Time) (Ticker, bool) {
+	var fresh, latest *Ticker
+	for i := range tickers {
+		ticker := &tickers[i]
+		if latest == nil || ticker.UpdatedAt > latest.UpdatedAt {
+			latest = ticker
+		}
+		if now.Sub(time.Unix(ticker.UpdatedAt, 0)) > t.MaxAge {
+			continue
+		}
+		if fresh == nil || priority[ticker.Provider] < priority[fresh.Provider] {
+			fresh = ticker
+		}
+	}
+	if fresh != nil {
+		return *fresh, true
+	}
+	if latest != nil {
+		return *latest, true
+	}
+	return Ticker{}, false
+}
+
+// normalizeSymbols returns the symbols upper-cased without the empty and repeated ones
+func normalizeSymbols(symbols []string) []string {
+	seen := make(map[string]bool, len(symbols))
+	result := make([]string, 0, len(symbols))
+	for _, s := range symbols {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		result = append(result, s)
+	}
+	return result
+}
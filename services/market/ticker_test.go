@@ -0,0 +1,147 @@
+package market
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+type providerMock struct {
+	name    string
+	tickers []Ticker
+	err     error
+}
+
+func (p providerMock) Name() string { return p.name }
+
+func (p providerMock) GetTickers(coins []string, currency string, ctx context.Context) ([]Ticker, error) {
+	return p.tickers, p.err
+}
+
+type kvMock map[string][]byte
+
+func (m kvMock) Get(key string) ([]byte, bool, error) {
+	value, ok := m[key]
+	return value, ok, nil
+}
+
+func (m kvMock) Set(key string, value []byte, ttl time.Duration) error {
+	m[key] = value
+	return nil
+}
+
+func TestTickers_GetTickers(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	fresh, stale := now.Add(-time.Minute).Unix(), now.Add(-time.Hour).Unix()
+	tickers := NewTickers(NewMemoryStore(), time.Minute*10,
+		providerMock{name: "first", tickers: []Ticker{
+			{Coin: "BTC", Currency: "USD", Price: 10000, Provider: "first", UpdatedAt: fresh},
+			{Coin: "ETH", Currency: "USD", Price: 300, Provider: "first", UpdatedAt: stale},
+			{Coin: "TRX", Currency: "USD", Price: 0.02, Provider: "first", UpdatedAt: stale},
+		}},
+		providerMock{name: "second", tickers: []Ticker{
+			{Coin: "BTC", Currency: "USD", Price: 10010, Provider: "second", UpdatedAt: fresh},
+			{Coin: "ETH", Currency: "USD", Price: 301, Provider: "second", UpdatedAt: fresh},
+			{Coin: "TRX", Currency: "USD", Price: 0.03, Provider: "second", UpdatedAt: stale - 60},
+		}},
+		providerMock{name: "failing", err: errors.E("unavailable")},
+	)
+	tickers.Refresh([]string{"BTC", "ETH", "TRX"}, []string{"usd"}, context.Background())
+
+	result, err := tickers.GetTickers([]string{"eth", "BTC", "TRX", "BNB", "btc"}, "usd", now, context.Background())
+	assert.Nil(t, err)
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, Ticker{Coin: "ETH", Currency: "USD", Price: 301, Provider: "second", UpdatedAt: fresh}, result[0])
+		assert.Equal(t, "first", result[1].Provider)
+		assert.Equal(t, "BTC", result[1].Coin)
+		assert.Equal(t, "first", result[2].Provider)
+		assert.Equal(t, "TRX", result[2].Coin)
+	}
+
+	result, err = tickers.GetTickers([]string{"BTC"}, "EUR", now, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, result)
+}
+
+func TestKVStore(t *testing.T) {
+	kv := kvMock{}
+	store := NewKVStore(kv, 0)
+	assert.Equal(t, DefaultTickerTTL, store.TTL)
+
+	btc := Ticker{Coin: "BTC", Currency: "USD", Price: 10000, Provider: "binance", UpdatedAt: 1600000000}
+	assert.Nil(t, store.SaveTickers([]Ticker{btc}, context.Background()))
+	assert.Contains(t, kv, "market:ticker:binance:BTC:USD")
+	kv["market:ticker:coingecko:BTC:USD"] = []byte("invalid")
+
+	result, err := store.GetTickers([]string{"coingecko", "binance"}, []string{"BTC", "ETH"}, "USD", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []Ticker{btc}, result)
+}
+
+func TestNewTickerProvider(t *testing.T) {
+	for _, name := range []string{"coingecko", "coinmarketcap", "binance"} {
+		p, err := NewTickerProvider(ProviderConfig{Name: name, API: "https://example.com"})
+		assert.Nil(t, err)
+		assert.Equal(t, name, p.Name())
+	}
+	_, err := NewTickerProvider(ProviderConfig{Name: "unknown", API: "https://example.com"})
+	assert.NotNil(t, err)
+	_, err = NewTickerProvider(ProviderConfig{Name: "binance"})
+	assert.NotNil(t, err)
+}
+
+func TestCoinGecko_GetTickers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/simple/price", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("include_24hr_change"))
+		assert.Equal(t, "usd", r.URL.Query().Get("vs_currencies"))
+		_, _ = w.Write([]byte(`{"bitcoin":{"usd":10000.5,"usd_24h_change":-1.5,"last_updated_at":1600000000}}`))
+	}))
+	defer server.Close()
+
+	tickers, err := NewCoinGecko(server.URL).GetTickers([]string{"BTC", "UNKNOWN"}, "USD", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []Ticker{{Coin: "BTC", Currency: "USD", Price: 10000.5, Change24h: -1.5, Provider: "coingecko", UpdatedAt: 1600000000}}, tickers)
+}
+
+func TestCoinMarketCap_GetTickers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/cryptocurrency/quotes/latest", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-CMC_PRO_API_KEY"))
+		assert.Equal(t, "BTC,ETH", r.URL.Query().Get("symbol"))
+		assert.Equal(t, "EUR", r.URL.Query().Get("convert"))
+		_, _ = w.Write([]byte(`{"data":{
+			"BTC":{"symbol":"BTC","quote":{"EUR":{"price":9000,"percent_change_24h":2.5,"last_updated":"2020-09-13T12:26:40.000Z"}}},
+			"ETH":{"symbol":"ETH","quote":{"USD":{"price":300}}}}}`))
+	}))
+	defer server.Close()
+
+	tickers, err := NewCoinMarketCap(server.URL, "secret").GetTickers([]string{"BTC", "ETH"}, "EUR", context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []Ticker{{Coin: "BTC", Currency: "EUR", Price: 9000, Change24h: 2.5, Provider: "coinmarketcap", UpdatedAt: 1600000000}}, tickers)
+}
+
+func TestBinance_GetTickers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/ticker/24hr", r.URL.Path)
+		_, _ = w.Write([]byte(`[
+			{"symbol":"BTCUSDT","lastPrice":"10000.10","priceChangePercent":"-0.50","closeTime":1600000000123},
+			{"symbol":"ETHBTC","lastPrice":"0.03","priceChangePercent":"1.00","closeTime":1600000000123},
+			{"symbol":"ETHUSDT","lastPrice":"300.00","priceChangePercent":"1.20","closeTime":1600000000456}]`))
+	}))
+	defer server.Close()
+
+	tickers, err := NewBinance(server.URL).GetTickers([]string{"BTC", "ETH", "BNB"}, "USD", context.Background())
+	assert.Nil(t, err)
+	sort.Slice(tickers, func(i, j int) bool { return tickers[i].Coin < tickers[j].Coin })
+	assert.Equal(t, []Ticker{
+		{Coin: "BTC", Currency: "USD", Price: 10000.10, Change24h: -0.5, Provider: "binance", UpdatedAt: 1600000000},
+		{Coin: "ETH", Currency: "USD", Price: 300, Change24h: 1.2, Provider: "binance", UpdatedAt: 1600000000},
+	}, tickers)
+}
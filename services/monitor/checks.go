@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+)
+
+const (
+	EndpointTransactions = "transactions"
+	EndpointTokens       = "tokens"
+	EndpointValidators   = "validators"
+)
+
+// Check is a critical endpoint of a coin, Upstream calls the provider behind it directly
+type Check struct {
+	Coin     string
+	Endpoint string
+	Path     string
+	Upstream func() error
+}
+
+// Checks returns the transactions and tokens endpoints of the sample address of every coin
+// and the validators endpoint of the staking coins
+func Checks(platforms map[string]blockatlas.Platform) []Check {
+	handles := make([]string, 0, len(platforms))
+	for handle := range platforms {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+
+	checks := make([]Check, 0)
+	for _, handle := range handles {
+		p := platforms[handle]
+		address := p.Coin().SampleAddr
+		if address != "" {
+			switch api := p.(type) {
+			case blockatlas.TxUtxoAPI:
+				checks = append(checks, Check{
+					Coin:     handle,
+					Endpoint: EndpointTransactions,
+					Path:     fmt.Sprintf("v1/%s/address/%s", handle, address),
+					Upstream: func() error { _, err := api.GetTxsByAddress(address); return err },
+				})
+			case blockatlas.TxAPI:
+				checks = append(checks, Check{
+					Coin:     handle,
+					Endpoint: EndpointTransactions,
+					Path:     fmt.Sprintf("v2/%s/transactions/%s", handle, address),
+					Upstream: func() error { _, err := api.GetTxsByAddress(address); return err },
+				})
+			}
+			if api, ok := p.(blockatlas.TokensAPI); ok {
+				checks = append(checks, Check{
+					Coin:     handle,
+					Endpoint: EndpointTokens,
+					Path:     fmt.Sprintf("v2/%s/tokens/%s", handle, address),
+					Upstream: func() error { _, err := api.GetTokenListByAddress(address); return err },
+				})
+			}
+		}
+		if api, ok := p.(blockatlas.StakeAPI); ok {
+			checks = append(checks, Check{
+				Coin:     handle,
+				Endpoint: EndpointValidators,
+				Path:     fmt.Sprintf("v2/%s/staking/validators", handle),
+				Upstream: func() error { _, err := api.GetValidators(); return err },
+			})
+		}
+	}
+	return checks
+}
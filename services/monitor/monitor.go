@@ -0,0 +1,161 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	resultOK       = "ok"
+	resultFailed   = "failed"
+	resultUpstream = "upstream_failed"
+)
+
+var (
+	checkCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atlas",
+		Name:      "synthetic_check_total",
+		Help:      "Synthetic checks of the own endpoints, by result: ok, failed (the upstream answers) or upstream_failed.",
+	}, []string{"coin", "endpoint", "result"})
+
+	checkDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "atlas",
+		Name:      "synthetic_check_duration_seconds",
+		Help:      "Latency of the synthetic checks of the own endpoints.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"coin", "endpoint"})
+
+	coinFlagged = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas",
+		Name:      "synthetic_coin_flagged",
+		Help:      "1 while an endpoint of the coin fails and its upstream answers.",
+	}, []string{"coin"})
+)
+
+func init() {
+	prometheus.MustRegister(checkCount, checkDuration, coinFlagged)
+}
+
+type (
+	// Monitor calls the critical endpoints of the API like a client does
+	Monitor struct {
+		client blockatlas.Request
+		checks []Check
+
+		sync.RWMutex
+		results map[string]Result
+	}
+
+	// Result is the last run of a check, Flagged when the endpoint fails while its upstream answers
+	Result struct {
+		Coin       string `json:"coin"`
+		Endpoint   string `json:"endpoint"`
+		OK         bool   `json:"ok"`
+		UpstreamOK bool   `json:"upstream_ok"`
+		Flagged    bool   `json:"flagged"`
+		LatencyMs  int64  `json:"latency_ms"`
+		Error      string `json:"error,omitempty"`
+		CheckedAt  int64  `json:"checked_at"`
+	}
+)
+
+// New checks the API at baseURL, the requests taking longer than timeout fail
+func New(baseURL string, checks []Check, timeout time.Duration) *Monitor {
+	client := blockatlas.InitJSONClient(baseURL)
+	client.HttpClient = &http.Client{Timeout: timeout}
+	client.ErrorHandler = func(res *http.Response, uri string) error {
+		if res.StatusCode != http.StatusOK {
+			return errors.E("unexpected status code", errors.Params{"status": res.StatusCode, "url": uri})
+		}
+		return nil
+	}
+	return &Monitor{client: client, checks: checks, results: make(map[string]Result)}
+}
+
+// Run runs every check once, the upstream of a failing endpoint is called to tell
+// the failures of the API from the failures of the providers
+func (m *Monitor) Run(now time.Time) {
+	flagged := make(map[string]bool)
+	for _, c := range m.checks {
+		result := m.run(c, now)
+		flagged[c.Coin] = flagged[c.Coin] || result.Flagged
+		m.Lock()
+		m.results[c.Coin+"/"+c.Endpoint] = result
+		m.Unlock()
+	}
+	for coin, f := range flagged {
+		if f {
+			coinFlagged.WithLabelValues(coin).Set(1)
+		} else {
+			coinFlagged.WithLabelValues(coin).Set(0)
+		}
+	}
+}
+
+func (m *Monitor) run(c Check, now time.Time) Result {
+	result := Result{Coin: c.Coin, Endpoint: c.Endpoint, CheckedAt: now.Unix()}
+	start := time.Now()
+	var body interface{}
+	err := m.client.Get(&body, c.Path, nil)
+	elapsed := time.Since(start)
+	result.LatencyMs = elapsed.Milliseconds()
+	checkDuration.WithLabelValues(c.Coin, c.Endpoint).Observe(elapsed.Seconds())
+	if err == nil {
+		result.OK, result.UpstreamOK = true, true
+		checkCount.WithLabelValues(c.Coin, c.Endpoint, resultOK).Inc()
+		return result
+	}
+	result.Error = err.Error()
+	upstreamErr := c.Upstream()
+	result.UpstreamOK = upstreamErr == nil || upstreamErr == blockatlas.ErrNotFound
+	if !result.UpstreamOK {
+		checkCount.WithLabelValues(c.Coin, c.Endpoint, resultUpstream).Inc()
+		logger.Warn("Synthetic check failed with its upstream", logger.Params{"coin": c.Coin, "endpoint": c.Endpoint, "err": upstreamErr})
+		return result
+	}
+	result.Flagged = true
+	checkCount.WithLabelValues(c.Coin, c.Endpoint, resultFailed).Inc()
+	logger.Error(err, "Synthetic check failed while the upstream answers", logger.Params{"coin": c.Coin, "endpoint": c.Endpoint})
+	return result
+}
+
+// Results returns the last result of every check by coin and endpoint
+func (m *Monitor) Results() []Result {
+	m.RLock()
+	defer m.RUnlock()
+	results := make([]Result, 0, len(m.results))
+	for _, r := range m.results {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Coin != results[j].Coin {
+			return results[i].Coin < results[j].Coin
+		}
+		return results[i].Endpoint < results[j].Endpoint
+	})
+	return results
+}
+
+// RunMonitor runs the checks every interval until ctx is done
+func RunMonitor(m *Monitor, interval time.Duration, ctx context.Context) {
+	logger.Info("Synthetic monitor started", logger.Params{"checks": len(m.checks), "interval": interval})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Synthetic monitor stopped")
+			return
+		case now := <-ticker.C:
+			m.Run(now)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package monitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type txPlatformMock struct {
+	err error
+}
+
+func (p txPlatformMock) Coin() coin.Coin { return coin.Coins[coin.ETH] }
+
+func (p txPlatformMock) GetTxsByAddress(address string) (types.TxPage, error) {
+	return nil, p.err
+}
+
+func TestChecks(t *testing.T) {
+	checks := Checks(map[string]blockatlas.Platform{"ethereum": txPlatformMock{}})
+	assert.Len(t, checks, 1)
+	assert.Equal(t, EndpointTransactions, checks[0].Endpoint)
+	assert.Equal(t, "v2/ethereum/transactions/"+coin.Coins[coin.ETH].SampleAddr, checks[0].Path)
+}
+
+func TestMonitor_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(`{"docs": []}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	ok := func() error { return nil }
+	checks := []Check{
+		{Coin: "ethereum", Endpoint: EndpointTransactions, Path: "ok", Upstream: ok},
+		{Coin: "ethereum", Endpoint: EndpointTokens, Path: "broken", Upstream: ok},
+		{Coin: "tezos", Endpoint: EndpointValidators, Path: "broken", Upstream: func() error { return blockatlas.ErrSourceConn }},
+		{Coin: "tron", Endpoint: EndpointTransactions, Path: "broken", Upstream: func() error { return blockatlas.ErrNotFound }},
+	}
+	m := New(server.URL, checks, time.Second)
+	m.Run(time.Unix(1600000000, 0))
+
+	results := m.Results()
+	assert.Len(t, results, 4)
+
+	assert.Equal(t, EndpointTokens, results[0].Endpoint)
+	assert.False(t, results[0].OK)
+	assert.True(t, results[0].Flagged)
+	assert.NotEmpty(t, results[0].Error)
+
+	assert.Equal(t, EndpointTransactions, results[1].Endpoint)
+	assert.True(t, results[1].OK)
+	assert.False(t, results[1].Flagged)
+	assert.Equal(t, int64(1600000000), results[1].CheckedAt)
+
+	// The upstream fails too, the API isn't flagged
+	assert.Equal(t, "tezos", results[2].Coin)
+	assert.False(t, results[2].UpstreamOK)
+	assert.False(t, results[2].Flagged)
+
+	// An address without data upstream is a healthy upstream
+	assert.Equal(t, "tron", results[3].Coin)
+	assert.True(t, results[3].Flagged)
+}
@@ -0,0 +1,165 @@
+package heads
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"golang.org/x/net/websocket"
+)
+
+const DefaultRetryInterval = time.Second * 30
+
+type (
+	// Subscription is the WebSocket connection to an EVM node, notifying the new heads
+	// and the logs of the addresses as they are seen by the node
+	Subscription struct {
+		URL       string   `mapstructure:"url"`
+		Addresses []string `mapstructure:"addresses"`
+		// RetryInterval is the time between the connection attempts after a drop
+		RetryInterval time.Duration `mapstructure:"retry_interval"`
+	}
+
+	request struct {
+		JsonRpc string        `json:"jsonrpc"`
+		ID      int           `json:"id"`
+		Method  string        `json:"method"`
+		Params  []interface{} `json:"params"`
+	}
+
+	message struct {
+		ID     int             `json:"id"`
+		Error  *rpcError       `json:"error"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	notification struct {
+		Result struct {
+			// Number is set for the heads, BlockNumber for the logs
+			Number      string `json:"number"`
+			BlockNumber string `json:"blockNumber"`
+			Removed     bool   `json:"removed"`
+		} `json:"result"`
+	}
+
+	logsFilter struct {
+		Address []string `json:"address"`
+	}
+)
+
+// Run keeps the subscription connected until the end of ctx and sends the block number of every
+// notification to the returned channel. Notifications the reader didn't take yet are coalesced into
+// the latest one, the channel is closed at the end of ctx.
+func (s Subscription) Run(ctx context.Context) <-chan int64 {
+	heads := make(chan int64, 1)
+	retry := s.RetryInterval
+	if retry <= 0 {
+		retry = DefaultRetryInterval
+	}
+	go func() {
+		defer close(heads)
+		for {
+			err := s.listen(heads, ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error(err, "WebSocket subscription dropped, polling until it reconnects", logger.Params{"url": s.URL, "retry": retry.String()})
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retry):
+			}
+		}
+	}()
+	return heads
+}
+
+// listen subscribes to the heads and the logs of the addresses, then reads the notifications until the
+// connection fails
+func (s Subscription) listen(heads chan int64, ctx context.Context) error {
+	conn, err := websocket.Dial(s.URL, "", "http://localhost/")
+	if err != nil {
+		return errors.E(err, "failed to connect")
+	}
+	defer conn.Close()
+	closed := make(chan struct{})
+	defer close(closed)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-closed:
+		}
+	}()
+
+	subscriptions := [][]interface{}{{"newHeads"}}
+	if len(s.Addresses) > 0 {
+		subscriptions = append(subscriptions, []interface{}{"logs", logsFilter{Address: s.Addresses}})
+	}
+	for i, params := range subscriptions {
+		req := request{JsonRpc: "2.0", ID: i + 1, Method: "eth_subscribe", Params: params}
+		if err := websocket.JSON.Send(conn, req); err != nil {
+			return errors.E(err, "failed to subscribe")
+		}
+	}
+	logger.Info("WebSocket subscription connected", logger.Params{"url": s.URL, "addresses": len(s.Addresses)})
+
+	for {
+		var msg message
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			return errors.E(err, "failed to read")
+		}
+		if msg.Error != nil {
+			return errors.E(msg.Error.Message, errors.Params{"code": msg.Error.Code, "id": msg.ID})
+		}
+		if msg.Method != "eth_subscription" {
+			continue
+		}
+		num, ok := blockNumber(msg.Params)
+		if !ok {
+			continue
+		}
+		notify(heads, num)
+	}
+}
+
+// blockNumber returns the block of a head or a log notification, false for the removed logs
+func blockNumber(params json.RawMessage) (int64, bool) {
+	var n notification
+	if err := json.Unmarshal(params, &n); err != nil || n.Result.Removed {
+		return 0, false
+	}
+	hex := n.Result.Number
+	if hex == "" {
+		hex = n.Result.BlockNumber
+	}
+	num, err := strconv.ParseInt(hex, 0, 64)
+	if err != nil {
+		return 0, false
+	}
+	return num, true
+}
+
+// notify replaces the pending block number with num, the reader only needs the latest one
+func notify(heads chan int64, num int64) {
+	for {
+		select {
+		case heads <- num:
+			return
+		default:
+		}
+		select {
+		case <-heads:
+		default:
+		}
+	}
+}
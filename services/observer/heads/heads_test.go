@@ -0,0 +1,109 @@
+package heads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	headSrc       = `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x9ce59a13059e417087c02d3236a0b1cc","result":{"number":"0x%x","hash":"0x8a2a"}}}`
+	logSrc        = `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x4a8a4c0517381924f9838102c5a4dcb7","result":{"address":"0x3d9819210a31b4961b30ef54be2aed79b9c9cd3b","blockNumber":"0x%x","removed":%t}}}`
+	subscribedSrc = `{"jsonrpc":"2.0","id":%d,"result":"0xcd0c3e8af590364c09d0fa6a1210faf5"}`
+)
+
+// node answers the subscriptions, sends the notifications of the connection then drops it
+func node(subscriptions int, connections ...[]string) (*httptest.Server, *[][]interface{}) {
+	var (
+		count  int32
+		params [][]interface{}
+	)
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		for i := 0; i < subscriptions; i++ {
+			var req request
+			if err := websocket.JSON.Receive(conn, &req); err != nil {
+				return
+			}
+			params = append(params, req.Params)
+			_ = websocket.Message.Send(conn, fmt.Sprintf(subscribedSrc, req.ID))
+		}
+		n := int(atomic.AddInt32(&count, 1)) - 1
+		if n >= len(connections) {
+			// the last connection stays open
+			var discard string
+			_ = websocket.Message.Receive(conn, &discard)
+			return
+		}
+		for _, msg := range connections[n] {
+			_ = websocket.Message.Send(conn, msg)
+		}
+		time.Sleep(time.Millisecond * 50)
+	}))
+	return server, &params
+}
+
+func receive(t *testing.T, heads <-chan int64) int64 {
+	select {
+	case num := <-heads:
+		return num
+	case <-time.After(time.Second * 5):
+		t.Fatal("no head received")
+		return 0
+	}
+}
+
+func TestSubscription_Run(t *testing.T) {
+	server, params := node(2,
+		[]string{fmt.Sprintf(headSrc, 100), fmt.Sprintf(logSrc, 99, true)},
+		[]string{fmt.Sprintf(logSrc, 101, false)},
+	)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscription := Subscription{
+		URL:           strings.Replace(server.URL, "http", "ws", 1),
+		Addresses:     []string{"0x3d9819210a31b4961b30ef54be2aed79b9c9cd3b"},
+		RetryInterval: time.Millisecond * 10,
+	}
+	heads := subscription.Run(ctx)
+	assert.Equal(t, int64(100), receive(t, heads))
+	assert.Equal(t, int64(101), receive(t, heads), "reconnected after the drop, the removed log is skipped")
+	assert.Equal(t, []interface{}{"newHeads"}, (*params)[0])
+	assert.Equal(t, "logs", (*params)[1][0])
+	assert.Equal(t, map[string]interface{}{"address": []interface{}{"0x3d9819210a31b4961b30ef54be2aed79b9c9cd3b"}}, (*params)[1][1])
+
+	cancel()
+	select {
+	case _, ok := <-heads:
+		assert.False(t, ok)
+	case <-time.After(time.Second * 5):
+		t.Fatal("the heads were not closed")
+	}
+}
+
+func TestNotify(t *testing.T) {
+	heads := make(chan int64, 1)
+	notify(heads, 1)
+	notify(heads, 2)
+	assert.Equal(t, int64(2), <-heads)
+}
+
+func TestBlockNumber(t *testing.T) {
+	var msg message
+	assert.Nil(t, json.Unmarshal([]byte(fmt.Sprintf(headSrc, 255)), &msg))
+	num, ok := blockNumber(msg.Params)
+	assert.True(t, ok)
+	assert.Equal(t, int64(255), num)
+
+	assert.Nil(t, json.Unmarshal([]byte(fmt.Sprintf(logSrc, 1, true)), &msg))
+	_, ok = blockNumber(msg.Params)
+	assert.False(t, ok)
+}
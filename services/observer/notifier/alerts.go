@@ -0,0 +1,115 @@
+package notifier
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/lending"
+	"github.com/trustwallet/blockatlas/services/observer/notifier/push"
+	"go.elastic.co/apm"
+)
+
+const (
+	// lendingRateEvent is the template of the lending rate alerts
+	lendingRateEvent = "lending_rate"
+	// liquidationRiskEvent is the template of the liquidation risk alerts
+	liquidationRiskEvent = "liquidation_risk"
+)
+
+// SendRateAlerts delivers the triggered lending rate alerts through the channel drivers
+func SendRateAlerts(database *db.Instance, alerts []lending.RateAlert, ctx context.Context) {
+	span, ctx := apm.StartSpan(ctx, "SendRateAlerts", "app")
+	defer span.End()
+
+	invalid := make(map[types.ChannelProvider][]string)
+	for _, alert := range alerts {
+		provider := types.ChannelProvider(alert.Channel)
+		driver, ok := Drivers[provider]
+		if !ok {
+			continue
+		}
+		message := buildRateAlertMessage(alert)
+		rejected, err := driver.Send([]string{alert.Token}, message, ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": provider, "lending_provider": alert.Provider, "asset": alert.Asset})
+		}
+		invalid[provider] = append(invalid[provider], rejected...)
+	}
+	deleteInvalidTokens(database, invalid, ctx)
+}
+
+func buildRateAlertMessage(alert lending.RateAlert) push.Message {
+	data := TemplateData{
+		Symbol:      alert.Asset,
+		Protocol:    strings.Title(alert.Provider),
+		APY:         formatAPY(alert.APY),
+		PreviousAPY: formatAPY(alert.PreviousAPY),
+	}
+	title, body, err := MessageTemplates.Render(alert.Locale, lendingRateEvent, data)
+	if err != nil {
+		logger.Error(err, logger.Params{"locale": alert.Locale, "event": lendingRateEvent})
+	}
+	return push.Message{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"type":         lendingRateEvent,
+			"provider":     alert.Provider,
+			"asset":        alert.Asset,
+			"apy":          data.APY,
+			"previous_apy": data.PreviousAPY,
+		},
+	}
+}
+
+// SendLiquidationAlerts delivers the triggered liquidation risk alerts through the channel drivers
+func SendLiquidationAlerts(database *db.Instance, alerts []lending.LiquidationAlert, ctx context.Context) {
+	span, ctx := apm.StartSpan(ctx, "SendLiquidationAlerts", "app")
+	defer span.End()
+
+	invalid := make(map[types.ChannelProvider][]string)
+	for _, alert := range alerts {
+		provider := types.ChannelProvider(alert.Channel)
+		driver, ok := Drivers[provider]
+		if !ok {
+			continue
+		}
+		message := buildLiquidationAlertMessage(alert)
+		rejected, err := driver.Send([]string{alert.Token}, message, ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": provider, "lending_provider": alert.Provider, "address": alert.Address})
+		}
+		invalid[provider] = append(invalid[provider], rejected...)
+	}
+	deleteInvalidTokens(database, invalid, ctx)
+}
+
+func buildLiquidationAlertMessage(alert lending.LiquidationAlert) push.Message {
+	data := TemplateData{
+		Address:      alert.Address,
+		Protocol:     strings.Title(alert.Provider),
+		HealthFactor: strconv.FormatFloat(alert.Risk.HealthFactor, 'f', 2, 64),
+	}
+	title, body, err := MessageTemplates.Render(alert.Locale, liquidationRiskEvent, data)
+	if err != nil {
+		logger.Error(err, logger.Params{"locale": alert.Locale, "event": liquidationRiskEvent})
+	}
+	return push.Message{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"type":          liquidationRiskEvent,
+			"provider":      alert.Provider,
+			"address":       alert.Address,
+			"health_factor": data.HealthFactor,
+		},
+	}
+}
+
+func formatAPY(apy float64) string {
+	return strconv.FormatFloat(apy, 'f', 2, 64)
+}
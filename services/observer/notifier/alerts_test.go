@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/lending"
+)
+
+func Test_buildRateAlertMessage(t *testing.T) {
+	alert := lending.RateAlert{
+		LendingRateAlert: models.LendingRateAlert{Provider: "compound", Asset: "DAI", APY: 7.126, Change: 20},
+		PreviousAPY:      5,
+	}
+	message := buildRateAlertMessage(alert)
+	assert.Equal(t, "DAI APY at Compound is now 7.13%", message.Title)
+	assert.Equal(t, "It was 5.00%", message.Body)
+	assert.Equal(t, "lending_rate", message.Data["type"])
+	assert.Equal(t, "7.13", message.Data["apy"])
+
+	alert.Locale = "es"
+	message = buildRateAlertMessage(alert)
+	assert.Equal(t, "El APY de DAI en Compound es ahora 7.13%", message.Title)
+}
+
+func Test_buildLiquidationAlertMessage(t *testing.T) {
+	alert := lending.LiquidationAlert{
+		LiquidationAlert: models.LiquidationAlert{Provider: "aave", Address: "0x08777CB1e80F45642752662B04886Df2d271E049", HealthFactor: 1.2},
+		Risk:             types.LiquidationRisk{HealthFactor: 1.084},
+	}
+	message := buildLiquidationAlertMessage(alert)
+	assert.Equal(t, "Your Aave position is at risk of liquidation", message.Title)
+	assert.Equal(t, "Health factor 1.08, 0x08777CB1e80F45642752662B04886Df2d271E049", message.Body)
+	assert.Equal(t, "liquidation_risk", message.Data["type"])
+}
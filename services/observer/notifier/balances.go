@@ -0,0 +1,127 @@
+package notifier
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// Balances fetch the balances of the notified addresses by coin, set when observer.balances is enabled.
+// The notifications of the other coins carry no balance change.
+var Balances = make(map[uint]blockatlas.BalanceAPI)
+
+// BalanceChange is the balance of the address in the asset moved by the transaction, before and
+// after it, in the smallest unit. Delta is signed, the fee is included when paid in the same asset.
+type BalanceChange struct {
+	// TokenID of the token, empty for the native coin
+	TokenID  string `json:"token_id,omitempty"`
+	Symbol   string `json:"symbol"`
+	Decimals uint   `json:"decimals"`
+	Pre      string `json:"pre"`
+	Post     string `json:"post"`
+	Delta    string `json:"delta"`
+}
+
+// addBalanceChanges fetches the current balance of every asset the notifications move once, and
+// walks the notifications from the latest back, the balance before a transaction being the one after
+// the previous. The balance is fetched when the block is notified, transactions of later blocks
+// already seen by the node shift it.
+func addBalanceChanges(api blockatlas.BalanceAPI, address string, notifications []TransactionNotification) {
+	order := make([]int, len(notifications))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := notifications[order[i]].Result, notifications[order[j]].Result
+		if a.Block != b.Block {
+			return a.Block > b.Block
+		}
+		return a.Sequence > b.Sequence
+	})
+
+	balances := make(map[string]*big.Int)
+	for _, i := range order {
+		change, delta, ok := balanceDelta(notifications[i].Result)
+		if !ok {
+			continue
+		}
+		post, ok := balances[change.TokenID]
+		if !ok {
+			balance, err := api.GetBalance(address, change.TokenID)
+			if err != nil {
+				logger.Error(err, "failed to fetch balance", logger.Params{"coin": api.Coin().Handle, "address": address, "token": change.TokenID})
+				balances[change.TokenID] = nil
+				continue
+			}
+			if post, ok = new(big.Int).SetString(balance, 10); !ok {
+				logger.Error("invalid balance", logger.Params{"coin": api.Coin().Handle, "address": address, "balance": balance})
+			}
+			balances[change.TokenID] = post
+		}
+		if post == nil {
+			continue
+		}
+		pre := new(big.Int).Sub(post, delta)
+		change.Post, change.Pre, change.Delta = post.String(), pre.String(), delta.String()
+		notifications[i].Balance = &change
+		balances[change.TokenID] = pre
+	}
+}
+
+// balanceDelta returns the asset the transaction moves for the address of its direction, and the
+// signed amount. The failed transactions only cost the fee.
+func balanceDelta(tx types.Tx) (BalanceChange, *big.Int, bool) {
+	var (
+		change BalanceChange
+		value  types.Amount
+		native bool
+	)
+	switch meta := tx.Meta.(type) {
+	case types.Transfer:
+		change, value, native = BalanceChange{Symbol: meta.Symbol, Decimals: meta.Decimals}, meta.Value, true
+	case *types.Transfer:
+		change, value, native = BalanceChange{Symbol: meta.Symbol, Decimals: meta.Decimals}, meta.Value, true
+	case types.TokenTransfer:
+		change, value = BalanceChange{TokenID: meta.TokenID, Symbol: meta.Symbol, Decimals: meta.Decimals}, meta.Value
+	case *types.TokenTransfer:
+		change, value = BalanceChange{TokenID: meta.TokenID, Symbol: meta.Symbol, Decimals: meta.Decimals}, meta.Value
+	case types.NativeTokenTransfer:
+		change, value = BalanceChange{TokenID: meta.TokenID, Symbol: meta.Symbol, Decimals: meta.Decimals}, meta.Value
+	case *types.NativeTokenTransfer:
+		change, value = BalanceChange{TokenID: meta.TokenID, Symbol: meta.Symbol, Decimals: meta.Decimals}, meta.Value
+	default:
+		return change, nil, false
+	}
+	if native && change.Symbol == "" {
+		if c, ok := coin.Coins[tx.Coin]; ok {
+			change.Symbol, change.Decimals = c.Symbol, c.Decimals
+		}
+	}
+
+	delta := amount(value)
+	if tx.Status == types.StatusError {
+		delta.SetInt64(0)
+	}
+	switch tx.Direction {
+	case types.DirectionIncoming:
+		return change, delta, true
+	case types.DirectionSelf:
+		delta.SetInt64(0)
+	}
+	if native {
+		delta.Add(delta, amount(tx.Fee))
+	}
+	return change, delta.Neg(delta), true
+}
+
+func amount(a types.Amount) *big.Int {
+	value, ok := new(big.Int).SetString(string(a), 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return value
+}
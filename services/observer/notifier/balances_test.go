@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type mockBalanceAPI map[string]string
+
+func (m mockBalanceAPI) Coin() coin.Coin { return coin.Coins[coin.ETH] }
+
+func (m mockBalanceAPI) GetBalance(address, token string) (string, error) {
+	balance, ok := m[token]
+	if !ok {
+		return "", blockatlas.ErrSourceConn
+	}
+	return balance, nil
+}
+
+func TestAddBalanceChanges(t *testing.T) {
+	address := "0x08777CB1e80F45642752662B04886Df2d271E049"
+	notifications := []TransactionNotification{
+		{Result: types.Tx{ID: "in", Coin: coin.ETH, Block: 10, Fee: "21", Direction: types.DirectionIncoming,
+			Meta: types.Transfer{Value: "1000", Symbol: "ETH", Decimals: 18}}},
+		{Result: types.Tx{ID: "token", Coin: coin.ETH, Block: 11, Fee: "50", Direction: types.DirectionOutgoing,
+			Meta: types.TokenTransfer{TokenID: "0xdac17f958d2ee523a2206206994597c13d831ec7", Symbol: "USDT", Decimals: 6, Value: "300"}}},
+		{Result: types.Tx{ID: "out", Coin: coin.ETH, Block: 12, Fee: "21", Direction: types.DirectionOutgoing,
+			Meta: types.Transfer{Value: "400", Symbol: "ETH", Decimals: 18}}},
+		{Result: types.Tx{ID: "failed", Coin: coin.ETH, Block: 12, Sequence: 1, Fee: "9", Direction: types.DirectionOutgoing,
+			Status: types.StatusError, Meta: types.Transfer{Value: "5000"}}},
+		{Result: types.Tx{ID: "call", Coin: coin.ETH, Block: 13, Meta: types.ContractCall{}}},
+		{Result: types.Tx{ID: "dai", Coin: coin.ETH, Block: 13, Direction: types.DirectionIncoming,
+			Meta: types.TokenTransfer{TokenID: "0x6b175474e89094c44da98b954eedeac495271d0f", Value: "1"}}},
+	}
+	addBalanceChanges(mockBalanceAPI{"": "2570", "0xdac17f958d2ee523a2206206994597c13d831ec7": "700"}, address, notifications)
+
+	// The latest transaction ends on the fetched balance
+	assert.Equal(t, &BalanceChange{Symbol: "ETH", Decimals: 18, Pre: "2579", Post: "2570", Delta: "-9"}, notifications[3].Balance)
+	assert.Equal(t, &BalanceChange{Symbol: "ETH", Decimals: 18, Pre: "3000", Post: "2579", Delta: "-421"}, notifications[2].Balance)
+	assert.Equal(t, &BalanceChange{Symbol: "ETH", Decimals: 18, Pre: "2000", Post: "3000", Delta: "1000"}, notifications[0].Balance)
+	assert.Equal(t, &BalanceChange{TokenID: "0xdac17f958d2ee523a2206206994597c13d831ec7", Symbol: "USDT", Decimals: 6,
+		Pre: "1000", Post: "700", Delta: "-300"}, notifications[1].Balance)
+	assert.Nil(t, notifications[4].Balance)
+	// The balance failed to be fetched
+	assert.Nil(t, notifications[5].Balance)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/streadway/amqp"
 	"github.com/trustwallet/blockatlas/db"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"time"
 
 	"go.elastic.co/apm"
 )
@@ -28,6 +29,9 @@ func RunNotifier(database *db.Instance, delivery amqp.Delivery) {
 	if err != nil {
 		logger.Error("failed to get transactions", err)
 	}
+	if Dedup != nil {
+		txs = Dedup.Filter(txs, time.Now())
+	}
 
 	allAddresses := make([]string, 0)
 	for _, tx := range txs {
@@ -39,20 +43,37 @@ func RunNotifier(database *db.Instance, delivery amqp.Delivery) {
 	if len(txs) < 1 {
 		return
 	}
+	if len(Drivers) > 0 {
+		sendChannelNotifications(database, txs, addresses, ctx)
+	}
+
 	subscriptionsDataList, err := database.GetSubscriptions(txs[0].Coin, addresses, ctx)
 	if err != nil || len(subscriptionsDataList) == 0 {
 		return
 	}
 
-	notifications := make([]TransactionNotification, 0)
+	notifications := make(map[string][]TransactionNotification)
 	for _, sub := range subscriptionsDataList {
 		notificationsForAddress := buildNotificationsByAddress(sub.Address, txs, ctx)
-		notifications = append(notifications, notificationsForAddress...)
+		for i := range notificationsForAddress {
+			notificationsForAddress[i].Xpub = sub.Xpub
+		}
+		if api, ok := Balances[sub.Coin]; ok {
+			addBalanceChanges(api, sub.Address, notificationsForAddress)
+		}
+		if locale := Tenants.Get(sub.Tenant).Locale; locale != "" {
+			for i, n := range notificationsForAddress {
+				message := buildMessage(locale, sub.Address, n)
+				notificationsForAddress[i].Message = &NotificationMessage{Title: message.Title, Body: message.Body}
+			}
+		}
+		notifications[sub.Tenant] = append(notifications[sub.Tenant], notificationsForAddress...)
+		if KeepHistory {
+			saveHistory(database, sub.Coin, sub.Address, notificationsForAddress, ctx)
+		}
 	}
 
-	batches := getNotificationBatches(notifications, MaxPushNotificationsBatchLimit, ctx)
-
-	for _, batch := range batches {
-		publishNotificationBatch(batch, ctx)
+	for name, tenantNotifications := range notifications {
+		publishTenantNotifications(database, name, tenantNotifications, ctx)
 	}
 }
@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/numbers"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/notifier/push"
+	"go.elastic.co/apm"
+)
+
+// Drivers deliver the notifications of the channel subscriptions, by provider
+var Drivers = make(map[types.ChannelProvider]push.Driver)
+
+func sendChannelNotifications(database *db.Instance, txs types.Txs, addresses []string, ctx context.Context) {
+	span, ctx := apm.StartSpan(ctx, "sendChannelNotifications", "app")
+	defer span.End()
+
+	subscriptions, err := database.GetChannelSubscriptions(txs[0].Coin, addresses, ctx)
+	if err != nil || len(subscriptions) == 0 {
+		return
+	}
+
+	type destination struct {
+		address  string
+		provider types.ChannelProvider
+		locale   string
+	}
+	tokens := make(map[destination][]string)
+	digestAddresses := make(map[string]bool)
+	for _, s := range subscriptions {
+		d := destination{address: s.Address, provider: types.ChannelProvider(s.Provider), locale: s.Locale}
+		if _, ok := Drivers[d.provider]; !ok {
+			continue
+		}
+		if s.Digest != "" {
+			digestAddresses[s.Address] = true
+			continue
+		}
+		tokens[d] = append(tokens[d], s.Token)
+	}
+
+	notifications := make(map[string][]TransactionNotification)
+	for address := range digestAddresses {
+		notifications[address] = buildNotificationsByAddress(address, txs, ctx)
+		saveDigestEntries(database, address, notifications[address], ctx)
+	}
+
+	invalid := make(map[types.ChannelProvider][]string)
+	for d, destinationTokens := range tokens {
+		if _, ok := notifications[d.address]; !ok {
+			notifications[d.address] = buildNotificationsByAddress(d.address, txs, ctx)
+		}
+		for _, notification := range notifications[d.address] {
+			message := buildMessage(d.locale, d.address, notification)
+			rejected, err := Drivers[d.provider].Send(destinationTokens, message, ctx)
+			if err != nil {
+				logger.Error(err, logger.Params{"provider": d.provider, "tokens": len(destinationTokens)})
+			}
+			invalid[d.provider] = append(invalid[d.provider], rejected...)
+		}
+	}
+
+	deleteInvalidTokens(database, invalid, ctx)
+}
+
+func deleteInvalidTokens(database *db.Instance, invalid map[types.ChannelProvider][]string, ctx context.Context) {
+	for provider, rejected := range invalid {
+		if len(rejected) == 0 {
+			continue
+		}
+		if err := database.DeleteChannelTokens(string(provider), rejected, ctx); err != nil {
+			logger.Error(err, logger.Params{"provider": provider})
+			continue
+		}
+		logger.Info("Removed invalid channel tokens", logger.Params{"provider": provider, "tokens": len(rejected)})
+	}
+}
+
+func buildMessage(locale, address string, notification TransactionNotification) push.Message {
+	tx := notification.Result
+	title, body, err := MessageTemplates.Render(locale, notification.Action, newTemplateData(address, tx))
+	if err != nil {
+		logger.Error(err, logger.Params{"locale": locale, "event": notification.Action})
+	}
+	return push.Message{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"coin":      fmt.Sprint(tx.Coin),
+			"address":   address,
+			"tx_id":     tx.ID,
+			"type":      string(notification.Action),
+			"direction": string(tx.Direction),
+		},
+		Tx: &tx,
+	}
+}
+
+func txAmount(tx types.Tx) (value, symbol string, ok bool) {
+	raw, symbol, decimals, ok := txValue(tx)
+	if !ok {
+		return "", "", false
+	}
+	return numbers.ToDecimal(string(raw), int(decimals)), symbol, true
+}
+
+func txValue(tx types.Tx) (value types.Amount, symbol string, decimals uint, ok bool) {
+	switch meta := tx.Meta.(type) {
+	case types.Transfer:
+		return meta.Value, meta.Symbol, meta.Decimals, true
+	case *types.Transfer:
+		return meta.Value, meta.Symbol, meta.Decimals, true
+	case types.NativeTokenTransfer:
+		return meta.Value, meta.Symbol, meta.Decimals, true
+	case *types.NativeTokenTransfer:
+		return meta.Value, meta.Symbol, meta.Decimals, true
+	case types.TokenTransfer:
+		return meta.Value, meta.Symbol, meta.Decimals, true
+	case *types.TokenTransfer:
+		return meta.Value, meta.Symbol, meta.Decimals, true
+	default:
+		return "", "", 0, false
+	}
+}
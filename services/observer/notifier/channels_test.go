@@ -0,0 +1,69 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func Test_buildMessage(t *testing.T) {
+	tx := nativeTokenTransfer
+	tx.Direction = types.DirectionOutgoing
+	message := buildMessage("", "tbnb1ttyn4csghfgyxreu7lmdu3lcplhqhxtzced45a", TransactionNotification{Action: types.TxNativeTokenTransfer, Result: tx})
+	assert.Equal(t, "Sent 2.10572645 YLC", message.Title)
+	assert.Equal(t, "tbnb1ttyn4csghfgyxreu7lmdu3lcplhqhxtzced45a", message.Body)
+	assert.Equal(t, tx.ID, message.Data["tx_id"])
+	assert.Equal(t, "714", message.Data["coin"])
+	assert.Equal(t, "outgoing", message.Data["direction"])
+
+	call := tokenTransfer
+	call.Direction = types.DirectionIncoming
+	call.Meta = types.ContractCall{Input: "0x", Value: "0"}
+	message = buildMessage("es", "0x38d45371993eEc84f38FEDf93C646aA2D2267CEA", TransactionNotification{Action: types.TxContractCall, Result: call})
+	assert.Equal(t, "Llamada a contrato de Ethereum", message.Title)
+}
+
+func Test_buildMessage_Event(t *testing.T) {
+	tx := tokenTransfer
+	tx.Event = &types.TxEvent{Type: types.EventLendingDeposit, Protocol: "compound", Value: "2000000000000000000", Symbol: "DAI", Decimals: 18}
+	notifications := buildNotificationsByAddress("0x08777CB1e80F45642752662B04886Df2d271E049", []types.Tx{tx}, context.Background())
+	assert.Len(t, notifications, 1)
+	assert.Equal(t, types.TransactionType(types.EventLendingDeposit), notifications[0].Action)
+
+	message := buildMessage("en", "0x08777CB1e80F45642752662B04886Df2d271E049", notifications[0])
+	assert.Equal(t, "Deposited 2 DAI to Compound", message.Title)
+}
+
+func Test_buildMessage_StakingEvent(t *testing.T) {
+	tx := types.Tx{
+		ID:   "unbonding",
+		Coin: coin.ATOM,
+		From: "cosmosvaloper1ptyzewnns2kn37ewtmv6ppsvhdnmeapvtfc9y5",
+		To:   "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl",
+		Fee:  "0",
+		Type: types.TxAnyAction,
+		Meta: types.AnyAction{Coin: coin.ATOM, Title: types.AnyActionUndelegation, Key: types.KeyStakeDelegate, Symbol: "ATOM", Decimals: 6, Value: "2000000"},
+		Event: &types.TxEvent{
+			Type: types.EventUnbondingComplete, Validator: "cosmosvaloper1ptyzewnns2kn37ewtmv6ppsvhdnmeapvtfc9y5", Value: "2000000", Symbol: "ATOM", Decimals: 6,
+		},
+	}
+	notifications := buildNotificationsByAddress("cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl", []types.Tx{tx}, context.Background())
+	assert.Len(t, notifications, 1)
+	assert.Equal(t, types.TransactionType(types.EventUnbondingComplete), notifications[0].Action)
+
+	message := buildMessage("en", "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl", notifications[0])
+	assert.Equal(t, "2 ATOM unstaked and available", message.Title)
+	assert.Equal(t, "Validator cosmosvaloper1ptyzewnns2kn37ewtmv6ppsvhdnmeapvtfc9y5", message.Body)
+}
+
+func Test_buildMessage_BridgeEvent(t *testing.T) {
+	tx := tokenTransfer
+	tx.Event = &types.TxEvent{Type: types.EventBridgeTransfer, Protocol: "wormhole", Network: "solana", Value: "5000000", Symbol: "USDC", Decimals: 6}
+	tx.Direction = types.DirectionOutgoing
+	message := buildMessage("en", "0x08777CB1e80F45642752662B04886Df2d271E049", TransactionNotification{Action: types.TransactionType(types.EventBridgeTransfer), Result: tx})
+	assert.Equal(t, "Bridged 5 USDC to Solana", message.Title)
+	assert.Equal(t, "Wormhole bridge, 0x08777CB1e80F45642752662B04886Df2d271E049", message.Body)
+}
@@ -0,0 +1,74 @@
+package notifier
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const DefaultDedupTTL = time.Hour
+
+// Dedup drops the transactions notified recently, set when observer.dedup is enabled
+var Dedup *TxDedup
+
+// TxDedup remembers the hashes of the transactions consumed for ttl: the batches published again by the parser
+// after a restart or delivered again by RabbitMQ after a reconnection notify once. The hashes are kept
+// by each notifier, a batch delivered again to another one isn't dropped.
+type TxDedup struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	seen      map[string]time.Time
+	lastPrune time.Time
+}
+
+func NewTxDedup(ttl time.Duration) *TxDedup {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	return &TxDedup{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+// Filter returns the transactions of the batch not consumed in the last ttl and remembers them. The transactions
+// of the batch sharing a hash are kept together.
+func (d *TxDedup) Filter(txs types.Txs, now time.Time) types.Txs {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if now.Sub(d.lastPrune) >= d.ttl {
+		d.prune(now)
+	}
+	result := make(types.Txs, 0, len(txs))
+	batch := make(map[string]bool)
+	for _, tx := range txs {
+		key := dedupKey(tx)
+		if seenAt, ok := d.seen[key]; ok && !batch[key] && now.Sub(seenAt) < d.ttl {
+			continue
+		}
+		batch[key] = true
+		result = append(result, tx)
+	}
+	for key := range batch {
+		d.seen[key] = now
+	}
+	return result
+}
+
+func (d *TxDedup) prune(now time.Time) {
+	for key, seenAt := range d.seen {
+		if now.Sub(seenAt) >= d.ttl {
+			delete(d.seen, key)
+		}
+	}
+	d.lastPrune = now
+}
+
+// dedupKey is the hash of the transaction in its coin, with the event since an unbonding completes with
+// the hash of its undelegation
+func dedupKey(tx types.Tx) string {
+	key := fmt.Sprintf("%d:%s", tx.Coin, tx.ID)
+	if tx.Event != nil {
+		key += ":" + string(tx.Event.Type)
+	}
+	return key
+}
@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestTxDedup_Filter(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	d := NewTxDedup(time.Hour)
+	batch := types.Txs{
+		{ID: "0x1", Coin: coin.ETH, From: "0xa"},
+		{ID: "0x1", Coin: coin.ETH, From: "0xb"},
+		{ID: "0x2", Coin: coin.ETH},
+	}
+	assert.Len(t, d.Filter(batch, now), 3)
+
+	again := d.Filter(types.Txs{{ID: "0x1", Coin: coin.ETH}, {ID: "0x1", Coin: coin.ETC}, {ID: "0x3", Coin: coin.ETH}}, now.Add(time.Minute))
+	assert.Equal(t, []string{"0x1", "0x3"}, []string{again[0].ID, again[1].ID})
+	assert.Equal(t, uint(coin.ETC), again[0].Coin)
+
+	unbonding := types.Txs{{ID: "0x2", Coin: coin.ETH, Event: &types.TxEvent{Type: types.EventUnbondingComplete}}}
+	assert.Len(t, d.Filter(unbonding, now.Add(time.Minute)), 1)
+
+	assert.Len(t, d.Filter(batch, now.Add(2*time.Hour)), 3)
+	assert.Len(t, d.seen, 2)
+}
@@ -29,20 +29,24 @@ func getTransactionsFromDelivery(delivery amqp.Delivery, ctx context.Context) (b
 	return txs, nil
 }
 
-func publishNotificationBatch(batch []TransactionNotification, ctx context.Context) {
+func publishNotificationBatch(queue mq.Queue, batch []TransactionNotification, ctx context.Context) {
 	span, _ := apm.StartSpan(ctx, "getNotificationBatches", "app")
 	defer span.End()
 
-	raw, err := json.Marshal(batch)
-	if err != nil {
-		err = errors.E(err, " failed to dispatch event")
+	if err := publishBatch(queue, batch); err != nil {
 		logger.Fatal(err)
 	}
-	err = mq.TxNotifications.Publish(raw)
+
+	logger.Info("Txs batch dispatched", logger.Params{"txs": len(batch), "queue": queue})
+}
+
+func publishBatch(queue mq.Queue, batch []TransactionNotification) error {
+	raw, err := json.Marshal(batch)
 	if err != nil {
-		err = errors.E(err, " failed to dispatch event")
-		logger.Fatal(err)
+		return errors.E(err, " failed to dispatch event")
 	}
-
-	logger.Info("Txs batch dispatched", logger.Params{"txs": len(batch)})
+	if err := queue.Publish(raw); err != nil {
+		return errors.E(err, " failed to dispatch event")
+	}
+	return nil
 }
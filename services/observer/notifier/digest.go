@@ -0,0 +1,215 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/numbers"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/notifier/push"
+	"go.elastic.co/apm"
+)
+
+const (
+	DefaultDigestInterval = 10 * time.Minute
+
+	// digestEvent is the template of the digest notifications
+	digestEvent = "digest"
+)
+
+var digestPeriods = []types.DigestPeriod{types.DigestDaily, types.DigestWeekly}
+
+func saveDigestEntries(database *db.Instance, address string, notifications []TransactionNotification, ctx context.Context) {
+	entries := toDigestEntries(address, notifications)
+	if len(entries) == 0 {
+		return
+	}
+	if err := database.AddDigestEntries(entries, ctx); err != nil {
+		logger.Error(err, logger.Params{"address": address, "entries": len(entries)})
+	}
+}
+
+func toDigestEntries(address string, notifications []TransactionNotification) []models.DigestEntry {
+	entries := make([]models.DigestEntry, 0, len(notifications))
+	for _, n := range notifications {
+		tx := n.Result
+		entry := models.DigestEntry{Coin: tx.Coin, Address: address, TxID: tx.ID, Direction: string(tx.Direction)}
+		if value, symbol, decimals, ok := txValue(tx); ok {
+			entry.Value, entry.Symbol, entry.Decimals = string(value), symbol, decimals
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// RunDigestScheduler sends the due digests of the channel subscriptions at every interval
+func RunDigestScheduler(database *db.Instance, interval time.Duration, ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Digest scheduler stopped")
+			return
+		case now := <-ticker.C:
+			for _, period := range digestPeriods {
+				sendDigests(database, period, now, ctx)
+			}
+			// The longest period is summarized, its entries are not needed anymore
+			if err := database.DeleteDigestEntries(now.Add(-7*24*time.Hour-interval), ctx); err != nil {
+				logger.Error(err, "Failed to delete digest entries")
+			}
+		}
+	}
+}
+
+func sendDigests(database *db.Instance, period types.DigestPeriod, now time.Time, c context.Context) {
+	tx := apm.DefaultTracer.StartTransaction("sendDigests", "app")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(c, tx)
+
+	duration, _ := period.Duration()
+	subscriptions, err := database.GetDueDigestSubscriptions(string(period), now.Add(-duration), ctx)
+	if err != nil {
+		logger.Error(err, logger.Params{"period": period})
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	type destination struct {
+		coin     uint
+		address  string
+		provider types.ChannelProvider
+		locale   string
+	}
+	tokens := make(map[destination][]string)
+	since := make(map[destination]time.Time)
+	for _, s := range subscriptions {
+		d := destination{coin: s.Coin, address: s.Address, provider: types.ChannelProvider(s.Provider), locale: s.Locale}
+		tokens[d] = append(tokens[d], s.Token)
+		if t, ok := since[d]; !ok || s.DigestSentAt.Before(t) {
+			since[d] = s.DigestSentAt
+		}
+	}
+
+	invalid := make(map[types.ChannelProvider][]string)
+	for d, destinationTokens := range tokens {
+		driver, ok := Drivers[d.provider]
+		if !ok {
+			continue
+		}
+		entries, err := database.GetDigestEntries(d.coin, d.address, since[d], ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"coin": d.coin, "address": d.address})
+			continue
+		}
+		// No activity, no digest
+		if len(entries) == 0 {
+			continue
+		}
+		message := buildDigestMessage(d.locale, period, d.coin, d.address, entries)
+		rejected, err := driver.Send(destinationTokens, message, ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": d.provider, "tokens": len(destinationTokens)})
+		}
+		invalid[d.provider] = append(invalid[d.provider], rejected...)
+	}
+	deleteInvalidTokens(database, invalid, ctx)
+
+	if err := database.SetDigestSentAt(subscriptions, now, ctx); err != nil {
+		logger.Error(err, logger.Params{"period": period})
+		return
+	}
+	logger.Info("Digests sent", logger.Params{"period": period, "subscriptions": len(subscriptions)})
+}
+
+func buildDigestMessage(locale string, period types.DigestPeriod, coinID uint, address string, entries []models.DigestEntry) push.Message {
+	data := TemplateData{
+		Address: address,
+		Period:  string(period),
+		Count:   countTransactions(entries),
+		Change:  portfolioChange(entries),
+	}
+	if c, ok := coin.Coins[coinID]; ok {
+		data.Coin = c.Name
+	}
+	title, body, err := MessageTemplates.Render(locale, digestEvent, data)
+	if err != nil {
+		logger.Error(err, logger.Params{"locale": locale, "event": digestEvent})
+	}
+	return push.Message{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"coin":    fmt.Sprint(coinID),
+			"address": address,
+			"type":    digestEvent,
+			"period":  string(period),
+		},
+	}
+}
+
+func countTransactions(entries []models.DigestEntry) int {
+	ids := make(map[string]bool)
+	for _, e := range entries {
+		ids[e.TxID] = true
+	}
+	return len(ids)
+}
+
+// portfolioChange sums the received and sent values by asset, e.g. "+1.5 ATOM, -20 DAI"
+func portfolioChange(entries []models.DigestEntry) string {
+	type asset struct {
+		symbol   string
+		decimals uint
+	}
+	totals := make(map[asset]*big.Int)
+	for _, e := range entries {
+		value, ok := new(big.Int).SetString(e.Value, 10)
+		if !ok || e.Symbol == "" {
+			continue
+		}
+		switch types.Direction(e.Direction) {
+		case types.DirectionIncoming:
+		case types.DirectionOutgoing:
+			value.Neg(value)
+		default:
+			continue
+		}
+		a := asset{symbol: e.Symbol, decimals: e.Decimals}
+		if totals[a] == nil {
+			totals[a] = new(big.Int)
+		}
+		totals[a].Add(totals[a], value)
+	}
+
+	assets := make([]asset, 0, len(totals))
+	for a := range totals {
+		assets = append(assets, a)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].symbol < assets[j].symbol })
+
+	changes := make([]string, 0, len(assets))
+	for _, a := range assets {
+		total := totals[a]
+		if total.Sign() == 0 {
+			continue
+		}
+		sign := ""
+		if total.Sign() > 0 {
+			sign = "+"
+		}
+		changes = append(changes, fmt.Sprintf("%s%s %s", sign, numbers.ToDecimal(total.String(), int(a.decimals)), a.symbol))
+	}
+	return strings.Join(changes, ", ")
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func Test_toDigestEntries(t *testing.T) {
+	tx := nativeTokenTransfer
+	tx.Direction = types.DirectionOutgoing
+	entries := toDigestEntries("tbnb1ttyn4csghfgyxreu7lmdu3lcplhqhxtzced45a", []TransactionNotification{{Action: types.TxNativeTokenTransfer, Result: tx}})
+	assert.Len(t, entries, 1)
+	assert.Equal(t, tx.ID, entries[0].TxID)
+	assert.Equal(t, "outgoing", entries[0].Direction)
+	assert.NotEmpty(t, entries[0].Value)
+}
+
+func Test_buildDigestMessage(t *testing.T) {
+	const address = "0x08777CB1e80F45642752662B04886Df2d271E049"
+	entries := []models.DigestEntry{
+		{Coin: coin.ETH, Address: address, TxID: "1", Direction: "incoming", Symbol: "ETH", Decimals: 18, Value: "2000000000000000000"},
+		{Coin: coin.ETH, Address: address, TxID: "2", Direction: "outgoing", Symbol: "ETH", Decimals: 18, Value: "500000000000000000"},
+		{Coin: coin.ETH, Address: address, TxID: "3", Direction: "outgoing", Symbol: "DAI", Decimals: 18, Value: "20000000000000000000"},
+		{Coin: coin.ETH, Address: address, TxID: "4", Direction: "yourself", Symbol: "ETH", Decimals: 18, Value: "1"},
+		{Coin: coin.ETH, Address: address, TxID: "5", Direction: "outgoing"},
+	}
+	message := buildDigestMessage("en", types.DigestDaily, coin.ETH, address, entries)
+	assert.Equal(t, "Your daily Ethereum summary", message.Title)
+	assert.Equal(t, "5 transactions: -20 DAI, +1.5 ETH", message.Body)
+	assert.Equal(t, "daily", message.Data["period"])
+
+	message = buildDigestMessage("es", types.DigestWeekly, coin.ETH, address, entries[3:4])
+	assert.Equal(t, "Tu resumen semanal de Ethereum", message.Title)
+	assert.Equal(t, "1 transacción", message.Body)
+}
@@ -0,0 +1,159 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/mq"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/notifier/push"
+	"go.elastic.co/apm"
+)
+
+const (
+	DefaultExpiryInterval = time.Hour
+	DefaultExpiryNotice   = 72 * time.Hour
+
+	// subscriptionExpiringEvent is the template of the expiry notices of the channel subscriptions
+	subscriptionExpiringEvent = "subscription_expiring"
+)
+
+// RunExpiryWorker notifies the owners of the subscriptions expiring within the notice period
+// and deletes the expired subscriptions at every interval
+func RunExpiryWorker(database *db.Instance, interval, notice time.Duration, ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Subscription expiry worker stopped")
+			return
+		case now := <-ticker.C:
+			expireSubscriptions(database, now, notice, ctx)
+		}
+	}
+}
+
+func expireSubscriptions(database *db.Instance, now time.Time, notice time.Duration, c context.Context) {
+	tx := apm.DefaultTracer.StartTransaction("expireSubscriptions", "app")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(c, tx)
+
+	notifyExpiringSubscriptions(database, now.Add(notice), ctx)
+	notifyExpiringChannelSubscriptions(database, now.Add(notice), ctx)
+
+	deleted, err := database.DeleteExpiredSubscriptions(now, ctx)
+	if err != nil {
+		logger.Error(err, "Failed to delete expired subscriptions")
+		return
+	}
+	if deleted > 0 {
+		logger.Info("Expired subscriptions deleted", logger.Params{"subscriptions": deleted})
+	}
+}
+
+// notifyExpiringSubscriptions publishes the expiry notices of the subscriptions to the subscriptionsExpiring queue
+func notifyExpiringSubscriptions(database *db.Instance, before time.Time, ctx context.Context) {
+	subscriptions, err := database.GetExpiringSubscriptions(before, ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get expiring subscriptions")
+		return
+	}
+	expiries := toSubscriptionExpiries(subscriptions)
+	for lo := 0; lo < len(expiries); lo += int(MaxPushNotificationsBatchLimit) {
+		hi := lo + int(MaxPushNotificationsBatchLimit)
+		if hi > len(expiries) {
+			hi = len(expiries)
+		}
+		raw, err := json.Marshal(expiries[lo:hi])
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+		if err := mq.SubscriptionsExpiring.Publish(raw); err != nil {
+			logger.Error(errors.E(err, "failed to dispatch expiry notices"))
+			return
+		}
+		if err := database.SetSubscriptionsExpiryNotified(subscriptions[lo:hi], ctx); err != nil {
+			logger.Error(err, "Failed to record expiry notices")
+			return
+		}
+	}
+	if len(expiries) > 0 {
+		logger.Info("Expiry notices dispatched", logger.Params{"subscriptions": len(expiries)})
+	}
+}
+
+func toSubscriptionExpiries(subscriptions []models.Subscription) []types.SubscriptionExpiry {
+	expiries := make([]types.SubscriptionExpiry, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		if s.ExpiresAt == nil {
+			continue
+		}
+		expiries = append(expiries, types.SubscriptionExpiry{Coin: s.Coin, Address: s.Address, ExpiresAt: s.ExpiresAt.Unix(), Xpub: s.Xpub, Tenant: s.Tenant})
+	}
+	return expiries
+}
+
+// notifyExpiringChannelSubscriptions sends the expiry notices of the channel subscriptions through the channel drivers
+func notifyExpiringChannelSubscriptions(database *db.Instance, before time.Time, ctx context.Context) {
+	subscriptions, err := database.GetExpiringChannelSubscriptions(before, ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get expiring channel subscriptions")
+		return
+	}
+	notified := make([]models.ChannelSubscription, 0, len(subscriptions))
+	invalid := make(map[types.ChannelProvider][]string)
+	for _, s := range subscriptions {
+		provider := types.ChannelProvider(s.Provider)
+		driver, ok := Drivers[provider]
+		if !ok || s.ExpiresAt == nil {
+			continue
+		}
+		rejected, err := driver.Send([]string{s.Token}, buildExpiryMessage(s), ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": provider, "coin": s.Coin, "address": s.Address})
+			continue
+		}
+		invalid[provider] = append(invalid[provider], rejected...)
+		notified = append(notified, s)
+	}
+	deleteInvalidTokens(database, invalid, ctx)
+	if len(notified) == 0 {
+		return
+	}
+	if err := database.SetChannelSubscriptionsExpiryNotified(notified, ctx); err != nil {
+		logger.Error(err, "Failed to record expiry notices")
+	}
+}
+
+func buildExpiryMessage(s models.ChannelSubscription) push.Message {
+	data := TemplateData{
+		Address:   s.Address,
+		ExpiresAt: s.ExpiresAt.UTC().Format("2006-01-02"),
+	}
+	if c, ok := coin.Coins[s.Coin]; ok {
+		data.Coin = c.Name
+	}
+	title, body, err := MessageTemplates.Render(s.Locale, subscriptionExpiringEvent, data)
+	if err != nil {
+		logger.Error(err, logger.Params{"locale": s.Locale, "event": subscriptionExpiringEvent})
+	}
+	return push.Message{
+		Title: title,
+		Body:  body,
+		Data: map[string]string{
+			"type":       subscriptionExpiringEvent,
+			"coin":       strconv.Itoa(int(s.Coin)),
+			"address":    s.Address,
+			"expires_at": strconv.FormatInt(s.ExpiresAt.Unix(), 10),
+		},
+	}
+}
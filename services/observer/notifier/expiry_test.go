@@ -0,0 +1,33 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func Test_toSubscriptionExpiries(t *testing.T) {
+	expiresAt := time.Unix(1600000000, 0)
+	expiries := toSubscriptionExpiries([]models.Subscription{
+		{Coin: 60, Address: "0xa", ExpiresAt: &expiresAt},
+		{Coin: 60, Address: "0xb"},
+	})
+	assert.Equal(t, []types.SubscriptionExpiry{{Coin: 60, Address: "0xa", ExpiresAt: 1600000000}}, expiries)
+}
+
+func Test_buildExpiryMessage(t *testing.T) {
+	expiresAt := time.Date(2020, 9, 13, 12, 0, 0, 0, time.UTC)
+	s := models.ChannelSubscription{Coin: 60, Address: "0xa", Provider: "fcm", Token: "device", ExpiresAt: &expiresAt}
+	message := buildExpiryMessage(s)
+	assert.Equal(t, "Your Ethereum notifications end on 2020-09-13", message.Title)
+	assert.Equal(t, "Renew the subscription to keep watching 0xa", message.Body)
+	assert.Equal(t, "subscription_expiring", message.Data["type"])
+	assert.Equal(t, "1599998400", message.Data["expires_at"])
+
+	s.Locale = "es"
+	message = buildExpiryMessage(s)
+	assert.Equal(t, "Tus notificaciones de Ethereum terminan el 2020-09-13", message.Title)
+}
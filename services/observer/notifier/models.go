@@ -2,14 +2,31 @@ package notifier
 
 import (
 	"context"
+	"github.com/trustwallet/blockatlas/coin"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
 	"go.elastic.co/apm"
 )
 
-type TransactionNotification struct {
-	Action blockatlas.TransactionType `json:"action"`
-	Result blockatlas.Tx              `json:"result"`
-}
+type (
+	TransactionNotification struct {
+		Action types.TransactionType `json:"action"`
+		Result types.Tx              `json:"result"`
+		// Ready to display text, set if the subscription has a locale
+		Message *NotificationMessage `json:"message,omitempty"`
+		// Replay is set on the notifications published again by a replay request
+		Replay bool `json:"replay,omitempty"`
+		// Xpub the address was derived from, for the addresses of an xpub subscription
+		Xpub string `json:"xpub,omitempty"`
+		// Balance of the address in the asset moved, for the coins with a balance API
+		Balance *BalanceChange `json:"balance,omitempty"`
+	}
+
+	NotificationMessage struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+)
 
 func getNotificationBatches(notifications []TransactionNotification, sizeUint uint, ctx context.Context) [][]TransactionNotification {
 	span, _ := apm.StartSpan(ctx, "getNotificationBatches", "app")
@@ -37,8 +54,13 @@ func buildNotificationsByAddress(address string, txs blockatlas.Txs, ctx context
 	result := make([]TransactionNotification, 0, len(transactionsByAddress))
 	for _, tx := range transactionsByAddress {
 		tx.Direction = tx.GetTransactionDirection(address)
-		tx.InferUtxoValue(address, tx.Coin)
-		result = append(result, TransactionNotification{Action: tx.Type, Result: tx})
+		c := coin.Coins[tx.Coin]
+		tx.InferUtxoValue(address, c.Symbol, c.Decimals)
+		action := tx.Type
+		if tx.Event != nil {
+			action = types.TransactionType(tx.Event.Type)
+		}
+		result = append(result, TransactionNotification{Action: action, Result: tx})
 	}
 
 	return result
@@ -0,0 +1,186 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const (
+	APNsProductionURL  = "https://api.push.apple.com"
+	APNsDevelopmentURL = "https://api.sandbox.push.apple.com"
+
+	// Apple rejects provider tokens older than an hour and refreshing more often than every 20 minutes
+	apnsTokenTTL = time.Minute * 50
+)
+
+type (
+	// APNs sends notifications with the token based authentication of a .p8 signing key
+	APNs struct {
+		URL    string
+		Topic  string
+		KeyID  string
+		TeamID string
+		Client *http.Client
+
+		key *ecdsa.PrivateKey
+
+		mu       sync.Mutex
+		token    string
+		issuedAt time.Time
+		now      func() time.Time
+	}
+
+	apnsPayload struct {
+		Aps  apnsAps           `json:"aps"`
+		Data map[string]string `json:"data,omitempty"`
+	}
+
+	apnsAps struct {
+		Alert apnsAlert `json:"alert"`
+		Sound string    `json:"sound,omitempty"`
+	}
+
+	apnsAlert struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+
+	apnsError struct {
+		Reason string `json:"reason"`
+	}
+)
+
+// NewAPNs parses the PEM encoded .p8 key, topic is the bundle id of the app
+func NewAPNs(keyPEM []byte, keyID, teamID, topic string, production bool) (*APNs, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.E("invalid apns key: no PEM data")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.E(err, "invalid apns key")
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.E("invalid apns key: not an ECDSA key")
+	}
+	url := APNsDevelopmentURL
+	if production {
+		url = APNsProductionURL
+	}
+	return &APNs{
+		URL:    url,
+		Topic:  topic,
+		KeyID:  keyID,
+		TeamID: teamID,
+		Client: &http.Client{Timeout: time.Second * 15},
+		key:    key,
+		now:    time.Now,
+	}, nil
+}
+
+// APNs has no batch endpoint, every token is a separate request over the same HTTP/2 connection
+func (a *APNs) Send(tokens []string, message Message, ctx context.Context) ([]string, error) {
+	body, err := json.Marshal(apnsPayload{
+		Aps:  apnsAps{Alert: apnsAlert{Title: message.Title, Body: message.Body}, Sound: "default"},
+		Data: message.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	jwt, err := a.providerToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := make([]string, 0)
+	for _, token := range tokens {
+		valid, err := a.send(token, jwt, body, ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": "apns"})
+			continue
+		}
+		if !valid {
+			invalid = append(invalid, token)
+		}
+	}
+	return invalid, nil
+}
+
+func (a *APNs) send(token, jwt string, body []byte, ctx context.Context) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/3/device/%s", a.URL, token), bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	req.Header.Set("authorization", "bearer "+jwt)
+	req.Header.Set("apns-topic", a.Topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := a.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return true, errors.E(err, errors.TypePlatformRequest)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return true, nil
+	}
+
+	raw, _ := ioutil.ReadAll(res.Body)
+	var apnsErr apnsError
+	_ = json.Unmarshal(raw, &apnsErr)
+	if res.StatusCode == http.StatusGone || apnsErr.Reason == "BadDeviceToken" || apnsErr.Reason == "Unregistered" {
+		return false, nil
+	}
+	return true, errors.E("apns request failed", errors.Params{"status": res.StatusCode, "reason": apnsErr.Reason})
+}
+
+// providerToken returns the ES256 signed JWT, reused until it expires
+func (a *APNs) providerToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if a.token != "" && now.Sub(a.issuedAt) < apnsTokenTTL {
+		return a.token, nil
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": a.KeyID})
+	claims, _ := json.Marshal(map[string]interface{}{"iss": a.TeamID, "iat": now.Unix()})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	hash := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, a.key, hash[:])
+	if err != nil {
+		return "", errors.E(err, "failed to sign apns token")
+	}
+	signature := append(padded(r, 32), padded(s, 32)...)
+
+	a.token = unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	a.issuedAt = now
+	return a.token, nil
+}
+
+func padded(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
@@ -0,0 +1,91 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAPNs(t *testing.T) (*APNs, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.Nil(t, err)
+	apns, err := NewAPNs(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), "KEYID", "TEAMID", "com.wallet", false)
+	assert.Nil(t, err)
+	return apns, key
+}
+
+func TestNewAPNs_InvalidKey(t *testing.T) {
+	_, err := NewAPNs([]byte("not a key"), "KEYID", "TEAMID", "com.wallet", true)
+	assert.NotNil(t, err)
+}
+
+func TestAPNs_providerToken(t *testing.T) {
+	apns, key := newTestAPNs(t)
+	now := time.Unix(1600000000, 0)
+	apns.now = func() time.Time { return now }
+
+	token, err := apns.providerToken()
+	assert.Nil(t, err)
+	parts := strings.Split(token, ".")
+	assert.Len(t, parts, 3)
+
+	header, _ := base64.RawURLEncoding.DecodeString(parts[0])
+	assert.JSONEq(t, `{"alg":"ES256","kid":"KEYID"}`, string(header))
+	claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.JSONEq(t, `{"iss":"TEAMID","iat":1600000000}`, string(claims))
+
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.Len(t, signature, 64)
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	assert.True(t, ecdsa.Verify(&key.PublicKey, hash[:], r, s))
+
+	now = now.Add(time.Minute * 10)
+	cached, _ := apns.providerToken()
+	assert.Equal(t, token, cached)
+
+	now = now.Add(time.Hour)
+	refreshed, _ := apns.providerToken()
+	assert.NotEqual(t, token, refreshed)
+}
+
+func TestAPNs_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "com.wallet", r.Header.Get("apns-topic"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("authorization"), "bearer "))
+		switch r.URL.Path {
+		case "/3/device/valid":
+			w.WriteHeader(http.StatusOK)
+		case "/3/device/gone":
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason":"Unregistered"}`))
+		case "/3/device/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	apns, _ := newTestAPNs(t)
+	apns.URL = server.URL
+	invalid, err := apns.Send([]string{"valid", "gone", "bad", "unavailable"}, Message{Title: "Sent"}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"gone", "bad"}, invalid)
+}
@@ -0,0 +1,81 @@
+package push
+
+import (
+	"context"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+const (
+	FCMURL = "https://fcm.googleapis.com/fcm/send"
+
+	// FCM accepts up to 1000 registration ids per request
+	fcmBatchLimit = 1000
+)
+
+type (
+	FCM struct {
+		blockatlas.Request
+	}
+
+	fcmRequest struct {
+		RegistrationIDs []string          `json:"registration_ids"`
+		Notification    fcmNotification   `json:"notification"`
+		Data            map[string]string `json:"data,omitempty"`
+	}
+
+	fcmNotification struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+
+	fcmResponse struct {
+		Success int         `json:"success"`
+		Failure int         `json:"failure"`
+		Results []fcmResult `json:"results"`
+	}
+
+	fcmResult struct {
+		MessageID string `json:"message_id"`
+		Error     string `json:"error"`
+	}
+)
+
+func NewFCM(serverKey string) *FCM {
+	request := blockatlas.InitJSONClient(FCMURL)
+	request.Headers["Authorization"] = "key=" + serverKey
+	return &FCM{Request: request}
+}
+
+func (f *FCM) Send(tokens []string, message Message, ctx context.Context) ([]string, error) {
+	invalid := make([]string, 0)
+	for lo := 0; lo < len(tokens); lo += fcmBatchLimit {
+		hi := lo + fcmBatchLimit
+		if hi > len(tokens) {
+			hi = len(tokens)
+		}
+		batch := tokens[lo:hi]
+
+		var res fcmResponse
+		err := f.PostWithContext(&res, "", fcmRequest{
+			RegistrationIDs: batch,
+			Notification:    fcmNotification{Title: message.Title, Body: message.Body},
+			Data:            message.Data,
+		}, ctx)
+		if err != nil {
+			return invalid, errors.E(err, "fcm request failed")
+		}
+		// Results are in the order of the registration ids
+		for i, r := range res.Results {
+			if i < len(batch) && isInvalidFCMToken(r.Error) {
+				invalid = append(invalid, batch[i])
+			}
+		}
+	}
+	return invalid, nil
+}
+
+func isInvalidFCMToken(err string) bool {
+	return err == "NotRegistered" || err == "InvalidRegistration"
+}
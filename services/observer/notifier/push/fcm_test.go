@@ -0,0 +1,30 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFCM_Send(t *testing.T) {
+	var received fcmRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key=secret", r.Header.Get("Authorization"))
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte(`{"success":1,"failure":2,"results":[{"message_id":"1"},{"error":"NotRegistered"},{"error":"Unavailable"}]}`))
+	}))
+	defer server.Close()
+
+	fcm := NewFCM("secret")
+	fcm.BaseUrl = server.URL
+	invalid, err := fcm.Send([]string{"a", "b", "c"}, Message{Title: "Received 1 BNB", Body: "bnb1", Data: map[string]string{"tx_id": "1"}}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b"}, invalid)
+	assert.Equal(t, []string{"a", "b", "c"}, received.RegistrationIDs)
+	assert.Equal(t, "Received 1 BNB", received.Notification.Title)
+	assert.Equal(t, "1", received.Data["tx_id"])
+}
@@ -0,0 +1,25 @@
+package push
+
+import (
+	"context"
+
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type (
+	// Message is the provider independent content of a notification, Tx is the normalized transaction
+	// of the transaction notifications
+	Message struct {
+		Title string
+		Body  string
+		Data  map[string]string
+		Tx    *types.Tx
+	}
+
+	// Driver delivers messages to the tokens of a provider.
+	// It returns the tokens the provider rejected as no longer valid,
+	// so their subscriptions can be removed.
+	Driver interface {
+		Send(tokens []string, message Message, ctx context.Context) (invalid []string, err error)
+	}
+)
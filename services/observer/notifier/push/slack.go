@@ -0,0 +1,82 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const SlackWebhookPrefix = "https://hooks.slack.com/"
+
+type (
+	// Slack posts the messages to incoming webhooks, tokens are the webhook URLs
+	Slack struct {
+		Client *http.Client
+		// Only webhooks under this prefix are called, others are reported invalid
+		Prefix string
+	}
+
+	slackMessage struct {
+		Text string `json:"text"`
+	}
+)
+
+func NewSlack() *Slack {
+	return &Slack{
+		Client: &http.Client{Timeout: time.Second * 15},
+		Prefix: SlackWebhookPrefix,
+	}
+}
+
+func (s *Slack) Send(tokens []string, message Message, ctx context.Context) ([]string, error) {
+	body, err := json.Marshal(slackMessage{Text: messageText(message)})
+	if err != nil {
+		return nil, err
+	}
+	invalid := make([]string, 0)
+	for _, webhook := range tokens {
+		if !strings.HasPrefix(webhook, s.Prefix) {
+			invalid = append(invalid, webhook)
+			continue
+		}
+		valid, err := s.post(webhook, body, ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": "slack"})
+			continue
+		}
+		if !valid {
+			invalid = append(invalid, webhook)
+		}
+	}
+	return invalid, nil
+}
+
+func (s *Slack) post(webhook string, body []byte, ctx context.Context) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return false, nil
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return true, errors.E(err, errors.TypePlatformRequest)
+	}
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	// Revoked webhook or archived channel
+	case http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+		return false, nil
+	default:
+		raw, _ := ioutil.ReadAll(res.Body)
+		return true, errors.E("slack request failed", errors.Params{"status": res.StatusCode, "body": string(raw)})
+	}
+}
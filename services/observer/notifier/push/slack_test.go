@@ -0,0 +1,42 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlack_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg slackMessage
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&msg))
+		assert.Equal(t, "Sent", msg.Text)
+		switch r.URL.Path {
+		case "/services/ok":
+			_, _ = w.Write([]byte("ok"))
+		case "/services/archived":
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte("channel_is_archived"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("no_service"))
+		}
+	}))
+	defer server.Close()
+
+	slack := NewSlack()
+	slack.Prefix = server.URL + "/services/"
+	tokens := []string{
+		server.URL + "/services/ok",
+		server.URL + "/services/archived",
+		server.URL + "/services/removed",
+		"http://169.254.169.254/latest/meta-data",
+	}
+	invalid, err := slack.Send(tokens, Message{Title: "Sent"}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, tokens[1:], invalid)
+}
@@ -0,0 +1,65 @@
+package push
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+const TelegramURL = "https://api.telegram.org"
+
+type (
+	// Telegram sends the messages from a bot, tokens are the chat ids
+	Telegram struct {
+		blockatlas.Request
+	}
+
+	telegramMessage struct {
+		ChatID string `json:"chat_id"`
+		Text   string `json:"text"`
+	}
+
+	telegramResponse struct {
+		Ok          bool   `json:"ok"`
+		ErrorCode   int    `json:"error_code"`
+		Description string `json:"description"`
+	}
+)
+
+func NewTelegram(botToken string) *Telegram {
+	return &Telegram{Request: blockatlas.InitJSONClient(TelegramURL + "/bot" + botToken)}
+}
+
+func (t *Telegram) Send(tokens []string, message Message, ctx context.Context) ([]string, error) {
+	text := messageText(message)
+	invalid := make([]string, 0)
+	for _, chatID := range tokens {
+		var res telegramResponse
+		err := t.PostWithContext(&res, "sendMessage", telegramMessage{ChatID: chatID, Text: text}, ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": "telegram"})
+			continue
+		}
+		if res.Ok {
+			continue
+		}
+		// The bot was blocked, removed from the chat or the chat was deleted
+		if res.ErrorCode == http.StatusForbidden || strings.Contains(res.Description, "chat not found") {
+			invalid = append(invalid, chatID)
+			continue
+		}
+		logger.Error(errors.E("telegram request failed", errors.Params{"code": res.ErrorCode, "description": res.Description}))
+	}
+	return invalid, nil
+}
+
+func messageText(message Message) string {
+	if message.Body == "" {
+		return message.Title
+	}
+	return message.Title + "\n" + message.Body
+}
@@ -0,0 +1,37 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelegram_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/botsecret/sendMessage", r.URL.Path)
+		var msg telegramMessage
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&msg))
+		assert.Equal(t, "Received 1 BNB\nbnb1", msg.Text)
+		switch msg.ChatID {
+		case "1":
+			_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+		case "2":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	telegram := NewTelegram("secret")
+	telegram.BaseUrl = server.URL + "/botsecret"
+	invalid, err := telegram.Send([]string{"1", "2", "3"}, Message{Title: "Received 1 BNB", Body: "bnb1"}, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2", "3"}, invalid)
+}
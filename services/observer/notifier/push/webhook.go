@@ -0,0 +1,167 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	DefaultWebhookRetries = 3
+	DefaultWebhookBackoff = time.Second * 2
+)
+
+type (
+	// Webhook posts the messages as JSON to callback URLs, tokens are the URLs. The failed deliveries
+	// (connection errors, 429 and 5xx) are retried, waiting Backoff and then twice as long each time.
+	Webhook struct {
+		Client  *http.Client
+		Retries int
+		Backoff time.Duration
+		// AllowHTTP calls the plain http URLs too, only the https ones are called otherwise
+		AllowHTTP bool
+		// DropRate is the share of the deliveries dropped without calling the URL, injected
+		// by faults.webhook_drop_rate for the resilience tests of staging
+		DropRate float64
+		// DeadLetters receives the deliveries still failing after the retries, e.g. mq.WebhookDeadLetters
+		DeadLetters DeadLetterPublisher
+	}
+
+	// DeadLetterPublisher queues the dead letters for inspection and redelivery
+	DeadLetterPublisher interface {
+		Publish(body []byte) error
+	}
+
+	// DeadLetter is a webhook delivery given up: the callback, the posted message, the error of the last
+	// attempt and the Unix time it failed
+	DeadLetter struct {
+		Callback string          `json:"callback"`
+		Message  json.RawMessage `json:"message"`
+		Error    string          `json:"error"`
+		Attempts int             `json:"attempts"`
+		FailedAt int64           `json:"failed_at"`
+	}
+
+	webhookMessage struct {
+		Title string            `json:"title"`
+		Body  string            `json:"body"`
+		Data  map[string]string `json:"data,omitempty"`
+		Tx    *types.Tx         `json:"tx,omitempty"`
+	}
+)
+
+func NewWebhook(retries int, backoff time.Duration) *Webhook {
+	return &Webhook{
+		Client:  &http.Client{Timeout: time.Second * 15},
+		Retries: retries,
+		Backoff: backoff,
+	}
+}
+
+// ValidWebhookURL reports whether the URL can be called, an absolute https (or http if allowed) URL
+func ValidWebhookURL(callback string, allowHTTP bool) bool {
+	u, err := url.Parse(callback)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return u.Scheme == "https" || (allowHTTP && u.Scheme == "http")
+}
+
+func (w *Webhook) Send(tokens []string, message Message, ctx context.Context) ([]string, error) {
+	body, err := json.Marshal(webhookMessage{Title: message.Title, Body: message.Body, Data: message.Data, Tx: message.Tx})
+	if err != nil {
+		return nil, err
+	}
+	invalid := make([]string, 0)
+	for _, callback := range tokens {
+		if !ValidWebhookURL(callback, w.AllowHTTP) {
+			invalid = append(invalid, callback)
+			continue
+		}
+		if w.DropRate > 0 && rand.Float64() < w.DropRate {
+			logger.Warn("Injected webhook drop", logger.Params{"provider": "webhook", "callback": callback})
+			continue
+		}
+		valid, attempts, err := w.deliver(callback, body, ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"provider": "webhook"})
+			w.deadLetter(callback, body, attempts, err)
+			continue
+		}
+		if !valid {
+			invalid = append(invalid, callback)
+		}
+	}
+	return invalid, nil
+}
+
+// deliver posts the body until it is accepted, rejected or the retries are exhausted, returning the attempts made
+func (w *Webhook) deliver(callback string, body []byte, ctx context.Context) (bool, int, error) {
+	backoff := w.Backoff
+	for attempt := 0; ; attempt++ {
+		valid, retry, err := w.post(callback, body, ctx)
+		if !retry || attempt >= w.Retries {
+			return valid, attempt + 1, err
+		}
+		select {
+		case <-ctx.Done():
+			return true, attempt + 1, errors.E(ctx.Err(), "webhook delivery cancelled", errors.Params{"attempts": attempt + 1})
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// deadLetter queues the failed delivery when dead letters are enabled
+func (w *Webhook) deadLetter(callback string, body []byte, attempts int, cause error) {
+	if w.DeadLetters == nil {
+		return
+	}
+	raw, err := json.Marshal(DeadLetter{
+		Callback: callback,
+		Message:  body,
+		Error:    cause.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error(err, logger.Params{"provider": "webhook"})
+		return
+	}
+	if err := w.DeadLetters.Publish(raw); err != nil {
+		logger.Error(err, "Failed to queue the webhook dead letter", logger.Params{"provider": "webhook", "callback": callback})
+	}
+}
+
+func (w *Webhook) post(callback string, body []byte, ctx context.Context) (valid, retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, callback, bytes.NewReader(body))
+	if err != nil {
+		return false, false, nil
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := w.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return true, true, errors.E(err, errors.TypePlatformRequest)
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return true, false, nil
+	// Removed callback
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone:
+		return false, false, nil
+	default:
+		raw, _ := ioutil.ReadAll(res.Body)
+		retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+		return true, retry, errors.E("webhook request failed", errors.Params{"status": res.StatusCode, "body": string(raw)})
+	}
+}
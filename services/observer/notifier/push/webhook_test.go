@@ -0,0 +1,123 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestWebhook_Send(t *testing.T) {
+	attempts := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg webhookMessage
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&msg))
+		assert.Equal(t, "Rate alert", msg.Title)
+		assert.Equal(t, "lending_rate", msg.Data["type"])
+		attempts[r.URL.Path]++
+		switch r.URL.Path {
+		case "/ok":
+		case "/flaky":
+			if attempts[r.URL.Path] < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		case "/down":
+			w.WriteHeader(http.StatusBadGateway)
+		case "/bad":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(2, time.Millisecond)
+	webhook.AllowHTTP = true
+	tokens := []string{
+		server.URL + "/ok",
+		server.URL + "/flaky",
+		server.URL + "/down",
+		server.URL + "/bad",
+		server.URL + "/removed",
+		"file:///etc/passwd",
+	}
+	message := Message{Title: "Rate alert", Data: map[string]string{"type": "lending_rate"}}
+	invalid, err := webhook.Send(tokens, message, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{server.URL + "/removed", "file:///etc/passwd"}, invalid)
+	assert.Equal(t, map[string]int{"/ok": 1, "/flaky": 3, "/down": 3, "/bad": 1, "/removed": 1}, attempts)
+}
+
+func TestWebhook_SendDropped(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	webhook := NewWebhook(0, time.Millisecond)
+	webhook.AllowHTTP = true
+	webhook.DropRate = 1
+	invalid, err := webhook.Send([]string{server.URL + "/ok"}, Message{Title: "Rate alert"}, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, invalid)
+	assert.Equal(t, 0, calls)
+}
+
+func TestValidWebhookURL(t *testing.T) {
+	assert.True(t, ValidWebhookURL("https://example.com/hooks/1", false))
+	assert.False(t, ValidWebhookURL("http://example.com/hooks/1", false))
+	assert.True(t, ValidWebhookURL("http://example.com/hooks/1", true))
+	assert.False(t, ValidWebhookURL("/hooks/1", true))
+	assert.False(t, ValidWebhookURL("ftp://example.com", true))
+}
+
+type deadLettersMock struct {
+	letters []DeadLetter
+}
+
+func (m *deadLettersMock) Publish(body []byte) error {
+	var letter DeadLetter
+	if err := json.Unmarshal(body, &letter); err != nil {
+		return err
+	}
+	m.letters = append(m.letters, letter)
+	return nil
+}
+
+func TestWebhook_SendDeadLetters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg webhookMessage
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&msg))
+		if assert.NotNil(t, msg.Tx) {
+			assert.Equal(t, "0x1", msg.Tx.ID)
+		}
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	deadLetters := &deadLettersMock{}
+	webhook := NewWebhook(1, time.Millisecond)
+	webhook.AllowHTTP = true
+	webhook.DeadLetters = deadLetters
+	message := Message{Title: "Received", Tx: &types.Tx{ID: "0x1", Coin: 60, Fee: "1", Type: types.TxTransfer, Meta: types.Transfer{Value: "1"}}}
+	invalid, err := webhook.Send([]string{server.URL + "/ok", server.URL + "/down"}, message, context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, invalid)
+	if assert.Len(t, deadLetters.letters, 1) {
+		letter := deadLetters.letters[0]
+		assert.Equal(t, server.URL+"/down", letter.Callback)
+		assert.Equal(t, 2, letter.Attempts)
+		assert.Contains(t, letter.Error, "webhook request failed")
+		var posted webhookMessage
+		assert.Nil(t, json.Unmarshal(letter.Message, &posted))
+		assert.Equal(t, "Received", posted.Title)
+	}
+}
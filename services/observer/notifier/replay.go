@@ -0,0 +1,124 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/mq"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"go.elastic.co/apm"
+)
+
+const (
+	DefaultHistoryRetention = 72 * time.Hour
+
+	// MaxReplayWindow bounds the time window of a replay request
+	MaxReplayWindow = 7 * 24 * time.Hour
+)
+
+// KeepHistory saves the published notifications for the replays, set when observer.replay is enabled
+var KeepHistory bool
+
+func saveHistory(database *db.Instance, coin uint, address string, notifications []TransactionNotification, ctx context.Context) {
+	records := toNotificationRecords(coin, address, notifications)
+	if len(records) == 0 {
+		return
+	}
+	if err := database.AddNotificationRecords(records, ctx); err != nil {
+		logger.Error(err, logger.Params{"coin": coin, "address": address, "records": len(records)})
+	}
+}
+
+func toNotificationRecords(coin uint, address string, notifications []TransactionNotification) []models.NotificationRecord {
+	records := make([]models.NotificationRecord, 0, len(notifications))
+	for _, n := range notifications {
+		payload, err := json.Marshal(n)
+		if err != nil {
+			logger.Error(err, logger.Params{"tx_id": n.Result.ID})
+			continue
+		}
+		records = append(records, models.NotificationRecord{
+			Coin:      coin,
+			Address:   address,
+			TxID:      n.Result.ID,
+			Direction: string(n.Result.Direction),
+			Date:      n.Result.Date,
+			Payload:   string(payload),
+		})
+	}
+	return records
+}
+
+// RunHistoryCleanup deletes the notification records older than the retention every hour
+func RunHistoryCleanup(database *db.Instance, retention time.Duration, ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Notification history cleanup stopped")
+			return
+		case now := <-ticker.C:
+			if err := database.DeleteNotificationRecords(now.Add(-retention), ctx); err != nil {
+				logger.Error(err, "Failed to delete notification records")
+			}
+		}
+	}
+}
+
+// Replayer publishes the recorded notifications again, for the consumers recovering from an outage
+type Replayer struct {
+	Database *db.Instance
+}
+
+// Replay publishes the recorded notifications of the request to the notifications queue, marked as replays
+func (r Replayer) Replay(req types.ReplayRequest, c context.Context) (int, error) {
+	span, ctx := apm.StartSpan(c, "Replay", "app")
+	defer span.End()
+
+	if req.To < req.From || time.Duration(req.To-req.From)*time.Second > MaxReplayWindow {
+		return 0, errors.E("invalid replay window", errors.Params{"max": MaxReplayWindow.String()})
+	}
+	notifications := make([]TransactionNotification, 0)
+	for coinStr, addresses := range req.Subscriptions {
+		coin, err := strconv.Atoi(coinStr)
+		if err != nil || len(addresses) == 0 {
+			continue
+		}
+		records, err := r.Database.GetNotificationRecords(uint(coin), addresses, req.From, req.To, ctx)
+		if err != nil {
+			return 0, err
+		}
+		notifications = append(notifications, fromNotificationRecords(records)...)
+	}
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+	for _, batch := range getNotificationBatches(notifications, MaxPushNotificationsBatchLimit, ctx) {
+		if err := publishBatch(mq.TxNotifications, batch); err != nil {
+			return 0, err
+		}
+	}
+	logger.Info("Notifications replayed", logger.Params{"notifications": len(notifications), "from": req.From, "to": req.To})
+	return len(notifications), nil
+}
+
+func fromNotificationRecords(records []models.NotificationRecord) []TransactionNotification {
+	notifications := make([]TransactionNotification, 0, len(records))
+	for _, record := range records {
+		var n TransactionNotification
+		if err := json.Unmarshal([]byte(record.Payload), &n); err != nil {
+			logger.Error(err, logger.Params{"coin": record.Coin, "tx_id": record.TxID})
+			continue
+		}
+		n.Replay = true
+		notifications = append(notifications, n)
+	}
+	return notifications
+}
@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func Test_notificationRecords(t *testing.T) {
+	tx := nativeTokenTransfer
+	tx.Type = types.TxNativeTokenTransfer
+	tx.Direction = types.DirectionOutgoing
+	notifications := []TransactionNotification{{Action: types.TxNativeTokenTransfer, Result: tx}}
+	records := toNotificationRecords(tx.Coin, "tbnb1ttyn4csghfgyxreu7lmdu3lcplhqhxtzced45a", notifications)
+	assert.Len(t, records, 1)
+	assert.Equal(t, tx.ID, records[0].TxID)
+	assert.Equal(t, tx.Date, records[0].Date)
+	assert.Equal(t, "outgoing", records[0].Direction)
+
+	replayed := fromNotificationRecords(records)
+	assert.Len(t, replayed, 1)
+	assert.True(t, replayed[0].Replay)
+	assert.Equal(t, notifications[0].Action, replayed[0].Action)
+	assert.Equal(t, tx.ID, replayed[0].Result.ID)
+}
+
+func TestReplayer_Replay_Window(t *testing.T) {
+	_, err := Replayer{}.Replay(types.ReplayRequest{From: 200, To: 100}, context.Background())
+	assert.NotNil(t, err)
+	_, err = Replayer{}.Replay(types.ReplayRequest{From: 0, To: int64(MaxReplayWindow.Seconds()) + 1}, context.Background())
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,294 @@
+package notifier
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/numbers"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	DefaultLocale = "en"
+
+	// defaultEvent is the template of the event types without their own template
+	defaultEvent = "default"
+)
+
+// notifierEvents are the messages of the notifier itself, rendered only from their own templates
+var notifierEvents = map[string]bool{
+	digestEvent:               true,
+	lendingRateEvent:          true,
+	liquidationRiskEvent:      true,
+	subscriptionExpiringEvent: true,
+}
+
+type (
+	// Template is the text of a notification, written with text/template
+	Template struct {
+		Title string `mapstructure:"title"`
+		Body  string `mapstructure:"body"`
+	}
+
+	// Templates holds the compiled templates by locale and event type
+	Templates struct {
+		sync.RWMutex
+		locale    string
+		templates map[string]map[string]*compiledTemplate
+	}
+
+	compiledTemplate struct {
+		title *template.Template
+		body  *template.Template
+	}
+
+	// TemplateData is the data available to the templates
+	TemplateData struct {
+		Coin      string
+		Symbol    string
+		Amount    string
+		Address   string
+		From      string
+		To        string
+		Direction types.Direction
+		Type      types.TransactionType
+		Protocol  string
+		Validator string
+		Network   string
+		TxID      string
+		Memo      string
+		// Summary of the digest notifications
+		Period string
+		Count  int
+		Change string
+		// Percentages of the lending rate alerts
+		APY         string
+		PreviousAPY string
+		// Health factor of the liquidation risk alerts
+		HealthFactor string
+		// Date the subscription of the expiry notices ends
+		ExpiresAt string
+	}
+)
+
+var defaultTemplates = map[string]map[string]Template{
+	"en": {
+		digestEvent: {
+			Title: `Your {{.Period}} {{.Coin}} summary`,
+			Body:  `{{.Count}} transaction{{if ne .Count 1}}s{{end}}{{if .Change}}: {{.Change}}{{end}}`,
+		},
+		lendingRateEvent: {
+			Title: `{{.Symbol}} APY at {{.Protocol}} is now {{.APY}}%`,
+			Body:  `It was {{.PreviousAPY}}%`,
+		},
+		liquidationRiskEvent: {
+			Title: `Your {{.Protocol}} position is at risk of liquidation`,
+			Body:  `Health factor {{.HealthFactor}}, {{.Address}}`,
+		},
+		subscriptionExpiringEvent: {
+			Title: `Your {{.Coin}} notifications end on {{.ExpiresAt}}`,
+			Body:  `Renew the subscription to keep watching {{.Address}}`,
+		},
+		defaultEvent: {
+			Title: `{{if eq .Direction "incoming"}}Received{{else if eq .Direction "yourself"}}Sent to yourself{{else}}Sent{{end}}{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}}`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.TxContractCall): {
+			Title: `{{.Coin}} contract call`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.EventLendingDeposit): {
+			Title: `Deposited{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}} to {{.Protocol}}`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.EventLendingWithdraw): {
+			Title: `Withdrew{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}} from {{.Protocol}}`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.EventRewardClaimed): {
+			Title: `Claimed{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}} staking rewards`,
+			Body:  `{{if .Validator}}Validator {{.Validator}}{{else}}{{.Address}}{{end}}`,
+		},
+		string(types.EventUnbondingComplete): {
+			Title: `{{if .Amount}}{{.Amount}} {{.Symbol}}{{else}}Your {{.Coin}}{{end}} unstaked and available`,
+			Body:  `{{if .Validator}}Validator {{.Validator}}{{else}}{{.Address}}{{end}}`,
+		},
+		string(types.EventBridgeTransfer): {
+			Title: `{{if eq .Direction "incoming"}}Received{{else}}Bridged{{end}}{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}} {{if eq .Direction "incoming"}}from{{else}}to{{end}} {{.Network}}`,
+			Body:  `{{.Protocol}} bridge, {{.Address}}`,
+		},
+	},
+	"es": {
+		digestEvent: {
+			Title: `Tu resumen {{if eq .Period "weekly"}}semanal{{else}}diario{{end}} de {{.Coin}}`,
+			Body:  `{{.Count}} transacci{{if eq .Count 1}}ón{{else}}ones{{end}}{{if .Change}}: {{.Change}}{{end}}`,
+		},
+		lendingRateEvent: {
+			Title: `El APY de {{.Symbol}} en {{.Protocol}} es ahora {{.APY}}%`,
+			Body:  `Antes era {{.PreviousAPY}}%`,
+		},
+		liquidationRiskEvent: {
+			Title: `Tu posición en {{.Protocol}} está en riesgo de liquidación`,
+			Body:  `Factor de salud {{.HealthFactor}}, {{.Address}}`,
+		},
+		subscriptionExpiringEvent: {
+			Title: `Tus notificaciones de {{.Coin}} terminan el {{.ExpiresAt}}`,
+			Body:  `Renueva la suscripción para seguir {{.Address}}`,
+		},
+		defaultEvent: {
+			Title: `{{if eq .Direction "incoming"}}Recibido{{else if eq .Direction "yourself"}}Enviado a ti mismo{{else}}Enviado{{end}}{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}}`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.TxContractCall): {
+			Title: `Llamada a contrato de {{.Coin}}`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.EventLendingDeposit): {
+			Title: `Depositado{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}} en {{.Protocol}}`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.EventLendingWithdraw): {
+			Title: `Retirado{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}} de {{.Protocol}}`,
+			Body:  `{{.Address}}`,
+		},
+		string(types.EventRewardClaimed): {
+			Title: `Recompensas de staking reclamadas{{if .Amount}}: {{.Amount}} {{.Symbol}}{{end}}`,
+			Body:  `{{if .Validator}}Validador {{.Validator}}{{else}}{{.Address}}{{end}}`,
+		},
+		string(types.EventUnbondingComplete): {
+			Title: `{{if .Amount}}{{.Amount}} {{.Symbol}}{{else}}Tus {{.Coin}}{{end}} ya disponibles tras el unstaking`,
+			Body:  `{{if .Validator}}Validador {{.Validator}}{{else}}{{.Address}}{{end}}`,
+		},
+		string(types.EventBridgeTransfer): {
+			Title: `{{if eq .Direction "incoming"}}Recibido{{else}}Enviado{{end}}{{if .Amount}} {{.Amount}} {{.Symbol}}{{end}} {{if eq .Direction "incoming"}}desde{{else}}a{{end}} {{.Network}}`,
+			Body:  `Puente de {{.Protocol}}, {{.Address}}`,
+		},
+	},
+}
+
+// MessageTemplates renders the notification messages, the configured templates are added with AddTemplates
+var MessageTemplates = NewTemplates(DefaultLocale)
+
+func NewTemplates(defaultLocale string) *Templates {
+	t := &Templates{
+		locale:    defaultLocale,
+		templates: make(map[string]map[string]*compiledTemplate),
+	}
+	if err := t.AddTemplates(defaultTemplates); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// AddTemplates compiles templates by locale and event type, replacing the existing ones
+func (t *Templates) AddTemplates(templates map[string]map[string]Template) error {
+	compiled := make(map[string]map[string]*compiledTemplate)
+	for locale, events := range templates {
+		locale = normalizeLocale(locale)
+		compiled[locale] = make(map[string]*compiledTemplate)
+		for event, tmpl := range events {
+			name := locale + "/" + event
+			title, err := template.New(name + "/title").Option("missingkey=zero").Parse(tmpl.Title)
+			if err != nil {
+				return errors.E(err, "invalid title template", errors.Params{"template": name})
+			}
+			body, err := template.New(name + "/body").Option("missingkey=zero").Parse(tmpl.Body)
+			if err != nil {
+				return errors.E(err, "invalid body template", errors.Params{"template": name})
+			}
+			compiled[locale][event] = &compiledTemplate{title: title, body: body}
+		}
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	for locale, events := range compiled {
+		if t.templates[locale] == nil {
+			t.templates[locale] = make(map[string]*compiledTemplate)
+		}
+		for event, tmpl := range events {
+			t.templates[locale][event] = tmpl
+		}
+	}
+	return nil
+}
+
+// Render returns the title and body of the event in the locale.
+// The locale falls back to its language (pt-BR to pt) then to the default locale,
+// and the transaction types without a template of these locales to their default template.
+func (t *Templates) Render(locale string, event types.TransactionType, data TemplateData) (string, string, error) {
+	tmpl := t.lookup(locale, string(event))
+	if tmpl == nil {
+		return "", "", errors.E("no template", errors.Params{"locale": locale, "event": event})
+	}
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, data); err != nil {
+		return "", "", errors.E(err, "failed to render title")
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", errors.E(err, "failed to render body")
+	}
+	return strings.TrimSpace(title.String()), strings.TrimSpace(body.String()), nil
+}
+
+func (t *Templates) lookup(locale, event string) *compiledTemplate {
+	t.RLock()
+	defer t.RUnlock()
+
+	locale = normalizeLocale(locale)
+	candidates := []string{locale}
+	if i := strings.Index(locale, "-"); i > 0 {
+		candidates = append(candidates, locale[:i])
+	}
+	candidates = append(candidates, t.locale)
+	for _, l := range candidates {
+		if tmpl, ok := t.templates[l][event]; ok {
+			return tmpl
+		}
+	}
+	// The alerts, digests and expiry notices are no transactions, the transfer text doesn't fit them
+	if notifierEvents[event] {
+		return nil
+	}
+	for _, l := range candidates {
+		if tmpl, ok := t.templates[l][defaultEvent]; ok {
+			return tmpl
+		}
+	}
+	return nil
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+func newTemplateData(address string, tx types.Tx) TemplateData {
+	data := TemplateData{
+		Address:   address,
+		From:      tx.From,
+		To:        tx.To,
+		Direction: tx.Direction,
+		Type:      tx.Type,
+		TxID:      tx.ID,
+		Memo:      tx.Memo,
+	}
+	if c, ok := coin.Coins[tx.Coin]; ok {
+		data.Coin = c.Name
+	}
+	if value, symbol, ok := txAmount(tx); ok {
+		data.Amount, data.Symbol = value, symbol
+	}
+	if tx.Event != nil {
+		data.Protocol = strings.Title(tx.Event.Protocol)
+		data.Validator = tx.Event.Validator
+		data.Network = strings.Title(tx.Event.Network)
+		if tx.Event.Value != "" {
+			data.Amount, data.Symbol = numbers.ToDecimal(string(tx.Event.Value), int(tx.Event.Decimals)), tx.Event.Symbol
+		}
+	}
+	return data
+}
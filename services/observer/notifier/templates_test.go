@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	templates := NewTemplates("en")
+	assert.Nil(t, templates.AddTemplates(map[string]map[string]Template{
+		"pt": {
+			"default":        {Title: "Transação {{.Amount}} {{.Symbol}}", Body: "{{.Address}}"},
+			"token_transfer": {Title: "Token {{.Symbol}}", Body: "{{.From}} → {{.To}}"},
+		},
+	}))
+	data := TemplateData{Amount: "1.5", Symbol: "BNB", Address: "bnb1", From: "bnb1", To: "bnb2", Direction: types.DirectionIncoming,
+		Coin: "BNB", Protocol: "Aave", APY: "3.1", PreviousAPY: "2.4", HealthFactor: "1.05"}
+
+	tests := []struct {
+		name      string
+		locale    string
+		event     types.TransactionType
+		wantTitle string
+		wantBody  string
+	}{
+		{"default locale", "", types.TxTransfer, "Received 1.5 BNB", "bnb1"},
+		{"unknown locale", "de", types.TxTransfer, "Received 1.5 BNB", "bnb1"},
+		{"built in locale", "es", types.TxTransfer, "Recibido 1.5 BNB", "bnb1"},
+		{"language of region", "pt_BR", types.TxTransfer, "Transação 1.5 BNB", "bnb1"},
+		{"event template", "pt-br", types.TxTokenTransfer, "Token BNB", "bnb1 → bnb2"},
+		{"event template of default locale", "pt", types.TxContractCall, "BNB contract call", "bnb1"},
+		{"lending rate", "pt", lendingRateEvent, "BNB APY at Aave is now 3.1%", "It was 2.4%"},
+		{"liquidation risk", "pt-BR", liquidationRiskEvent, "Your Aave position is at risk of liquidation", "Health factor 1.05, bnb1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body, err := templates.Render(tt.locale, tt.event, data)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantTitle, title)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestTemplates_AddTemplates_Invalid(t *testing.T) {
+	templates := NewTemplates("en")
+	err := templates.AddTemplates(map[string]map[string]Template{"en": {"default": {Title: "{{.Amount"}}})
+	assert.NotNil(t, err)
+
+	title, _, err := templates.Render("en", types.TxTransfer, TemplateData{Direction: types.DirectionOutgoing})
+	assert.Nil(t, err)
+	assert.Equal(t, "Sent", title)
+}
+
+func TestTemplates_Render_NotifierEvent(t *testing.T) {
+	templates := &Templates{locale: "pt", templates: make(map[string]map[string]*compiledTemplate)}
+	assert.Nil(t, templates.AddTemplates(map[string]map[string]Template{"pt": {"default": {Title: "Transação"}}}))
+
+	title, _, err := templates.Render("pt", types.TxTransfer, TemplateData{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Transação", title)
+	_, _, err = templates.Render("pt", digestEvent, TemplateData{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/mq"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/services/observer/tenant"
+)
+
+var (
+	// Tenants are the quotas of the tenants, set from observer.tenants
+	Tenants *tenant.Registry
+
+	// RecordUsage saves the notifications of the tenants for the quota usage, set out of the memory mode
+	RecordUsage bool
+
+	limiter = tenant.NewLimiter()
+)
+
+// NotificationsQueue returns the queue of the notifications of the tenant, each tenant consumes its own
+func NotificationsQueue(name string) mq.Queue {
+	if name == "" {
+		return mq.TxNotifications
+	}
+	return mq.TxNotifications + "." + mq.Queue(name)
+}
+
+// publishTenantNotifications publishes the notifications allowed by the rate limit of the tenant to its queue,
+// the others are dropped
+func publishTenantNotifications(database *db.Instance, name string, notifications []TransactionNotification, ctx context.Context) {
+	quota := Tenants.Get(name).Quota
+	allowed := limiter.Allow(name, quota.MaxNotificationsPerMinute, len(notifications))
+	throttled := len(notifications) - allowed
+	if throttled > 0 {
+		logger.Info("Notifications rate limit exceeded", logger.Params{
+			"tenant": name, "max_notifications_per_minute": quota.MaxNotificationsPerMinute, "dropped": throttled,
+		})
+	}
+
+	queue := NotificationsQueue(name)
+	for _, batch := range getNotificationBatches(notifications[:allowed], MaxPushNotificationsBatchLimit, ctx) {
+		publishNotificationBatch(queue, batch, ctx)
+	}
+
+	if RecordUsage && name != "" {
+		day := time.Now().UTC().Format("2006-01-02")
+		if err := database.AddTenantUsage(name, day, int64(allowed), int64(throttled), ctx); err != nil {
+			logger.Error(err, logger.Params{"tenant": name})
+		}
+	}
+}
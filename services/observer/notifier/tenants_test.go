@@ -0,0 +1,13 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/mq"
+)
+
+func TestNotificationsQueue(t *testing.T) {
+	assert.Equal(t, mq.TxNotifications, NotificationsQueue(""))
+	assert.Equal(t, mq.Queue("txNotifications.exchange"), NotificationsQueue("exchange"))
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"go.elastic.co/apm"
+)
+
+// Bridge is a contract moving the funds of its users to another network
+type Bridge struct {
+	Protocol string `mapstructure:"protocol"`
+	Network  string `mapstructure:"network"`
+}
+
+var bridgeContracts = struct {
+	sync.RWMutex
+	// coin -> lower case contract address -> bridge
+	m map[uint]map[string]Bridge
+}{m: map[uint]map[string]Bridge{
+	coin.ETH: {
+		"0xa0c68c638235ee32657e8f720a23cec1bfc77c77": {Protocol: "polygon", Network: "polygon"},   // PoS RootChainManager
+		"0x40ec5b33f54e0e8a33a975908c5ba1c14e5bbbdf": {Protocol: "polygon", Network: "polygon"},   // PoS ERC20Predicate
+		"0x8484ef722627bf18ca5ae6bcf031c23e6e922b30": {Protocol: "polygon", Network: "polygon"},   // PoS EtherPredicate
+		"0x4dbd4fc535ac27206064b68ffcf827b0a60bab3f": {Protocol: "arbitrum", Network: "arbitrum"}, // Delayed Inbox
+		"0xa3a7b6f88361f48403514059f1f16c8e78d60eec": {Protocol: "arbitrum", Network: "arbitrum"}, // L1 ERC20 Gateway
+		"0x3ee18b2214aaf2f8fa59e6b65d7f1f9e4a9b1cdc": {Protocol: "wormhole", Network: "solana"},   // Token Bridge
+	},
+	coin.SOL: {
+		"wormdtuj6awpnvk59vgqbdvgjmqbdtdgwgaqclbcgub": {Protocol: "wormhole", Network: "ethereum"}, // Token Bridge
+	},
+}}
+
+// AddBridgeContracts registers more bridge contracts of the coin, by address
+func AddBridgeContracts(coinID uint, contracts map[string]Bridge) {
+	bridgeContracts.Lock()
+	defer bridgeContracts.Unlock()
+	if bridgeContracts.m[coinID] == nil {
+		bridgeContracts.m[coinID] = make(map[string]Bridge)
+	}
+	for address, bridge := range contracts {
+		bridgeContracts.m[coinID][strings.ToLower(address)] = bridge
+	}
+}
+
+func bridgeContract(coinID uint, address string) (Bridge, bool) {
+	bridgeContracts.RLock()
+	defer bridgeContracts.RUnlock()
+	bridge, ok := bridgeContracts.m[coinID][strings.ToLower(address)]
+	return bridge, ok
+}
+
+// detectBridgeEvent recognizes the funds sent to a bridge contract, and the funds
+// released by it when they come back from the other network
+func detectBridgeEvent(tx *types.Tx) *types.TxEvent {
+	switch meta := tx.Meta.(type) {
+	case types.ContractCall:
+		return bridgeCallEvent(tx, meta)
+	case *types.ContractCall:
+		return bridgeCallEvent(tx, *meta)
+	case types.Transfer:
+		return bridgeTransferEvent(tx.Coin, tx.From, tx.To, meta.Value, meta.Symbol, meta.Decimals)
+	case *types.Transfer:
+		return bridgeTransferEvent(tx.Coin, tx.From, tx.To, meta.Value, meta.Symbol, meta.Decimals)
+	case types.TokenTransfer:
+		return bridgeTransferEvent(tx.Coin, meta.From, meta.To, meta.Value, meta.Symbol, meta.Decimals)
+	case *types.TokenTransfer:
+		return bridgeTransferEvent(tx.Coin, meta.From, meta.To, meta.Value, meta.Symbol, meta.Decimals)
+	default:
+		return nil
+	}
+}
+
+func bridgeCallEvent(tx *types.Tx, call types.ContractCall) *types.TxEvent {
+	bridge, ok := bridgeContract(tx.Coin, tx.To)
+	if !ok {
+		return nil
+	}
+	event := &types.TxEvent{Type: types.EventBridgeTransfer, Protocol: bridge.Protocol, Network: bridge.Network}
+	if call.Value != "" && call.Value != "0" {
+		if c, ok := coin.Coins[tx.Coin]; ok {
+			event.Value, event.Symbol, event.Decimals = types.Amount(call.Value), c.Symbol, c.Decimals
+		}
+	}
+	return event
+}
+
+func bridgeTransferEvent(coinID uint, from, to string, value types.Amount, symbol string, decimals uint) *types.TxEvent {
+	bridge, ok := bridgeContract(coinID, to)
+	if !ok {
+		if bridge, ok = bridgeContract(coinID, from); !ok {
+			return nil
+		}
+	}
+	return &types.TxEvent{
+		Type:     types.EventBridgeTransfer,
+		Protocol: bridge.Protocol,
+		Network:  bridge.Network,
+		Value:    value,
+		Symbol:   symbol,
+		Decimals: decimals,
+	}
+}
+
+// CorrelateBridgeTransfers saves the side of the bridge transfers sent or delivered by the transactions,
+// keyed by the message of the bridge, and sets the transfer on their event. The event of the side parsed
+// last links both transactions, once the parsers of both networks saw the message.
+func CorrelateBridgeTransfers(params Params, txs types.Txs, ctx context.Context) {
+	api, ok := params.Api.(blockatlas.BridgeMessageAPI)
+	if !ok {
+		return
+	}
+	span, ctx := apm.StartSpan(ctx, "CorrelateBridgeTransfers", "app")
+	defer span.End()
+
+	for i := range txs {
+		tx := &txs[i]
+		if tx.Event == nil || tx.Event.Type != types.EventBridgeTransfer {
+			continue
+		}
+		message, err := api.GetBridgeMessage(*tx)
+		if err != nil {
+			logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle, "tx": tx.ID})
+			continue
+		}
+		if message == nil {
+			continue
+		}
+		transfer, err := params.Database.SaveBridgeTransfer(bridgeTransferSide(*tx, *message), ctx)
+		if err != nil {
+			logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle, "tx": tx.ID})
+			continue
+		}
+		tx.Event.Transfer = &transfer
+	}
+}
+
+func bridgeTransferSide(tx types.Tx, message blockatlas.BridgeMessage) models.BridgeTransfer {
+	transfer := models.BridgeTransfer{MessageID: message.ID, Protocol: message.Protocol}
+	if message.Delivered {
+		transfer.DestinationCoin, transfer.DestinationTxID = tx.Coin, tx.ID
+	} else {
+		transfer.SourceCoin, transfer.SourceTxID = tx.Coin, tx.ID
+	}
+	return transfer
+}
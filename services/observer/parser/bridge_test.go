@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestDetectEvents_Bridge(t *testing.T) {
+	const (
+		user             = "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1"
+		rootChainManager = "0xA0c68C638235ee32657e8f720a23ceC1bFc77C77"
+		erc20Predicate   = "0x40ec5B33f54e0E8A33A975908C5BA1c14e5BbbDf"
+		wormhole         = "0x3ee18B2214AAF2F8Fa59E6B65D7F1f9E4A9b1cDc"
+		usdc             = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+		optimism         = "0x99C9fc46f92E8a1c0deC1b1747d010903E884bE1"
+	)
+	AddBridgeContracts(coin.ETH, map[string]Bridge{optimism: {Protocol: "optimism", Network: "optimism"}})
+
+	txs := types.Txs{
+		{ID: "deposit ether", Coin: coin.ETH, From: user, To: rootChainManager, Meta: types.ContractCall{Input: "0x4faa8a26", Value: "1000000000000000000"}},
+		{ID: "deposit token", Coin: coin.ETH, From: user, To: usdc, Meta: types.TokenTransfer{TokenID: usdc, Symbol: "USDC", Decimals: 6, Value: "5000000", From: user, To: erc20Predicate}},
+		{ID: "exit", Coin: coin.ETH, From: user, To: usdc, Meta: &types.TokenTransfer{TokenID: usdc, Symbol: "USDC", Decimals: 6, Value: "7", From: wormhole, To: user}},
+		{ID: "configured", Coin: coin.ETH, From: user, To: optimism, Meta: types.Transfer{Value: "2", Symbol: "ETH", Decimals: 18}},
+		{ID: "transfer", Coin: coin.ETH, From: user, To: usdc, Meta: types.Transfer{Value: "1"}},
+		{ID: "solana", Coin: coin.SOL, From: "boot1Z6jb15CLqpaMTn2CxktktwZpRAVAgHZEW6SxQ7", To: "wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb", Meta: types.ContractCall{Input: "3DTZbgwsozUF", Value: "0"}},
+	}
+	DetectEvents(txs)
+
+	assert.Equal(t, &types.TxEvent{Type: types.EventBridgeTransfer, Protocol: "polygon", Network: "polygon", Value: "1000000000000000000", Symbol: "ETH", Decimals: 18}, txs[0].Event)
+	assert.Equal(t, &types.TxEvent{Type: types.EventBridgeTransfer, Protocol: "polygon", Network: "polygon", Value: "5000000", Symbol: "USDC", Decimals: 6}, txs[1].Event)
+	assert.Equal(t, "solana", txs[2].Event.Network)
+	assert.Equal(t, "optimism", txs[3].Event.Protocol)
+	assert.Nil(t, txs[4].Event)
+	assert.Equal(t, &types.TxEvent{Type: types.EventBridgeTransfer, Protocol: "wormhole", Network: "ethereum"}, txs[5].Event)
+}
+
+// bridgePlatform returns the Wormhole messages of the transactions by id
+type bridgePlatform struct {
+	Platform
+	messages map[string]*blockatlas.BridgeMessage
+}
+
+func (p *bridgePlatform) GetBridgeMessage(tx types.Tx) (*blockatlas.BridgeMessage, error) {
+	return p.messages[tx.ID], nil
+}
+
+func TestCorrelateBridgeTransfers(t *testing.T) {
+	const messageID = "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/74165"
+	sqlDB, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	gormDB, err := gorm.Open("postgres", sqlDB)
+	assert.Nil(t, err)
+	defer gormDB.Close()
+
+	// The Solana parser saved the source, the Ethereum one delivers the message
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bridge_transfers`)).
+		WithArgs(messageID, "wormhole", 0, "", coin.ETH, "0xcomplete", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "protocol", "source_coin", "source_tx_id", "destination_coin", "destination_tx_id"}).
+			AddRow(messageID, "wormhole", coin.SOL, "sent", coin.ETH, "0xcomplete"))
+
+	wormhole := "0x3ee18B2214AAF2F8Fa59E6B65D7F1f9E4A9b1cDc"
+	txs := types.Txs{
+		{ID: "0xcomplete", Coin: coin.ETH, From: "0xuser", To: wormhole, Meta: types.ContractCall{Input: "0xc6878519", Value: "0"}},
+		{ID: "0xpending", Coin: coin.ETH, From: "0xuser", To: wormhole, Meta: types.ContractCall{Input: "0x0f5287b0", Value: "0"}},
+		{ID: "0xtransfer", Coin: coin.ETH, From: "0xuser", To: "0xother", Meta: types.Transfer{Value: "1"}},
+	}
+	DetectEvents(txs)
+	params := Params{
+		Api: &bridgePlatform{Platform: Platform{CoinIndex: coin.ETH}, messages: map[string]*blockatlas.BridgeMessage{
+			"0xcomplete": {Protocol: "wormhole", ID: messageID, Delivered: true},
+		}},
+		Database: &db.Instance{Gorm: gormDB},
+	}
+	CorrelateBridgeTransfers(params, txs, context.Background())
+
+	assert.Equal(t, &types.BridgeTransfer{
+		Protocol:    "wormhole",
+		MessageID:   messageID,
+		Source:      &types.BridgeLeg{Coin: coin.SOL, TxID: "sent"},
+		Destination: &types.BridgeLeg{Coin: coin.ETH, TxID: "0xcomplete"},
+	}, txs[0].Event.Transfer)
+	assert.Nil(t, txs[1].Event.Transfer)
+	assert.Nil(t, txs[2].Event)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBridgeTransferSide(t *testing.T) {
+	tx := types.Tx{ID: "sent", Coin: coin.SOL}
+	assert.Equal(t, "sent", bridgeTransferSide(tx, blockatlas.BridgeMessage{Protocol: "wormhole", ID: "1/00/1"}).SourceTxID)
+	delivered := bridgeTransferSide(tx, blockatlas.BridgeMessage{Protocol: "wormhole", ID: "1/00/1", Delivered: true})
+	assert.Equal(t, uint(coin.SOL), delivered.DestinationCoin)
+	assert.Empty(t, delivered.SourceTxID)
+}
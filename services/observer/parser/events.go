@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+// eventDetectors recognize the event of a transaction, the first match wins
+var eventDetectors = []func(tx *types.Tx) *types.TxEvent{
+	detectLendingEvent,
+	detectStakingEvent,
+	detectBridgeEvent,
+}
+
+// DetectEvents sets the event of the transactions matching a known protocol
+func DetectEvents(txs types.Txs) {
+	for i := range txs {
+		if txs[i].Event != nil {
+			continue
+		}
+		for _, detect := range eventDetectors {
+			if event := detect(&txs[i]); event != nil {
+				txs[i].Event = event
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"time"
+)
+
+// blockTimeSmoothing is the weight of the last observed block time in the estimate
+const blockTimeSmoothing = 0.3
+
+// adaptiveInterval tunes the polling interval of a coin between its bounds: it polls at
+// the observed block time at the chain head, as fast as allowed while behind (several
+// blocks found by a step) and backs off when a step finds no new block
+type adaptiveInterval struct {
+	min, max  time.Duration
+	blockTime time.Duration
+	current   time.Duration
+	head      int64
+	headAt    time.Time
+}
+
+func newAdaptiveInterval(blockTime, min, max time.Duration) *adaptiveInterval {
+	a := &adaptiveInterval{min: min, max: max, blockTime: blockTime}
+	a.current = a.clamp(blockTime)
+	return a
+}
+
+// next returns the time to wait after a parse step which saw the chain head and fetched newBlocks
+func (a *adaptiveInterval) next(head, newBlocks int64, now time.Time) time.Duration {
+	if head > a.head {
+		if a.head > 0 {
+			observed := now.Sub(a.headAt) / time.Duration(head-a.head)
+			if a.blockTime <= 0 {
+				a.blockTime = observed
+			} else {
+				a.blockTime = time.Duration(blockTimeSmoothing*float64(observed) + (1-blockTimeSmoothing)*float64(a.blockTime))
+			}
+		}
+		a.head = head
+		a.headAt = now
+	}
+
+	switch {
+	case newBlocks > 1:
+		a.current = a.min
+	case newBlocks == 1:
+		a.current = a.clamp(a.blockTime)
+	default:
+		a.current = a.clamp(a.current + a.current/2)
+	}
+	return a.current
+}
+
+func (a *adaptiveInterval) clamp(d time.Duration) time.Duration {
+	if d < a.min {
+		return a.min
+	}
+	if d > a.max {
+		return a.max
+	}
+	return d
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	a := newAdaptiveInterval(time.Second*10, time.Second*2, time.Minute)
+	assert.Equal(t, time.Second*10, a.current)
+	now := time.Unix(1600000000, 0)
+
+	// Behind the head: catch up as fast as allowed
+	assert.Equal(t, time.Second*2, a.next(100, 50, now))
+
+	// At the head the interval follows the observed block time (20s), smoothed
+	now = now.Add(time.Second * 40)
+	assert.Equal(t, time.Second*13, a.next(102, 1, now))
+	now = now.Add(time.Second * 20)
+	assert.Equal(t, time.Duration(15.1*float64(time.Second)), a.next(103, 1, now))
+
+	// No new block, relax up to the maximum
+	interval := a.next(103, 0, now)
+	assert.True(t, interval > time.Second*15)
+	for i := 0; i < 20; i++ {
+		interval = a.next(103, 0, now)
+	}
+	assert.Equal(t, time.Minute, interval)
+}
+
+func TestAdaptiveInterval_UnknownBlockTime(t *testing.T) {
+	a := newAdaptiveInterval(0, time.Second*3, time.Second*30)
+	assert.Equal(t, time.Second*3, a.current)
+	now := time.Unix(1600000000, 0)
+	a.next(10, 1, now)
+	assert.Equal(t, time.Second*6, a.next(11, 1, now.Add(time.Second*6)))
+}
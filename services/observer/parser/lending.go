@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// Method ids of the lending calls sent to the protocol contracts
+var (
+	lendingDepositMethods = map[string]bool{
+		"0x1249c58b": true, // Compound mint()
+		"0xa0712d68": true, // Compound mint(uint256)
+		"0xe8eda9df": true, // Aave v2 deposit(address,uint256,address,uint16)
+	}
+	lendingWithdrawMethods = map[string]bool{
+		"0xdb006a75": true, // Compound redeem(uint256)
+		"0x852a12e3": true, // Compound redeemUnderlying(uint256)
+		"0x69328dec": true, // Aave v2 withdraw(address,uint256,address)
+	}
+)
+
+var lendingContracts = struct {
+	sync.RWMutex
+	// coin -> lower case contract address -> protocol
+	m map[uint]map[string]string
+}{m: map[uint]map[string]string{
+	coin.ETH: {
+		"0x4ddc2d193948926d02f9b1fe9e1daa0718270ed5": "compound", // cETH
+		"0x5d3a536e4d6dbd6114cc1ead35777bab948e3643": "compound", // cDAI
+		"0x39aa39c021dfbae8fac545936693ac917d5e7563": "compound", // cUSDC
+		"0xf650c3d88d12db855b8bf7d11be6c55a4e07dcc9": "compound", // cUSDT
+		"0x7d2768de32b0b80b7a3454c06bdac94a69ddc7a9": "aave",     // LendingPool v2
+		"0x028171bca77440897b824ca71d1c56cac55b68a3": "aave",     // aDAI v2
+		"0xbcca60bb61934080951369a648fb03df4f96263c": "aave",     // aUSDC v2
+	},
+}}
+
+// AddLendingContracts registers more lending protocol contracts of the coin, by address
+func AddLendingContracts(coinID uint, contracts map[string]string) {
+	lendingContracts.Lock()
+	defer lendingContracts.Unlock()
+	if lendingContracts.m[coinID] == nil {
+		lendingContracts.m[coinID] = make(map[string]string)
+	}
+	for address, protocol := range contracts {
+		lendingContracts.m[coinID][strings.ToLower(address)] = protocol
+	}
+}
+
+func lendingProtocol(coinID uint, address string) (string, bool) {
+	lendingContracts.RLock()
+	defer lendingContracts.RUnlock()
+	protocol, ok := lendingContracts.m[coinID][strings.ToLower(address)]
+	return protocol, ok
+}
+
+// detectLendingEvent recognizes the calls to the deposit and withdraw methods of a lending contract,
+// and the receipt tokens it mints (deposit) or burns (withdraw)
+func detectLendingEvent(tx *types.Tx) *types.TxEvent {
+	switch meta := tx.Meta.(type) {
+	case types.ContractCall:
+		return lendingCallEvent(tx, meta)
+	case *types.ContractCall:
+		return lendingCallEvent(tx, *meta)
+	case types.TokenTransfer:
+		return lendingTransferEvent(tx.Coin, meta)
+	case *types.TokenTransfer:
+		return lendingTransferEvent(tx.Coin, *meta)
+	default:
+		return nil
+	}
+}
+
+func lendingCallEvent(tx *types.Tx, call types.ContractCall) *types.TxEvent {
+	protocol, ok := lendingProtocol(tx.Coin, tx.To)
+	if !ok || len(call.Input) < 10 {
+		return nil
+	}
+	method := strings.ToLower(call.Input[:10])
+	event := &types.TxEvent{Protocol: protocol}
+	switch {
+	case lendingDepositMethods[method]:
+		event.Type = types.EventLendingDeposit
+	case lendingWithdrawMethods[method]:
+		event.Type = types.EventLendingWithdraw
+	default:
+		return nil
+	}
+	// The native currency sent along the call, e.g. to cETH
+	if call.Value != "" && call.Value != "0" {
+		if c, ok := coin.Coins[tx.Coin]; ok {
+			event.Value, event.Symbol, event.Decimals = types.Amount(call.Value), c.Symbol, c.Decimals
+		}
+	}
+	return event
+}
+
+// lendingTransferEvent recognizes the receipt tokens (cDAI, aDAI) minted to the user on a deposit and burned
+// on a withdraw. The underlying transfers to and from the contracts are also borrows and repays, they are no event.
+func lendingTransferEvent(coinID uint, transfer types.TokenTransfer) *types.TxEvent {
+	protocol, ok := lendingProtocol(coinID, transfer.TokenID)
+	if !ok {
+		return nil
+	}
+	event := &types.TxEvent{Protocol: protocol, Value: transfer.Value, Symbol: transfer.Symbol, Decimals: transfer.Decimals}
+	switch {
+	case isMintOrBurnAccount(transfer.From, transfer.TokenID):
+		event.Type = types.EventLendingDeposit
+	case isMintOrBurnAccount(transfer.To, transfer.TokenID):
+		event.Type = types.EventLendingWithdraw
+	default:
+		return nil
+	}
+	return event
+}
+
+// isMintOrBurnAccount tells whether the receipt tokens are minted from or burned to the address:
+// the zero address for Aave, the cToken contract itself for Compound
+func isMintOrBurnAccount(address, token string) bool {
+	return address == zeroAddress || strings.EqualFold(address, token)
+}
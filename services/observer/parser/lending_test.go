@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestDetectEvents_Lending(t *testing.T) {
+	const (
+		user = "0x7d8bf18C7cE84b3E175b339c4Ca93aEd1dD166F1"
+		cETH = "0x4Ddc2D193948926D02f9B1fE9e1daa0718270ED5"
+		cDAI = "0x5d3a536E4D6DbD6114cc1Ead35777bAB948E3643"
+		aDAI = "0x028171bCA77440897B824Ca71D1c56caC55b68A3"
+		dai  = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+		zero = "0x0000000000000000000000000000000000000000"
+	)
+	txs := types.Txs{
+		{ID: "mint", Coin: coin.ETH, From: user, To: cETH, Meta: types.ContractCall{Input: "0x1249c58b", Value: "1000000000000000000"}},
+		{ID: "redeem", Coin: coin.ETH, From: user, To: cDAI, Meta: types.ContractCall{Input: "0xdb006a750000000000000000000000000000000000000000000000000000000000000001", Value: "0"}},
+		{ID: "compound deposit", Coin: coin.ETH, From: user, To: cDAI, Meta: types.TokenTransfer{TokenID: cDAI, Symbol: "cDAI", Decimals: 8, Value: "5", From: cDAI, To: user}},
+		{ID: "compound withdraw", Coin: coin.ETH, From: user, To: cDAI, Meta: &types.TokenTransfer{TokenID: cDAI, Symbol: "cDAI", Decimals: 8, Value: "6", From: user, To: cDAI}},
+		{ID: "aave deposit", Coin: coin.ETH, From: user, To: aDAI, Meta: types.TokenTransfer{TokenID: aDAI, Symbol: "aDAI", Decimals: 18, Value: "7", From: zero, To: user}},
+		{ID: "aave withdraw", Coin: coin.ETH, From: user, To: aDAI, Meta: types.TokenTransfer{TokenID: aDAI, Symbol: "aDAI", Decimals: 18, Value: "8", From: user, To: zero}},
+		{ID: "borrow", Coin: coin.ETH, From: user, To: cDAI, Meta: types.TokenTransfer{TokenID: dai, Symbol: "DAI", Decimals: 18, Value: "9", From: cDAI, To: user}},
+		{ID: "repay", Coin: coin.ETH, From: user, To: cDAI, Meta: types.TokenTransfer{TokenID: dai, Symbol: "DAI", Decimals: 18, Value: "10", From: user, To: cDAI}},
+		{ID: "receipt transfer", Coin: coin.ETH, From: user, To: cDAI, Meta: types.TokenTransfer{TokenID: cDAI, Symbol: "cDAI", Value: "11", From: user, To: dai}},
+		{ID: "borrow call", Coin: coin.ETH, From: user, To: cDAI, Meta: types.ContractCall{Input: "0xc5ebeaec", Value: "0"}},
+		{ID: "transfer", Coin: coin.ETH, From: user, To: cETH, Meta: types.Transfer{Value: "1"}},
+		{ID: "other chain", Coin: coin.ETC, From: user, To: cETH, Meta: types.ContractCall{Input: "0x1249c58b"}},
+	}
+	DetectEvents(txs)
+
+	assert.Equal(t, &types.TxEvent{Type: types.EventLendingDeposit, Protocol: "compound", Value: "1000000000000000000", Symbol: "ETH", Decimals: 18}, txs[0].Event)
+	assert.Equal(t, &types.TxEvent{Type: types.EventLendingWithdraw, Protocol: "compound"}, txs[1].Event)
+	assert.Equal(t, &types.TxEvent{Type: types.EventLendingDeposit, Protocol: "compound", Value: "5", Symbol: "cDAI", Decimals: 8}, txs[2].Event)
+	assert.Equal(t, &types.TxEvent{Type: types.EventLendingWithdraw, Protocol: "compound", Value: "6", Symbol: "cDAI", Decimals: 8}, txs[3].Event)
+	assert.Equal(t, &types.TxEvent{Type: types.EventLendingDeposit, Protocol: "aave", Value: "7", Symbol: "aDAI", Decimals: 18}, txs[4].Event)
+	assert.Equal(t, &types.TxEvent{Type: types.EventLendingWithdraw, Protocol: "aave", Value: "8", Symbol: "aDAI", Decimals: 18}, txs[5].Event)
+	for _, tx := range txs[6:] {
+		assert.Nil(t, tx.Event, tx.ID)
+	}
+}
+
+func TestAddLendingContracts(t *testing.T) {
+	AddLendingContracts(coin.ETC, map[string]string{"0xA07c5b74C9B40447a954e1466938b865b6BBea36": "ethercompound"})
+	protocol, ok := lendingProtocol(coin.ETC, "0xa07c5b74c9b40447a954e1466938b865b6bbea36")
+	assert.True(t, ok)
+	assert.Equal(t, "ethercompound", protocol)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/errors"
 	"github.com/trustwallet/blockatlas/pkg/numbers"
+	"github.com/trustwallet/blockatlas/pkg/types"
 	"go.elastic.co/apm"
 	"sync/atomic"
 
@@ -30,6 +31,17 @@ type (
 		StopChannel                               chan<- struct{}
 		TxBatchLimit                              uint
 		Database                                  *db.Instance
+		// MinInterval and MaxInterval bound the adaptive polling interval,
+		// ParsingBlocksInterval is used between every step when they are not set
+		MinInterval, MaxInterval time.Duration
+		// Feed also gets the batches when set, for the long-polling API instances
+		Feed mq.Exchange
+		// Heads wakes the parser up before the end of the interval when set, with the new blocks
+		// of a node subscription
+		Heads <-chan int64
+		// ReorgDepth is the number of parsed blocks fetched again with the new ones, parsed again when
+		// their hash changed. 0 disables the detection of the reorganizations.
+		ReorgDepth int64
 	}
 
 	GetBlockByNumber func(num int64) (*blockatlas.Block, error)
@@ -46,8 +58,19 @@ type (
 
 const MinTxsBatchLimit = 500
 
+// publishAttempts and publishRetryDelay cover a restore of the RabbitMQ connection, the batch is lost after them
+const (
+	publishAttempts   = 3
+	publishRetryDelay = time.Second * 10
+)
+
 func RunParser(params Params) {
 	logger.Info("------------------------------------------------------------")
+	var interval *adaptiveInterval
+	if params.MaxInterval > 0 {
+		blockTime := time.Duration(params.Api.Coin().BlockTime) * time.Millisecond
+		interval = newAdaptiveInterval(blockTime, params.MinInterval, params.MaxInterval)
+	}
 	for {
 		select {
 		case <-params.Ctx.Done():
@@ -55,9 +78,13 @@ func RunParser(params Params) {
 			params.StopChannel <- struct{}{}
 			return
 		default:
-			parse(params)
-			logger.Info("Sleep ...", logger.Params{"interval": params.ParsingBlocksInterval.String()})
-			time.Sleep(params.ParsingBlocksInterval)
+			head, newBlocks := parse(params)
+			sleep := params.ParsingBlocksInterval
+			if interval != nil && head > 0 {
+				sleep = interval.next(head, newBlocks, time.Now())
+			}
+			logger.Info("Sleep ...", logger.Params{"interval": sleep.String(), "new_blocks": newBlocks})
+			wait(params.Heads, sleep)
 			logger.Info("Leaving select")
 		}
 		logger.Info("Going to the next  cycle... ")
@@ -65,6 +92,23 @@ func RunParser(params Params) {
 	}
 }
 
+// wait sleeps for the interval, or until the next head when the subscription is connected
+func wait(heads <-chan int64, interval time.Duration) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	if heads == nil {
+		<-timer.C
+		return
+	}
+	select {
+	case _, ok := <-heads:
+		if !ok {
+			<-timer.C
+		}
+	case <-timer.C:
+	}
+}
+
 func GetInterval(value int, minInterval, maxInterval time.Duration) time.Duration {
 	interval := time.Duration(value) * time.Millisecond
 	pMin := numbers.Max(minInterval.Nanoseconds(), interval.Nanoseconds())
@@ -72,7 +116,8 @@ func GetInterval(value int, minInterval, maxInterval time.Duration) time.Duratio
 	return time.Duration(pMax)
 }
 
-func parse(params Params) {
+// parse runs a step and returns the chain head with the number of blocks it fetched, 0 on failure
+func parse(params Params) (int64, int64) {
 	tx := apm.DefaultTracer.StartTransaction("parse", "app")
 	defer tx.End()
 
@@ -82,22 +127,37 @@ func parse(params Params) {
 	if err != nil || lastParsedBlock > currentBlock {
 		logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
 		time.Sleep(params.ParsingBlocksInterval)
-		return
+		return 0, 0
 	}
 
-	blocks := FetchBlocks(params, lastParsedBlock, currentBlock, ctx)
+	from := lastParsedBlock
+	if lastParsedBlock < currentBlock {
+		from = rescanFrom(params, lastParsedBlock)
+	}
+	blocks := FetchBlocks(params, from, currentBlock, ctx)
 
-	err = SaveLastParsedBlock(params, blocks, ctx)
+	err = SaveLastParsedBlock(params, blocksAfter(blocks, lastParsedBlock), ctx)
 	if err != nil {
 		logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
 		time.Sleep(params.ParsingBlocksInterval)
-		return
+		return 0, 0
 	}
+	blocks = DetectReorgs(params, blocks, lastParsedBlock, ctx)
 
 	txs := ConvertToBatch(blocks, ctx)
-	PublishTransactionsBatch(params, txs, ctx)
+	DetectEvents(txs)
+	CorrelateBridgeTransfers(params, txs, ctx)
+	unbondings := TrackUnbondings(params, txs, ctx)
+	txs = append(txs, unbondingCompleteTxs(unbondings)...)
+	if err := PublishTransactionsBatch(params, txs, ctx); err != nil {
+		// The completed unbondings stay pending, their events are published again on the next step
+		logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
+	} else if err := params.Database.DeletePendingUnbondings(unbondings, ctx); err != nil {
+		logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
+	}
 
 	logger.Info("End of parse step")
+	return currentBlock, int64(len(blocks))
 }
 
 func GetBlocksIntervalToFetch(params Params, ctx context.Context) (int64, int64, error) {
@@ -252,21 +312,29 @@ func ConvertToBatch(blocks []blockatlas.Block, ctx context.Context) blockatlas.T
 	return txsBatch.Txs
 }
 
-func PublishTransactionsBatch(params Params, txs blockatlas.Txs, ctx context.Context) {
+// PublishTransactionsBatch publishes the txs in batches of TxBatchLimit, the error of the first batch lost
+func PublishTransactionsBatch(params Params, txs types.Txs, ctx context.Context) error {
 	span, ctx := apm.StartSpan(ctx, "PublishTransactionsBatch", "app")
 	defer span.End()
 
 	if len(txs) == 0 {
-		return
+		return nil
 	}
 
 	batches := getTxsBatches(txs, params.TxBatchLimit, ctx)
 
+	var lost error
 	for _, batch := range batches {
-		publish(params, batch, ctx)
+		if err := publish(params, batch, ctx); err != nil && lost == nil {
+			lost = err
+		}
+	}
+	if lost != nil {
+		return lost
 	}
 
 	logger.Info("Published transactions batch", logger.Params{"txs": len(txs), "batchCount": len(batches)})
+	return nil
 }
 
 func getTxsBatches(txs blockatlas.Txs, sizeUint uint, ctx context.Context) []blockatlas.Txs {
@@ -287,20 +355,32 @@ func getTxsBatches(txs blockatlas.Txs, sizeUint uint, ctx context.Context) []blo
 	return result
 }
 
-func publish(params Params, txs blockatlas.Txs, ctx context.Context) {
+// publish sends the batch to the queue, an error once it is lost. The feed is best effort.
+func publish(params Params, txs types.Txs, ctx context.Context) error {
 	span, _ := apm.StartSpan(ctx, "publish", "app")
 	defer span.End()
 
 	body, err := json.Marshal(txs)
 	if err != nil {
-		logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
-		return
+		return errors.E(err, "Transactions batch lost", errors.Params{"txs": len(txs)})
 	}
-	err = params.Queue.Publish(body)
-	if err != nil {
+	for attempt := 1; ; attempt++ {
+		if err = params.Queue.Publish(body); err == nil {
+			break
+		}
+		if attempt == publishAttempts {
+			return errors.E(err, "Transactions batch lost", errors.Params{"txs": len(txs)})
+		}
+		logger.Warn("Failed to publish transactions batch, retrying", logger.Params{"coin": params.Api.Coin().Handle, "err": err})
+		time.Sleep(publishRetryDelay)
+	}
+	if params.Feed == "" {
+		return nil
+	}
+	if err := params.Feed.Publish(body); err != nil {
 		logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
-		return
 	}
+	return nil
 }
 
 func getBlockByNumberWithRetry(attempts int, sleep time.Duration, getBlockByNumber GetBlockByNumber, n int64, symbol string, ctx context.Context) (*blockatlas.Block, error) {
@@ -221,3 +221,20 @@ func TestGetInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestWait(t *testing.T) {
+	heads := make(chan int64, 1)
+	heads <- 10
+	start := time.Now()
+	wait(heads, time.Minute)
+	assert.True(t, time.Since(start) < time.Second, "woken up by the head")
+
+	start = time.Now()
+	wait(heads, time.Millisecond*20)
+	assert.True(t, time.Since(start) >= time.Millisecond*20, "polling without head")
+
+	close(heads)
+	start = time.Now()
+	wait(heads, time.Millisecond*20)
+	assert.True(t, time.Since(start) >= time.Millisecond*20, "polling after the subscription ended")
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"context"
+
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"go.elastic.co/apm"
+)
+
+// rescanFrom is the block the step fetches after, ReorgDepth blocks before the last parsed one to
+// find the blocks reorganized since
+func rescanFrom(params Params, lastParsedBlock int64) int64 {
+	if params.ReorgDepth <= 0 {
+		return lastParsedBlock
+	}
+	if from := lastParsedBlock - params.ReorgDepth; from > 0 {
+		return from
+	}
+	return 0
+}
+
+// blocksAfter returns the blocks after the number, the tracker doesn't move back to a block fetched again
+func blocksAfter(blocks []types.Block, number int64) []types.Block {
+	result := make([]types.Block, 0, len(blocks))
+	for _, b := range blocks {
+		if b.Number > number {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// DetectReorgs keeps the new blocks and the parsed ones whose hash changed since, and records the hashes of
+// the blocks for the next steps. The parsed blocks without a recorded hash (before the first step, in memory
+// mode or from the platforms without block hashes) are dropped.
+func DetectReorgs(params Params, blocks []types.Block, lastParsedBlock int64, ctx context.Context) []types.Block {
+	span, ctx := apm.StartSpan(ctx, "DetectReorgs", "app")
+	defer span.End()
+
+	if params.ReorgDepth <= 0 {
+		return blocks
+	}
+	handle := params.Api.Coin().Handle
+	hashes, err := params.Database.GetBlockHashes(handle, rescanFrom(params, lastParsedBlock)+1, ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get block hashes", logger.Params{"coin": handle})
+	}
+	result, recorded, head := changedBlocks(blocks, hashes, lastParsedBlock)
+	for _, b := range result {
+		if b.Number <= lastParsedBlock {
+			logger.Warn("Reorganized block parsed again", logger.Params{"coin": handle, "block": b.Number, "hash": b.ID, "previous": hashes[b.Number]})
+		}
+	}
+	if err := params.Database.SaveBlockHashes(handle, recorded, head-params.ReorgDepth+1, ctx); err != nil {
+		logger.Error(err, "Failed to save block hashes", logger.Params{"coin": handle})
+	}
+	return result
+}
+
+// changedBlocks returns the blocks after lastParsedBlock and the ones before with another hash than
+// the recorded one, with the hashes of all the blocks and the highest number
+func changedBlocks(blocks []types.Block, hashes map[int64]string, lastParsedBlock int64) ([]types.Block, map[int64]string, int64) {
+	result := make([]types.Block, 0, len(blocks))
+	recorded := make(map[int64]string, len(blocks))
+	var head int64
+	for _, b := range blocks {
+		if b.Number > head {
+			head = b.Number
+		}
+		if b.ID != "" {
+			recorded[b.Number] = b.ID
+		}
+		if b.Number > lastParsedBlock {
+			result = append(result, b)
+			continue
+		}
+		if hash, ok := hashes[b.Number]; ok && b.ID != "" && hash != b.ID {
+			result = append(result, b)
+		}
+	}
+	return result, recorded, head
+}
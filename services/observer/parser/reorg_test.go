@@ -0,0 +1,30 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func Test_rescanFrom(t *testing.T) {
+	assert.Equal(t, int64(100), rescanFrom(Params{}, 100))
+	assert.Equal(t, int64(94), rescanFrom(Params{ReorgDepth: 6}, 100))
+	assert.Equal(t, int64(0), rescanFrom(Params{ReorgDepth: 6}, 4))
+}
+
+func Test_changedBlocks(t *testing.T) {
+	blocks := []types.Block{
+		{Number: 98, ID: "0x98"},
+		{Number: 99, ID: "0x99b"},
+		{Number: 100},
+		{Number: 101, ID: "0x101"},
+	}
+	hashes := map[int64]string{98: "0x98", 99: "0x99a", 100: "0x100"}
+	result, recorded, head := changedBlocks(blocks, hashes, 100)
+	assert.Equal(t, []types.Block{{Number: 99, ID: "0x99b"}, {Number: 101, ID: "0x101"}}, result)
+	assert.Equal(t, map[int64]string{98: "0x98", 99: "0x99b", 101: "0x101"}, recorded)
+	assert.Equal(t, int64(101), head)
+
+	assert.Equal(t, []types.Block{{Number: 102}}, blocksAfter([]types.Block{{Number: 100}, {Number: 102}}, 101))
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"go.elastic.co/apm"
+)
+
+var unbondingPeriods = struct {
+	sync.RWMutex
+	m map[uint]time.Duration
+}{m: map[uint]time.Duration{
+	coin.ATOM: 21 * 24 * time.Hour,
+	coin.KAVA: 21 * 24 * time.Hour,
+}}
+
+// SetUnbondingPeriod sets the time the undelegated funds of the coin stay locked,
+// a zero period disables the unbonding_complete events of the coin
+func SetUnbondingPeriod(coinID uint, period time.Duration) {
+	unbondingPeriods.Lock()
+	defer unbondingPeriods.Unlock()
+	if period <= 0 {
+		delete(unbondingPeriods.m, coinID)
+		return
+	}
+	unbondingPeriods.m[coinID] = period
+}
+
+func unbondingPeriod(coinID uint) (time.Duration, bool) {
+	unbondingPeriods.RLock()
+	defer unbondingPeriods.RUnlock()
+	period, ok := unbondingPeriods.m[coinID]
+	return period, ok
+}
+
+func stakingAction(tx *types.Tx) (types.AnyAction, bool) {
+	switch meta := tx.Meta.(type) {
+	case types.AnyAction:
+		return meta, true
+	case *types.AnyAction:
+		return *meta, true
+	default:
+		return types.AnyAction{}, false
+	}
+}
+
+// detectStakingEvent recognizes the claims of staking rewards, the validator is the
+// recipient of the claim transaction
+func detectStakingEvent(tx *types.Tx) *types.TxEvent {
+	action, ok := stakingAction(tx)
+	if !ok || action.Key != types.KeyStakeClaimRewards {
+		return nil
+	}
+	return &types.TxEvent{
+		Type:      types.EventRewardClaimed,
+		Validator: tx.To,
+		Value:     action.Value,
+		Symbol:    action.Symbol,
+		Decimals:  action.Decimals,
+	}
+}
+
+// pendingUnbondings returns the undelegations of coins with an unbonding period,
+// their funds become liquid once the period ends
+func pendingUnbondings(txs types.Txs) []models.PendingUnbonding {
+	result := make([]models.PendingUnbonding, 0)
+	for i := range txs {
+		tx := &txs[i]
+		action, ok := stakingAction(tx)
+		if !ok || action.Title != types.AnyActionUndelegation || tx.Status != types.StatusCompleted {
+			continue
+		}
+		period, ok := unbondingPeriod(tx.Coin)
+		if !ok {
+			continue
+		}
+		result = append(result, models.PendingUnbonding{
+			Coin:        tx.Coin,
+			TxID:        tx.ID,
+			Delegator:   tx.From,
+			Validator:   tx.To,
+			Value:       string(action.Value),
+			Symbol:      action.Symbol,
+			Decimals:    action.Decimals,
+			CompletesAt: time.Unix(tx.Date, 0).Add(period),
+		})
+	}
+	return result
+}
+
+// unbondingCompleteTx describes the end of the unbonding as a transaction from the
+// validator to the delegator, there is no such transaction on chain
+func unbondingCompleteTx(u models.PendingUnbonding) types.Tx {
+	tx := types.Tx{
+		ID:     u.TxID,
+		Coin:   u.Coin,
+		From:   u.Validator,
+		To:     u.Delegator,
+		Fee:    "0",
+		Date:   u.CompletesAt.Unix(),
+		Status: types.StatusCompleted,
+		Type:   types.TxAnyAction,
+		Event: &types.TxEvent{
+			Type:      types.EventUnbondingComplete,
+			Validator: u.Validator,
+			Value:     types.Amount(u.Value),
+			Symbol:    u.Symbol,
+			Decimals:  u.Decimals,
+		},
+	}
+	action := types.AnyAction{
+		Coin:     u.Coin,
+		Title:    types.AnyActionUndelegation,
+		Key:      types.KeyStakeDelegate,
+		Symbol:   u.Symbol,
+		Decimals: u.Decimals,
+		Value:    types.Amount(u.Value),
+	}
+	if c, ok := coin.Coins[u.Coin]; ok {
+		action.Name = c.Name
+	}
+	tx.Meta = action
+	return tx
+}
+
+// TrackUnbondings saves the new undelegations and returns the unbondings completed
+// since the last parse step, they are removed once published
+func TrackUnbondings(params Params, txs types.Txs, ctx context.Context) []models.PendingUnbonding {
+	span, ctx := apm.StartSpan(ctx, "TrackUnbondings", "app")
+	defer span.End()
+
+	coinID := params.Api.Coin().ID
+	if _, ok := unbondingPeriod(coinID); !ok {
+		return nil
+	}
+	if pending := pendingUnbondings(txs); len(pending) > 0 {
+		if err := params.Database.AddPendingUnbondings(pending, ctx); err != nil {
+			logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
+		}
+	}
+	completed, err := params.Database.GetCompletedUnbondings(coinID, time.Now(), ctx)
+	if err != nil {
+		logger.Error(err, logger.Params{"coin": params.Api.Coin().Handle})
+		return nil
+	}
+	return completed
+}
+
+func unbondingCompleteTxs(unbondings []models.PendingUnbonding) types.Txs {
+	txs := make(types.Txs, 0, len(unbondings))
+	for _, u := range unbondings {
+		txs = append(txs, unbondingCompleteTx(u))
+	}
+	return txs
+}
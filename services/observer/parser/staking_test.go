@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/coin"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+const (
+	delegator = "cosmos1rw62phusuv9vzraezr55k0vsqssvz6ed52zyrl"
+	validator = "cosmosvaloper1ptyzewnns2kn37ewtmv6ppsvhdnmeapvtfc9y5"
+)
+
+func TestDetectEvents_Staking(t *testing.T) {
+	txs := types.Txs{
+		{ID: "claim", Coin: coin.ATOM, From: delegator, To: validator, Meta: types.AnyAction{
+			Key: types.KeyStakeClaimRewards, Title: types.AnyActionClaimRewards, Symbol: "ATOM", Decimals: 6, Value: "1500000",
+		}},
+		{ID: "delegate", Coin: coin.ATOM, From: delegator, To: validator, Meta: types.AnyAction{
+			Key: types.KeyStakeDelegate, Title: types.AnyActionDelegation, Symbol: "ATOM", Decimals: 6, Value: "1",
+		}},
+	}
+	DetectEvents(txs)
+
+	assert.Equal(t, &types.TxEvent{Type: types.EventRewardClaimed, Validator: validator, Value: "1500000", Symbol: "ATOM", Decimals: 6}, txs[0].Event)
+	assert.Nil(t, txs[1].Event)
+}
+
+func Test_pendingUnbondings(t *testing.T) {
+	undelegation := types.AnyAction{Key: types.KeyStakeDelegate, Title: types.AnyActionUndelegation, Symbol: "ATOM", Decimals: 6, Value: "2000000"}
+	txs := types.Txs{
+		{ID: "undelegate", Coin: coin.ATOM, From: delegator, To: validator, Date: 1600000000, Status: types.StatusCompleted, Meta: undelegation},
+		{ID: "failed", Coin: coin.ATOM, From: delegator, To: validator, Date: 1600000000, Status: types.StatusError, Meta: undelegation},
+		{ID: "tezos", Coin: coin.XTZ, From: delegator, To: validator, Date: 1600000000, Status: types.StatusCompleted, Meta: undelegation},
+	}
+	pending := pendingUnbondings(txs)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "undelegate", pending[0].TxID)
+	assert.Equal(t, time.Unix(1600000000, 0).Add(21*24*time.Hour), pending[0].CompletesAt)
+
+	tx := unbondingCompleteTx(pending[0])
+	assert.Equal(t, delegator, tx.To)
+	assert.Equal(t, validator, tx.From)
+	assert.Equal(t, pending[0].CompletesAt.Unix(), tx.Date)
+	assert.Equal(t, &types.TxEvent{Type: types.EventUnbondingComplete, Validator: validator, Value: "2000000", Symbol: "ATOM", Decimals: 6}, tx.Event)
+	assert.Equal(t, types.DirectionIncoming, tx.GetTransactionDirection(delegator))
+}
+
+func TestSetUnbondingPeriod(t *testing.T) {
+	SetUnbondingPeriod(coin.XTZ, time.Hour)
+	period, ok := unbondingPeriod(coin.XTZ)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, period)
+
+	SetUnbondingPeriod(coin.XTZ, 0)
+	_, ok = unbondingPeriod(coin.XTZ)
+	assert.False(t, ok)
+}
@@ -0,0 +1,113 @@
+package screening
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+type (
+	// Request is a subscription request screened before it is queued for the subscriber
+	Request struct {
+		Tenant        string                      `json:"tenant,omitempty"`
+		Operation     types.SubscriptionOperation `json:"operation"`
+		Subscriptions []types.Subscription        `json:"subscriptions"`
+		Xpubs         []types.Subscription        `json:"xpubs,omitempty"`
+		Channel       types.ChannelProvider       `json:"channel,omitempty"`
+		// WebhookHost is the host of the callback URL of a webhook channel, the URL itself is not sent
+		WebhookHost string `json:"webhook_host,omitempty"`
+	}
+
+	// Decision is the answer of a screener, Reason tells the client why the request is rejected
+	Decision struct {
+		Allowed bool   `json:"allowed"`
+		Reason  string `json:"reason,omitempty"`
+	}
+
+	// Screener decides whether a subscription request is accepted, e.g. to enforce the sanctions
+	// policy of the operator. An error leaves the request undecided.
+	Screener interface {
+		Screen(req Request, ctx context.Context) (Decision, error)
+	}
+
+	// Chain asks the screeners in order, the first rejection wins
+	Chain []Screener
+
+	// Rules rejects the requests subscribing one of the Addresses (case-insensitive, on any coin)
+	// or delivering to a webhook of one of the WebhookHosts, ".example.com" matching its subdomains
+	Rules struct {
+		Addresses    []string `mapstructure:"addresses"`
+		WebhookHosts []string `mapstructure:"webhook_hosts"`
+	}
+
+	// Callout posts the requests as JSON to the URL of the operator, answered with a Decision.
+	// With FailOpen the requests are accepted when the URL fails to answer.
+	Callout struct {
+		blockatlas.Request
+		FailOpen bool
+	}
+)
+
+// NewRequest returns the request of the subscription event, with the host of its webhook channel
+func NewRequest(event types.SubscriptionEvent) Request {
+	req := Request{
+		Tenant:        event.Tenant,
+		Operation:     event.Operation,
+		Subscriptions: event.ParseSubscriptions(event.Subscriptions),
+		Xpubs:         event.ParseSubscriptions(event.Xpubs),
+	}
+	if event.Channel != nil {
+		req.Channel = event.Channel.Provider
+		if event.Channel.Provider == types.ChannelWebhook {
+			if u, err := url.Parse(event.Channel.Token); err == nil {
+				req.WebhookHost = u.Hostname()
+			}
+		}
+	}
+	return req
+}
+
+func (c Chain) Screen(req Request, ctx context.Context) (Decision, error) {
+	for _, s := range c {
+		decision, err := s.Screen(req, ctx)
+		if err != nil || !decision.Allowed {
+			return decision, err
+		}
+	}
+	return Decision{Allowed: true}, nil
+}
+
+func (r Rules) Screen(req Request, ctx context.Context) (Decision, error) {
+	for _, s := range append(req.Subscriptions, req.Xpubs...) {
+		for _, address := range r.Addresses {
+			if strings.EqualFold(s.Address, address) {
+				return Decision{Reason: "address not allowed: " + s.Address}, nil
+			}
+		}
+	}
+	if req.WebhookHost == "" {
+		return Decision{Allowed: true}, nil
+	}
+	host := strings.ToLower(req.WebhookHost)
+	for _, blocked := range r.WebhookHosts {
+		blocked = strings.ToLower(blocked)
+		if host == blocked || (strings.HasPrefix(blocked, ".") && strings.HasSuffix(host, blocked)) {
+			return Decision{Reason: "webhook host not allowed: " + req.WebhookHost}, nil
+		}
+	}
+	return Decision{Allowed: true}, nil
+}
+
+func (c *Callout) Screen(req Request, ctx context.Context) (Decision, error) {
+	var decision Decision
+	err := c.PostWithContext(&decision, "", req, ctx)
+	if err != nil && c.FailOpen {
+		logger.Error(err, "Screening callout failed, subscription accepted", logger.Params{"tenant": req.Tenant})
+		return Decision{Allowed: true}, nil
+	}
+	return decision, err
+}
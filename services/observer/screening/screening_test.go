@@ -0,0 +1,57 @@
+package screening
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+)
+
+func TestNewRequest(t *testing.T) {
+	req := NewRequest(types.SubscriptionEvent{
+		Subscriptions: types.Subscriptions{"60": {"0xa"}},
+		Operation:     "AddSubscription",
+		Channel:       &types.Channel{Provider: types.ChannelWebhook, Token: "https://hooks.example.com:8443/cb?secret=1"},
+		Tenant:        "exchange",
+	})
+	assert.Equal(t, []types.Subscription{{Coin: 60, Address: "0xa", Tenant: "exchange"}}, req.Subscriptions)
+	assert.Equal(t, "hooks.example.com", req.WebhookHost)
+	assert.Equal(t, types.ChannelWebhook, req.Channel)
+}
+
+func TestCallout(t *testing.T) {
+	var received Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		if received.Tenant == "down" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Decision{Allowed: received.Tenant != "blocked", Reason: "policy"})
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	callout := &Callout{Request: blockatlas.InitJSONClient(server.URL)}
+	screener := Chain{Rules{Addresses: []string{"0xbad"}}, callout}
+
+	decision, err := screener.Screen(Request{Tenant: "exchange", Subscriptions: []types.Subscription{{Coin: 60, Address: "0xa"}}}, ctx)
+	assert.Nil(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, "0xa", received.Subscriptions[0].Address)
+
+	decision, err = screener.Screen(Request{Tenant: "blocked"}, ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, Decision{Reason: "policy"}, decision)
+
+	decision, err = screener.Screen(Request{Tenant: "down"}, ctx)
+	assert.NotNil(t, err)
+	callout.FailOpen = true
+	decision, err = screener.Screen(Request{Tenant: "down"}, ctx)
+	assert.Nil(t, err)
+	assert.True(t, decision.Allowed)
+}
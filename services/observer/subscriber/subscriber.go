@@ -8,15 +8,61 @@ import (
 	"github.com/trustwallet/blockatlas/db/models"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
 	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/tenant"
 	"go.elastic.co/apm"
+	"strings"
+	"time"
 )
 
 const (
 	AddSubscription    blockatlas.SubscriptionOperation = "AddSubscription"
 	DeleteSubscription blockatlas.SubscriptionOperation = "DeleteSubscription"
 	UpdateSubscription blockatlas.SubscriptionOperation = "UpdateSubscription"
+	// RenewSubscription extends the expiry of the stored subscriptions by the TTL of the event
+	RenewSubscription types.SubscriptionOperation = "RenewSubscription"
 )
 
+var (
+	// DefaultTTL is the lifetime of the subscriptions added without a TTL, 0 keeps them until deleted
+	DefaultTTL time.Duration
+
+	// Tenants are the quotas of the tenants, set from observer.tenants
+	Tenants *tenant.Registry
+)
+
+// applyQuota keeps the subscriptions fitting in the subscriptions quota of the tenant
+func applyQuota(database *db.Instance, name string, subscriptions []models.Subscription, ctx context.Context) ([]models.Subscription, error) {
+	quota := Tenants.Get(name).Quota
+	if quota.MaxSubscriptions <= 0 || len(subscriptions) == 0 {
+		return subscriptions, nil
+	}
+	stored, err := database.CountSubscriptions(name, ctx)
+	if err != nil {
+		return nil, err
+	}
+	allowed := quota.Allowed(stored, len(subscriptions))
+	if allowed < len(subscriptions) {
+		logger.Info("Subscriptions quota exceeded", logger.Params{
+			"tenant": name, "max_subscriptions": quota.MaxSubscriptions, "dropped": len(subscriptions) - allowed,
+		})
+	}
+	return subscriptions[:allowed], nil
+}
+
+// ExpiresAt returns the expiry of the subscriptions of an event, nil when they don't expire
+func ExpiresAt(ttl int64, now time.Time) *time.Time {
+	lifetime := time.Duration(ttl) * time.Second
+	if ttl <= 0 {
+		lifetime = DefaultTTL
+	}
+	if lifetime <= 0 {
+		return nil
+	}
+	expiresAt := now.Add(lifetime)
+	return &expiresAt
+}
+
 func RunSubscriber(database *db.Instance, delivery amqp.Delivery) {
 	tx := apm.DefaultTracer.StartTransaction("RunSubscriber", "app")
 	defer tx.End()
@@ -32,14 +78,65 @@ func RunSubscriber(database *db.Instance, delivery amqp.Delivery) {
 
 	subscriptions := event.ParseSubscriptions(event.Subscriptions)
 	params := logger.Params{"operation": event.Operation, "subscriptions_len": len(subscriptions)}
+	if event.Tenant != "" {
+		params["tenant"] = event.Tenant
+	}
+	expiresAt := ExpiresAt(event.TTL, time.Now())
+
+	if event.Channel != nil {
+		params["provider"] = event.Channel.Provider
+		if len(subscriptions) > 0 {
+			runChannelSubscriber(database, event.Operation, ToChannelSubscriptionData(subscriptions, *event.Channel), expiresAt, params, ctx)
+		}
+		if len(event.LendingAlerts) > 0 {
+			runLendingAlertsSubscriber(database, event.Operation, ToLendingRateAlertData(event.Operation, event.LendingAlerts, *event.Channel, event.Locale), params, ctx)
+		}
+		if len(event.LiquidationAlerts) > 0 {
+			runLiquidationAlertsSubscriber(database, event.Operation, ToLiquidationAlertData(event.Operation, event.LiquidationAlerts, *event.Channel, event.Locale), params, ctx)
+		}
+		if err := delivery.Ack(false); err != nil {
+			logger.Error(err, params)
+		}
+		return
+	}
+
+	if len(event.Xpubs) > 0 {
+		xpubs := event.ParseSubscriptions(event.Xpubs)
+		runXpubSubscriber(database, event.Operation, ToXpubSubscriptionData(xpubs), expiresAt, params, ctx)
+		// An event of xpubs alone has no addresses
+		if len(subscriptions) == 0 {
+			if err := delivery.Ack(false); err != nil {
+				logger.Error(err, params)
+			}
+			return
+		}
+	}
 
 	switch event.Operation {
 	case AddSubscription, UpdateSubscription:
-		err = database.AddSubscriptions(ToSubscriptionData(subscriptions), ctx)
+		data := ToSubscriptionData(subscriptions)
+		for i := range data {
+			data[i].ExpiresAt = expiresAt
+		}
+		data, err = applyQuota(database, event.Tenant, data, ctx)
+		if err != nil {
+			logger.Error(err, params)
+			break
+		}
+		if len(data) == 0 {
+			break
+		}
+		err = database.AddSubscriptions(data, ctx)
 		if err != nil {
 			logger.Error(err, params)
 		}
 		logger.Info("Added", params)
+	case RenewSubscription:
+		err := database.RenewSubscriptions(ToSubscriptionData(subscriptions), expiresAt, ctx)
+		if err != nil {
+			logger.Error(err, params)
+		}
+		logger.Info("Renewed", params)
 	case DeleteSubscription:
 		err := database.DeleteSubscriptions(ToSubscriptionData(subscriptions), ctx)
 		if err != nil {
@@ -57,7 +154,136 @@ func RunSubscriber(database *db.Instance, delivery amqp.Delivery) {
 func ToSubscriptionData(sub []blockatlas.Subscription) []models.Subscription {
 	data := make([]models.Subscription, 0, len(sub))
 	for _, s := range sub {
-		data = append(data, models.Subscription{Coin: s.Coin, Address: s.Address})
+		data = append(data, models.Subscription{Coin: s.Coin, Address: s.Address, Tenant: s.Tenant})
+	}
+	return data
+}
+
+func runChannelSubscriber(database *db.Instance, operation types.SubscriptionOperation, subscriptions []models.ChannelSubscription, expiresAt *time.Time, params logger.Params, ctx context.Context) {
+	switch operation {
+	case AddSubscription, UpdateSubscription:
+		for i := range subscriptions {
+			subscriptions[i].ExpiresAt = expiresAt
+		}
+		if err := database.AddChannelSubscriptions(subscriptions, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Added channel", params)
+	case RenewSubscription:
+		if err := database.RenewChannelSubscriptions(subscriptions, expiresAt, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Renewed channel", params)
+	case DeleteSubscription:
+		if err := database.DeleteChannelSubscriptions(subscriptions, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Deleted channel", params)
+	}
+}
+
+func ToChannelSubscriptionData(sub []types.Subscription, channel types.Channel) []models.ChannelSubscription {
+	digest := channel.Digest
+	if _, ok := digest.Duration(); !ok {
+		digest = ""
+	}
+	data := make([]models.ChannelSubscription, 0, len(sub))
+	for _, s := range sub {
+		data = append(data, models.ChannelSubscription{
+			Coin:     s.Coin,
+			Address:  s.Address,
+			Provider: string(channel.Provider),
+			Token:    channel.Token,
+			Locale:   s.Locale,
+			Digest:   string(digest),
+		})
+	}
+	return data
+}
+
+func runLendingAlertsSubscriber(database *db.Instance, operation types.SubscriptionOperation, alerts []models.LendingRateAlert, params logger.Params, ctx context.Context) {
+	params["lending_alerts_len"] = len(alerts)
+	switch operation {
+	case AddSubscription, UpdateSubscription:
+		if err := database.AddLendingRateAlerts(alerts, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Added lending alerts", params)
+	case DeleteSubscription:
+		if err := database.DeleteLendingRateAlerts(alerts, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Deleted lending alerts", params)
+	}
+}
+
+// ToLendingRateAlertData skips the alerts without a provider or an asset, and the added or updated
+// alerts without any threshold
+func ToLendingRateAlertData(operation types.SubscriptionOperation, alerts []types.LendingRateAlert, channel types.Channel, locale string) []models.LendingRateAlert {
+	data := make([]models.LendingRateAlert, 0, len(alerts))
+	for _, a := range alerts {
+		if a.Provider == "" || a.Asset == "" {
+			continue
+		}
+		if operation != DeleteSubscription && a.Above <= 0 && a.Below <= 0 && a.Change <= 0 {
+			continue
+		}
+		data = append(data, models.LendingRateAlert{
+			Provider: strings.ToLower(a.Provider),
+			Asset:    strings.ToUpper(a.Asset),
+			Channel:  string(channel.Provider),
+			Token:    channel.Token,
+			Locale:   locale,
+			Above:    a.Above,
+			Below:    a.Below,
+			Change:   a.Change,
+		})
+	}
+	return data
+}
+
+func runLiquidationAlertsSubscriber(database *db.Instance, operation types.SubscriptionOperation, alerts []models.LiquidationAlert, params logger.Params, ctx context.Context) {
+	params["liquidation_alerts_len"] = len(alerts)
+	switch operation {
+	case AddSubscription, UpdateSubscription:
+		if err := database.AddLiquidationAlerts(alerts, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Added liquidation alerts", params)
+	case DeleteSubscription:
+		if err := database.DeleteLiquidationAlerts(alerts, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Deleted liquidation alerts", params)
+	}
+}
+
+// ToLiquidationAlertData skips the alerts without a provider or an address, and the added or updated
+// alerts without a health factor above 1
+func ToLiquidationAlertData(operation types.SubscriptionOperation, alerts []types.LiquidationAlert, channel types.Channel, locale string) []models.LiquidationAlert {
+	data := make([]models.LiquidationAlert, 0, len(alerts))
+	for _, a := range alerts {
+		if a.Provider == "" || a.Address == "" {
+			continue
+		}
+		if operation != DeleteSubscription && a.HealthFactor <= 1 {
+			continue
+		}
+		data = append(data, models.LiquidationAlert{
+			Provider:     strings.ToLower(a.Provider),
+			Address:      a.Address,
+			Channel:      string(channel.Provider),
+			Token:        channel.Token,
+			Locale:       locale,
+			HealthFactor: a.HealthFactor,
+		})
 	}
 	return data
 }
@@ -1,10 +1,16 @@
 package subscriber
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"github.com/trustwallet/blockatlas/db"
 	"github.com/trustwallet/blockatlas/db/models"
 	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"github.com/trustwallet/blockatlas/services/observer/tenant"
+	"os"
 	"testing"
+	"time"
 )
 
 func TestToSubscriptionData(t *testing.T) {
@@ -31,3 +37,98 @@ func TestToSubscriptionData(t *testing.T) {
 	assert.Equal(t, expectedModel, res[0])
 	assert.Equal(t, expectedModel1, res[1])
 }
+
+func TestToChannelSubscriptionData(t *testing.T) {
+	subs := []types.Subscription{{Coin: 60, Address: "A"}, {Coin: 714, Address: "B"}}
+	res := ToChannelSubscriptionData(subs, types.Channel{Provider: types.ChannelFCM, Token: "device"})
+	assert.Equal(t, []models.ChannelSubscription{
+		{Coin: 60, Address: "A", Provider: "fcm", Token: "device"},
+		{Coin: 714, Address: "B", Provider: "fcm", Token: "device"},
+	}, res)
+
+	res = ToChannelSubscriptionData(subs[:1], types.Channel{Provider: types.ChannelFCM, Token: "device", Digest: types.DigestWeekly})
+	assert.Equal(t, "weekly", res[0].Digest)
+	res = ToChannelSubscriptionData(subs[:1], types.Channel{Provider: types.ChannelFCM, Token: "device", Digest: "hourly"})
+	assert.Equal(t, "", res[0].Digest)
+}
+
+func TestToLendingRateAlertData(t *testing.T) {
+	alerts := []types.LendingRateAlert{
+		{Provider: "Compound", Asset: "dai", Above: 5, Change: 20},
+		{Provider: "compound", Asset: "USDC"},
+		{Asset: "USDC", Below: 1},
+	}
+	res := ToLendingRateAlertData(AddSubscription, alerts, types.Channel{Provider: types.ChannelTelegram, Token: "chat"}, "es")
+	assert.Equal(t, []models.LendingRateAlert{
+		{Provider: "compound", Asset: "DAI", Channel: "telegram", Token: "chat", Locale: "es", Above: 5, Change: 20},
+	}, res)
+	res = ToLendingRateAlertData(DeleteSubscription, alerts, types.Channel{Provider: types.ChannelTelegram, Token: "chat"}, "es")
+	assert.Equal(t, []models.LendingRateAlert{
+		{Provider: "compound", Asset: "DAI", Channel: "telegram", Token: "chat", Locale: "es", Above: 5, Change: 20},
+		{Provider: "compound", Asset: "USDC", Channel: "telegram", Token: "chat", Locale: "es"},
+	}, res)
+}
+
+func TestToLiquidationAlertData(t *testing.T) {
+	alerts := []types.LiquidationAlert{
+		{Provider: "Aave", Address: "0x08777CB1e80F45642752662B04886Df2d271E049", HealthFactor: 1.2},
+		{Provider: "aave", Address: "0x08777CB1e80F45642752662B04886Df2d271E049", HealthFactor: 0.9},
+	}
+	res := ToLiquidationAlertData(UpdateSubscription, alerts, types.Channel{Provider: types.ChannelFCM, Token: "device"}, "")
+	assert.Equal(t, []models.LiquidationAlert{
+		{Provider: "aave", Address: "0x08777CB1e80F45642752662B04886Df2d271E049", Channel: "fcm", Token: "device", HealthFactor: 1.2},
+	}, res)
+	res = ToLiquidationAlertData(DeleteSubscription, alerts[1:], types.Channel{Provider: types.ChannelFCM, Token: "device"}, "")
+	assert.Equal(t, []models.LiquidationAlert{
+		{Provider: "aave", Address: "0x08777CB1e80F45642752662B04886Df2d271E049", Channel: "fcm", Token: "device", HealthFactor: 0.9},
+	}, res)
+}
+
+func TestExpiresAt(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	defer func(ttl time.Duration) { DefaultTTL = ttl }(DefaultTTL)
+
+	DefaultTTL = 0
+	assert.Nil(t, ExpiresAt(0, now))
+	assert.Equal(t, now.Add(time.Hour), *ExpiresAt(3600, now))
+
+	DefaultTTL = 30 * 24 * time.Hour
+	assert.Equal(t, now.Add(DefaultTTL), *ExpiresAt(0, now))
+	assert.Equal(t, now.Add(time.Minute), *ExpiresAt(60, now))
+}
+
+func TestToXpubSubscriptionData(t *testing.T) {
+	event := types.SubscriptionEvent{Xpubs: types.Subscriptions{"0": {"zpub1"}}, Locale: "es"}
+	res := ToXpubSubscriptionData(event.ParseSubscriptions(event.Xpubs))
+	assert.Equal(t, []models.XpubSubscription{{Coin: 0, Xpub: "zpub1"}}, res)
+}
+
+func Test_newDerivedSubscriptions(t *testing.T) {
+	expiresAt := time.Unix(1600000000, 0)
+	xpub := models.XpubSubscription{Coin: 0, Xpub: "zpub1", ExpiresAt: &expiresAt, Tenant: "exchange"}
+	stored := []models.Subscription{{Coin: 0, Address: "bc1a", Tenant: "exchange"}, {Coin: 0, Address: "bc1b"}}
+	res := newDerivedSubscriptions(xpub, []string{"bc1a", "bc1b", "bc1c"}, stored)
+	assert.Equal(t, []models.Subscription{
+		{Coin: 0, Address: "bc1b", ExpiresAt: &expiresAt, Xpub: "zpub1", Tenant: "exchange"},
+		{Coin: 0, Address: "bc1c", ExpiresAt: &expiresAt, Xpub: "zpub1", Tenant: "exchange"},
+	}, res)
+}
+
+func Test_applyQuota(t *testing.T) {
+	defer func(tenants *tenant.Registry) { Tenants = tenants }(Tenants)
+	database, err := db.NewMemory(&db.FileSnapshotStore{Dir: os.TempDir() + "/missing_snapshots"}, context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, database.AddSubscriptions([]models.Subscription{
+		{Coin: 60, Address: "0xa", Tenant: "exchange"},
+		{Coin: 60, Address: "0xb"},
+	}, context.Background()))
+
+	Tenants = tenant.NewRegistry([]tenant.Tenant{{Name: "exchange", Quota: tenant.Quota{MaxSubscriptions: 3}}})
+	subs := []models.Subscription{{Coin: 60, Address: "0xc"}, {Coin: 60, Address: "0xd"}, {Coin: 60, Address: "0xe"}}
+	res, err := applyQuota(database, "exchange", subs, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, subs[:2], res)
+	res, err = applyQuota(database, "", subs, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, subs, res, "the default tenant is unlimited")
+}
@@ -0,0 +1,145 @@
+package subscriber
+
+import (
+	"context"
+	"time"
+
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/db/models"
+	"github.com/trustwallet/blockatlas/pkg/blockatlas"
+	"github.com/trustwallet/blockatlas/pkg/errors"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+	"github.com/trustwallet/blockatlas/pkg/types"
+	"go.elastic.co/apm"
+)
+
+const DefaultXpubInterval = 10 * time.Minute
+
+// XpubAPIs derive the addresses of the xpub subscriptions by coin, set when observer.xpub is enabled
+var XpubAPIs map[uint]blockatlas.XpubAPI
+
+func runXpubSubscriber(database *db.Instance, operation types.SubscriptionOperation, subscriptions []models.XpubSubscription, expiresAt *time.Time, params logger.Params, ctx context.Context) {
+	params["xpubs_len"] = len(subscriptions)
+	switch operation {
+	case AddSubscription, UpdateSubscription:
+		for i := range subscriptions {
+			subscriptions[i].ExpiresAt = expiresAt
+		}
+		if err := database.AddXpubSubscriptions(subscriptions, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		// The addresses already derived are subscribed right away, the next ones by the xpub worker
+		for _, s := range subscriptions {
+			if err := syncXpub(database, s, time.Now(), ctx); err != nil {
+				logger.Error(err, logger.Params{"coin": s.Coin})
+			}
+		}
+		logger.Info("Added xpubs", params)
+	case RenewSubscription:
+		if err := database.RenewXpubSubscriptions(subscriptions, expiresAt, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Renewed xpubs", params)
+	case DeleteSubscription:
+		if err := database.DeleteXpubSubscriptions(subscriptions, ctx); err != nil {
+			logger.Error(err, params)
+			return
+		}
+		logger.Info("Deleted xpubs", params)
+	}
+}
+
+func ToXpubSubscriptionData(xpubs []types.Subscription) []models.XpubSubscription {
+	data := make([]models.XpubSubscription, 0, len(xpubs))
+	for _, x := range xpubs {
+		data = append(data, models.XpubSubscription{Coin: x.Coin, Xpub: x.Address, Tenant: x.Tenant})
+	}
+	return data
+}
+
+// RunXpubWorker subscribes the addresses newly derived from the xpub subscriptions at every interval
+func RunXpubWorker(database *db.Instance, interval time.Duration, ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Xpub worker stopped")
+			return
+		case now := <-ticker.C:
+			syncXpubs(database, now, ctx)
+		}
+	}
+}
+
+func syncXpubs(database *db.Instance, now time.Time, c context.Context) {
+	tx := apm.DefaultTracer.StartTransaction("syncXpubs", "app")
+	defer tx.End()
+	ctx := apm.ContextWithTransaction(c, tx)
+
+	subscriptions, err := database.GetXpubSubscriptions(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get xpub subscriptions")
+		return
+	}
+	for _, s := range subscriptions {
+		if err := syncXpub(database, s, now, ctx); err != nil {
+			logger.Error(err, logger.Params{"coin": s.Coin})
+		}
+	}
+}
+
+func syncXpub(database *db.Instance, subscription models.XpubSubscription, now time.Time, ctx context.Context) error {
+	api, ok := XpubAPIs[subscription.Coin]
+	if !ok {
+		return errors.E("xpub not supported by the coin", errors.Params{"coin": subscription.Coin})
+	}
+	addresses, err := api.GetAddressesFromXpub(subscription.Xpub)
+	if err != nil {
+		return errors.E(err, "failed to derive the xpub addresses")
+	}
+	if len(addresses) == 0 {
+		return database.SetXpubSubscriptionSynced(subscription, 0, now, ctx)
+	}
+	stored, err := database.GetSubscriptions(subscription.Coin, addresses, ctx)
+	if err != nil {
+		return err
+	}
+	// The new addresses count in the subscriptions quota, the ones over it are tried again at the next sync
+	derived, err := applyQuota(database, subscription.Tenant, newDerivedSubscriptions(subscription, addresses, stored), ctx)
+	if err != nil {
+		return err
+	}
+	if len(derived) > 0 {
+		if err := database.AddSubscriptions(derived, ctx); err != nil {
+			return err
+		}
+	}
+	return database.SetXpubSubscriptionSynced(subscription, len(addresses), now, ctx)
+}
+
+// newDerivedSubscriptions returns the subscriptions of the derived addresses not stored for the tenant yet
+func newDerivedSubscriptions(subscription models.XpubSubscription, addresses []string, stored []models.Subscription) []models.Subscription {
+	subscribed := make(map[string]bool, len(stored))
+	for _, s := range stored {
+		if s.Tenant == subscription.Tenant {
+			subscribed[s.Address] = true
+		}
+	}
+	data := make([]models.Subscription, 0, len(addresses))
+	for _, address := range addresses {
+		if subscribed[address] {
+			continue
+		}
+		data = append(data, models.Subscription{
+			Coin:      subscription.Coin,
+			Address:   address,
+			ExpiresAt: subscription.ExpiresAt,
+			Xpub:      subscription.Xpub,
+			Tenant:    subscription.Tenant,
+		})
+	}
+	return data
+}
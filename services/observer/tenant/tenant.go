@@ -0,0 +1,146 @@
+package tenant
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// Tenant is an integrator of the observer, identified by its API keys
+	Tenant struct {
+		Name    string   `mapstructure:"name"`
+		APIKeys []string `mapstructure:"api_keys"`
+		// Locale of the messages rendered on the notifications of the tenant queue, none without it
+		Locale string `mapstructure:"locale"`
+		Quota  `mapstructure:",squash"`
+	}
+
+	// Quota limits the subscriptions and the notifications of a tenant, 0 is unlimited
+	Quota struct {
+		MaxSubscriptions          int64 `mapstructure:"max_subscriptions"`
+		MaxNotificationsPerMinute int   `mapstructure:"max_notifications_per_minute"`
+	}
+
+	// Registry holds the tenants configured under observer.tenants
+	Registry struct {
+		tenants map[string]Tenant
+		keys    map[string]string
+	}
+
+	// Limiter counts the notifications of each tenant in windows of one minute
+	Limiter struct {
+		sync.Mutex
+		windows map[string]*window
+		now     func() time.Time
+	}
+
+	window struct {
+		start time.Time
+		used  int
+	}
+)
+
+// NewRegistry indexes the tenants by name and API key, tenants without a name are skipped
+func NewRegistry(tenants []Tenant) *Registry {
+	r := &Registry{tenants: make(map[string]Tenant), keys: make(map[string]string)}
+	for _, t := range tenants {
+		if t.Name == "" {
+			continue
+		}
+		r.tenants[t.Name] = t
+		for _, key := range t.APIKeys {
+			if key != "" {
+				r.keys[key] = t.Name
+			}
+		}
+	}
+	return r
+}
+
+// Get returns the tenant, the default tenant "" and the unknown ones have no quota
+func (r *Registry) Get(name string) Tenant {
+	if r == nil {
+		return Tenant{Name: name}
+	}
+	if t, ok := r.tenants[name]; ok {
+		return t
+	}
+	return Tenant{Name: name}
+}
+
+// ByKey returns the name of the tenant of the API key, false for the other keys
+func (r *Registry) ByKey(key string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	name, ok := r.keys[key]
+	return name, ok
+}
+
+// Names returns the names of the configured tenants
+func (r *Registry) Names() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.tenants))
+	for name := range r.tenants {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Keys returns the API keys of every tenant
+func (r *Registry) Keys() []string {
+	if r == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(r.keys))
+	for key := range r.keys {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Allowed returns how many of the new subscriptions fit in the quota, given the stored ones
+func (q Quota) Allowed(stored int64, subscriptions int) int {
+	if q.MaxSubscriptions <= 0 {
+		return subscriptions
+	}
+	free := q.MaxSubscriptions - stored
+	if free <= 0 {
+		return 0
+	}
+	if free < int64(subscriptions) {
+		return int(free)
+	}
+	return subscriptions
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{windows: make(map[string]*window), now: time.Now}
+}
+
+// Allow takes up to n notifications of the tenant from the limit of the current minute,
+// returning how many can be sent
+func (l *Limiter) Allow(tenant string, perMinute, n int) int {
+	if perMinute <= 0 {
+		return n
+	}
+	l.Lock()
+	defer l.Unlock()
+	now := l.now()
+	w, ok := l.windows[tenant]
+	if !ok || now.Sub(w.start) >= time.Minute {
+		w = &window{start: now}
+		l.windows[tenant] = w
+	}
+	allowed := perMinute - w.used
+	if allowed <= 0 {
+		return 0
+	}
+	if allowed > n {
+		allowed = n
+	}
+	w.used += allowed
+	return allowed
+}
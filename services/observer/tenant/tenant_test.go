@@ -0,0 +1,48 @@
+package tenant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry([]Tenant{
+		{Name: "exchange", APIKeys: []string{"key1", "key2"}, Quota: Quota{MaxSubscriptions: 10}},
+		{APIKeys: []string{"orphan"}},
+	})
+	name, ok := r.ByKey("key2")
+	assert.True(t, ok)
+	assert.Equal(t, "exchange", name)
+	_, ok = r.ByKey("orphan")
+	assert.False(t, ok)
+	assert.Equal(t, int64(10), r.Get("exchange").MaxSubscriptions)
+	assert.Equal(t, Tenant{Name: "unknown"}, r.Get("unknown"))
+	assert.ElementsMatch(t, []string{"key1", "key2"}, r.Keys())
+
+	var empty *Registry
+	assert.Equal(t, Tenant{}, empty.Get(""))
+}
+
+func TestQuota_Allowed(t *testing.T) {
+	assert.Equal(t, 5, Quota{}.Allowed(100, 5))
+	assert.Equal(t, 5, Quota{MaxSubscriptions: 10}.Allowed(5, 5))
+	assert.Equal(t, 2, Quota{MaxSubscriptions: 10}.Allowed(8, 5))
+	assert.Equal(t, 0, Quota{MaxSubscriptions: 10}.Allowed(12, 5))
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	l := NewLimiter()
+	l.now = func() time.Time { return now }
+
+	assert.Equal(t, 100, l.Allow("exchange", 0, 100))
+	assert.Equal(t, 6, l.Allow("exchange", 10, 6))
+	assert.Equal(t, 4, l.Allow("exchange", 10, 6))
+	assert.Equal(t, 0, l.Allow("exchange", 10, 1))
+	assert.Equal(t, 3, l.Allow("other", 10, 3), "the tenants have their own window")
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, 6, l.Allow("exchange", 10, 6))
+}
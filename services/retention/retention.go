@@ -0,0 +1,141 @@
+package retention
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trustwallet/blockatlas/db"
+	"github.com/trustwallet/blockatlas/pkg/logger"
+)
+
+// DefaultInterval is the time between the prunings without retention.interval
+const DefaultInterval = time.Hour
+
+var (
+	pruned = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atlas",
+		Name:      "retention_pruned_total",
+		Help:      "Rows deleted by the retention policy, by table and coin.",
+	}, []string{"table", "coin"})
+	storeRows = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas",
+		Name:      "store_rows",
+		Help:      "Estimated rows of the stored tables, updated after every pruning.",
+	}, []string{"table"})
+	storeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "atlas",
+		Name:      "store_bytes",
+		Help:      "Size on disk of the stored tables with their indexes, updated after every pruning.",
+	}, []string{"table"})
+)
+
+func init() {
+	prometheus.MustRegister(pruned, storeRows, storeBytes)
+}
+
+type (
+	// Policy is the number of days the records of a coin are kept, Coins overriding Default.
+	// 0 days keeps the records.
+	Policy struct {
+		Default int          `mapstructure:"default_days"`
+		Coins   map[uint]int `mapstructure:"coins"`
+	}
+
+	// Table is a store of records by coin, pruned by the date of their transaction
+	Table struct {
+		Name   string
+		Coins  func(ctx context.Context) ([]uint, error)
+		Delete func(coin uint, before time.Time, ctx context.Context) (int64, error)
+	}
+
+	// Sizer reports the size of the tables, for the store metrics
+	Sizer interface {
+		TableSizes(tables []string, ctx context.Context) ([]db.TableSize, error)
+	}
+)
+
+// Days returns the retention of the coin
+func (p Policy) Days(coin uint) int {
+	if days, ok := p.Coins[coin]; ok {
+		return days
+	}
+	return p.Default
+}
+
+// TokenTransfers is the table of the transfers stored by the indexer
+func TokenTransfers(database *db.Instance) Table {
+	return Table{Name: db.TableTokenTransfers, Coins: database.TokenTransferCoins, Delete: database.DeleteTokenTransfers}
+}
+
+// NotificationHistory is the table of the notifications recorded for the replays
+func NotificationHistory(database *db.Instance) Table {
+	return Table{Name: db.TableNotificationRecords, Coins: database.NotificationRecordCoins, Delete: database.DeleteCoinNotificationRecords}
+}
+
+// Prune deletes the records of every coin older than its retention, returning the deleted rows by coin
+func Prune(table Table, policy Policy, now time.Time, ctx context.Context) (map[uint]int64, error) {
+	coins, err := table.Coins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deleted := make(map[uint]int64)
+	for _, coin := range coins {
+		days := policy.Days(coin)
+		if days <= 0 {
+			continue
+		}
+		n, err := table.Delete(coin, now.AddDate(0, 0, -days), ctx)
+		if err != nil {
+			return deleted, err
+		}
+		deleted[coin] = n
+		pruned.WithLabelValues(table.Name, strconv.Itoa(int(coin))).Add(float64(n))
+	}
+	return deleted, nil
+}
+
+// RunPruner prunes the tables at once, then every interval, and updates the store metrics
+func RunPruner(tables []Table, sizer Sizer, policy Policy, interval time.Duration, ctx context.Context) {
+	run := func(now time.Time) {
+		names := make([]string, 0, len(tables))
+		for _, table := range tables {
+			names = append(names, table.Name)
+			deleted, err := Prune(table, policy, now, ctx)
+			if err != nil {
+				logger.Error(err, "Failed to prune", logger.Params{"table": table.Name})
+			}
+			for coin, n := range deleted {
+				if n > 0 {
+					logger.Info("Pruned", logger.Params{"table": table.Name, "coin": coin, "rows": n, "days": policy.Days(coin)})
+				}
+			}
+		}
+		updateSizes(sizer, names, ctx)
+	}
+	run(time.Now())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Retention pruner stopped")
+			return
+		case now := <-ticker.C:
+			run(now)
+		}
+	}
+}
+
+func updateSizes(sizer Sizer, tables []string, ctx context.Context) {
+	sizes, err := sizer.TableSizes(tables, ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get the table sizes")
+		return
+	}
+	for _, s := range sizes {
+		storeRows.WithLabelValues(s.Table).Set(float64(s.Rows))
+		storeBytes.WithLabelValues(s.Table).Set(float64(s.Bytes))
+	}
+}
@@ -0,0 +1,33 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Days(t *testing.T) {
+	p := Policy{Default: 90, Coins: map[uint]int{60: 30, 714: 0}}
+	assert.Equal(t, 30, p.Days(60))
+	assert.Equal(t, 0, p.Days(714))
+	assert.Equal(t, 90, p.Days(0))
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	cutoffs := make(map[uint]time.Time)
+	table := Table{
+		Name:  "token_transfers",
+		Coins: func(ctx context.Context) ([]uint, error) { return []uint{0, 60, 714}, nil },
+		Delete: func(coin uint, before time.Time, ctx context.Context) (int64, error) {
+			cutoffs[coin] = before
+			return int64(coin), nil
+		},
+	}
+	deleted, err := Prune(table, Policy{Default: 90, Coins: map[uint]int{60: 30, 714: 0}}, now, context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, map[uint]int64{0: 0, 60: 60}, deleted)
+	assert.Equal(t, map[uint]time.Time{0: now.AddDate(0, 0, -90), 60: now.AddDate(0, 0, -30)}, cutoffs)
+}
@@ -0,0 +1,105 @@
+package tokens
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/trustwallet/blockatlas/pkg/errors"
+)
+
+// embedded is the metadata of the top tokens of each coin, built into the binary
+//
+//go:embed tokens.json
+var embedded []byte
+
+// Token is the metadata of a token contract
+type Token struct {
+	Contract string `json:"contract"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint   `json:"decimals"`
+}
+
+// Tokens is the top-token metadata, the embedded dataset unless replaced with Load
+var Tokens = mustParse(embedded)
+
+type TokenSet struct {
+	sync.RWMutex
+	// tokens are by coin then lowercase contract, ranked keeps the dataset order
+	tokens map[uint]map[string]Token
+	ranked map[uint][]Token
+}
+
+func NewTokenSet() *TokenSet {
+	return &TokenSet{tokens: make(map[uint]map[string]Token), ranked: make(map[uint][]Token)}
+}
+
+// Get returns the token of the coin, contracts are matched case-insensitively
+func (s *TokenSet) Get(coin uint, contract string) (Token, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	token, ok := s.tokens[coin][strings.ToLower(contract)]
+	return token, ok
+}
+
+// List returns the tokens of the coin in the order of the dataset
+func (s *TokenSet) List(coin uint) []Token {
+	s.RLock()
+	defer s.RUnlock()
+	return append([]Token(nil), s.ranked[coin]...)
+}
+
+// Load replaces the tokens with the dataset of the file
+func (s *TokenSet) Load(path string) error {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.E(err, "failed to read tokens", errors.Params{"path": path})
+	}
+	loaded, err := parse(raw)
+	if err != nil {
+		return errors.E(err, errors.Params{"path": path})
+	}
+	loaded.RLock()
+	defer loaded.RUnlock()
+	s.Lock()
+	defer s.Unlock()
+	s.tokens, s.ranked = loaded.tokens, loaded.ranked
+	return nil
+}
+
+// parse reads a dataset of the form {"<coin id>": [{"contract", "name", "symbol", "decimals"}]}, by rank
+func parse(raw []byte) (*TokenSet, error) {
+	var dataset map[string][]Token
+	if err := json.Unmarshal(raw, &dataset); err != nil {
+		return nil, errors.E(err, "invalid tokens")
+	}
+	s := NewTokenSet()
+	for coinStr, tokens := range dataset {
+		coin, err := strconv.ParseUint(coinStr, 10, 32)
+		if err != nil {
+			return nil, errors.E(err, "invalid tokens coin", errors.Params{"coin": coinStr})
+		}
+		byContract := make(map[string]Token, len(tokens))
+		for _, t := range tokens {
+			if t.Contract == "" {
+				return nil, errors.E("token without contract", errors.Params{"coin": coinStr, "symbol": t.Symbol})
+			}
+			byContract[strings.ToLower(t.Contract)] = t
+		}
+		s.tokens[uint(coin)] = byContract
+		s.ranked[uint(coin)] = tokens
+	}
+	return s, nil
+}
+
+func mustParse(raw []byte) *TokenSet {
+	s, err := parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
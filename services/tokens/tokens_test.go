@@ -0,0 +1,44 @@
+package tokens
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokens_Embedded(t *testing.T) {
+	dai, ok := Tokens.Get(60, "0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	assert.True(t, ok)
+	assert.Equal(t, Token{Contract: "0x6b175474e89094c44da98b954eedeac495271d0f", Name: "Dai Stablecoin", Symbol: "DAI", Decimals: 18}, dai)
+
+	top := Tokens.List(60)
+	assert.NotEmpty(t, top)
+	assert.Equal(t, "USDT", top[0].Symbol)
+
+	_, ok = Tokens.Get(60, "0x0000000000000000000000000000000000000001")
+	assert.False(t, ok)
+}
+
+func TestTokenSet_Load(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokens")
+	assert.Nil(t, err)
+	path := filepath.Join(dir, "tokens.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"61": [{"contract": "0xABC", "name": "Token", "symbol": "TKN", "decimals": 8}]}`), 0600))
+
+	s := mustParse(embedded)
+	assert.Nil(t, s.Load(path))
+	token, ok := s.Get(61, "0xabc")
+	assert.True(t, ok)
+	assert.Equal(t, "TKN", token.Symbol)
+	assert.Empty(t, s.List(60))
+
+	assert.NotNil(t, s.Load(filepath.Join(dir, "missing.json")))
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"eth": []}`), 0600))
+	assert.NotNil(t, s.Load(path))
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"60": [{"symbol": "TKN"}]}`), 0600))
+	assert.NotNil(t, s.Load(path))
+	// The failed loads keep the tokens
+	assert.Len(t, s.List(61), 1)
+}
@@ -0,0 +1,8 @@
+// +build tools
+
+package blockatlas
+
+// The build tools run with go run, pinned to the versions of go.mod
+import (
+	_ "github.com/swaggo/swag/cmd/swag"
+)